@@ -83,6 +83,7 @@ func (w *withCompression) ReadFile(ctx context.Context, name string) ([]byte, er
 
 type compressReader struct {
 	io.ReadCloser
+	fileReader ExternalFileReader
 }
 
 // nolint:interfacer
@@ -96,9 +97,19 @@ func newInterceptReader(fileReader ExternalFileReader, compressType CompressType
 	}
 	return &compressReader{
 		ReadCloser: r,
+		fileReader: fileReader,
 	}, nil
 }
 
+// Close closes both the decompressing reader and the underlying file.
+func (r *compressReader) Close() error {
+	err := r.ReadCloser.Close()
+	if err1 := r.fileReader.Close(); err == nil {
+		err = err1
+	}
+	return errors.Trace(err)
+}
+
 func (r *compressReader) Seek(_ int64, _ int) (int64, error) {
 	return int64(0), errors.Annotatef(berrors.ErrStorageInvalidConfig, "compressReader doesn't support Seek now")
 }
@@ -35,6 +35,27 @@ func ParseRawURL(rawURL string) (*url.URL, error) {
 	return u, nil
 }
 
+// IsURL checks whether the raw URL starts with a scheme followed by "://", e.g. "s3://bucket/prefix".
+// A local path is not a URL even if it contains the characters which are special in URLs, e.g. ':' or '%'.
+func IsURL(rawURL string) bool {
+	idx := strings.Index(rawURL, "://")
+	if idx <= 0 {
+		return false
+	}
+	for i, c := range rawURL[:idx] {
+		switch {
+		case 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z':
+		case '0' <= c && c <= '9' || c == '+' || c == '-' || c == '.':
+			if i == 0 {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
 // ParseBackend constructs a structured backend description from the
 // storage URL.
 func ParseBackend(rawURL string, options *BackendOptions) (*backuppb.StorageBackend, error) {
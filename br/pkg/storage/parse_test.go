@@ -146,6 +146,17 @@ func TestCreateStorage(t *testing.T) {
 	require.Equal(t, expectedLocalPath, local.GetPath())
 }
 
+func TestIsURL(t *testing.T) {
+	require.True(t, IsURL("s3://bucket/prefix"))
+	require.True(t, IsURL("local:///tmp/storage"))
+	require.True(t, IsURL("noop://"))
+	require.False(t, IsURL("/tmp/storage"))
+	require.False(t, IsURL("/tmp/a:b%c#d?e"))
+	require.False(t, IsURL("/tmp/a://b"))
+	require.False(t, IsURL("1invalid://"))
+	require.False(t, IsURL("://"))
+}
+
 func TestFormatBackendURL(t *testing.T) {
 	backendURL := FormatBackendURL(&backuppb.StorageBackend{
 		Backend: &backuppb.StorageBackend_Local{
@@ -6,6 +6,7 @@ import (
 	"context"
 	"io"
 
+	"github.com/klauspost/compress/zstd"
 	"github.com/pingcap/errors"
 )
 
@@ -17,6 +18,8 @@ const (
 	NoCompression CompressType = iota
 	// Gzip will compress given bytes in gzip format.
 	Gzip
+	// Zstd will compress given bytes in zstd format.
+	Zstd
 )
 
 type flusher interface {
@@ -50,6 +53,10 @@ func newCompressWriter(compressType CompressType, w io.Writer) simpleCompressWri
 	switch compressType {
 	case Gzip:
 		return gzip.NewWriter(w)
+	case Zstd:
+		// zstd.NewWriter only fails with invalid options.
+		newWriter, _ := zstd.NewWriter(w)
+		return newWriter
 	default:
 		return nil
 	}
@@ -59,6 +66,12 @@ func newCompressReader(compressType CompressType, r io.Reader) (io.ReadCloser, e
 	switch compressType {
 	case Gzip:
 		return gzip.NewReader(r)
+	case Zstd:
+		newReader, err := zstd.NewReader(r)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return newReader.IOReadCloser(), nil
 	default:
 		return nil, nil
 	}
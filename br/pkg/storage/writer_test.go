@@ -102,7 +102,7 @@ func TestCompressReaderWriter(t *testing.T) {
 		ctx := context.Background()
 		storage, err := Create(ctx, backend, true)
 		require.NoError(t, err)
-		storage = WithCompression(storage, Gzip)
+		storage = WithCompression(storage, test.compressType)
 		fileName := strings.ReplaceAll(test.name, " ", "-") + ".txt.gz"
 		writer, err := storage.Create(ctx, fileName)
 		require.NoError(t, err)
@@ -135,7 +135,7 @@ func TestCompressReaderWriter(t *testing.T) {
 
 		require.Nil(t, file.Close())
 	}
-	compressTypeArr := []CompressType{Gzip}
+	compressTypeArr := []CompressType{Gzip, Zstd}
 	tests := []testcase{
 		{
 			name: "long text medium chunks",
@@ -49,15 +49,17 @@ import (
 type backfillWorkerType byte
 
 const (
-	typeAddIndexWorker     backfillWorkerType = 0
-	typeUpdateColumnWorker backfillWorkerType = 1
-	typeCleanUpIndexWorker backfillWorkerType = 2
+	typeAddIndexWorker       backfillWorkerType = 0
+	typeUpdateColumnWorker   backfillWorkerType = 1
+	typeCleanUpIndexWorker   backfillWorkerType = 2
+	typeReorgPartitionWorker backfillWorkerType = 3
 )
 
 // By now the DDL jobs that need backfilling include:
 // 1: add-index
 // 2: modify-column-type
 // 3: clean-up global index
+// 4: reorganize partition
 //
 // They all have a write reorganization state to back fill data into the rows existed.
 // Backfilling is time consuming, to accelerate this process, TiDB has built some sub
@@ -114,6 +116,8 @@ func (bWT backfillWorkerType) String() string {
 		return "update column"
 	case typeCleanUpIndexWorker:
 		return "clean up index"
+	case typeReorgPartitionWorker:
+		return "reorg partition"
 	default:
 		return "unknown"
 	}
@@ -652,6 +656,14 @@ func (w *worker) writePhysicalTableRecord(t table.PhysicalTable, bfWorkerType ba
 				idxWorker.priority = job.Priority
 				backfillWorkers = append(backfillWorkers, idxWorker.backfillWorker)
 				go idxWorker.backfillWorker.run(reorgInfo.d, idxWorker, job)
+			case typeReorgPartitionWorker:
+				partWorker, err := newReorgPartitionWorker(sessCtx, w, i, t)
+				if err != nil {
+					return errors.Trace(err)
+				}
+				partWorker.priority = job.Priority
+				backfillWorkers = append(backfillWorkers, partWorker.backfillWorker)
+				go partWorker.backfillWorker.run(reorgInfo.d, partWorker, job)
 			default:
 				return errors.New("unknow backfill type")
 			}
@@ -113,6 +113,65 @@ func adjustColumnInfoInDropColumn(tblInfo *model.TableInfo, offset int) {
 	tblInfo.Columns = newCols
 }
 
+// moveColumnInfo moves the column from one offset to another and updates the offsets of the affected columns and
+// index columns. In a multi-schema change, the adding or dropping column isn't always the last column.
+func moveColumnInfo(tblInfo *model.TableInfo, from, to int) {
+	if from == to {
+		return
+	}
+	offsetChanged := make(map[int]int)
+	src := tblInfo.Columns[from]
+	if from < to {
+		for i := from; i < to; i++ {
+			tblInfo.Columns[i] = tblInfo.Columns[i+1]
+			tblInfo.Columns[i].Offset = i
+			offsetChanged[i+1] = i
+		}
+	} else {
+		for i := from; i > to; i-- {
+			tblInfo.Columns[i] = tblInfo.Columns[i-1]
+			tblInfo.Columns[i].Offset = i
+			offsetChanged[i-1] = i
+		}
+	}
+	tblInfo.Columns[to] = src
+	src.Offset = to
+	offsetChanged[from] = to
+	for _, idx := range tblInfo.Indices {
+		for _, col := range idx.Columns {
+			if newOffset, ok := offsetChanged[col.Offset]; ok {
+				col.Offset = newOffset
+			}
+		}
+	}
+}
+
+// locateOffsetToMove returns the offset the added column should be moved to when it becomes public.
+func locateOffsetToMove(tblInfo *model.TableInfo, pos *ast.ColumnPosition) (int, error) {
+	if pos == nil {
+		pos = &ast.ColumnPosition{}
+	}
+	switch pos.Tp {
+	case ast.ColumnPositionFirst:
+		return 0, nil
+	case ast.ColumnPositionAfter:
+		c := model.FindColumnInfo(tblInfo.Columns, pos.RelativeColumn.Name.L)
+		if c == nil || c.State != model.StatePublic {
+			return 0, infoschema.ErrColumnNotExists.GenWithStackByArgs(pos.RelativeColumn, tblInfo.Name)
+		}
+		return c.Offset + 1, nil
+	default:
+		// The non-public columns are always behind the public ones.
+		offset := 0
+		for _, c := range tblInfo.Columns {
+			if c.State == model.StatePublic {
+				offset++
+			}
+		}
+		return offset, nil
+	}
+}
+
 func createColumnInfo(tblInfo *model.TableInfo, colInfo *model.ColumnInfo, pos *ast.ColumnPosition) (*model.ColumnInfo, *ast.ColumnPosition, int, error) {
 	// Check column name duplicate.
 	cols := tblInfo.Columns
@@ -236,10 +295,19 @@ func onAddColumn(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error)
 		}
 		// Update the job state when all affairs done.
 		job.SchemaState = model.StateWriteReorganization
+		job.MarkNonRevertible()
 	case model.StateWriteReorganization:
 		// reorganization -> public
 		// Adjust table column offset.
-		adjustColumnInfoInAddColumn(tblInfo, offset)
+		if job.MultiSchemaInfo != nil {
+			offset, err = locateOffsetToMove(tblInfo, pos)
+			if err != nil {
+				return ver, errors.Trace(err)
+			}
+			moveColumnInfo(tblInfo, columnInfo.Offset, offset)
+		} else {
+			adjustColumnInfoInAddColumn(tblInfo, offset)
+		}
 		columnInfo.State = model.StatePublic
 		ver, err = updateVersionAndTableInfo(t, job, tblInfo, originalState != columnInfo.State)
 		if err != nil {
@@ -545,6 +613,8 @@ func checkDropColumns(t *meta.Meta, job *model.Job) (*model.TableInfo, []*model.
 }
 
 func checkDropColumnForStatePublic(tblInfo *model.TableInfo, colInfo *model.ColumnInfo) (err error) {
+	// The check constraints which only refer to the dropping column are dropped with it.
+	dropCheckConstraintsOnColumn(tblInfo, colInfo.Name)
 	// Set this column's offset to the last and reset all following columns' offsets.
 	adjustColumnInfoInDropColumn(tblInfo, colInfo.Offset)
 	// When the dropping column has not-null flag and it hasn't the default value, we can backfill the column value like "add column".
@@ -571,6 +641,12 @@ func onDropColumn(t *meta.Meta, job *model.Job) (ver int64, _ error) {
 	if err != nil {
 		return ver, errors.Trace(err)
 	}
+	if job.MultiSchemaInfo != nil && job.MultiSchemaInfo.Revertible && !job.IsRollingback() {
+		// The column is still public, wait for the other sub-jobs of the multi-schema change.
+		job.MarkNonRevertible()
+		job.SchemaState = colInfo.State
+		return ver, nil
+	}
 
 	originalState := colInfo.State
 	switch colInfo.State {
@@ -616,6 +692,7 @@ func onDropColumn(t *meta.Meta, job *model.Job) (ver int64, _ error) {
 	case model.StateDeleteReorganization:
 		// reorganization -> absent
 		// All reorganization jobs are done, drop this column.
+		moveColumnInfo(tblInfo, colInfo.Offset, len(tblInfo.Columns)-1)
 		tblInfo.Columns = tblInfo.Columns[:len(tblInfo.Columns)-1]
 		colInfo.State = model.StateNone
 		ver, err = updateVersionAndTableInfo(t, job, tblInfo, originalState != colInfo.State)
@@ -682,6 +759,10 @@ func onSetDefaultValue(t *meta.Meta, job *model.Job) (ver int64, _ error) {
 		job.State = model.JobStateCancelled
 		return ver, errors.Trace(err)
 	}
+	if job.MultiSchemaInfo != nil && job.MultiSchemaInfo.Revertible {
+		job.MarkNonRevertible()
+		return ver, nil
+	}
 
 	return updateColumnDefaultValue(t, job, newCol, &newCol.Name)
 }
@@ -1499,6 +1580,12 @@ func (w *worker) doModifyColumn(
 		}
 	}
 
+	if job.MultiSchemaInfo != nil && job.MultiSchemaInfo.Revertible {
+		job.MarkNonRevertible()
+		// Store the mark and wait for the other sub-jobs of the multi-schema change.
+		return updateVersionAndTableInfoWithCheck(t, job, tblInfo, false)
+	}
+
 	if err := adjustColumnInfoInModifyColumn(job, tblInfo, newCol, oldCol, pos, ""); err != nil {
 		return ver, errors.Trace(err)
 	}
@@ -0,0 +1,442 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/meta"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/format"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/util/dbterror"
+	"github.com/pingcap/tidb/util/sqlexec"
+)
+
+func onAddCheckConstraint(w *worker, t *meta.Meta, job *model.Job) (ver int64, err error) {
+	dbInfo, tblInfo, constraintInfo, err := checkAddCheckConstraint(t, job)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+
+	originalState := constraintInfo.State
+	switch constraintInfo.State {
+	case model.StateNone:
+		// none -> write only
+		// The new rows are checked by the constraint from the write only state, so the existing rows
+		// are verified after all the TiDB servers have known the constraint.
+		job.SchemaState = model.StateWriteOnly
+		constraintInfo.State = model.StateWriteOnly
+		ver, err = updateVersionAndTableInfoWithCheck(t, job, tblInfo, originalState != constraintInfo.State)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+	case model.StateWriteOnly:
+		// write only -> public
+		if constraintInfo.Enforced {
+			err = w.verifyRemainRecordsForCheckConstraint(dbInfo.Name, tblInfo, constraintInfo)
+			if err != nil {
+				if !table.ErrCheckConstraintViolated.Equal(err) {
+					return ver, errors.Trace(err)
+				}
+				dropCheckConstraintInfo(tblInfo, constraintInfo.Name)
+				ver, err1 := updateVersionAndTableInfo(t, job, tblInfo, true)
+				if err1 != nil {
+					return ver, errors.Trace(err1)
+				}
+				job.FinishTableJob(model.JobStateRollbackDone, model.StateNone, ver, tblInfo)
+				return ver, errors.Trace(err)
+			}
+		}
+		constraintInfo.State = model.StatePublic
+		ver, err = updateVersionAndTableInfo(t, job, tblInfo, originalState != constraintInfo.State)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		// Finish this job.
+		job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	default:
+		err = dbterror.ErrInvalidDDLState.GenWithStackByArgs("constraint", constraintInfo.State)
+	}
+	return ver, errors.Trace(err)
+}
+
+func checkAddCheckConstraint(t *meta.Meta, job *model.Job) (*model.DBInfo, *model.TableInfo, *model.ConstraintInfo, error) {
+	schemaID := job.SchemaID
+	dbInfo, err := checkSchemaExistAndCancelNotExistJob(t, job)
+	if err != nil {
+		return nil, nil, nil, errors.Trace(err)
+	}
+	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, schemaID)
+	if err != nil {
+		return nil, nil, nil, errors.Trace(err)
+	}
+	constraintInfo := &model.ConstraintInfo{}
+	err = job.DecodeArgs(constraintInfo)
+	if err != nil {
+		job.State = model.JobStateCancelled
+		return nil, nil, nil, errors.Trace(err)
+	}
+	// The constraint has been added by the previous step.
+	if existing := tblInfo.FindConstraintInfoByName(constraintInfo.Name.L); existing != nil {
+		if existing.State == model.StatePublic {
+			job.State = model.JobStateCancelled
+			return nil, nil, nil, dbterror.ErrCheckConstraintDupName.GenWithStackByArgs(constraintInfo.Name.O)
+		}
+		return dbInfo, tblInfo, existing, nil
+	}
+	// The columns may be changed after the job is submitted.
+	for _, colName := range constraintInfo.ConstraintCols {
+		col := model.FindColumnInfo(tblInfo.Cols(), colName.L)
+		if col == nil {
+			job.State = model.JobStateCancelled
+			return nil, nil, nil, dbterror.ErrCheckConstraintRefersUnknownColumn.GenWithStackByArgs(constraintInfo.Name.O, colName.O)
+		}
+	}
+	constraintInfo.ID = allocateConstraintID(tblInfo)
+	constraintInfo.State = model.StateNone
+	tblInfo.Constraints = append(tblInfo.Constraints, constraintInfo)
+	return dbInfo, tblInfo, constraintInfo, nil
+}
+
+func rollingbackAddCheckConstraint(t *meta.Meta, job *model.Job) (ver int64, err error) {
+	_, tblInfo, constraintInfo, err := checkAddCheckConstraint(t, job)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	if constraintInfo.State == model.StateNone {
+		job.State = model.JobStateCancelled
+		return ver, dbterror.ErrCancelledDDLJob
+	}
+	// It's safe to drop the constraint directly, since it's only used to refuse the writing rows.
+	dropCheckConstraintInfo(tblInfo, constraintInfo.Name)
+	ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateRollbackDone, model.StateNone, ver, tblInfo)
+	return ver, dbterror.ErrCancelledDDLJob
+}
+
+func onDropCheckConstraint(t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	tblInfo, constraintInfo, err := checkDropCheckConstraint(t, job)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+
+	originalState := constraintInfo.State
+	switch constraintInfo.State {
+	case model.StatePublic:
+		// The constraint is only used to refuse the writing rows, so we make it none directly.
+		// public -> none
+		dropCheckConstraintInfo(tblInfo, constraintInfo.Name)
+		ver, err = updateVersionAndTableInfo(t, job, tblInfo, originalState != model.StateNone)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		// Finish this job.
+		job.FinishTableJob(model.JobStateDone, model.StateNone, ver, tblInfo)
+		return ver, nil
+	default:
+		return ver, dbterror.ErrInvalidDDLState.GenWithStackByArgs("constraint", constraintInfo.State)
+	}
+}
+
+func checkDropCheckConstraint(t *meta.Meta, job *model.Job) (*model.TableInfo, *model.ConstraintInfo, error) {
+	schemaID := job.SchemaID
+	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, schemaID)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+
+	var constrName model.CIStr
+	err = job.DecodeArgs(&constrName)
+	if err != nil {
+		job.State = model.JobStateCancelled
+		return nil, nil, errors.Trace(err)
+	}
+
+	constraintInfo := tblInfo.FindConstraintInfoByName(constrName.L)
+	if constraintInfo == nil {
+		job.State = model.JobStateCancelled
+		return nil, nil, dbterror.ErrCheckConstraintNotFound.GenWithStackByArgs(constrName.O)
+	}
+	return tblInfo, constraintInfo, nil
+}
+
+func onAlterCheckConstraint(w *worker, t *meta.Meta, job *model.Job) (ver int64, err error) {
+	dbInfo, err := checkSchemaExistAndCancelNotExistJob(t, job)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+
+	var (
+		constrName model.CIStr
+		enforced   bool
+	)
+	err = job.DecodeArgs(&constrName, &enforced)
+	if err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	constraintInfo := tblInfo.FindConstraintInfoByName(constrName.L)
+	if constraintInfo == nil {
+		job.State = model.JobStateCancelled
+		return ver, dbterror.ErrCheckConstraintNotFound.GenWithStackByArgs(constrName.O)
+	}
+
+	originalState := constraintInfo.State
+	switch constraintInfo.State {
+	case model.StatePublic:
+		if !enforced || constraintInfo.Enforced {
+			// public -> public
+			constraintInfo.Enforced = enforced
+			ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
+			if err != nil {
+				return ver, errors.Trace(err)
+			}
+			job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+			return ver, nil
+		}
+		// Like adding the constraint, the existing rows are verified after the new rows are checked.
+		// public -> write only
+		job.SchemaState = model.StateWriteOnly
+		constraintInfo.Enforced = true
+		constraintInfo.State = model.StateWriteOnly
+		ver, err = updateVersionAndTableInfo(t, job, tblInfo, originalState != constraintInfo.State)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+	case model.StateWriteOnly:
+		// write only -> public
+		err = w.verifyRemainRecordsForCheckConstraint(dbInfo.Name, tblInfo, constraintInfo)
+		constraintInfo.State = model.StatePublic
+		if err != nil {
+			if !table.ErrCheckConstraintViolated.Equal(err) {
+				return ver, errors.Trace(err)
+			}
+			constraintInfo.Enforced = false
+			ver, err1 := updateVersionAndTableInfo(t, job, tblInfo, true)
+			if err1 != nil {
+				return ver, errors.Trace(err1)
+			}
+			job.FinishTableJob(model.JobStateRollbackDone, model.StatePublic, ver, tblInfo)
+			return ver, errors.Trace(err)
+		}
+		ver, err = updateVersionAndTableInfo(t, job, tblInfo, originalState != constraintInfo.State)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	default:
+		err = dbterror.ErrInvalidDDLState.GenWithStackByArgs("constraint", constraintInfo.State)
+	}
+	return ver, errors.Trace(err)
+}
+
+// verifyRemainRecordsForCheckConstraint checks whether the existing rows satisfy the check constraint.
+func (w *worker) verifyRemainRecordsForCheckConstraint(dbName model.CIStr, tblInfo *model.TableInfo, constraintInfo *model.ConstraintInfo) error {
+	var sctx sessionctx.Context
+	sctx, err := w.sessPool.get()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer w.sessPool.put(sctx)
+
+	// The constraint expression may contain the identifiers, which couldn't be escaped in ParseWithParams(...),
+	// so we write it to the SQL string directly and escape the '%' in it.
+	var buf strings.Builder
+	buf.WriteString("select 1 from %n.%n where not (")
+	buf.WriteString(strings.ReplaceAll(constraintInfo.ExprString, "%", "%%"))
+	buf.WriteString(") limit 1")
+	rows, _, err := sctx.(sqlexec.RestrictedSQLExecutor).ExecRestrictedSQL(w.ddlJobCtx, nil, buf.String(), dbName.L, tblInfo.Name.L)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(rows) != 0 {
+		return table.ErrCheckConstraintViolated.GenWithStackByArgs(constraintInfo.Name.O)
+	}
+	return nil
+}
+
+func allocateConstraintID(tblInfo *model.TableInfo) int64 {
+	tblInfo.MaxConstraintID++
+	return tblInfo.MaxConstraintID
+}
+
+func dropCheckConstraintInfo(tblInfo *model.TableInfo, constrName model.CIStr) {
+	constraints := tblInfo.Constraints[:0]
+	for _, constraintInfo := range tblInfo.Constraints {
+		if constraintInfo.Name.L != constrName.L {
+			constraints = append(constraints, constraintInfo)
+		}
+	}
+	tblInfo.Constraints = constraints
+}
+
+// dropCheckConstraintsOnColumn drops the check constraints which only refer to the dropping column.
+func dropCheckConstraintsOnColumn(tblInfo *model.TableInfo, colName model.CIStr) {
+	constraints := tblInfo.Constraints[:0]
+	for _, constraintInfo := range tblInfo.Constraints {
+		if len(constraintInfo.ConstraintCols) == 1 && constraintInfo.ConstraintCols[0].L == colName.L {
+			continue
+		}
+		constraints = append(constraints, constraintInfo)
+	}
+	tblInfo.Constraints = constraints
+}
+
+// checkColumnDroppableForCheckConstraint checks whether the column can be dropped or renamed.
+// The column can't be renamed if any check constraint refers to it, and it can't be dropped
+// if the check constraint also refers to the other columns.
+func checkColumnDroppableForCheckConstraint(tblInfo *model.TableInfo, colName model.CIStr, isRename bool) error {
+	for _, constraintInfo := range tblInfo.Constraints {
+		for _, col := range constraintInfo.ConstraintCols {
+			if col.L != colName.L {
+				continue
+			}
+			if isRename || len(constraintInfo.ConstraintCols) > 1 {
+				return dbterror.ErrDependentByCheckConstraint.GenWithStackByArgs(constraintInfo.Name.O, colName.O)
+			}
+		}
+	}
+	return nil
+}
+
+// buildCheckConstraintInfos builds the check constraints of the new table, the constraints without
+// names are named after the table.
+func buildCheckConstraintInfos(tblInfo *model.TableInfo, constrs []*ast.Constraint) error {
+	names := make(map[string]struct{}, len(constrs))
+	for _, constr := range constrs {
+		if constr.Name == "" {
+			continue
+		}
+		name := strings.ToLower(constr.Name)
+		if _, ok := names[name]; ok {
+			return dbterror.ErrCheckConstraintDupName.GenWithStackByArgs(constr.Name)
+		}
+		names[name] = struct{}{}
+	}
+	for _, constr := range constrs {
+		name := model.NewCIStr(constr.Name)
+		if constr.Name == "" {
+			name = genCheckConstraintName(tblInfo, names)
+			names[name.L] = struct{}{}
+		}
+		constraintInfo, err := buildCheckConstraintInfo(tblInfo, name, constr)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		constraintInfo.ID = allocateConstraintID(tblInfo)
+		constraintInfo.State = model.StatePublic
+		tblInfo.Constraints = append(tblInfo.Constraints, constraintInfo)
+	}
+	return nil
+}
+
+// genCheckConstraintName generates the constraint name like MySQL, e.g. `t_chk_1`.
+func genCheckConstraintName(tblInfo *model.TableInfo, usedNames map[string]struct{}) model.CIStr {
+	for i := 1; ; i++ {
+		name := model.NewCIStr(fmt.Sprintf("%s_chk_%d", tblInfo.Name.O, i))
+		if _, ok := usedNames[name.L]; ok {
+			continue
+		}
+		if tblInfo.FindConstraintInfoByName(name.L) != nil {
+			continue
+		}
+		return name
+	}
+}
+
+// buildCheckConstraintInfo builds the check constraint and validates its expression.
+// The returned constraint has no ID and state, they are set by the caller.
+func buildCheckConstraintInfo(tblInfo *model.TableInfo, name model.CIStr, constr *ast.Constraint) (*model.ConstraintInfo, error) {
+	if err := checkIllegalFn4CheckConstraint(name.O, constr.Expr); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	cols := tblInfo.Cols()
+	constraintCols := make([]model.CIStr, 0, 2)
+	for _, colName := range findColumnNamesInExpr(constr.Expr) {
+		col := model.FindColumnInfo(cols, colName.Name.L)
+		if col == nil || col.Hidden {
+			return nil, dbterror.ErrCheckConstraintRefersUnknownColumn.GenWithStackByArgs(name.O, colName.Name.O)
+		}
+		if constr.InColumn && col.Name.L != strings.ToLower(constr.InColumnName) {
+			return nil, dbterror.ErrColumnCheckConstraintReferencesOtherColumn.GenWithStackByArgs(name.O)
+		}
+		if mysql.HasAutoIncrementFlag(col.Flag) {
+			return nil, dbterror.ErrCheckConstraintRefersAutoIncrementColumn.GenWithStackByArgs(name.O)
+		}
+		found := false
+		for _, c := range constraintCols {
+			if c.L == col.Name.L {
+				found = true
+				break
+			}
+		}
+		if !found {
+			constraintCols = append(constraintCols, col.Name)
+		}
+	}
+
+	var sb strings.Builder
+	restoreFlags := format.RestoreStringSingleQuotes | format.RestoreKeyWordLowercase | format.RestoreNameBackQuotes |
+		format.RestoreSpacesAroundBinaryOperation
+	restoreCtx := format.NewRestoreCtx(restoreFlags, &sb)
+	if err := constr.Expr.Restore(restoreCtx); err != nil {
+		return nil, errors.Trace(err)
+	}
+	constraintInfo := &model.ConstraintInfo{
+		Name:           name,
+		Table:          tblInfo.Name,
+		ConstraintCols: constraintCols,
+		Enforced:       constr.Enforced,
+		InColumn:       constr.InColumn,
+		ExprString:     sb.String(),
+	}
+	// Make sure the expression can be built when loading the table.
+	if _, err := table.ToConstraint(constraintInfo, tblInfo); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return constraintInfo, nil
+}
+
+func checkIllegalFn4CheckConstraint(name string, expr ast.ExprNode) error {
+	var c illegalFunctionChecker
+	expr.Accept(&c)
+	if c.hasVariable {
+		return dbterror.ErrCheckConstraintVariables.GenWithStackByArgs(name)
+	}
+	if len(c.illegalFuncName) > 0 {
+		return dbterror.ErrCheckConstraintNamedFunctionIsNotAllowed.GenWithStackByArgs(name, c.illegalFuncName)
+	}
+	if c.hasIllegalFunc || c.hasAggFunc || c.hasWindowFunc {
+		return dbterror.ErrCheckConstraintFunctionIsNotAllowed.GenWithStackByArgs(name)
+	}
+	if c.hasRowVal {
+		return dbterror.ErrCheckConstraintRowValue.GenWithStackByArgs(name)
+	}
+	return c.otherErr
+}
@@ -3679,30 +3679,38 @@ func (s *testDBSuite4) TestColumnCheck(c *C) {
 	tk.MustExec("drop table if exists column_check")
 	tk.MustExec("create table column_check (pk int primary key, a int check (a > 1))")
 	defer tk.MustExec("drop table if exists column_check")
-	c.Assert(tk.Se.GetSessionVars().StmtCtx.WarningCount(), Equals, uint16(1))
-	tk.MustQuery("show warnings").Check(testutil.RowsWithSep("|", "Warning|8231|CONSTRAINT CHECK is not supported"))
+	c.Assert(tk.Se.GetSessionVars().StmtCtx.WarningCount(), Equals, uint16(0))
+	tk.MustExec("insert into column_check values (1, 2), (2, null)")
+	tk.MustGetErrCode("insert into column_check values (3, 1)", errno.ErrCheckConstraintViolated)
+	tk.MustGetErrCode("create table column_check_1 (a int check (b > 1), b int)", errno.ErrColumnCheckConstraintReferencesOtherColumn)
 }
 
 func (s *testDBSuite5) TestAlterCheck(c *C) {
 	tk := testkit.NewTestKit(c, s.store)
 	tk.MustExec("use " + s.schemaName)
 	tk.MustExec("drop table if exists alter_check")
-	tk.MustExec("create table alter_check (pk int primary key)")
+	tk.MustExec("create table alter_check (pk int primary key, a int, constraint crcn check (a > 1) not enforced)")
 	defer tk.MustExec("drop table if exists alter_check")
-	tk.MustExec("alter table alter_check alter check crcn ENFORCED")
-	c.Assert(tk.Se.GetSessionVars().StmtCtx.WarningCount(), Equals, uint16(1))
-	tk.MustQuery("show warnings").Check(testutil.RowsWithSep("|", "Warning|8231|ALTER CHECK is not supported"))
+	tk.MustGetErrCode("alter table alter_check alter check crcn1 enforced", errno.ErrCheckConstraintNotFound)
+	tk.MustExec("insert into alter_check values (1, 1)")
+	tk.MustGetErrCode("alter table alter_check alter check crcn enforced", errno.ErrCheckConstraintViolated)
+	tk.MustExec("insert into alter_check values (2, 1)")
+	tk.MustExec("delete from alter_check")
+	tk.MustExec("alter table alter_check alter check crcn enforced")
+	tk.MustGetErrCode("insert into alter_check values (1, 1)", errno.ErrCheckConstraintViolated)
+	tk.MustExec("alter table alter_check alter check crcn not enforced")
+	tk.MustExec("insert into alter_check values (1, 1)")
 }
 
 func (s *testDBSuite6) TestDropCheck(c *C) {
 	tk := testkit.NewTestKit(c, s.store)
 	tk.MustExec("use " + s.schemaName)
 	tk.MustExec("drop table if exists drop_check")
-	tk.MustExec("create table drop_check (pk int primary key)")
+	tk.MustExec("create table drop_check (pk int primary key, a int, constraint crcn check (a > 1))")
 	defer tk.MustExec("drop table if exists drop_check")
+	tk.MustGetErrCode("alter table drop_check drop check crcn1", errno.ErrCheckConstraintNotFound)
 	tk.MustExec("alter table drop_check drop check crcn")
-	c.Assert(tk.Se.GetSessionVars().StmtCtx.WarningCount(), Equals, uint16(1))
-	tk.MustQuery("show warnings").Check(testutil.RowsWithSep("|", "Warning|8231|DROP CHECK is not supported"))
+	tk.MustExec("insert into drop_check values (1, 1)")
 }
 
 func (s *testDBSuite7) TestAddConstraintCheck(c *C) {
@@ -3711,21 +3719,33 @@ func (s *testDBSuite7) TestAddConstraintCheck(c *C) {
 	tk.MustExec("drop table if exists add_constraint_check")
 	tk.MustExec("create table add_constraint_check (pk int primary key, a int)")
 	defer tk.MustExec("drop table if exists add_constraint_check")
+	tk.MustExec("insert into add_constraint_check values (1, 1)")
+	tk.MustGetErrCode("alter table add_constraint_check add constraint crn check (a > 1)", errno.ErrCheckConstraintViolated)
+	tk.MustQuery("show create table add_constraint_check").Check(testutil.RowsWithSep("|", ""+
+		"add_constraint_check CREATE TABLE `add_constraint_check` (\n"+
+		"  `pk` int(11) NOT NULL,\n"+
+		"  `a` int(11) DEFAULT NULL,\n"+
+		"  PRIMARY KEY (`pk`) /*T![clustered_index] CLUSTERED */\n"+
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+	tk.MustExec("update add_constraint_check set a = 2")
 	tk.MustExec("alter table add_constraint_check add constraint crn check (a > 1)")
-	c.Assert(tk.Se.GetSessionVars().StmtCtx.WarningCount(), Equals, uint16(1))
-	tk.MustQuery("show warnings").Check(testutil.RowsWithSep("|", "Warning|8231|ADD CONSTRAINT CHECK is not supported"))
+	c.Assert(tk.Se.GetSessionVars().StmtCtx.WarningCount(), Equals, uint16(0))
+	tk.MustGetErrCode("alter table add_constraint_check add constraint crn check (a > 0)", errno.ErrCheckConstraintDupName)
+	tk.MustGetErrCode("insert into add_constraint_check values (2, 1)", errno.ErrCheckConstraintViolated)
+	tk.MustGetErrCode("update add_constraint_check set a = 0", errno.ErrCheckConstraintViolated)
 }
 
-func (s *testDBSuite7) TestCreateTableIngoreCheckConstraint(c *C) {
+func (s *testDBSuite7) TestCreateTableCheckConstraint(c *C) {
 	tk := testkit.NewTestKit(c, s.store)
 	tk.MustExec("use " + s.schemaName)
-	tk.MustExec("drop table if exists table_constraint_check")
+	tk.MustExec("drop table if exists admin_user")
 	tk.MustExec("CREATE TABLE admin_user (enable bool, CHECK (enable IN (0, 1)));")
-	c.Assert(tk.Se.GetSessionVars().StmtCtx.WarningCount(), Equals, uint16(1))
-	tk.MustQuery("show warnings").Check(testutil.RowsWithSep("|", "Warning|8231|CONSTRAINT CHECK is not supported"))
+	defer tk.MustExec("drop table if exists admin_user")
+	c.Assert(tk.Se.GetSessionVars().StmtCtx.WarningCount(), Equals, uint16(0))
 	tk.MustQuery("show create table admin_user").Check(testutil.RowsWithSep("|", ""+
 		"admin_user CREATE TABLE `admin_user` (\n"+
-		"  `enable` tinyint(1) DEFAULT NULL\n"+
+		"  `enable` tinyint(1) DEFAULT NULL,\n"+
+		"  CONSTRAINT `admin_user_chk_1` CHECK ((`enable` in (0,1)))\n"+
 		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
 }
 
@@ -276,13 +276,12 @@ func TestCreateTableWithPartition(t *testing.T) {
 
 	tk.MustExec("set @@tidb_enable_table_partition = 1")
 	tk.MustExec("set @@tidb_enable_table_partition = 1")
-	tk.MustExec(`create table t30 (
+	tk.MustGetErrCode(`create table t30 (
 		  a int,
 		  b float,
 		  c varchar(30))
 		  partition by range columns (a, b)
-		  (partition p0 values less than (10, 10.0))`)
-	tk.MustQuery("show warnings").Check(testkit.Rows("Warning 8200 Unsupported partition type RANGE, treat as normal table"))
+		  (partition p0 values less than (10, 10.0))`, tmysql.ErrFieldTypeNotAllowedAsPartitionField)
 
 	tk.MustGetErrCode(`create table t31 (a int not null) partition by range( a );`, tmysql.ErrPartitionsMustBeDefined)
 	tk.MustGetErrCode(`create table t32 (a int not null) partition by range columns( a );`, tmysql.ErrPartitionsMustBeDefined)
@@ -390,7 +389,8 @@ func TestCreateTableWithHashPartition(t *testing.T) {
                                        PARTITION p0 VALUES LESS THAN (100),
                                        PARTITION p1 VALUES LESS THAN (200),
                                        PARTITION p2 VALUES LESS THAN MAXVALUE)`)
-	tk.MustGetErrCode("select * from t_sub partition (p0)", tmysql.ErrPartitionClauseOnNonpartitioned)
+	tk.MustQuery("select * from t_sub partition (p0)").Check(testkit.Rows())
+	tk.MustQuery("select * from t_sub partition (p0sp1)").Check(testkit.Rows())
 
 	// Fix create partition table using extract() function as partition key.
 	tk.MustExec("create table t2 (a date, b datetime) partition by hash (EXTRACT(YEAR_MONTH FROM a)) partitions 7")
@@ -398,6 +398,96 @@ func TestCreateTableWithHashPartition(t *testing.T) {
 	tk.MustExec("create table t4 (a int, b int) partition by hash(floor(a-b)) partitions 10")
 }
 
+func TestCreateTableWithSubPartition(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set @@session.tidb_enable_list_partition = ON")
+
+	tk.MustExec(`create table t (a int, b int) partition by range (a) subpartition by linear hash (b) subpartitions 2 (
+		partition p0 values less than (10))`)
+	tk.MustQuery("show warnings").Check(testkit.Rows("Warning 8200 Unsupported partition type RANGE, treat as normal table"))
+	tk.MustExec("drop table t")
+	tk.MustGetErrCode(`create table t (a int, b int, primary key (a)) partition by range (a) subpartition by hash (b) subpartitions 2 (
+		partition p0 values less than (10))`, tmysql.ErrUniqueKeyNeedAllFieldsInPf)
+	tk.MustGetErrCode(`create table t (a int, b int) partition by range (a) subpartition by key (b, b) subpartitions 2 (
+		partition p0 values less than (10))`, tmysql.ErrSameNamePartitionField)
+	tk.MustGetErrCode(`create table t (a int, b int) partition by range (a) subpartition by hash (b) (
+		partition p0 values less than (10) (subpartition s0, subpartition s1),
+		partition p1 values less than (20) (subpartition s2, subpartition s0))`, tmysql.ErrSameNamePartition)
+
+	tk.MustExec(`create table t (a int, b int, primary key (a, b)) partition by range (a) subpartition by hash (b) subpartitions 2 (
+		partition p0 values less than (10),
+		partition p1 values less than (20) comment 'p1',
+		partition p2 values less than maxvalue)`)
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `a` int(11) NOT NULL,\n" +
+		"  `b` int(11) NOT NULL,\n" +
+		"  PRIMARY KEY (`a`,`b`) /*T![clustered_index] NONCLUSTERED */\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin\n" +
+		"PARTITION BY RANGE (`a`)\n" +
+		"SUBPARTITION BY HASH (`b`) SUBPARTITIONS 2\n" +
+		"(PARTITION `p0` VALUES LESS THAN (10)\n" +
+		" (SUBPARTITION `p0sp0`,\n" +
+		"  SUBPARTITION `p0sp1`),\n" +
+		" PARTITION `p1` VALUES LESS THAN (20) COMMENT 'p1'\n" +
+		" (SUBPARTITION `p1sp0` COMMENT 'p1',\n" +
+		"  SUBPARTITION `p1sp1` COMMENT 'p1'),\n" +
+		" PARTITION `p2` VALUES LESS THAN (MAXVALUE)\n" +
+		" (SUBPARTITION `p2sp0`,\n" +
+		"  SUBPARTITION `p2sp1`))"))
+	tk.MustQuery("select partition_name, subpartition_name, partition_ordinal_position, subpartition_ordinal_position, subpartition_method, subpartition_expression " +
+		"from information_schema.partitions where table_schema = 'test' and table_name = 't'").Check(testkit.Rows(
+		"p0 p0sp0 1 1 HASH `b`", "p0 p0sp1 1 2 HASH `b`",
+		"p1 p1sp0 2 1 HASH `b`", "p1 p1sp1 2 2 HASH `b`",
+		"p2 p2sp0 3 1 HASH `b`", "p2 p2sp1 3 2 HASH `b`"))
+
+	tk.MustExec("insert into t values (1, 1), (2, 2), (11, 1), (12, 2), (21, 1), (22, 2)")
+	tk.MustQuery("select * from t partition (p0) order by a").Check(testkit.Rows("1 1", "2 2"))
+	tk.MustQuery("select * from t partition (p1sp0)").Check(testkit.Rows("12 2"))
+	tk.MustQuery("select * from t partition (p1sp1)").Check(testkit.Rows("11 1"))
+	tk.MustQuery("select * from t partition (p0, p2sp1) order by a").Check(testkit.Rows("1 1", "2 2", "21 1"))
+	tk.MustGetErrCode("insert into t partition (p0sp0) values (1, 3)", tmysql.ErrRowDoesNotMatchGivenPartitionSet)
+	tk.MustExec("insert into t partition (p0) values (1, 3)")
+	tk.MustExec("update t set a = a + 10 where b = 3")
+	tk.MustQuery("select * from t partition (p1sp1) order by a").Check(testkit.Rows("11 1", "11 3"))
+	tk.MustQuery("select * from t where a > 10 and b = 2 order by a").Check(testkit.Rows("12 2", "22 2"))
+
+	tk.MustExec("alter table t truncate partition p1sp1")
+	tk.MustQuery("select * from t order by a").Check(testkit.Rows("1 1", "2 2", "12 2", "21 1", "22 2"))
+	tk.MustExec("alter table t truncate partition p2")
+	tk.MustQuery("select * from t order by a").Check(testkit.Rows("1 1", "2 2", "12 2"))
+	tk.MustGetErrCode("alter table t drop partition p0sp0", tmysql.ErrDropPartitionNonExistent)
+	tk.MustExec("alter table t drop partition p0")
+	tk.MustQuery("select * from t order by a").Check(testkit.Rows("12 2"))
+	tk.MustQuery("select partition_name, subpartition_name from information_schema.partitions where table_schema = 'test' and table_name = 't'").Check(testkit.Rows(
+		"p1 p1sp0", "p1 p1sp1", "p2 p2sp0", "p2 p2sp1"))
+	tk.MustGetErrCode("alter table t add partition (partition p3 values less than (30))", tmysql.ErrUnsupportedDDLOperation)
+
+	tk.MustExec("drop table t")
+	tk.MustExec(`create table t (a int, b varchar(10)) partition by list (a) subpartition by key (b) (
+		partition p0 values in (1, 2) (subpartition s0, subpartition s1 comment 's1'),
+		partition p1 values in (3, 4) (subpartition s2, subpartition s3))`)
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `a` int(11) DEFAULT NULL,\n" +
+		"  `b` varchar(10) DEFAULT NULL\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin\n" +
+		"PARTITION BY LIST (`a`)\n" +
+		"SUBPARTITION BY KEY (`b`) SUBPARTITIONS 2\n" +
+		"(PARTITION `p0` VALUES IN (1,2)\n" +
+		" (SUBPARTITION `s0`,\n" +
+		"  SUBPARTITION `s1` COMMENT 's1'),\n" +
+		" PARTITION `p1` VALUES IN (3,4)\n" +
+		" (SUBPARTITION `s2`,\n" +
+		"  SUBPARTITION `s3`))"))
+	tk.MustExec("insert into t values (1, 'a'), (2, 'b'), (3, 'c'), (4, 'd')")
+	tk.MustGetErrCode("insert into t values (5, 'e')", tmysql.ErrNoPartitionForGivenValue)
+	tk.MustQuery("select * from t partition (p1) order by a").Check(testkit.Rows("3 c", "4 d"))
+	tk.MustQuery("select count(*) from t partition (s0, s1)").Check(testkit.Rows("2"))
+	tk.MustQuery("select * from t where a = 2 and b = 'b'").Check(testkit.Rows("2 b"))
+}
+
 func TestCreateTableWithRangeColumnPartition(t *testing.T) {
 	store, clean := testkit.CreateMockStore(t)
 	defer clean()
@@ -491,39 +581,36 @@ create table log_message_1 (
 			"create table t (id text) partition by range columns (id) (partition p0 values less than ('abc'));",
 			dbterror.ErrNotAllowedTypeInPartition,
 		},
-		// create as normal table, warning.
-		//	{
-		//		"create table t (a int, b varchar(64)) partition by range columns (a, b) (" +
-		//			"partition p0 values less than (1, 'a')," +
-		//			"partition p1 values less than (1, 'a'))",
-		//		dbterror.ErrRangeNotIncreasing,
-		//	},
+		{
+			"create table t (a int, b varchar(64)) partition by range columns (a, b) (" +
+				"partition p0 values less than (1, 'a')," +
+				"partition p1 values less than (1, 'a'))",
+			dbterror.ErrRangeNotIncreasing,
+		},
 		{
 			"create table t (a int, b varchar(64)) partition by range columns ( b) (" +
 				"partition p0 values less than ( 'a')," +
 				"partition p1 values less than ('a'))",
 			dbterror.ErrRangeNotIncreasing,
 		},
-		// create as normal table, warning.
-		//	{
-		//		"create table t (a int, b varchar(64)) partition by range columns (a, b) (" +
-		//			"partition p0 values less than (1, 'b')," +
-		//			"partition p1 values less than (1, 'a'))",
-		//		dbterror.ErrRangeNotIncreasing,
-		//	},
+		{
+			"create table t (a int, b varchar(64)) partition by range columns (a, b) (" +
+				"partition p0 values less than (1, 'b')," +
+				"partition p1 values less than (1, 'a'))",
+			dbterror.ErrRangeNotIncreasing,
+		},
 		{
 			"create table t (a int, b varchar(64)) partition by range columns (b) (" +
 				"partition p0 values less than ('b')," +
 				"partition p1 values less than ('a'))",
 			dbterror.ErrRangeNotIncreasing,
 		},
-		// create as normal table, warning.
-		//		{
-		//			"create table t (a int, b varchar(64)) partition by range columns (a, b) (" +
-		//				"partition p0 values less than (1, maxvalue)," +
-		//				"partition p1 values less than (1, 'a'))",
-		//			dbterror.ErrRangeNotIncreasing,
-		//		},
+		{
+			"create table t (a int, b varchar(64)) partition by range columns (a, b) (" +
+				"partition p0 values less than (1, maxvalue)," +
+				"partition p1 values less than (1, 'a'))",
+			dbterror.ErrRangeNotIncreasing,
+		},
 		{
 			"create table t (a int, b varchar(64)) partition by range columns ( b) (" +
 				"partition p0 values less than (  maxvalue)," +
@@ -1360,7 +1447,26 @@ func TestCreateTableWithKeyPartition(t *testing.T) {
 	partition by key(s1) partitions 10;`)
 
 	tk.MustExec(`drop table if exists tm2`)
-	tk.MustExec(`create table tm2 (a char(5), unique key(a(5))) partition by key() partitions 5;`)
+	tk.MustGetErrCode(`create table tm2 (a char(5), unique key(a(5))) partition by key() partitions 5;`, tmysql.ErrFieldNotFoundPart)
+	tk.MustExec(`create table tm2 (a char(5) not null, unique key(a)) partition by key() partitions 5;`)
+	tk.MustQuery("show create table tm2").Check(testkit.Rows("tm2 CREATE TABLE `tm2` (\n" +
+		"  `a` char(5) NOT NULL,\n" +
+		"  UNIQUE KEY `a` (`a`)\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin\n" +
+		"PARTITION BY KEY (`a`) PARTITIONS 5"))
+
+	tk.MustExec(`drop table if exists tm3`)
+	tk.MustGetErrCode(`create table tm3 (a int, b blob) partition by key(b) partitions 3`, tmysql.ErrBlobFieldInPartFunc)
+	tk.MustGetErrCode(`create table tm3 (a int, b json) partition by key(b) partitions 3`, tmysql.ErrFieldTypeNotAllowedAsPartitionField)
+	tk.MustGetErrCode(`create table tm3 (a int, b int) partition by key(c) partitions 3`, tmysql.ErrFieldNotFoundPart)
+	tk.MustGetErrCode(`create table tm3 (a int, b int, primary key (a)) partition by key(b) partitions 3`, tmysql.ErrUniqueKeyNeedAllFieldsInPf)
+	tk.MustExec(`create table tm3 (a int, b varchar(10), primary key (a, b)) partition by key(a, b) partitions 3`)
+	tk.MustQuery("show create table tm3").Check(testkit.Rows("tm3 CREATE TABLE `tm3` (\n" +
+		"  `a` int(11) NOT NULL,\n" +
+		"  `b` varchar(10) NOT NULL,\n" +
+		"  PRIMARY KEY (`a`,`b`) /*T![clustered_index] NONCLUSTERED */\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin\n" +
+		"PARTITION BY KEY (`a`,`b`) PARTITIONS 3"))
 }
 
 func TestAlterTableAddPartition(t *testing.T) {
@@ -2662,7 +2768,6 @@ func TestPartitionUniqueKeyNeedAllFieldsInPf(t *testing.T) {
         )`
 	tk.MustGetErrCode(sql10, tmysql.ErrUniqueKeyNeedAllFieldsInPf)
 
-	// after we support multiple columns partition, this sql should fail. For now, it will be a normal table.
 	sql11 := `create table part9 (
                  a int not null,
                  b int not null,
@@ -2677,7 +2782,7 @@ func TestPartitionUniqueKeyNeedAllFieldsInPf(t *testing.T) {
                partition p1 values less than (7, 9),
                partition p2 values less than (11, 22)
         )`
-	tk.MustExec(sql11)
+	tk.MustGetErrCode(sql11, tmysql.ErrUniqueKeyNeedAllFieldsInPf)
 
 	sql12 := `create table part12 (a varchar(20), b binary, unique index (a(5))) partition by range columns (a) (
 			partition p0 values less than ('aaaaa'),
@@ -3167,13 +3272,12 @@ func TestPartitionErrorCode(t *testing.T) {
 	_, err = tk.Exec("alter table t_part coalesce partition 4;")
 	require.True(t, dbterror.ErrCoalesceOnlyOnHashPartition.Equal(err))
 
-	tk.MustGetErrCode(`alter table t_part reorganize partition p0, p1 into (
+	tk.MustGetErrCode(`alter table clients reorganize partition p0, p1 into (
 			partition p0 values less than (1980));`, tmysql.ErrUnsupportedDDLOperation)
 
 	tk.MustGetErrCode("alter table t_part check partition p0, p1;", tmysql.ErrUnsupportedDDLOperation)
 	tk.MustGetErrCode("alter table t_part optimize partition p0,p1;", tmysql.ErrUnsupportedDDLOperation)
 	tk.MustGetErrCode("alter table t_part rebuild partition p0,p1;", tmysql.ErrUnsupportedDDLOperation)
-	tk.MustGetErrCode("alter table t_part remove partitioning;", tmysql.ErrUnsupportedDDLOperation)
 	tk.MustGetErrCode("alter table t_part repair partition p1;", tmysql.ErrUnsupportedDDLOperation)
 
 	// Reduce the impact on DML when executing partition DDL
@@ -3330,8 +3434,7 @@ func TestUnsupportedPartitionManagementDDLs(t *testing.T) {
 		);
 	`)
 
-	_, err := tk.Exec("alter table test_1465 partition by hash(a)")
-	require.Regexp(t, ".*alter table partition is unsupported", err.Error())
+	tk.MustGetErrCode("alter table test_1465 partition by linear key(a) partitions 2", tmysql.ErrUnsupportedDDLOperation)
 }
 
 func TestCommitWhenSchemaChange(t *testing.T) {
@@ -3708,3 +3811,377 @@ func TestDuplicatePartitionNames(t *testing.T) {
 		"(PARTITION `p2` VALUES IN (2),\n" +
 		" PARTITION `p3` VALUES IN (3))"))
 }
+
+func TestReorganizePartition(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec(`create table t (a int primary key, b varchar(10), key idx_b(b)) partition by range (a) (
+		partition p0 values less than (10),
+		partition p1 values less than (20),
+		partition pmax values less than (maxvalue))`)
+	tk.MustExec("insert into t values (1, 'a'), (11, 'b'), (21, 'c'), (31, 'd')")
+
+	// Split the MAXVALUE partition.
+	tk.MustExec("alter table t reorganize partition pmax into (partition p2 values less than (30), partition pmax values less than (maxvalue))")
+	tk.MustQuery("select * from t partition (p2)").Check(testkit.Rows("21 c"))
+	tk.MustQuery("select * from t partition (pmax)").Check(testkit.Rows("31 d"))
+	tk.MustQuery("select a from t use index(idx_b) where b = 'd'").Check(testkit.Rows("31"))
+	tk.MustExec("admin check table t")
+
+	// Merge the partitions.
+	tk.MustExec("alter table t reorganize partition p0, p1 into (partition p01 values less than (20))")
+	tk.MustQuery("select * from t partition (p01) order by a").Check(testkit.Rows("1 a", "11 b"))
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `a` int(11) NOT NULL,\n" +
+		"  `b` varchar(10) DEFAULT NULL,\n" +
+		"  PRIMARY KEY (`a`) /*T![clustered_index] CLUSTERED */,\n" +
+		"  KEY `idx_b` (`b`)\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin\n" +
+		"PARTITION BY RANGE (`a`)\n" +
+		"(PARTITION `p01` VALUES LESS THAN (20),\n" +
+		" PARTITION `p2` VALUES LESS THAN (30),\n" +
+		" PARTITION `pmax` VALUES LESS THAN (MAXVALUE))"))
+	tk.MustExec("admin check table t")
+	tk.MustExec("insert into t values (2, 'e'), (12, 'f')")
+	tk.MustQuery("select * from t partition (p01) order by a").Check(testkit.Rows("1 a", "2 e", "11 b", "12 f"))
+
+	tk.MustGetErrCode("alter table t reorganize partition", errno.ErrReorgNoParam)
+	tk.MustGetErrCode("alter table t reorganize partition p3 into (partition p3 values less than (40))", errno.ErrDropPartitionNonExistent)
+	tk.MustGetErrCode("alter table t reorganize partition p01, pmax into (partition p3 values less than (maxvalue))", errno.ErrConsecutiveReorgPartitions)
+	tk.MustGetErrCode("alter table t reorganize partition p01 into (partition p0 values less than (15))", errno.ErrReorgOutsideRange)
+	tk.MustGetErrCode("alter table t reorganize partition p2 into (partition p3 values less than (40))", errno.ErrReorgOutsideRange)
+	tk.MustGetErrCode("alter table t reorganize partition p2 into (partition p01 values less than (30))", errno.ErrSameNamePartition)
+	tk.MustGetErrCode("alter table t reorganize partition p01 into (partition p0 values less than (15), partition p1 values less than (12))", errno.ErrRangeNotIncreasing)
+
+	// The range of the last partition can be extended.
+	tk.MustExec("drop table t")
+	tk.MustExec(`create table t (a int, b int, unique key idx_a(a)) partition by range (a) (
+		partition p0 values less than (10),
+		partition p1 values less than (20))`)
+	tk.MustExec("insert into t values (1, 1), (11, 11), (19, 19)")
+	tk.MustExec("alter table t reorganize partition p1 into (partition p1 values less than (15), partition p2 values less than (30))")
+	tk.MustQuery("select a, _tidb_rowid from t partition (p2)").Check(testkit.Rows("19 3"))
+	tk.MustExec("insert into t values (25, 25)")
+	tk.MustGetErrCode("insert into t values (19, 0)", errno.ErrDupEntry)
+	tk.MustExec("admin check table t")
+
+	tk.MustExec("drop table t")
+	tk.MustExec("create table t (a int) partition by hash(a) partitions 4")
+	tk.MustGetErrCode("alter table t reorganize partition p0 into (partition p0 values less than (10))", errno.ErrUnsupportedDDLOperation)
+}
+
+func TestReorganizeListPartition(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set @@session.tidb_enable_list_partition = ON")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec(`create table t (a int, b int, key idx_b(b)) partition by list (a) (
+		partition p0 values in (1, 2),
+		partition p1 values in (3, 4),
+		partition p2 values in (5, 6))`)
+	tk.MustExec("insert into t values (1, 1), (2, 2), (3, 3), (4, 4), (5, 5)")
+
+	tk.MustExec("alter table t reorganize partition p0, p1 into (partition p0 values in (1, 3), partition p1 values in (2, 4, 7))")
+	tk.MustQuery("select a from t partition (p0) order by a").Check(testkit.Rows("1", "3"))
+	tk.MustQuery("select a from t partition (p1) order by a").Check(testkit.Rows("2", "4"))
+	tk.MustExec("insert into t values (7, 7)")
+	tk.MustQuery("select a from t partition (p1) order by a").Check(testkit.Rows("2", "4", "7"))
+	tk.MustExec("admin check table t")
+
+	tk.MustGetErrCode("alter table t reorganize partition p0 into (partition p0 values in (1))", errno.ErrReorgOutsideRange)
+	tk.MustGetErrCode("alter table t reorganize partition p0 into (partition p0 values in (1, 3, 5))", errno.ErrMultipleDefConstInListPart)
+}
+
+func TestReorganizePartitionWithConcurrentDML(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec(`create table t (a int, b int, key idx_b(b)) partition by range (a) (
+		partition p0 values less than (10),
+		partition pmax values less than (maxvalue))`)
+	tk.MustExec("insert into t values (1, 1), (11, 11), (21, 21), (31, 31), (41, 41)")
+
+	tk1 := testkit.NewTestKit(t, store)
+	tk1.MustExec("use test")
+	dom := domain.GetDomain(tk.Session())
+	originHook := dom.DDL().GetHook()
+	defer dom.DDL().SetHook(originHook)
+	hook := &ddl.TestDDLCallback{Do: dom}
+	var checkErr error
+	dmls := map[model.SchemaState][]string{
+		model.StateDeleteOnly:           {"insert into t values (12, 12)", "delete from t where a = 11"},
+		model.StateWriteOnly:            {"insert into t values (22, 22)", "update t set a = 32 where a = 31"},
+		model.StateWriteReorganization:  {"insert into t values (13, 13)", "update t set a = 23, b = 23 where a = 21"},
+		model.StateDeleteReorganization: {"insert into t values (42, 42)", "delete from t where a = 41"},
+	}
+	hook.OnJobRunBeforeExported = func(job *model.Job) {
+		if job.Type != model.ActionReorganizePartition || checkErr != nil {
+			return
+		}
+		stmts, ok := dmls[job.SchemaState]
+		if !ok {
+			return
+		}
+		delete(dmls, job.SchemaState)
+		for _, stmt := range stmts {
+			if _, checkErr = tk1.Exec(stmt); checkErr != nil {
+				return
+			}
+		}
+	}
+	dom.DDL().SetHook(hook)
+	tk.MustExec("alter table t reorganize partition pmax into (partition p1 values less than (20), partition p2 values less than (30), partition pmax values less than (maxvalue))")
+	require.NoError(t, checkErr)
+	require.Len(t, dmls, 0)
+
+	tk.MustQuery("select * from t partition (p1) order by a").Check(testkit.Rows("12 12", "13 13"))
+	tk.MustQuery("select * from t partition (p2) order by a").Check(testkit.Rows("22 22", "23 23"))
+	tk.MustQuery("select * from t partition (pmax) order by a").Check(testkit.Rows("32 31", "42 42"))
+	tk.MustQuery("select a from t use index(idx_b) where b > 10 order by a").Check(testkit.Rows("12", "13", "22", "23", "32", "42"))
+	tk.MustExec("admin check table t")
+}
+
+func TestCancelReorganizePartition(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec(`create table t (a int primary key, b int) partition by range (a) (
+		partition p0 values less than (10),
+		partition pmax values less than (maxvalue))`)
+	tk.MustExec("insert into t values (1, 1), (11, 11), (21, 21)")
+
+	tk1 := testkit.NewTestKit(t, store)
+	dom := domain.GetDomain(tk.Session())
+	originHook := dom.DDL().GetHook()
+	defer dom.DDL().SetHook(originHook)
+	hook := &ddl.TestDDLCallback{Do: dom}
+	var checkErr error
+	cancelled := false
+	hook.OnJobRunBeforeExported = func(job *model.Job) {
+		if job.Type == model.ActionReorganizePartition && job.SchemaState == model.StateWriteReorganization && !cancelled {
+			cancelled = true
+			_, checkErr = tk1.Exec(fmt.Sprintf("admin cancel ddl jobs %d", job.ID))
+		}
+	}
+	dom.DDL().SetHook(hook)
+	tk.MustGetErrCode("alter table t reorganize partition pmax into (partition p1 values less than (20), partition pmax values less than (maxvalue))", errno.ErrCancelledDDLJob)
+	require.NoError(t, checkErr)
+	dom.DDL().SetHook(originHook)
+
+	tk.MustQuery("select * from t partition (pmax) order by a").Check(testkit.Rows("11 11", "21 21"))
+	require.Nil(t, tk.GetTableByName("test", "t").Meta().Partition.AddingDefinitions)
+	tk.MustExec("insert into t values (12, 12)")
+	tk.MustExec("admin check table t")
+}
+
+func TestAlterTablePartitioning(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t (a int, b int, c int, key(b), unique key idx_ac(a, c))")
+	tk.MustExec("insert into t values (1, 1, 1), (11, 11, 11), (21, 21, 21), (31, 31, 31)")
+	rowIDs := tk.MustQuery("select a, _tidb_rowid from t order by a").Rows()
+
+	tk.MustGetErrCode("alter table t remove partitioning", errno.ErrPartitionMgmtOnNonpartitioned)
+	tk.MustGetErrCode("alter table t partition by hash (b) partitions 2", errno.ErrUniqueKeyNeedAllFieldsInPf)
+	tk.MustGetErrCode("alter table t partition by range (a) (partition p0 values less than (10))", errno.ErrNoPartitionForGivenValue)
+
+	// Partition the non-partitioned table.
+	tk.MustExec("alter table t partition by range (a) (partition p0 values less than (10), partition p1 values less than (20), partition pmax values less than (maxvalue))")
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `a` int(11) DEFAULT NULL,\n" +
+		"  `b` int(11) DEFAULT NULL,\n" +
+		"  `c` int(11) DEFAULT NULL,\n" +
+		"  KEY `b` (`b`),\n" +
+		"  UNIQUE KEY `idx_ac` (`a`,`c`)\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin\n" +
+		"PARTITION BY RANGE (`a`)\n" +
+		"(PARTITION `p0` VALUES LESS THAN (10),\n" +
+		" PARTITION `p1` VALUES LESS THAN (20),\n" +
+		" PARTITION `pmax` VALUES LESS THAN (MAXVALUE))"))
+	tk.MustQuery("select a from t partition (p1)").Check(testkit.Rows("11"))
+	tk.MustQuery("select a from t partition (pmax) order by a").Check(testkit.Rows("21", "31"))
+	tk.MustQuery("select a, _tidb_rowid from t order by a").Check(rowIDs)
+	tk.MustQuery("select a from t use index(idx_ac) where a > 10 and c > 20 order by a").Check(testkit.Rows("21", "31"))
+	tk.MustExec("admin check table t")
+	tk.MustExec("insert into t values (2, 2, 2)")
+
+	// Change the partitioning.
+	tk.MustExec("alter table t partition by hash (a) partitions 3")
+	tk.MustQuery("select a from t partition (p1) order by a").Check(testkit.Rows("1", "31"))
+	tk.MustQuery("select a from t partition (p2) order by a").Check(testkit.Rows("2", "11"))
+	tk.MustExec("admin check table t")
+
+	// Remove the partitioning.
+	tk.MustExec("alter table t remove partitioning")
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `a` int(11) DEFAULT NULL,\n" +
+		"  `b` int(11) DEFAULT NULL,\n" +
+		"  `c` int(11) DEFAULT NULL,\n" +
+		"  KEY `b` (`b`),\n" +
+		"  UNIQUE KEY `idx_ac` (`a`,`c`)\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+	require.Nil(t, tk.GetTableByName("test", "t").Meta().Partition)
+	tk.MustQuery("select a, c from t order by a").Check(testkit.Rows("1 1", "2 2", "11 11", "21 21", "31 31"))
+	tk.MustGetErrCode("insert into t values (2, 3, 2)", errno.ErrDupEntry)
+	tk.MustExec("admin check table t")
+
+	// Clustered index.
+	tk.MustExec("drop table t")
+	tk.MustExec("create table t (a int, b varchar(10), c int, primary key (a, b) clustered)")
+	tk.MustExec("insert into t values (1, 'a', 1), (11, 'b', 11), (21, 'c', 21)")
+	tk.MustGetErrCode("alter table t partition by hash (c) partitions 2", errno.ErrUniqueKeyNeedAllFieldsInPf)
+	tk.MustExec("set @@session.tidb_enable_list_partition = ON")
+	tk.MustExec("alter table t partition by list (a) (partition p0 values in (1, 11), partition p1 values in (21))")
+	tk.MustQuery("select a from t partition (p0) order by a").Check(testkit.Rows("1", "11"))
+	tk.MustExec("admin check table t")
+	tk.MustExec("alter table t remove partitioning")
+	tk.MustQuery("select * from t where a = 11 and b = 'b'").Check(testkit.Rows("11 b 11"))
+	tk.MustExec("admin check table t")
+}
+
+func TestAlterTablePartitioningWithGlobalIndex(t *testing.T) {
+	restore := config.RestoreFunc()
+	defer restore()
+	config.UpdateGlobal(func(conf *config.Config) {
+		conf.EnableGlobalIndex = true
+	})
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t (a int, b int, unique key idx_b(b))")
+	tk.MustExec("insert into t values (1, 1), (11, 11), (21, 21)")
+	oldIdxID := tk.GetTableByName("test", "t").Meta().FindIndexByName("idx_b").ID
+
+	// The index is rebuilt as a global index.
+	tk.MustExec("alter table t partition by hash (a) partitions 2")
+	idxInfo := tk.GetTableByName("test", "t").Meta().FindIndexByName("idx_b")
+	require.True(t, idxInfo.Global)
+	require.NotEqual(t, oldIdxID, idxInfo.ID)
+	require.Len(t, tk.GetTableByName("test", "t").Meta().Indices, 1)
+	tk.MustQuery("select a from t use index(idx_b) where b > 5 order by b").Check(testkit.Rows("11", "21"))
+	tk.MustGetErrCode("insert into t values (2, 11)", errno.ErrDupEntry)
+	tk.MustExec("admin check table t")
+
+	// The global index is rebuilt for the new partitions.
+	tk.MustExec("alter table t partition by range (a) (partition p0 values less than (10), partition p1 values less than (maxvalue))")
+	require.True(t, tk.GetTableByName("test", "t").Meta().FindIndexByName("idx_b").Global)
+	tk.MustQuery("select a from t use index(idx_b) where b > 5 order by b").Check(testkit.Rows("11", "21"))
+	tk.MustExec("admin check table t")
+
+	// The global index is rebuilt as a normal index.
+	tk.MustExec("alter table t remove partitioning")
+	require.False(t, tk.GetTableByName("test", "t").Meta().FindIndexByName("idx_b").Global)
+	tk.MustQuery("select a from t use index(idx_b) where b > 5 order by b").Check(testkit.Rows("11", "21"))
+	tk.MustGetErrCode("insert into t values (2, 11)", errno.ErrDupEntry)
+	tk.MustExec("admin check table t")
+}
+
+func TestAlterTablePartitioningWithConcurrentDML(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t (a int, b int, key idx_b(b))")
+	tk.MustExec("insert into t values (1, 1), (11, 11), (21, 21), (31, 31), (41, 41)")
+
+	tk1 := testkit.NewTestKit(t, store)
+	tk1.MustExec("use test")
+	dom := domain.GetDomain(tk.Session())
+	originHook := dom.DDL().GetHook()
+	defer dom.DDL().SetHook(originHook)
+	hook := &ddl.TestDDLCallback{Do: dom}
+	var checkErr error
+	var dmls map[model.SchemaState][]string
+	hook.OnJobRunBeforeExported = func(job *model.Job) {
+		if (job.Type != model.ActionAlterTablePartitioning && job.Type != model.ActionRemovePartitioning) || checkErr != nil {
+			return
+		}
+		stmts, ok := dmls[job.SchemaState]
+		if !ok {
+			return
+		}
+		delete(dmls, job.SchemaState)
+		for _, stmt := range stmts {
+			if _, checkErr = tk1.Exec(stmt); checkErr != nil {
+				return
+			}
+		}
+	}
+	dom.DDL().SetHook(hook)
+
+	dmls = map[model.SchemaState][]string{
+		model.StateDeleteOnly:           {"insert into t values (12, 12)", "delete from t where a = 11"},
+		model.StateWriteOnly:            {"insert into t values (22, 22)", "update t set a = 32 where a = 31"},
+		model.StateWriteReorganization:  {"insert into t values (13, 13)", "update t set a = 23, b = 23 where a = 21"},
+		model.StateDeleteReorganization: {"insert into t values (42, 42)", "delete from t where a = 41"},
+	}
+	tk.MustExec("alter table t partition by range (a) (partition p0 values less than (10), partition p1 values less than (20), partition p2 values less than (maxvalue))")
+	require.NoError(t, checkErr)
+	require.Len(t, dmls, 0)
+	tk.MustQuery("select * from t partition (p1) order by a").Check(testkit.Rows("12 12", "13 13"))
+	tk.MustQuery("select * from t partition (p2) order by a").Check(testkit.Rows("22 22", "23 23", "32 31", "42 42"))
+	tk.MustQuery("select a from t use index(idx_b) where b > 10 order by a").Check(testkit.Rows("12", "13", "22", "23", "32", "42"))
+	tk.MustExec("admin check table t")
+
+	dmls = map[model.SchemaState][]string{
+		model.StateDeleteOnly:           {"insert into t values (14, 14)", "delete from t where a = 12"},
+		model.StateWriteOnly:            {"insert into t values (24, 24)", "update t set a = 2 where a = 32"},
+		model.StateWriteReorganization:  {"insert into t values (15, 15)", "update t set a = 25, b = 25 where a = 23"},
+		model.StateDeleteReorganization: {"insert into t values (43, 43)", "delete from t where a = 42"},
+	}
+	tk.MustExec("alter table t remove partitioning")
+	require.NoError(t, checkErr)
+	require.Len(t, dmls, 0)
+	tk.MustQuery("select * from t order by a").Check(testkit.Rows("1 1", "2 31", "13 13", "14 14", "15 15", "22 22", "24 24", "25 25", "43 43"))
+	tk.MustQuery("select a from t use index(idx_b) where b > 10 order by a").Check(testkit.Rows("2", "13", "14", "15", "22", "24", "25", "43"))
+	tk.MustExec("admin check table t")
+}
+
+func TestCancelAlterTablePartitioning(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t (a int primary key, b int, key idx_b(b))")
+	tk.MustExec("insert into t values (1, 1), (11, 11), (21, 21)")
+
+	tk1 := testkit.NewTestKit(t, store)
+	dom := domain.GetDomain(tk.Session())
+	originHook := dom.DDL().GetHook()
+	defer dom.DDL().SetHook(originHook)
+	hook := &ddl.TestDDLCallback{Do: dom}
+	var checkErr error
+	cancelled := false
+	hook.OnJobRunBeforeExported = func(job *model.Job) {
+		if job.Type == model.ActionAlterTablePartitioning && job.SchemaState == model.StateWriteReorganization && !cancelled {
+			cancelled = true
+			_, checkErr = tk1.Exec(fmt.Sprintf("admin cancel ddl jobs %d", job.ID))
+		}
+	}
+	dom.DDL().SetHook(hook)
+	tk.MustGetErrCode("alter table t partition by hash (a) partitions 2", errno.ErrCancelledDDLJob)
+	require.NoError(t, checkErr)
+	dom.DDL().SetHook(originHook)
+
+	require.Nil(t, tk.GetTableByName("test", "t").Meta().Partition)
+	tk.MustQuery("select * from t order by a").Check(testkit.Rows("1 1", "11 11", "21 21"))
+	tk.MustExec("insert into t values (12, 12)")
+	tk.MustQuery("select a from t use index(idx_b) where b > 10 order by b").Check(testkit.Rows("11", "12", "21"))
+	tk.MustExec("admin check table t")
+}
@@ -121,6 +121,10 @@ type DDL interface {
 	CreatePlacementPolicy(ctx sessionctx.Context, stmt *ast.CreatePlacementPolicyStmt) error
 	DropPlacementPolicy(ctx sessionctx.Context, stmt *ast.DropPlacementPolicyStmt) error
 	AlterPlacementPolicy(ctx sessionctx.Context, stmt *ast.AlterPlacementPolicyStmt) error
+	CreateProcedure(ctx sessionctx.Context, stmt *ast.CreateProcedureStmt) error
+	DropProcedure(ctx sessionctx.Context, stmt *ast.DropProcedureStmt) error
+	CreateTrigger(ctx sessionctx.Context, stmt *ast.CreateTriggerStmt) error
+	DropTrigger(ctx sessionctx.Context, stmt *ast.DropTriggerStmt) error
 
 	// CreateSchemaWithInfo creates a database (schema) given its database info.
 	//
@@ -530,8 +534,12 @@ func getIntervalFromPolicy(policy []time.Duration, i int) (time.Duration, bool)
 }
 
 func getJobCheckInterval(job *model.Job, i int) (time.Duration, bool) {
+	if job.Type == model.ActionMultiSchemaChange && subJobNeedReorg(job) {
+		return getIntervalFromPolicy(slowDDLIntervalPolicy, i)
+	}
 	switch job.Type {
-	case model.ActionAddIndex, model.ActionAddPrimaryKey, model.ActionModifyColumn:
+	case model.ActionAddIndex, model.ActionAddPrimaryKey, model.ActionModifyColumn, model.ActionReorganizePartition,
+		model.ActionAlterTablePartitioning, model.ActionRemovePartitioning:
 		return getIntervalFromPolicy(slowDDLIntervalPolicy, i)
 	case model.ActionCreateTable, model.ActionCreateSchema:
 		return getIntervalFromPolicy(fastDDLIntervalPolicy, i)
@@ -543,7 +551,8 @@ func getJobCheckInterval(job *model.Job, i int) (time.Duration, bool) {
 // mayNeedReorg indicates that this job may need to reorganize the data.
 func mayNeedReorg(job *model.Job) bool {
 	switch job.Type {
-	case model.ActionAddIndex, model.ActionAddPrimaryKey:
+	case model.ActionAddIndex, model.ActionAddPrimaryKey, model.ActionReorganizePartition,
+		model.ActionAlterTablePartitioning, model.ActionRemovePartitioning:
 		return true
 	case model.ActionModifyColumn:
 		if len(job.CtxVars) > 0 {
@@ -551,6 +560,8 @@ func mayNeedReorg(job *model.Job) bool {
 			return ok && needReorg
 		}
 		return false
+	case model.ActionMultiSchemaChange:
+		return subJobNeedReorg(job)
 	default:
 		return false
 	}
@@ -597,6 +608,10 @@ func recordLastDDLInfo(ctx sessionctx.Context, job *model.Job) {
 // - context.Cancel: job has been sent to worker, but not found in history DDL job before cancel
 // - other: found in history DDL job and return that job error
 func (d *ddl) doDDLJob(ctx sessionctx.Context, job *model.Job) error {
+	if mci := ctx.GetSessionVars().StmtCtx.MultiSchemaInfo; mci != nil {
+		// In a multi-schema change, the job isn't run but merged into the pending job as a sub-job.
+		return errors.Trace(appendToSubJobs(mci, job))
+	}
 	// Get a global job ID and put the DDL job in the queue.
 	job.Query, _ = ctx.Value(sessionctx.QueryString).(string)
 	task := &limitJobTask{job, make(chan error)}
@@ -52,6 +52,7 @@ import (
 	"github.com/pingcap/tidb/tablecodec"
 	"github.com/pingcap/tidb/types"
 	driver "github.com/pingcap/tidb/types/parser_driver"
+	"github.com/pingcap/tidb/types/spatial"
 	"github.com/pingcap/tidb/util"
 	"github.com/pingcap/tidb/util/chunk"
 	"github.com/pingcap/tidb/util/collate"
@@ -668,9 +669,13 @@ func buildColumnAndConstraint(
 // checkColumnDefaultValue checks the default value of the column.
 // In non-strict SQL mode, if the default value of the column is an empty string, the default value can be ignored.
 // In strict SQL mode, TEXT/BLOB/JSON can't have not null default values.
+// GEOMETRY can't have not null default values in any SQL mode.
 // In NO_ZERO_DATE SQL mode, TIMESTAMP/DATE/DATETIME type can't have zero date like '0000-00-00' or '0000-00-00 00:00:00'.
 func checkColumnDefaultValue(ctx sessionctx.Context, col *table.Column, value interface{}) (bool, interface{}, error) {
 	hasDefaultValue := true
+	if value != nil && col.Tp == mysql.TypeGeometry {
+		return hasDefaultValue, value, dbterror.ErrBlobCantHaveDefault.GenWithStackByArgs(col.Name.O)
+	}
 	if value != nil && (col.Tp == mysql.TypeJSON ||
 		col.Tp == mysql.TypeTinyBlob || col.Tp == mysql.TypeMediumBlob ||
 		col.Tp == mysql.TypeLongBlob || col.Tp == mysql.TypeBlob) {
@@ -901,8 +906,14 @@ func columnDefToCol(ctx sessionctx.Context, offset int, colDef *ast.ColumnDef, o
 				}
 			case ast.ColumnOptionFulltext:
 				ctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrTableCantHandleFt.GenWithStackByArgs())
+			case ast.ColumnOptionSrid:
+				if err = setColumnSRID(col, v); err != nil {
+					return nil, nil, errors.Trace(err)
+				}
 			case ast.ColumnOptionCheck:
-				ctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrUnsupportedConstraintCheck.GenWithStackByArgs("CONSTRAINT CHECK"))
+				constraint := &ast.Constraint{Tp: ast.ConstraintCheck, Name: v.ConstraintName, Expr: v.Expr,
+					Enforced: v.Enforced, InColumn: true, InColumnName: colDef.Name.Name.O}
+				constraints = append(constraints, constraint)
 			}
 		}
 	}
@@ -913,6 +924,23 @@ func columnDefToCol(ctx sessionctx.Context, offset int, colDef *ast.ColumnDef, o
 	return col, constraints, nil
 }
 
+// setColumnSRID sets the SRID attribute of the geometry column.
+func setColumnSRID(col *table.Column, option *ast.ColumnOption) error {
+	if col.Tp != mysql.TypeGeometry {
+		return dbterror.ErrWrongUsage.GenWithStackByArgs("SRID", "non-geometry column")
+	}
+	srid := option.Expr.(ast.ValueExpr).GetValue().(uint64)
+	if srid > math.MaxUint32 {
+		return spatial.ErrSRSNotFound.GenWithStackByArgs(srid)
+	}
+	if err := spatial.CheckSRID(uint32(srid)); err != nil {
+		return err
+	}
+	col.Srid = new(uint32)
+	*col.Srid = uint32(srid)
+	return nil
+}
+
 // getDefaultValue will get the default value for column.
 // 1: get the expr restored string for the column which uses sequence next value as default value.
 // 2: get specific default value for the other column.
@@ -1438,6 +1466,7 @@ func setEmptyConstraintName(namesMap map[string]bool, constr *ast.Constraint, fo
 func checkConstraintNames(constraints []*ast.Constraint) error {
 	constrNames := map[string]bool{}
 	fkNames := map[string]bool{}
+	checkNames := map[string]bool{}
 
 	// Check not empty constraint name whether is duplicated.
 	for _, constr := range constraints {
@@ -1446,6 +1475,15 @@ func checkConstraintNames(constraints []*ast.Constraint) error {
 			if err != nil {
 				return errors.Trace(err)
 			}
+		} else if constr.Tp == ast.ConstraintCheck {
+			if constr.Name == "" {
+				continue
+			}
+			nameLower := strings.ToLower(constr.Name)
+			if checkNames[nameLower] {
+				return dbterror.ErrCheckConstraintDupName.GenWithStackByArgs(constr.Name)
+			}
+			checkNames[nameLower] = true
 		} else {
 			err := checkDuplicateConstraint(constrNames, constr.Name, false)
 			if err != nil {
@@ -1607,6 +1645,7 @@ func buildTableInfo(
 		tbInfo.Columns = append(tbInfo.Columns, v.ToInfo())
 		tblColumns = append(tblColumns, table.ToColumn(v.ToInfo()))
 	}
+	var checkConstraints []*ast.Constraint
 	for _, constr := range constraints {
 		// Build hidden columns if necessary.
 		hiddenCols, err := buildHiddenColumnInfo(ctx, constr.Keys, model.NewCIStr(constr.Name), tbInfo, tblColumns)
@@ -1665,16 +1704,23 @@ func buildTableInfo(
 			}
 		}
 
-		if constr.Tp == ast.ConstraintFulltext {
-			ctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrTableCantHandleFt.GenWithStackByArgs())
-			continue
-		}
 		if constr.Tp == ast.ConstraintCheck {
-			ctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrUnsupportedConstraintCheck.GenWithStackByArgs("CONSTRAINT CHECK"))
+			// Build the check constraints after all the columns are built.
+			checkConstraints = append(checkConstraints, constr)
 			continue
 		}
 		// build index info.
-		idxInfo, err := buildIndexInfo(tbInfo, model.NewCIStr(constr.Name), constr.Keys, model.StatePublic)
+		var idxInfo *model.IndexInfo
+		if constr.Tp == ast.ConstraintFulltext {
+			idxInfo, err = buildFullTextIndexInfo(tbInfo, model.NewCIStr(constr.Name), constr.Keys, constr.Option, model.StatePublic)
+		} else if constr.Tp == ast.ConstraintSpatial {
+			idxInfo, err = buildSpatialIndexInfo(tbInfo, model.NewCIStr(constr.Name), constr.Keys, model.StatePublic)
+			if err == nil {
+				warnUselessSpatialIndex(ctx, tbInfo.Columns, idxInfo.Columns)
+			}
+		} else {
+			idxInfo, err = buildIndexInfo(tbInfo, model.NewCIStr(constr.Name), constr.Keys, model.StatePublic)
+		}
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
@@ -1690,8 +1736,14 @@ func buildTableInfo(
 		case ast.ConstraintUniq, ast.ConstraintUniqKey, ast.ConstraintUniqIndex:
 			idxInfo.Unique = true
 		}
+		if idxInfo.MVIndex && idxInfo.Unique {
+			return nil, dbterror.ErrNotSupportedYet.GenWithStackByArgs("unique multi-valued index")
+		}
 		// set index type.
 		if constr.Option != nil {
+			if err = checkIndexOptionNotHypo(constr.Option); err != nil {
+				return nil, errors.Trace(err)
+			}
 			idxInfo.Comment, err = validateCommentLength(ctx.GetSessionVars(), idxInfo.Name.String(), constr.Option)
 			if err != nil {
 				return nil, errors.Trace(err)
@@ -1713,6 +1765,9 @@ func buildTableInfo(
 		tbInfo.Indices = append(tbInfo.Indices, idxInfo)
 	}
 
+	if err = buildCheckConstraintInfos(tbInfo, checkConstraints); err != nil {
+		return nil, errors.Trace(err)
+	}
 	return
 }
 
@@ -1812,6 +1867,11 @@ func checkTableInfoValidWithStmt(ctx sessionctx.Context, tbInfo *model.TableInfo
 			if err := checkPartitionFuncType(ctx, s.Partition.Expr, tbInfo); err != nil {
 				return errors.Trace(err)
 			}
+			if s.Partition.Sub != nil && tbInfo.Partition.IsSubPartitioned() {
+				if err := checkPartitionFuncType(ctx, s.Partition.Sub.Expr, tbInfo); err != nil {
+					return errors.Trace(err)
+				}
+			}
 			if err := checkPartitioningKeysConstraints(ctx, s, tbInfo); err != nil {
 				return errors.Trace(err)
 			}
@@ -1832,6 +1892,22 @@ func checkPartitionDefinitionConstraints(ctx sessionctx.Context, tbInfo *model.T
 	if err = checkAddPartitionOnTemporaryMode(tbInfo); err != nil {
 		return err
 	}
+	if pi := tbInfo.Partition; pi.IsSubPartitioned() {
+		// The names of the partitions and the subpartitions share the same namespace.
+		allDefs := make([]model.PartitionDefinition, 0, len(pi.ParentDefinitions)+len(pi.Definitions))
+		allDefs = append(append(allDefs, pi.ParentDefinitions...), pi.Definitions...)
+		if err = checkPartitionNameUnique(&model.PartitionInfo{Definitions: allDefs}); err != nil {
+			return errors.Trace(err)
+		}
+		if err = checkSubPartitionColumnsUnique(pi); err != nil {
+			return err
+		}
+		// The first level partitions are checked as the partitions of a table which is not subpartitioned.
+		tbInfo.Partition = pi.FirstLevel()
+		defer func() {
+			tbInfo.Partition = pi
+		}()
+	}
 	if err = checkPartitionColumnsUnique(tbInfo); err != nil {
 		return err
 	}
@@ -1839,7 +1915,7 @@ func checkPartitionDefinitionConstraints(ctx sessionctx.Context, tbInfo *model.T
 	switch tbInfo.Partition.Type {
 	case model.PartitionTypeRange:
 		err = checkPartitionByRange(ctx, tbInfo)
-	case model.PartitionTypeHash:
+	case model.PartitionTypeHash, model.PartitionTypeKey:
 		err = checkPartitionByHash(ctx, tbInfo)
 	case model.PartitionTypeList:
 		err = checkPartitionByList(ctx, tbInfo)
@@ -2533,6 +2609,14 @@ func checkTwoRangeColumns(ctx sessionctx.Context, curr, prev *model.PartitionDef
 		if succ {
 			return true, nil
 		}
+		// The following columns are compared only if the current and previous values are equal.
+		less, err := parseAndEvalBoolExpr(ctx, prev.LessThan[i], curr.LessThan[i], colInfo, tbInfo)
+		if err != nil {
+			return false, err
+		}
+		if less {
+			return false, nil
+		}
 	}
 	return false, nil
 }
@@ -2808,14 +2892,16 @@ func checkMultiSpecs(sctx sessionctx.Context, specs []*ast.AlterTableSpec) error
 		if len(specs) == 1 && len(specs[0].NewColumns) > 1 && specs[0].Tp == ast.AlterTableAddColumns {
 			return dbterror.ErrRunMultiSchemaChanges
 		}
-	} else {
-		if len(specs) > 1 && !isSameTypeMultiSpecs(specs) {
-			return dbterror.ErrRunMultiSchemaChanges
-		}
 	}
 	return nil
 }
 
+// isMultiSchemaChange checks whether the specs should be run as one ActionMultiSchemaChange job.
+// The same type specs are still run by the legacy multi-column or multi-index jobs.
+func isMultiSchemaChange(specs []*ast.AlterTableSpec) bool {
+	return len(specs) > 1 && !isSameTypeMultiSpecs(specs)
+}
+
 func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, ident ast.Ident, specs []*ast.AlterTableSpec) (err error) {
 	validSpecs, err := resolveAlterTableSpec(sctx, specs)
 	if err != nil {
@@ -2832,7 +2918,13 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, ident ast
 		return err
 	}
 
-	if len(validSpecs) > 1 {
+	if isMultiSchemaChange(validSpecs) {
+		// The specs are collected as the sub-jobs, they are run as one job at the end.
+		sctx.GetSessionVars().StmtCtx.MultiSchemaInfo = model.NewMultiSchemaInfo()
+		defer func() {
+			sctx.GetSessionVars().StmtCtx.MultiSchemaInfo = nil
+		}()
+	} else if len(validSpecs) > 1 {
 		switch validSpecs[0].Tp {
 		case ast.AlterTableAddColumns:
 			err = d.AddColumns(sctx, ident, validSpecs)
@@ -2853,17 +2945,26 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, ident ast
 		var handledCharsetOrCollate bool
 		switch spec.Tp {
 		case ast.AlterTableAddColumns:
-			if len(spec.NewColumns) != 1 {
-				err = d.AddColumns(sctx, ident, []*ast.AlterTableSpec{spec})
-			} else {
+			if len(spec.NewColumns) == 1 {
 				err = d.AddColumn(sctx, ident, spec)
+			} else if sctx.GetSessionVars().StmtCtx.MultiSchemaInfo != nil {
+				// Split the columns into the sub-jobs.
+				for _, col := range spec.NewColumns {
+					colSpec := *spec
+					colSpec.NewColumns = []*ast.ColumnDef{col}
+					if err = d.AddColumn(sctx, ident, &colSpec); err != nil {
+						break
+					}
+				}
+			} else {
+				err = d.AddColumns(sctx, ident, []*ast.AlterTableSpec{spec})
 			}
 		case ast.AlterTableAddPartitions:
 			err = d.AddTablePartitions(sctx, ident, spec)
 		case ast.AlterTableCoalescePartitions:
 			err = d.CoalescePartitions(sctx, ident, spec)
 		case ast.AlterTableReorganizePartition:
-			err = errors.Trace(dbterror.ErrUnsupportedReorganizePartition)
+			err = d.ReorganizePartitions(sctx, ident, spec)
 		case ast.AlterTableCheckPartitions:
 			err = errors.Trace(dbterror.ErrUnsupportedCheckPartition)
 		case ast.AlterTableRebuildPartition:
@@ -2871,7 +2972,7 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, ident ast
 		case ast.AlterTableOptimizePartition:
 			err = errors.Trace(dbterror.ErrUnsupportedOptimizePartition)
 		case ast.AlterTableRemovePartitioning:
-			err = errors.Trace(dbterror.ErrUnsupportedRemovePartition)
+			err = d.RemovePartitioning(sctx, ident, spec)
 		case ast.AlterTableRepairPartition:
 			err = errors.Trace(dbterror.ErrUnsupportedRepairPartition)
 		case ast.AlterTableDropColumn:
@@ -2888,7 +2989,7 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, ident ast
 			err = d.TruncateTablePartition(sctx, ident, spec)
 		case ast.AlterTableWriteable:
 			if !config.TableLockEnabled() {
-				return nil
+				continue
 			}
 			tName := &ast.TableName{Schema: ident.Schema, Name: ident.Name}
 			if spec.Writeable {
@@ -2922,9 +3023,13 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, ident ast
 			case ast.ConstraintPrimaryKey:
 				err = d.CreatePrimaryKey(sctx, ident, model.NewCIStr(constr.Name), spec.Constraint.Keys, constr.Option)
 			case ast.ConstraintFulltext:
-				sctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrTableCantHandleFt)
+				err = d.CreateIndex(sctx, ident, ast.IndexKeyTypeFullText, model.NewCIStr(constr.Name),
+					spec.Constraint.Keys, constr.Option, constr.IfNotExists)
+			case ast.ConstraintSpatial:
+				err = d.CreateIndex(sctx, ident, ast.IndexKeyTypeSpatial, model.NewCIStr(constr.Name),
+					spec.Constraint.Keys, constr.Option, constr.IfNotExists)
 			case ast.ConstraintCheck:
-				sctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrUnsupportedConstraintCheck.GenWithStackByArgs("ADD CONSTRAINT CHECK"))
+				err = d.CreateCheckConstraint(sctx, ident, model.NewCIStr(constr.Name), spec.Constraint)
 			default:
 				// Nothing to do now.
 			}
@@ -2944,8 +3049,7 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, ident ast
 			isAlterTable := true
 			err = d.RenameTable(sctx, ident, newIdent, isAlterTable)
 		case ast.AlterTablePartition:
-			// Prevent silent succeed if user executes ALTER TABLE x PARTITION BY ...
-			err = errors.New("alter table partition is unsupported")
+			err = d.AlterTablePartitioning(sctx, ident, spec)
 		case ast.AlterTableOption:
 			var placementPolicyRef *model.PolicyRefInfo
 			for i, opt := range spec.Options {
@@ -3006,9 +3110,9 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, ident ast
 		case ast.AlterTableIndexInvisible:
 			err = d.AlterIndexVisibility(sctx, ident, spec.IndexName, spec.Visibility)
 		case ast.AlterTableAlterCheck:
-			sctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrUnsupportedConstraintCheck.GenWithStackByArgs("ALTER CHECK"))
+			err = d.AlterCheckConstraint(sctx, ident, model.NewCIStr(spec.Constraint.Name), spec.Constraint.Enforced)
 		case ast.AlterTableDropCheck:
-			sctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrUnsupportedConstraintCheck.GenWithStackByArgs("DROP CHECK"))
+			err = d.DropCheckConstraint(sctx, ident, model.NewCIStr(spec.Constraint.Name))
 		case ast.AlterTableWithValidation:
 			sctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrUnsupportedAlterTableWithValidation)
 		case ast.AlterTableWithoutValidation:
@@ -3036,6 +3140,9 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, ident ast
 		}
 	}
 
+	if sctx.GetSessionVars().StmtCtx.MultiSchemaInfo != nil {
+		return d.multiSchemaChange(sctx, ident)
+	}
 	return nil
 }
 
@@ -3225,6 +3332,10 @@ func checkAndCreateNewColumn(ctx sessionctx.Context, ti ast.Ident, schema *model
 				return nil, errors.Trace(err)
 			}
 		}
+		// The check constraint can't be added with the column, since the existing rows are not verified.
+		if option.Tp == ast.ColumnOptionCheck {
+			ctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrUnsupportedConstraintCheck.GenWithStackByArgs("ADD COLUMN with CHECK"))
+		}
 		// Specially, since sequence has been supported, if a newly added column has a
 		// sequence nextval function as it's default value option, it won't fill the
 		// known rows with specific sequence next value under current add column logic.
@@ -3399,6 +3510,9 @@ func (d *ddl) AddTablePartitions(ctx sessionctx.Context, ident ast.Ident, spec *
 	if pi == nil {
 		return errors.Trace(dbterror.ErrPartitionMgmtOnNonpartitioned)
 	}
+	if pi.IsSubPartitioned() {
+		return errors.Trace(dbterror.ErrUnsupportedSubPartitionManagement.GenWithStackByArgs("add partitions"))
+	}
 
 	partInfo, err := buildAddedPartitionInfo(ctx, meta, spec)
 	if err != nil {
@@ -3480,6 +3594,182 @@ func (d *ddl) CoalescePartitions(ctx sessionctx.Context, ident ast.Ident, spec *
 	return errors.Trace(err)
 }
 
+// ReorganizePartitions reorganizes the consecutive range or list partitions into the new partitions.
+func (d *ddl) ReorganizePartitions(ctx sessionctx.Context, ident ast.Ident, spec *ast.AlterTableSpec) error {
+	is := d.infoCache.GetLatest()
+	schema, ok := is.SchemaByName(ident.Schema)
+	if !ok {
+		return errors.Trace(infoschema.ErrDatabaseNotExists.GenWithStackByArgs(schema))
+	}
+	t, err := is.TableByName(ident.Schema, ident.Name)
+	if err != nil {
+		return errors.Trace(infoschema.ErrTableNotExists.GenWithStackByArgs(ident.Schema, ident.Name))
+	}
+
+	meta := t.Meta()
+	pi := meta.GetPartitionInfo()
+	if pi == nil {
+		return errors.Trace(dbterror.ErrPartitionMgmtOnNonpartitioned)
+	}
+	switch pi.Type {
+	case model.PartitionTypeRange, model.PartitionTypeList:
+	default:
+		return errors.Trace(dbterror.ErrUnsupportedReorganizePartition)
+	}
+	if pi.IsSubPartitioned() {
+		return errors.Trace(dbterror.ErrUnsupportedSubPartitionManagement.GenWithStackByArgs("reorganize partition"))
+	}
+	if spec.OnAllPartitions {
+		return errors.Trace(dbterror.ErrReorgNoParam)
+	}
+	// The global indexes and TiFlash replicas of the reorganized partitions are not maintained yet.
+	if hasGlobalIndex(meta) || (meta.TiFlashReplica != nil && meta.TiFlashReplica.Count > 0) {
+		return errors.Trace(dbterror.ErrUnsupportedReorganizePartition)
+	}
+
+	partNames := make([]string, 0, len(spec.PartitionNames))
+	for _, partCIName := range spec.PartitionNames {
+		partNames = append(partNames, partCIName.L)
+	}
+	partInfo, err := buildAddedPartitionInfo(ctx, meta, spec)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err = checkReorganizePartition(ctx, meta, partNames, partInfo); err != nil {
+		return errors.Trace(err)
+	}
+	if err = d.assignPartitionIDs(partInfo.Definitions); err != nil {
+		return errors.Trace(err)
+	}
+	if err = handlePartitionPlacement(ctx, partInfo); err != nil {
+		return errors.Trace(err)
+	}
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    meta.ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionReorganizePartition,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{partNames, partInfo},
+	}
+
+	err = d.doDDLJob(ctx, job)
+	if err == nil {
+		d.preSplitAndScatter(ctx, meta, partInfo)
+	}
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
+// AlterTablePartitioning partitions the table by the new partitioning, the table may be a non-partitioned table.
+func (d *ddl) AlterTablePartitioning(ctx sessionctx.Context, ident ast.Ident, spec *ast.AlterTableSpec) error {
+	schema, t, err := d.getSchemaAndTableByIdent(ctx, ident)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	meta := t.Meta()
+	if meta.TableCacheStatusType != model.TableCacheStatusDisable {
+		return errors.Trace(dbterror.ErrOptOnCacheTable.GenWithStackByArgs("Alter Table Partition By"))
+	}
+	// The TiFlash replicas of the partitions are not maintained yet.
+	if meta.TiFlashReplica != nil && meta.TiFlashReplica.Count > 0 {
+		return errors.Trace(dbterror.ErrUnsupportedAlterTablePartitioning)
+	}
+	if (meta.Partition != nil && meta.Partition.IsSubPartitioned()) || spec.Partition.Sub != nil {
+		return errors.Trace(dbterror.ErrUnsupportedSubPartitionManagement.GenWithStackByArgs("alter table partition by"))
+	}
+	newMeta := meta.Clone()
+	newMeta.Partition = nil
+	if err = buildTablePartitionInfo(ctx, spec.Partition, newMeta); err != nil {
+		return errors.Trace(err)
+	}
+	if newMeta.Partition == nil {
+		// The partition type is unsupported or the partitioning is disabled, prevent silent succeed.
+		return errors.Trace(dbterror.ErrUnsupportedAlterTablePartitioning)
+	}
+	partInfo := newMeta.Partition
+	if err = checkPartitionDefinitionConstraints(ctx, newMeta); err != nil {
+		return errors.Trace(err)
+	}
+	if err = checkPartitionFuncType(ctx, spec.Partition.Expr, newMeta); err != nil {
+		return errors.Trace(err)
+	}
+	if err = checkPartitioningKeysConstraints(ctx, &ast.CreateTableStmt{Partition: spec.Partition}, newMeta); err != nil {
+		return errors.Trace(err)
+	}
+	if err = d.assignPartitionIDs(partInfo.Definitions); err != nil {
+		return errors.Trace(err)
+	}
+	if err = handlePartitionPlacement(ctx, partInfo); err != nil {
+		return errors.Trace(err)
+	}
+
+	// All the partitions are reorganized, a non-partitioned table is regarded as a single partition.
+	partNames := []string{fullTablePartitionName}
+	if pi := meta.GetPartitionInfo(); pi != nil {
+		partNames = make([]string, 0, len(pi.Definitions))
+		for _, def := range pi.Definitions {
+			partNames = append(partNames, def.Name.L)
+		}
+	}
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    meta.ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionAlterTablePartitioning,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{partNames, partInfo},
+	}
+
+	err = d.doDDLJob(ctx, job)
+	if err == nil {
+		d.preSplitAndScatter(ctx, meta, partInfo)
+	}
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
+// RemovePartitioning removes the partitioning of the table, all the partitions are merged into the table.
+func (d *ddl) RemovePartitioning(ctx sessionctx.Context, ident ast.Ident, spec *ast.AlterTableSpec) error {
+	schema, t, err := d.getSchemaAndTableByIdent(ctx, ident)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	meta := t.Meta()
+	pi := meta.GetPartitionInfo()
+	if pi == nil {
+		return errors.Trace(dbterror.ErrPartitionMgmtOnNonpartitioned)
+	}
+	// The TiFlash replicas of the table are not maintained yet.
+	if meta.TiFlashReplica != nil && meta.TiFlashReplica.Count > 0 {
+		return errors.Trace(dbterror.ErrUnsupportedRemovePartition)
+	}
+	if pi.IsSubPartitioned() {
+		return errors.Trace(dbterror.ErrUnsupportedSubPartitionManagement.GenWithStackByArgs("remove partitioning"))
+	}
+
+	partNames := make([]string, 0, len(pi.Definitions))
+	for _, def := range pi.Definitions {
+		partNames = append(partNames, def.Name.L)
+	}
+	partInfo := newFullTablePartitionInfo(meta.ID)
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    meta.ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionRemovePartitioning,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{partNames, partInfo},
+	}
+
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
 func (d *ddl) TruncateTablePartition(ctx sessionctx.Context, ident ast.Ident, spec *ast.AlterTableSpec) error {
 	is := d.infoCache.GetLatest()
 	schema, ok := is.SchemaByName(ident.Schema)
@@ -3506,11 +3796,13 @@ func (d *ddl) TruncateTablePartition(ctx sessionctx.Context, ident ast.Ident, sp
 		// so we filter them out through a hash
 		pidMap := make(map[int64]bool)
 		for _, name := range spec.PartitionNames {
-			pid, err := tables.FindPartitionByName(meta, name.L)
+			partIDs, err := tables.FindPartitionIDsByName(meta, name.L)
 			if err != nil {
 				return errors.Trace(err)
 			}
-			pidMap[pid] = true
+			for _, pid := range partIDs {
+				pidMap[pid] = true
+			}
 		}
 		// linter makezero does not handle changing pids to zero length,
 		// so create a new var and then assign to pids...
@@ -3749,7 +4041,9 @@ func (d *ddl) ExchangeTablePartition(ctx sessionctx.Context, ident ast.Ident, sp
 
 	partName := spec.PartitionNames[0].L
 
-	// NOTE: if pt is subPartitioned, it should be checked
+	if ptMeta.Partition.IsSubPartitioned() {
+		return errors.Trace(dbterror.ErrUnsupportedSubPartitionManagement.GenWithStackByArgs("exchange partition"))
+	}
 
 	defID, err := tables.FindPartitionByName(ptMeta, partName)
 	if err != nil {
@@ -3911,7 +4205,8 @@ func checkIsDroppableColumn(ctx sessionctx.Context, t table.Table, spec *ast.Alt
 		return false, err
 	}
 
-	if err = isDroppableColumn(ctx.GetSessionVars().EnableChangeMultiSchema, tblInfo, colName); err != nil {
+	multiSchemaChange := ctx.GetSessionVars().EnableChangeMultiSchema || ctx.GetSessionVars().StmtCtx.MultiSchemaInfo != nil
+	if err = isDroppableColumn(multiSchemaChange, tblInfo, colName); err != nil {
 		return false, errors.Trace(err)
 	}
 	// We don't support dropping column with PK handle covered now.
@@ -4170,6 +4465,9 @@ func (d *ddl) getModifiableColumnJob(ctx context.Context, sctx sessionctx.Contex
 		if c != nil {
 			return nil, infoschema.ErrColumnExists.GenWithStackByArgs(newColName)
 		}
+		if err = checkColumnDroppableForCheckConstraint(t.Meta(), originalColName, true); err != nil {
+			return nil, errors.Trace(err)
+		}
 	}
 
 	// Constraints in the new column means adding new constraints. Errors should thrown,
@@ -4348,6 +4646,13 @@ func checkColumnWithIndexConstraint(tbInfo *model.TableInfo, originalCol, newCol
 		if !modified {
 			return
 		}
+		if indexInfo.FullText {
+			// The FULLTEXT index stores the tokens of the values, so only the type of the column is checked.
+			return checkFullTextIndexColumn(model.FindColumnInfo(columns, originalCol.Name.L))
+		}
+		if indexInfo.Spatial {
+			return checkSpatialIndexColumn(model.FindColumnInfo(columns, originalCol.Name.L))
+		}
 		err = checkIndexInModifiableColumns(columns, indexInfo.Columns)
 		if err != nil {
 			return
@@ -4522,6 +4827,9 @@ func (d *ddl) RenameColumn(ctx sessionctx.Context, ident ast.Ident, spec *ast.Al
 	if fkInfo := getColumnForeignKeyInfo(oldColName.L, tbl.Meta().ForeignKeys); fkInfo != nil {
 		return dbterror.ErrFKIncompatibleColumns.GenWithStackByArgs(oldColName, fkInfo.Name)
 	}
+	if err = checkColumnDroppableForCheckConstraint(tbl.Meta(), oldColName, true); err != nil {
+		return errors.Trace(err)
+	}
 
 	// Check generated expression.
 	for _, col := range allCols {
@@ -4821,18 +5129,11 @@ func (d *ddl) AlterTableAddStatistics(ctx sessionctx.Context, ident ast.Ident, s
 	if !ctx.GetSessionVars().EnableExtendedStats {
 		return errors.New("Extended statistics feature is not generally available now, and tidb_enable_extended_stats is OFF")
 	}
-	// Not support Cardinality and Dependency statistics type for now.
-	if stats.StatsType == ast.StatsTypeCardinality || stats.StatsType == ast.StatsTypeDependency {
-		return errors.New("Cardinality and Dependency statistics types are not supported now")
-	}
 	_, tbl, err := d.getSchemaAndTableByIdent(ctx, ident)
 	if err != nil {
 		return err
 	}
 	tblInfo := tbl.Meta()
-	if tblInfo.GetPartitionInfo() != nil {
-		return errors.New("Extended statistics on partitioned tables are not supported now")
-	}
 	colIDs := make([]int64, 0, 2)
 	colIDSet := make(map[int64]struct{}, 2)
 	// Check whether columns exist.
@@ -4854,7 +5155,7 @@ func (d *ddl) AlterTableAddStatistics(ctx sessionctx.Context, ident ast.Ident, s
 	if len(colIDs) != 2 && (stats.StatsType == ast.StatsTypeCorrelation || stats.StatsType == ast.StatsTypeDependency) {
 		return errors.New("Only support Correlation and Dependency statistics types on 2 columns")
 	}
-	if len(colIDs) < 1 && stats.StatsType == ast.StatsTypeCardinality {
+	if len(colIDs) < 2 && stats.StatsType == ast.StatsTypeCardinality {
 		return errors.New("Only support Cardinality statistics type on at least 2 columns")
 	}
 	// TODO: check whether covering index exists for cardinality / dependency types.
@@ -4876,7 +5177,18 @@ func (d *ddl) AlterTableDropStatistics(ctx sessionctx.Context, ident ast.Ident,
 	tblInfo := tbl.Meta()
 	// Call utilities of statistics.Handle to modify system tables instead of doing DML directly,
 	// because locking in Handle can guarantee the correctness of `version` in system tables.
-	return d.ddlCtx.statsHandle.MarkExtendedStatsDeleted(stats.StatsName, tblInfo.ID, ifExists)
+	if err = d.ddlCtx.statsHandle.MarkExtendedStatsDeleted(stats.StatsName, tblInfo.ID, ifExists); err != nil {
+		return err
+	}
+	// The partition-level extended stats are built with the same name by ANALYZE, delete them as well.
+	if pi := tblInfo.GetPartitionInfo(); pi != nil {
+		for _, def := range pi.Definitions {
+			if err = d.ddlCtx.statsHandle.MarkExtendedStatsDeleted(stats.StatsName, def.ID, true); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
 }
 
 // UpdateTableReplicaInfo updates the table flash replica infos.
@@ -5298,6 +5610,9 @@ func (d *ddl) CreatePrimaryKey(ctx sessionctx.Context, ti ast.Ident, indexName m
 		return dbterror.ErrUnsupportedModifyPrimaryKey.GenWithStack("Adding clustered primary key is not supported. " +
 			"Please consider adding NONCLUSTERED primary key instead")
 	}
+	if err := checkIndexOptionNotHypo(indexOption); err != nil {
+		return errors.Trace(err)
+	}
 	schema, t, err := d.getSchemaAndTableByIdent(ctx, ti)
 	if err != nil {
 		return errors.Trace(err)
@@ -5464,11 +5779,12 @@ func buildHiddenColumnInfo(ctx sessionctx.Context, indexPartSpecifications []*as
 
 func (d *ddl) CreateIndex(ctx sessionctx.Context, ti ast.Ident, keyType ast.IndexKeyType, indexName model.CIStr,
 	indexPartSpecifications []*ast.IndexPartSpecification, indexOption *ast.IndexOption, ifNotExists bool) error {
-	// not support Spatial and FullText index
-	if keyType == ast.IndexKeyTypeFullText || keyType == ast.IndexKeyTypeSpatial {
-		return dbterror.ErrUnsupportedIndexType.GenWithStack("FULLTEXT and SPATIAL index is not supported")
-	}
 	unique := keyType == ast.IndexKeyTypeUnique
+	fullText := keyType == ast.IndexKeyTypeFullText
+	spatial := keyType == ast.IndexKeyTypeSpatial
+	if err := checkIndexOptionNotHypo(indexOption); err != nil {
+		return errors.Trace(err)
+	}
 	schema, t, err := d.getSchemaAndTableByIdent(ctx, ti)
 	if err != nil {
 		return errors.Trace(err)
@@ -5520,16 +5836,45 @@ func (d *ddl) CreateIndex(ctx sessionctx.Context, ti ast.Ident, keyType ast.Inde
 	finalColumns := make([]*model.ColumnInfo, len(tblInfo.Columns), len(tblInfo.Columns)+len(hiddenCols))
 	copy(finalColumns, tblInfo.Columns)
 	finalColumns = append(finalColumns, hiddenCols...)
+	finalColumns = appendPendingAddedColumns(ctx, finalColumns)
 	// Check before the job is put to the queue.
 	// This check is redundant, but useful. If DDL check fail before the job is put
 	// to job queue, the fail path logic is super fast.
 	// After DDL job is put to the queue, and if the check fail, TiDB will run the DDL cancel logic.
 	// The recover step causes DDL wait a few seconds, makes the unit test painfully slow.
 	// For same reason, decide whether index is global here.
-	indexColumns, err := buildIndexColumns(finalColumns, indexPartSpecifications)
+	var indexColumns []*model.IndexColumn
+	if fullText {
+		indexColumns, err = buildFullTextIndexColumns(finalColumns, indexPartSpecifications)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		var parser string
+		if indexOption != nil {
+			parser = indexOption.ParserName.L
+		}
+		if err = checkFullTextParser(parser); err != nil {
+			return errors.Trace(err)
+		}
+	} else if spatial {
+		indexColumns, err = buildSpatialIndexColumns(finalColumns, indexPartSpecifications)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		warnUselessSpatialIndex(ctx, finalColumns, indexColumns)
+	} else {
+		indexColumns, err = buildIndexColumns(finalColumns, indexPartSpecifications)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	isMVIndex, err := isMultiValuedIndex(finalColumns, indexColumns)
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if isMVIndex && unique {
+		return dbterror.ErrNotSupportedYet.GenWithStackByArgs("unique multi-valued index")
+	}
 
 	global := false
 	if unique && tblInfo.GetPartitionInfo() != nil {
@@ -5563,7 +5908,7 @@ func (d *ddl) CreateIndex(ctx sessionctx.Context, ti ast.Ident, keyType ast.Inde
 			WarningsCount: make(map[errors.ErrorID]int64),
 			Location:      &model.TimeZoneLocation{Name: tzName, Offset: tzOffset},
 		},
-		Args:     []interface{}{unique, indexName, indexPartSpecifications, indexOption, hiddenCols, global},
+		Args:     []interface{}{unique, indexName, indexPartSpecifications, indexOption, hiddenCols, global, fullText, spatial},
 		Priority: ctx.GetSessionVars().DDLReorgPriority,
 	}
 
@@ -5593,9 +5938,10 @@ func buildFKInfo(fkName model.CIStr, keys []*ast.IndexPartSpecification, refer *
 	}
 
 	fkInfo := &model.FKInfo{
-		Name:     fkName,
-		RefTable: refer.Table.Name,
-		Cols:     make([]model.CIStr, len(keys)),
+		Name:      fkName,
+		RefSchema: refer.Table.Schema,
+		RefTable:  refer.Table.Name,
+		Cols:      make([]model.CIStr, len(keys)),
 	}
 
 	for i, key := range keys {
@@ -5718,6 +6064,88 @@ func (d *ddl) DropForeignKey(ctx sessionctx.Context, ti ast.Ident, fkName model.
 	return errors.Trace(err)
 }
 
+func (d *ddl) CreateCheckConstraint(ctx sessionctx.Context, ti ast.Ident, constrName model.CIStr, constr *ast.Constraint) error {
+	schema, t, err := d.getSchemaAndTableByIdent(ctx, ti)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	tblInfo := t.Meta()
+	if constrName.L == "" {
+		constrName = genCheckConstraintName(tblInfo, nil)
+	} else if tblInfo.FindConstraintInfoByName(constrName.L) != nil {
+		return dbterror.ErrCheckConstraintDupName.GenWithStackByArgs(constrName.O)
+	}
+
+	constraintInfo, err := buildCheckConstraintInfo(tblInfo, constrName, constr)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    tblInfo.ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionAddCheckConstraint,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{constraintInfo},
+	}
+
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
+func (d *ddl) DropCheckConstraint(ctx sessionctx.Context, ti ast.Ident, constrName model.CIStr) error {
+	schema, t, err := d.getSchemaAndTableByIdent(ctx, ti)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if t.Meta().FindConstraintInfoByName(constrName.L) == nil {
+		return dbterror.ErrCheckConstraintNotFound.GenWithStackByArgs(constrName.O)
+	}
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    t.Meta().ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionDropCheckConstraint,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{constrName},
+	}
+
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
+func (d *ddl) AlterCheckConstraint(ctx sessionctx.Context, ti ast.Ident, constrName model.CIStr, enforced bool) error {
+	schema, t, err := d.getSchemaAndTableByIdent(ctx, ti)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	constraintInfo := t.Meta().FindConstraintInfoByName(constrName.L)
+	if constraintInfo == nil {
+		return dbterror.ErrCheckConstraintNotFound.GenWithStackByArgs(constrName.O)
+	}
+	// Nothing to do if the enforcement is not changed.
+	if constraintInfo.Enforced == enforced && constraintInfo.State == model.StatePublic {
+		return nil
+	}
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    t.Meta().ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionAlterCheckConstraint,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{constrName, enforced},
+	}
+
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
 func (d *ddl) DropIndex(ctx sessionctx.Context, ti ast.Ident, indexName model.CIStr, ifExists bool) error {
 	is := d.infoCache.GetLatest()
 	schema, ok := is.SchemaByName(ti.Schema)
@@ -5870,7 +6298,7 @@ func isDroppableColumn(multiSchemaChange bool, tblInfo *model.TableInfo, colName
 	if fkInfo := getColumnForeignKeyInfo(colName.L, tblInfo.ForeignKeys); fkInfo != nil {
 		return dbterror.ErrFkColumnCannotDrop.GenWithStackByArgs(colName, fkInfo.Name)
 	}
-	return nil
+	return checkColumnDroppableForCheckConstraint(tblInfo, colName, false)
 }
 
 // validateCommentLength checks comment length of table, column, index and partition.
@@ -6338,6 +6766,155 @@ func (d *ddl) DropSequence(ctx sessionctx.Context, ti ast.Ident, ifExists bool)
 	return errors.Trace(err)
 }
 
+func (d *ddl) CreateProcedure(ctx sessionctx.Context, stmt *ast.CreateProcedureStmt) error {
+	ident := ast.Ident{Schema: stmt.ProcedureName.Schema, Name: stmt.ProcedureName.Name}
+	is := d.GetInfoSchemaWithInterceptor(ctx)
+	schema, exists, err := checkProcedureExists(is, ident.Schema, ident.Name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if exists {
+		err = infoschema.ErrProcedureExists.GenWithStackByArgs("PROCEDURE", ident.Name.O)
+		if stmt.IfNotExists {
+			ctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+
+	procInfo, err := buildProcedureInfo(ctx, stmt, schema)
+	if err != nil {
+		return err
+	}
+	genIDs, err := d.genGlobalIDs(1)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	procInfo.ID = genIDs[0]
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionCreateProcedure,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{procInfo},
+	}
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
+func (d *ddl) DropProcedure(ctx sessionctx.Context, stmt *ast.DropProcedureStmt) error {
+	ident := ast.Ident{Schema: stmt.ProcedureName.Schema, Name: stmt.ProcedureName.Name}
+	is := d.GetInfoSchemaWithInterceptor(ctx)
+	schema, exists, err := checkProcedureExists(is, ident.Schema, ident.Name)
+	if err == nil && !exists {
+		err = infoschema.ErrProcedureNotExists.GenWithStackByArgs("PROCEDURE", ident.Name.O)
+	}
+	if err != nil {
+		if stmt.IfExists {
+			ctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return errors.Trace(err)
+	}
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionDropProcedure,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{ident.Name},
+	}
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
+func (d *ddl) CreateTrigger(ctx sessionctx.Context, stmt *ast.CreateTriggerStmt) error {
+	ident := ast.Ident{Schema: stmt.Table.Schema, Name: stmt.Table.Name}
+	schema, tb, err := d.getSchemaAndTableByIdent(ctx, ident)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if stmt.TriggerName.Schema.L != schema.Name.L {
+		return dbterror.ErrTrgInWrongSchema.GenWithStackByArgs()
+	}
+	if util.IsMemOrSysDB(schema.Name.L) {
+		return dbterror.ErrNoTriggersOnSystemSchema.GenWithStackByArgs()
+	}
+	tblInfo := tb.Meta()
+	if tblInfo.IsView() || tblInfo.IsSequence() || tblInfo.TempTableType != model.TempTableNone {
+		return dbterror.ErrTrgOnViewOrTempTable.GenWithStackByArgs(tblInfo.Name.O)
+	}
+	is := d.GetInfoSchemaWithInterceptor(ctx)
+	_, trigTbl, err := findTriggerTable(is, schema.Name, stmt.TriggerName.Name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if trigTbl != nil {
+		err = infoschema.ErrTriggerExists.GenWithStackByArgs()
+		if stmt.IfNotExists {
+			ctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+
+	trigInfo, err := buildTriggerInfo(ctx, stmt, tblInfo)
+	if err != nil {
+		return err
+	}
+	if _, err = findTriggerPosition(tblInfo, trigInfo, stmt.Order); err != nil {
+		return err
+	}
+	genIDs, err := d.genGlobalIDs(1)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	trigInfo.ID = genIDs[0]
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    tblInfo.ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionCreateTrigger,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{trigInfo, stmt.Order},
+	}
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
+func (d *ddl) DropTrigger(ctx sessionctx.Context, stmt *ast.DropTriggerStmt) error {
+	ident := ast.Ident{Schema: stmt.TriggerName.Schema, Name: stmt.TriggerName.Name}
+	is := d.GetInfoSchemaWithInterceptor(ctx)
+	schema, tblInfo, err := findTriggerTable(is, ident.Schema, ident.Name)
+	if err == nil && tblInfo == nil {
+		err = infoschema.ErrTriggerNotExists.GenWithStackByArgs()
+	}
+	if err != nil {
+		if stmt.IfExists {
+			ctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return errors.Trace(err)
+	}
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    tblInfo.ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionDropTrigger,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{ident.Name},
+	}
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
 func (d *ddl) AlterIndexVisibility(ctx sessionctx.Context, ident ast.Ident, indexName model.CIStr, visibility ast.IndexVisibility) error {
 	schema, tb, err := d.getSchemaAndTableByIdent(ctx, ident)
 	if err != nil {
@@ -6548,6 +7125,9 @@ func removePartitionPlacement(partInfo *model.PartitionInfo) bool {
 			hasPlacementSettings = true
 		}
 	}
+	for i := range partInfo.ParentDefinitions {
+		partInfo.ParentDefinitions[i].PlacementPolicyRef = nil
+	}
 	return hasPlacementSettings
 }
 
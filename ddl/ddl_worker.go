@@ -375,7 +375,8 @@ func (w *worker) updateDDLJob(t *meta.Meta, job *model.Job, meetErr bool) error
 	updateRawArgs := true
 	// If there is an error when running job and the RawArgs hasn't been decoded by DecodeArgs,
 	// so we shouldn't replace RawArgs with the marshaling Args.
-	if meetErr && (job.RawArgs != nil && job.Args == nil) {
+	// The multi-schema change job keeps the args in its sub-jobs, which are always decoded before running.
+	if meetErr && (job.RawArgs != nil && job.Args == nil) && job.MultiSchemaInfo == nil {
 		logutil.Logger(w.logCtx).Info("[ddl] meet something wrong before update DDL job, shouldn't update raw args",
 			zap.String("job", job.String()))
 		updateRawArgs = false
@@ -393,6 +394,31 @@ func (w *worker) deleteRange(ctx context.Context, job *model.Job) error {
 	return errors.Trace(err)
 }
 
+// jobNeedGC checks whether the finished job needs to use delete-range to clean up the data.
+func jobNeedGC(job *model.Job) bool {
+	if job.IsCancelled() {
+		return false
+	}
+	switch job.Type {
+	case model.ActionAddIndex, model.ActionAddPrimaryKey:
+		// After rolling back an AddIndex operation, we need to use delete-range to delete the half-done index data.
+		return job.State == model.JobStateRollbackDone
+	case model.ActionDropSchema, model.ActionDropTable, model.ActionTruncateTable, model.ActionDropIndex, model.ActionDropPrimaryKey,
+		model.ActionDropTablePartition, model.ActionTruncateTablePartition, model.ActionDropColumn, model.ActionDropColumns, model.ActionModifyColumn, model.ActionDropIndexes,
+		model.ActionReorganizePartition, model.ActionAlterTablePartitioning, model.ActionRemovePartitioning:
+		return true
+	case model.ActionMultiSchemaChange:
+		for _, sub := range job.MultiSchemaInfo.SubJobs {
+			proxyJob := sub.ToProxyJob(job)
+			if jobNeedGC(&proxyJob) {
+				return true
+			}
+		}
+		return false
+	}
+	return false
+}
+
 // finishDDLJob deletes the finished DDL job in the ddl queue and puts it to history queue.
 // If the DDL job need to handle in background, it will prepare a background job.
 func (w *worker) finishDDLJob(t *meta.Meta, job *model.Job) (err error) {
@@ -401,19 +427,8 @@ func (w *worker) finishDDLJob(t *meta.Meta, job *model.Job) (err error) {
 		metrics.DDLWorkerHistogram.WithLabelValues(metrics.WorkerFinishDDLJob, job.Type.String(), metrics.RetLabel(err)).Observe(time.Since(startTime).Seconds())
 	}()
 
-	if !job.IsCancelled() {
-		switch job.Type {
-		case model.ActionAddIndex, model.ActionAddPrimaryKey:
-			if job.State != model.JobStateRollbackDone {
-				break
-			}
-
-			// After rolling back an AddIndex operation, we need to use delete-range to delete the half-done index data.
-			err = w.deleteRange(w.ddlJobCtx, job)
-		case model.ActionDropSchema, model.ActionDropTable, model.ActionTruncateTable, model.ActionDropIndex, model.ActionDropPrimaryKey,
-			model.ActionDropTablePartition, model.ActionTruncateTablePartition, model.ActionDropColumn, model.ActionDropColumns, model.ActionModifyColumn, model.ActionDropIndexes:
-			err = w.deleteRange(w.ddlJobCtx, job)
-		}
+	if jobNeedGC(job) {
+		err = w.deleteRange(w.ddlJobCtx, job)
 	}
 
 	switch job.Type {
@@ -789,6 +804,14 @@ func (w *worker) runDDLJob(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64,
 		ver, err = onDropSchema(d, t, job)
 	case model.ActionModifySchemaDefaultPlacement:
 		ver, err = onModifySchemaDefaultPlacement(t, job)
+	case model.ActionCreateProcedure:
+		ver, err = onCreateProcedure(t, job)
+	case model.ActionDropProcedure:
+		ver, err = onDropProcedure(t, job)
+	case model.ActionCreateTrigger:
+		ver, err = onCreateTrigger(t, job)
+	case model.ActionDropTrigger:
+		ver, err = onDropTrigger(t, job)
 	case model.ActionCreateTable:
 		ver, err = onCreateTable(d, t, job)
 	case model.ActionCreateTables:
@@ -831,6 +854,12 @@ func (w *worker) runDDLJob(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64,
 		ver, err = onCreateForeignKey(t, job)
 	case model.ActionDropForeignKey:
 		ver, err = onDropForeignKey(t, job)
+	case model.ActionAddCheckConstraint:
+		ver, err = onAddCheckConstraint(w, t, job)
+	case model.ActionDropCheckConstraint:
+		ver, err = onDropCheckConstraint(t, job)
+	case model.ActionAlterCheckConstraint:
+		ver, err = onAlterCheckConstraint(w, t, job)
 	case model.ActionTruncateTable:
 		ver, err = onTruncateTable(d, t, job)
 	case model.ActionRebaseAutoID:
@@ -847,6 +876,8 @@ func (w *worker) runDDLJob(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64,
 		ver, err = onModifyTableAutoIDCache(t, job)
 	case model.ActionAddTablePartition:
 		ver, err = w.onAddTablePartition(d, t, job)
+	case model.ActionReorganizePartition, model.ActionAlterTablePartitioning, model.ActionRemovePartitioning:
+		ver, err = w.onReorganizePartition(d, t, job)
 	case model.ActionModifyTableCharsetAndCollate:
 		ver, err = onModifyTableCharsetAndCollate(t, job)
 	case model.ActionRecoverTable:
@@ -885,6 +916,8 @@ func (w *worker) runDDLJob(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64,
 		ver, err = onAlterCacheTable(t, job)
 	case model.ActionAlterNoCacheTable:
 		ver, err = onAlterNoCacheTable(t, job)
+	case model.ActionMultiSchemaChange:
+		ver, err = onMultiSchemaChange(w, d, t, job)
 	default:
 		// Invalid job, cancel it.
 		job.State = model.JobStateCancelled
@@ -297,7 +297,7 @@ func insertJobIntoDeleteRangeTable(ctx context.Context, sctx sessionctx.Context,
 		startKey = tablecodec.EncodeTablePrefix(tableID)
 		endKey := tablecodec.EncodeTablePrefix(tableID + 1)
 		return doInsert(ctx, s, job.ID, tableID, startKey, endKey, now)
-	case model.ActionDropTablePartition, model.ActionTruncateTablePartition:
+	case model.ActionDropTablePartition, model.ActionTruncateTablePartition, model.ActionReorganizePartition:
 		var physicalTableIDs []int64
 		if err := job.DecodeArgs(&physicalTableIDs); err != nil {
 			return errors.Trace(err)
@@ -309,6 +309,31 @@ func insertJobIntoDeleteRangeTable(ctx context.Context, sctx sessionctx.Context,
 				return errors.Trace(err)
 			}
 		}
+	case model.ActionAlterTablePartitioning, model.ActionRemovePartitioning:
+		tableID := job.TableID
+		var physicalTableIDs, indexIDs []int64
+		if err := job.DecodeArgs(&physicalTableIDs, &indexIDs); err != nil {
+			return errors.Trace(err)
+		}
+		for _, physicalTableID := range physicalTableIDs {
+			startKey := tablecodec.EncodeTablePrefix(physicalTableID)
+			endKey := tablecodec.EncodeTablePrefix(physicalTableID + 1)
+			if physicalTableID == tableID {
+				// The indexes of the table are kept, they are deleted by the index IDs.
+				startKey = tablecodec.GenTableRecordPrefix(tableID)
+				endKey = startKey.PrefixNext()
+			}
+			if err := doInsert(ctx, s, job.ID, physicalTableID, startKey, endKey, now); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		for _, indexID := range indexIDs {
+			startKey := tablecodec.EncodeTableIndexPrefix(tableID, indexID)
+			endKey := tablecodec.EncodeTableIndexPrefix(tableID, indexID+1)
+			if err := doInsert(ctx, s, job.ID, indexID, startKey, endKey, now); err != nil {
+				return errors.Trace(err)
+			}
+		}
 	// ActionAddIndex, ActionAddPrimaryKey needs do it, because it needs to be rolled back when it's canceled.
 	case model.ActionAddIndex, model.ActionAddPrimaryKey:
 		tableID := job.TableID
@@ -423,6 +448,16 @@ func insertJobIntoDeleteRangeTable(ctx context.Context, sctx sessionctx.Context,
 				return errors.Trace(err)
 			}
 		}
+	case model.ActionMultiSchemaChange:
+		for _, sub := range job.MultiSchemaInfo.SubJobs {
+			proxyJob := sub.ToProxyJob(job)
+			if !jobNeedGC(&proxyJob) {
+				continue
+			}
+			if err := insertJobIntoDeleteRangeTable(ctx, sctx, &proxyJob); err != nil {
+				return errors.Trace(err)
+			}
+		}
 	}
 	return nil
 }
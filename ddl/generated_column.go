@@ -273,7 +273,12 @@ type illegalFunctionChecker struct {
 	hasRowVal            bool // hasRowVal checks whether the functional index refers to a row value
 	hasWindowFunc        bool
 	hasNotGAFunc4ExprIdx bool
+	hasVariable          bool
+	illegalFuncName      string
 	otherErr             error
+	// arrayCastDepth is the number of CAST(... AS ... ARRAY) expressions being visited, the JSON_EXTRACT
+	// inside them is the key part of a multi-valued index, rather than an expression index on its own.
+	arrayCastDepth int
 }
 
 func (c *illegalFunctionChecker) Enter(inNode ast.Node) (outNode ast.Node, skipChildren bool) {
@@ -283,6 +288,7 @@ func (c *illegalFunctionChecker) Enter(inNode ast.Node) (outNode ast.Node, skipC
 		_, IsFunctionBlocked := expression.IllegalFunctions4GeneratedColumns[node.FnName.L]
 		if IsFunctionBlocked || !expression.IsFunctionSupported(node.FnName.L) {
 			c.hasIllegalFunc = true
+			c.illegalFuncName = node.FnName.O
 			return inNode, true
 		}
 		err := expression.VerifyArgsWrapper(node.FnName.L, len(node.Args))
@@ -291,12 +297,17 @@ func (c *illegalFunctionChecker) Enter(inNode ast.Node) (outNode ast.Node, skipC
 			return inNode, true
 		}
 		_, isFuncGA := variable.GAFunction4ExpressionIndex[node.FnName.L]
-		if !isFuncGA {
+		if !isFuncGA && (c.arrayCastDepth == 0 || node.FnName.L != ast.JSONExtract) {
 			c.hasNotGAFunc4ExprIdx = true
 		}
+	case *ast.FuncCastExpr:
+		if node.Tp.Array {
+			c.arrayCastDepth++
+		}
 	case *ast.SubqueryExpr, *ast.ValuesExpr, *ast.VariableExpr:
 		// Subquery & `values(x)` & variable is not allowed
 		c.hasIllegalFunc = true
+		_, c.hasVariable = node.(*ast.VariableExpr)
 		return inNode, true
 	case *ast.AggregateFuncExpr:
 		// Aggregate function is not allowed
@@ -313,6 +324,9 @@ func (c *illegalFunctionChecker) Enter(inNode ast.Node) (outNode ast.Node, skipC
 }
 
 func (c *illegalFunctionChecker) Leave(inNode ast.Node) (node ast.Node, ok bool) {
+	if node, ok := inNode.(*ast.FuncCastExpr); ok && node.Tp.Array {
+		c.arrayCastDepth--
+	}
 	return inNode, true
 }
 
@@ -40,6 +40,7 @@ import (
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util"
 	"github.com/pingcap/tidb/util/dbterror"
+	"github.com/pingcap/tidb/util/fulltext"
 	"github.com/pingcap/tidb/util/logutil"
 	decoder "github.com/pingcap/tidb/util/rowDecoder"
 	"github.com/prometheus/client_golang/prometheus"
@@ -231,15 +232,208 @@ func buildIndexInfo(tblInfo *model.TableInfo, indexName model.CIStr, indexPartSp
 		return nil, errors.Trace(err)
 	}
 
+	isMVIndex, err := isMultiValuedIndex(tblInfo.Columns, idxColumns)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
 	// Create index info.
 	idxInfo := &model.IndexInfo{
 		Name:    indexName,
 		Columns: idxColumns,
 		State:   state,
+		MVIndex: isMVIndex,
+	}
+	return idxInfo, nil
+}
+
+// buildFullTextIndexInfo builds the info of a FULLTEXT index, which is an inverted index from the tokens of
+// the indexed columns to the rows containing them.
+func buildFullTextIndexInfo(tblInfo *model.TableInfo, indexName model.CIStr, indexPartSpecifications []*ast.IndexPartSpecification,
+	indexOption *ast.IndexOption, state model.SchemaState) (*model.IndexInfo, error) {
+	if err := checkTooLongIndex(indexName); err != nil {
+		return nil, errors.Trace(err)
+	}
+	idxColumns, err := buildFullTextIndexColumns(tblInfo.Columns, indexPartSpecifications)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var parser string
+	if indexOption != nil {
+		parser = indexOption.ParserName.L
+	}
+	if err = checkFullTextParser(parser); err != nil {
+		return nil, errors.Trace(err)
+	}
+	idxInfo := &model.IndexInfo{
+		Name:           indexName,
+		Columns:        idxColumns,
+		State:          state,
+		FullText:       true,
+		FullTextParser: parser,
 	}
 	return idxInfo, nil
 }
 
+// buildFullTextIndexColumns builds the columns of a FULLTEXT index, which can only be built on the non-binary
+// CHAR, VARCHAR and TEXT columns. The prefix lengths are ignored since the index stores the tokens of the values.
+func buildFullTextIndexColumns(columns []*model.ColumnInfo, indexPartSpecifications []*ast.IndexPartSpecification) ([]*model.IndexColumn, error) {
+	idxParts := make([]*model.IndexColumn, 0, len(indexPartSpecifications))
+	for _, ip := range indexPartSpecifications {
+		if ip.Expr != nil {
+			return nil, dbterror.ErrFulltextFunctionalIndex
+		}
+		col := model.FindColumnInfo(columns, ip.Column.Name.L)
+		if col == nil {
+			return nil, dbterror.ErrKeyColumnDoesNotExits.GenWithStack("column does not exist: %s", ip.Column.Name)
+		}
+		if err := checkFullTextIndexColumn(col); err != nil {
+			return nil, err
+		}
+		idxParts = append(idxParts, &model.IndexColumn{
+			Name:   col.Name,
+			Offset: col.Offset,
+			Length: types.UnspecifiedLength,
+		})
+	}
+	return idxParts, nil
+}
+
+// checkFullTextIndexColumn checks whether the column can be a part of FULLTEXT index.
+func checkFullTextIndexColumn(col *model.ColumnInfo) error {
+	if col.Hidden {
+		// The hidden column is generated from the expression key part.
+		return dbterror.ErrFulltextFunctionalIndex
+	}
+	isText := types.IsTypeChar(col.Tp) || types.IsTypeVarchar(col.Tp) || types.IsTypeBlob(col.Tp)
+	if !isText || col.Charset == charset.CharsetBin {
+		return dbterror.ErrBadFtColumn.GenWithStackByArgs(col.Name.O)
+	}
+	return nil
+}
+
+// checkFullTextParser checks whether the parser of the FULLTEXT index is supported.
+func checkFullTextParser(parser string) error {
+	if _, err := fulltext.NewTokenizer(parser); err != nil {
+		return dbterror.ErrUnsupportedIndexType.GenWithStack("FULLTEXT parser %s is not supported", parser)
+	}
+	return nil
+}
+
+// BuildHypoIndexInfo builds the info of a hypothetical index, which is only seen by the optimizer of the session
+// creating it. The index can't be built on the expressions, which require the hidden columns in the table.
+func BuildHypoIndexInfo(tblInfo *model.TableInfo, indexName model.CIStr, unique bool, indexPartSpecifications []*ast.IndexPartSpecification) (*model.IndexInfo, error) {
+	for _, ip := range indexPartSpecifications {
+		if ip.Expr != nil {
+			return nil, dbterror.ErrUnsupportedIndexType.GenWithStack("hypothetical index on expressions is not supported")
+		}
+	}
+	idxInfo, err := buildIndexInfo(tblInfo, indexName, indexPartSpecifications, model.StatePublic)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if idxInfo.MVIndex {
+		return nil, dbterror.ErrUnsupportedIndexType.GenWithStack("hypothetical multi-valued index is not supported")
+	}
+	idxInfo.Table = tblInfo.Name
+	idxInfo.Unique = unique
+	idxInfo.Tp = model.IndexTypeHypo
+	return idxInfo, nil
+}
+
+// checkIndexOptionNotHypo checks the index isn't a hypothetical index, which can only be created by CREATE INDEX
+// and never stored in the table.
+func checkIndexOptionNotHypo(indexOption *ast.IndexOption) error {
+	if indexOption != nil && indexOption.Tp == model.IndexTypeHypo {
+		return dbterror.ErrUnsupportedIndexType.GenWithStack("hypothetical index can only be created by CREATE INDEX")
+	}
+	return nil
+}
+
+// buildSpatialIndexInfo builds the info of a SPATIAL index, which indexes the rows by the cells covering
+// the geometries.
+func buildSpatialIndexInfo(tblInfo *model.TableInfo, indexName model.CIStr, indexPartSpecifications []*ast.IndexPartSpecification,
+	state model.SchemaState) (*model.IndexInfo, error) {
+	if err := checkTooLongIndex(indexName); err != nil {
+		return nil, errors.Trace(err)
+	}
+	idxColumns, err := buildSpatialIndexColumns(tblInfo.Columns, indexPartSpecifications)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	idxInfo := &model.IndexInfo{
+		Name:    indexName,
+		Columns: idxColumns,
+		State:   state,
+		Spatial: true,
+	}
+	return idxInfo, nil
+}
+
+// buildSpatialIndexColumns builds the column of a SPATIAL index, which can only be built on a single NOT NULL
+// geometry column.
+func buildSpatialIndexColumns(columns []*model.ColumnInfo, indexPartSpecifications []*ast.IndexPartSpecification) ([]*model.IndexColumn, error) {
+	if len(indexPartSpecifications) != 1 {
+		return nil, dbterror.ErrTooManyKeyParts.GenWithStackByArgs(1)
+	}
+	ip := indexPartSpecifications[0]
+	if ip.Expr != nil {
+		return nil, dbterror.ErrSpatialFunctionalIndex
+	}
+	col := model.FindColumnInfo(columns, ip.Column.Name.L)
+	if col == nil {
+		return nil, dbterror.ErrKeyColumnDoesNotExits.GenWithStack("column does not exist: %s", ip.Column.Name)
+	}
+	if err := checkSpatialIndexColumn(col); err != nil {
+		return nil, err
+	}
+	return []*model.IndexColumn{{
+		Name:   col.Name,
+		Offset: col.Offset,
+		Length: types.UnspecifiedLength,
+	}}, nil
+}
+
+// checkSpatialIndexColumn checks whether the column can be the column of SPATIAL index.
+func checkSpatialIndexColumn(col *model.ColumnInfo) error {
+	if col.Hidden {
+		// The hidden column is generated from the expression key part.
+		return dbterror.ErrSpatialFunctionalIndex
+	}
+	if col.Tp != mysql.TypeGeometry {
+		return dbterror.ErrSpatialMustHaveGeomCol
+	}
+	if !mysql.HasNotNullFlag(col.Flag) {
+		return dbterror.ErrSpatialCantHaveNull
+	}
+	return nil
+}
+
+// warnUselessSpatialIndex appends a warning if the SPATIAL index can't be used by the optimizer.
+func warnUselessSpatialIndex(ctx sessionctx.Context, columns []*model.ColumnInfo, idxColumns []*model.IndexColumn) {
+	col := model.FindColumnInfo(columns, idxColumns[0].Name.L)
+	if col.Srid == nil {
+		ctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrWarnUselessSpatialIndex.GenWithStackByArgs(col.Name.O))
+	}
+}
+
+// isMultiValuedIndex checks whether the index is a multi-valued index, which has a key part of
+// CAST(... AS ... ARRAY). Only one such key part is allowed in an index.
+func isMultiValuedIndex(columns []*model.ColumnInfo, idxColumns []*model.IndexColumn) (bool, error) {
+	isMVIndex := false
+	for _, idxCol := range idxColumns {
+		col := model.FindColumnInfo(columns, idxCol.Name.L)
+		if col == nil || !col.FieldType.Array {
+			continue
+		}
+		if isMVIndex {
+			return false, dbterror.ErrNotSupportedYet.GenWithStackByArgs("more than one multi-valued key part per index")
+		}
+		isMVIndex = true
+	}
+	return isMVIndex, nil
+}
+
 func addIndexColumnFlag(tblInfo *model.TableInfo, indexInfo *model.IndexInfo) {
 	if indexInfo.Primary {
 		for _, col := range indexInfo.Columns {
@@ -307,6 +501,10 @@ func onRenameIndex(t *meta.Meta, job *model.Job) (ver int64, _ error) {
 	if tblInfo.TableCacheStatusType != model.TableCacheStatusDisable {
 		return ver, errors.Trace(dbterror.ErrOptOnCacheTable.GenWithStackByArgs("Rename Index"))
 	}
+	if job.MultiSchemaInfo != nil && job.MultiSchemaInfo.Revertible {
+		job.MarkNonRevertible()
+		return ver, nil
+	}
 
 	idx := tblInfo.FindIndexByName(from.L)
 	idx.Name = to
@@ -332,6 +530,10 @@ func onAlterIndexVisibility(t *meta.Meta, job *model.Job) (ver int64, _ error) {
 	if err != nil || tblInfo == nil {
 		return ver, errors.Trace(err)
 	}
+	if job.MultiSchemaInfo != nil && job.MultiSchemaInfo.Revertible {
+		job.MarkNonRevertible()
+		return ver, nil
+	}
 	idx := tblInfo.FindIndexByName(from.L)
 	idx.Invisible = invisible
 	if ver, err = updateVersionAndTableInfoWithCheck(t, job, tblInfo, true); err != nil {
@@ -418,12 +620,14 @@ func (w *worker) onCreateIndex(d *ddlCtx, t *meta.Meta, job *model.Job, isPK boo
 		sqlMode                 mysql.SQLMode
 		warnings                []string
 		hiddenCols              []*model.ColumnInfo
+		fullText                bool
+		spatial                 bool
 	)
 	if isPK {
 		// Notice: sqlMode and warnings is used to support non-strict mode.
 		err = job.DecodeArgs(&unique, &indexName, &indexPartSpecifications, &indexOption, &sqlMode, &warnings, &global)
 	} else {
-		err = job.DecodeArgs(&unique, &indexName, &indexPartSpecifications, &indexOption, &hiddenCols, &global)
+		err = job.DecodeArgs(&unique, &indexName, &indexPartSpecifications, &indexOption, &hiddenCols, &global, &fullText, &spatial)
 	}
 	if err != nil {
 		job.State = model.JobStateCancelled
@@ -467,7 +671,13 @@ func (w *worker) onCreateIndex(d *ddlCtx, t *meta.Meta, job *model.Job, isPK boo
 			job.State = model.JobStateCancelled
 			return ver, errors.Trace(err)
 		}
-		indexInfo, err = buildIndexInfo(tblInfo, indexName, indexPartSpecifications, model.StateNone)
+		if fullText {
+			indexInfo, err = buildFullTextIndexInfo(tblInfo, indexName, indexPartSpecifications, indexOption, model.StateNone)
+		} else if spatial {
+			indexInfo, err = buildSpatialIndexInfo(tblInfo, indexName, indexPartSpecifications, model.StateNone)
+		} else {
+			indexInfo, err = buildIndexInfo(tblInfo, indexName, indexPartSpecifications, model.StateNone)
+		}
 		if err != nil {
 			job.State = model.JobStateCancelled
 			return ver, errors.Trace(err)
@@ -552,44 +762,53 @@ func (w *worker) onCreateIndex(d *ddlCtx, t *meta.Meta, job *model.Job, isPK boo
 		job.SchemaState = model.StateWriteReorganization
 	case model.StateWriteReorganization:
 		// reorganization -> public
-		tbl, err := getTable(d.store, schemaID, tblInfo)
-		if err != nil {
-			return ver, errors.Trace(err)
-		}
-
-		elements := []*meta.Element{{ID: indexInfo.ID, TypeKey: meta.IndexElementKey}}
-		reorgInfo, err := getReorgInfo(d, t, job, tbl, elements)
-		if err != nil || reorgInfo.first {
-			// If we run reorg firstly, we should update the job snapshot version
-			// and then run the reorg next time.
-			return ver, errors.Trace(err)
-		}
+		// The reorganization of a non-revertible sub-job of the multi-schema change has been done.
+		if job.MultiSchemaInfo == nil || job.MultiSchemaInfo.Revertible {
+			tbl, err := getTable(d.store, schemaID, tblInfo)
+			if err != nil {
+				return ver, errors.Trace(err)
+			}
 
-		err = w.runReorgJob(t, reorgInfo, tbl.Meta(), d.lease, func() (addIndexErr error) {
-			defer util.Recover(metrics.LabelDDL, "onCreateIndex",
-				func() {
-					addIndexErr = dbterror.ErrCancelledDDLJob.GenWithStack("add table `%v` index `%v` panic", tblInfo.Name, indexInfo.Name)
-				}, false)
-			return w.addTableIndex(tbl, indexInfo, reorgInfo)
-		})
-		if err != nil {
-			if dbterror.ErrWaitReorgTimeout.Equal(err) {
-				// if timeout, we should return, check for the owner and re-wait job done.
-				return ver, nil
+			elements := []*meta.Element{{ID: indexInfo.ID, TypeKey: meta.IndexElementKey}}
+			reorgInfo, err := getReorgInfo(d, t, job, tbl, elements)
+			if err != nil || reorgInfo.first {
+				// If we run reorg firstly, we should update the job snapshot version
+				// and then run the reorg next time.
+				return ver, errors.Trace(err)
 			}
-			if kv.ErrKeyExists.Equal(err) || dbterror.ErrCancelledDDLJob.Equal(err) || dbterror.ErrCantDecodeRecord.Equal(err) {
-				logutil.BgLogger().Warn("[ddl] run add index job failed, convert job to rollback", zap.String("job", job.String()), zap.Error(err))
-				ver, err = convertAddIdxJob2RollbackJob(t, job, tblInfo, indexInfo, err)
-				if err1 := t.RemoveDDLReorgHandle(job, reorgInfo.elements); err1 != nil {
-					logutil.BgLogger().Warn("[ddl] run add index job failed, convert job to rollback, RemoveDDLReorgHandle failed", zap.String("job", job.String()), zap.Error(err1))
+
+			err = w.runReorgJob(t, reorgInfo, tbl.Meta(), d.lease, func() (addIndexErr error) {
+				defer util.Recover(metrics.LabelDDL, "onCreateIndex",
+					func() {
+						addIndexErr = dbterror.ErrCancelledDDLJob.GenWithStack("add table `%v` index `%v` panic", tblInfo.Name, indexInfo.Name)
+					}, false)
+				return w.addTableIndex(tbl, indexInfo, reorgInfo)
+			})
+			if err != nil {
+				if dbterror.ErrWaitReorgTimeout.Equal(err) {
+					// if timeout, we should return, check for the owner and re-wait job done.
+					return ver, nil
 				}
+				if kv.ErrKeyExists.Equal(err) || dbterror.ErrCancelledDDLJob.Equal(err) || dbterror.ErrCantDecodeRecord.Equal(err) {
+					logutil.BgLogger().Warn("[ddl] run add index job failed, convert job to rollback", zap.String("job", job.String()), zap.Error(err))
+					ver, err = convertAddIdxJob2RollbackJob(t, job, tblInfo, indexInfo, err)
+					if err1 := t.RemoveDDLReorgHandle(job, reorgInfo.elements); err1 != nil {
+						logutil.BgLogger().Warn("[ddl] run add index job failed, convert job to rollback, RemoveDDLReorgHandle failed", zap.String("job", job.String()), zap.Error(err1))
+					}
+				}
+				// Clean up the channel of notifyCancelReorgJob. Make sure it can't affect other jobs.
+				w.reorgCtx.cleanNotifyReorgCancel()
+				return ver, errors.Trace(err)
 			}
 			// Clean up the channel of notifyCancelReorgJob. Make sure it can't affect other jobs.
 			w.reorgCtx.cleanNotifyReorgCancel()
-			return ver, errors.Trace(err)
+
+			if job.MultiSchemaInfo != nil {
+				// Wait for the other sub-jobs of the multi-schema change before making the index public.
+				job.MarkNonRevertible()
+				return ver, nil
+			}
 		}
-		// Clean up the channel of notifyCancelReorgJob. Make sure it can't affect other jobs.
-		w.reorgCtx.cleanNotifyReorgCancel()
 
 		indexInfo.State = model.StatePublic
 		// Set column index flag.
@@ -620,6 +839,12 @@ func onDropIndex(t *meta.Meta, job *model.Job) (ver int64, _ error) {
 	if tblInfo.TableCacheStatusType != model.TableCacheStatusDisable {
 		return ver, errors.Trace(dbterror.ErrOptOnCacheTable.GenWithStackByArgs("Drop Index"))
 	}
+	if job.MultiSchemaInfo != nil && job.MultiSchemaInfo.Revertible && !job.IsRollingback() {
+		// The index is still public, wait for the other sub-jobs of the multi-schema change.
+		job.MarkNonRevertible()
+		job.SchemaState = indexInfo.State
+		return ver, nil
+	}
 
 	dependentHiddenCols := make([]*model.ColumnInfo, 0)
 	for _, indexColumn := range indexInfo.Columns {
@@ -1088,7 +1313,12 @@ func (w *baseIndexWorker) getIndexRecord(idxInfo *model.IndexInfo, handle kv.Han
 			idxVal[j] = idxColumnVal
 			continue
 		}
-		idxColumnVal, err = tables.GetColDefaultValue(w.sessCtx, col, w.defaultVals)
+		if col.State != model.StatePublic && col.ChangeStateInfo == nil {
+			// The column is added by the same multi-schema change, the old rows take its origin default value.
+			idxColumnVal, err = table.GetColOriginDefaultValue(w.sessCtx, col.ToInfo())
+		} else {
+			idxColumnVal, err = tables.GetColDefaultValue(w.sessCtx, col, w.defaultVals)
+		}
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
@@ -1575,13 +1805,14 @@ func (w *worker) updateReorgInfoForPartitions(t table.PartitionedTable, reorg *r
 	}
 
 	var pid int64
-	for i, pi := range partitionIDs {
-		if pi == reorg.PhysicalTableID {
+	for i, id := range partitionIDs {
+		if id == reorg.PhysicalTableID {
 			if i == len(partitionIDs)-1 {
 				return true, nil
 			}
+			pid = partitionIDs[i+1]
+			break
 		}
-		pid = partitionIDs[i+1]
 	}
 
 	currentVer, err := getValidCurrentVersion(reorg.d.store)
@@ -0,0 +1,357 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"github.com/pingcap/errors"
+	ddlutil "github.com/pingcap/tidb/ddl/util"
+	"github.com/pingcap/tidb/meta"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/terror"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/util/dbterror"
+)
+
+// multiSchemaChange merges the sub-jobs collected by the specs of an ALTER TABLE statement
+// into one ActionMultiSchemaChange job and runs it.
+func (d *ddl) multiSchemaChange(ctx sessionctx.Context, ti ast.Ident) error {
+	info := ctx.GetSessionVars().StmtCtx.MultiSchemaInfo
+	// Reset the statement context so that the merged job can be put into the queue.
+	ctx.GetSessionVars().StmtCtx.MultiSchemaInfo = nil
+	if info == nil || len(info.SubJobs) == 0 {
+		return nil
+	}
+	schema, t, err := d.getSchemaAndTableByIdent(ctx, ti)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err = checkMultiSchemaInfo(info, t); err != nil {
+		return errors.Trace(err)
+	}
+
+	tzName, tzOffset := ddlutil.GetTimeZone(ctx)
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    t.Meta().ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionMultiSchemaChange,
+		BinlogInfo: &model.HistoryInfo{},
+		ReorgMeta: &model.DDLReorgMeta{
+			SQLMode:       ctx.GetSessionVars().SQLMode,
+			Warnings:      make(map[errors.ErrorID]*terror.Error),
+			WarningsCount: make(map[errors.ErrorID]int64),
+			Location:      &model.TimeZoneLocation{Name: tzName, Offset: tzOffset},
+		},
+		MultiSchemaInfo: info,
+		Priority:        ctx.GetSessionVars().DDLReorgPriority,
+	}
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
+// appendToSubJobs appends the job as a sub-job of the multi-schema change instead of running it.
+func appendToSubJobs(info *model.MultiSchemaInfo, job *model.Job) error {
+	if err := fillMultiSchemaInfo(info, job); err != nil {
+		return errors.Trace(err)
+	}
+	info.SubJobs = append(info.SubJobs, &model.SubJob{
+		Type:       job.Type,
+		Args:       job.Args,
+		RawArgs:    job.RawArgs,
+		Revertible: true,
+		CtxVars:    job.CtxVars,
+	})
+	return nil
+}
+
+// appendPendingAddedColumns appends the columns added by the collected sub-jobs, so that the
+// following specs of the same multi-schema change can refer to them.
+func appendPendingAddedColumns(ctx sessionctx.Context, cols []*model.ColumnInfo) []*model.ColumnInfo {
+	info := ctx.GetSessionVars().StmtCtx.MultiSchemaInfo
+	if info == nil {
+		return cols
+	}
+	for _, sub := range info.SubJobs {
+		if sub.Type == model.ActionAddColumn {
+			cols = append(cols, sub.Args[0].(*table.Column).ColumnInfo)
+		}
+	}
+	return cols
+}
+
+// fillMultiSchemaInfo records the columns and indexes changed by the job, they are used to check the conflicts
+// between the sub-jobs. It returns an error if the job can't be a sub-job of a multi-schema change.
+func fillMultiSchemaInfo(info *model.MultiSchemaInfo, job *model.Job) error {
+	switch job.Type {
+	case model.ActionAddColumn:
+		col := job.Args[0].(*table.Column)
+		pos := job.Args[1].(*ast.ColumnPosition)
+		info.AddColumns = append(info.AddColumns, col.Name)
+		if pos != nil && pos.Tp == ast.ColumnPositionAfter {
+			info.PositionColumns = append(info.PositionColumns, pos.RelativeColumn.Name)
+		}
+	case model.ActionDropColumn:
+		colName := job.Args[0].(model.CIStr)
+		info.DropColumns = append(info.DropColumns, colName)
+	case model.ActionModifyColumn:
+		if mayNeedReorg(job) {
+			return dbterror.ErrRunMultiSchemaChanges.GenWithStack("Unsupported multi schema change for modify column with reorganizing data")
+		}
+		var newColName model.CIStr
+		switch newCol := job.Args[0].(type) {
+		case **table.Column:
+			newColName = (*newCol).Name
+		case **model.ColumnInfo:
+			newColName = (*newCol).Name
+		}
+		oldColName := job.Args[1].(model.CIStr)
+		info.ModifyColumns = append(info.ModifyColumns, oldColName)
+		if newColName.L != oldColName.L {
+			info.ModifyColumns = append(info.ModifyColumns, newColName)
+		}
+		if pos := job.Args[2].(*ast.ColumnPosition); pos != nil && pos.Tp == ast.ColumnPositionAfter {
+			info.PositionColumns = append(info.PositionColumns, pos.RelativeColumn.Name)
+		}
+	case model.ActionSetDefaultValue:
+		col := job.Args[0].(*table.Column)
+		info.ModifyColumns = append(info.ModifyColumns, col.Name)
+	case model.ActionAddIndex, model.ActionAddPrimaryKey:
+		if job.Type == model.ActionAddIndex {
+			if hiddenCols := job.Args[4].([]*model.ColumnInfo); len(hiddenCols) > 0 {
+				return dbterror.ErrRunMultiSchemaChanges.GenWithStack("Unsupported multi schema change for expression index")
+			}
+		}
+		indexName := job.Args[1].(model.CIStr)
+		info.AddIndexes = append(info.AddIndexes, indexName)
+		for _, spec := range job.Args[2].([]*ast.IndexPartSpecification) {
+			if spec.Column != nil {
+				info.RelativeColumns = append(info.RelativeColumns, spec.Column.Name)
+			}
+		}
+	case model.ActionDropIndex, model.ActionDropPrimaryKey:
+		indexName := job.Args[0].(model.CIStr)
+		info.DropIndexes = append(info.DropIndexes, indexName)
+	case model.ActionRenameIndex:
+		from := job.Args[0].(model.CIStr)
+		to := job.Args[1].(model.CIStr)
+		info.AlterIndexes = append(info.AlterIndexes, from, to)
+	case model.ActionAlterIndexVisibility:
+		indexName := job.Args[0].(model.CIStr)
+		info.AlterIndexes = append(info.AlterIndexes, indexName)
+	default:
+		return dbterror.ErrRunMultiSchemaChanges.GenWithStack("Unsupported multi schema change for %s", job.Type.String())
+	}
+	return nil
+}
+
+// checkMultiSchemaInfo checks the conflicts between the sub-jobs before the job is put into the queue.
+func checkMultiSchemaInfo(info *model.MultiSchemaInfo, t table.Table) error {
+	// The single-column indexes are dropped with the columns.
+	for _, colName := range info.DropColumns {
+		for _, idx := range listIndicesWithColumn(colName.L, t.Meta().Indices) {
+			info.DropIndexes = append(info.DropIndexes, idx.Name)
+		}
+	}
+
+	changedCols := make(map[string]struct{})
+	checkColumns := func(colNames []model.CIStr, addToChanged bool) error {
+		for _, colName := range colNames {
+			if _, ok := changedCols[colName.L]; ok {
+				return dbterror.ErrOperateSameColumn.GenWithStackByArgs(colName.O)
+			}
+			if addToChanged {
+				changedCols[colName.L] = struct{}{}
+			}
+		}
+		return nil
+	}
+	if err := checkColumns(info.DropColumns, true); err != nil {
+		return err
+	}
+	if err := checkColumns(info.ModifyColumns, true); err != nil {
+		return err
+	}
+	// The new indexes can be built on the added columns, but not on the dropped or modified ones.
+	if err := checkColumns(info.RelativeColumns, false); err != nil {
+		return err
+	}
+	if err := checkColumns(info.AddColumns, true); err != nil {
+		return err
+	}
+	if err := checkColumns(info.PositionColumns, false); err != nil {
+		return err
+	}
+
+	changedIdxes := make(map[string]struct{})
+	for _, idxNames := range [][]model.CIStr{info.AddIndexes, info.DropIndexes, info.AlterIndexes} {
+		for _, idxName := range idxNames {
+			if _, ok := changedIdxes[idxName.L]; ok {
+				return dbterror.ErrOperateSameIndex.GenWithStackByArgs(idxName.O)
+			}
+			changedIdxes[idxName.L] = struct{}{}
+		}
+	}
+
+	if err := checkAddColumnTooManyColumns(len(t.Cols()) + len(info.AddColumns)); err != nil {
+		return errors.Trace(err)
+	}
+	if len(info.DropColumns) > 0 && len(info.AddColumns) == 0 {
+		return checkDropVisibleColumnCnt(t, len(info.DropColumns))
+	}
+	return nil
+}
+
+// onMultiSchemaChange runs the sub-jobs of an ActionMultiSchemaChange job.
+// The sub-jobs are run one by one until they reach their last revertible schema states, then they are stepped to
+// the non-revertible states all at once, and they finish one by one at last. If any sub-job fails before that,
+// all the sub-jobs are rolled back in reverse order.
+func onMultiSchemaChange(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
+	if job.MultiSchemaInfo.Revertible {
+		// Handle the rolling back job.
+		if job.IsRollingback() {
+			// Roll back the sub-jobs in reverse order.
+			for i := len(job.MultiSchemaInfo.SubJobs) - 1; i >= 0; i-- {
+				sub := job.MultiSchemaInfo.SubJobs[i]
+				if sub.IsFinished() {
+					continue
+				}
+				proxyJob := sub.ToProxyJob(job)
+				ver, err = w.runDDLJob(d, t, &proxyJob)
+				sub.FromProxyJob(&proxyJob, ver)
+				return ver, err
+			}
+			// All the sub-jobs are rolled back or cancelled.
+			job.State = model.JobStateRollbackDone
+			job.SchemaState = model.StateNone
+			return ver, nil
+		}
+
+		// Run the first sub-job which has not reached its last revertible schema state.
+		for _, sub := range job.MultiSchemaInfo.SubJobs {
+			if !sub.Revertible || sub.IsFinished() {
+				continue
+			}
+			proxyJob := sub.ToProxyJob(job)
+			ver, err = w.runDDLJob(d, t, &proxyJob)
+			sub.FromProxyJob(&proxyJob, ver)
+			handleRevertibleException(job, sub, proxyJob.Error)
+			return ver, err
+		}
+
+		// Save the table info and the sub-jobs for rolling back.
+		tblInfo, err := t.GetTable(job.SchemaID, job.TableID)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		subJobs := make([]model.SubJob, len(job.MultiSchemaInfo.SubJobs))
+		for i, sub := range job.MultiSchemaInfo.SubJobs {
+			subJobs[i] = *sub
+		}
+		// Step all the sub-jobs to their non-revertible schema states at once.
+		for i, sub := range job.MultiSchemaInfo.SubJobs {
+			if sub.IsFinished() {
+				continue
+			}
+			proxyJob := sub.ToProxyJob(job)
+			var subVer int64
+			subVer, err = w.runDDLJob(d, t, &proxyJob)
+			sub.FromProxyJob(&proxyJob, subVer)
+			if subVer != 0 {
+				ver = subVer
+			}
+			if err != nil || proxyJob.Error != nil {
+				for j := i - 1; j >= 0; j-- {
+					job.MultiSchemaInfo.SubJobs[j] = &subJobs[j]
+				}
+				handleRevertibleException(job, sub, proxyJob.Error)
+				// The table info has been changed by the previous sub-jobs, restore it.
+				_, err1 := updateVersionAndTableInfo(t, job, tblInfo, false)
+				if err1 != nil {
+					return ver, errors.Trace(err1)
+				}
+				return ver, errors.Trace(err)
+			}
+		}
+		job.MarkNonRevertible()
+		job.SchemaState = model.StateWriteReorganization
+		return ver, nil
+	}
+
+	// Run the non-revertible sub-jobs one by one.
+	for _, sub := range job.MultiSchemaInfo.SubJobs {
+		if sub.IsFinished() {
+			continue
+		}
+		proxyJob := sub.ToProxyJob(job)
+		ver, err = w.runDDLJob(d, t, &proxyJob)
+		sub.FromProxyJob(&proxyJob, ver)
+		return ver, err
+	}
+	job.State = model.JobStateDone
+	job.SchemaState = model.StatePublic
+	return ver, nil
+}
+
+// handleRevertibleException starts to roll back the whole job if the sub-job fails in its revertible states.
+func handleRevertibleException(job *model.Job, sub *model.SubJob, err *terror.Error) {
+	if sub.IsNormal() {
+		return
+	}
+	job.State = model.JobStateRollingback
+	job.Error = err
+	// Flush the cancelling state and the cancelled state to the other sub-jobs.
+	for _, s := range job.MultiSchemaInfo.SubJobs {
+		switch s.State {
+		case model.JobStateRunning:
+			s.State = model.JobStateCancelling
+		case model.JobStateNone:
+			s.State = model.JobStateCancelled
+		}
+	}
+}
+
+// rollingBackMultiSchemaChange converts the cancelling multi-schema change job to a rolling back job.
+// The job can only be rolled back before all the sub-jobs reach their non-revertible schema states.
+func rollingBackMultiSchemaChange(job *model.Job) error {
+	if !job.MultiSchemaInfo.Revertible {
+		// The job can't be rolled back any more, keep running it.
+		job.State = model.JobStateRunning
+		return nil
+	}
+	for _, sub := range job.MultiSchemaInfo.SubJobs {
+		switch sub.State {
+		case model.JobStateRunning:
+			sub.State = model.JobStateCancelling
+		case model.JobStateNone:
+			sub.State = model.JobStateCancelled
+		}
+	}
+	job.State = model.JobStateRollingback
+	return dbterror.ErrCancelledDDLJob
+}
+
+// subJobNeedReorg returns whether any sub-job of the multi-schema change job may need to reorganize the data.
+func subJobNeedReorg(job *model.Job) bool {
+	for _, sub := range job.MultiSchemaInfo.SubJobs {
+		proxyJob := sub.ToProxyJob(job)
+		if mayNeedReorg(&proxyJob) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,161 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pingcap/tidb/ddl"
+	"github.com/pingcap/tidb/domain"
+	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiSchemaChangeMixed(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t (a int, b int, d int, key idx_d(d))")
+	tk.MustExec("insert into t values (1, 1, 1), (2, 2, 2)")
+
+	tk.MustExec("alter table t add column c int default 3, add index idx_c(c), modify column b bigint, drop index idx_d")
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `a` int(11) DEFAULT NULL,\n" +
+		"  `b` bigint(20) DEFAULT NULL,\n" +
+		"  `d` int(11) DEFAULT NULL,\n" +
+		"  `c` int(11) DEFAULT '3',\n" +
+		"  KEY `idx_c` (`c`)\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+	tk.MustQuery("select * from t use index(idx_c) where c = 3 order by a").Check(testkit.Rows("1 1 1 3", "2 2 2 3"))
+	tk.MustExec("admin check table t")
+
+	tk.MustExec("alter table t add column e int first, drop column d, rename index idx_c to idx_cc, alter column a set default 5")
+	tk.MustQuery("select * from t order by a").Check(testkit.Rows("<nil> 1 1 3", "<nil> 2 2 3"))
+	tk.MustExec("insert into t (b) values (3)")
+	tk.MustQuery("select a from t where b = 3").Check(testkit.Rows("5"))
+	require.NotNil(t, tk.GetTableByName("test", "t").Meta().FindIndexByName("idx_cc"))
+	tk.MustExec("admin check table t")
+
+	// Multiple columns in one ADD COLUMN clause are added together with other changes.
+	tk.MustExec("alter table t add column (f int, g int default 7), add index idx_fg(f, g)")
+	tk.MustQuery("select g from t use index(idx_fg) order by b").Check(testkit.Rows("7", "7", "7"))
+	tk.MustExec("admin check table t")
+
+	// The multi-schema change is rejected when tidb_enable_change_multi_schema is disabled.
+	tk.MustExec("set global tidb_enable_change_multi_schema = off")
+	tk.MustGetErrCode("alter table t add column h int, add index idx_h(h)", errno.ErrUnsupportedDDLOperation)
+	tk.MustExec("set global tidb_enable_change_multi_schema = on")
+}
+
+func TestMultiSchemaChangeConflicts(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t (a int, b int, c int, key idx_a(a))")
+
+	tk.MustGetErrCode("alter table t modify column b bigint, modify column b int", errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("alter table t add column d int, drop column d", errno.ErrCantDropFieldOrKey)
+	tk.MustGetErrCode("alter table t drop column b, modify column b bigint", errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("alter table t drop column a, add index idx_ab(a, b)", errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("alter table t add index idx_b(b), drop index idx_b", errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("alter table t drop index idx_a, rename index idx_a to idx_aa", errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("alter table t drop column c, add column d int after c", errno.ErrUnsupportedDDLOperation)
+	// Changes that rewrite the column data are not supported in a multi-schema change.
+	tk.MustGetErrCode("alter table t add column d int, modify column b varchar(10)", errno.ErrUnsupportedDDLOperation)
+
+	// Dropping a column also drops its single-column index.
+	tk.MustExec("alter table t drop column a, add column d int")
+	require.Nil(t, tk.GetTableByName("test", "t").Meta().FindIndexByName("idx_a"))
+	tk.MustExec("admin check table t")
+}
+
+func TestMultiSchemaChangeRollback(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t (a int, b int)")
+	tk.MustExec("insert into t values (1, 1), (2, 1)")
+
+	// The unique index fails in the reorganization, all the sub-jobs are rolled back.
+	tk.MustGetErrCode("alter table t add column c int, drop column a, add unique index idx_b(b)", errno.ErrDupEntry)
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `a` int(11) DEFAULT NULL,\n" +
+		"  `b` int(11) DEFAULT NULL\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+	tk.MustQuery("select * from t order by a").Check(testkit.Rows("1 1", "2 1"))
+	tk.MustExec("admin check table t")
+
+	tk1 := testkit.NewTestKit(t, store)
+	dom := domain.GetDomain(tk.Session())
+	originHook := dom.DDL().GetHook()
+	defer dom.DDL().SetHook(originHook)
+	hook := &ddl.TestDDLCallback{Do: dom}
+	var checkErr error
+	cancelled := false
+	hook.OnJobRunBeforeExported = func(job *model.Job) {
+		if job.Type != model.ActionMultiSchemaChange || cancelled {
+			return
+		}
+		if job.MultiSchemaInfo.SubJobs[1].SchemaState == model.StateWriteReorganization {
+			cancelled = true
+			_, checkErr = tk1.Exec(fmt.Sprintf("admin cancel ddl jobs %d", job.ID))
+		}
+	}
+	dom.DDL().SetHook(hook)
+	tk.MustGetErrCode("alter table t add column c int, add index idx_b(b), modify column a bigint", errno.ErrCancelledDDLJob)
+	require.NoError(t, checkErr)
+	dom.DDL().SetHook(originHook)
+
+	tbl := tk.GetTableByName("test", "t").Meta()
+	require.Len(t, tbl.Columns, 2)
+	require.Len(t, tbl.Indices, 0)
+	tk.MustQuery("select * from t order by a").Check(testkit.Rows("1 1", "2 1"))
+	tk.MustExec("admin check table t")
+}
+
+func TestMultiSchemaChangeAdminShowDDLJobs(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t (a int, b int)")
+	tk.MustExec("alter table t add column c int, add index idx_a(a), modify column b bigint")
+
+	rows := tk.MustQuery("admin show ddl jobs 4").Rows()
+	require.Equal(t, model.ActionMultiSchemaChange.String(), rows[0][3])
+	require.Equal(t, "synced", rows[0][11])
+	subJobTypes := []string{
+		model.ActionAddColumn.String(),
+		model.ActionAddIndex.String(),
+		model.ActionModifyColumn.String(),
+	}
+	for i, tp := range subJobTypes {
+		require.Equal(t, tp+" /* subjob */", rows[i+1][3])
+		require.Equal(t, "public", rows[i+1][4])
+		require.Equal(t, "done", rows[i+1][11])
+	}
+	tk.MustQuery("select job_type from information_schema.ddl_jobs where query like 'alter table t add column c%' order by job_type").
+		Check(testkit.Rows("add column /* subjob */", "add index /* subjob */", "alter table multi-schema change", "modify column /* subjob */"))
+}
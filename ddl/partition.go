@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -33,6 +34,7 @@ import (
 	"github.com/pingcap/tidb/domain/infosync"
 	"github.com/pingcap/tidb/expression"
 	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tidb/meta"
 	"github.com/pingcap/tidb/metrics"
 	"github.com/pingcap/tidb/parser"
@@ -42,6 +44,7 @@ import (
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/table/tables"
 	"github.com/pingcap/tidb/tablecodec"
 	"github.com/pingcap/tidb/types"
 	driver "github.com/pingcap/tidb/types/parser_driver"
@@ -53,6 +56,7 @@ import (
 	"github.com/pingcap/tidb/util/logutil"
 	"github.com/pingcap/tidb/util/slice"
 	"github.com/pingcap/tidb/util/sqlexec"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/tikv/client-go/v2/tikv"
 	"go.uber.org/zap"
 )
@@ -390,25 +394,24 @@ func buildTablePartitionInfo(ctx sessionctx.Context, s *ast.PartitionOptions, tb
 	switch s.Tp {
 	case model.PartitionTypeRange:
 		// When tidb_enable_table_partition is 'on' or 'auto'.
-		if s.Sub == nil {
-			// Partition by range expression is enabled by default.
-			if s.ColumnNames == nil {
-				enable = true
-			}
-			// Partition by range columns and just one column.
-			if len(s.ColumnNames) == 1 {
-				enable = true
-			}
-		}
+		// Partition by range expression and partition by range columns are enabled by default,
+		// so is subpartition by hash or key.
+		enable = s.Sub == nil || isSubPartitionMethodSupported(s.Sub)
 	case model.PartitionTypeHash:
 		// Partition by hash is enabled by default.
 		// Note that linear hash is not enabled.
 		if !s.Linear && s.Sub == nil {
 			enable = true
 		}
+	case model.PartitionTypeKey:
+		// Partition by key is enabled by default.
+		// Note that linear key and the hashing of MySQL 5.1 (ALGORITHM=1) are not enabled.
+		if !s.Linear && s.Sub == nil && (s.KeyAlgorithm == nil || s.KeyAlgorithm.Type == 2) {
+			enable = true
+		}
 	case model.PartitionTypeList:
 		// Partition by list is enabled only when tidb_enable_list_partition is 'ON'.
-		enable = ctx.GetSessionVars().EnableListTablePartition
+		enable = ctx.GetSessionVars().EnableListTablePartition && (s.Sub == nil || isSubPartitionMethodSupported(s.Sub))
 	}
 
 	if !enable {
@@ -432,6 +435,12 @@ func buildTablePartitionInfo(ctx sessionctx.Context, s *ast.PartitionOptions, tb
 			return err
 		}
 		pi.Expr = buf.String()
+	} else if s.Tp == model.PartitionTypeKey {
+		cols, err := buildKeyPartitionColumns(tbInfo, s.ColumnNames)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		pi.Columns = cols
 	} else if s.ColumnNames != nil {
 		pi.Columns = make([]model.CIStr, 0, len(s.ColumnNames))
 		for _, cn := range s.ColumnNames {
@@ -448,6 +457,87 @@ func buildTablePartitionInfo(ctx sessionctx.Context, s *ast.PartitionOptions, tb
 	}
 
 	tbInfo.Partition.Definitions = defs
+	if s.Sub != nil {
+		return buildSubPartitionInfo(ctx, s, tbInfo)
+	}
+	return nil
+}
+
+// isSubPartitionMethodSupported checks whether the subpartitioning can be enabled, only non-linear
+// HASH and KEY (with the default algorithm) are supported, which are the same as the first level.
+func isSubPartitionMethodSupported(sub *ast.PartitionMethod) bool {
+	if sub.Linear {
+		return false
+	}
+	switch sub.Tp {
+	case model.PartitionTypeHash:
+		return true
+	case model.PartitionTypeKey:
+		return sub.KeyAlgorithm == nil || sub.KeyAlgorithm.Type == 2
+	}
+	return false
+}
+
+// buildSubPartitionInfo builds the subpartitioning of a table whose first level partitions have been built.
+// The first level partitions are moved to ParentDefinitions, and Definitions are replaced by the subpartitions.
+func buildSubPartitionInfo(ctx sessionctx.Context, s *ast.PartitionOptions, tbInfo *model.TableInfo) error {
+	pi := tbInfo.Partition
+	pi.SubType = s.Sub.Tp
+	pi.SubNum = s.Sub.Num
+	if pi.SubNum == 0 {
+		pi.SubNum = 1
+	}
+	if s.Sub.Expr != nil {
+		if err := checkPartitionFuncValid(ctx, tbInfo, s.Sub.Expr); err != nil {
+			return errors.Trace(err)
+		}
+		buf := new(bytes.Buffer)
+		restoreCtx := format.NewRestoreCtx(format.DefaultRestoreFlags|format.RestoreBracketAroundBinaryOperation, buf)
+		if err := s.Sub.Expr.Restore(restoreCtx); err != nil {
+			return err
+		}
+		pi.SubExpr = buf.String()
+	} else {
+		cols, err := buildKeyPartitionColumns(tbInfo, s.Sub.ColumnNames)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		pi.SubColumns = cols
+	}
+	if err := checkAddPartitionTooManyPartitions(uint64(len(pi.Definitions)) * pi.SubNum); err != nil {
+		return err
+	}
+
+	subDefs := make([]model.PartitionDefinition, 0, uint64(len(pi.Definitions))*pi.SubNum)
+	for i, parent := range pi.Definitions {
+		var astSubDefs []*ast.SubPartitionDefinition
+		if i < len(s.Definitions) {
+			astSubDefs = s.Definitions[i].Sub
+		}
+		for j := uint64(0); j < pi.SubNum; j++ {
+			// Like MySQL, the subpartitions inherit the comment and placement of their partition.
+			def := model.PartitionDefinition{
+				Name:    model.NewCIStr(fmt.Sprintf("%ssp%d", parent.Name.O, j)),
+				Comment: parent.Comment,
+			}
+			if parent.PlacementPolicyRef != nil {
+				policyRef := *parent.PlacementPolicyRef
+				def.PlacementPolicyRef = &policyRef
+			}
+			if len(astSubDefs) > 0 {
+				astSubDef := astSubDefs[j]
+				def.Name = astSubDef.Name
+				if comment, ok := astSubDef.Comment(); ok {
+					def.Comment = comment
+				}
+				if err := setPartitionPlacementFromOptions(&def, astSubDef.Options); err != nil {
+					return err
+				}
+			}
+			subDefs = append(subDefs, def)
+		}
+	}
+	pi.ParentDefinitions, pi.Definitions = pi.Definitions, subDefs
 	return nil
 }
 
@@ -456,7 +546,7 @@ func buildPartitionDefinitionsInfo(ctx sessionctx.Context, defs []*ast.Partition
 	switch tbInfo.Partition.Type {
 	case model.PartitionTypeRange:
 		partitions, err = buildRangePartitionDefinitions(ctx, defs, tbInfo)
-	case model.PartitionTypeHash:
+	case model.PartitionTypeHash, model.PartitionTypeKey:
 		partitions, err = buildHashPartitionDefinitions(ctx, defs, tbInfo)
 	case model.PartitionTypeList:
 		partitions, err = buildListPartitionDefinitions(ctx, defs, tbInfo)
@@ -488,6 +578,77 @@ func setPartitionPlacementFromOptions(partition *model.PartitionDefinition, opti
 	return nil
 }
 
+// buildKeyPartitionColumns returns the columns of a key (sub)partitioned table. Like MySQL, the primary key, or a unique key
+// whose columns are all not null if there is no primary key, is used when the column list is empty.
+func buildKeyPartitionColumns(tbInfo *model.TableInfo, colNames []*ast.ColumnName) ([]model.CIStr, error) {
+	var cols []model.CIStr
+	if len(colNames) == 0 {
+		cols = getKeyPartitionDefaultColumns(tbInfo)
+		if len(cols) == 0 {
+			return nil, errors.Trace(dbterror.ErrFieldNotFoundPart)
+		}
+	} else {
+		cols = make([]model.CIStr, 0, len(colNames))
+		for _, cn := range colNames {
+			cols = append(cols, cn.Name)
+		}
+	}
+	return cols, checkKeyPartitionColumnsType(tbInfo, cols)
+}
+
+// getKeyPartitionDefaultColumns returns the columns used by `PARTITION BY KEY ()`.
+func getKeyPartitionDefaultColumns(tbInfo *model.TableInfo) []model.CIStr {
+	if tbInfo.PKIsHandle {
+		return []model.CIStr{tbInfo.GetPkName()}
+	}
+	var uniqueIdx *model.IndexInfo
+	for _, idx := range tbInfo.Indices {
+		if idx.Primary {
+			uniqueIdx = idx
+			break
+		}
+		if !idx.Unique || uniqueIdx != nil {
+			continue
+		}
+		allNotNull := true
+		for _, idxCol := range idx.Columns {
+			if !mysql.HasNotNullFlag(tbInfo.Columns[idxCol.Offset].Flag) {
+				allNotNull = false
+				break
+			}
+		}
+		if allNotNull {
+			uniqueIdx = idx
+		}
+	}
+	if uniqueIdx == nil {
+		return nil
+	}
+	names := make([]model.CIStr, 0, len(uniqueIdx.Columns))
+	for _, idxCol := range uniqueIdx.Columns {
+		names = append(names, idxCol.Name)
+	}
+	return names
+}
+
+// checkKeyPartitionColumnsType checks the types of the columns of a key partitioned table.
+// All types except BLOB, TEXT, JSON and GEOMETRY can be used in key partitioning.
+func checkKeyPartitionColumnsType(tbInfo *model.TableInfo, cols []model.CIStr) error {
+	for _, col := range cols {
+		colInfo := getColumnInfoByName(tbInfo, col.L)
+		if colInfo == nil {
+			return errors.Trace(dbterror.ErrFieldNotFoundPart)
+		}
+		switch colInfo.FieldType.Tp {
+		case mysql.TypeTinyBlob, mysql.TypeBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob:
+			return errors.Trace(dbterror.ErrBlobFieldInPartFunc)
+		case mysql.TypeJSON, mysql.TypeGeometry:
+			return errors.Trace(dbterror.ErrNotAllowedTypeInPartition.GenWithStackByArgs(col.O))
+		}
+	}
+	return nil
+}
+
 func buildHashPartitionDefinitions(_ sessionctx.Context, defs []*ast.PartitionDefinition, tbInfo *model.TableInfo) ([]model.PartitionDefinition, error) {
 	if err := checkAddPartitionTooManyPartitions(tbInfo.Partition.Num); err != nil {
 		return nil, err
@@ -697,8 +858,8 @@ func checkAndOverridePartitionID(newTableInfo, oldTableInfo *model.TableInfo) er
 	if newTableInfo.Partition.Type != oldTableInfo.Partition.Type {
 		return dbterror.ErrRepairTableFail.GenWithStackByArgs("Partition type should be the same")
 	}
-	// Check whether partitionType is hash partition.
-	if newTableInfo.Partition.Type == model.PartitionTypeHash {
+	// Check whether partitionType is hash or key partition.
+	if newTableInfo.Partition.Type == model.PartitionTypeHash || newTableInfo.Partition.Type == model.PartitionTypeKey {
 		if newTableInfo.Partition.Num != oldTableInfo.Partition.Num {
 			return dbterror.ErrRepairTableFail.GenWithStackByArgs("Hash partition num should be the same")
 		}
@@ -941,6 +1102,10 @@ func checkDropTablePartition(meta *model.TableInfo, partLowerNames []string) err
 	// To be error compatible with MySQL, we need to do this first!
 	// see https://github.com/pingcap/tidb/issues/31681#issuecomment-1015536214
 	oldDefs := pi.Definitions
+	if pi.IsSubPartitioned() {
+		// Only the first level partitions can be dropped, together with all their subpartitions.
+		oldDefs = pi.ParentDefinitions
+	}
 	if len(oldDefs) <= len(partLowerNames) {
 		return errors.Trace(dbterror.ErrDropLastPartition)
 	}
@@ -967,6 +1132,9 @@ func checkDropTablePartition(meta *model.TableInfo, partLowerNames []string) err
 
 // updateDroppingPartitionInfo move dropping partitions to DroppingDefinitions, and return partitionIDs
 func updateDroppingPartitionInfo(tblInfo *model.TableInfo, partLowerNames []string) []int64 {
+	if tblInfo.Partition.IsSubPartitioned() {
+		return updateDroppingSubPartitionInfo(tblInfo, partLowerNames)
+	}
 	oldDefs := tblInfo.Partition.Definitions
 	newDefs := make([]model.PartitionDefinition, 0, len(oldDefs)-len(partLowerNames))
 	droppingDefs := make([]model.PartitionDefinition, 0, len(partLowerNames))
@@ -994,6 +1162,38 @@ func updateDroppingPartitionInfo(tblInfo *model.TableInfo, partLowerNames []stri
 	return pids
 }
 
+// updateDroppingSubPartitionInfo is like updateDroppingPartitionInfo, but partLowerNames are the first level partitions
+// of a subpartitioned table, they are removed from ParentDefinitions, and their subpartitions are dropped.
+func updateDroppingSubPartitionInfo(tblInfo *model.TableInfo, partLowerNames []string) []int64 {
+	pi := tblInfo.Partition
+	newParentDefs := make([]model.PartitionDefinition, 0, len(pi.ParentDefinitions)-len(partLowerNames))
+	newDefs := make([]model.PartitionDefinition, 0, len(pi.Definitions))
+	droppingDefs := make([]model.PartitionDefinition, 0, uint64(len(partLowerNames))*pi.SubNum)
+	pids := make([]int64, 0, cap(droppingDefs))
+	for i, parentDef := range pi.ParentDefinitions {
+		found := false
+		for _, partName := range partLowerNames {
+			if parentDef.Name.L == partName {
+				found = true
+				break
+			}
+		}
+		if found {
+			for _, def := range pi.SubDefinitions(i) {
+				pids = append(pids, def.ID)
+				droppingDefs = append(droppingDefs, def)
+			}
+		} else {
+			newParentDefs = append(newParentDefs, parentDef)
+			newDefs = append(newDefs, pi.SubDefinitions(i)...)
+		}
+	}
+	pi.ParentDefinitions = newParentDefs
+	pi.Definitions = newDefs
+	pi.DroppingDefinitions = droppingDefs
+	return pids
+}
+
 func getPartitionDef(tblInfo *model.TableInfo, partName string) (index int, def *model.PartitionDefinition, _ error) {
 	defs := tblInfo.Partition.Definitions
 	for i := 0; i < len(defs); i++ {
@@ -1095,7 +1295,12 @@ func (w *worker) onDropTablePartition(d *ddlCtx, t *meta.Meta, job *model.Job) (
 			return ver, errors.Trace(err)
 		}
 		physicalTableIDs = updateDroppingPartitionInfo(tblInfo, partNames)
-		err = dropLabelRules(d, job.SchemaName, tblInfo.Name.L, partNames)
+		// The label rules belong to the physical partitions, which are the subpartitions of a subpartitioned table.
+		droppingNames := make([]string, 0, len(tblInfo.Partition.DroppingDefinitions))
+		for _, def := range tblInfo.Partition.DroppingDefinitions {
+			droppingNames = append(droppingNames, def.Name.L)
+		}
+		err = dropLabelRules(d, job.SchemaName, tblInfo.Name.L, droppingNames)
 		if err != nil {
 			job.State = model.JobStateCancelled
 			return ver, errors.Wrapf(err, "failed to notify PD the label rules")
@@ -1167,6 +1372,622 @@ func (w *worker) onDropTablePartition(d *ddlCtx, t *meta.Meta, job *model.Job) (
 	return ver, errors.Trace(err)
 }
 
+// getReorganizedPartitionDefs returns the index of the first partition to reorganize and the partitions to reorganize,
+// which must be consecutive.
+func getReorganizedPartitionDefs(pi *model.PartitionInfo, partNames []string) (int, []model.PartitionDefinition, error) {
+	if len(partNames) > len(pi.Definitions) {
+		return 0, nil, errors.Trace(dbterror.ErrReorgPartitionNotExist)
+	}
+	idxs := make([]int, 0, len(partNames))
+	for _, partName := range partNames {
+		idx := -1
+		for i := range pi.Definitions {
+			if pi.Definitions[i].Name.L == partName {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 || slice.AnyOf(idxs, func(i int) bool { return idxs[i] == idx }) {
+			return 0, nil, errors.Trace(dbterror.ErrDropPartitionNonExistent.GenWithStackByArgs("REORGANIZE"))
+		}
+		idxs = append(idxs, idx)
+	}
+	sort.Ints(idxs)
+	for i := 1; i < len(idxs); i++ {
+		if idxs[i] != idxs[i-1]+1 {
+			return 0, nil, errors.Trace(dbterror.ErrConsecutiveReorgPartitions)
+		}
+	}
+	defs := make([]model.PartitionDefinition, 0, len(idxs))
+	defs = append(defs, pi.Definitions[idxs[0]:idxs[0]+len(idxs)]...)
+	return idxs[0], defs, nil
+}
+
+// checkReorganizePartition checks the new partitions can replace the partitions to reorganize: they must cover the
+// same range or list values, except that the range of the last partition can be extended.
+func checkReorganizePartition(ctx sessionctx.Context, tblInfo *model.TableInfo, partNames []string, partInfo *model.PartitionInfo) error {
+	pi := tblInfo.Partition
+	firstIdx, oldDefs, err := getReorganizedPartitionDefs(pi, partNames)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	clonedMeta := tblInfo.Clone()
+	tmp := *pi
+	tmp.Definitions = tables.ReplacePartitionDefinitions(pi.Definitions, oldDefs, partInfo.Definitions)
+	clonedMeta.Partition = &tmp
+	if err = checkPartitionDefinitionConstraints(ctx, clonedMeta); err != nil {
+		return errors.Trace(err)
+	}
+
+	switch pi.Type {
+	case model.PartitionTypeRange:
+		oldBound := oldDefs[len(oldDefs)-1].LessThan
+		newBound := partInfo.Definitions[len(partInfo.Definitions)-1].LessThan
+		cmp, err := compareRangePartitionBound(ctx, tblInfo, newBound, oldBound)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		isLast := firstIdx+len(oldDefs) == len(pi.Definitions)
+		if cmp < 0 || (cmp > 0 && !isLast) {
+			return errors.Trace(dbterror.ErrReorgOutsideRange)
+		}
+	case model.PartitionTypeList:
+		oldValues, err := getListPartitionValues(ctx, tblInfo, oldDefs)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		newValues, err := getListPartitionValues(ctx, tblInfo, partInfo.Definitions)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for v := range oldValues {
+			if _, ok := newValues[v]; !ok {
+				return errors.Trace(dbterror.ErrReorgOutsideRange)
+			}
+		}
+	}
+	return nil
+}
+
+// compareRangePartitionBound compares the VALUES LESS THAN of two range partitions.
+func compareRangePartitionBound(ctx sessionctx.Context, tblInfo *model.TableInfo, a, b []string) (int, error) {
+	pi := tblInfo.Partition
+	for i := range a {
+		aIsMax, bIsMax := strings.EqualFold(a[i], partitionMaxValue), strings.EqualFold(b[i], partitionMaxValue)
+		switch {
+		case aIsMax && bIsMax:
+			continue
+		case aIsMax:
+			return 1, nil
+		case bIsMax:
+			return -1, nil
+		}
+
+		if len(pi.Columns) == 0 {
+			isUnsigned := isColUnsigned(tblInfo.Columns, pi)
+			aVal, _, err := getRangeValue(ctx, a[i], isUnsigned)
+			if err != nil {
+				return 0, errors.Trace(err)
+			}
+			bVal, _, err := getRangeValue(ctx, b[i], isUnsigned)
+			if err != nil {
+				return 0, errors.Trace(err)
+			}
+			if isUnsigned {
+				return types.CompareUint64(aVal.(uint64), bVal.(uint64)), nil
+			}
+			return types.CompareInt64(aVal.(int64), bVal.(int64)), nil
+		}
+
+		colInfo := findColumnByName(pi.Columns[i].L, tblInfo)
+		if colInfo == nil {
+			return 0, errors.Trace(dbterror.ErrFieldNotFoundPart)
+		}
+		vals := make([]types.Datum, 0, 2)
+		for _, s := range []string{a[i], b[i]} {
+			expr, err := expression.ParseSimpleExprCastWithTableInfo(ctx, s, &model.TableInfo{}, &colInfo.FieldType)
+			if err != nil {
+				return 0, errors.Trace(err)
+			}
+			val, err := expr.Eval(chunk.Row{})
+			if err != nil {
+				return 0, errors.Trace(err)
+			}
+			vals = append(vals, val)
+		}
+		cmp, err := vals[0].Compare(ctx.GetSessionVars().StmtCtx, &vals[1], collate.GetCollator(colInfo.Collate))
+		if err != nil || cmp != 0 {
+			return cmp, errors.Trace(err)
+		}
+	}
+	return 0, nil
+}
+
+// getListPartitionValues returns the formatted values of the list partitions.
+func getListPartitionValues(ctx sessionctx.Context, tblInfo *model.TableInfo, defs []model.PartitionDefinition) (map[string]struct{}, error) {
+	tmp := *tblInfo.Partition
+	tmp.Definitions = make([]model.PartitionDefinition, 0, len(defs))
+	for _, def := range defs {
+		// formatListPartitionValue rewrites the values in place, don't touch the shared ones.
+		inValues := make([][]string, 0, len(def.InValues))
+		for _, vs := range def.InValues {
+			inValues = append(inValues, append([]string(nil), vs...))
+		}
+		def.InValues = inValues
+		tmp.Definitions = append(tmp.Definitions, def)
+	}
+	clonedMeta := tblInfo.Clone()
+	clonedMeta.Partition = &tmp
+	strs, err := formatListPartitionValue(ctx, clonedMeta)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	values := make(map[string]struct{}, len(strs))
+	for _, s := range strs {
+		values[s] = struct{}{}
+	}
+	return values, nil
+}
+
+// fullTablePartitionName is the name of the single partition of a non-partitioned table, when the table is being
+// partitioned or the partitioning of it is being removed.
+const fullTablePartitionName = "pfulltable"
+
+// newFullTablePartitionInfo returns the partition info of a non-partitioned table, the only partition is the table.
+func newFullTablePartitionInfo(tableID int64) *model.PartitionInfo {
+	return &model.PartitionInfo{
+		Type:   model.PartitionTypeNone,
+		Enable: true,
+		Num:    1,
+		Definitions: []model.PartitionDefinition{{
+			ID:   tableID,
+			Name: model.NewCIStr(fullTablePartitionName),
+		}},
+	}
+}
+
+// replaceChangedIndexes adds the new indexes of the new partitioning for the indexes which need to be rebuilt,
+// i.e. the global indexes before or after changing the partitioning, since the keys or values of them are changed.
+func replaceChangedIndexes(tblInfo *model.TableInfo, partInfo *model.PartitionInfo) error {
+	pi := tblInfo.Partition
+	pi.DDLChangedIndex = make(map[int64]bool)
+	indices := tblInfo.Indices
+	for _, idxInfo := range indices {
+		global := false
+		if partInfo.Type != model.PartitionTypeNone && idxInfo.Unique {
+			ck, err := checkPartitionKeysConstraint(partInfo, idxInfo.Columns, tblInfo)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if !ck {
+				if idxInfo.Primary {
+					return dbterror.ErrUniqueKeyNeedAllFieldsInPf.GenWithStackByArgs("PRIMARY KEY")
+				}
+				if !config.GetGlobalConfig().EnableGlobalIndex {
+					return dbterror.ErrUniqueKeyNeedAllFieldsInPf.GenWithStackByArgs("UNIQUE INDEX")
+				}
+				global = true
+			}
+		}
+		if !global && !idxInfo.Global {
+			continue
+		}
+		newIdxInfo := idxInfo.Clone()
+		newIdxInfo.ID = allocateIndexID(tblInfo)
+		newIdxInfo.Global = global
+		newIdxInfo.State = model.StateDeleteOnly
+		tblInfo.Indices = append(tblInfo.Indices, newIdxInfo)
+		pi.DDLChangedIndex[idxInfo.ID] = false
+		pi.DDLChangedIndex[newIdxInfo.ID] = true
+	}
+	return nil
+}
+
+// dropChangedIndexes drops the new indexes if isNewIndex is true, or the replaced ones, see replaceChangedIndexes.
+// The dropped indexes belong to the partitioning of `pi.DDLType`, it returns the IDs of the indexes whose data under
+// the table ID should be deleted, which includes all the indexes if the partitioning is a non-partitioned table.
+func dropChangedIndexes(tblInfo *model.TableInfo, isNewIndex bool) []int64 {
+	pi := tblInfo.Partition
+	droppedTypeIsNone := pi.DDLType == model.PartitionTypeNone
+	var indexIDs []int64
+	indices := tblInfo.Indices[:0]
+	for _, idxInfo := range tblInfo.Indices {
+		v, ok := pi.DDLChangedIndex[idxInfo.ID]
+		if ok && v == isNewIndex {
+			indexIDs = append(indexIDs, idxInfo.ID)
+			continue
+		}
+		if !ok && droppedTypeIsNone {
+			indexIDs = append(indexIDs, idxInfo.ID)
+		}
+		indices = append(indices, idxInfo)
+	}
+	tblInfo.Indices = indices
+	pi.DDLChangedIndex = nil
+	return indexIDs
+}
+
+// onReorganizePartition reorganizes the partitions into the new ones.
+// The adding partitions are double written since the write only state, after the rows of the reorganized partitions
+// are copied to them, the definitions are swapped and the reorganized partitions are dropped.
+// It also handles ALTER TABLE ... PARTITION BY and REMOVE PARTITIONING, which reorganize all the partitions, and a
+// non-partitioned table is regarded as a table with a single partition whose ID is the table ID.
+func (w *worker) onReorganizePartition(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	// Handle the rolling back job
+	if job.IsRollingback() {
+		return rollbackReorganizePartition(t, job)
+	}
+
+	var partNames []string
+	partInfo := &model.PartitionInfo{}
+	if err := job.DecodeArgs(&partNames, &partInfo); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	if job.Type == model.ActionAlterTablePartitioning && job.SchemaState == model.StateNone && tblInfo.Partition == nil {
+		tblInfo.Partition = newFullTablePartitionInfo(tblInfo.ID)
+	}
+	pi := tblInfo.GetPartitionInfo()
+	if pi == nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(dbterror.ErrPartitionMgmtOnNonpartitioned)
+	}
+
+	// The partition states are kept in `pi.DDLState` because the DML needs it to double write the rows,
+	// and it's the same as `job.SchemaState`.
+	switch job.SchemaState {
+	case model.StateNone:
+		// The table may be changed since the job is submitted.
+		_, droppingDefs, err := getReorganizedPartitionDefs(pi, partNames)
+		if err != nil {
+			job.State = model.JobStateCancelled
+			return ver, errors.Trace(err)
+		}
+		tmp := *pi
+		tmp.Definitions = tables.ReplacePartitionDefinitions(pi.Definitions, droppingDefs, partInfo.Definitions)
+		if err = checkPartitionNameUnique(&tmp); err != nil {
+			job.State = model.JobStateCancelled
+			return ver, errors.Trace(err)
+		}
+		for _, def := range partInfo.Definitions {
+			if _, err = checkPlacementPolicyRefValidAndCanNonValidJob(t, job, def.PlacementPolicyRef); err != nil {
+				return ver, errors.Trace(err)
+			}
+		}
+		bundles, err := alterTablePartitionBundles(t, tblInfo, partInfo.Definitions)
+		if err != nil {
+			job.State = model.JobStateCancelled
+			return ver, errors.Trace(err)
+		}
+		if err = infosync.PutRuleBundlesWithDefaultRetry(context.TODO(), bundles); err != nil {
+			job.State = model.JobStateCancelled
+			return ver, errors.Wrapf(err, "failed to notify PD the placement rules")
+		}
+
+		if job.Type != model.ActionReorganizePartition {
+			if err = replaceChangedIndexes(tblInfo, partInfo); err != nil {
+				job.State = model.JobStateCancelled
+				return ver, errors.Trace(err)
+			}
+		}
+		pi.DroppingDefinitions = droppingDefs
+		updateAddingPartitionInfo(partInfo, tblInfo)
+		pi.DDLType, pi.DDLExpr, pi.DDLColumns = partInfo.Type, partInfo.Expr, partInfo.Columns
+		// none -> delete only
+		pi.DDLState = model.StateDeleteOnly
+		job.SchemaState = model.StateDeleteOnly
+		ver, err = updateVersionAndTableInfoWithCheck(t, job, tblInfo, true)
+	case model.StateDeleteOnly:
+		// delete only -> write only
+		pi.DDLState = model.StateWriteOnly
+		job.SchemaState = model.StateWriteOnly
+		ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
+	case model.StateWriteOnly:
+		// write only -> reorganization
+		pi.DDLState = model.StateWriteReorganization
+		job.SchemaState = model.StateWriteReorganization
+		ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
+	case model.StateWriteReorganization:
+		tbl, err := getTable(d.store, job.SchemaID, tblInfo)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		physicalTableIDs := getPartitionIDsFromDefinitions(pi.DroppingDefinitions)
+		// The element is only used to record the reorg handle.
+		elements := []*meta.Element{{ID: tblInfo.ID, TypeKey: meta.ColumnElementKey}}
+		reorgInfo, err := getReorgInfoFromPartitions(d, t, job, tbl, physicalTableIDs, elements)
+		if err != nil || reorgInfo.first {
+			// If we run reorg firstly, we should update the job snapshot version
+			// and then run the reorg next time.
+			return ver, errors.Trace(err)
+		}
+		err = w.runReorgJob(t, reorgInfo, tbl.Meta(), d.lease, func() (reorgErr error) {
+			defer tidbutil.Recover(metrics.LabelDDL, "onReorganizePartition",
+				func() {
+					reorgErr = dbterror.ErrCancelledDDLJob.GenWithStack("reorganize partition panic")
+				}, false)
+			return w.reorgPartitionData(tbl.(table.PartitionedTable), physicalTableIDs, reorgInfo)
+		})
+		if err != nil {
+			if dbterror.ErrWaitReorgTimeout.Equal(err) {
+				// if timeout, we should return, check for the owner and re-wait job done.
+				return ver, nil
+			}
+			logutil.BgLogger().Warn("[ddl] run reorganize partition job failed, convert job to rollback", zap.String("job", job.String()), zap.Error(err))
+			job.State = model.JobStateRollingback
+			if err1 := t.RemoveDDLReorgHandle(job, reorgInfo.elements); err1 != nil {
+				logutil.BgLogger().Warn("[ddl] run reorganize partition job failed, convert job to rollback, RemoveDDLReorgHandle failed", zap.String("job", job.String()), zap.Error(err1))
+			}
+			// Clean up the channel of notifyCancelReorgJob. Make sure it can't affect other jobs.
+			w.reorgCtx.cleanNotifyReorgCancel()
+			return ver, errors.Trace(err)
+		}
+		// Clean up the channel of notifyCancelReorgJob. Make sure it can't affect other jobs.
+		w.reorgCtx.cleanNotifyReorgCancel()
+
+		// Swap the partitions, the rows written to the added partitions are still written to the reorganized
+		// partitions until all the servers can't see them.
+		pi.Definitions = tables.ReplacePartitionDefinitions(pi.Definitions, pi.DroppingDefinitions, pi.AddingDefinitions)
+		pi.Type, pi.DDLType = pi.DDLType, pi.Type
+		pi.Expr, pi.DDLExpr = pi.DDLExpr, pi.Expr
+		pi.Columns, pi.DDLColumns = pi.DDLColumns, pi.Columns
+		if job.Type != model.ActionReorganizePartition {
+			pi.Num = partInfo.Num
+			// The new indexes become public, and the replaced ones are only written for the previous layout.
+			for _, idxInfo := range tblInfo.Indices {
+				if isNewIndex, ok := pi.DDLChangedIndex[idxInfo.ID]; ok {
+					if isNewIndex {
+						idxInfo.State = model.StatePublic
+					} else {
+						idxInfo.State = model.StateDeleteOnly
+					}
+				}
+			}
+		}
+		pi.DDLState = model.StateDeleteReorganization
+		job.SchemaState = model.StateDeleteReorganization
+		ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
+	case model.StateDeleteReorganization:
+		physicalTableIDs := getPartitionIDsFromDefinitions(pi.DroppingDefinitions)
+		var indexIDs []int64
+		if job.Type != model.ActionReorganizePartition {
+			indexIDs = dropChangedIndexes(tblInfo, false)
+		}
+		pi.AddingDefinitions = nil
+		pi.DroppingDefinitions = nil
+		pi.DDLState = model.StateNone
+		pi.DDLType, pi.DDLExpr, pi.DDLColumns = model.PartitionTypeNone, "", nil
+		if pi.Type == model.PartitionTypeNone {
+			tblInfo.Partition = nil
+		}
+		ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		job.FinishTableJob(model.JobStateDone, model.StateNone, ver, tblInfo)
+		asyncNotifyEvent(d, &util.Event{Tp: job.Type, TableInfo: tblInfo, PartInfo: partInfo})
+		// A background job will be created to delete old partition data.
+		job.Args = []interface{}{physicalTableIDs}
+		if job.Type != model.ActionReorganizePartition {
+			job.Args = append(job.Args, indexIDs)
+		}
+	default:
+		err = dbterror.ErrInvalidDDLState.GenWithStackByArgs("partition", job.SchemaState)
+	}
+	return ver, errors.Trace(err)
+}
+
+// rollbackReorganizePartition removes the adding partitions, their data will be deleted by the delete-range.
+func rollbackReorganizePartition(t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	pi := tblInfo.Partition
+	var indexIDs []int64
+	if job.Type != model.ActionReorganizePartition {
+		indexIDs = dropChangedIndexes(tblInfo, true)
+	}
+	physicalTableIDs, _, rollbackBundles := rollbackAddingPartitionInfo(tblInfo)
+	if err = infosync.PutRuleBundlesWithDefaultRetry(context.TODO(), rollbackBundles); err != nil {
+		return ver, errors.Wrapf(err, "failed to notify PD the placement rules")
+	}
+	pi.DroppingDefinitions = nil
+	pi.DDLState = model.StateNone
+	pi.DDLType, pi.DDLExpr, pi.DDLColumns = model.PartitionTypeNone, "", nil
+	if pi.Type == model.PartitionTypeNone {
+		tblInfo.Partition = nil
+	}
+	ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateRollbackDone, model.StateNone, ver, tblInfo)
+	job.Args = []interface{}{physicalTableIDs}
+	if job.Type != model.ActionReorganizePartition {
+		job.Args = append(job.Args, indexIDs)
+	}
+	return ver, nil
+}
+
+// reorgPartitionData copies the rows of the reorganized partitions to the adding partitions one by one.
+func (w *worker) reorgPartitionData(tbl table.PartitionedTable, partitionIDs []int64, reorgInfo *reorgInfo) error {
+	var err error
+	var finish bool
+	for !finish {
+		p := tbl.GetPartition(reorgInfo.PhysicalTableID)
+		if p == nil {
+			return dbterror.ErrCancelledDDLJob.GenWithStack("Can not find partition id %d for table %d", reorgInfo.PhysicalTableID, tbl.Meta().ID)
+		}
+		logutil.BgLogger().Info("[ddl] start to reorganize partition", zap.String("job", reorgInfo.Job.String()), zap.String("reorgInfo", reorgInfo.String()))
+		err = w.writePhysicalTableRecord(p, typeReorgPartitionWorker, nil, nil, nil, reorgInfo)
+		if err != nil {
+			break
+		}
+		finish, err = w.updateReorgInfoForPartitions(tbl, reorgInfo, partitionIDs)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return errors.Trace(err)
+}
+
+type reorgPartitionWorker struct {
+	*backfillWorker
+	metricCounter prometheus.Counter
+	// reorgedTbl is the table whose reorganized partitions are replaced by the adding ones.
+	reorgedTbl table.PartitionedTable
+
+	// The following attributes are used to reduce memory allocation.
+	rowRecords []*reorgPartitionRecord
+}
+
+type reorgPartitionRecord struct {
+	key    kv.Key // It's the row key in the reorganized partition, used to lock the row.
+	newKey kv.Key // It's the row key in the adding partition.
+	vals   []byte
+	handle kv.Handle
+	row    []types.Datum
+	part   table.PhysicalTable
+}
+
+func newReorgPartitionWorker(sessCtx sessionctx.Context, worker *worker, id int, t table.PhysicalTable) (*reorgPartitionWorker, error) {
+	reorgedTbl, err := tables.TableFromMeta(nil, tables.GetReorgPartitionedTableInfo(t.Meta()))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &reorgPartitionWorker{
+		backfillWorker: newBackfillWorker(sessCtx, worker, id, t),
+		metricCounter:  metrics.BackfillTotalCounter.WithLabelValues("reorg_partition_rate"),
+		reorgedTbl:     reorgedTbl.(table.PartitionedTable),
+	}, nil
+}
+
+func (w *reorgPartitionWorker) AddMetricInfo(cnt float64) {
+	w.metricCounter.Add(cnt)
+}
+
+// getNextKey gets next handle of entry that we are going to process.
+func (w *reorgPartitionWorker) getNextKey(taskRange reorgBackfillTask,
+	taskDone bool, lastAccessedHandle kv.Key) (nextHandle kv.Key) {
+	if !taskDone {
+		// The task is not done. So we need to pick the last processed entry's handle and add one.
+		return lastAccessedHandle.Next()
+	}
+
+	return taskRange.endKey.Next()
+}
+
+func (w *reorgPartitionWorker) fetchRowColVals(txn kv.Transaction, taskRange reorgBackfillTask) ([]*reorgPartitionRecord, kv.Key, bool, error) {
+	w.rowRecords = w.rowRecords[:0]
+	startTime := time.Now()
+
+	// taskDone means that the added handle is out of taskRange.endHandle.
+	taskDone := false
+	var lastAccessedHandle kv.Key
+	oprStartTime := startTime
+	err := iterateSnapshotRows(w.sessCtx.GetStore(), w.priority, w.table, txn.StartTS(), taskRange.startKey, taskRange.endKey,
+		func(handle kv.Handle, recordKey kv.Key, rawRow []byte) (bool, error) {
+			oprEndTime := time.Now()
+			logSlowOperations(oprEndTime.Sub(oprStartTime), "iterateSnapshotRows in reorgPartitionWorker fetchRowColVals", 0)
+			oprStartTime = oprEndTime
+
+			taskDone = recordKey.Cmp(taskRange.endKey) > 0
+
+			if taskDone || len(w.rowRecords) >= w.batchCnt {
+				return false, nil
+			}
+
+			row, _, err := tables.DecodeRawRowData(w.sessCtx, w.table.Meta(), handle, w.table.WritableCols(), rawRow)
+			if err != nil {
+				return false, errors.Trace(dbterror.ErrCantDecodeRecord.GenWithStackByArgs("row", err))
+			}
+			part, err := w.reorgedTbl.GetPartitionByRow(w.sessCtx, row)
+			if err != nil {
+				return false, errors.Trace(err)
+			}
+			w.rowRecords = append(w.rowRecords, &reorgPartitionRecord{
+				key:    recordKey,
+				newKey: tablecodec.EncodeRecordKey(part.RecordPrefix(), handle),
+				vals:   rawRow,
+				handle: handle,
+				row:    row,
+				part:   part,
+			})
+			lastAccessedHandle = recordKey
+			if recordKey.Cmp(taskRange.endKey) == 0 {
+				// If taskRange.endIncluded == false, we will not reach here when handle == taskRange.endHandle.
+				taskDone = true
+				return false, nil
+			}
+			return true, nil
+		})
+
+	if len(w.rowRecords) == 0 {
+		taskDone = true
+	}
+
+	logutil.BgLogger().Debug("[ddl] txn fetches handle info", zap.Uint64("txnStartTS", txn.StartTS()), zap.String("taskRange", taskRange.String()), zap.Duration("takeTime", time.Since(startTime)))
+	return w.rowRecords, w.getNextKey(taskRange, taskDone, lastAccessedHandle), taskDone, errors.Trace(err)
+}
+
+// BackfillDataInTxn copies the rows to the adding partitions in a transaction, the rows already written by the
+// DML in the transition are skipped.
+func (w *reorgPartitionWorker) BackfillDataInTxn(handleRange reorgBackfillTask) (taskCtx backfillTaskContext, errInTxn error) {
+	oprStartTime := time.Now()
+	errInTxn = kv.RunInNewTxn(context.Background(), w.sessCtx.GetStore(), true, func(ctx context.Context, txn kv.Transaction) error {
+		taskCtx.addedCount = 0
+		taskCtx.scanCount = 0
+		txn.SetOption(kv.Priority, w.priority)
+
+		rowRecords, nextKey, taskDone, err := w.fetchRowColVals(txn, handleRange)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		taskCtx.nextKey = nextKey
+		taskCtx.done = taskDone
+
+		for _, record := range rowRecords {
+			taskCtx.scanCount++
+			_, err = txn.Get(ctx, record.newKey)
+			if err == nil {
+				continue
+			}
+			if !kv.IsErrNotFound(err) {
+				return errors.Trace(err)
+			}
+
+			// Lock the row key to make sure the row isn't changed since it's read.
+			if err = txn.LockKeys(context.Background(), new(kv.LockCtx), record.key); err != nil {
+				return errors.Trace(err)
+			}
+			if err = txn.Set(record.newKey, record.vals); err != nil {
+				return errors.Trace(err)
+			}
+			for _, idx := range record.part.Indices() {
+				idxVals, err := idx.FetchValues(record.row, nil)
+				if err != nil {
+					return errors.Trace(err)
+				}
+				rsData := tables.TryGetHandleRestoredDataWrapper(record.part, record.row, nil, idx.Meta())
+				if _, err = idx.Create(w.sessCtx, txn, idxVals, record.handle, rsData, table.WithIgnoreAssertion); err != nil {
+					return errors.Trace(err)
+				}
+			}
+			taskCtx.addedCount++
+		}
+		return nil
+	})
+	logSlowOperations(time.Since(oprStartTime), "ReorgPartitionBackfillDataInTxn", 3000)
+
+	return
+}
+
 // onTruncateTablePartition truncates old partition meta.
 func onTruncateTablePartition(d *ddlCtx, t *meta.Meta, job *model.Job) (int64, error) {
 	var ver int64
@@ -1559,6 +2380,8 @@ func checkExchangePartitionRecordValidation(w *worker, pt *model.TableInfo, inde
 			sql, paramList = buildCheckSQLForRangeExprPartition(pi, index, schemaName, tableName)
 		} else if len(pi.Columns) == 1 {
 			sql, paramList = buildCheckSQLForRangeColumnsPartition(pi, index, schemaName, tableName)
+		} else {
+			sql, paramList = buildCheckSQLForMultiRangeColumnsPartition(pi, index, schemaName, tableName)
 		}
 	case model.PartitionTypeList:
 		if len(pi.Columns) == 0 {
@@ -1635,6 +2458,49 @@ func buildCheckSQLForRangeColumnsPartition(pi *model.PartitionInfo, index int, s
 	}
 }
 
+func buildCheckSQLForMultiRangeColumnsPartition(pi *model.PartitionInfo, index int, schemaName, tableName model.CIStr) (string, []interface{}) {
+	paramList := make([]interface{}, 0, 2+4*len(pi.Columns))
+	paramList = append(paramList, schemaName.L, tableName.L)
+	conds := make([]string, 0, 2)
+	if index > 0 {
+		cond, params := rangeColumnsLessThanCond(pi, pi.Definitions[index-1].LessThan)
+		conds = append(conds, cond)
+		paramList = append(paramList, params...)
+	}
+	if cond, params := rangeColumnsLessThanCond(pi, pi.Definitions[index].LessThan); cond != "1" {
+		conds = append(conds, "not ("+cond+")")
+		paramList = append(paramList, params...)
+	}
+	return "select 1 from %n.%n where " + strings.Join(conds, " or ") + " limit 1", paramList
+}
+
+// rangeColumnsLessThanCond returns the condition that the columns are less than the values of a range columns
+// partition. The tuple (c1, c2) < (v1, MAXVALUE) is the same as (c1) <= (v1).
+func rangeColumnsLessThanCond(pi *model.PartitionInfo, lessThan []string) (string, []interface{}) {
+	n, op := len(pi.Columns), "<"
+	for i, v := range lessThan {
+		if strings.EqualFold(v, partitionMaxValue) {
+			n, op = i, "<="
+			break
+		}
+	}
+	if n == 0 {
+		return "1", nil
+	}
+	cols := make([]string, 0, n)
+	vals := make([]string, 0, n)
+	params := make([]interface{}, 0, 2*n)
+	for i := 0; i < n; i++ {
+		cols = append(cols, "%n")
+		params = append(params, pi.Columns[i].L)
+	}
+	for i := 0; i < n; i++ {
+		vals = append(vals, "%?")
+		params = append(params, trimQuotation(lessThan[i]))
+	}
+	return fmt.Sprintf("(%s) %s (%s)", strings.Join(cols, ","), op, strings.Join(vals, ",")), params
+}
+
 func buildCheckSQLForListPartition(pi *model.PartitionInfo, index int, schemaName, tableName model.CIStr) (string, []interface{}) {
 	var buf strings.Builder
 	buf.WriteString("select 1 from %n.%n where ")
@@ -1694,6 +2560,18 @@ func checkPartitionColumnsUnique(tbInfo *model.TableInfo) error {
 	return nil
 }
 
+// checkSubPartitionColumnsUnique checks the column list of `SUBPARTITION BY KEY` has no duplicated columns.
+func checkSubPartitionColumnsUnique(pi *model.PartitionInfo) error {
+	columnsMap := make(map[string]struct{}, len(pi.SubColumns))
+	for _, col := range pi.SubColumns {
+		if _, ok := columnsMap[col.L]; ok {
+			return dbterror.ErrSameNamePartitionField.GenWithStackByArgs(col.L)
+		}
+		columnsMap[col.L] = struct{}{}
+	}
+	return nil
+}
+
 func checkNoHashPartitions(ctx sessionctx.Context, partitionNum uint64) error {
 	if partitionNum == 0 {
 		return ast.ErrNoParts.GenWithStackByArgs("partitions")
@@ -1739,12 +2617,32 @@ func checkPartitioningKeysConstraints(sctx sessionctx.Context, s *ast.CreateTabl
 			return err
 		}
 		partCols = columnInfoSlice(partColumns)
-	} else if len(s.Partition.ColumnNames) > 0 {
-		partCols = columnNameSlice(s.Partition.ColumnNames)
+	} else if len(tblInfo.Partition.Columns) > 0 {
+		// The columns of `PARTITION BY KEY ()` are filled by the primary key or a unique key.
+		partColNames := make([]*ast.ColumnName, 0, len(tblInfo.Partition.Columns))
+		for _, col := range tblInfo.Partition.Columns {
+			partColNames = append(partColNames, &ast.ColumnName{Name: col})
+		}
+		partCols = columnNameSlice(partColNames)
 	} else {
 		// TODO: Check keys constraints for list, key partition type and so on.
 		return nil
 	}
+	if pi := tblInfo.Partition; pi.IsSubPartitioned() {
+		// The columns of the subpartitioning key are a part of the partitioning key.
+		subCols, err := getPartitionColumnInfos(pi.SubExpr, pi.SubColumns, tblInfo)
+		if err != nil {
+			return err
+		}
+		allCols := make(columnNameSlice, 0, partCols.Len()+len(subCols))
+		for i := 0; i < partCols.Len(); i++ {
+			allCols = append(allCols, &ast.ColumnName{Name: model.NewCIStr(partCols.At(i))})
+		}
+		for _, col := range subCols {
+			allCols = append(allCols, &ast.ColumnName{Name: col.Name})
+		}
+		partCols = allCols
+	}
 
 	// Checks that the partitioning key is included in the constraint.
 	// Every unique key on the table must use every column in the table's partitioning expression.
@@ -1773,27 +2671,16 @@ func checkPartitioningKeysConstraints(sctx sessionctx.Context, s *ast.CreateTabl
 }
 
 func checkPartitionKeysConstraint(pi *model.PartitionInfo, indexColumns []*model.IndexColumn, tblInfo *model.TableInfo) (bool, error) {
-	var (
-		partCols []*model.ColumnInfo
-		err      error
-	)
-	// The expr will be an empty string if the partition is defined by:
-	// CREATE TABLE t (...) PARTITION BY RANGE COLUMNS(...)
-	if partExpr := pi.Expr; partExpr != "" {
-		// Parse partitioning key, extract the column names in the partitioning key to slice.
-		partCols, err = extractPartitionColumns(partExpr, tblInfo)
+	partCols, err := getPartitionColumnInfos(pi.Expr, pi.Columns, tblInfo)
+	if err != nil {
+		return false, err
+	}
+	if pi.IsSubPartitioned() {
+		subCols, err := getPartitionColumnInfos(pi.SubExpr, pi.SubColumns, tblInfo)
 		if err != nil {
 			return false, err
 		}
-	} else {
-		partCols = make([]*model.ColumnInfo, 0, len(pi.Columns))
-		for _, col := range pi.Columns {
-			colInfo := getColumnInfoByName(tblInfo, col.L)
-			if colInfo == nil {
-				return false, infoschema.ErrColumnNotExists.GenWithStackByArgs(col, tblInfo.Name)
-			}
-			partCols = append(partCols, colInfo)
-		}
+		partCols = append(partCols, subCols...)
 	}
 
 	// In MySQL, every unique key on the table must use every column in the table's partitioning expression.(This
@@ -1803,6 +2690,25 @@ func checkPartitionKeysConstraint(pi *model.PartitionInfo, indexColumns []*model
 	return checkUniqueKeyIncludePartKey(columnInfoSlice(partCols), indexColumns), nil
 }
 
+// getPartitionColumnInfos returns the columns used by a partitioning expression or a partitioning column list.
+func getPartitionColumnInfos(partExpr string, columns []model.CIStr, tblInfo *model.TableInfo) ([]*model.ColumnInfo, error) {
+	// The expr will be an empty string if the partition is defined by:
+	// CREATE TABLE t (...) PARTITION BY RANGE COLUMNS(...)
+	if partExpr != "" {
+		// Parse partitioning key, extract the column names in the partitioning key to slice.
+		return extractPartitionColumns(partExpr, tblInfo)
+	}
+	partCols := make([]*model.ColumnInfo, 0, len(columns))
+	for _, col := range columns {
+		colInfo := getColumnInfoByName(tblInfo, col.L)
+		if colInfo == nil {
+			return nil, infoschema.ErrColumnNotExists.GenWithStackByArgs(col, tblInfo.Name)
+		}
+		partCols = append(partCols, colInfo)
+	}
+	return partCols, nil
+}
+
 type columnNameExtractor struct {
 	extractedColumns []*model.ColumnInfo
 	tblInfo          *model.TableInfo
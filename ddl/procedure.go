@@ -0,0 +1,333 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/meta"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/charset"
+	"github.com/pingcap/tidb/parser/format"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/dbterror"
+)
+
+func onCreateProcedure(t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	procInfo := &model.ProcedureInfo{}
+	if err := job.DecodeArgs(procInfo); err != nil {
+		// Invalid arguments, cancel this job.
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	dbInfo, err := checkSchemaExistAndCancelNotExistJob(t, job)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	if dbInfo.FindProcedure(procInfo.Name.L) != nil {
+		job.State = model.JobStateCancelled
+		return ver, infoschema.ErrProcedureExists.GenWithStackByArgs("PROCEDURE", procInfo.Name.O)
+	}
+
+	dbInfo.Procedures = append(dbInfo.Procedures, procInfo)
+	if err = t.UpdateDatabase(dbInfo); err != nil {
+		return ver, errors.Trace(err)
+	}
+	if ver, err = updateSchemaVersion(t, job); err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishDBJob(model.JobStateDone, model.StatePublic, ver, dbInfo)
+	return ver, nil
+}
+
+func onDropProcedure(t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	var procName model.CIStr
+	if err := job.DecodeArgs(&procName); err != nil {
+		// Invalid arguments, cancel this job.
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	dbInfo, err := checkSchemaExistAndCancelNotExistJob(t, job)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	procs := make([]*model.ProcedureInfo, 0, len(dbInfo.Procedures))
+	for _, proc := range dbInfo.Procedures {
+		if proc.Name.L != procName.L {
+			procs = append(procs, proc)
+		}
+	}
+	if len(procs) == len(dbInfo.Procedures) {
+		job.State = model.JobStateCancelled
+		return ver, infoschema.ErrProcedureNotExists.GenWithStackByArgs("PROCEDURE", procName.O)
+	}
+
+	dbInfo.Procedures = procs
+	if err = t.UpdateDatabase(dbInfo); err != nil {
+		return ver, errors.Trace(err)
+	}
+	if ver, err = updateSchemaVersion(t, job); err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishDBJob(model.JobStateDone, model.StatePublic, ver, dbInfo)
+	return ver, nil
+}
+
+func buildProcedureInfo(ctx sessionctx.Context, stmt *ast.CreateProcedureStmt, dbInfo *model.DBInfo) (*model.ProcedureInfo, error) {
+	if err := checkProcedureParams(stmt.Params); err != nil {
+		return nil, err
+	}
+	if err := CheckProcedureBody(stmt.Body); err != nil {
+		return nil, err
+	}
+	var sb strings.Builder
+	if err := stmt.Body.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	vars := ctx.GetSessionVars()
+	chs, coll := vars.GetCharsetInfo()
+	sqlMode, _ := vars.GetSystemVar(variable.SQLModeVar)
+	procInfo := &model.ProcedureInfo{
+		Name:       stmt.ProcedureName.Name,
+		Params:     make([]*model.ProcedureParamInfo, 0, len(stmt.Params)),
+		Body:       sb.String(),
+		Definer:    vars.User,
+		SQLMode:    sqlMode,
+		Charset:    chs,
+		Collate:    coll,
+		CreateTime: time.Now(),
+	}
+	for _, param := range stmt.Params {
+		if err := CompleteProcedureVarType(ctx, param.Tp, param.Name, dbInfo); err != nil {
+			return nil, err
+		}
+		procInfo.Params = append(procInfo.Params, &model.ProcedureParamInfo{
+			Name: model.NewCIStr(param.Name),
+			Mode: param.Mode,
+			Tp:   param.Tp,
+		})
+	}
+	return procInfo, nil
+}
+
+// CompleteProcedureVarType completes the type of a stored procedure parameter or local variable,
+// the charset and the collation default to the ones of the database, and the unspecified length
+// and decimal default to the ones of the type.
+func CompleteProcedureVarType(ctx sessionctx.Context, tp *types.FieldType, name string, dbInfo *model.DBInfo) error {
+	chs, coll := dbInfo.Charset, dbInfo.Collate
+	if tp.Charset != "" {
+		chs, coll = tp.Charset, tp.Collate
+	}
+	if chs == "" {
+		chs, coll = charset.GetDefaultCharsetAndCollate()
+	}
+	if coll == "" {
+		var err error
+		if coll, err = charset.GetDefaultCollation(chs); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return setCharsetCollationFlenDecimal(tp, name, chs, coll, ctx.GetSessionVars())
+}
+
+func checkProcedureParams(params []*ast.StoredProcedureParam) error {
+	names := make(map[string]struct{}, len(params))
+	for _, param := range params {
+		name := strings.ToLower(param.Name)
+		if _, ok := names[name]; ok {
+			return dbterror.ErrSpDupParam.GenWithStackByArgs(param.Name)
+		}
+		names[name] = struct{}{}
+	}
+	return nil
+}
+
+// procedureChecker checks the labels, the cursors and the declarations of a procedure body.
+type procedureChecker struct {
+	// labels are the labels of the enclosing compound statements, the label
+	// of a BEGIN ... END block can be left but not iterated.
+	labels   []string
+	iterable []bool
+	// cursors are the cursors declared in each enclosing BEGIN ... END block.
+	cursors []map[string]struct{}
+}
+
+// CheckProcedureBody checks the labels, the declarations and the cursors in the body of a stored program.
+func CheckProcedureBody(body ast.StmtNode) error {
+	c := &procedureChecker{}
+	return c.check(body)
+}
+
+func (c *procedureChecker) checkList(stmts []ast.StmtNode) error {
+	for _, stmt := range stmts {
+		if err := c.check(stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *procedureChecker) pushLabel(label string, iterable bool) error {
+	if label != "" {
+		for _, l := range c.labels {
+			if strings.EqualFold(l, label) {
+				return dbterror.ErrSpLabelRedefine.GenWithStackByArgs(label)
+			}
+		}
+	}
+	c.labels = append(c.labels, label)
+	c.iterable = append(c.iterable, iterable)
+	return nil
+}
+
+func (c *procedureChecker) popLabel() {
+	c.labels = c.labels[:len(c.labels)-1]
+	c.iterable = c.iterable[:len(c.iterable)-1]
+}
+
+func (c *procedureChecker) checkCursor(name string) error {
+	for i := len(c.cursors) - 1; i >= 0; i-- {
+		if _, ok := c.cursors[i][strings.ToLower(name)]; ok {
+			return nil
+		}
+	}
+	return dbterror.ErrSpCursorMismatch.GenWithStackByArgs(name)
+}
+
+func (c *procedureChecker) check(node ast.StmtNode) error {
+	switch x := node.(type) {
+	case *ast.ProcedureBlock:
+		return c.checkBlock(x)
+	case *ast.ProcedureLoopStmt:
+		if err := c.pushLabel(x.Label, true); err != nil {
+			return err
+		}
+		defer c.popLabel()
+		return c.checkList(x.Stmts)
+	case *ast.ProcedureIfStmt:
+		for _, branch := range x.Branches {
+			if err := c.checkList(branch.Stmts); err != nil {
+				return err
+			}
+		}
+		return c.checkList(x.Else)
+	case *ast.ProcedureJumpStmt:
+		for i := len(c.labels) - 1; i >= 0; i-- {
+			if strings.EqualFold(c.labels[i], x.Label) && (!x.IsIterate || c.iterable[i]) {
+				return nil
+			}
+		}
+		if x.IsIterate {
+			return dbterror.ErrSpLilabelMismatch.GenWithStackByArgs("ITERATE", x.Label)
+		}
+		return dbterror.ErrSpLilabelMismatch.GenWithStackByArgs("LEAVE", x.Label)
+	case *ast.ProcedureOpenStmt:
+		return c.checkCursor(x.Cursor)
+	case *ast.ProcedureFetchStmt:
+		return c.checkCursor(x.Cursor)
+	case *ast.ProcedureCloseStmt:
+		return c.checkCursor(x.Cursor)
+	}
+	return nil
+}
+
+func (c *procedureChecker) checkBlock(block *ast.ProcedureBlock) error {
+	if err := c.pushLabel(block.Label, false); err != nil {
+		return err
+	}
+	defer c.popLabel()
+	vars := make(map[string]struct{})
+	cursors := make(map[string]struct{})
+	c.cursors = append(c.cursors, cursors)
+	defer func() {
+		c.cursors = c.cursors[:len(c.cursors)-1]
+	}()
+	var hasCursor, hasHandler bool
+	for _, stmt := range block.Stmts {
+		switch x := stmt.(type) {
+		case *ast.ProcedureVarDecl:
+			if hasCursor || hasHandler {
+				return dbterror.ErrSpVarcondAfterCurshndlr.GenWithStackByArgs()
+			}
+			for _, name := range x.Names {
+				if _, ok := vars[strings.ToLower(name)]; ok {
+					return dbterror.ErrSpDupVar.GenWithStackByArgs(name)
+				}
+				vars[strings.ToLower(name)] = struct{}{}
+			}
+		case *ast.ProcedureCursorDecl:
+			if hasHandler {
+				return dbterror.ErrSpCursorAfterHandler.GenWithStackByArgs()
+			}
+			if _, ok := cursors[strings.ToLower(x.Name)]; ok {
+				return dbterror.ErrSpDupCurs.GenWithStackByArgs(x.Name)
+			}
+			cursors[strings.ToLower(x.Name)] = struct{}{}
+			hasCursor = true
+		case *ast.ProcedureHandlerDecl:
+			for _, cond := range x.Conditions {
+				if cond.Tp == ast.ProcedureConditionSQLState && !isValidProcedureSQLState(cond.SQLState) {
+					return dbterror.ErrSpBadSQLstate.GenWithStackByArgs(cond.SQLState)
+				}
+			}
+			// The statement of a handler can't see the labels of the block.
+			labels, iterable := c.labels, c.iterable
+			c.labels, c.iterable = nil, nil
+			err := c.check(x.Stmt)
+			c.labels, c.iterable = labels, iterable
+			if err != nil {
+				return err
+			}
+			hasHandler = true
+		default:
+			if err := c.check(stmt); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// isValidProcedureSQLState checks whether the SQLSTATE value of a handler
+// condition is valid, the success class '00' can't be handled.
+func isValidProcedureSQLState(state string) bool {
+	if len(state) != 5 || strings.HasPrefix(state, "00") {
+		return false
+	}
+	for _, c := range state {
+		if !(c >= '0' && c <= '9') && !(c >= 'A' && c <= 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+// checkProcedureExists checks whether the stored procedure exists in the schema.
+func checkProcedureExists(is infoschema.InfoSchema, schema, name model.CIStr) (*model.DBInfo, bool, error) {
+	dbInfo, ok := is.SchemaByName(schema)
+	if !ok {
+		return nil, false, infoschema.ErrDatabaseNotExists.GenWithStackByArgs(schema.O)
+	}
+	return dbInfo, dbInfo.FindProcedure(name.L) != nil, nil
+}
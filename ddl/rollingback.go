@@ -325,6 +325,10 @@ func rollingbackDropIndexes(t *meta.Meta, job *model.Job) (ver int64, err error)
 }
 
 func rollingbackAddIndex(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job, isPK bool) (ver int64, err error) {
+	// The backfilling of a non-revertible sub-job of the multi-schema change is finished, roll back the index directly.
+	if job.MultiSchemaInfo != nil && !job.MultiSchemaInfo.Revertible {
+		return convertNotStartAddIdxJob2RollbackJob(t, job, dbterror.ErrCancelledDDLJob)
+	}
 	// If the value of SnapshotVer isn't zero, it means the work is backfilling the indexes.
 	if job.SchemaState == model.StateWriteReorganization && job.SnapshotVer != 0 {
 		// add index workers are started. need to ask them to exit.
@@ -338,6 +342,28 @@ func rollingbackAddIndex(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job, isP
 	return
 }
 
+func rollingbackReorganizePartition(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
+	// If the value of SnapshotVer isn't zero, it means the work is backfilling the partitions.
+	if job.SchemaState == model.StateWriteReorganization && job.SnapshotVer != 0 {
+		// reorganize partition workers are started. need to ask them to exit.
+		logutil.Logger(w.logCtx).Info("[ddl] run the cancelling DDL job", zap.String("job", job.String()))
+		w.reorgCtx.notifyReorgCancel()
+		return w.onReorganizePartition(d, t, job)
+	}
+	switch job.SchemaState {
+	case model.StateNone:
+		job.State = model.JobStateCancelled
+	case model.StateDeleteReorganization:
+		// The partitions have been swapped, it couldn't be cancelled.
+		job.State = model.JobStateRunning
+		return ver, nil
+	default:
+		// The adding partitions are removed by onReorganizePartition.
+		job.State = model.JobStateRollingback
+	}
+	return ver, dbterror.ErrCancelledDDLJob
+}
+
 func convertAddTablePartitionJob2RollbackJob(t *meta.Meta, job *model.Job, otherwiseErr error, tblInfo *model.TableInfo) (ver int64, err error) {
 	addingDefinitions := tblInfo.Partition.AddingDefinitions
 	partNames := make([]string, 0, len(addingDefinitions))
@@ -452,6 +478,8 @@ func convertJob2RollbackJob(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job)
 		ver, err = rollingbackAddIndex(w, d, t, job, true)
 	case model.ActionAddTablePartition:
 		ver, err = rollingbackAddTablePartition(t, job)
+	case model.ActionReorganizePartition, model.ActionAlterTablePartitioning, model.ActionRemovePartitioning:
+		ver, err = rollingbackReorganizePartition(w, d, t, job)
 	case model.ActionDropColumn:
 		ver, err = rollingbackDropColumn(t, job)
 	case model.ActionDropColumns:
@@ -472,12 +500,19 @@ func convertJob2RollbackJob(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job)
 		ver, err = rollingbackTruncateTable(t, job)
 	case model.ActionModifyColumn:
 		ver, err = rollingbackModifyColumn(w, d, t, job)
+	case model.ActionAddCheckConstraint:
+		ver, err = rollingbackAddCheckConstraint(t, job)
+	case model.ActionMultiSchemaChange:
+		err = rollingBackMultiSchemaChange(job)
 	case model.ActionRebaseAutoID, model.ActionShardRowID, model.ActionAddForeignKey,
 		model.ActionDropForeignKey, model.ActionRenameTable, model.ActionRenameTables,
 		model.ActionModifyTableCharsetAndCollate, model.ActionTruncateTablePartition,
 		model.ActionModifySchemaCharsetAndCollate, model.ActionRepairTable,
 		model.ActionModifyTableAutoIdCache, model.ActionAlterIndexVisibility,
-		model.ActionExchangeTablePartition, model.ActionModifySchemaDefaultPlacement:
+		model.ActionExchangeTablePartition, model.ActionModifySchemaDefaultPlacement,
+		model.ActionCreateProcedure, model.ActionDropProcedure,
+		model.ActionCreateTrigger, model.ActionDropTrigger,
+		model.ActionDropCheckConstraint, model.ActionAlterCheckConstraint:
 		ver, err = cancelOnlyNotHandledJob(job)
 	default:
 		job.State = model.JobStateCancelled
@@ -0,0 +1,266 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/meta"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/format"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/util/dbterror"
+)
+
+func onCreateTrigger(t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	trigInfo := &model.TriggerInfo{}
+	var order *ast.TriggerOrder
+	if err := job.DecodeArgs(trigInfo, &order); err != nil {
+		// Invalid arguments, cancel this job.
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	// The trigger names are unique in a schema.
+	tables, err := t.ListTables(job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	for _, tbl := range tables {
+		if tbl.FindTrigger(trigInfo.Name.L) != nil {
+			job.State = model.JobStateCancelled
+			return ver, infoschema.ErrTriggerExists.GenWithStackByArgs()
+		}
+	}
+
+	pos, err := findTriggerPosition(tblInfo, trigInfo, order)
+	if err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	triggers := make([]*model.TriggerInfo, 0, len(tblInfo.Triggers)+1)
+	triggers = append(triggers, tblInfo.Triggers[:pos]...)
+	triggers = append(triggers, trigInfo)
+	tblInfo.Triggers = append(triggers, tblInfo.Triggers[pos:]...)
+
+	ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	return ver, nil
+}
+
+func onDropTrigger(t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	var trigName model.CIStr
+	if err := job.DecodeArgs(&trigName); err != nil {
+		// Invalid arguments, cancel this job.
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	triggers := make([]*model.TriggerInfo, 0, len(tblInfo.Triggers))
+	for _, trig := range tblInfo.Triggers {
+		if trig.Name.L != trigName.L {
+			triggers = append(triggers, trig)
+		}
+	}
+	if len(triggers) == len(tblInfo.Triggers) {
+		job.State = model.JobStateCancelled
+		return ver, infoschema.ErrTriggerNotExists.GenWithStackByArgs()
+	}
+
+	tblInfo.Triggers = triggers
+	ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	return ver, nil
+}
+
+// findTriggerPosition returns the position in tblInfo.Triggers where the new trigger is inserted.
+// The triggers with the same timing and event are activated in the order of their positions,
+// a trigger without FOLLOWS or PRECEDES is activated after the existing ones.
+func findTriggerPosition(tblInfo *model.TableInfo, trigInfo *model.TriggerInfo, order *ast.TriggerOrder) (int, error) {
+	if order == nil {
+		return len(tblInfo.Triggers), nil
+	}
+	for i, trig := range tblInfo.Triggers {
+		if trig.Name.L != order.Trigger.L || trig.Timing != trigInfo.Timing || trig.Event != trigInfo.Event {
+			continue
+		}
+		if order.Precedes {
+			return i, nil
+		}
+		return i + 1, nil
+	}
+	return 0, dbterror.ErrReferencedTrgDoesNotExist.GenWithStackByArgs(order.Trigger.O)
+}
+
+func buildTriggerInfo(ctx sessionctx.Context, stmt *ast.CreateTriggerStmt, tblInfo *model.TableInfo) (*model.TriggerInfo, error) {
+	if err := CheckProcedureBody(stmt.Body); err != nil {
+		return nil, err
+	}
+	if err := checkTriggerBody(stmt, tblInfo); err != nil {
+		return nil, err
+	}
+	var sb strings.Builder
+	if err := stmt.Body.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	vars := ctx.GetSessionVars()
+	chs, coll := vars.GetCharsetInfo()
+	sqlMode, _ := vars.GetSystemVar(variable.SQLModeVar)
+	return &model.TriggerInfo{
+		Name:       stmt.TriggerName.Name,
+		Timing:     stmt.Timing,
+		Event:      stmt.Event,
+		Body:       sb.String(),
+		Definer:    vars.User,
+		SQLMode:    sqlMode,
+		Charset:    chs,
+		Collate:    coll,
+		CreateTime: time.Now(),
+	}, nil
+}
+
+// triggerChecker checks the statements of a trigger body and its references to the NEW and OLD rows.
+type triggerChecker struct {
+	stmt    *ast.CreateTriggerStmt
+	tblInfo *model.TableInfo
+	err     error
+}
+
+func checkTriggerBody(stmt *ast.CreateTriggerStmt, tblInfo *model.TableInfo) error {
+	c := &triggerChecker{stmt: stmt, tblInfo: tblInfo}
+	if err := c.checkStmt(stmt.Body); err != nil {
+		return err
+	}
+	stmt.Body.Accept(c)
+	return c.err
+}
+
+// checkStmt checks that the statements of a trigger body neither return a result set nor commit implicitly.
+func (c *triggerChecker) checkStmt(node ast.StmtNode) error {
+	switch x := node.(type) {
+	case *ast.ProcedureBlock:
+		return c.checkStmts(x.Stmts)
+	case *ast.ProcedureLoopStmt:
+		return c.checkStmts(x.Stmts)
+	case *ast.ProcedureIfStmt:
+		for _, branch := range x.Branches {
+			if err := c.checkStmts(branch.Stmts); err != nil {
+				return err
+			}
+		}
+		return c.checkStmts(x.Else)
+	case *ast.ProcedureHandlerDecl:
+		return c.checkStmt(x.Stmt)
+	case *ast.SelectStmt:
+		if x.SelectIntoOpt == nil {
+			return dbterror.ErrSpNoRetset.GenWithStackByArgs("trigger")
+		}
+	case *ast.SetOprStmt:
+		return dbterror.ErrSpNoRetset.GenWithStackByArgs("trigger")
+	case *ast.CommitStmt, *ast.RollbackStmt, *ast.CreateTableStmt, *ast.DropTableStmt, *ast.TruncateTableStmt:
+		return dbterror.ErrCommitNotAllowedInSfOrTrg.GenWithStackByArgs()
+	}
+	return nil
+}
+
+func (c *triggerChecker) checkStmts(stmts []ast.StmtNode) error {
+	for _, stmt := range stmts {
+		if err := c.checkStmt(stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkRow checks the reference to the column of the NEW or OLD row.
+func (c *triggerChecker) checkRow(row, col string, assign bool) error {
+	row = strings.ToUpper(row)
+	event := c.stmt.Event
+	if (row == "NEW" && event == model.TriggerDelete) || (row == "OLD" && event == model.TriggerInsert) {
+		return dbterror.ErrTrgNoSuchRowInTrg.GenWithStackByArgs(row, event.String())
+	}
+	if assign {
+		if row == "OLD" {
+			return dbterror.ErrTrgCantChangeRow.GenWithStackByArgs(row, "")
+		}
+		if c.stmt.Timing == model.TriggerAfter {
+			return dbterror.ErrTrgCantChangeRow.GenWithStackByArgs(row, "after ")
+		}
+	}
+	if model.FindColumnInfo(c.tblInfo.Columns, strings.ToLower(col)) == nil {
+		return infoschema.ErrColumnNotExists.GenWithStackByArgs(col, row)
+	}
+	return nil
+}
+
+// Enter implements ast.Visitor interface.
+func (c *triggerChecker) Enter(in ast.Node) (ast.Node, bool) {
+	switch x := in.(type) {
+	case *ast.ColumnName:
+		if x.Schema.L == "" && (x.Table.L == "new" || x.Table.L == "old") {
+			c.err = c.checkRow(x.Table.O, x.Name.O, false)
+		}
+	case *ast.VariableAssignment:
+		if !x.IsSystem || x.IsGlobal || x.ExtendValue != nil {
+			break
+		}
+		if idx := strings.IndexByte(x.Name, '.'); idx > 0 {
+			row := strings.ToLower(x.Name[:idx])
+			if row == "new" || row == "old" {
+				c.err = c.checkRow(x.Name[:idx], x.Name[idx+1:], true)
+			}
+		}
+	}
+	return in, c.err != nil
+}
+
+// Leave implements ast.Visitor interface.
+func (c *triggerChecker) Leave(in ast.Node) (ast.Node, bool) {
+	return in, c.err == nil
+}
+
+// findTriggerTable finds the table which the trigger belongs to, it returns a nil table if the trigger doesn't exist.
+func findTriggerTable(is infoschema.InfoSchema, schema, name model.CIStr) (*model.DBInfo, *model.TableInfo, error) {
+	dbInfo, ok := is.SchemaByName(schema)
+	if !ok {
+		return nil, nil, infoschema.ErrDatabaseNotExists.GenWithStackByArgs(schema.O)
+	}
+	for _, tbl := range is.SchemaTables(schema) {
+		if tbl.Meta().FindTrigger(name.L) != nil {
+			return dbInfo, tbl.Meta(), nil
+		}
+	}
+	return dbInfo, nil, nil
+}
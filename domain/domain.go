@@ -1243,6 +1243,40 @@ func (do *Domain) PlanReplayerLoop() {
 	}()
 }
 
+const (
+	eventSchedulerPrompt   = "event"
+	eventSchedulerOwnerKey = "/tidb/event/owner"
+)
+
+// EventSchedulerInterval is the interval to check the scheduled events which are due.
+var EventSchedulerInterval = time.Second
+
+// EventSchedulerLoop creates a goroutine that fires the scheduled events regularly.
+// Only the owner of the event scheduler fires the events, so an event fires once in the cluster.
+func (do *Domain) EventSchedulerLoop(fire func()) {
+	do.wg.Add(1)
+	go func() {
+		defer func() {
+			do.wg.Done()
+			logutil.BgLogger().Info("EventSchedulerLoop exited.")
+			util.Recover(metrics.LabelDomain, "EventSchedulerLoop", nil, false)
+		}()
+		owner := do.newOwnerManager(eventSchedulerPrompt, eventSchedulerOwnerKey)
+		for {
+			select {
+			case <-do.exit:
+				owner.Cancel()
+				return
+			case <-time.After(EventSchedulerInterval):
+				if !owner.IsOwner() {
+					continue
+				}
+				fire()
+			}
+		}
+	}()
+}
+
 // StatsHandle returns the statistic handle.
 func (do *Domain) StatsHandle() *handle.Handle {
 	return (*handle.Handle)(atomic.LoadPointer(&do.statsHandle))
@@ -822,9 +822,13 @@ const (
 	ErrRowInWrongPartition                                   = 1863
 	ErrErrorLast                                             = 1863
 	ErrMaxExecTimeExceeded                                   = 1907
+	ErrForeignKeyCascadeDepthExceeded                        = 3008
+	ErrReferencedTrgDoesNotExist                             = 3011
 	ErrInvalidFieldSize                                      = 3013
 	ErrInvalidArgumentForLogarithm                           = 3020
 	ErrAggregateOrderNonAggQuery                             = 3029
+	ErrGISDifferentSRIDs                                     = 3033
+	ErrGISInvalidData                                        = 3037
 	ErrIncorrectType                                         = 3064
 	ErrFieldInOrderNotSelect                                 = 3065
 	ErrAggregateInOrderNotSelect                             = 3066
@@ -853,9 +857,11 @@ const (
 	ErrInvalidJSONPathArrayCell                              = 3165
 	ErrInvalidEncryptionOption                               = 3184
 	ErrTooLongValueForType                                   = 3505
+	ErrGISUnsupportedArgument                                = 3516
 	ErrPKIndexCantBeInvisible                                = 3522
 	ErrGrantRole                                             = 3523
 	ErrRoleNotGranted                                        = 3530
+	ErrSrsNotFound                                           = 3548
 	ErrLockAcquireFailAndNoWaitSet                           = 3572
 	ErrCTERecursiveRequiresUnion                             = 3573
 	ErrCTERecursiveRequiresNonRecursiveFirst                 = 3574
@@ -883,9 +889,18 @@ const (
 	ErrWindowNoGroupOrderUnused                              = 3597
 	ErrWindowExplainJSON                                     = 3598
 	ErrWindowFunctionIgnoresFrame                            = 3599
+	ErrLongitudeOutOfRange                                   = 3616
+	ErrLatitudeOutOfRange                                    = 3617
+	ErrNotImplementedForGeographicSrs                        = 3618
 	ErrIllegalPrivilegeLevel                                 = 3619
 	ErrCTEMaxRecursionDepth                                  = 3636
 	ErrNotHintUpdatable                                      = 3637
+	ErrWrongSridForColumn                                    = 3643
+	ErrMissingJSONTableValue                                 = 3665
+	ErrWrongJSONTableValue                                   = 3666
+	ErrTableFunctionForbiddenJoinType                        = 3668
+	ErrWarnUselessSpatialIndex                               = 3674
+	ErrNonpositiveRadius                                     = 3706
 	ErrDataTruncatedFunctionalIndex                          = 3751
 	ErrDataOutOfRangeFunctionalIndex                         = 3752
 	ErrFunctionalIndexOnJSONOrGeometryFunction               = 3753
@@ -901,6 +916,16 @@ const (
 	ErrGeneratedColumnRowValueIsNotAllowed                   = 3764
 	ErrFKIncompatibleColumns                                 = 3780
 	ErrFunctionalIndexRowValueIsNotAllowed                   = 3800
+	ErrColumnCheckConstraintReferencesOtherColumn            = 3813
+	ErrCheckConstraintNamedFunctionIsNotAllowed              = 3814
+	ErrCheckConstraintFunctionIsNotAllowed                   = 3815
+	ErrCheckConstraintVariables                              = 3816
+	ErrCheckConstraintRowValue                               = 3817
+	ErrCheckConstraintRefersAutoIncrementColumn              = 3818
+	ErrCheckConstraintViolated                               = 3819
+	ErrCheckConstraintRefersUnknownColumn                    = 3820
+	ErrCheckConstraintNotFound                               = 3821
+	ErrCheckConstraintDupName                                = 3822
 	ErrDependentByFunctionalIndex                            = 3837
 	ErrCannotConvertString                                   = 3854
 	ErrInvalidJSONValueForFuncIndex                          = 3903
@@ -908,6 +933,7 @@ const (
 	ErrFunctionalIndexDataIsTooLong                          = 3907
 	ErrFunctionalIndexNotApplicable                          = 3909
 	ErrDynamicPrivilegeNotRegistered                         = 3929
+	ErrDependentByCheckConstraint                            = 3959
 	// MariaDB errors.
 	ErrOnlyOneDefaultPartionAllowed         = 4030
 	ErrWrongPartitionTypeExpectedSystemTime = 4113
@@ -1061,6 +1087,8 @@ const (
 	ErrPlacementPolicyInUse               = 8241
 	ErrOptOnCacheTable                    = 8242
 	ErrHTTPServiceError                   = 8243
+	ErrExecHypoIndex                      = 8244
+	ErrParquetWriterNotRegistered         = 8245
 	// TiKV/PD/TiFlash errors.
 	ErrPDServerTimeout           = 9001
 	ErrTiKVServerTimeout         = 9002
@@ -835,9 +835,13 @@ var MySQLErrName = map[uint16]*mysql.ErrMessage{
 	ErrGeneratedColumnRefAutoInc:                             mysql.Message("Generated column '%s' cannot refer to auto-increment column.", nil),
 	ErrWarnConflictingHint:                                   mysql.Message("Hint %s is ignored as conflicting/duplicated.", nil),
 	ErrUnresolvedHintName:                                    mysql.Message("Unresolved name '%s' for %s hint", nil),
+	ErrForeignKeyCascadeDepthExceeded:                        mysql.Message("Foreign key cascade delete/update exceeds max depth of %v.", nil),
+	ErrReferencedTrgDoesNotExist:                             mysql.Message("Referenced trigger '%s' for the given action time and event type does not exist.", nil),
 	ErrInvalidFieldSize:                                      mysql.Message("Invalid size for column '%s'.", nil),
 	ErrInvalidArgumentForLogarithm:                           mysql.Message("Invalid argument for logarithm", nil),
 	ErrAggregateOrderNonAggQuery:                             mysql.Message("Expression #%d of ORDER BY contains aggregate function and applies to the result of a non-aggregated query", nil),
+	ErrGISDifferentSRIDs:                                     mysql.Message("Binary geometry function %s given two geometries of different srids: %d and %d, which should have been identical.", nil),
+	ErrGISInvalidData:                                        mysql.Message("Invalid GIS data provided to function %s.", nil),
 	ErrIncorrectType:                                         mysql.Message("Incorrect type for argument %s in function %s.", nil),
 	ErrFieldInOrderNotSelect:                                 mysql.Message("Expression #%d of ORDER BY clause is not in SELECT list, references column '%s' which is not in SELECT list; this is incompatible with %s", nil),
 	ErrAggregateInOrderNotSelect:                             mysql.Message("Expression #%d of ORDER BY clause is not in SELECT list, contains aggregate function; this is incompatible with %s", nil),
@@ -856,6 +860,7 @@ var MySQLErrName = map[uint16]*mysql.ErrMessage{
 	ErrInvalidJSONPathArrayCell:                              mysql.Message("A path expression is not a path to a cell in an array.", nil),
 	ErrInvalidEncryptionOption:                               mysql.Message("Invalid encryption option.", nil),
 	ErrTooLongValueForType:                                   mysql.Message("Too long enumeration/set value for column %s.", nil),
+	ErrGISUnsupportedArgument:                                mysql.Message("Calling geometry function %s with unsupported types of arguments.", nil),
 	ErrPKIndexCantBeInvisible:                                mysql.Message("A primary key index cannot be invisible", nil),
 	ErrWindowNoSuchWindow:                                    mysql.Message("Window name '%s' is not defined.", nil),
 	ErrWindowCircularityInWindowGraph:                        mysql.Message("There is a circularity in the window dependency graph.", nil),
@@ -879,9 +884,19 @@ var MySQLErrName = map[uint16]*mysql.ErrMessage{
 	ErrWindowExplainJSON:                                     mysql.Message("To get information about window functions use EXPLAIN FORMAT=JSON", nil),
 	ErrWindowFunctionIgnoresFrame:                            mysql.Message("Window function '%s' ignores the frame clause of window '%s' and aggregates over the whole partition", nil),
 	ErrRoleNotGranted:                                        mysql.Message("%s is not granted to %s", nil),
+	ErrSrsNotFound:                                           mysql.Message("There's no spatial reference system with SRID %d.", nil),
+	ErrLongitudeOutOfRange:                                   mysql.Message("Longitude %f is out of range in function %s. It must be within (%f, %f].", nil),
+	ErrLatitudeOutOfRange:                                    mysql.Message("Latitude %f is out of range in function %s. It must be within [%f, %f].", nil),
+	ErrNotImplementedForGeographicSrs:                        mysql.Message("%s(%s) has not been implemented for geographic spatial reference systems.", nil),
 	ErrMaxExecTimeExceeded:                                   mysql.Message("Query execution was interrupted, max_execution_time exceeded.", nil),
 	ErrLockAcquireFailAndNoWaitSet:                           mysql.Message("Statement aborted because lock(s) could not be acquired immediately and NOWAIT is set.", nil),
 	ErrNotHintUpdatable:                                      mysql.Message("Variable '%s' cannot be set using SET_VAR hint.", nil),
+	ErrWrongSridForColumn:                                    mysql.Message("The SRID of the geometry does not match the SRID of the column '%s'. The SRID of the geometry is %d, but the SRID of the column is %d. Consider changing the SRID of the geometry or the SRID property of the column.", nil),
+	ErrMissingJSONTableValue:                                 mysql.Message("Missing value for JSON_TABLE column '%s'", nil),
+	ErrWrongJSONTableValue:                                   mysql.Message("Can't store an array or an object in the scalar column '%s' of JSON_TABLE", nil),
+	ErrTableFunctionForbiddenJoinType:                        mysql.Message("INNER or LEFT JOIN must be used for LATERAL references made by '%s'", nil),
+	ErrWarnUselessSpatialIndex:                               mysql.Message("The spatial index on column '%s' will not be used by the query optimizer since the column does not have an SRID attribute. Consider adding an SRID attribute to the column.", nil),
+	ErrNonpositiveRadius:                                     mysql.Message("Only positive radius values are allowed in %s.", nil),
 	ErrDataTruncatedFunctionalIndex:                          mysql.Message("Data truncated for expression index '%s' at row %d", nil),
 	ErrDataOutOfRangeFunctionalIndex:                         mysql.Message("Value is out of range for expression index '%s' at row %d", nil),
 	ErrFunctionalIndexOnJSONOrGeometryFunction:               mysql.Message("Cannot create an expression index on a function that returns a JSON or GEOMETRY value", nil),
@@ -896,6 +911,16 @@ var MySQLErrName = map[uint16]*mysql.ErrMessage{
 	ErrFunctionalIndexOnField:                                mysql.Message("Expression index on a column is not supported. Consider using a regular index instead", nil),
 	ErrFKIncompatibleColumns:                                 mysql.Message("Referencing column '%s' in foreign key constraint '%s' are incompatible", nil),
 	ErrFunctionalIndexRowValueIsNotAllowed:                   mysql.Message("Expression of expression index '%s' cannot refer to a row value", nil),
+	ErrColumnCheckConstraintReferencesOtherColumn:            mysql.Message("Column check constraint '%-.192s' references other column.", nil),
+	ErrCheckConstraintNamedFunctionIsNotAllowed:              mysql.Message("An expression of a check constraint '%-.192s' contains disallowed function: %s.", nil),
+	ErrCheckConstraintFunctionIsNotAllowed:                   mysql.Message("An expression of a check constraint '%-.192s' contains disallowed function.", nil),
+	ErrCheckConstraintVariables:                              mysql.Message("An expression of a check constraint '%-.192s' cannot refer to a user or system variable.", nil),
+	ErrCheckConstraintRowValue:                               mysql.Message("Check constraint '%-.192s' cannot refer to a row value.", nil),
+	ErrCheckConstraintRefersAutoIncrementColumn:              mysql.Message("Check constraint '%-.192s' cannot refer to an auto-increment column.", nil),
+	ErrCheckConstraintViolated:                               mysql.Message("Check constraint '%-.192s' is violated.", nil),
+	ErrCheckConstraintRefersUnknownColumn:                    mysql.Message("Check constraint '%-.192s' refers to non-existing column '%-.192s'.", nil),
+	ErrCheckConstraintNotFound:                               mysql.Message("Check constraint '%-.192s' is not found in the table.", nil),
+	ErrCheckConstraintDupName:                                mysql.Message("Duplicate check constraint name '%-.192s'.", nil),
 	ErrDependentByFunctionalIndex:                            mysql.Message("Column '%s' has an expression index dependency and cannot be dropped or renamed", nil),
 	ErrCannotConvertString:                                   mysql.Message("Cannot convert string '%.64s' from %s to %s", nil),
 	ErrInvalidJSONValueForFuncIndex:                          mysql.Message("Invalid JSON value for CAST for expression index '%s'", nil),
@@ -904,6 +929,7 @@ var MySQLErrName = map[uint16]*mysql.ErrMessage{
 	ErrFunctionalIndexNotApplicable:                          mysql.Message("Cannot use expression index '%s' due to type or collation conversion", nil),
 	ErrUnsupportedConstraintCheck:                            mysql.Message("%s is not supported", nil),
 	ErrDynamicPrivilegeNotRegistered:                         mysql.Message("Dynamic privilege '%s' is not registered with the server.", nil),
+	ErrDependentByCheckConstraint:                            mysql.Message("Check constraint '%-.192s' uses column '%-.192s', hence column cannot be dropped or renamed.", nil),
 	ErrIllegalPrivilegeLevel:                                 mysql.Message("Illegal privilege level specified for %s", nil),
 	ErrCTERecursiveRequiresUnion:                             mysql.Message("Recursive Common Table Expression '%s' should contain a UNION", nil),
 	ErrCTERecursiveRequiresNonRecursiveFirst:                 mysql.Message("Recursive Common Table Expression '%s' should have one or more non-recursive query blocks followed by one or more recursive ones", nil),
@@ -1067,6 +1093,8 @@ var MySQLErrName = map[uint16]*mysql.ErrMessage{
 	ErrPlacementPolicyWithDirectOption: mysql.Message("Placement policy '%s' can't co-exist with direct placement options", nil),
 	ErrPlacementPolicyInUse:            mysql.Message("Placement policy '%-.192s' is still in use", nil),
 	ErrOptOnCacheTable:                 mysql.Message("'%s' is unsupported on cache tables.", nil),
+	ErrExecHypoIndex:                   mysql.Message("The hypothetical index '%-.192s' can't be used to execute the statement", nil),
+	ErrParquetWriterNotRegistered:      mysql.Message("Outfile format parquet is not supported, the parquet writer is not registered", nil),
 	// TiKV/PD errors.
 	ErrPDServerTimeout:           mysql.Message("PD server timeout", nil),
 	ErrTiKVServerTimeout:         mysql.Message("TiKV server timeout", nil),
@@ -648,7 +648,7 @@ func buildFirstValue(aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc
 		args:    aggFuncDesc.Args,
 		ordinal: ordinal,
 	}
-	return &firstValue{baseAggFunc: base, tp: aggFuncDesc.RetTp}
+	return &firstValue{baseAggFunc: base, tp: aggFuncDesc.RetTp, ignoreNulls: aggFuncDesc.IgnoreNull}
 }
 
 func buildLastValue(aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
@@ -656,7 +656,7 @@ func buildLastValue(aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
 		args:    aggFuncDesc.Args,
 		ordinal: ordinal,
 	}
-	return &lastValue{baseAggFunc: base, tp: aggFuncDesc.RetTp}
+	return &lastValue{baseAggFunc: base, tp: aggFuncDesc.RetTp, ignoreNulls: aggFuncDesc.IgnoreNull}
 }
 
 func buildCumeDist(ordinal int, orderByCols []*expression.Column) AggFunc {
@@ -674,7 +674,7 @@ func buildNthValue(aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
 	}
 	// Already checked when building the function description.
 	nth, _, _ := expression.GetUint64FromConstant(aggFuncDesc.Args[1])
-	return &nthValue{baseAggFunc: base, tp: aggFuncDesc.RetTp, nth: nth}
+	return &nthValue{baseAggFunc: base, tp: aggFuncDesc.RetTp, nth: nth, ignoreNulls: aggFuncDesc.IgnoreNull, fromLast: aggFuncDesc.FromLast}
 }
 
 func buildNtile(aggFuncDes *aggregation.AggFuncDesc, ordinal int) AggFunc {
@@ -715,7 +715,7 @@ func buildLeadLag(ctx sessionctx.Context, aggFuncDesc *aggregation.AggFuncDesc,
 		ordinal: ordinal,
 	}
 	ve, _ := buildValueEvaluator(aggFuncDesc.RetTp)
-	return baseLeadLag{baseAggFunc: base, offset: offset, defaultExpr: defaultExpr, valueEvaluator: ve}
+	return baseLeadLag{baseAggFunc: base, offset: offset, defaultExpr: defaultExpr, valueEvaluator: ve, ignoreNulls: aggFuncDesc.IgnoreNull}
 }
 
 func buildLead(ctx sessionctx.Context, aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
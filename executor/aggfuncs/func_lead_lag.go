@@ -15,6 +15,7 @@
 package aggfuncs
 
 import (
+	"sort"
 	"unsafe"
 
 	"github.com/pingcap/tidb/expression"
@@ -33,11 +34,15 @@ type baseLeadLag struct {
 
 	defaultExpr expression.Expression
 	offset      uint64
+	// ignoreNulls indicates the offset is counted on the rows whose argument is not null.
+	ignoreNulls bool
 }
 
 type partialResult4LeadLag struct {
 	rows   []chunk.Row
 	curIdx uint64
+	// nonNullRows stores the indexes of the rows whose argument is not null, it's only used by IGNORE NULLS.
+	nonNullRows []uint64
 }
 
 func (v *baseLeadLag) AllocPartialResult() (pr PartialResult, memDelta int64) {
@@ -48,15 +53,53 @@ func (v *baseLeadLag) ResetPartialResult(pr PartialResult) {
 	p := (*partialResult4LeadLag)(pr)
 	p.rows = p.rows[:0]
 	p.curIdx = 0
+	p.nonNullRows = p.nonNullRows[:0]
 }
 
 func (v *baseLeadLag) UpdatePartialResult(sctx sessionctx.Context, rowsInGroup []chunk.Row, pr PartialResult) (memDelta int64, err error) {
 	p := (*partialResult4LeadLag)(pr)
+	if v.ignoreNulls {
+		for i, row := range rowsInGroup {
+			isNull, err := isNullArg(v.args[0], row)
+			if err != nil {
+				return 0, err
+			}
+			if !isNull {
+				p.nonNullRows = append(p.nonNullRows, uint64(len(p.rows)+i))
+				memDelta += DefUint64Size
+			}
+		}
+	}
 	p.rows = append(p.rows, rowsInGroup...)
 	memDelta += int64(len(rowsInGroup)) * DefRowSize
 	return memDelta, nil
 }
 
+// getTargetRow returns the index of the row that is `offset` rows after (or before if `isLag`) the current row.
+// With IGNORE NULLS, only the rows whose argument is not null are counted.
+func (v *baseLeadLag) getTargetRow(p *partialResult4LeadLag, isLag bool) (uint64, bool) {
+	if !v.ignoreNulls || v.offset == 0 {
+		if isLag {
+			return p.curIdx - v.offset, p.curIdx >= v.offset
+		}
+		return p.curIdx + v.offset, p.curIdx+v.offset < uint64(len(p.rows))
+	}
+	if isLag {
+		// pos is the number of the non-null rows before the current row.
+		pos := uint64(sort.Search(len(p.nonNullRows), func(i int) bool { return p.nonNullRows[i] >= p.curIdx }))
+		if pos < v.offset {
+			return 0, false
+		}
+		return p.nonNullRows[pos-v.offset], true
+	}
+	// pos is the position of the first non-null row after the current row.
+	pos := uint64(sort.Search(len(p.nonNullRows), func(i int) bool { return p.nonNullRows[i] > p.curIdx }))
+	if pos+v.offset-1 >= uint64(len(p.nonNullRows)) {
+		return 0, false
+	}
+	return p.nonNullRows[pos+v.offset-1], true
+}
+
 type lead struct {
 	baseLeadLag
 }
@@ -64,8 +107,8 @@ type lead struct {
 func (v *lead) AppendFinalResult2Chunk(sctx sessionctx.Context, pr PartialResult, chk *chunk.Chunk) error {
 	p := (*partialResult4LeadLag)(pr)
 	var err error
-	if p.curIdx+v.offset < uint64(len(p.rows)) {
-		_, err = v.evaluateRow(sctx, v.args[0], p.rows[p.curIdx+v.offset])
+	if idx, ok := v.getTargetRow(p, false); ok {
+		_, err = v.evaluateRow(sctx, v.args[0], p.rows[idx])
 	} else {
 		_, err = v.evaluateRow(sctx, v.defaultExpr, p.rows[p.curIdx])
 	}
@@ -84,8 +127,8 @@ type lag struct {
 func (v *lag) AppendFinalResult2Chunk(sctx sessionctx.Context, pr PartialResult, chk *chunk.Chunk) error {
 	p := (*partialResult4LeadLag)(pr)
 	var err error
-	if p.curIdx >= v.offset {
-		_, err = v.evaluateRow(sctx, v.args[0], p.rows[p.curIdx-v.offset])
+	if idx, ok := v.getTargetRow(p, true); ok {
+		_, err = v.evaluateRow(sctx, v.args[0], p.rows[idx])
 	} else {
 		_, err = v.evaluateRow(sctx, v.defaultExpr, p.rows[p.curIdx])
 	}
@@ -238,10 +238,20 @@ func buildValueEvaluator(tp *types.FieldType) (ve valueEvaluator, memDelta int64
 	return nil, 0
 }
 
+// isNullArg checks whether the argument is null on the row, it's used by IGNORE NULLS.
+func isNullArg(arg expression.Expression, row chunk.Row) (bool, error) {
+	d, err := arg.Eval(row)
+	if err != nil {
+		return false, err
+	}
+	return d.IsNull(), nil
+}
+
 type firstValue struct {
 	baseAggFunc
 
-	tp *types.FieldType
+	tp          *types.FieldType
+	ignoreNulls bool
 }
 
 type partialResult4FirstValue struct {
@@ -265,12 +275,22 @@ func (v *firstValue) UpdatePartialResult(sctx sessionctx.Context, rowsInGroup []
 	if p.gotFirstValue {
 		return 0, nil
 	}
-	if len(rowsInGroup) > 0 {
+	for _, row := range rowsInGroup {
+		if v.ignoreNulls {
+			isNull, err := isNullArg(v.args[0], row)
+			if err != nil {
+				return 0, err
+			}
+			if isNull {
+				continue
+			}
+		}
 		p.gotFirstValue = true
-		memDelta, err = p.evaluator.evaluateRow(sctx, v.args[0], rowsInGroup[0])
+		memDelta, err = p.evaluator.evaluateRow(sctx, v.args[0], row)
 		if err != nil {
 			return 0, err
 		}
+		break
 	}
 	return memDelta, nil
 }
@@ -288,7 +308,8 @@ func (v *firstValue) AppendFinalResult2Chunk(sctx sessionctx.Context, pr Partial
 type lastValue struct {
 	baseAggFunc
 
-	tp *types.FieldType
+	tp          *types.FieldType
+	ignoreNulls bool
 }
 
 type partialResult4LastValue struct {
@@ -309,12 +330,22 @@ func (v *lastValue) ResetPartialResult(pr PartialResult) {
 
 func (v *lastValue) UpdatePartialResult(sctx sessionctx.Context, rowsInGroup []chunk.Row, pr PartialResult) (memDelta int64, err error) {
 	p := (*partialResult4LastValue)(pr)
-	if len(rowsInGroup) > 0 {
+	for i := len(rowsInGroup) - 1; i >= 0; i-- {
+		if v.ignoreNulls {
+			isNull, err := isNullArg(v.args[0], rowsInGroup[i])
+			if err != nil {
+				return 0, err
+			}
+			if isNull {
+				continue
+			}
+		}
 		p.gotLastValue = true
-		memDelta, err = p.evaluator.evaluateRow(sctx, v.args[0], rowsInGroup[len(rowsInGroup)-1])
+		memDelta, err = p.evaluator.evaluateRow(sctx, v.args[0], rowsInGroup[i])
 		if err != nil {
 			return 0, err
 		}
+		break
 	}
 	return memDelta, nil
 }
@@ -332,11 +363,16 @@ func (v *lastValue) AppendFinalResult2Chunk(sctx sessionctx.Context, pr PartialR
 type nthValue struct {
 	baseAggFunc
 
-	tp  *types.FieldType
-	nth uint64
+	tp          *types.FieldType
+	nth         uint64
+	ignoreNulls bool
+	// fromLast indicates the rows are counted from the last row of the frame,
+	// the whole frame should be given in one UpdatePartialResult call.
+	fromLast bool
 }
 
 type partialResult4NthValue struct {
+	// seenRows is the number of rows counted, the null rows are not counted with IGNORE NULLS.
 	seenRows  uint64
 	evaluator valueEvaluator
 }
@@ -357,6 +393,9 @@ func (v *nthValue) UpdatePartialResult(sctx sessionctx.Context, rowsInGroup []ch
 		return 0, nil
 	}
 	p := (*partialResult4NthValue)(pr)
+	if v.ignoreNulls || v.fromLast {
+		return v.updateByCounting(sctx, rowsInGroup, p)
+	}
 	numRows := uint64(len(rowsInGroup))
 	if v.nth > p.seenRows && v.nth-p.seenRows <= numRows {
 		memDelta, err = p.evaluator.evaluateRow(sctx, v.args[0], rowsInGroup[v.nth-p.seenRows-1])
@@ -368,6 +407,30 @@ func (v *nthValue) UpdatePartialResult(sctx sessionctx.Context, rowsInGroup []ch
 	return memDelta, nil
 }
 
+// updateByCounting counts the rows one by one to find the nth row, it's used by IGNORE NULLS and FROM LAST.
+func (v *nthValue) updateByCounting(sctx sessionctx.Context, rowsInGroup []chunk.Row, p *partialResult4NthValue) (memDelta int64, err error) {
+	for i := range rowsInGroup {
+		row := rowsInGroup[i]
+		if v.fromLast {
+			row = rowsInGroup[len(rowsInGroup)-1-i]
+		}
+		if v.ignoreNulls {
+			isNull, err := isNullArg(v.args[0], row)
+			if err != nil {
+				return 0, err
+			}
+			if isNull {
+				continue
+			}
+		}
+		p.seenRows++
+		if p.seenRows == v.nth {
+			return p.evaluator.evaluateRow(sctx, v.args[0], row)
+		}
+	}
+	return 0, nil
+}
+
 func (v *nthValue) AppendFinalResult2Chunk(sctx sessionctx.Context, pr PartialResult, chk *chunk.Chunk) error {
 	p := (*partialResult4NthValue)(pr)
 	if v.nth == 0 || p.seenRows < v.nth {
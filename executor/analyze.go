@@ -220,6 +220,26 @@ func (e *AnalyzeExec) Next(ctx context.Context, req *chunk.Chunk) error {
 				}
 			}
 		}
+		if e.ctx.GetSessionVars().EnableExtendedStats {
+			// The global-level extended stats are merged after all the global-level column stats are saved.
+			is := e.ctx.GetInfoSchema().(infoschema.InfoSchema)
+			for globalStatsID := range globalStatsMap {
+				if globalStatsID.indexID != -1 {
+					continue
+				}
+				tbl, ok := is.TableByID(globalStatsID.tableID)
+				if !ok {
+					continue
+				}
+				extStats, err := statsHandle.MergePartitionExtStats2GlobalExtStats(tbl.Meta())
+				if err != nil {
+					return err
+				}
+				if err = statsHandle.SaveExtendedStatsToStorage(globalStatsID.tableID, extStats, false); err != nil {
+					logutil.Logger(ctx).Error("save global-level extended stats to storage failed", zap.Error(err))
+				}
+			}
+		}
 	}
 	err = e.saveAnalyzeOptsV2()
 	if err != nil {
@@ -1072,7 +1092,7 @@ func (e *AnalyzeColumnsExec) buildSamplingStats(
 	count = rootRowCollector.Base().Count
 	if needExtStats {
 		statsHandle := domain.GetDomain(e.ctx).StatsHandle()
-		extStats, err = statsHandle.BuildExtendedStats(e.TableID.GetStatisticsID(), e.colsInfo, sampleCollectors)
+		extStats, err = statsHandle.BuildExtendedStats(e.TableID.TableID, e.colsInfo, sampleCollectors)
 		if err != nil {
 			return 0, nil, nil, nil, nil, err
 		}
@@ -1552,7 +1572,7 @@ func (e *AnalyzeColumnsExec) buildStats(ranges []*ranger.Range, needExtStats boo
 	}
 	if needExtStats {
 		statsHandle := domain.GetDomain(e.ctx).StatsHandle()
-		extStats, err = statsHandle.BuildExtendedStats(e.TableID.GetStatisticsID(), e.colsInfo, collectors)
+		extStats, err = statsHandle.BuildExtendedStats(e.TableID.TableID, e.colsInfo, collectors)
 		if err != nil {
 			return nil, nil, nil, nil, nil, err
 		}
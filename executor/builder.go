@@ -99,6 +99,11 @@ type executorBuilder struct {
 	inDeleteStmt     bool
 	inInsertStmt     bool
 	inSelectLockStmt bool
+	// fkCascadeDepth is the depth of the foreign key cascade which builds the executors.
+	fkCascadeDepth int
+	// triggerTables are the IDs of the tables modified by the statements which activate the triggers,
+	// when the executors are built for a statement of a trigger body.
+	triggerTables map[int64]struct{}
 
 	// forDataReaderBuilder indicates whether the builder is used by a dataReaderBuilder.
 	// When forDataReader is true, the builder should use the dataReaderTS as the executor read ts. This is because
@@ -200,6 +205,8 @@ func (b *executorBuilder) build(p plannercore.Plan) Executor {
 		return b.buildIndexAdvise(v)
 	case *plannercore.PlanReplayer:
 		return b.buildPlanReplayer(v)
+	case *plannercore.XARecover:
+		return &XARecoverExec{baseExecutor: newBaseExecutor(b.ctx, v.Schema(), v.ID()), convertXID: v.ConvertXID}
 	case *plannercore.PhysicalLimit:
 		return b.buildLimit(v)
 	case *plannercore.Prepare:
@@ -260,6 +267,8 @@ func (b *executorBuilder) build(p plannercore.Plan) Executor {
 		return b.buildMemTable(v)
 	case *plannercore.PhysicalTableDual:
 		return b.buildTableDual(v)
+	case *plannercore.PhysicalJSONTable:
+		return b.buildJSONTable(v)
 	case *plannercore.PhysicalApply:
 		return b.buildApply(v)
 	case *plannercore.PhysicalMaxOneRow:
@@ -902,6 +911,18 @@ func (b *executorBuilder) buildInsert(v *plannercore.Insert) Executor {
 		b.err = err
 		return nil
 	}
+	ivs.fkChecks, b.err = b.buildFKCheckExecs(v.Table, v.FKChecks)
+	if b.err != nil {
+		return nil
+	}
+	ivs.fkCascades, b.err = b.buildFKCascadeExecs(v.Table, v.FKCascades)
+	if b.err != nil {
+		return nil
+	}
+	ivs.triggers = b.buildTriggerExec(v.Table)
+	if b.err != nil {
+		return nil
+	}
 
 	if v.IsReplace {
 		return b.buildReplace(ivs)
@@ -938,6 +959,7 @@ func (b *executorBuilder) buildLoadData(v *plannercore.LoadData) Executor {
 		ColumnAssignments:  v.ColumnAssignments,
 		ColumnsAndUserVars: v.ColumnsAndUserVars,
 		Ctx:                b.ctx,
+		IsLocal:            v.IsLocal,
 	}
 	columnNames := loadDataInfo.initFieldMappings()
 	err := loadDataInfo.initLoadColumns(columnNames)
@@ -1091,6 +1113,7 @@ func (b *executorBuilder) buildSelectInto(v *plannercore.SelectInto) Executor {
 	return &SelectIntoExec{
 		baseExecutor: newBaseExecutor(b.ctx, v.Schema(), v.ID(), child),
 		intoOpt:      v.IntoOpt,
+		outputNames:  v.TargetNames,
 	}
 }
 
@@ -1526,6 +1549,15 @@ func (b *executorBuilder) buildTableDual(v *plannercore.PhysicalTableDual) Execu
 	return e
 }
 
+func (b *executorBuilder) buildJSONTable(v *plannercore.PhysicalJSONTable) Executor {
+	e := &JSONTableExec{
+		baseExecutor: newBaseExecutor(b.ctx, v.Schema(), v.ID()),
+		expr:         v.Expr,
+		rootPath:     v.RootPath,
+	}
+	return e
+}
+
 // `getSnapshotTS` returns for-update-ts if in insert/update/delete/lock statement otherwise the isolation read ts
 // Please notice that in RC isolation, the above two ts are the same
 func (b *executorBuilder) getSnapshotTS() (uint64, error) {
@@ -1704,6 +1736,8 @@ func (b *executorBuilder) buildMemTable(v *plannercore.PhysicalMemTable) Executo
 			strings.ToLower(infoschema.TableStatistics),
 			strings.ToLower(infoschema.TableTiDBIndexes),
 			strings.ToLower(infoschema.TableViews),
+			strings.ToLower(infoschema.TableRoutines),
+			strings.ToLower(infoschema.TableTriggers),
 			strings.ToLower(infoschema.TableTables),
 			strings.ToLower(infoschema.TableReferConst),
 			strings.ToLower(infoschema.TableSequences),
@@ -1733,7 +1767,10 @@ func (b *executorBuilder) buildMemTable(v *plannercore.PhysicalMemTable) Executo
 			strings.ToLower(infoschema.TableClientErrorsSummaryByUser),
 			strings.ToLower(infoschema.TableClientErrorsSummaryByHost),
 			strings.ToLower(infoschema.TableAttributes),
-			strings.ToLower(infoschema.TablePlacementPolicies):
+			strings.ToLower(infoschema.TablePlacementPolicies),
+			strings.ToLower(infoschema.TableCheckConstraints),
+			strings.ToLower(infoschema.TableEvents),
+			strings.ToLower(infoschema.TableEventHistory):
 			return &MemTableReaderExec{
 				baseExecutor: newBaseExecutor(b.ctx, v.Schema(), v.ID()),
 				table:        v.Table,
@@ -2103,6 +2140,14 @@ func (b *executorBuilder) buildUpdate(v *plannercore.Update) Executor {
 		tblColPosInfos:            v.TblColPosInfos,
 		assignFlag:                assignFlag,
 	}
+	updateExec.fkChecks, updateExec.fkCascades, b.err = b.buildFKExecsForTables(tblID2table, v.FKChecks, v.FKCascades)
+	if b.err != nil {
+		return nil
+	}
+	updateExec.triggers = b.buildTriggerExecsForTables(tblID2table)
+	if b.err != nil {
+		return nil
+	}
 	return updateExec
 }
 
@@ -2146,6 +2191,14 @@ func (b *executorBuilder) buildDelete(v *plannercore.Delete) Executor {
 		IsMultiTable:   v.IsMultiTable,
 		tblColPosInfos: v.TblColPosInfos,
 	}
+	deleteExec.fkChecks, deleteExec.fkCascades, b.err = b.buildFKExecsForTables(tblID2table, v.FKChecks, v.FKCascades)
+	if b.err != nil {
+		return nil
+	}
+	deleteExec.triggers = b.buildTriggerExecsForTables(tblID2table)
+	if b.err != nil {
+		return nil
+	}
 	return deleteExec
 }
 
@@ -3383,12 +3436,23 @@ func prunePartitionForInnerExecutor(ctx sessionctx.Context, tbl table.Table, sch
 	return usedPartition, true, contentPos, nil
 }
 
+// checkHypoIndex returns an error if the index is a hypothetical index, which has no data to be read.
+func checkHypoIndex(idxInfo *model.IndexInfo) error {
+	if idxInfo != nil && idxInfo.Tp == model.IndexTypeHypo {
+		return ErrExecHypoIndex.GenWithStackByArgs(idxInfo.Name.O)
+	}
+	return nil
+}
+
 func buildNoRangeIndexReader(b *executorBuilder, v *plannercore.PhysicalIndexReader) (*IndexReaderExecutor, error) {
+	is := v.IndexPlans[0].(*plannercore.PhysicalIndexScan)
+	if err := checkHypoIndex(is.Index); err != nil {
+		return nil, err
+	}
 	dagReq, streaming, err := constructDAGReq(b.ctx, v.IndexPlans, kv.TiKV)
 	if err != nil {
 		return nil, err
 	}
-	is := v.IndexPlans[0].(*plannercore.PhysicalIndexScan)
 	tbl, _ := b.is.TableByID(is.Table.ID)
 	isPartition, physicalTableID := is.IsPartition()
 	if isPartition {
@@ -3531,6 +3595,9 @@ func buildIndexReq(ctx sessionctx.Context, schemaLen, handleLen int, plans []pla
 
 func buildNoRangeIndexLookUpReader(b *executorBuilder, v *plannercore.PhysicalIndexLookUpReader) (*IndexLookUpExecutor, error) {
 	is := v.IndexPlans[0].(*plannercore.PhysicalIndexScan)
+	if err := checkHypoIndex(is.Index); err != nil {
+		return nil, err
+	}
 	var handleLen int
 	if len(v.CommonHandleCols) != 0 {
 		handleLen = len(v.CommonHandleCols)
@@ -3674,6 +3741,13 @@ func (b *executorBuilder) buildIndexLookUpReader(v *plannercore.PhysicalIndexLoo
 }
 
 func buildNoRangeIndexMergeReader(b *executorBuilder, v *plannercore.PhysicalIndexMergeReader) (*IndexMergeReaderExecutor, error) {
+	for _, partialPlan := range v.PartialPlans {
+		if is, ok := partialPlan[0].(*plannercore.PhysicalIndexScan); ok {
+			if err := checkHypoIndex(is.Index); err != nil {
+				return nil, err
+			}
+		}
+	}
 	partialPlanCount := len(v.PartialPlans)
 	partialReqs := make([]*tipb.DAGRequest, 0, partialPlanCount)
 	partialStreamings := make([]bool, 0, partialPlanCount)
@@ -3746,6 +3820,7 @@ func buildNoRangeIndexMergeReader(b *executorBuilder, v *plannercore.PhysicalInd
 		isCorColInPartialFilters: isCorColInPartialFilters,
 		isCorColInTableFilter:    isCorColInTableFilter,
 		isCorColInPartialAccess:  isCorColInPartialAccess,
+		isIntersection:           v.IsIntersectionType,
 	}
 	collectTable := false
 	e.tableRequest.CollectRangeCounts = &collectTable
@@ -4349,7 +4424,7 @@ func (b *executorBuilder) buildWindow(v *plannercore.PhysicalWindow) Executor {
 	partialResults := make([]aggfuncs.PartialResult, 0, len(v.WindowFuncDescs))
 	resultColIdx := v.Schema().Len() - len(v.WindowFuncDescs)
 	for _, desc := range v.WindowFuncDescs {
-		aggDesc, err := aggregation.NewAggFuncDescForWindowFunc(b.ctx, desc, false)
+		aggDesc, err := aggregation.NewAggFuncDescForWindowFunc(b.ctx, desc)
 		if err != nil {
 			b.err = err
 			return nil
@@ -4361,7 +4436,8 @@ func (b *executorBuilder) buildWindow(v *plannercore.PhysicalWindow) Executor {
 		resultColIdx++
 	}
 
-	if b.ctx.GetSessionVars().EnablePipelinedWindowExec {
+	// The `GROUPS` frame needs the peer groups of the whole partition, so it's only supported by WindowExec.
+	if b.ctx.GetSessionVars().EnablePipelinedWindowExec && (v.Frame == nil || v.Frame.Type != ast.Groups) {
 		exec := &PipelinedWindowExec{
 			baseExecutor:   base,
 			groupChecker:   newVecGroupChecker(b.ctx, groupByItems),
@@ -4407,6 +4483,19 @@ func (b *executorBuilder) buildWindow(v *plannercore.PhysicalWindow) Executor {
 			start:          v.Frame.Start,
 			end:            v.Frame.End,
 		}
+	} else if v.Frame.Type == ast.Groups {
+		cmpFuncs := make([]expression.CompareFunc, 0, len(orderByCols))
+		for _, col := range orderByCols {
+			cmpFuncs = append(cmpFuncs, expression.GetCmpFunction(b.ctx, col, col))
+		}
+		processor = &groupsFrameWindowProcessor{
+			windowFuncs:    windowFuncs,
+			partialResults: partialResults,
+			start:          v.Frame.Start,
+			end:            v.Frame.End,
+			orderByCols:    orderByCols,
+			cmpFuncs:       cmpFuncs,
+		}
 	} else {
 		cmpResult := int64(-1)
 		if len(v.OrderBy) > 0 && v.OrderBy[0].Desc {
@@ -4565,6 +4654,10 @@ func (b *executorBuilder) buildBatchPointGet(plan *plannercore.BatchPointGetPlan
 		b.err = err
 		return nil
 	}
+	if err := checkHypoIndex(plan.IndexInfo); err != nil {
+		b.err = err
+		return nil
+	}
 
 	startTS, err := b.getSnapshotTS()
 	if err != nil {
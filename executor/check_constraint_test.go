@@ -0,0 +1,104 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor_test
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/testkit"
+)
+
+func TestCheckConstraintOnWrite(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t (id int primary key, a int check (a > 0), b int, constraint c_ab check (a < b))")
+
+	tk.MustExec("insert into t values (1, 1, 2), (2, null, 1)")
+	tk.MustGetErrCode("insert into t values (3, 0, 2)", errno.ErrCheckConstraintViolated)
+	tk.MustGetErrCode("insert into t values (3, 2, 2)", errno.ErrCheckConstraintViolated)
+	tk.MustGetErrCode("update t set b = 1 where id = 1", errno.ErrCheckConstraintViolated)
+	tk.MustGetErrCode("replace into t values (1, 5, 1)", errno.ErrCheckConstraintViolated)
+	tk.MustGetErrCode("insert into t values (1, 1, 2) on duplicate key update a = 10", errno.ErrCheckConstraintViolated)
+	tk.MustExec("replace into t values (1, 5, 6)")
+	tk.MustQuery("select * from t order by id").Check(testkit.Rows("1 5 6", "2 <nil> 1"))
+
+	// The violated rows are skipped with warnings under IGNORE.
+	tk.MustExec("insert ignore into t values (3, 0, 1), (4, 1, 2)")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Warning 3819 Check constraint 't_chk_1' is violated."))
+	tk.MustExec("update ignore t set b = 0")
+	tk.MustQuery("select * from t order by id").Check(testkit.Rows("1 5 6", "2 <nil> 0", "4 1 2"))
+
+	// NOT ENFORCED constraints are not checked.
+	tk.MustExec("alter table t alter check c_ab not enforced")
+	tk.MustExec("insert into t values (5, 3, 2)")
+	tk.MustGetErrCode("alter table t alter check c_ab enforced", errno.ErrCheckConstraintViolated)
+	tk.MustExec("insert into t values (6, 3, 2)")
+	tk.MustExec("delete from t where a >= b")
+	tk.MustExec("alter table t alter check c_ab enforced")
+	tk.MustGetErrCode("insert into t values (5, 3, 2)", errno.ErrCheckConstraintViolated)
+}
+
+func TestCheckConstraintDDL(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+
+	tk.MustGetErrCode("create table t (a int, constraint c check (a > 0), constraint c check (a < 10))", errno.ErrCheckConstraintDupName)
+	tk.MustGetErrCode("create table t (a int, check (b > 0))", errno.ErrCheckConstraintRefersUnknownColumn)
+	tk.MustGetErrCode("create table t (a int auto_increment primary key, check (a > 0))", errno.ErrCheckConstraintRefersAutoIncrementColumn)
+	tk.MustGetErrCode("create table t (a int, check (a > rand()))", errno.ErrCheckConstraintNamedFunctionIsNotAllowed)
+	tk.MustGetErrCode("create table t (a int, check (a > @x))", errno.ErrCheckConstraintVariables)
+
+	tk.MustExec("create table t (a int, b int, c int check (c > 0), check (a > 0), constraint c_ab check (a < b) not enforced)")
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `a` int(11) DEFAULT NULL,\n" +
+		"  `b` int(11) DEFAULT NULL,\n" +
+		"  `c` int(11) DEFAULT NULL,\n" +
+		"  CONSTRAINT `c_ab` CHECK ((`a` < `b`)) /*!80016 NOT ENFORCED */,\n" +
+		"  CONSTRAINT `t_chk_1` CHECK ((`a` > 0)),\n" +
+		"  CONSTRAINT `t_chk_2` CHECK ((`c` > 0))\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+	tk.MustQuery("select constraint_schema, constraint_name, check_clause from information_schema.check_constraints where constraint_schema = 'test' order by constraint_name").Check(testkit.Rows(
+		"test c_ab (`a` < `b`)",
+		"test t_chk_1 (`a` > 0)",
+		"test t_chk_2 (`c` > 0)",
+	))
+	tk.MustQuery("select constraint_name, constraint_type from information_schema.table_constraints where table_schema = 'test' and table_name = 't' order by constraint_name").Check(testkit.Rows(
+		"c_ab CHECK",
+		"t_chk_1 CHECK",
+		"t_chk_2 CHECK",
+	))
+
+	// The column referred by a multi-column constraint cannot be dropped or renamed.
+	tk.MustGetErrCode("alter table t drop column a", errno.ErrDependentByCheckConstraint)
+	tk.MustGetErrCode("alter table t rename column b to b1", errno.ErrDependentByCheckConstraint)
+	tk.MustGetErrCode("alter table t change column a a1 int", errno.ErrDependentByCheckConstraint)
+	// Dropping the only column of a constraint drops the constraint as well.
+	tk.MustExec("alter table t drop column c")
+	tk.MustQuery("select constraint_name from information_schema.check_constraints where constraint_schema = 'test' order by constraint_name").Check(testkit.Rows("c_ab", "t_chk_1"))
+
+	tk.MustExec("alter table t drop check c_ab")
+	tk.MustExec("alter table t drop column b")
+	tk.MustExec("alter table t add constraint check (a < 10)")
+	tk.MustQuery("select constraint_name from information_schema.check_constraints where constraint_schema = 'test' order by constraint_name").Check(testkit.Rows("t_chk_1", "t_chk_2"))
+	tk.MustGetErrCode("insert into t values (10)", errno.ErrCheckConstraintViolated)
+	tk.MustGetErrCode("alter table t drop check c_ab", errno.ErrCheckConstraintNotFound)
+}
@@ -99,6 +99,14 @@ func (e *DDLExec) Next(ctx context.Context, req *chunk.Chunk) (err error) {
 		if s.TemporaryKeyword == ast.TemporaryLocal {
 			return e.createSessionTemporaryTable(s)
 		}
+	case *ast.CreateIndexStmt:
+		if s.IndexOption != nil && s.IndexOption.Tp == model.IndexTypeHypo {
+			return e.createHypoIndex(s)
+		}
+	case *ast.DropIndexStmt:
+		if s.IsHypo {
+			return e.dropHypoIndex(s)
+		}
 	case *ast.DropTableStmt:
 		if s.IsView {
 			break
@@ -185,6 +193,14 @@ func (e *DDLExec) Next(ctx context.Context, req *chunk.Chunk) (err error) {
 		err = e.executeDropSequence(x)
 	case *ast.AlterSequenceStmt:
 		err = e.executeAlterSequence(x)
+	case *ast.CreateProcedureStmt:
+		err = e.executeCreateProcedure(x)
+	case *ast.DropProcedureStmt:
+		err = e.executeDropProcedure(x)
+	case *ast.CreateTriggerStmt:
+		err = e.executeCreateTrigger(x)
+	case *ast.DropTriggerStmt:
+		err = e.executeDropTrigger(x)
 	case *ast.CreatePlacementPolicyStmt:
 		if x.OrReplace && x.IfNotExists {
 			err = dbterror.ErrWrongUsage.GenWithStackByArgs("OR REPLACE", "IF NOT EXISTS")
@@ -402,6 +418,9 @@ func (e *DDLExec) executeDropDatabase(s *ast.DropDatabaseStmt) error {
 		} else {
 			err = infoschema.ErrDatabaseDropExists.GenWithStackByArgs(s.Name)
 		}
+	} else if err == nil {
+		// The events are dropped with their schema.
+		err = dropSchemaEvents(context.Background(), e.ctx, dbName)
 	}
 	sessionVars := e.ctx.GetSessionVars()
 	if err == nil && strings.ToLower(sessionVars.CurrentDB) == dbName.L {
@@ -546,6 +565,85 @@ func (e *DDLExec) dropLocalTemporaryTables(localTempTables []*ast.TableName) err
 	return nil
 }
 
+// createHypoIndex creates a hypothetical index in the session, which is only seen by the optimizer of the session.
+func (e *DDLExec) createHypoIndex(s *ast.CreateIndexStmt) error {
+	tbl, err := e.is.TableByName(s.Table.Schema, s.Table.Name)
+	if err != nil {
+		return err
+	}
+	tblInfo := tbl.Meta()
+	if tblInfo.TempTableType == model.TempTableLocal {
+		return dbterror.ErrUnsupportedLocalTempTableDDL.GenWithStackByArgs("CREATE HYPO INDEX")
+	}
+	if tblInfo.IsView() || tblInfo.IsSequence() || !tbl.Type().IsNormalTable() {
+		return dbterror.ErrWrongObject.GenWithStackByArgs(s.Table.Schema, s.Table.Name, "BASE TABLE")
+	}
+	if s.KeyType != ast.IndexKeyTypeNone && s.KeyType != ast.IndexKeyTypeUnique {
+		return dbterror.ErrUnsupportedIndexType.GenWithStack("hypothetical index can only be a normal or unique index")
+	}
+	sessVars := e.ctx.GetSessionVars()
+	indexName := model.NewCIStr(s.IndexName)
+	if tblInfo.FindIndexByName(indexName.L) != nil || findHypoIndex(sessVars.HypoIndexes[tblInfo.ID], indexName) >= 0 {
+		err = dbterror.ErrDupKeyName.GenWithStack("index already exist %s", indexName)
+		if s.IfNotExists {
+			sessVars.StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+	idxInfo, err := ddl.BuildHypoIndexInfo(tblInfo, indexName, s.KeyType == ast.IndexKeyTypeUnique, s.IndexPartSpecifications)
+	if err != nil {
+		return err
+	}
+	if s.IndexOption != nil {
+		idxInfo.Comment = s.IndexOption.Comment
+	}
+	if sessVars.HypoIndexes == nil {
+		sessVars.HypoIndexes = make(map[int64][]*model.IndexInfo)
+	}
+	sessVars.HypoIndexes[tblInfo.ID] = append(sessVars.HypoIndexes[tblInfo.ID], idxInfo)
+	return nil
+}
+
+// dropHypoIndex drops a hypothetical index of the session.
+func (e *DDLExec) dropHypoIndex(s *ast.DropIndexStmt) error {
+	tbl, err := e.is.TableByName(s.Table.Schema, s.Table.Name)
+	if err != nil {
+		if (infoschema.ErrDatabaseNotExists.Equal(err) || infoschema.ErrTableNotExists.Equal(err)) && s.IfExists {
+			return nil
+		}
+		return err
+	}
+	sessVars := e.ctx.GetSessionVars()
+	tblID := tbl.Meta().ID
+	hypoIndexes := sessVars.HypoIndexes[tblID]
+	i := findHypoIndex(hypoIndexes, model.NewCIStr(s.IndexName))
+	if i < 0 {
+		err = dbterror.ErrCantDropFieldOrKey.GenWithStack("index %s doesn't exist", s.IndexName)
+		if s.IfExists {
+			sessVars.StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+	hypoIndexes = append(hypoIndexes[:i:i], hypoIndexes[i+1:]...)
+	if len(hypoIndexes) == 0 {
+		delete(sessVars.HypoIndexes, tblID)
+	} else {
+		sessVars.HypoIndexes[tblID] = hypoIndexes
+	}
+	return nil
+}
+
+func findHypoIndex(hypoIndexes []*model.IndexInfo, name model.CIStr) int {
+	for i, idxInfo := range hypoIndexes {
+		if idxInfo.Name.L == name.L {
+			return i
+		}
+	}
+	return -1
+}
+
 func (e *DDLExec) executeDropIndex(s *ast.DropIndexStmt) error {
 	ti := ast.Ident{Schema: s.Table.Schema, Name: s.Table.Name}
 	if _, ok := e.getLocalTemporaryTable(ti.Schema, ti.Name); ok {
@@ -803,6 +901,22 @@ func (e *DDLExec) executeAlterSequence(s *ast.AlterSequenceStmt) error {
 	return domain.GetDomain(e.ctx).DDL().AlterSequence(e.ctx, s)
 }
 
+func (e *DDLExec) executeCreateProcedure(s *ast.CreateProcedureStmt) error {
+	return domain.GetDomain(e.ctx).DDL().CreateProcedure(e.ctx, s)
+}
+
+func (e *DDLExec) executeDropProcedure(s *ast.DropProcedureStmt) error {
+	return domain.GetDomain(e.ctx).DDL().DropProcedure(e.ctx, s)
+}
+
+func (e *DDLExec) executeCreateTrigger(s *ast.CreateTriggerStmt) error {
+	return domain.GetDomain(e.ctx).DDL().CreateTrigger(e.ctx, s)
+}
+
+func (e *DDLExec) executeDropTrigger(s *ast.DropTriggerStmt) error {
+	return domain.GetDomain(e.ctx).DDL().DropTrigger(e.ctx, s)
+}
+
 func (e *DDLExec) executeCreatePlacementPolicy(s *ast.CreatePlacementPolicyStmt) error {
 	return domain.GetDomain(e.ctx).DDL().CreatePlacementPolicy(e.ctx, s)
 }
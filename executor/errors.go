@@ -53,12 +53,40 @@ var (
 	ErrIllegalPrivilegeLevel         = dbterror.ClassExecutor.NewStd(mysql.ErrIllegalPrivilegeLevel)
 	ErrInvalidSplitRegionRanges      = dbterror.ClassExecutor.NewStd(mysql.ErrInvalidSplitRegionRanges)
 	ErrViewInvalid                   = dbterror.ClassExecutor.NewStd(mysql.ErrViewInvalid)
+	ErrRowIsReferenced2              = dbterror.ClassExecutor.NewStd(mysql.ErrRowIsReferenced2)
+	ErrNoReferencedRow2              = dbterror.ClassExecutor.NewStd(mysql.ErrNoReferencedRow2)
+
+	ErrForeignKeyCascadeDepthExceeded = dbterror.ClassExecutor.NewStd(mysql.ErrForeignKeyCascadeDepthExceeded)
+
+	ErrCommitNotAllowedInSfOrTrg    = dbterror.ClassExecutor.NewStd(mysql.ErrCommitNotAllowedInSfOrTrg)
+	ErrCantUpdateUsedTableInSfOrTrg = dbterror.ClassExecutor.NewStd(mysql.ErrCantUpdateUsedTableInSfOrTrg)
+
+	ErrEventAlreadyExists               = dbterror.ClassExecutor.NewStd(mysql.ErrEventAlreadyExists)
+	ErrEventDoesNotExist                = dbterror.ClassExecutor.NewStd(mysql.ErrEventDoesNotExist)
+	ErrEventIntervalNotPositiveOrTooBig = dbterror.ClassExecutor.NewStd(mysql.ErrEventIntervalNotPositiveOrTooBig)
+	ErrEventEndsBeforeStarts            = dbterror.ClassExecutor.NewStd(mysql.ErrEventEndsBeforeStarts)
+	ErrEventExecTimeInThePast           = dbterror.ClassExecutor.NewStd(mysql.ErrEventExecTimeInThePast)
+	ErrEventSameName                    = dbterror.ClassExecutor.NewStd(mysql.ErrEventSameName)
+	ErrEventCannotCreateInThePast       = dbterror.ClassExecutor.NewStd(mysql.ErrEventCannotCreateInThePast)
+	ErrEventCannotAlterInThePast        = dbterror.ClassExecutor.NewStd(mysql.ErrEventCannotAlterInThePast)
+	ErrWrongValue                       = dbterror.ClassExecutor.NewStd(mysql.ErrWrongValue)
+	ErrFileExists                       = dbterror.ClassExecutor.NewStd(mysql.ErrFileExists)
+	ErrExecHypoIndex                    = dbterror.ClassExecutor.NewStd(mysql.ErrExecHypoIndex)
+	ErrParquetWriterNotRegistered       = dbterror.ClassExecutor.NewStd(mysql.ErrParquetWriterNotRegistered)
+
+	ErrXaerNota     = dbterror.ClassExecutor.NewStd(mysql.ErrXaerNota)
+	ErrXaerRmfail   = dbterror.ClassExecutor.NewStd(mysql.ErrXaerRmfail)
+	ErrXaerOutside  = dbterror.ClassExecutor.NewStd(mysql.ErrXaerOutside)
+	ErrXaerDupid    = dbterror.ClassExecutor.NewStd(mysql.ErrXaerDupid)
+	ErrXaRbrollback = dbterror.ClassExecutor.NewStd(mysql.ErrXaRbrollback)
 
 	ErrBRIEBackupFailed      = dbterror.ClassExecutor.NewStd(mysql.ErrBRIEBackupFailed)
 	ErrBRIERestoreFailed     = dbterror.ClassExecutor.NewStd(mysql.ErrBRIERestoreFailed)
 	ErrBRIEImportFailed      = dbterror.ClassExecutor.NewStd(mysql.ErrBRIEImportFailed)
 	ErrBRIEExportFailed      = dbterror.ClassExecutor.NewStd(mysql.ErrBRIEExportFailed)
 	ErrCTEMaxRecursionDepth  = dbterror.ClassExecutor.NewStd(mysql.ErrCTEMaxRecursionDepth)
+	ErrMissingJSONTableValue = dbterror.ClassExecutor.NewStd(mysql.ErrMissingJSONTableValue)
+	ErrWrongJSONTableValue   = dbterror.ClassExecutor.NewStd(mysql.ErrWrongJSONTableValue)
 	ErrNotSupportedWithSem   = dbterror.ClassOptimizer.NewStd(mysql.ErrNotSupportedWithSem)
 	ErrPluginIsNotLoaded     = dbterror.ClassExecutor.NewStd(mysql.ErrPluginIsNotLoaded)
 	ErrSetPasswordAuthPlugin = dbterror.ClassExecutor.NewStd(mysql.ErrSetPasswordAuthPlugin)
@@ -0,0 +1,439 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/ddl"
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/format"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/dbterror"
+	"github.com/pingcap/tidb/util/sqlexec"
+)
+
+// The values of mysql.event.status and mysql.event.on_completion.
+const (
+	EventEnabled     = "ENABLED"
+	EventDisabled    = "DISABLED"
+	EventPreserve    = "PRESERVE"
+	EventNotPreserve = "NOT PRESERVE"
+)
+
+// EventInfo is a scheduled event stored in mysql.event. The times are in UTC,
+// and a zero time is a NULL value.
+type EventInfo struct {
+	Schema        string
+	Name          string
+	Definer       string
+	Body          string
+	ExecuteAt     time.Time
+	IntervalValue string
+	IntervalField string
+	Starts        time.Time
+	Ends          time.Time
+	Status        string
+	OnCompletion  string
+	SQLMode       string
+	TimeZone      string
+	Charset       string
+	Collation     string
+	Comment       string
+	Created       time.Time
+	LastAltered   time.Time
+	LastExecuted  time.Time
+	NextExecute   time.Time
+}
+
+const eventColumns = "event_schema, event_name, definer, body, execute_at, interval_value, interval_field, starts, ends, " +
+	"status, on_completion, sql_mode, time_zone, character_set_client, collation_connection, event_comment, " +
+	"created, last_altered, last_executed, next_execute"
+
+// LoadEvents loads the events which match the condition from mysql.event.
+func LoadEvents(ctx context.Context, sctx sessionctx.Context, where string, args ...interface{}) ([]*EventInfo, error) {
+	exec := sctx.(sqlexec.RestrictedSQLExecutor)
+	rows, _, err := exec.ExecRestrictedSQL(ctx, nil, "SELECT "+eventColumns+" FROM mysql.event WHERE "+where+" ORDER BY event_schema, event_name", args...)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]*EventInfo, 0, len(rows))
+	for _, row := range rows {
+		events = append(events, &EventInfo{
+			Schema:        row.GetString(0),
+			Name:          row.GetString(1),
+			Definer:       row.GetString(2),
+			Body:          row.GetString(3),
+			ExecuteAt:     eventTime(row, 4),
+			IntervalValue: row.GetString(5),
+			IntervalField: row.GetString(6),
+			Starts:        eventTime(row, 7),
+			Ends:          eventTime(row, 8),
+			Status:        row.GetString(9),
+			OnCompletion:  row.GetString(10),
+			SQLMode:       row.GetString(11),
+			TimeZone:      row.GetString(12),
+			Charset:       row.GetString(13),
+			Collation:     row.GetString(14),
+			Comment:       row.GetString(15),
+			Created:       eventTime(row, 16),
+			LastAltered:   eventTime(row, 17),
+			LastExecuted:  eventTime(row, 18),
+			NextExecute:   eventTime(row, 19),
+		})
+	}
+	return events, nil
+}
+
+func loadEvent(ctx context.Context, sctx sessionctx.Context, schema, name model.CIStr) (*EventInfo, error) {
+	events, err := LoadEvents(ctx, sctx, "LOWER(event_schema) = %? AND LOWER(event_name) = %?", schema.L, name.L)
+	if err != nil || len(events) == 0 {
+		return nil, err
+	}
+	return events[0], nil
+}
+
+func eventTime(row chunk.Row, i int) time.Time {
+	if row.IsNull(i) {
+		return time.Time{}
+	}
+	t, err := row.GetTime(i).GoTime(time.UTC)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// eventTimeArg converts a time to the argument of an internal SQL, a zero time is NULL.
+func eventTimeArg(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return t
+}
+
+// eventTimeValue converts a time of an event to a DATETIME value in the location, a zero time is NULL.
+func eventTimeValue(t time.Time, loc *time.Location) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return types.NewTime(types.FromGoTime(t.In(loc)), mysql.TypeDatetime, 0)
+}
+
+// NextTime returns the first time not before `from` when the event fires, it
+// returns a zero time if the event doesn't fire any more. A recurring event
+// fires at STARTS + k * INTERVAL, the executions missed are skipped.
+func (ev *EventInfo) NextTime(from time.Time) (time.Time, error) {
+	if !ev.ExecuteAt.IsZero() {
+		if ev.ExecuteAt.Before(from) {
+			return time.Time{}, nil
+		}
+		return ev.ExecuteAt, nil
+	}
+	if strings.Contains(ev.IntervalField, "MICROSECOND") {
+		return time.Time{}, dbterror.ErrNotSupportedYet.GenWithStackByArgs(ev.IntervalField)
+	}
+	y, m, d, n, err := types.ParseDurationValue(ev.IntervalField, ev.IntervalValue)
+	if err != nil {
+		return time.Time{}, err
+	}
+	months := 12*y + m
+	dur := time.Duration(d)*24*time.Hour + time.Duration(n)
+	if months < 0 || dur < 0 || (months == 0 && dur == 0) {
+		return time.Time{}, ErrEventIntervalNotPositiveOrTooBig.GenWithStackByArgs()
+	}
+	next := ev.Starts
+	if next.Before(from) {
+		var k int64
+		if months == 0 {
+			k = int64(from.Sub(ev.Starts) / dur)
+		} else {
+			elapsed := int64(from.Year()-ev.Starts.Year())*12 + int64(from.Month()-ev.Starts.Month()) - 1
+			if elapsed > 0 {
+				k = elapsed / months
+			}
+		}
+		for next = addEventInterval(ev.Starts, k, months, dur); next.Before(from); {
+			k++
+			next = addEventInterval(ev.Starts, k, months, dur)
+		}
+	}
+	if !ev.Ends.IsZero() && next.After(ev.Ends) {
+		return time.Time{}, nil
+	}
+	return next, nil
+}
+
+func addEventInterval(t time.Time, k, months int64, dur time.Duration) time.Time {
+	return t.AddDate(0, int(k*months), 0).Add(time.Duration(k) * dur)
+}
+
+// evalEventTime evaluates the AT, STARTS or ENDS clause of an event to a time in UTC.
+func (e *SimpleExec) evalEventTime(clause string, expr ast.ExprNode) (time.Time, error) {
+	d, err := expression.EvalAstExpr(e.ctx, expr)
+	if err != nil {
+		return time.Time{}, err
+	}
+	sc := e.ctx.GetSessionVars().StmtCtx
+	v, err := d.ConvertTo(sc, types.NewFieldType(mysql.TypeDatetime))
+	if err != nil || v.IsNull() || v.GetMysqlTime().IsZero() {
+		str, _ := d.ToString()
+		return time.Time{}, ErrWrongValue.GenWithStackByArgs(clause, str)
+	}
+	t, err := v.GetMysqlTime().GoTime(e.ctx.GetSessionVars().Location())
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.UTC().Truncate(time.Second), nil
+}
+
+// setEventSchedule sets the schedule of the event, a recurring event starts at `now` by default.
+func (e *SimpleExec) setEventSchedule(ev *EventInfo, schedule *ast.EventSchedule, now time.Time) (err error) {
+	ev.ExecuteAt, ev.IntervalValue, ev.IntervalField, ev.Starts, ev.Ends = time.Time{}, "", "", time.Time{}, time.Time{}
+	if schedule.At != nil {
+		ev.ExecuteAt, err = e.evalEventTime("AT", schedule.At)
+		return err
+	}
+	d, err := expression.EvalAstExpr(e.ctx, schedule.Every)
+	if err != nil {
+		return err
+	}
+	if d.IsNull() {
+		return ErrEventIntervalNotPositiveOrTooBig.GenWithStackByArgs()
+	}
+	if ev.IntervalValue, err = d.ToString(); err != nil {
+		return err
+	}
+	ev.IntervalField = schedule.Unit.String()
+	ev.Starts = now
+	if schedule.Starts != nil {
+		if ev.Starts, err = e.evalEventTime("STARTS", schedule.Starts); err != nil {
+			return err
+		}
+	}
+	if schedule.Ends != nil {
+		if ev.Ends, err = e.evalEventTime("ENDS", schedule.Ends); err != nil {
+			return err
+		}
+		if ev.Ends.Before(ev.Starts) {
+			return ErrEventEndsBeforeStarts.GenWithStackByArgs()
+		}
+	}
+	// Validate the interval.
+	_, err = ev.NextTime(ev.Starts)
+	return err
+}
+
+// setEventBody sets the body of the event, the event runs with the current sql_mode,
+// time zone and character set, and the privileges of the current user.
+func (e *SimpleExec) setEventBody(ev *EventInfo, body ast.StmtNode) error {
+	if err := ddl.CheckProcedureBody(body); err != nil {
+		return err
+	}
+	var sb strings.Builder
+	if err := body.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)); err != nil {
+		return errors.Trace(err)
+	}
+	vars := e.ctx.GetSessionVars()
+	ev.Body = sb.String()
+	if vars.User != nil {
+		ev.Definer = vars.User.String()
+	}
+	ev.SQLMode, _ = vars.GetSystemVar(variable.SQLModeVar)
+	ev.TimeZone, _ = vars.GetSystemVar(variable.TimeZone)
+	ev.Charset, ev.Collation = vars.GetCharsetInfo()
+	return nil
+}
+
+// scheduleEvent sets the next execution time of the event. An event which doesn't fire any
+// more is disabled, it returns false if the event should be dropped instead.
+func (e *SimpleExec) scheduleEvent(ev *EventInfo, now time.Time) (bool, error) {
+	ev.NextExecute = time.Time{}
+	if ev.Status != EventEnabled {
+		return true, nil
+	}
+	next, err := ev.NextTime(now)
+	if err != nil {
+		return false, err
+	}
+	if next.IsZero() {
+		if ev.OnCompletion == EventNotPreserve {
+			return false, nil
+		}
+		ev.Status = EventDisabled
+		e.ctx.GetSessionVars().StmtCtx.AppendNote(ErrEventExecTimeInThePast.GenWithStackByArgs())
+		return true, nil
+	}
+	ev.NextExecute = next
+	return true, nil
+}
+
+func (e *SimpleExec) executeCreateEvent(ctx context.Context, s *ast.CreateEventStmt) error {
+	dbInfo, ok := e.is.SchemaByName(s.EventName.Schema)
+	if !ok {
+		return ErrBadDB.GenWithStackByArgs(s.EventName.Schema.O)
+	}
+	old, err := loadEvent(ctx, e.ctx, s.EventName.Schema, s.EventName.Name)
+	if err != nil {
+		return err
+	}
+	if old != nil {
+		err = ErrEventAlreadyExists.GenWithStackByArgs(s.EventName.Name.O)
+		if s.IfNotExists {
+			e.ctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	ev := &EventInfo{
+		Schema:       dbInfo.Name.O,
+		Name:         s.EventName.Name.O,
+		Status:       EventEnabled,
+		OnCompletion: EventNotPreserve,
+		Created:      now,
+		LastAltered:  now,
+	}
+	if s.Status != ast.EventStatusNone {
+		ev.Status = s.Status.String()
+	}
+	if s.Completion != ast.EventCompletionNone {
+		ev.OnCompletion = s.Completion.String()
+	}
+	if s.Comment != nil {
+		ev.Comment = *s.Comment
+	}
+	if err = e.setEventBody(ev, s.Body); err != nil {
+		return err
+	}
+	if err = e.setEventSchedule(ev, s.Schedule, now); err != nil {
+		return err
+	}
+	keep, err := e.scheduleEvent(ev, now)
+	if err != nil {
+		return err
+	}
+	if !keep {
+		e.ctx.GetSessionVars().StmtCtx.AppendNote(ErrEventCannotCreateInThePast.GenWithStackByArgs())
+		return nil
+	}
+	exec := e.ctx.(sqlexec.RestrictedSQLExecutor)
+	_, _, err = exec.ExecRestrictedSQL(ctx, nil, "INSERT INTO mysql.event ("+eventColumns+") VALUES (%?, %?, %?, %?, %?, %?, %?, %?, %?, %?, %?, %?, %?, %?, %?, %?, %?, %?, %?, %?)",
+		ev.Schema, ev.Name, ev.Definer, ev.Body, eventTimeArg(ev.ExecuteAt), ev.IntervalValue, ev.IntervalField,
+		eventTimeArg(ev.Starts), eventTimeArg(ev.Ends), ev.Status, ev.OnCompletion, ev.SQLMode, ev.TimeZone,
+		ev.Charset, ev.Collation, ev.Comment, ev.Created, ev.LastAltered, nil, eventTimeArg(ev.NextExecute))
+	return err
+}
+
+func (e *SimpleExec) executeAlterEvent(ctx context.Context, s *ast.AlterEventStmt) error {
+	ev, err := loadEvent(ctx, e.ctx, s.EventName.Schema, s.EventName.Name)
+	if err != nil {
+		return err
+	}
+	if ev == nil {
+		return ErrEventDoesNotExist.GenWithStackByArgs(s.EventName.Name.O)
+	}
+	oldSchema, oldName := ev.Schema, ev.Name
+
+	now := time.Now().UTC().Truncate(time.Second)
+	if s.Schedule != nil {
+		if err = e.setEventSchedule(ev, s.Schedule, now); err != nil {
+			return err
+		}
+	}
+	if s.Completion != ast.EventCompletionNone {
+		ev.OnCompletion = s.Completion.String()
+	}
+	if s.RenameTo != nil {
+		if s.RenameTo.Schema.L == strings.ToLower(ev.Schema) && s.RenameTo.Name.L == strings.ToLower(ev.Name) {
+			return ErrEventSameName.GenWithStackByArgs()
+		}
+		dbInfo, ok := e.is.SchemaByName(s.RenameTo.Schema)
+		if !ok {
+			return ErrBadDB.GenWithStackByArgs(s.RenameTo.Schema.O)
+		}
+		other, err := loadEvent(ctx, e.ctx, s.RenameTo.Schema, s.RenameTo.Name)
+		if err != nil {
+			return err
+		}
+		if other != nil {
+			return ErrEventAlreadyExists.GenWithStackByArgs(s.RenameTo.Name.O)
+		}
+		ev.Schema, ev.Name = dbInfo.Name.O, s.RenameTo.Name.O
+	}
+	if s.Status != ast.EventStatusNone {
+		ev.Status = s.Status.String()
+	}
+	if s.Comment != nil {
+		ev.Comment = *s.Comment
+	}
+	if s.Body != nil {
+		if err = e.setEventBody(ev, s.Body); err != nil {
+			return err
+		}
+	}
+	ev.LastAltered = now
+	keep, err := e.scheduleEvent(ev, now)
+	if err != nil {
+		return err
+	}
+	if !keep {
+		return ErrEventCannotAlterInThePast.GenWithStackByArgs()
+	}
+	exec := e.ctx.(sqlexec.RestrictedSQLExecutor)
+	_, _, err = exec.ExecRestrictedSQL(ctx, nil, `UPDATE mysql.event SET event_schema = %?, event_name = %?, definer = %?, body = %?,
+		execute_at = %?, interval_value = %?, interval_field = %?, starts = %?, ends = %?, status = %?, on_completion = %?,
+		sql_mode = %?, time_zone = %?, character_set_client = %?, collation_connection = %?, event_comment = %?,
+		last_altered = %?, next_execute = %? WHERE event_schema = %? AND event_name = %?`,
+		ev.Schema, ev.Name, ev.Definer, ev.Body, eventTimeArg(ev.ExecuteAt), ev.IntervalValue, ev.IntervalField,
+		eventTimeArg(ev.Starts), eventTimeArg(ev.Ends), ev.Status, ev.OnCompletion, ev.SQLMode, ev.TimeZone,
+		ev.Charset, ev.Collation, ev.Comment, ev.LastAltered, eventTimeArg(ev.NextExecute), oldSchema, oldName)
+	return err
+}
+
+func (e *SimpleExec) executeDropEvent(ctx context.Context, s *ast.DropEventStmt) error {
+	ev, err := loadEvent(ctx, e.ctx, s.EventName.Schema, s.EventName.Name)
+	if err != nil {
+		return err
+	}
+	if ev == nil {
+		err = ErrEventDoesNotExist.GenWithStackByArgs(s.EventName.Name.O)
+		if s.IfExists {
+			e.ctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+	exec := e.ctx.(sqlexec.RestrictedSQLExecutor)
+	_, _, err = exec.ExecRestrictedSQL(ctx, nil, "DELETE FROM mysql.event WHERE event_schema = %? AND event_name = %?", ev.Schema, ev.Name)
+	return err
+}
+
+// dropSchemaEvents drops the events of a dropped schema.
+func dropSchemaEvents(ctx context.Context, sctx sessionctx.Context, schema model.CIStr) error {
+	exec := sctx.(sqlexec.RestrictedSQLExecutor)
+	_, _, err := exec.ExecRestrictedSQL(ctx, nil, "DELETE FROM mysql.event WHERE LOWER(event_schema) = %?", schema.L)
+	return err
+}
@@ -550,6 +550,32 @@ func (e *DDLJobRetriever) appendJobToChunk(req *chunk.Chunk, job *model.Job, che
 		req.AppendNull(10)
 	}
 	req.AppendString(11, job.State.String())
+	if job.Type != model.ActionMultiSchemaChange {
+		return
+	}
+	// Show the progress of the sub-jobs of the multi-schema change.
+	for _, subJob := range job.MultiSchemaInfo.SubJobs {
+		req.AppendInt64(0, job.ID)
+		req.AppendString(1, schemaName)
+		req.AppendString(2, tableName)
+		req.AppendString(3, subJob.Type.String()+" /* subjob */")
+		req.AppendString(4, subJob.SchemaState.String())
+		req.AppendInt64(5, job.SchemaID)
+		req.AppendInt64(6, job.TableID)
+		req.AppendInt64(7, subJob.RowCount)
+		req.AppendTime(8, createTime)
+		if job.RealStartTS > 0 {
+			req.AppendTime(9, startTime)
+		} else {
+			req.AppendNull(9)
+		}
+		if finishTS > 0 {
+			req.AppendTime(10, finishTime)
+		} else {
+			req.AppendNull(10)
+		}
+		req.AppendString(11, subJob.State.String())
+	}
 }
 
 func ts2Time(timestamp uint64, loc *time.Location) types.Time {
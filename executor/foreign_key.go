@@ -0,0 +1,584 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/terror"
+	plannercore "github.com/pingcap/tidb/planner/core"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/table/tables"
+	"github.com/pingcap/tidb/tablecodec"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/codec"
+	"github.com/pingcap/tidb/util/collate"
+	"github.com/pingcap/tidb/util/hint"
+)
+
+// maxForeignKeyCascadeDepth is the maximum depth of the cascading foreign key actions, it's the same as MySQL.
+const maxForeignKeyCascadeDepth = 15
+
+// FKCheckExec checks the foreign key constraint of the modified rows.
+// The values of the foreign key columns are collected when the rows are written, and they are
+// looked up in the referred (or referring) table after the rows of the batch are all written.
+type FKCheckExec struct {
+	*plannercore.FKCheck
+	ctx sessionctx.Context
+
+	// colsOffsets are the offsets of FKCheck.Cols in the row of the modified table.
+	colsOffsets []int
+
+	toBeCheckedKeys   map[string]struct{}
+	toBeCheckedValues [][]types.Datum
+}
+
+// FKCascadeExec executes the foreign key cascade actions on the child table, which are triggered by
+// deleting or updating the rows of the parent table.
+type FKCascadeExec struct {
+	*plannercore.FKCascade
+	b *executorBuilder
+
+	// colsOffsets are the offsets of the referred columns in the row of the parent table.
+	colsOffsets []int
+	// depth is the depth of the cascade, the statement modifying the parent table is at depth 0.
+	depth int
+
+	fkValuesSet map[string]struct{}
+	fkValues    [][]types.Datum
+	// fkUpdatedValues are the new values of the referred columns for ON UPDATE CASCADE,
+	// they're in the same order as fkValues.
+	fkUpdatedValues [][]types.Datum
+}
+
+func (b *executorBuilder) buildFKCheckExecs(tbl table.Table, fkChecks []*plannercore.FKCheck) ([]*FKCheckExec, error) {
+	fkCheckExecs := make([]*FKCheckExec, 0, len(fkChecks))
+	for _, fkCheck := range fkChecks {
+		colsOffsets, err := getColsOffsets(tbl, fkCheck.Cols)
+		if err != nil {
+			return nil, err
+		}
+		fkCheckExecs = append(fkCheckExecs, &FKCheckExec{
+			FKCheck:         fkCheck,
+			ctx:             b.ctx,
+			colsOffsets:     colsOffsets,
+			toBeCheckedKeys: make(map[string]struct{}),
+		})
+	}
+	return fkCheckExecs, nil
+}
+
+func (b *executorBuilder) buildFKCascadeExecs(tbl table.Table, fkCascades []*plannercore.FKCascade) ([]*FKCascadeExec, error) {
+	fkCascadeExecs := make([]*FKCascadeExec, 0, len(fkCascades))
+	for _, fkCascade := range fkCascades {
+		colsOffsets, err := getColsOffsets(tbl, fkCascade.ReferredFK.Cols)
+		if err != nil {
+			return nil, err
+		}
+		fkCascadeExecs = append(fkCascadeExecs, &FKCascadeExec{
+			FKCascade:   fkCascade,
+			b:           b,
+			colsOffsets: colsOffsets,
+			depth:       b.fkCascadeDepth,
+			fkValuesSet: make(map[string]struct{}),
+		})
+	}
+	return fkCascadeExecs, nil
+}
+
+func (b *executorBuilder) buildFKExecsForTables(tblID2table map[int64]table.Table, fkChecks map[int64][]*plannercore.FKCheck,
+	fkCascades map[int64][]*plannercore.FKCascade) (map[int64][]*FKCheckExec, map[int64][]*FKCascadeExec, error) {
+	var checkExecs map[int64][]*FKCheckExec
+	var cascadeExecs map[int64][]*FKCascadeExec
+	for tid, checks := range fkChecks {
+		execs, err := b.buildFKCheckExecs(tblID2table[tid], checks)
+		if err != nil {
+			return nil, nil, err
+		}
+		if checkExecs == nil {
+			checkExecs = make(map[int64][]*FKCheckExec, len(fkChecks))
+		}
+		checkExecs[tid] = execs
+	}
+	for tid, cascades := range fkCascades {
+		execs, err := b.buildFKCascadeExecs(tblID2table[tid], cascades)
+		if err != nil {
+			return nil, nil, err
+		}
+		if cascadeExecs == nil {
+			cascadeExecs = make(map[int64][]*FKCascadeExec, len(fkCascades))
+		}
+		cascadeExecs[tid] = execs
+	}
+	return checkExecs, cascadeExecs, nil
+}
+
+func getColsOffsets(tbl table.Table, cols []model.CIStr) ([]int, error) {
+	offsets := make([]int, 0, len(cols))
+	for _, name := range cols {
+		col := table.FindCol(tbl.Cols(), name.L)
+		if col == nil {
+			return nil, errors.Errorf("foreign key column %s is not found in table %s", name.O, tbl.Meta().Name.O)
+		}
+		offsets = append(offsets, col.Offset)
+	}
+	return offsets, nil
+}
+
+// fkValues returns the copied values of the columns at offsets in the row, it returns nil if any
+// of the values is NULL, since NULL never refers to anything.
+func fkValues(row []types.Datum, offsets []int) []types.Datum {
+	vals := make([]types.Datum, len(offsets))
+	for i, offset := range offsets {
+		if row[offset].IsNull() {
+			return nil
+		}
+		row[offset].Copy(&vals[i])
+	}
+	return vals
+}
+
+// fkValuesChanged returns whether the values of the columns at offsets are changed in binary collation.
+func fkValuesChanged(sc *stmtctx.StatementContext, oldRow, newRow []types.Datum, offsets []int) (bool, error) {
+	for _, offset := range offsets {
+		cmp, err := oldRow[offset].Compare(sc, &newRow[offset], collate.GetBinaryCollator())
+		if err != nil {
+			return false, err
+		}
+		if cmp != 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (fkc *FKCheckExec) insertRowNeedToCheck(sc *stmtctx.StatementContext, row []types.Datum) error {
+	return fkc.addRowNeedToCheck(sc, row)
+}
+
+func (fkc *FKCheckExec) updateRowNeedToCheck(sc *stmtctx.StatementContext, oldRow, newRow []types.Datum) error {
+	changed, err := fkValuesChanged(sc, oldRow, newRow, fkc.colsOffsets)
+	if err != nil || !changed {
+		return err
+	}
+	if fkc.CheckExist {
+		return fkc.addRowNeedToCheck(sc, newRow)
+	}
+	return fkc.addRowNeedToCheck(sc, oldRow)
+}
+
+func (fkc *FKCheckExec) deleteRowNeedToCheck(sc *stmtctx.StatementContext, row []types.Datum) error {
+	return fkc.addRowNeedToCheck(sc, row)
+}
+
+func (fkc *FKCheckExec) addRowNeedToCheck(sc *stmtctx.StatementContext, row []types.Datum) error {
+	vals := fkValues(row, fkc.colsOffsets)
+	if vals == nil {
+		return nil
+	}
+	if fkc.Tbl == nil {
+		// The referred table doesn't exist.
+		return fkc.checkFailedErr()
+	}
+	for i, col := range fkc.LookupCols {
+		v, err := vals[i].ConvertTo(sc, &col.FieldType)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		vals[i] = v
+	}
+	key, err := codec.EncodeKey(sc, nil, vals...)
+	if err != nil {
+		return err
+	}
+	if _, ok := fkc.toBeCheckedKeys[string(key)]; ok {
+		return nil
+	}
+	fkc.toBeCheckedKeys[string(key)] = struct{}{}
+	fkc.toBeCheckedValues = append(fkc.toBeCheckedValues, vals)
+	return nil
+}
+
+func (fkc *FKCheckExec) doCheck(ctx context.Context) error {
+	if len(fkc.toBeCheckedValues) == 0 {
+		return nil
+	}
+	txn, err := fkc.ctx.Txn(true)
+	if err != nil {
+		return err
+	}
+	for _, vals := range fkc.toBeCheckedValues {
+		exist, err := fkc.rowExists(ctx, txn, vals)
+		if err != nil {
+			return err
+		}
+		if exist != fkc.CheckExist {
+			return fkc.checkFailedErr()
+		}
+	}
+	fkc.toBeCheckedValues = fkc.toBeCheckedValues[:0]
+	fkc.toBeCheckedKeys = make(map[string]struct{})
+	return nil
+}
+
+func (fkc *FKCheckExec) checkFailedErr() error {
+	fkStr := fkc.FK.String(fkc.ChildDB.O, fkc.ChildTable.O)
+	if fkc.CheckExist {
+		return ErrNoReferencedRow2.FastGenByArgs(fkStr)
+	}
+	return ErrRowIsReferenced2.FastGenByArgs(fkStr)
+}
+
+// rowExists returns whether the row with the values of LookupCols exists in Tbl.
+func (fkc *FKCheckExec) rowExists(ctx context.Context, txn kv.Transaction, vals []types.Datum) (bool, error) {
+	if fkc.Idx == nil && !fkc.HandleLookup {
+		return fkc.scanTableForRow(vals)
+	}
+	sc := fkc.ctx.GetSessionVars().StmtCtx
+	tblInfo := fkc.Tbl.Meta()
+	pids := []int64{tblInfo.ID}
+	if pi := tblInfo.GetPartitionInfo(); pi != nil && (fkc.Idx == nil || !fkc.Idx.Global) {
+		pids = pids[:0]
+		for _, def := range pi.Definitions {
+			pids = append(pids, def.ID)
+		}
+	}
+	for _, pid := range pids {
+		var prefix kv.Key
+		if fkc.HandleLookup && !tblInfo.IsCommonHandle {
+			prefix = tablecodec.EncodeRowKeyWithHandle(pid, kv.IntHandle(vals[0].GetInt64()))
+		} else {
+			encoded, err := codec.EncodeKey(sc, nil, vals...)
+			if err != nil {
+				return false, err
+			}
+			if fkc.HandleLookup {
+				prefix = tablecodec.EncodeRowKey(pid, encoded)
+			} else {
+				prefix = tablecodec.EncodeIndexSeekKey(pid, fkc.Idx.ID, encoded)
+			}
+		}
+		key, err := seekKeyWithPrefix(txn, prefix)
+		if err != nil {
+			return false, err
+		}
+		if key == nil {
+			continue
+		}
+		// Lock the referred row to prevent it from being deleted or updated by the concurrent transactions.
+		if fkc.CheckExist && fkc.ctx.GetSessionVars().TxnCtx.IsPessimistic {
+			vars := fkc.ctx.GetSessionVars()
+			if err := doLockKeys(ctx, fkc.ctx, newLockCtx(vars, vars.LockWaitTimeout, 1), key); err != nil {
+				return false, err
+			}
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+func seekKeyWithPrefix(txn kv.Transaction, prefix kv.Key) (kv.Key, error) {
+	it, err := txn.Iter(prefix, prefix.PrefixNext())
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+	if it.Valid() && it.Key().HasPrefix(prefix) {
+		return it.Key().Clone(), nil
+	}
+	return nil, nil
+}
+
+// scanTableForRow looks up the row by scanning the whole table, it's used when there is no
+// index on the looked up columns.
+func (fkc *FKCheckExec) scanTableForRow(vals []types.Datum) (bool, error) {
+	sc := fkc.ctx.GetSessionVars().StmtCtx
+	tbls := []table.Table{fkc.Tbl}
+	if pt, ok := fkc.Tbl.(table.PartitionedTable); ok {
+		tbls = tbls[:0]
+		for _, def := range fkc.Tbl.Meta().GetPartitionInfo().Definitions {
+			tbls = append(tbls, pt.GetPartition(def.ID))
+		}
+	}
+	found := false
+	for _, tbl := range tbls {
+		err := tables.IterRecords(tbl, fkc.ctx, tbl.Cols(), func(_ kv.Handle, rec []types.Datum, _ []*table.Column) (bool, error) {
+			for i, col := range fkc.LookupCols {
+				cmp, err := rec[col.Offset].Compare(sc, &vals[i], collate.GetCollator(col.Collate))
+				if err != nil {
+					return false, err
+				}
+				if cmp != 0 {
+					return true, nil
+				}
+			}
+			found = true
+			return false, nil
+		})
+		if err != nil || found {
+			return found, err
+		}
+	}
+	return false, nil
+}
+
+func (fkc *FKCascadeExec) onDeleteRow(sc *stmtctx.StatementContext, row []types.Datum) error {
+	vals := fkValues(row, fkc.colsOffsets)
+	if vals == nil {
+		return nil
+	}
+	return fkc.addValues(sc, vals, nil)
+}
+
+func (fkc *FKCascadeExec) onUpdateRow(sc *stmtctx.StatementContext, oldRow, newRow []types.Datum) error {
+	changed, err := fkValuesChanged(sc, oldRow, newRow, fkc.colsOffsets)
+	if err != nil || !changed {
+		return err
+	}
+	vals := fkValues(oldRow, fkc.colsOffsets)
+	if vals == nil {
+		return nil
+	}
+	var newVals []types.Datum
+	if ast.ReferOptionType(fkc.FK.OnUpdate) == ast.ReferOptionCascade {
+		newVals = make([]types.Datum, len(fkc.colsOffsets))
+		for i, offset := range fkc.colsOffsets {
+			newRow[offset].Copy(&newVals[i])
+		}
+	}
+	return fkc.addValues(sc, vals, newVals)
+}
+
+func (fkc *FKCascadeExec) addValues(sc *stmtctx.StatementContext, vals, newVals []types.Datum) error {
+	key, err := codec.EncodeKey(sc, nil, vals...)
+	if err != nil {
+		return err
+	}
+	if _, ok := fkc.fkValuesSet[string(key)]; ok {
+		return nil
+	}
+	fkc.fkValuesSet[string(key)] = struct{}{}
+	fkc.fkValues = append(fkc.fkValues, vals)
+	fkc.fkUpdatedValues = append(fkc.fkUpdatedValues, newVals)
+	return nil
+}
+
+func (fkc *FKCascadeExec) doCascade(ctx context.Context) error {
+	if len(fkc.fkValues) == 0 {
+		return nil
+	}
+	if fkc.depth >= maxForeignKeyCascadeDepth {
+		return ErrForeignKeyCascadeDepthExceeded.GenWithStackByArgs(maxForeignKeyCascadeDepth)
+	}
+	sc := fkc.b.ctx.GetSessionVars().StmtCtx
+	// The rows modified by the cascade actions aren't counted in the result of the statement.
+	counter := sc.SaveRowsCounter()
+	defer sc.RestoreRowsCounter(counter)
+	for _, stmt := range fkc.buildCascadeStmts() {
+		if err := fkc.execStmt(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	fkc.fkValues = fkc.fkValues[:0]
+	fkc.fkUpdatedValues = fkc.fkUpdatedValues[:0]
+	fkc.fkValuesSet = make(map[string]struct{})
+	return nil
+}
+
+// buildCascadeStmts builds the statements which modify the child table. ON DELETE CASCADE deletes
+// the child rows by `DELETE FROM child WHERE (fk_cols) IN (old_values...)`, SET NULL updates them by
+// `UPDATE child SET fk_cols = NULL WHERE (fk_cols) IN (old_values...)`, and ON UPDATE CASCADE updates
+// them by `UPDATE child SET fk_cols = new_values WHERE (fk_cols) IN (old_values)` for each updated row.
+func (fkc *FKCascadeExec) buildCascadeStmts() []ast.StmtNode {
+	tn := &ast.TableName{Schema: fkc.ReferredFK.ChildSchema, Name: fkc.ReferredFK.ChildTable}
+	tableRefs := &ast.TableRefsClause{TableRefs: &ast.Join{Left: &ast.TableSource{Source: tn}}}
+	if fkc.Tp == plannercore.FKCascadeOnDelete && ast.ReferOptionType(fkc.FK.OnDelete) == ast.ReferOptionCascade {
+		return []ast.StmtNode{&ast.DeleteStmt{
+			TableRefs: tableRefs,
+			Where:     genFKCascadeCondition(fkc.FK.Cols, fkc.fkValues),
+		}}
+	}
+	if fkc.Tp == plannercore.FKCascadeOnUpdate && ast.ReferOptionType(fkc.FK.OnUpdate) == ast.ReferOptionCascade {
+		stmts := make([]ast.StmtNode, 0, len(fkc.fkValues))
+		for i, vals := range fkc.fkValues {
+			stmts = append(stmts, &ast.UpdateStmt{
+				TableRefs: tableRefs,
+				List:      genFKCascadeAssignments(fkc.FK.Cols, fkc.fkUpdatedValues[i]),
+				Where:     genFKCascadeCondition(fkc.FK.Cols, [][]types.Datum{vals}),
+			})
+		}
+		return stmts
+	}
+	return []ast.StmtNode{&ast.UpdateStmt{
+		TableRefs: tableRefs,
+		List:      genFKCascadeAssignments(fkc.FK.Cols, nil),
+		Where:     genFKCascadeCondition(fkc.FK.Cols, fkc.fkValues),
+	}}
+}
+
+// genFKCascadeAssignments generates the assignments `cols = vals`, the columns are set to NULL when vals is nil.
+func genFKCascadeAssignments(cols []model.CIStr, vals []types.Datum) []*ast.Assignment {
+	list := make([]*ast.Assignment, 0, len(cols))
+	for i, col := range cols {
+		var v types.Datum
+		if vals != nil {
+			v = vals[i]
+		}
+		list = append(list, &ast.Assignment{
+			Column: &ast.ColumnName{Name: col},
+			Expr:   newFKValueExpr(v),
+		})
+	}
+	return list
+}
+
+// genFKCascadeCondition generates the condition `(cols) IN ((vals)...)`.
+func genFKCascadeCondition(cols []model.CIStr, values [][]types.Datum) ast.ExprNode {
+	var expr ast.ExprNode
+	list := make([]ast.ExprNode, 0, len(values))
+	if len(cols) == 1 {
+		expr = &ast.ColumnNameExpr{Name: &ast.ColumnName{Name: cols[0]}}
+		for _, vals := range values {
+			list = append(list, newFKValueExpr(vals[0]))
+		}
+	} else {
+		row := &ast.RowExpr{Values: make([]ast.ExprNode, 0, len(cols))}
+		for _, col := range cols {
+			row.Values = append(row.Values, &ast.ColumnNameExpr{Name: &ast.ColumnName{Name: col}})
+		}
+		expr = row
+		for _, vals := range values {
+			valRow := &ast.RowExpr{Values: make([]ast.ExprNode, 0, len(vals))}
+			for _, v := range vals {
+				valRow.Values = append(valRow.Values, newFKValueExpr(v))
+			}
+			list = append(list, valRow)
+		}
+	}
+	return &ast.PatternInExpr{Expr: expr, List: list}
+}
+
+func newFKValueExpr(v types.Datum) ast.ExprNode {
+	return ast.NewValueExpr(v.GetValue(), "", v.Collation())
+}
+
+func (fkc *FKCascadeExec) execStmt(ctx context.Context, stmt ast.StmtNode) error {
+	sctx := fkc.b.ctx
+	is := fkc.b.is
+	err := plannercore.Preprocess(sctx, stmt, plannercore.WithPreprocessorReturn(&plannercore.PreprocessorReturn{InfoSchema: is}))
+	if err != nil {
+		return err
+	}
+	builder, _ := plannercore.NewPlanBuilder().Init(sctx, is, &hint.BlockHintProcessor{})
+	p, err := builder.Build(ctx, stmt)
+	if err != nil {
+		return err
+	}
+	b := newExecutorBuilder(sctx, is, nil, fkc.b.snapshotTS, fkc.b.isStaleness, fkc.b.readReplicaScope)
+	b.fkCascadeDepth = fkc.depth + 1
+	e := b.build(p)
+	if b.err != nil {
+		return b.err
+	}
+	if err = e.Open(ctx); err != nil {
+		terror.Call(e.Close)
+		return err
+	}
+	if err = Next(ctx, e, newFirstChunk(e)); err != nil {
+		terror.Call(e.Close)
+		return err
+	}
+	return e.Close()
+}
+
+// onAddRowForFK collects the row added to the child table for the foreign key checks.
+func onAddRowForFK(sc *stmtctx.StatementContext, row []types.Datum, fkChecks []*FKCheckExec) error {
+	for _, fkc := range fkChecks {
+		if !fkc.CheckExist {
+			continue
+		}
+		if err := fkc.insertRowNeedToCheck(sc, row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// doFKCascadesAndChecks executes the cascade actions on the child tables and then does the checks
+// after the rows are written.
+func doFKCascadesAndChecks(ctx context.Context, fkChecks []*FKCheckExec, fkCascades []*FKCascadeExec) error {
+	if err := doFKCascades(ctx, fkCascades); err != nil {
+		return err
+	}
+	return doFKChecks(ctx, fkChecks)
+}
+
+func doFKChecks(ctx context.Context, fkChecks []*FKCheckExec) error {
+	for _, fkc := range fkChecks {
+		if err := fkc.doCheck(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func doFKCascades(ctx context.Context, fkCascades []*FKCascadeExec) error {
+	for _, fkc := range fkCascades {
+		if err := fkc.doCascade(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// onRemoveRowForFK collects the row removed from the parent table for the foreign key checks and cascades.
+func onRemoveRowForFK(sc *stmtctx.StatementContext, row []types.Datum, fkChecks []*FKCheckExec, fkCascades []*FKCascadeExec) error {
+	for _, fkc := range fkChecks {
+		// Removing the rows of the child table never violates its foreign keys.
+		if fkc.CheckExist {
+			continue
+		}
+		if err := fkc.deleteRowNeedToCheck(sc, row); err != nil {
+			return err
+		}
+	}
+	for _, fkc := range fkCascades {
+		if err := fkc.onDeleteRow(sc, row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// onUpdateRowForFK collects the updated row for the foreign key checks and cascades.
+func onUpdateRowForFK(sc *stmtctx.StatementContext, oldRow, newRow []types.Datum, fkChecks []*FKCheckExec, fkCascades []*FKCascadeExec) error {
+	for _, fkc := range fkChecks {
+		if err := fkc.updateRowNeedToCheck(sc, oldRow, newRow); err != nil {
+			return err
+		}
+	}
+	for _, fkc := range fkCascades {
+		if err := fkc.onUpdateRow(sc, oldRow, newRow); err != nil {
+			return err
+		}
+	}
+	return nil
+}
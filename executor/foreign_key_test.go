@@ -0,0 +1,174 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor_test
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForeignKeyCheckOnChildTable(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set @@foreign_key_checks=1")
+
+	// Look up the parent table by the int handle, the clustered index, the secondary index and the full table scan.
+	parents := []string{
+		"create table t1 (id int primary key, name varchar(10))",
+		"create table t1 (id varchar(10) primary key clustered, name varchar(10))",
+		"create table t1 (id int, name varchar(10), unique index(id, name))",
+		"create table t1 (id int, name varchar(10))",
+	}
+	for _, parent := range parents {
+		tk.MustExec("drop table if exists t2, t1")
+		tk.MustExec(parent)
+		tk.MustExec("create table t2 (id int primary key, pid int, index(pid), foreign key fk_1 (pid) references t1(id))")
+		tk.MustExec("insert into t1 (id, name) values (1, 'a'), (2, 'b')")
+		tk.MustExec("insert into t2 values (1, 1), (2, null), (3, 2)")
+		tk.MustGetErrCode("insert into t2 values (4, 3)", errno.ErrNoReferencedRow2)
+		tk.MustGetErrCode("update t2 set pid = 3 where id = 1", errno.ErrNoReferencedRow2)
+		tk.MustGetErrCode("insert into t2 values (1, 1) on duplicate key update pid = 5", errno.ErrNoReferencedRow2)
+		tk.MustExec("update t2 set pid = 2 where id = 1")
+		tk.MustExec("insert into t2 values (1, 1) on duplicate key update pid = 1")
+		tk.MustQuery("select * from t2 order by id").Check(testkit.Rows("1 1", "2 <nil>", "3 2"))
+	}
+
+	// The rows inserted in the same statement can be referred.
+	tk.MustExec("drop table if exists t2, t1")
+	tk.MustExec("create table t1 (id int primary key, pid int, foreign key (pid) references t1(id))")
+	tk.MustExec("insert into t1 values (1, 1), (2, 1)")
+	tk.MustGetErrCode("insert into t1 values (3, 4)", errno.ErrNoReferencedRow2)
+
+	// The checks are skipped when foreign_key_checks is OFF.
+	tk.MustExec("set @@foreign_key_checks=0")
+	tk.MustExec("insert into t1 values (3, 4)")
+	tk.MustExec("set @@foreign_key_checks=1")
+
+	// The error message describes the foreign key.
+	tk.MustExec("create table t3 (id int, a int, b int, constraint fk_ab foreign key (a, b) references t4(x, y))")
+	err := tk.ExecToErr("insert into t3 values (1, 1, 1)")
+	require.EqualError(t, err, "[executor:1452]Cannot add or update a child row: a foreign key constraint fails (`test`.`t3`, CONSTRAINT `fk_ab` FOREIGN KEY (`a`, `b`) REFERENCES `t4` (`x`, `y`))")
+	tk.MustExec("insert into t3 values (1, null, 1)")
+}
+
+func TestForeignKeyOnDeleteAndUpdateParent(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set @@foreign_key_checks=1")
+
+	// RESTRICT
+	tk.MustExec("create table t1 (id int primary key, name varchar(10))")
+	tk.MustExec("create table t2 (id int, pid int, index(pid), foreign key (pid) references t1(id) on delete restrict)")
+	tk.MustExec("insert into t1 values (1, 'a'), (2, 'b'), (3, 'c')")
+	tk.MustExec("insert into t2 values (1, 1), (2, 2)")
+	tk.MustGetErrCode("delete from t1 where id = 1", errno.ErrRowIsReferenced2)
+	tk.MustGetErrCode("delete from t1 where id in (1, 3)", errno.ErrRowIsReferenced2)
+	tk.MustGetErrCode("update t1 set id = 10 where id = 2", errno.ErrRowIsReferenced2)
+	tk.MustGetErrCode("replace into t1 values (1, 'aa')", errno.ErrRowIsReferenced2)
+	tk.MustExec("delete from t1 where id = 3")
+	tk.MustExec("update t1 set name = 'bb' where id = 2")
+	tk.MustExec("delete t1, t2 from t1 join t2 on t1.id = t2.pid where t1.id = 1")
+	tk.MustQuery("select * from t1").Check(testkit.Rows("2 bb"))
+	tk.MustQuery("select * from t2").Check(testkit.Rows("2 2"))
+
+	// CASCADE
+	tk.MustExec("drop table t2, t1")
+	tk.MustExec("create table t1 (id int primary key, name varchar(10))")
+	tk.MustExec("create table t2 (id int primary key, pid int, foreign key (pid) references t1(id) on delete cascade on update cascade)")
+	tk.MustExec("create table t3 (id int primary key, pid int, foreign key (pid) references t2(id) on delete cascade)")
+	tk.MustExec("insert into t1 values (1, 'a'), (2, 'b'), (3, 'c')")
+	tk.MustExec("insert into t2 values (1, 1), (2, 1), (3, 2)")
+	tk.MustExec("insert into t3 values (1, 1), (2, 3)")
+	tk.MustExec("delete from t1 where id = 1")
+	require.Equal(t, uint64(1), tk.Session().AffectedRows())
+	tk.MustQuery("select * from t2").Check(testkit.Rows("3 2"))
+	tk.MustQuery("select * from t3").Check(testkit.Rows("2 3"))
+	tk.MustExec("update t1 set id = 20 where id = 2")
+	tk.MustQuery("select * from t2").Check(testkit.Rows("3 20"))
+
+	// SET NULL
+	tk.MustExec("drop table t3, t2, t1")
+	tk.MustExec("create table t1 (a int, b int, unique index(a, b))")
+	tk.MustExec("create table t2 (id int, a int, b int, foreign key (a, b) references t1(a, b) on delete set null on update set null)")
+	tk.MustExec("insert into t1 values (1, 1), (2, 2), (3, 3)")
+	tk.MustExec("insert into t2 values (1, 1, 1), (2, 2, 2), (3, 3, 3)")
+	tk.MustExec("delete from t1 where a = 1")
+	tk.MustExec("update t1 set b = 20 where a = 2")
+	tk.MustQuery("select * from t2 order by id").Check(testkit.Rows("1 <nil> <nil>", "2 <nil> <nil>", "3 3 3"))
+
+	// The cascade depth is limited.
+	tk.MustExec("create table t3 (id int primary key, pid int, foreign key (pid) references t3(id) on delete cascade)")
+	tk.MustExec("insert into t3 values (0, null)")
+	for i := 1; i <= 20; i++ {
+		tk.MustExec("insert into t3 values (?, ?)", i, i-1)
+	}
+	tk.MustGetErrCode("delete from t3 where id = 0", errno.ErrForeignKeyCascadeDepthExceeded)
+	tk.MustExec("delete from t3 where id = 10")
+	tk.MustQuery("select count(*) from t3").Check(testkit.Rows("10"))
+}
+
+func TestForeignKeyInTxn(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set @@foreign_key_checks=1")
+	tk.MustExec("create table t1 (id int primary key)")
+	tk.MustExec("create table t2 (id int, pid int, foreign key (pid) references t1(id))")
+
+	for _, mode := range []string{"pessimistic", "optimistic"} {
+		tk.MustExec("delete from t2")
+		tk.MustExec("delete from t1")
+		tk.MustExec("begin " + mode)
+		// The uncommitted rows in the transaction can be referred.
+		tk.MustExec("insert into t1 values (1)")
+		tk.MustExec("insert into t2 values (1, 1)")
+		tk.MustGetErrCode("delete from t1", errno.ErrRowIsReferenced2)
+		tk.MustExec("delete from t2")
+		tk.MustExec("delete from t1")
+		tk.MustGetErrCode("insert into t2 values (1, 1)", errno.ErrNoReferencedRow2)
+		tk.MustExec("commit")
+	}
+}
+
+func TestForeignKeyExplain(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t1 (id int primary key, a int, index idx_a(a))")
+	tk.MustExec("create table t2 (id int, pid int, foreign key fk_1 (pid) references t1(id) on delete cascade)")
+	tk.MustExec("create table t3 (id int, a int, index(a), foreign key fk_2 (a) references t1(a))")
+
+	// No foreign key plans when foreign_key_checks is OFF.
+	tk.MustQuery("explain format = 'brief' insert into t2 values (1, 1)").Check(testkit.Rows(
+		"Insert N/A root  N/A"))
+	tk.MustExec("set @@foreign_key_checks=1")
+	tk.MustQuery("explain format = 'brief' insert into t2 values (1, 1)").Check(testkit.Rows(
+		"Insert N/A root  N/A",
+		"└─Foreign_Key_Check N/A root table:t1, handle foreign_key:fk_1, check_exist"))
+	tk.MustQuery("explain format = 'brief' delete from t1 where id = 1").Check(testkit.Rows(
+		"Delete N/A root  N/A",
+		"├─Point_Get 1.00 root table:t1 handle:1",
+		"├─Foreign_Key_Check N/A root table:t3, index:a foreign_key:fk_2, check_not_exist",
+		"└─Foreign_Key_Cascade N/A root table:t2 foreign_key:fk_1, on_delete:CASCADE"))
+}
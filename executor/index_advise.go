@@ -16,14 +16,28 @@ package executor
 
 import (
 	"context"
+	"fmt"
+	"math"
 	"strings"
+	"time"
 
+	"github.com/cznic/mathutil"
 	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/infoschema"
 	"github.com/pingcap/tidb/parser"
 	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/charset"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/planner"
+	plannercore "github.com/pingcap/tidb/planner/core"
 	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util"
 	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/memory"
+	"github.com/pingcap/tidb/util/stringutil"
 )
 
 // IndexAdviseExec represents a index advise executor.
@@ -123,13 +137,477 @@ func (e *IndexAdviseInfo) GetIndexAdvice(ctx context.Context, data []byte) error
 	if err := e.prepareInfo(data); err != nil {
 		return err
 	}
-	// TODO: Finish the index advise process. It will be done in another PR.
+	sessVars := e.Ctx.GetSessionVars()
+	originStmtCtx := sessVars.StmtCtx
+	defer func() {
+		sessVars.StmtCtx = originStmtCtx
+	}()
+	advisor := &indexAdvisor{
+		info: e,
+		is:   e.Ctx.GetInfoSchema().(infoschema.InfoSchema),
+		// The warnings are appended to the statement context of INDEX ADVISE, rather than the ones used to
+		// plan the statements of the workload.
+		sc: originStmtCtx,
+	}
+	if e.MaxMinutes != ast.UnspecifiedSize {
+		advisor.deadline = time.Now().Add(time.Duration(e.MaxMinutes) * time.Minute)
+	}
+	advice, err := advisor.advise(ctx)
+	if err != nil {
+		return err
+	}
+	e.Result = advice
 	return nil
 }
 
-// IndexAdvice represents the index advice.
+// IndexAdvice represents the index advice, it implements the sqlexec.RecordSet interface. Each row is an
+// index recommended by the advisor, in the order they are chosen.
 type IndexAdvice struct {
-	// TODO: Define index advice data structure and implements the ResultSet interface. It will be done in another PR
+	fields       []*ast.ResultField
+	rows         []chunk.Row
+	idx          int
+	maxChunkSize int
+}
+
+var indexAdviceColumns = []struct {
+	name string
+	tp   byte
+}{
+	{"Database", mysql.TypeVarchar},
+	{"Table", mysql.TypeVarchar},
+	{"Index_name", mysql.TypeVarchar},
+	{"Index_columns", mysql.TypeVarchar},
+	{"Create_statement", mysql.TypeVarchar},
+	// Cost_reduction is the reduction of the estimated cost of the workload after the index is added to the
+	// indexes recommended before it.
+	{"Cost_reduction", mysql.TypeDouble},
+	// Workload_improvement is the percentage of the total reduction of the estimated cost of the workload after
+	// the index and the indexes recommended before it are added.
+	{"Workload_improvement", mysql.TypeDouble},
+}
+
+func newIndexAdvice(recommended []*indexCandidate, originCost float64, maxChunkSize int) *IndexAdvice {
+	advice := &IndexAdvice{maxChunkSize: maxChunkSize}
+	fieldTypes := make([]*types.FieldType, 0, len(indexAdviceColumns))
+	for _, c := range indexAdviceColumns {
+		ft := types.NewFieldType(c.tp)
+		if c.tp == mysql.TypeVarchar {
+			ft.Charset, ft.Collate = mysql.DefaultCharset, mysql.DefaultCollationName
+			ft.Flen = mysql.MaxFieldVarCharLength
+		} else {
+			ft.Charset, ft.Collate = charset.CharsetBin, charset.CollationBin
+			ft.Flen, ft.Decimal = mysql.MaxRealWidth, 2
+		}
+		advice.fields = append(advice.fields, &ast.ResultField{
+			Column:       &model.ColumnInfo{Name: model.NewCIStr(c.name), FieldType: *ft},
+			ColumnAsName: model.NewCIStr(c.name),
+		})
+		fieldTypes = append(fieldTypes, ft)
+	}
+	chk := chunk.NewChunkWithCapacity(fieldTypes, len(recommended))
+	var totalReduction float64
+	for _, c := range recommended {
+		colNames := make([]string, 0, len(c.cols))
+		quotedColNames := make([]string, 0, len(c.cols))
+		for _, col := range c.cols {
+			colNames = append(colNames, col.Name.O)
+			quotedColNames = append(quotedColNames, stringutil.Escape(col.Name.O, mysql.ModeNone))
+		}
+		totalReduction += c.costReduction
+		chk.AppendString(0, c.dbName.O)
+		chk.AppendString(1, c.tblInfo.Name.O)
+		chk.AppendString(2, c.idxInfo.Name.O)
+		chk.AppendString(3, strings.Join(colNames, ","))
+		chk.AppendString(4, fmt.Sprintf("CREATE INDEX %s ON %s.%s(%s)", stringutil.Escape(c.idxInfo.Name.O, mysql.ModeNone),
+			stringutil.Escape(c.dbName.O, mysql.ModeNone), stringutil.Escape(c.tblInfo.Name.O, mysql.ModeNone), strings.Join(quotedColNames, ", ")))
+		chk.AppendFloat64(5, math.Round(c.costReduction*100)/100)
+		chk.AppendFloat64(6, math.Round(totalReduction/originCost*10000)/100)
+	}
+	iter := chunk.NewIterator4Chunk(chk)
+	for row := iter.Begin(); row != iter.End(); row = iter.Next() {
+		advice.rows = append(advice.rows, row)
+	}
+	return advice
+}
+
+// Fields implements the sqlexec.RecordSet interface.
+func (a *IndexAdvice) Fields() []*ast.ResultField {
+	return a.fields
+}
+
+// Next implements the sqlexec.RecordSet interface.
+func (a *IndexAdvice) Next(_ context.Context, chk *chunk.Chunk) error {
+	chk.Reset()
+	for !chk.IsFull() && a.idx < len(a.rows) {
+		chk.AppendRow(a.rows[a.idx])
+		a.idx++
+	}
+	return nil
+}
+
+// NewChunk implements the sqlexec.RecordSet interface.
+func (a *IndexAdvice) NewChunk(alloc chunk.Allocator) *chunk.Chunk {
+	fieldTypes := make([]*types.FieldType, 0, len(a.fields))
+	for _, field := range a.fields {
+		fieldTypes = append(fieldTypes, &field.Column.FieldType)
+	}
+	if alloc == nil {
+		return chunk.New(fieldTypes, a.maxChunkSize, a.maxChunkSize)
+	}
+	return alloc.Alloc(fieldTypes, a.maxChunkSize, a.maxChunkSize)
+}
+
+// Close implements the sqlexec.RecordSet interface.
+func (a *IndexAdvice) Close() error {
+	return nil
+}
+
+const (
+	// maxAdvisedIndexColumns is the maximum number of the columns of a recommended index.
+	maxAdvisedIndexColumns = 3
+	// minCostReductionRatio is the minimum ratio of the reduction of the workload cost for an index to be
+	// recommended, so the indexes which barely help are not recommended.
+	minCostReductionRatio = 0.01
+)
+
+// indexAdvisor recommends the indexes for a workload. It extracts the indexable columns of the statements,
+// generates the candidate indexes from them, and greedily adds the candidate which reduces the estimated cost
+// of the workload the most, until no candidate helps or the limits are reached. The costs are estimated by the
+// optimizer with the candidates attached to the tables as the hypothetical indexes.
+type indexAdvisor struct {
+	info     *IndexAdviseInfo
+	is       infoschema.InfoSchema
+	sc       *stmtctx.StatementContext
+	deadline time.Time
+
+	stmts      []*advisedStmt
+	candidates []*indexCandidate
+	// hypoIndexes are the recommended indexes, indexed by the table IDs.
+	hypoIndexes map[int64][]*model.IndexInfo
+}
+
+// advisedStmt is a statement of the workload.
+type advisedStmt struct {
+	node ast.StmtNode
+	// tableIDs are the IDs of the tables accessed by the statement.
+	tableIDs map[int64]struct{}
+	// cost is the estimated cost of the statement with the recommended indexes.
+	cost float64
+}
+
+// indexCandidate is a candidate index.
+type indexCandidate struct {
+	dbName  model.CIStr
+	tblInfo *model.TableInfo
+	cols    []*model.ColumnInfo
+	idxInfo *model.IndexInfo
+	// costReduction is the reduction of the workload cost when the index is recommended.
+	costReduction float64
+}
+
+func (a *indexAdvisor) advise(ctx context.Context) (*IndexAdvice, error) {
+	for _, stmts := range a.info.StmtNodes {
+		for _, stmt := range stmts {
+			if err := a.addStmt(ctx, stmt); err != nil {
+				return nil, err
+			}
+		}
+	}
+	var originCost float64
+	for _, stmt := range a.stmts {
+		originCost += stmt.cost
+	}
+	a.hypoIndexes = make(map[int64][]*model.IndexInfo)
+	recommended, err := a.search(ctx, originCost)
+	if err != nil {
+		return nil, err
+	}
+	return newIndexAdvice(recommended, originCost, a.info.Ctx.GetSessionVars().MaxChunkSize), nil
+}
+
+// addStmt extracts the indexable columns of the statement to generate the candidates, and estimates its cost
+// without the recommended indexes. The statements other than SELECT, UPDATE and DELETE are ignored, and so
+// are the statements failed to plan, with a warning.
+func (a *indexAdvisor) addStmt(ctx context.Context, stmt ast.StmtNode) error {
+	node := toAdvisedSelect(stmt)
+	if node == nil {
+		return nil
+	}
+	a.resetStmtCtx()
+	if err := plannercore.Preprocess(a.info.Ctx, node, plannercore.WithPreprocessorReturn(&plannercore.PreprocessorReturn{InfoSchema: a.is})); err != nil {
+		a.sc.AppendWarning(err)
+		return nil
+	}
+	tables, err := plannercore.ExtractIndexableColumns(ctx, a.info.Ctx, node, a.is)
+	if err != nil {
+		a.sc.AppendWarning(err)
+		return nil
+	}
+	cost, err := a.estimateCost(ctx, node, a.is)
+	if err != nil {
+		a.sc.AppendWarning(err)
+		return nil
+	}
+	advised := &advisedStmt{node: node, tableIDs: make(map[int64]struct{}, len(tables)), cost: cost}
+	for _, tbl := range tables {
+		advised.tableIDs[tbl.TableInfo.ID] = struct{}{}
+		a.addCandidates(tbl)
+	}
+	a.stmts = append(a.stmts, advised)
+	return nil
+}
+
+// toAdvisedSelect returns the SELECT statement whose plan is used to estimate the cost of the statement. An
+// UPDATE or DELETE statement on a single table reads the rows like a SELECT statement with the same conditions.
+func toAdvisedSelect(stmt ast.StmtNode) ast.StmtNode {
+	fields := &ast.FieldList{Fields: []*ast.SelectField{{WildCard: &ast.WildCardField{}}}}
+	switch x := stmt.(type) {
+	case *ast.SelectStmt, *ast.SetOprStmt:
+		return x
+	case *ast.UpdateStmt:
+		if x.MultipleTable {
+			return nil
+		}
+		return &ast.SelectStmt{Kind: ast.SelectStmtKindSelect, SelectStmtOpts: &ast.SelectStmtOpts{SQLCache: true},
+			Fields: fields, From: x.TableRefs, Where: x.Where, OrderBy: x.Order, Limit: x.Limit}
+	case *ast.DeleteStmt:
+		if x.IsMultiTable {
+			return nil
+		}
+		return &ast.SelectStmt{Kind: ast.SelectStmtKindSelect, SelectStmtOpts: &ast.SelectStmtOpts{SQLCache: true},
+			Fields: fields, From: x.TableRefs, Where: x.Where, OrderBy: x.Order, Limit: x.Limit}
+	}
+	return nil
+}
+
+// resetStmtCtx resets the statement context before a statement of the workload is planned. The statements
+// are planned like EXPLAIN, so the hypothetical indexes are considered and the subqueries aren't executed.
+func (a *indexAdvisor) resetStmtCtx() {
+	sessVars := a.info.Ctx.GetSessionVars()
+	sc := &stmtctx.StatementContext{TimeZone: sessVars.Location(), InSelectStmt: true, InExplainStmt: true}
+	sc.InitMemTracker(memory.LabelForSQLText, sessVars.MemQuotaQuery)
+	sc.InitDiskTracker(memory.LabelForSQLText, -1)
+	sessVars.StmtCtx = sc
+}
+
+func (a *indexAdvisor) estimateCost(ctx context.Context, node ast.StmtNode, is infoschema.InfoSchema) (float64, error) {
+	a.resetStmtCtx()
+	if err := plannercore.Preprocess(a.info.Ctx, node, plannercore.WithPreprocessorReturn(&plannercore.PreprocessorReturn{InfoSchema: is})); err != nil {
+		return 0, err
+	}
+	_, cost, err := planner.OptimizeWithCost(ctx, a.info.Ctx, node, is)
+	return cost, err
+}
+
+// addCandidates generates the candidate indexes from the indexable columns of a table in a statement, which
+// are the indexes on a single column, and the indexes on the equal columns followed by a range column or the
+// order columns.
+func (a *indexAdvisor) addCandidates(tbl *plannercore.IndexableColumns) {
+	eqCols, rangeCols, orderCols := indexableColumns(tbl.EqualCols), indexableColumns(tbl.RangeCols), indexableColumns(tbl.OrderCols)
+	for _, col := range eqCols {
+		a.addCandidate(tbl, []*model.ColumnInfo{col})
+	}
+	for _, col := range rangeCols {
+		a.addCandidate(tbl, []*model.ColumnInfo{col})
+	}
+	if len(orderCols) > 0 {
+		a.addCandidate(tbl, orderCols[:1])
+	}
+	if len(eqCols) > 1 {
+		a.addCandidate(tbl, eqCols[:mathutil.Min(len(eqCols), maxAdvisedIndexColumns)])
+	}
+	prefix := eqCols[:mathutil.Min(len(eqCols), maxAdvisedIndexColumns-1)]
+	if len(prefix) == 0 {
+		return
+	}
+	for _, col := range rangeCols {
+		if !containsColumn(prefix, col) {
+			a.addCandidate(tbl, append(append([]*model.ColumnInfo{}, prefix...), col))
+		}
+	}
+	cols := append([]*model.ColumnInfo{}, prefix...)
+	for _, col := range orderCols {
+		if !containsColumn(cols, col) && len(cols) < maxAdvisedIndexColumns {
+			cols = append(cols, col)
+		}
+	}
+	a.addCandidate(tbl, cols)
+}
+
+func (a *indexAdvisor) addCandidate(tbl *plannercore.IndexableColumns, cols []*model.ColumnInfo) {
+	// The candidate is useless if it's a prefix of an existing index.
+	if len(cols) == 1 && tbl.TableInfo.PKIsHandle && mysql.HasPriKeyFlag(cols[0].Flag) {
+		return
+	}
+	for _, idx := range tbl.TableInfo.Indices {
+		if idx.State == model.StatePublic && !idx.Invisible && !idx.MVIndex && !idx.FullText && !idx.Spatial && isIndexPrefix(idx, cols) {
+			return
+		}
+	}
+	for _, c := range a.candidates {
+		if c.tblInfo.ID == tbl.TableInfo.ID && len(c.cols) == len(cols) && isIndexPrefix(c.idxInfo, cols) {
+			return
+		}
+	}
+	idxCols := make([]*model.IndexColumn, 0, len(cols))
+	colNames := make([]string, 0, len(cols))
+	for _, col := range cols {
+		idxCols = append(idxCols, &model.IndexColumn{Name: col.Name, Offset: col.Offset, Length: types.UnspecifiedLength})
+		colNames = append(colNames, col.Name.L)
+	}
+	a.candidates = append(a.candidates, &indexCandidate{
+		dbName:  tbl.DBName,
+		tblInfo: tbl.TableInfo,
+		cols:    cols,
+		idxInfo: &model.IndexInfo{
+			Name:    a.candidateIndexName(tbl.TableInfo, "idx_"+strings.Join(colNames, "_")),
+			Table:   tbl.TableInfo.Name,
+			Columns: idxCols,
+			State:   model.StatePublic,
+			Tp:      model.IndexTypeHypo,
+		},
+	})
+}
+
+// candidateIndexName returns a name which isn't used by the indexes of the table and the other candidates.
+func (a *indexAdvisor) candidateIndexName(tblInfo *model.TableInfo, name string) model.CIStr {
+	if len(name) > mysql.MaxIndexIdentifierLen {
+		name = name[:mysql.MaxIndexIdentifierLen]
+	}
+	used := func(name string) bool {
+		if tblInfo.FindIndexByName(name) != nil {
+			return true
+		}
+		for _, c := range a.candidates {
+			if c.tblInfo.ID == tblInfo.ID && c.idxInfo.Name.L == strings.ToLower(name) {
+				return true
+			}
+		}
+		return false
+	}
+	newName := name
+	for i := 2; used(newName); i++ {
+		suffix := fmt.Sprintf("_%d", i)
+		newName = name[:mathutil.Min(len(name), mysql.MaxIndexIdentifierLen-len(suffix))] + suffix
+	}
+	return model.NewCIStr(newName)
+}
+
+// search greedily chooses the candidate which reduces the cost of the workload the most.
+func (a *indexAdvisor) search(ctx context.Context, originCost float64) ([]*indexCandidate, error) {
+	var recommended []*indexCandidate
+	tableIdxNum := make(map[int64]uint64)
+	dbIdxNum := make(map[string]uint64)
+	remained := a.candidates
+	for len(remained) > 0 {
+		var (
+			best      *indexCandidate
+			bestCosts map[*advisedStmt]float64
+		)
+		for _, c := range remained {
+			if a.timeout() {
+				break
+			}
+			if maxNum := a.info.MaxIndexNum; maxNum != nil &&
+				(tableIdxNum[c.tblInfo.ID] >= maxNum.PerTable || dbIdxNum[c.dbName.L] >= maxNum.PerDB) {
+				continue
+			}
+			costs, reduction, err := a.evaluate(ctx, c)
+			if err != nil {
+				return nil, err
+			}
+			if best == nil || reduction > best.costReduction {
+				best, bestCosts = c, costs
+				best.costReduction = reduction
+			}
+		}
+		if best == nil || best.costReduction < originCost*minCostReductionRatio {
+			break
+		}
+		recommended = append(recommended, best)
+		a.hypoIndexes[best.tblInfo.ID] = append(a.hypoIndexes[best.tblInfo.ID], best.idxInfo)
+		for stmt, cost := range bestCosts {
+			stmt.cost = cost
+		}
+		tableIdxNum[best.tblInfo.ID]++
+		dbIdxNum[best.dbName.L]++
+		remained = removeCandidate(remained, best)
+	}
+	return recommended, nil
+}
+
+// evaluate estimates the costs of the statements accessing the table of the candidate, when the candidate is
+// added to the recommended indexes, and returns the reduction of the workload cost.
+func (a *indexAdvisor) evaluate(ctx context.Context, c *indexCandidate) (map[*advisedStmt]float64, float64, error) {
+	hypoIndexes := make(map[int64][]*model.IndexInfo, len(a.hypoIndexes)+1)
+	for id, indexes := range a.hypoIndexes {
+		hypoIndexes[id] = indexes
+	}
+	hypoIndexes[c.tblInfo.ID] = append(append([]*model.IndexInfo{}, a.hypoIndexes[c.tblInfo.ID]...), c.idxInfo)
+	is := &infoschema.HypoIndexAttachedInfoSchema{InfoSchema: a.is, HypoIndexes: hypoIndexes}
+	costs := make(map[*advisedStmt]float64)
+	var reduction float64
+	for _, stmt := range a.stmts {
+		if _, ok := stmt.tableIDs[c.tblInfo.ID]; !ok {
+			continue
+		}
+		cost, err := a.estimateCost(ctx, stmt.node, is)
+		if err != nil {
+			return nil, 0, err
+		}
+		costs[stmt] = cost
+		reduction += stmt.cost - cost
+	}
+	return costs, reduction, nil
+}
+
+func (a *indexAdvisor) timeout() bool {
+	return !a.deadline.IsZero() && time.Now().After(a.deadline)
+}
+
+// indexableColumns filters out the columns whose types can't be indexed without a prefix length or at all.
+func indexableColumns(cols []*model.ColumnInfo) []*model.ColumnInfo {
+	res := make([]*model.ColumnInfo, 0, len(cols))
+	for _, col := range cols {
+		switch col.Tp {
+		case mysql.TypeJSON, mysql.TypeGeometry, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
+			continue
+		}
+		res = append(res, col)
+	}
+	return res
+}
+
+func containsColumn(cols []*model.ColumnInfo, col *model.ColumnInfo) bool {
+	for _, c := range cols {
+		if c.ID == col.ID {
+			return true
+		}
+	}
+	return false
+}
+
+// isIndexPrefix returns whether the columns are a prefix of the columns of the index.
+func isIndexPrefix(idx *model.IndexInfo, cols []*model.ColumnInfo) bool {
+	if len(cols) > len(idx.Columns) {
+		return false
+	}
+	for i, col := range cols {
+		if idx.Columns[i].Name.L != col.Name.L || idx.Columns[i].Length != types.UnspecifiedLength {
+			return false
+		}
+	}
+	return true
+}
+
+func removeCandidate(candidates []*indexCandidate, c *indexCandidate) []*indexCandidate {
+	res := make([]*indexCandidate, 0, len(candidates)-1)
+	for _, candidate := range candidates {
+		if candidate != c {
+			res = append(res, candidate)
+		}
+	}
+	return res
 }
 
 // IndexAdviseVarKeyType is a dummy type to avoid naming collision in context.
@@ -15,10 +15,14 @@
 package executor_test
 
 import (
+	"context"
+	"fmt"
 	"os"
+	"strconv"
 	"testing"
 
 	"github.com/pingcap/tidb/executor"
+	"github.com/pingcap/tidb/session"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/testkit"
 	"github.com/stretchr/testify/require"
@@ -59,7 +63,6 @@ func TestIndexAdvise(t *testing.T) {
 		"\n")
 	require.NoError(t, err)
 
-	// TODO: Using "tastCase" to do more test when we finish the index advisor completely.
 	tk.MustExec("index advise local infile '/tmp/index_advise.sql' max_minutes 3 max_idxnum per_table 4 per_db 5")
 	ctx := tk.Session().(sessionctx.Context)
 	ia, ok := ctx.Value(executor.IndexAdviseVarKey).(*executor.IndexAdviseInfo)
@@ -69,3 +72,64 @@ func TestIndexAdvise(t *testing.T) {
 	require.Equal(t, uint64(4), ia.MaxIndexNum.PerTable)
 	require.Equal(t, uint64(5), ia.MaxIndexNum.PerDB)
 }
+
+func TestIndexAdviseWorkload(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t1 (id int primary key, a int, b int, c varchar(20), d text, index idx_b(b))")
+	tk.MustExec("create table t2 (id int primary key, a int, e int)")
+	for i := 0; i < 100; i++ {
+		tk.MustExec(fmt.Sprintf("insert into t1 values (%d, %d, %d, 'c%d', 'd%d')", i, i%50, i%10, i, i))
+		tk.MustExec(fmt.Sprintf("insert into t2 values (%d, %d, %d)", i, i, i%20))
+	}
+	tk.MustExec("analyze table t1, t2")
+
+	tk.MustExec("index advise local infile '/tmp/index_advise.sql' max_idxnum per_table 2 per_db 3")
+	ctx := tk.Session().(sessionctx.Context)
+	ia, ok := ctx.Value(executor.IndexAdviseVarKey).(*executor.IndexAdviseInfo)
+	ctx.SetValue(executor.IndexAdviseVarKey, nil)
+	require.True(t, ok)
+	workload := "select * from t1 where a = 1 and c > 'c5';\n" +
+		"select * from t1 where a = 2 and c > 'c7';\n" +
+		"select * from t1 where b = 1 order by id;\n" +
+		"select t1.id, t2.e from t1 join t2 on t1.a = t2.a where t2.e = 5;\n" +
+		"update t2 set e = 1 where e = 2;\n" +
+		"delete from t1 where d = 'd1';\n" +
+		"select * from t_not_exists where a = 1;\n" +
+		"insert into t1 values (1000, 1, 1, 'c', 'd');\n"
+	require.NoError(t, ia.GetIndexAdvice(context.Background(), []byte(workload)))
+	rows, err := session.ResultSetToStringSlice(context.Background(), tk.Session(), ia.Result)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	// The composite index serves both the equal and the range conditions, and the index on t2 serves both the
+	// filter and the index join.
+	require.Equal(t, []string{"test", "t1", "idx_a_c", "a,c", "CREATE INDEX `idx_a_c` ON `test`.`t1`(`a`, `c`)"}, rows[0][:5])
+	require.Equal(t, []string{"test", "t2", "idx_e_a", "e,a", "CREATE INDEX `idx_e_a` ON `test`.`t2`(`e`, `a`)"}, rows[1][:5])
+	improvements := make([]float64, 0, len(rows))
+	for _, row := range rows {
+		reduction, err := strconv.ParseFloat(row[5], 64)
+		require.NoError(t, err)
+		require.Greater(t, reduction, 0.0)
+		improvement, err := strconv.ParseFloat(row[6], 64)
+		require.NoError(t, err)
+		improvements = append(improvements, improvement)
+	}
+	// The improvements are accumulated, and they are the percentages of the cost of the workload.
+	require.Less(t, improvements[0], improvements[1])
+	require.LessOrEqual(t, improvements[1], 100.0)
+	// The statements failed to plan are skipped with warnings.
+	tk.MustQuery("show warnings").Check(testkit.Rows("Warning 1146 Table 'test.t_not_exists' doesn't exist"))
+
+	tk.MustExec("index advise local infile '/tmp/index_advise.sql' max_idxnum per_db 1")
+	ia, ok = ctx.Value(executor.IndexAdviseVarKey).(*executor.IndexAdviseInfo)
+	ctx.SetValue(executor.IndexAdviseVarKey, nil)
+	require.True(t, ok)
+	require.NoError(t, ia.GetIndexAdvice(context.Background(), []byte(workload)))
+	rows, err = session.ResultSetToStringSlice(context.Background(), tk.Session(), ia.Result)
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	require.Equal(t, "idx_a_c", rows[0][2])
+}
@@ -42,6 +42,7 @@ import (
 	"github.com/pingcap/tidb/util/memory"
 	"github.com/pingcap/tidb/util/ranger"
 	"github.com/pingcap/tipb/go-tipb"
+	"github.com/twmb/murmur3"
 	"go.uber.org/zap"
 )
 
@@ -52,7 +53,7 @@ var (
 // IndexMergeReaderExecutor accesses a table with multiple index/table scan.
 // There are three types of workers:
 // 1. partialTableWorker/partialIndexWorker, which are used to fetch the handles
-// 2. indexMergeProcessWorker, which is used to do the `Union` operation.
+// 2. indexMergeProcessWorker, which is used to do the `Union` or `Intersection` operation.
 // 3. indexMergeTableScanWorker, which is used to get the table tuples with the given handles.
 //
 // The execution flow is really like IndexLookUpReader. However, it uses multiple index scans
@@ -64,6 +65,10 @@ var (
 //    1. check whether it has been accessed.
 //    2. if not, record it and send it to the indexMergeTableScanWorker.
 //    3. if accessed, just ignore it.
+//    For the intersection type, indexMergeProcessWorker dispatches the handles to several
+//    intersectionProcessWorkers by their hash values. After all the partial workers finish, every
+//    intersectionProcessWorker sends the handles returned by all the partial workers to the
+//    indexMergeTableScanWorker.
 type IndexMergeReaderExecutor struct {
 	baseExecutor
 
@@ -116,6 +121,9 @@ type IndexMergeReaderExecutor struct {
 	isCorColInPartialFilters []bool
 	isCorColInTableFilter    bool
 	isCorColInPartialAccess  []bool
+
+	// isIntersection indicates whether the handles of the partial plans are intersected rather than united.
+	isIntersection bool
 }
 
 // Table implements the dataSourceExecutor interface.
@@ -250,7 +258,11 @@ func (e *IndexMergeReaderExecutor) startIndexMergeProcessWorker(ctx context.Cont
 		defer trace.StartRegion(ctx, "IndexMergeProcessWorker").End()
 		util.WithRecovery(
 			func() {
-				idxMergeProcessWorker.fetchLoop(ctx, fetch, workCh, e.resultCh, e.finished)
+				if e.isIntersection {
+					idxMergeProcessWorker.fetchLoopIntersection(ctx, fetch, workCh, e.resultCh, e.finished)
+				} else {
+					idxMergeProcessWorker.fetchLoop(ctx, fetch, workCh, e.resultCh, e.finished)
+				}
 			},
 			idxMergeProcessWorker.handleLoopFetcherPanic(ctx, e.resultCh),
 		)
@@ -765,6 +777,166 @@ func (w *indexMergeProcessWorker) fetchLoop(ctx context.Context, fetchCh <-chan
 	}
 }
 
+// fetchLoopIntersection dispatches the handles fetched by the partial workers to the intersectionProcessWorkers
+// by the hash values of the handles, so that every intersectionProcessWorker intersects a disjoint part of the
+// handles concurrently.
+func (w *indexMergeProcessWorker) fetchLoopIntersection(ctx context.Context, fetchCh <-chan *lookupTableTask,
+	workCh chan<- *lookupTableTask, resultCh chan<- *lookupTableTask, finished <-chan struct{}) {
+	defer func() {
+		close(workCh)
+		close(resultCh)
+	}()
+
+	sessVars := w.indexMerge.ctx.GetSessionVars()
+	workerCnt := sessVars.IndexLookupConcurrency()
+	workers := make([]*intersectionProcessWorker, 0, workerCnt)
+	var wg sync.WaitGroup
+	for i := 0; i < workerCnt; i++ {
+		worker := &intersectionProcessWorker{
+			indexMerge:   w.indexMerge,
+			inputCh:      make(chan *lookupTableTask, 1),
+			handleMaps:   make(map[int64]*kv.HandleMap),
+			partitions:   make(map[int64]table.PhysicalTable),
+			partialCnt:   len(w.indexMerge.partialPlans),
+			maxBatchSize: sessVars.IndexLookupSize,
+		}
+		workers = append(workers, worker)
+		wg.Add(1)
+		go func() {
+			defer trace.StartRegion(ctx, "IndexMergeIntersectionProcessWorker").End()
+			defer wg.Done()
+			util.WithRecovery(
+				func() {
+					worker.doIntersection(ctx, workCh, resultCh, finished)
+				},
+				w.handleLoopFetcherPanic(ctx, resultCh),
+			)
+		}()
+	}
+
+dispatch:
+	for task := range fetchCh {
+		start := time.Now()
+		hashedHandles := make([][]kv.Handle, workerCnt)
+		for _, h := range task.handles {
+			idx := intersectionWorkerIdx(h, workerCnt)
+			hashedHandles[idx] = append(hashedHandles[idx], h)
+		}
+		if w.stats != nil {
+			w.stats.IndexMergeProcess += time.Since(start)
+		}
+		for i, handles := range hashedHandles {
+			if len(handles) == 0 {
+				continue
+			}
+			select {
+			case <-ctx.Done():
+				break dispatch
+			case <-finished:
+				break dispatch
+			case workers[i].inputCh <- &lookupTableTask{handles: handles, partitionTable: task.partitionTable}:
+			}
+		}
+	}
+	for _, worker := range workers {
+		close(worker.inputCh)
+	}
+	wg.Wait()
+}
+
+// intersectionWorkerIdx returns the index of the intersectionProcessWorker that handles h.
+func intersectionWorkerIdx(h kv.Handle, workerCnt int) int {
+	if h.IsInt() {
+		return int(uint64(h.IntValue()) % uint64(workerCnt))
+	}
+	return int(murmur3.Sum32(h.Encoded()) % uint32(workerCnt))
+}
+
+// intersectionProcessWorker counts how many partial workers have returned every handle dispatched to it, and
+// sends the handles returned by all the partial workers to the indexMergeTableScanWorker.
+type intersectionProcessWorker struct {
+	indexMerge *IndexMergeReaderExecutor
+	inputCh    chan *lookupTableTask
+	// handleMaps maps the physical table ID to the counts of the handles.
+	handleMaps map[int64]*kv.HandleMap
+	partitions map[int64]table.PhysicalTable
+	partialCnt int
+
+	maxBatchSize int
+}
+
+func (w *intersectionProcessWorker) doIntersection(ctx context.Context, workCh chan<- *lookupTableTask,
+	resultCh chan<- *lookupTableTask, finished <-chan struct{}) {
+	for task := range w.inputCh {
+		var tblID int64
+		if w.indexMerge.partitionTableMode {
+			tblID = getPhysicalTableID(task.partitionTable)
+		} else {
+			tblID = getPhysicalTableID(w.indexMerge.table)
+		}
+		hMap, ok := w.handleMaps[tblID]
+		if !ok {
+			hMap = kv.NewHandleMap()
+			w.handleMaps[tblID] = hMap
+			w.partitions[tblID] = task.partitionTable
+		}
+		for _, h := range task.handles {
+			// The handles returned by one partial worker are distinct, so the count of a handle is the
+			// number of the partial workers returning it.
+			if cnt, ok := hMap.Get(h); ok {
+				hMap.Set(h, cnt.(int)+1)
+			} else {
+				hMap.Set(h, 1)
+			}
+		}
+	}
+
+	for tblID, hMap := range w.handleMaps {
+		handles := make([]kv.Handle, 0, mathutil.Min(hMap.Len(), w.maxBatchSize))
+		stopped := false
+		hMap.Range(func(h kv.Handle, val interface{}) bool {
+			if val.(int) != w.partialCnt {
+				return true
+			}
+			handles = append(handles, h)
+			if len(handles) < w.maxBatchSize {
+				return true
+			}
+			if !w.sendTask(ctx, handles, w.partitions[tblID], workCh, resultCh, finished) {
+				stopped = true
+				return false
+			}
+			handles = make([]kv.Handle, 0, w.maxBatchSize)
+			return true
+		})
+		if stopped {
+			return
+		}
+		if len(handles) > 0 && !w.sendTask(ctx, handles, w.partitions[tblID], workCh, resultCh, finished) {
+			return
+		}
+	}
+}
+
+func (w *intersectionProcessWorker) sendTask(ctx context.Context, handles []kv.Handle, partition table.PhysicalTable,
+	workCh chan<- *lookupTableTask, resultCh chan<- *lookupTableTask, finished <-chan struct{}) bool {
+	task := &lookupTableTask{
+		handles: handles,
+		doneCh:  make(chan error, 1),
+
+		partitionTable: partition,
+	}
+	select {
+	case <-ctx.Done():
+		return false
+	case <-finished:
+		return false
+	case workCh <- task:
+		resultCh <- task
+	}
+	return true
+}
+
 func (w *indexMergeProcessWorker) handleLoopFetcherPanic(ctx context.Context, resultCh chan<- *lookupTableTask) func(r interface{}) {
 	return func(r interface{}) {
 		if r == nil {
@@ -580,3 +580,71 @@ func TestPessimisticLockOnPartitionForIndexMerge(t *testing.T) {
 
 	// TODO: add support for index merge reader in dynamic tidb_partition_prune_mode
 }
+
+func TestIndexMergeIntersection(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set @@tidb_enable_index_merge=1")
+	tk.MustExec("set @@tidb_partition_prune_mode='dynamic'")
+	// Use small batches and several workers to intersect the handles in multiple tasks concurrently.
+	tk.MustExec("set @@tidb_index_lookup_size=8")
+	tk.MustExec("set @@tidb_index_lookup_concurrency=3")
+	tk.MustExec("create table t (a int, b int, c int, key(a), key(b), key(c))")
+	tk.MustExec("create table tpk (id varchar(10) primary key clustered, a int, b int, c int, key(a), key(b), key(c))")
+	tk.MustExec("create table tp (a int, b int, c int, key(a), key(b), key(c)) partition by hash(c) partitions 4")
+	tk.MustExec("create table tnormal (a int, b int, c int)")
+
+	values := make([]string, 0, 256)
+	pkValues := make([]string, 0, 256)
+	for i := 0; i < 256; i++ {
+		a, b, c := rand.Intn(20), rand.Intn(20), rand.Intn(20)
+		values = append(values, fmt.Sprintf("(%v, %v, %v)", a, b, c))
+		pkValues = append(pkValues, fmt.Sprintf("('k%v', %v, %v, %v)", i, a, b, c))
+	}
+	for _, tbl := range []string{"t", "tp", "tnormal"} {
+		tk.MustExec(fmt.Sprintf("insert into %v values %v", tbl, strings.Join(values, ", ")))
+	}
+	tk.MustExec(fmt.Sprintf("insert into tpk values %v", strings.Join(pkValues, ", ")))
+
+	rows := tk.MustQuery("explain select /*+ use_index_merge(t, a, b) */ * from t where a < 10 and b > 5").Rows()
+	require.Equal(t, "type: intersection", rows[0][4])
+
+	randRange := func() (int, int) {
+		a, b := rand.Intn(20), rand.Intn(20)
+		if a > b {
+			return b, a
+		}
+		return a, b
+	}
+	for i := 0; i < 64; i++ {
+		la, ra := randRange()
+		lb, rb := randRange()
+		lc, rc := randRange()
+		cond := fmt.Sprintf("(a between %v and %v) and (b between %v and %v) and c >= %v", la, ra, lb, rb, lc)
+		result := tk.MustQuery("select * from tnormal where " + cond).Sort().Rows()
+		tk.MustQuery("select /*+ use_index_merge(t, a, b) */ * from t where " + cond).Sort().Check(result)
+		tk.MustQuery("select /*+ use_index_merge(tp, a, b) */ * from tp where " + cond).Sort().Check(result)
+		tk.MustQuery("select /*+ use_index_merge(tpk, a, b) */ a, b, c from tpk where " + cond).Sort().Check(result)
+
+		cond = fmt.Sprintf("(a between %v and %v) and (b between %v and %v) and (c between %v and %v)", la, ra, lb, rb, lc, rc)
+		result = tk.MustQuery("select * from tnormal where " + cond).Sort().Rows()
+		tk.MustQuery("select /*+ use_index_merge(t, a, b, c) */ * from t where " + cond).Sort().Check(result)
+		tk.MustQuery("select /*+ use_index_merge(tp, a, b, c) */ * from tp where " + cond).Sort().Check(result)
+	}
+
+	// The rows changed in the transaction are merged by the UnionScan.
+	tk.MustExec("begin")
+	tk.MustExec("insert into t values (1, 1, 1), (1, 2, 3)")
+	tk.MustExec("insert into tnormal values (1, 1, 1), (1, 2, 3)")
+	tk.MustExec("delete from t where a = 2 and b = 2")
+	tk.MustExec("delete from tnormal where a = 2 and b = 2")
+	tk.MustExec("update t set b = 2 where a = 3 and b = 3")
+	tk.MustExec("update tnormal set b = 2 where a = 3 and b = 3")
+	for _, cond := range []string{"a = 1 and b = 1", "a < 4 and b = 2", "a = 2 and b = 2"} {
+		result := tk.MustQuery("select * from tnormal where " + cond).Sort().Rows()
+		tk.MustQuery("select /*+ use_index_merge(t, a, b) */ * from t where " + cond).Sort().Check(result)
+	}
+	tk.MustExec("rollback")
+}
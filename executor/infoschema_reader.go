@@ -121,6 +121,10 @@ func (e *memtableRetriever) retrieve(ctx context.Context, sctx sessionctx.Contex
 			e.setDataFromIndexes(sctx, dbs)
 		case infoschema.TableViews:
 			e.setDataFromViews(sctx, dbs)
+		case infoschema.TableRoutines:
+			e.setDataFromRoutines(sctx, dbs)
+		case infoschema.TableTriggers:
+			e.setDataFromTriggers(sctx, dbs)
 		case infoschema.TableEngines:
 			e.setDataFromEngines()
 		case infoschema.TableCharacterSets:
@@ -166,6 +170,12 @@ func (e *memtableRetriever) retrieve(ctx context.Context, sctx sessionctx.Contex
 			err = e.setDataForAttributes(sctx, is)
 		case infoschema.TablePlacementPolicies:
 			err = e.setDataFromPlacementPolicies(sctx)
+		case infoschema.TableCheckConstraints:
+			e.setDataFromCheckConstraints(sctx, dbs)
+		case infoschema.TableEvents:
+			err = e.setDataFromEvents(ctx, sctx, is)
+		case infoschema.TableEventHistory:
+			err = e.setDataFromEventHistory(ctx, sctx)
 		}
 		if err != nil {
 			return nil, err
@@ -438,6 +448,16 @@ func (e *memtableRetriever) setDataForStatisticsInTable(schema *model.DBInfo, ta
 				expression = tblCol.GeneratedExprString
 			}
 
+			var collation interface{} = "A"
+			indexType := "BTREE"
+			if index.FullText {
+				collation = nil
+				indexType = "FULLTEXT"
+			} else if index.Spatial {
+				collation = nil
+				indexType = "SPATIAL"
+			}
+
 			record := types.MakeDatums(
 				infoschema.CatalogVal, // TABLE_CATALOG
 				schema.Name.O,         // TABLE_SCHEMA
@@ -447,12 +467,12 @@ func (e *memtableRetriever) setDataForStatisticsInTable(schema *model.DBInfo, ta
 				index.Name.O,          // INDEX_NAME
 				i+1,                   // SEQ_IN_INDEX
 				colName,               // COLUMN_NAME
-				"A",                   // COLLATION
+				collation,             // COLLATION
 				0,                     // CARDINALITY
 				nil,                   // SUB_PART
 				nil,                   // PACKED
 				nullable,              // NULLABLE
-				"BTREE",               // INDEX_TYPE
+				indexType,             // INDEX_TYPE
 				"",                    // COMMENT
 				index.Comment,         // INDEX_COMMENT
 				visible,               // IS_VISIBLE
@@ -896,21 +916,42 @@ func (e *memtableRetriever) setDataFromPartitions(ctx context.Context, sctx sess
 						avgRowLength = dataLength / rowCount
 					}
 
+					// The physical partitions of a subpartitioned table are the subpartitions, the values
+					// of the partition are those of the first level partition which they belong to.
+					partDef, partPos := pi, i
+					var (
+						subPartName, subPartPos    interface{}
+						subPartMethod, subPartExpr interface{}
+					)
+					if table.Partition.IsSubPartitioned() {
+						partPos = i / int(table.Partition.SubNum)
+						partDef = table.Partition.ParentDefinitions[partPos]
+						subPartName, subPartPos = pi.Name.O, i%int(table.Partition.SubNum)+1
+						subPartMethod, subPartExpr = table.Partition.SubType.String(), table.Partition.SubExpr
+						if len(table.Partition.SubColumns) > 0 {
+							cols := make([]string, 0, len(table.Partition.SubColumns))
+							for _, col := range table.Partition.SubColumns {
+								cols = append(cols, col.String())
+							}
+							subPartExpr = strings.Join(cols, ",")
+						}
+					}
+
 					var partitionDesc string
 					if table.Partition.Type == model.PartitionTypeRange {
-						partitionDesc = strings.Join(pi.LessThan, ",")
+						partitionDesc = strings.Join(partDef.LessThan, ",")
 					} else if table.Partition.Type == model.PartitionTypeList {
-						if len(pi.InValues) > 0 {
+						if len(partDef.InValues) > 0 {
 							buf := bytes.NewBuffer(nil)
-							if len(pi.InValues[0]) == 1 {
-								for i, vs := range pi.InValues {
+							if len(partDef.InValues[0]) == 1 {
+								for i, vs := range partDef.InValues {
 									if i > 0 {
 										buf.WriteString(",")
 									}
 									buf.WriteString(vs[0])
 								}
-							} else if len(pi.InValues[0]) > 1 {
-								for i, vs := range pi.InValues {
+							} else if len(partDef.InValues[0]) > 1 {
+								for i, vs := range partDef.InValues {
 									if i > 0 {
 										buf.WriteString(",")
 									}
@@ -925,11 +966,13 @@ func (e *memtableRetriever) setDataFromPartitions(ctx context.Context, sctx sess
 
 					partitionMethod := table.Partition.Type.String()
 					partitionExpr := table.Partition.Expr
-					if table.Partition.Type == model.PartitionTypeRange && len(table.Partition.Columns) > 0 {
-						partitionMethod = "RANGE COLUMNS"
-						partitionExpr = table.Partition.Columns[0].String()
-					} else if table.Partition.Type == model.PartitionTypeList && len(table.Partition.Columns) > 0 {
-						partitionMethod = "LIST COLUMNS"
+					if len(table.Partition.Columns) > 0 {
+						switch table.Partition.Type {
+						case model.PartitionTypeRange:
+							partitionMethod = "RANGE COLUMNS"
+						case model.PartitionTypeList:
+							partitionMethod = "LIST COLUMNS"
+						}
 						buf := bytes.NewBuffer(nil)
 						for i, col := range table.Partition.Columns {
 							if i > 0 {
@@ -948,14 +991,14 @@ func (e *memtableRetriever) setDataFromPartitions(ctx context.Context, sctx sess
 						infoschema.CatalogVal, // TABLE_CATALOG
 						schema.Name.O,         // TABLE_SCHEMA
 						table.Name.O,          // TABLE_NAME
-						pi.Name.O,             // PARTITION_NAME
-						nil,                   // SUBPARTITION_NAME
-						i+1,                   // PARTITION_ORDINAL_POSITION
-						nil,                   // SUBPARTITION_ORDINAL_POSITION
+						partDef.Name.O,        // PARTITION_NAME
+						subPartName,           // SUBPARTITION_NAME
+						partPos+1,             // PARTITION_ORDINAL_POSITION
+						subPartPos,            // SUBPARTITION_ORDINAL_POSITION
 						partitionMethod,       // PARTITION_METHOD
-						nil,                   // SUBPARTITION_METHOD
+						subPartMethod,         // SUBPARTITION_METHOD
 						partitionExpr,         // PARTITION_EXPRESSION
-						nil,                   // SUBPARTITION_EXPRESSION
+						subPartExpr,           // SUBPARTITION_EXPRESSION
 						partitionDesc,         // PARTITION_DESCRIPTION
 						rowCount,              // TABLE_ROWS
 						avgRowLength,          // AVG_ROW_LENGTH
@@ -1103,6 +1146,126 @@ func (e *memtableRetriever) setDataFromViews(ctx sessionctx.Context, schemas []*
 	e.rows = rows
 }
 
+func (e *memtableRetriever) setDataFromRoutines(ctx sessionctx.Context, schemas []*model.DBInfo) {
+	checker := privilege.GetPrivilegeManager(ctx)
+	loc := ctx.GetSessionVars().TimeZone
+	if loc == nil {
+		loc = time.Local
+	}
+	var rows [][]types.Datum
+	for _, schema := range schemas {
+		if checker != nil && !checker.RequestVerification(ctx.GetSessionVars().ActiveRoles, schema.Name.L, "", "", mysql.AllPrivMask) {
+			continue
+		}
+		collation := schema.Collate
+		if collation == "" {
+			collation = mysql.DefaultCollationName
+		}
+		for _, proc := range schema.Procedures {
+			createTime := types.NewTime(types.FromGoTime(proc.CreateTime.In(loc)), mysql.TypeDatetime, types.DefaultFsp)
+			definer := ""
+			if proc.Definer != nil {
+				definer = proc.Definer.String()
+			}
+			record := types.MakeDatums(
+				proc.Name.O,           // SPECIFIC_NAME
+				infoschema.CatalogVal, // ROUTINE_CATALOG
+				schema.Name.O,         // ROUTINE_SCHEMA
+				proc.Name.O,           // ROUTINE_NAME
+				"PROCEDURE",           // ROUTINE_TYPE
+				"",                    // DATA_TYPE
+				nil,                   // CHARACTER_MAXIMUM_LENGTH
+				nil,                   // CHARACTER_OCTET_LENGTH
+				nil,                   // NUMERIC_PRECISION
+				nil,                   // NUMERIC_SCALE
+				nil,                   // DATETIME_PRECISION
+				nil,                   // CHARACTER_SET_NAME
+				nil,                   // COLLATION_NAME
+				nil,                   // DTD_IDENTIFIER
+				"SQL",                 // ROUTINE_BODY
+				proc.Body,             // ROUTINE_DEFINITION
+				nil,                   // EXTERNAL_NAME
+				"SQL",                 // EXTERNAL_LANGUAGE
+				"SQL",                 // PARAMETER_STYLE
+				"NO",                  // IS_DETERMINISTIC
+				"CONTAINS SQL",        // SQL_DATA_ACCESS
+				nil,                   // SQL_PATH
+				"INVOKER",             // SECURITY_TYPE
+				createTime,            // CREATED
+				createTime,            // LAST_ALTERED
+				proc.SQLMode,          // SQL_MODE
+				"",                    // ROUTINE_COMMENT
+				definer,               // DEFINER
+				proc.Charset,          // CHARACTER_SET_CLIENT
+				proc.Collate,          // COLLATION_CONNECTION
+				collation,             // DATABASE_COLLATION
+			)
+			rows = append(rows, record)
+		}
+	}
+	e.rows = rows
+}
+
+func (e *memtableRetriever) setDataFromTriggers(ctx sessionctx.Context, schemas []*model.DBInfo) {
+	checker := privilege.GetPrivilegeManager(ctx)
+	loc := ctx.GetSessionVars().TimeZone
+	if loc == nil {
+		loc = time.Local
+	}
+	var rows [][]types.Datum
+	for _, schema := range schemas {
+		collation := schema.Collate
+		if collation == "" {
+			collation = mysql.DefaultCollationName
+		}
+		for _, tbl := range schema.Tables {
+			if len(tbl.Triggers) == 0 {
+				continue
+			}
+			if checker != nil && !checker.RequestVerification(ctx.GetSessionVars().ActiveRoles, schema.Name.L, tbl.Name.L, "", mysql.TriggerPriv) {
+				continue
+			}
+			// ACTION_ORDER is the activation order among the triggers with the same timing and event.
+			orders := make(map[[2]int]int64)
+			for _, trig := range tbl.Triggers {
+				key := [2]int{int(trig.Timing), int(trig.Event)}
+				orders[key]++
+				createTime := types.NewTime(types.FromGoTime(trig.CreateTime.In(loc)), mysql.TypeDatetime, 2)
+				definer := ""
+				if trig.Definer != nil {
+					definer = trig.Definer.String()
+				}
+				record := types.MakeDatums(
+					infoschema.CatalogVal, // TRIGGER_CATALOG
+					schema.Name.O,         // TRIGGER_SCHEMA
+					trig.Name.O,           // TRIGGER_NAME
+					trig.Event.String(),   // EVENT_MANIPULATION
+					infoschema.CatalogVal, // EVENT_OBJECT_CATALOG
+					schema.Name.O,         // EVENT_OBJECT_SCHEMA
+					tbl.Name.O,            // EVENT_OBJECT_TABLE
+					orders[key],           // ACTION_ORDER
+					nil,                   // ACTION_CONDITION
+					trig.Body,             // ACTION_STATEMENT
+					"ROW",                 // ACTION_ORIENTATION
+					trig.Timing.String(),  // ACTION_TIMING
+					nil,                   // ACTION_REFERENCE_OLD_TABLE
+					nil,                   // ACTION_REFERENCE_NEW_TABLE
+					"OLD",                 // ACTION_REFERENCE_OLD_ROW
+					"NEW",                 // ACTION_REFERENCE_NEW_ROW
+					createTime,            // CREATED
+					trig.SQLMode,          // SQL_MODE
+					definer,               // DEFINER
+					trig.Charset,          // CHARACTER_SET_CLIENT
+					trig.Collate,          // COLLATION_CONNECTION
+					collation,             // DATABASE_COLLATION
+				)
+				rows = append(rows, record)
+			}
+		}
+	}
+	e.rows = rows
+}
+
 func (e *memtableRetriever) dataForTiKVStoreStatus(ctx sessionctx.Context) (err error) {
 	tikvStore, ok := ctx.GetStore().(helper.Storage)
 	if !ok {
@@ -1191,6 +1354,15 @@ func (e *DDLJobsReaderExec) Open(ctx context.Context) error {
 	return nil
 }
 
+// appendJobAndQueryToChunk appends the rows of the job and fills the query of each row.
+func (e *DDLJobsReaderExec) appendJobAndQueryToChunk(req *chunk.Chunk, job *model.Job, checker privilege.Manager) {
+	numRows := req.NumRows()
+	e.appendJobToChunk(req, job, checker)
+	for i := numRows; i < req.NumRows(); i++ {
+		req.AppendString(12, job.Query)
+	}
+}
+
 // Next implements the Executor Next interface.
 func (e *DDLJobsReaderExec) Next(ctx context.Context, req *chunk.Chunk) error {
 	req.GrowAndReset(e.maxChunkSize)
@@ -1201,8 +1373,7 @@ func (e *DDLJobsReaderExec) Next(ctx context.Context, req *chunk.Chunk) error {
 	if e.cursor < len(e.runningJobs) {
 		num := mathutil.Min(req.Capacity(), len(e.runningJobs)-e.cursor)
 		for i := e.cursor; i < e.cursor+num; i++ {
-			e.appendJobToChunk(req, e.runningJobs[i], checker)
-			req.AppendString(12, e.runningJobs[i].Query)
+			e.appendJobAndQueryToChunk(req, e.runningJobs[i], checker)
 		}
 		e.cursor += num
 		count += num
@@ -1216,8 +1387,7 @@ func (e *DDLJobsReaderExec) Next(ctx context.Context, req *chunk.Chunk) error {
 			return err
 		}
 		for _, job := range e.cacheJobs {
-			e.appendJobToChunk(req, job, checker)
-			req.AppendString(12, job.Query)
+			e.appendJobAndQueryToChunk(req, job, checker)
 		}
 		e.cursor += len(e.cacheJobs)
 	}
@@ -1641,11 +1811,145 @@ func (e *memtableRetriever) setDataFromTableConstraints(ctx sessionctx.Context,
 				)
 				rows = append(rows, record)
 			}
+
+			for _, constraint := range tbl.Constraints {
+				if constraint.State != model.StatePublic {
+					continue
+				}
+				record := types.MakeDatums(
+					infoschema.CatalogVal,          // CONSTRAINT_CATALOG
+					schema.Name.O,                  // CONSTRAINT_SCHEMA
+					constraint.Name.O,              // CONSTRAINT_NAME
+					schema.Name.O,                  // TABLE_SCHEMA
+					tbl.Name.O,                     // TABLE_NAME
+					infoschema.CheckConstraintType, // CONSTRAINT_TYPE
+				)
+				rows = append(rows, record)
+			}
 		}
 	}
 	e.rows = rows
 }
 
+// setDataFromCheckConstraints constructs data for table information_schema.check_constraints.
+// See https://dev.mysql.com/doc/refman/8.0/en/information-schema-check-constraints-table.html
+func (e *memtableRetriever) setDataFromCheckConstraints(ctx sessionctx.Context, schemas []*model.DBInfo) {
+	checker := privilege.GetPrivilegeManager(ctx)
+	var rows [][]types.Datum
+	for _, schema := range schemas {
+		for _, tbl := range schema.Tables {
+			if checker != nil && !checker.RequestVerification(ctx.GetSessionVars().ActiveRoles, schema.Name.L, tbl.Name.L, "", mysql.AllPrivMask) {
+				continue
+			}
+			for _, constraint := range tbl.Constraints {
+				if constraint.State != model.StatePublic {
+					continue
+				}
+				record := types.MakeDatums(
+					infoschema.CatalogVal, // CONSTRAINT_CATALOG
+					schema.Name.O,         // CONSTRAINT_SCHEMA
+					constraint.Name.O,     // CONSTRAINT_NAME
+					fmt.Sprintf("(%s)", constraint.ExprString), // CHECK_CLAUSE
+				)
+				rows = append(rows, record)
+			}
+		}
+	}
+	e.rows = rows
+}
+
+// setDataFromEvents constructs data for table information_schema.events.
+// See https://dev.mysql.com/doc/refman/8.0/en/information-schema-events-table.html
+func (e *memtableRetriever) setDataFromEvents(ctx context.Context, sctx sessionctx.Context, is infoschema.InfoSchema) error {
+	events, err := LoadEvents(ctx, sctx, "1")
+	if err != nil {
+		return err
+	}
+	checker := privilege.GetPrivilegeManager(sctx)
+	loc := sctx.GetSessionVars().Location()
+	var rows [][]types.Datum
+	for _, ev := range events {
+		schema, ok := is.SchemaByName(model.NewCIStr(ev.Schema))
+		if !ok {
+			continue
+		}
+		if checker != nil && !checker.RequestVerification(sctx.GetSessionVars().ActiveRoles, schema.Name.L, "", "", mysql.EventPriv) {
+			continue
+		}
+		collation := schema.Collate
+		if collation == "" {
+			collation = mysql.DefaultCollationName
+		}
+		eventType, intervalValue, intervalField := "RECURRING", interface{}(ev.IntervalValue), interface{}(ev.IntervalField)
+		if !ev.ExecuteAt.IsZero() {
+			eventType, intervalValue, intervalField = "ONE TIME", nil, nil
+		}
+		record := types.MakeDatums(
+			infoschema.CatalogVal,                // EVENT_CATALOG
+			ev.Schema,                            // EVENT_SCHEMA
+			ev.Name,                              // EVENT_NAME
+			ev.Definer,                           // DEFINER
+			ev.TimeZone,                          // TIME_ZONE
+			"SQL",                                // EVENT_BODY
+			ev.Body,                              // EVENT_DEFINITION
+			eventType,                            // EVENT_TYPE
+			eventTimeValue(ev.ExecuteAt, loc),    // EXECUTE_AT
+			intervalValue,                        // INTERVAL_VALUE
+			intervalField,                        // INTERVAL_FIELD
+			ev.SQLMode,                           // SQL_MODE
+			eventTimeValue(ev.Starts, loc),       // STARTS
+			eventTimeValue(ev.Ends, loc),         // ENDS
+			ev.Status,                            // STATUS
+			ev.OnCompletion,                      // ON_COMPLETION
+			eventTimeValue(ev.Created, loc),      // CREATED
+			eventTimeValue(ev.LastAltered, loc),  // LAST_ALTERED
+			eventTimeValue(ev.LastExecuted, loc), // LAST_EXECUTED
+			ev.Comment,                           // EVENT_COMMENT
+			0,                                    // ORIGINATOR
+			ev.Charset,                           // CHARACTER_SET_CLIENT
+			ev.Collation,                         // COLLATION_CONNECTION
+			collation,                            // DATABASE_COLLATION
+		)
+		rows = append(rows, record)
+	}
+	e.rows = rows
+	return nil
+}
+
+// setDataFromEventHistory constructs data for table information_schema.event_history.
+func (e *memtableRetriever) setDataFromEventHistory(ctx context.Context, sctx sessionctx.Context) error {
+	exec := sctx.(sqlexec.RestrictedSQLExecutor)
+	chunkRows, _, err := exec.ExecRestrictedSQL(ctx, nil, "SELECT event_schema, event_name, instance, start_time, end_time, state, error_message FROM mysql.event_history ORDER BY start_time")
+	if err != nil {
+		return err
+	}
+	checker := privilege.GetPrivilegeManager(sctx)
+	loc := sctx.GetSessionVars().Location()
+	rows := make([][]types.Datum, 0, len(chunkRows))
+	for _, row := range chunkRows {
+		schema := row.GetString(0)
+		if checker != nil && !checker.RequestVerification(sctx.GetSessionVars().ActiveRoles, strings.ToLower(schema), "", "", mysql.EventPriv) {
+			continue
+		}
+		record := types.MakeDatums(schema, row.GetString(1), row.GetString(2), nil, nil, row.GetEnum(5).String(), nil)
+		for i, col := range []int{3, 4} {
+			if !row.IsNull(col) {
+				t := row.GetTime(col)
+				if err := t.ConvertTimeZone(time.UTC, loc); err != nil {
+					return err
+				}
+				record[3+i] = types.NewDatum(t)
+			}
+		}
+		if !row.IsNull(6) {
+			record[6] = types.NewDatum(row.GetString(6))
+		}
+		rows = append(rows, record)
+	}
+	e.rows = rows
+	return nil
+}
+
 // tableStorageStatsRetriever is used to read slow log data.
 type tableStorageStatsRetriever struct {
 	dummyCloser
@@ -111,6 +111,9 @@ func (e *InsertExec) exec(ctx context.Context, rows [][]types.Datum) error {
 			e.stats.CheckInsertTime += time.Since(start)
 		}
 	}
+	if err = doFKCascadesAndChecks(ctx, e.fkChecks, e.fkCascades); err != nil {
+		return err
+	}
 	e.memTracker.Consume(int64(txn.Size() - txnSize))
 	return nil
 }
@@ -406,11 +409,11 @@ func (e *InsertExec) doDupRowUpdate(ctx context.Context, handle kv.Handle, oldRo
 	}
 
 	newData := e.row4Update[:len(oldRow)]
-	_, err := updateRecord(ctx, e.ctx, handle, oldRow, newData, assignFlag, e.Table, true, e.memTracker)
-	if err != nil {
+	changed, err := updateRecord(ctx, e.ctx, handle, oldRow, newData, assignFlag, e.Table, true, e.memTracker, e.triggers)
+	if err != nil || !changed {
 		return err
 	}
-	return nil
+	return onUpdateRowForFK(e.ctx.GetSessionVars().StmtCtx, oldRow, newData, e.fkChecks, e.fkCascades)
 }
 
 // setMessage sets info message(ERR_INSERT_INFO) generated by INSERT statement
@@ -95,6 +95,10 @@ type InsertValues struct {
 	// We use mutex to protect routine from using invalid txn.
 	isLoadData bool
 	txnInUse   sync.Mutex
+
+	fkChecks   []*FKCheckExec
+	fkCascades []*FKCascadeExec
+	triggers   *triggerExec
 }
 
 type defaultVal struct {
@@ -646,6 +650,20 @@ func (e *InsertValues) fillRow(ctx context.Context, row []types.Datum, hasValue
 			return nil, err
 		}
 	}
+	if e.triggers != nil {
+		if err := e.triggers.fire(ctx, model.TriggerBefore, model.TriggerInsert, nil, row); err != nil {
+			return nil, err
+		}
+		// The BEFORE INSERT triggers may set the columns to NULL.
+		for i, c := range e.Table.Cols() {
+			if e.lazyFillAutoID && mysql.HasAutoIncrementFlag(c.Flag) {
+				continue
+			}
+			if err := c.HandleBadNull(&row[i], e.ctx.GetSessionVars().StmtCtx); err != nil {
+				return nil, err
+			}
+		}
+	}
 	return row, nil
 }
 
@@ -1125,6 +1143,11 @@ func (e *InsertValues) batchCheckAndInsert(ctx context.Context, rows [][]types.D
 			e.ctx.GetSessionVars().StmtCtx.AddCopiedRows(1)
 			err = addRecord(ctx, rows[i])
 			if err != nil {
+				// The rows which violate the check constraints are skipped with warnings in the IGNORE mode.
+				if e.ctx.GetSessionVars().StmtCtx.DupKeyAsWarning && table.ErrCheckConstraintViolated.Equal(err) {
+					e.ctx.GetSessionVars().StmtCtx.AppendWarning(err)
+					continue
+				}
 				return err
 			}
 		}
@@ -1209,7 +1232,10 @@ func (e *InsertValues) addRecordWithAutoIDHint(ctx context.Context, row []types.
 	if e.lastInsertID != 0 {
 		vars.SetLastInsertID(e.lastInsertID)
 	}
-	return nil
+	if err = onAddRowForFK(vars.StmtCtx, row, e.fkChecks); err != nil {
+		return err
+	}
+	return e.triggers.fire(ctx, model.TriggerAfter, model.TriggerInsert, nil, row)
 }
 
 // InsertRuntimeStat record the stat about insert and check
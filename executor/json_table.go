@@ -0,0 +1,179 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/mysql"
+	plannercore "github.com/pingcap/tidb/planner/core"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/types/json"
+	"github.com/pingcap/tidb/util/chunk"
+)
+
+var _ Executor = &JSONTableExec{}
+
+// JSONTableExec turns the JSON document into rows for the JSON_TABLE table function.
+// All the rows of the document are generated in Open, since the document has been evaluated
+// into memory anyway. It is reopened by the Apply for each outer row when the document
+// references the tables on its left side.
+type JSONTableExec struct {
+	baseExecutor
+
+	expr     expression.Expression
+	rootPath *plannercore.JSONTableNestedPath
+
+	rows   [][]types.Datum
+	cursor int
+}
+
+// Open implements the Executor Open interface.
+func (e *JSONTableExec) Open(ctx context.Context) error {
+	e.rows = e.rows[:0]
+	e.cursor = 0
+	doc, isNull, err := e.expr.EvalJSON(e.ctx, chunk.Row{})
+	if err != nil || isNull {
+		return err
+	}
+	row := make([]types.Datum, e.schema.Len())
+	return e.appendRows(e.rootPath, doc, row)
+}
+
+// Next implements the Executor Next interface.
+func (e *JSONTableExec) Next(ctx context.Context, req *chunk.Chunk) error {
+	req.GrowAndReset(e.maxChunkSize)
+	for ; e.cursor < len(e.rows) && !req.IsFull(); e.cursor++ {
+		for i := range e.rows[e.cursor] {
+			req.AppendDatum(i, &e.rows[e.cursor][i])
+		}
+	}
+	return nil
+}
+
+// Close implements the Executor Close interface.
+func (e *JSONTableExec) Close() error {
+	e.rows = nil
+	return e.baseExecutor.Close()
+}
+
+// appendRows generates the rows for every value matched by the nested path in the context value.
+func (e *JSONTableExec) appendRows(nestedPath *plannercore.JSONTableNestedPath, ctxVal json.BinaryJSON, row []types.Datum) error {
+	for i, val := range ctxVal.ExtractAll(nestedPath.Path) {
+		for _, col := range nestedPath.Columns {
+			d, err := e.evalColumn(col, val, i+1)
+			if err != nil {
+				return err
+			}
+			row[col.Offset] = d
+		}
+		if err := e.appendNestedRows(nestedPath.NestedPaths, val, row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// appendNestedRows joins the row with the rows generated by the sibling nested paths. The rows of the
+// siblings are not joined with each other, the columns of the other siblings are NULL in them. If no
+// sibling produces a row, the row is still generated with all the nested columns as NULL.
+func (e *JSONTableExec) appendNestedRows(nestedPaths []*plannercore.JSONTableNestedPath, ctxVal json.BinaryJSON, row []types.Datum) error {
+	numRows := len(e.rows)
+	for _, nestedPath := range nestedPaths {
+		if err := e.appendRows(nestedPath, ctxVal, row); err != nil {
+			return err
+		}
+		resetJSONTableColumns(nestedPath, row)
+	}
+	if len(e.rows) == numRows {
+		e.rows = append(e.rows, append([]types.Datum(nil), row...))
+	}
+	return nil
+}
+
+func resetJSONTableColumns(nestedPath *plannercore.JSONTableNestedPath, row []types.Datum) {
+	for _, col := range nestedPath.Columns {
+		row[col.Offset].SetNull()
+	}
+	for _, child := range nestedPath.NestedPaths {
+		resetJSONTableColumns(child, row)
+	}
+}
+
+func (e *JSONTableExec) evalColumn(col *plannercore.JSONTableColumn, ctxVal json.BinaryJSON, ordinality int) (types.Datum, error) {
+	tp := e.schema.Columns[col.Offset].RetType
+	switch col.Tp {
+	case ast.JSONTableColumnOrdinality:
+		return types.NewUintDatum(uint64(ordinality)), nil
+	case ast.JSONTableColumnExistsPath:
+		d := types.NewIntDatum(0)
+		if len(ctxVal.ExtractAll(col.Path)) > 0 {
+			d.SetInt64(1)
+		}
+		return d.ConvertTo(e.ctx.GetSessionVars().StmtCtx, tp)
+	}
+
+	vals := ctxVal.ExtractAll(col.Path)
+	if len(vals) == 0 {
+		return e.onResponse(col.OnEmpty, col.DefaultOnEmpty, ErrMissingJSONTableValue.GenWithStackByArgs(col.Name.O))
+	}
+	d, err := e.convertValue(col, vals, tp)
+	if err != nil {
+		return e.onResponse(col.OnError, col.DefaultOnError, err)
+	}
+	return d, nil
+}
+
+func (e *JSONTableExec) convertValue(col *plannercore.JSONTableColumn, vals []json.BinaryJSON, tp *types.FieldType) (types.Datum, error) {
+	if len(vals) > 1 {
+		return types.Datum{}, ErrWrongJSONTableValue.GenWithStackByArgs(col.Name.O)
+	}
+	val := vals[0]
+	if tp.Tp == mysql.TypeJSON {
+		return types.NewJSONDatum(val), nil
+	}
+	var d types.Datum
+	switch val.TypeCode {
+	case json.TypeCodeObject, json.TypeCodeArray:
+		return types.Datum{}, ErrWrongJSONTableValue.GenWithStackByArgs(col.Name.O)
+	case json.TypeCodeLiteral:
+		if val.Value[0] == json.LiteralNil {
+			return d, nil
+		}
+		d = types.NewJSONDatum(val)
+	case json.TypeCodeString:
+		d = types.NewStringDatum(string(val.GetString()))
+	default:
+		d = types.NewJSONDatum(val)
+	}
+	return d.ConvertTo(e.ctx.GetSessionVars().StmtCtx, tp)
+}
+
+// onResponse returns the value of the column according to the `ON EMPTY` or `ON ERROR` clause, the default
+// behavior is `NULL ON EMPTY` and `NULL ON ERROR`.
+func (e *JSONTableExec) onResponse(resp *ast.JSONTableOnResponse, defaultVal types.Datum, err error) (types.Datum, error) {
+	if resp == nil {
+		return types.Datum{}, nil
+	}
+	switch resp.Tp {
+	case ast.JSONTableOnResponseError:
+		return types.Datum{}, err
+	case ast.JSONTableOnResponseDefault:
+		return defaultVal, nil
+	}
+	return types.Datum{}, nil
+}
@@ -0,0 +1,93 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor_test
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/testkit"
+)
+
+func TestJSONTable(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	tk.MustQuery(`select * from json_table('[{"a": 1, "b": "x"}, {"a": 2}, {"a": "3", "b": [1]}]', '$[*]' columns (
+		id for ordinality,
+		a int path '$.a',
+		b varchar(10) path '$.b',
+		c json path '$.b',
+		d int exists path '$.b')) as jt`).Check(testkit.Rows(
+		"1 1 x \"x\" 1",
+		"2 2 <nil> <nil> 0",
+		"3 3 <nil> [1] 1",
+	))
+
+	// ON EMPTY and ON ERROR.
+	tk.MustQuery(`select * from json_table('[{"a": 1}, {"b": 2}, {"a": [1, 2]}]', '$[*]' columns (
+		a int path '$.a' default '10' on empty default '20' on error)) as jt`).Check(testkit.Rows("1", "10", "20"))
+	tk.MustGetErrMsg(`select * from json_table('[{"b": 2}]', '$[*]' columns (a int path '$.a' error on empty)) as jt`,
+		"[executor:3665]Missing value for JSON_TABLE column 'a'")
+	tk.MustGetErrMsg(`select * from json_table('[{"a": [1]}]', '$[*]' columns (a int path '$.a' error on error)) as jt`,
+		"[executor:3666]Can't store an array or an object in the scalar column 'a' of JSON_TABLE")
+
+	// NESTED PATH.
+	doc := `'[{"a": 1, "b": [11, 12], "c": ["x"]}, {"a": 2, "b": [], "c": []}, {"a": 3, "b": [31]}]'`
+	tk.MustQuery(`select * from json_table(` + doc + `, '$[*]' columns (
+		a int path '$.a',
+		nested path '$.b[*]' columns (bid for ordinality, b int path '$'),
+		nested path '$.c[*]' columns (c varchar(10) path '$'))) as jt`).Check(testkit.Rows(
+		"1 1 11 <nil>",
+		"1 2 12 <nil>",
+		"1 <nil> <nil> x",
+		"2 <nil> <nil> <nil>",
+		"3 1 31 <nil>",
+	))
+	tk.MustQuery(`select * from json_table('{"a": {"b": [{"c": [1, 2]}, {"c": []}]}}', '$.a' columns (
+		nested path '$.b[*]' columns (nested path '$.c[*]' columns (c int path '$')))) as jt`).Check(testkit.Rows(
+		"1", "2", "<nil>",
+	))
+
+	// Lateral references to the tables on the left side.
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t (id int, doc json)")
+	tk.MustExec(`insert into t values (1, '[1, 2]'), (2, '[]'), (3, null), (4, '[3]')`)
+	tk.MustQuery(`select t.id, jt.v from t, json_table(t.doc, '$[*]' columns (v int path '$')) as jt order by t.id, jt.v`).Check(testkit.Rows(
+		"1 1", "1 2", "4 3",
+	))
+	tk.MustQuery(`explain format='brief' select t.id, jt.v from t, json_table(t.doc, '$[*]' columns (v int path '$')) as jt`).Check(testkit.Rows(
+		"Projection 10000.00 root  test.t.id, Column#4",
+		"└─Apply 10000.00 root  CARTESIAN inner join",
+		"  ├─TableReader(Build) 10000.00 root  data:TableFullScan",
+		"  │ └─TableFullScan 10000.00 cop[tikv] table:t keep order:false, stats:pseudo",
+		"  └─JSONTable(Probe) 1.00 root  expr:test.t.doc, path:$[*]",
+	))
+	tk.MustQuery(`select t.id, jt.v from t left join json_table(t.doc, '$[*]' columns (v int path '$')) as jt on true order by t.id, jt.v`).Check(testkit.Rows(
+		"1 1", "1 2", "2 <nil>", "3 <nil>", "4 3",
+	))
+	tk.MustQuery(`select t.id, jt.v from t join json_table(t.doc, '$[*]' columns (v int path '$')) as jt on jt.v > 1 where t.id < 4 order by t.id`).Check(testkit.Rows(
+		"1 2",
+	))
+	tk.MustQuery(`select id, (select sum(v) from json_table(t.doc, '$[*]' columns (v int path '$')) as jt) from t order by id`).Check(testkit.Rows(
+		"1 3", "2 <nil>", "3 <nil>", "4 3",
+	))
+	tk.MustGetErrCode(`select * from t right join json_table(t.doc, '$[*]' columns (v int path '$')) as jt on true`, errno.ErrTableFunctionForbiddenJoinType)
+	tk.MustGetErrCode(`select * from json_table('[]', '$[*]' columns (a int path '$', a int path '$')) as jt`, errno.ErrDupFieldName)
+	tk.MustGetErrCode(`select * from json_table('[]', '$[*' columns (a int path '$')) as jt`, errno.ErrInvalidJSONPath)
+}
@@ -24,6 +24,7 @@ import (
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/failpoint"
+	"github.com/pingcap/tidb/br/pkg/storage"
 	"github.com/pingcap/tidb/expression"
 	"github.com/pingcap/tidb/parser/ast"
 	"github.com/pingcap/tidb/parser/model"
@@ -54,10 +55,6 @@ type LoadDataExec struct {
 // Next implements the Executor Next interface.
 func (e *LoadDataExec) Next(ctx context.Context, req *chunk.Chunk) error {
 	req.GrowAndReset(e.maxChunkSize)
-	// TODO: support load data without local field.
-	if !e.IsLocal {
-		return errors.New("Load Data: don't support load data without local field")
-	}
 	e.loadDataInfo.OnDuplicate = e.OnDuplicate
 	// TODO: support lines terminated is "".
 	if len(e.loadDataInfo.LinesInfo.Terminated) == 0 {
@@ -73,6 +70,12 @@ func (e *LoadDataExec) Next(ctx context.Context, req *chunk.Chunk) error {
 	if e.loadDataInfo.Path == "" {
 		return errors.New("Load Data: infile path is empty")
 	}
+	// The files of LOAD DATA INFILE are read from the external storage rather than sent by the client.
+	if !e.IsLocal {
+		if err := e.loadDataInfo.initExternalStorage(ctx); err != nil {
+			return err
+		}
+	}
 	sctx.SetValue(LoadDataVarKey, e.loadDataInfo)
 
 	return nil
@@ -121,6 +124,11 @@ type LoadDataInfo struct {
 	StopCh          chan struct{}
 	QuitCh          chan struct{}
 	OnDuplicate     ast.OnDuplicateKeyHandlingType
+
+	// IsLocal is false for LOAD DATA INFILE, whose files are read from store instead of the client.
+	IsLocal bool
+	store   storage.ExternalStorage
+	files   []string
 }
 
 // FieldMapping inticates the relationship between input field and table column or user variable
@@ -544,6 +552,30 @@ func (e *LoadDataInfo) InsertData(ctx context.Context, prevData, curData []byte)
 	return curData, reachLimit, nil
 }
 
+// InsertDataWithCommit inserts the data like InsertData, and enqueues a commit task whenever
+// the number of rows reaches the batch limit. It returns the data which isn't processed.
+func (e *LoadDataInfo) InsertDataWithCommit(ctx context.Context, prevData, curData []byte) ([]byte, error) {
+	var err error
+	var reachLimit bool
+	for {
+		prevData, reachLimit, err = e.InsertData(ctx, prevData, curData)
+		if err != nil {
+			return nil, err
+		}
+		if !reachLimit {
+			break
+		}
+		// push into commit task queue
+		err = e.EnqOneTask(ctx)
+		if err != nil {
+			return prevData, err
+		}
+		curData = prevData
+		prevData = nil
+	}
+	return prevData, nil
+}
+
 // CheckAndInsertOneBatch is used to commit one transaction batch full filled data
 func (e *LoadDataInfo) CheckAndInsertOneBatch(ctx context.Context, rows [][]types.Datum, cnt uint64) error {
 	if e.stats != nil && e.stats.BasicRuntimeStats != nil {
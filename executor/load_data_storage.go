@@ -0,0 +1,316 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/util/logutil"
+	"github.com/pingcap/tidb/util/sem"
+	"go.uber.org/zap"
+)
+
+// loadDataReadBlockSize is the size of the blocks read from the files of LOAD DATA INFILE.
+var loadDataReadBlockSize = 1 << 20
+
+// loadDataBlock is a block of data read from a file, or the error encountered when reading it.
+type loadDataBlock struct {
+	data []byte
+	err  error
+}
+
+// initExternalStorage opens the external storage of LOAD DATA INFILE and lists the files to load.
+// The last element of the path can be a glob pattern, e.g. 's3://bucket/path/*.csv'.
+func (e *LoadDataInfo) initExternalStorage(ctx context.Context) error {
+	store, pattern, err := e.openInfileStorage(ctx)
+	if err != nil {
+		return err
+	}
+
+	var files []string
+	if !strings.ContainsAny(pattern, "*?[") {
+		exists, err := store.FileExists(ctx, pattern)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if exists {
+			files = append(files, pattern)
+		}
+	} else {
+		// Validate the pattern before walking, path.Match only reports it on the first mismatch.
+		if _, err = path.Match(pattern, ""); err != nil {
+			return errors.Annotatef(err, "Load Data: invalid infile pattern %s", pattern)
+		}
+		err = store.WalkDir(ctx, &storage.WalkOption{}, func(name string, size int64) error {
+			name = strings.TrimPrefix(name, "/")
+			// Only the files right under the directory are matched.
+			if strings.Contains(name, "/") {
+				return nil
+			}
+			if ok, _ := path.Match(pattern, name); ok {
+				files = append(files, name)
+			}
+			return nil
+		})
+		if err != nil {
+			return errors.Trace(err)
+		}
+		sort.Strings(files)
+	}
+	if len(files) == 0 {
+		return errors.Errorf("Load Data: no file matches infile path %s", e.Path)
+	}
+	e.store = store
+	e.files = files
+	return nil
+}
+
+// openInfileStorage opens the directory of the infile path as an external storage, and returns the
+// last element of the path, which is the file name or the glob pattern.
+func (e *LoadDataInfo) openInfileStorage(ctx context.Context) (storage.ExternalStorage, string, error) {
+	// A path without a scheme is a file of the server, even if it contains the characters
+	// which are special in URLs. A relative path is relative to the working directory of the server.
+	if !storage.IsURL(e.Path) {
+		if sem.IsEnabled() {
+			// Storage is not permitted to be local when SEM is enabled.
+			return nil, "", ErrNotSupportedWithSem.GenWithStackByArgs("local storage")
+		}
+		dir, pattern := filepath.Split(e.Path)
+		if pattern == "" {
+			return nil, "", errors.Errorf("Load Data: infile path %s is a directory", e.Path)
+		}
+		dir, err := filepath.Abs(dir)
+		if err != nil {
+			return nil, "", errors.Trace(err)
+		}
+		store, err := openLocalInfileStorage(dir)
+		return store, pattern, err
+	}
+
+	u, err := storage.ParseRawURL(e.Path)
+	if err != nil {
+		return nil, "", errors.Annotate(err, "Load Data: invalid infile path")
+	}
+	dir, pattern := path.Split(u.Path)
+	if pattern == "" {
+		return nil, "", errors.Errorf("Load Data: infile path %s is a directory", e.Path)
+	}
+	u.Path = dir
+	backend, err := storage.ParseBackend(u.String(), nil)
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	if local := backend.GetLocal(); local != nil {
+		if sem.IsEnabled() {
+			// Storage is not permitted to be local when SEM is enabled.
+			return nil, "", ErrNotSupportedWithSem.GenWithStackByArgs("local storage")
+		}
+		store, err := openLocalInfileStorage(local.Path)
+		return store, pattern, err
+	}
+	if backend.GetHdfs() != nil && sem.IsEnabled() {
+		// Storage is not permitted to be hdfs when SEM is enabled.
+		return nil, "", ErrNotSupportedWithSem.GenWithStackByArgs("hdfs storage")
+	}
+	store, err := storage.New(ctx, backend, &storage.ExternalStorageOptions{})
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	return store, pattern, nil
+}
+
+// openLocalInfileStorage opens the local directory of the infile path. Unlike the local storage of
+// BR, the directory must exist.
+func openLocalInfileStorage(dir string) (storage.ExternalStorage, error) {
+	if _, err := os.Stat(dir); err != nil {
+		return nil, errors.Trace(err)
+	}
+	store, err := storage.NewLocalStorage(dir)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return store, nil
+}
+
+// compressTypeOfFile returns the compression type of the file according to its extension.
+func compressTypeOfFile(name string) storage.CompressType {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".gz", ".gzip":
+		return storage.Gzip
+	case ".zst", ".zstd":
+		return storage.Zstd
+	}
+	return storage.NoCompression
+}
+
+// LoadFromStorage reads the files of LOAD DATA INFILE from the external storage and inserts the rows
+// into the table. Like LOAD DATA LOCAL INFILE, the rows are committed in batches.
+func (e *LoadDataInfo) LoadFromStorage(ctx context.Context) error {
+	if e.store == nil {
+		return errors.New("Load Data: external storage is not initialized")
+	}
+	e.InitQueues()
+	e.SetMaxRowsInBatch(uint64(e.Ctx.GetSessionVars().DMLBatchSize))
+	e.StartStopWatcher()
+	// let stop watcher goroutine quit
+	defer e.ForceQuit()
+	if err := e.Ctx.NewTxn(ctx); err != nil {
+		return err
+	}
+	// processFiles reads and parses the files, enqueue commit task
+	wg := new(sync.WaitGroup)
+	wg.Add(1)
+	go e.processFiles(ctx, wg)
+	err := e.CommitWork(ctx)
+	wg.Wait()
+	return err
+}
+
+// processFiles parses the files in order, while they are read by the readers in parallel.
+func (e *LoadDataInfo) processFiles(ctx context.Context, wg *sync.WaitGroup) {
+	var err error
+	done := make(chan struct{})
+	defer func() {
+		close(done)
+		r := recover()
+		if r != nil {
+			logutil.Logger(ctx).Error("process routine panicked",
+				zap.Reflect("r", r),
+				zap.Stack("stack"))
+		}
+		if err != nil || r != nil {
+			e.ForceQuit()
+		} else {
+			e.CloseTaskQueue()
+		}
+		wg.Done()
+	}()
+	ignoreLines := e.IgnoreLines
+	for i, blocks := range e.readFiles(ctx, done) {
+		var prevData []byte
+		// The lines are ignored at the beginning of every file.
+		e.IgnoreLines = ignoreLines
+		for block := range blocks {
+			if block.err != nil {
+				err = errors.Annotatef(block.err, "Load Data: read file %s", e.files[i])
+				break
+			}
+			select {
+			case <-e.QuitCh:
+				err = errors.New("processFiles forced to quit")
+			default:
+			}
+			if err != nil {
+				break
+			}
+			// prepare batch and enqueue task
+			prevData, err = e.InsertDataWithCommit(ctx, prevData, block.data)
+			if err != nil {
+				break
+			}
+		}
+		if err != nil {
+			break
+		}
+		// The last line of the file may not end with the terminator.
+		for len(prevData) > 0 && err == nil {
+			prevData, err = e.InsertDataWithCommit(ctx, prevData, nil)
+		}
+		if err != nil {
+			break
+		}
+	}
+	if err != nil {
+		logutil.Logger(ctx).Error("load data process files error", zap.Error(err))
+		return
+	}
+	if err = e.EnqOneTask(ctx); err != nil {
+		logutil.Logger(ctx).Error("load data process files error", zap.Error(err))
+	}
+}
+
+// readFiles starts the readers of the files, at most tidb_executor_concurrency files are read at the same time.
+// The blocks of each file are sent to its own channel, which is closed when the file is read up or done is closed.
+func (e *LoadDataInfo) readFiles(ctx context.Context, done <-chan struct{}) []chan loadDataBlock {
+	concurrency := e.Ctx.GetSessionVars().ExecutorConcurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	blockChs := make([]chan loadDataBlock, len(e.files))
+	for i := range blockChs {
+		blockChs[i] = make(chan loadDataBlock, 2)
+	}
+	tokens := make(chan struct{}, concurrency)
+	go func() {
+		// The tokens are acquired in the order of files, so the file being parsed is always being read.
+		for i, name := range e.files {
+			select {
+			case tokens <- struct{}{}:
+			case <-done:
+				return
+			}
+			go func(name string, blockCh chan<- loadDataBlock) {
+				defer func() { <-tokens }()
+				e.readFile(ctx, name, blockCh, done)
+			}(name, blockChs[i])
+		}
+	}()
+	return blockChs
+}
+
+func (e *LoadDataInfo) readFile(ctx context.Context, name string, blockCh chan<- loadDataBlock, done <-chan struct{}) {
+	defer close(blockCh)
+	send := func(block loadDataBlock) bool {
+		select {
+		case blockCh <- block:
+			return true
+		case <-done:
+			return false
+		}
+	}
+	reader, err := storage.WithCompression(e.store, compressTypeOfFile(name)).Open(ctx, name)
+	if err != nil {
+		send(loadDataBlock{err: err})
+		return
+	}
+	defer func() {
+		if err := reader.Close(); err != nil {
+			logutil.Logger(ctx).Warn("load data close file failed", zap.String("file", name), zap.Error(err))
+		}
+	}()
+	for {
+		data := make([]byte, loadDataReadBlockSize)
+		n, err := io.ReadFull(reader, data)
+		if n > 0 && !send(loadDataBlock{data: data[:n]}) {
+			return
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return
+		}
+		if err != nil {
+			send(loadDataBlock{err: err})
+			return
+		}
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package parquetwriter encodes the rows of SELECT ... INTO OUTFILE into parquet files. It's kept out of
+// the executor package because parquet-go starts the goroutines of its zstd decoder when it's initialized.
+package parquetwriter
+
+import (
+	"io"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/executor"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+// NewWriter builds a parquet writer which writes into w, it implements executor.ParquetWriterBuilder.
+func NewWriter(w io.Writer, schema []string, codec string, rowGroupSize int64) (executor.ParquetWriter, error) {
+	var compressionCodec parquet.CompressionCodec
+	switch codec {
+	case "none":
+		compressionCodec = parquet.CompressionCodec_UNCOMPRESSED
+	case "snappy":
+		compressionCodec = parquet.CompressionCodec_SNAPPY
+	case "gzip":
+		compressionCodec = parquet.CompressionCodec_GZIP
+	case "zstd":
+		compressionCodec = parquet.CompressionCodec_ZSTD
+	default:
+		return nil, errors.Errorf("unknown parquet compression codec %s", codec)
+	}
+	pw, err := writer.NewCSVWriterFromWriter(schema, w, 1)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	pw.CompressionType = compressionCodec
+	pw.RowGroupSize = rowGroupSize
+	return &csvWriter{pw}, nil
+}
+
+// csvWriter writes the rows whose values are in the order of the columns of the schema.
+type csvWriter struct {
+	*writer.CSVWriter
+}
+
+// Write implements the executor.ParquetWriter interface.
+func (w *csvWriter) Write(row []interface{}) error {
+	return w.CSVWriter.Write(row)
+}
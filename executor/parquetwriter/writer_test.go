@@ -0,0 +1,93 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetwriter_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/lightning/mydump"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func readParquet(t *testing.T, dir, name string) ([]string, []string) {
+	ctx := context.Background()
+	store, err := storage.NewLocalStorage(dir)
+	require.NoError(t, err)
+	r, err := store.Open(ctx, name)
+	require.NoError(t, err)
+	parser, err := mydump.NewParquetParser(ctx, store, r, name)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, parser.Close())
+	}()
+	var rows []string
+	for {
+		err = parser.ReadRow()
+		if errors.Cause(err) == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		var row []string
+		for _, d := range parser.LastRow().Row {
+			if d.IsNull() {
+				row = append(row, "<nil>")
+				continue
+			}
+			s, err := d.ToString()
+			require.NoError(t, err)
+			row = append(row, s)
+		}
+		rows = append(rows, strings.Join(row, " "))
+	}
+	return parser.Columns(), rows
+}
+
+func TestSelectIntoParquet(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b varchar(10), c double, d decimal(5, 2), e bigint unsigned)")
+	tk.MustExec("insert into t values (1, 'aa', 1.5, 1.25, 1), (2, 'bb', null, 2.50, 18446744073709551615), (3, null, 3.5, null, null)")
+	dir := t.TempDir()
+
+	tk.MustExec(fmt.Sprintf("select a, b, c, d, e, a as a from t order by a into outfile 'local://%s/result.parquet' format = 'parquet'", filepath.ToSlash(dir)))
+	require.Equal(t, uint64(3), tk.Session().GetSessionVars().StmtCtx.AffectedRows())
+	columns, rows := readParquet(t, dir, "result.parquet")
+	require.Equal(t, []string{"a", "b", "c", "d", "e", "a_2"}, columns)
+	require.Equal(t, []string{
+		"1 aa 1.5 1.25 1 1",
+		"2 bb <nil> 2.50 18446744073709551615 2",
+		"3 <nil> 3.5 <nil> <nil> 3",
+	}, rows)
+
+	// The pages are compressed by the codec, and the result is split by MAX_FILE_SIZE.
+	tk.MustExec(fmt.Sprintf("select a, b from t order by a into outfile '%s' format = 'parquet' compression = 'zstd' max_file_size = 1", filepath.Join(dir, "split.parquet")))
+	for i, expected := range []string{"1 aa", "2 bb", "3 <nil>"} {
+		_, rows = readParquet(t, dir, fmt.Sprintf("split.%06d.parquet", i+1))
+		require.Equal(t, []string{expected}, rows)
+	}
+
+	err := tk.ExecToErr(fmt.Sprintf("select a from t into outfile '%s' format = 'parquet' compression = 'lz4'", filepath.Join(dir, "lz4.parquet")))
+	require.EqualError(t, err, "[executor:1525]Incorrect COMPRESSION value: 'lz4'")
+}
@@ -48,6 +48,10 @@ func (b *executorBuilder) buildPointGet(p *plannercore.PointGetPlan) Executor {
 		b.err = err
 		return nil
 	}
+	if err := checkHypoIndex(p.IndexInfo); err != nil {
+		b.err = err
+		return nil
+	}
 
 	startTS, err := b.getSnapshotTS()
 	if err != nil {
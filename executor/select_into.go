@@ -15,11 +15,9 @@
 package executor
 
 import (
-	"bufio"
 	"bytes"
 	"context"
 	"math"
-	"os"
 	"strconv"
 
 	"github.com/pingcap/errors"
@@ -34,13 +32,15 @@ type SelectIntoExec struct {
 	baseExecutor
 	intoOpt *ast.SelectIntoOption
 
+	// outputNames are the names of the columns of the parquet files.
+	outputNames types.NameSlice
+
 	lineBuf   []byte
 	realBuf   []byte
 	fieldBuf  []byte
 	escapeBuf []byte
 	enclosed  bool
-	writer    *bufio.Writer
-	dstFile   *os.File
+	outfile   *selectIntoOutfile
 	chk       *chunk.Chunk
 	started   bool
 }
@@ -52,14 +52,19 @@ func (s *SelectIntoExec) Open(ctx context.Context) error {
 		return errors.New("unsupported SelectInto type")
 	}
 
-	// MySQL-compatible behavior: allow files to be group-readable
-	f, err := os.OpenFile(s.intoOpt.FileName, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0640) // # nosec G302
+	outfile, err := newSelectIntoOutfile(ctx, s.intoOpt)
 	if err != nil {
-		return errors.Trace(err)
+		return err
+	}
+	if outfile.format == selectIntoFormatParquet {
+		outfile.parquetSchema = s.parquetSchema()
+	}
+	// The first file is created even if the result is empty.
+	if err = outfile.createFile(ctx); err != nil {
+		return err
 	}
 	s.started = true
-	s.dstFile = f
-	s.writer = bufio.NewWriter(s.dstFile)
+	s.outfile = outfile
 	s.chk = newFirstChunk(s.children[0])
 	s.lineBuf = make([]byte, 0, 1024)
 	s.fieldBuf = make([]byte, 0, 64)
@@ -76,7 +81,13 @@ func (s *SelectIntoExec) Next(ctx context.Context, req *chunk.Chunk) error {
 		if s.chk.NumRows() == 0 {
 			break
 		}
-		if err := s.dumpToOutfile(); err != nil {
+		var err error
+		if s.outfile.format == selectIntoFormatParquet {
+			err = s.dumpToParquet(ctx)
+		} else {
+			err = s.dumpToOutfile(ctx)
+		}
+		if err != nil {
 			return err
 		}
 	}
@@ -118,7 +129,7 @@ func (s *SelectIntoExec) escapeField(f []byte) []byte {
 	return s.escapeBuf
 }
 
-func (s *SelectIntoExec) dumpToOutfile() error {
+func (s *SelectIntoExec) dumpToOutfile(ctx context.Context) error {
 	lineTerm := "\n"
 	if s.intoOpt.LinesInfo.Terminated != "" {
 		lineTerm = s.intoOpt.LinesInfo.Terminated
@@ -208,8 +219,8 @@ func (s *SelectIntoExec) dumpToOutfile() error {
 			}
 		}
 		s.lineBuf = append(s.lineBuf, lineTerm...)
-		if _, err := s.writer.Write(s.lineBuf); err != nil {
-			return errors.Trace(err)
+		if err := s.outfile.writeLine(ctx, s.lineBuf); err != nil {
+			return err
 		}
 	}
 	s.ctx.GetSessionVars().StmtCtx.AddAffectedRows(uint64(s.chk.NumRows()))
@@ -221,15 +232,12 @@ func (s *SelectIntoExec) Close() error {
 	if !s.started {
 		return nil
 	}
-	err1 := s.writer.Flush()
-	err2 := s.dstFile.Close()
-	err3 := s.baseExecutor.Close()
+	err1 := s.outfile.closeFile(context.Background())
+	err2 := s.baseExecutor.Close()
 	if err1 != nil {
-		return errors.Trace(err1)
-	} else if err2 != nil {
-		return errors.Trace(err2)
+		return err1
 	}
-	return err3
+	return err2
 }
 
 const (
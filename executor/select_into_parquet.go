@@ -0,0 +1,175 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/pingcap/tidb/parser/charset"
+	"github.com/pingcap/tidb/parser/mysql"
+)
+
+// ParquetWriter encodes the rows of SELECT ... INTO OUTFILE into a parquet file.
+type ParquetWriter interface {
+	// Write writes a row. The values are int64, float32, float64, string, or nil for NULL.
+	Write(row []interface{}) error
+	// WriteStop flushes the buffered rows and writes the footer of the file.
+	WriteStop() error
+}
+
+// ParquetWriterBuilder builds a ParquetWriter which writes into w. The schema is the metadata of the
+// columns, codec is the compression codec of the pages, which is one of "none", "snappy", "gzip" and
+// "zstd", and rowGroupSize is the max size of the row groups.
+type ParquetWriterBuilder func(w io.Writer, schema []string, codec string, rowGroupSize int64) (ParquetWriter, error)
+
+var parquetWriterBuilder ParquetWriterBuilder
+
+// RegisterParquetWriterBuilder registers the builder of the parquet writers. The parquet encoder lives
+// in a separate package, so only the binaries which write parquet files depend on it.
+func RegisterParquetWriterBuilder(builder ParquetWriterBuilder) {
+	parquetWriterBuilder = builder
+}
+
+// selectIntoParquetRowGroupSize is the max size of the row groups of the parquet files.
+// The rows of a row group are buffered in memory until the row group is written.
+const selectIntoParquetRowGroupSize = 64 * 1024 * 1024
+
+// parquetSchema returns the metadata of the columns of the parquet files. All the columns are optional,
+// the numbers are written as the parquet numeric types and the others are written as strings.
+func (s *SelectIntoExec) parquetSchema() []string {
+	cols := s.children[0].Schema().Columns
+	schema := make([]string, 0, len(cols))
+	used := make(map[string]struct{}, len(cols))
+	for i, col := range cols {
+		base := ""
+		if i < len(s.outputNames) {
+			base = parquetColumnName(s.outputNames[i].ColName.O)
+		}
+		if base == "" {
+			base = "col_" + strconv.Itoa(i+1)
+		}
+		// The parquet columns must have unique names.
+		name := base
+		for j := 2; ; j++ {
+			if _, ok := used[name]; !ok {
+				break
+			}
+			name = base + "_" + strconv.Itoa(j)
+		}
+		used[name] = struct{}{}
+		tp := col.GetType()
+		var parquetType string
+		switch tp.Tp {
+		case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong, mysql.TypeYear:
+			parquetType = "INT64"
+		case mysql.TypeLonglong:
+			if mysql.HasUnsignedFlag(tp.Flag) {
+				parquetType = "UINT_64"
+			} else {
+				parquetType = "INT64"
+			}
+		case mysql.TypeFloat:
+			parquetType = "FLOAT"
+		case mysql.TypeDouble:
+			parquetType = "DOUBLE"
+		case mysql.TypeBit:
+			parquetType = "BYTE_ARRAY"
+		case mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar,
+			mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
+			if tp.Charset == charset.CharsetBin {
+				parquetType = "BYTE_ARRAY"
+			} else {
+				parquetType = "UTF8"
+			}
+		default:
+			parquetType = "UTF8"
+		}
+		schema = append(schema, fmt.Sprintf("name=%s, type=%s", name, parquetType))
+	}
+	return schema
+}
+
+// parquetColumnName replaces the characters which can't be used in the parquet metadata.
+func parquetColumnName(name string) string {
+	b := []byte(name)
+	for i, c := range b {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_') {
+			b[i] = '_'
+		}
+	}
+	return string(b)
+}
+
+func (s *SelectIntoExec) dumpToParquet(ctx context.Context) error {
+	cols := s.children[0].Schema().Columns
+	for i := 0; i < s.chk.NumRows(); i++ {
+		row := s.chk.GetRow(i)
+		// The rows are buffered by the parquet writer, so each row has its own values.
+		values := make([]interface{}, len(cols))
+		size := 0
+		for j, col := range cols {
+			if row.IsNull(j) {
+				continue
+			}
+			tp := col.GetType()
+			var v interface{}
+			switch tp.Tp {
+			case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong, mysql.TypeYear, mysql.TypeLonglong:
+				// The unsigned BIGINT is stored as INT64 annotated with UINT_64.
+				v = row.GetInt64(j)
+				size += 8
+			case mysql.TypeFloat:
+				v = row.GetFloat32(j)
+				size += 4
+			case mysql.TypeDouble:
+				v = row.GetFloat64(j)
+				size += 8
+			default:
+				// The strings are copied out of the chunk, which is reused by the next batch.
+				var str string
+				switch tp.Tp {
+				case mysql.TypeNewDecimal:
+					str = row.GetMyDecimal(j).String()
+				case mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar,
+					mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob, mysql.TypeBit:
+					str = string(row.GetBytes(j))
+				case mysql.TypeDate, mysql.TypeDatetime, mysql.TypeTimestamp:
+					str = row.GetTime(j).String()
+				case mysql.TypeDuration:
+					str = row.GetDuration(j, tp.Decimal).String()
+				case mysql.TypeEnum:
+					str = row.GetEnum(j).String()
+				case mysql.TypeSet:
+					str = row.GetSet(j).String()
+				case mysql.TypeJSON:
+					str = row.GetJSON(j).String()
+				default:
+					str = row.GetDatum(j, tp).String()
+				}
+				v = str
+				size += len(str)
+			}
+			values[j] = v
+		}
+		if err := s.outfile.writeParquetRow(ctx, values, size); err != nil {
+			return err
+		}
+	}
+	s.ctx.GetSessionVars().StmtCtx.AddAffectedRows(uint64(s.chk.NumRows()))
+	return nil
+}
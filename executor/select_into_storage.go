@@ -0,0 +1,297 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/util/sem"
+)
+
+const (
+	selectIntoFormatCSV     = "csv"
+	selectIntoFormatParquet = "parquet"
+)
+
+// selectIntoCompressChunkSize is the size of the chunks compressed and written into the local output files.
+const selectIntoCompressChunkSize = 5 * 1024 * 1024
+
+// selectIntoOutfile writes the result of SELECT ... INTO OUTFILE into a local file or the files of an
+// external storage. When MAX_FILE_SIZE is specified, the result is split into files named with sequence
+// numbers, e.g. 'result.000001.csv', and a new file is started whenever the current one would exceed
+// the size. The size is measured on the uncompressed data, and a row is never split across files.
+type selectIntoOutfile struct {
+	// store is nil if the output files are local files.
+	store storage.ExternalStorage
+	// dir is the directory of the output files in the local file system, or in store.
+	dir  string
+	name string
+
+	format       string
+	compressType storage.CompressType
+	// parquetCodec is the compression codec of the pages of the parquet files.
+	parquetCodec string
+	// parquetSchema is the metadata of the columns of the parquet files.
+	parquetSchema []string
+	maxFileSize   uint64
+
+	seq    int
+	size   uint64
+	writer storage.ExternalFileWriter
+	// parquetWriter encodes the rows into writer if the format is parquet.
+	parquetWriter ParquetWriter
+}
+
+func newSelectIntoOutfile(ctx context.Context, opt *ast.SelectIntoOption) (*selectIntoOutfile, error) {
+	o := &selectIntoOutfile{format: selectIntoFormatCSV}
+	var compression string
+	for _, fileOpt := range opt.FileOptions {
+		switch fileOpt.Tp {
+		case ast.SelectIntoFileFormat:
+			o.format = fileOpt.StrValue
+		case ast.SelectIntoFileCompression:
+			compression = fileOpt.StrValue
+		case ast.SelectIntoFileMaxSize:
+			o.maxFileSize = fileOpt.UintValue
+		}
+	}
+	switch o.format {
+	case selectIntoFormatCSV:
+		switch compression {
+		case "", "none":
+			o.compressType = storage.NoCompression
+		case "gzip":
+			o.compressType = storage.Gzip
+		case "zstd":
+			o.compressType = storage.Zstd
+		default:
+			return nil, ErrWrongValue.GenWithStackByArgs("COMPRESSION", compression)
+		}
+	case selectIntoFormatParquet:
+		if parquetWriterBuilder == nil {
+			return nil, ErrParquetWriterNotRegistered.GenWithStackByArgs()
+		}
+		// The parquet files are compressed by pages, rather than as a whole.
+		switch compression {
+		case "":
+			o.parquetCodec = "snappy"
+		case "none", "snappy", "gzip", "zstd":
+			o.parquetCodec = compression
+		default:
+			return nil, ErrWrongValue.GenWithStackByArgs("COMPRESSION", compression)
+		}
+	default:
+		return nil, ErrWrongValue.GenWithStackByArgs("FORMAT", o.format)
+	}
+
+	// A path without a scheme is a file of the server, even if it contains the characters
+	// which are special in URLs.
+	if !storage.IsURL(opt.FileName) {
+		if sem.IsEnabled() {
+			return nil, ErrNotSupportedWithSem.GenWithStackByArgs("SELECT INTO")
+		}
+		o.dir, o.name = path.Split(opt.FileName)
+		return o, nil
+	}
+	u, err := storage.ParseRawURL(opt.FileName)
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid outfile path")
+	}
+	dir, name := path.Split(u.Path)
+	if name == "" {
+		return nil, errors.Errorf("outfile path %s is a directory", opt.FileName)
+	}
+	u.Path = dir
+	backend, err := storage.ParseBackend(u.String(), nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	o.name = name
+	if local := backend.GetLocal(); local != nil {
+		if sem.IsEnabled() {
+			// Storage is not permitted to be local when SEM is enabled.
+			return nil, ErrNotSupportedWithSem.GenWithStackByArgs("local storage")
+		}
+		o.dir = local.Path
+		return o, nil
+	}
+	if backend.GetHdfs() != nil && sem.IsEnabled() {
+		// Storage is not permitted to be hdfs when SEM is enabled.
+		return nil, ErrNotSupportedWithSem.GenWithStackByArgs("hdfs storage")
+	}
+	o.store, err = storage.New(ctx, backend, &storage.ExternalStorageOptions{})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// The files of the external storages are compressed according to their extensions by default.
+	if o.format == selectIntoFormatCSV && compression == "" {
+		o.compressType = compressTypeOfFile(name)
+	}
+	return o, nil
+}
+
+// fileName returns the name of the current output file. The sequence number is inserted
+// before the extensions of the file name when the result is split into several files.
+func (o *selectIntoOutfile) fileName() string {
+	if o.maxFileSize == 0 {
+		return o.name
+	}
+	base, ext := o.name, ""
+	if idx := strings.IndexByte(o.name, '.'); idx > 0 {
+		base, ext = o.name[:idx], o.name[idx:]
+	}
+	return fmt.Sprintf("%s.%06d%s", base, o.seq, ext)
+}
+
+// createFile closes the current output file and creates the next one.
+func (o *selectIntoOutfile) createFile(ctx context.Context) error {
+	if err := o.closeFile(ctx); err != nil {
+		return err
+	}
+	o.seq++
+	o.size = 0
+	name := o.fileName()
+	var err error
+	if o.store == nil {
+		o.writer, err = createLocalOutfile(path.Join(o.dir, name))
+		if err == nil && o.compressType != storage.NoCompression {
+			o.writer = storage.NewUploaderWriter(o.writer, selectIntoCompressChunkSize, o.compressType)
+		}
+	} else {
+		var exists bool
+		exists, err = o.store.FileExists(ctx, name)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		// Like the local files, the existing files are never overwritten.
+		if exists {
+			return ErrFileExists.GenWithStackByArgs(o.store.URI() + "/" + name)
+		}
+		o.writer, err = storage.WithCompression(o.store, o.compressType).Create(ctx, name)
+	}
+	if err != nil {
+		return err
+	}
+	if o.format == selectIntoFormatParquet {
+		rowGroupSize := int64(selectIntoParquetRowGroupSize)
+		if o.maxFileSize > 0 && int64(o.maxFileSize) < rowGroupSize {
+			rowGroupSize = int64(o.maxFileSize)
+		}
+		o.parquetWriter, err = parquetWriterBuilder(&ctxFileWriter{ctx: ctx, w: o.writer}, o.parquetSchema, o.parquetCodec, rowGroupSize)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// prepareWrite starts a new output file if writing size bytes would exceed MAX_FILE_SIZE.
+func (o *selectIntoOutfile) prepareWrite(ctx context.Context, size int) error {
+	if o.maxFileSize > 0 && o.size > 0 && o.size+uint64(size) > o.maxFileSize {
+		if err := o.createFile(ctx); err != nil {
+			return err
+		}
+	}
+	o.size += uint64(size)
+	return nil
+}
+
+// writeLine writes a line of the delimited text.
+func (o *selectIntoOutfile) writeLine(ctx context.Context, line []byte) error {
+	if err := o.prepareWrite(ctx, len(line)); err != nil {
+		return err
+	}
+	_, err := o.writer.Write(ctx, line)
+	return errors.Trace(err)
+}
+
+// writeParquetRow writes a row into the parquet file, size is the estimated size of the row.
+func (o *selectIntoOutfile) writeParquetRow(ctx context.Context, row []interface{}, size int) error {
+	if err := o.prepareWrite(ctx, size); err != nil {
+		return err
+	}
+	return errors.Trace(o.parquetWriter.Write(row))
+}
+
+func (o *selectIntoOutfile) closeFile(ctx context.Context) error {
+	if o.writer == nil {
+		return nil
+	}
+	w := o.writer
+	o.writer = nil
+	if o.parquetWriter != nil {
+		pw := o.parquetWriter
+		o.parquetWriter = nil
+		// WriteStop flushes the buffered rows and writes the footer.
+		if err := pw.WriteStop(); err != nil {
+			// Close the file anyway, the error of WriteStop is more relevant.
+			_ = w.Close(ctx)
+			return errors.Trace(err)
+		}
+	}
+	return errors.Trace(w.Close(ctx))
+}
+
+// localOutfileWriter writes a local output file.
+type localOutfileWriter struct {
+	file *os.File
+	buf  *bufio.Writer
+}
+
+func createLocalOutfile(name string) (*localOutfileWriter, error) {
+	// MySQL-compatible behavior: allow files to be group-readable
+	f, err := os.OpenFile(name, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0640) // # nosec G302
+	if err != nil {
+		if os.IsExist(err) {
+			return nil, ErrFileExists.GenWithStackByArgs(name)
+		}
+		return nil, errors.Trace(err)
+	}
+	return &localOutfileWriter{file: f, buf: bufio.NewWriter(f)}, nil
+}
+
+// Write implements the storage.ExternalFileWriter interface.
+func (w *localOutfileWriter) Write(_ context.Context, p []byte) (int, error) {
+	return w.buf.Write(p)
+}
+
+// Close implements the storage.ExternalFileWriter interface.
+func (w *localOutfileWriter) Close(_ context.Context) error {
+	err1 := w.buf.Flush()
+	err2 := w.file.Close()
+	if err1 != nil {
+		return errors.Trace(err1)
+	}
+	return errors.Trace(err2)
+}
+
+// ctxFileWriter adapts a storage.ExternalFileWriter to io.Writer.
+type ctxFileWriter struct {
+	ctx context.Context
+	w   storage.ExternalFileWriter
+}
+
+// Write implements the io.Writer interface.
+func (w *ctxFileWriter) Write(p []byte) (int, error) {
+	return w.w.Write(w.ctx, p)
+}
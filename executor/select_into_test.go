@@ -15,17 +15,23 @@
 package executor_test
 
 import (
+	"bytes"
+	"compress/gzip"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/klauspost/compress/zstd"
 	"github.com/pingcap/tidb/executor"
 	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/parser/terror"
 	"github.com/pingcap/tidb/testkit"
 	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/sem"
 	"github.com/stretchr/testify/require"
 )
 
@@ -297,3 +303,76 @@ func TestYearType(t *testing.T) {
 	tk.MustExec(fmt.Sprintf("select * from t into outfile '%v' fields terminated by ',' optionally enclosed by '\"' lines terminated by '\\n';", outfile))
 	cmpAndRm("2010\n2011\n2012\n2030\n", outfile, t)
 }
+
+func TestSelectIntoOutfileStorage(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b varchar(10), c double, d decimal(5, 2), e bigint unsigned)")
+	tk.MustExec("insert into t values (1, 'aa', 1.5, 1.25, 1), (2, 'bb', null, 2.50, 18446744073709551615), (3, null, 3.5, null, null)")
+	dir := t.TempDir()
+
+	// The result is split into files of at most MAX_FILE_SIZE bytes, which never split a row.
+	tk.MustExec(fmt.Sprintf("select a, b from t order by a into outfile 'local://%s/split.csv' fields terminated by ',' max_file_size = 10", filepath.ToSlash(dir)))
+	require.Equal(t, uint64(3), tk.Session().GetSessionVars().StmtCtx.AffectedRows())
+	cmpAndRm("1,aa\n2,bb\n", filepath.Join(dir, "split.000001.csv"), t)
+	cmpAndRm("3,\\N\n", filepath.Join(dir, "split.000002.csv"), t)
+
+	// The local files are compressed only if the compression is specified.
+	outfile := filepath.Join(dir, "result.csv.gz")
+	tk.MustExec(fmt.Sprintf("select a from t order by a into outfile %q", outfile))
+	cmpAndRm("1\n2\n3\n", outfile, t)
+	tk.MustExec(fmt.Sprintf("select a from t order by a into outfile %q compression = 'gzip'", outfile))
+	content, err := os.ReadFile(outfile)
+	require.NoError(t, err)
+	gr, err := gzip.NewReader(bytes.NewReader(content))
+	require.NoError(t, err)
+	content, err = io.ReadAll(gr)
+	require.NoError(t, err)
+	require.Equal(t, "1\n2\n3\n", string(content))
+	err = tk.ExecToErr(fmt.Sprintf("select a from t into outfile %q", outfile))
+	require.True(t, terror.ErrorEqual(err, executor.ErrFileExists))
+
+	tk.MustExec(fmt.Sprintf("select a from t order by a into outfile 'local://%s/result.zst' compression = 'zstd'", filepath.ToSlash(dir)))
+	content, err = os.ReadFile(filepath.Join(dir, "result.zst"))
+	require.NoError(t, err)
+	zr, err := zstd.NewReader(nil)
+	require.NoError(t, err)
+	defer zr.Close()
+	content, err = zr.DecodeAll(content, nil)
+	require.NoError(t, err)
+	require.Equal(t, "1\n2\n3\n", string(content))
+
+	// A path without a scheme is a local file, even if it contains the characters which are special in URLs.
+	outfile = filepath.Join(dir, "a:b%c#d?e.csv")
+	tk.MustExec(fmt.Sprintf("select a from t order by a into outfile %q", outfile))
+	cmpAndRm("1\n2\n3\n", outfile, t)
+	// A relative path is relative to the working directory of the server.
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "out"), 0o755))
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	defer func() {
+		require.NoError(t, os.Chdir(wd))
+	}()
+	tk.MustExec("select a from t order by a into outfile 'out/x.csv'")
+	cmpAndRm("1\n2\n3\n", filepath.Join(dir, "out", "x.csv"), t)
+
+	// The parquet writer is registered by tidb-server.
+	err = tk.ExecToErr(fmt.Sprintf("select a from t into outfile '%s' format = 'parquet'", filepath.Join(dir, "result.parquet")))
+	require.True(t, terror.ErrorEqual(err, executor.ErrParquetWriterNotRegistered))
+
+	err = tk.ExecToErr(fmt.Sprintf("select a from t into outfile '%s' format = 'json'", filepath.Join(dir, "result.json")))
+	require.True(t, terror.ErrorEqual(err, executor.ErrWrongValue))
+	err = tk.ExecToErr(fmt.Sprintf("select a from t into outfile '%s' compression = 'snappy'", filepath.Join(dir, "result.txt")))
+	require.True(t, terror.ErrorEqual(err, executor.ErrWrongValue))
+
+	// Only the cloud storages are permitted when SEM is enabled.
+	sem.Enable()
+	defer sem.Disable()
+	err = tk.ExecToErr(fmt.Sprintf("select a from t into outfile 'local://%s/sem.csv'", filepath.ToSlash(dir)))
+	require.True(t, terror.ErrorEqual(err, executor.ErrNotSupportedWithSem))
+	err = tk.ExecToErr(fmt.Sprintf("select a from t into outfile '%s'", filepath.Join(dir, "sem.csv")))
+	require.True(t, terror.ErrorEqual(err, executor.ErrNotSupportedWithSem))
+}
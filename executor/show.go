@@ -183,7 +183,7 @@ func (e *ShowExec) fetchAll(ctx context.Context) error {
 	case ast.ShowProcessList:
 		return e.fetchShowProcessList()
 	case ast.ShowEvents:
-		// empty result
+		return e.fetchShowEvents(ctx)
 	case ast.ShowStatsExtended:
 		return e.fetchShowStatsExtended()
 	case ast.ShowStatsMeta:
@@ -690,23 +690,33 @@ func (e *ShowExec) fetchShowIndex() error {
 				expression = tblCol.GeneratedExprString
 			}
 
+			var collation interface{} = "A"
+			indexType := idx.Meta().Tp.String()
+			if idx.Meta().FullText {
+				collation = nil
+				indexType = "FULLTEXT"
+			} else if idx.Meta().Spatial {
+				collation = nil
+				indexType = "SPATIAL"
+			}
+
 			e.appendRow([]interface{}{
-				tb.Meta().Name.O,       // Table
-				nonUniq,                // Non_unique
-				idx.Meta().Name.O,      // Key_name
-				i + 1,                  // Seq_in_index
-				colName,                // Column_name
-				"A",                    // Collation
-				0,                      // Cardinality
-				subPart,                // Sub_part
-				nil,                    // Packed
-				nullVal,                // Null
-				idx.Meta().Tp.String(), // Index_type
-				"",                     // Comment
-				idx.Meta().Comment,     // Index_comment
-				visible,                // Index_visible
-				expression,             // Expression
-				isClustered,            // Clustered
+				tb.Meta().Name.O,   // Table
+				nonUniq,            // Non_unique
+				idx.Meta().Name.O,  // Key_name
+				i + 1,              // Seq_in_index
+				colName,            // Column_name
+				collation,          // Collation
+				0,                  // Cardinality
+				subPart,            // Sub_part
+				nil,                // Packed
+				nullVal,            // Null
+				indexType,          // Index_type
+				"",                 // Comment
+				idx.Meta().Comment, // Index_comment
+				visible,            // Index_visible
+				expression,         // Expression
+				isClustered,        // Clustered
 			})
 		}
 	}
@@ -941,6 +951,9 @@ func ConstructResultOfShowCreateTable(ctx sessionctx.Context, tableInfo *model.T
 		if ddl.IsAutoRandomColumnID(tableInfo, col.ID) {
 			buf.WriteString(fmt.Sprintf(" /*T![auto_rand] AUTO_RANDOM(%d) */", tableInfo.AutoRandomBits))
 		}
+		if col.Srid != nil {
+			fmt.Fprintf(buf, " /*!80003 SRID %d */", *col.Srid)
+		}
 		if len(col.Comment) > 0 {
 			buf.WriteString(fmt.Sprintf(" COMMENT '%s'", format.OutputFormat(col.Comment)))
 		}
@@ -974,6 +987,10 @@ func ConstructResultOfShowCreateTable(ctx sessionctx.Context, tableInfo *model.T
 			buf.WriteString("  PRIMARY KEY ")
 		} else if idxInfo.Unique {
 			fmt.Fprintf(buf, "  UNIQUE KEY %s ", stringutil.Escape(idxInfo.Name.O, sqlMode))
+		} else if idxInfo.FullText {
+			fmt.Fprintf(buf, "  FULLTEXT KEY %s ", stringutil.Escape(idxInfo.Name.O, sqlMode))
+		} else if idxInfo.Spatial {
+			fmt.Fprintf(buf, "  SPATIAL KEY %s ", stringutil.Escape(idxInfo.Name.O, sqlMode))
 		} else {
 			fmt.Fprintf(buf, "  KEY %s ", stringutil.Escape(idxInfo.Name.O, sqlMode))
 		}
@@ -992,6 +1009,9 @@ func ConstructResultOfShowCreateTable(ctx sessionctx.Context, tableInfo *model.T
 			cols = append(cols, colInfo)
 		}
 		fmt.Fprintf(buf, "(%s)", strings.Join(cols, ","))
+		if idxInfo.FullTextParser != "" {
+			fmt.Fprintf(buf, ` /*!50100 WITH PARSER %s */`, stringutil.Escape(idxInfo.FullTextParser, sqlMode))
+		}
 		if idxInfo.Invisible {
 			fmt.Fprintf(buf, ` /*!80000 INVISIBLE */`)
 		}
@@ -1033,6 +1053,23 @@ func ConstructResultOfShowCreateTable(ctx sessionctx.Context, tableInfo *model.T
 		}
 	}
 
+	// Check constraints are shown in the order of their names, the same as MySQL.
+	constraints := make([]*model.ConstraintInfo, 0, len(tableInfo.Constraints))
+	for _, constraintInfo := range tableInfo.Constraints {
+		if constraintInfo.State == model.StatePublic {
+			constraints = append(constraints, constraintInfo)
+		}
+	}
+	sort.Slice(constraints, func(i, j int) bool {
+		return constraints[i].Name.L < constraints[j].Name.L
+	})
+	for _, constraintInfo := range constraints {
+		fmt.Fprintf(buf, ",\n  CONSTRAINT %s CHECK ((%s))", stringutil.Escape(constraintInfo.Name.O, sqlMode), constraintInfo.ExprString)
+		if !constraintInfo.Enforced {
+			buf.WriteString(" /*!80016 NOT ENFORCED */")
+		}
+	}
+
 	buf.WriteString("\n")
 
 	buf.WriteString(") ENGINE=InnoDB")
@@ -1241,15 +1278,33 @@ func fetchShowCreateTable4View(ctx sessionctx.Context, tb *model.TableInfo, buf
 	fmt.Fprintf(buf, ") AS %s", tb.View.SelectStmt)
 }
 
+// partitionColumnsString returns the escaped column names of `PARTITION BY KEY`, separated by commas.
+func partitionColumnsString(partitionInfo *model.PartitionInfo, sqlMode mysql.SQLMode) string {
+	cols := make([]string, 0, len(partitionInfo.Columns))
+	for _, col := range partitionInfo.Columns {
+		cols = append(cols, stringutil.Escape(col.O, sqlMode))
+	}
+	return strings.Join(cols, ",")
+}
+
+func subPartitionColumnsString(partitionInfo *model.PartitionInfo, sqlMode mysql.SQLMode) string {
+	cols := make([]string, 0, len(partitionInfo.SubColumns))
+	for _, col := range partitionInfo.SubColumns {
+		cols = append(cols, stringutil.Escape(col.O, sqlMode))
+	}
+	return strings.Join(cols, ",")
+}
+
 func appendPartitionInfo(partitionInfo *model.PartitionInfo, buf *bytes.Buffer, sqlMode mysql.SQLMode) {
-	if partitionInfo == nil {
+	// The table is shown as a non-partitioned table when it's being partitioned or the partitioning is being removed.
+	if partitionInfo == nil || partitionInfo.Type == model.PartitionTypeNone {
 		return
 	}
 	// Since MySQL 5.1/5.5 is very old and TiDB aims for 5.7/8.0 compatibility, we will not
 	// include the /*!50100 or /*!50500 comments for TiDB.
 	// This also solves the issue with comments within comments that would happen for
 	// PLACEMENT POLICY options.
-	if partitionInfo.Type == model.PartitionTypeHash {
+	if partitionInfo.Type == model.PartitionTypeHash || partitionInfo.Type == model.PartitionTypeKey {
 		defaultPartitionDefinitions := true
 		for i, def := range partitionInfo.Definitions {
 			if def.Name.O != fmt.Sprintf("p%d", i) {
@@ -1263,12 +1318,18 @@ func appendPartitionInfo(partitionInfo *model.PartitionInfo, buf *bytes.Buffer,
 		}
 
 		if defaultPartitionDefinitions {
-			fmt.Fprintf(buf, "\nPARTITION BY HASH (%s) PARTITIONS %d", partitionInfo.Expr, partitionInfo.Num)
+			if partitionInfo.Type == model.PartitionTypeHash {
+				fmt.Fprintf(buf, "\nPARTITION BY HASH (%s) PARTITIONS %d", partitionInfo.Expr, partitionInfo.Num)
+			} else {
+				fmt.Fprintf(buf, "\nPARTITION BY KEY (%s) PARTITIONS %d", partitionColumnsString(partitionInfo, sqlMode), partitionInfo.Num)
+			}
 			return
 		}
 	}
 	// this if statement takes care of lists/range columns case
-	if partitionInfo.Columns != nil {
+	if partitionInfo.Type == model.PartitionTypeKey {
+		fmt.Fprintf(buf, "\nPARTITION BY KEY (%s)", partitionColumnsString(partitionInfo, sqlMode))
+	} else if partitionInfo.Columns != nil {
 		// partitionInfo.Type == model.PartitionTypeRange || partitionInfo.Type == model.PartitionTypeList
 		// Notice that MySQL uses two spaces between LIST and COLUMNS...
 		fmt.Fprintf(buf, "\nPARTITION BY %s COLUMNS(", partitionInfo.Type.String())
@@ -1278,12 +1339,23 @@ func appendPartitionInfo(partitionInfo *model.PartitionInfo, buf *bytes.Buffer,
 				buf.WriteString(",")
 			}
 		}
-		buf.WriteString(")\n(")
+		buf.WriteString(")")
 	} else {
-		fmt.Fprintf(buf, "\nPARTITION BY %s (%s)\n(", partitionInfo.Type.String(), partitionInfo.Expr)
+		fmt.Fprintf(buf, "\nPARTITION BY %s (%s)", partitionInfo.Type.String(), partitionInfo.Expr)
+	}
+	defs := partitionInfo.Definitions
+	if partitionInfo.IsSubPartitioned() {
+		defs = partitionInfo.ParentDefinitions
+		if partitionInfo.SubType == model.PartitionTypeKey {
+			fmt.Fprintf(buf, "\nSUBPARTITION BY KEY (%s)", subPartitionColumnsString(partitionInfo, sqlMode))
+		} else {
+			fmt.Fprintf(buf, "\nSUBPARTITION BY %s (%s)", partitionInfo.SubType.String(), partitionInfo.SubExpr)
+		}
+		fmt.Fprintf(buf, " SUBPARTITIONS %d", partitionInfo.SubNum)
 	}
+	buf.WriteString("\n(")
 
-	for i, def := range partitionInfo.Definitions {
+	for i, def := range defs {
 		if i > 0 {
 			fmt.Fprintf(buf, ",\n ")
 		}
@@ -1315,6 +1387,27 @@ func appendPartitionInfo(partitionInfo *model.PartitionInfo, buf *bytes.Buffer,
 			// add placement ref info here
 			fmt.Fprintf(buf, " /*T![placement] PLACEMENT POLICY=%s */", stringutil.Escape(def.PlacementPolicyRef.Name.O, sqlMode))
 		}
+		if partitionInfo.IsSubPartitioned() {
+			appendSubPartitionDefinitions(partitionInfo.SubDefinitions(i), buf, sqlMode)
+		}
+	}
+	buf.WriteString(")")
+}
+
+// appendSubPartitionDefinitions appends the subpartitions of a first level partition.
+func appendSubPartitionDefinitions(subDefs []model.PartitionDefinition, buf *bytes.Buffer, sqlMode mysql.SQLMode) {
+	buf.WriteString("\n (")
+	for j, def := range subDefs {
+		if j > 0 {
+			buf.WriteString(",\n  ")
+		}
+		fmt.Fprintf(buf, "SUBPARTITION %s", stringutil.Escape(def.Name.O, sqlMode))
+		if len(def.Comment) > 0 {
+			buf.WriteString(fmt.Sprintf(" COMMENT '%s'", format.OutputFormat(def.Comment)))
+		}
+		if def.PlacementPolicyRef != nil {
+			fmt.Fprintf(buf, " /*T![placement] PLACEMENT POLICY=%s */", stringutil.Escape(def.PlacementPolicyRef.Name.O, sqlMode))
+		}
 	}
 	buf.WriteString(")")
 }
@@ -1560,10 +1653,79 @@ func (e *ShowExec) fetchShowPrivileges() error {
 }
 
 func (e *ShowExec) fetchShowTriggers() error {
+	if !e.is.SchemaExists(e.DBName) {
+		return ErrBadDB.GenWithStackByArgs(e.DBName)
+	}
+	dbInfo, _ := e.is.SchemaByName(e.DBName)
+	checker := privilege.GetPrivilegeManager(e.ctx)
+	activeRoles := e.ctx.GetSessionVars().ActiveRoles
+	tables := e.is.SchemaTables(e.DBName)
+	sort.Slice(tables, func(i, j int) bool { return tables[i].Meta().Name.L < tables[j].Meta().Name.L })
+	for _, tbl := range tables {
+		tblInfo := tbl.Meta()
+		if len(tblInfo.Triggers) == 0 {
+			continue
+		}
+		if checker != nil && !checker.RequestVerification(activeRoles, e.DBName.O, tblInfo.Name.O, "", mysql.TriggerPriv) {
+			continue
+		}
+		for _, trig := range tblInfo.Triggers {
+			createTime := types.NewTime(types.FromGoTime(trig.CreateTime.In(e.ctx.GetSessionVars().Location())), mysql.TypeDatetime, 2)
+			definer := ""
+			if trig.Definer != nil {
+				definer = trig.Definer.String()
+			}
+			e.appendRow([]interface{}{trig.Name.O, trig.Event.String(), tblInfo.Name.O, trig.Body, trig.Timing.String(),
+				createTime, trig.SQLMode, definer, trig.Charset, trig.Collate, dbInfo.Collate})
+		}
+	}
+	return nil
+}
+
+func (e *ShowExec) fetchShowEvents(ctx context.Context) error {
+	dbInfo, ok := e.is.SchemaByName(e.DBName)
+	if !ok {
+		return ErrBadDB.GenWithStackByArgs(e.DBName)
+	}
+	checker := privilege.GetPrivilegeManager(e.ctx)
+	if checker != nil && !checker.RequestVerification(e.ctx.GetSessionVars().ActiveRoles, dbInfo.Name.L, "", "", mysql.EventPriv) {
+		return nil
+	}
+	events, err := LoadEvents(ctx, e.ctx, "LOWER(event_schema) = %?", dbInfo.Name.L)
+	if err != nil {
+		return err
+	}
+	loc := e.ctx.GetSessionVars().Location()
+	for _, ev := range events {
+		eventType, intervalValue, intervalField := "RECURRING", interface{}(ev.IntervalValue), interface{}(ev.IntervalField)
+		if !ev.ExecuteAt.IsZero() {
+			eventType, intervalValue, intervalField = "ONE TIME", nil, nil
+		}
+		e.appendRow([]interface{}{ev.Schema, ev.Name, ev.TimeZone, ev.Definer, eventType,
+			eventTimeValue(ev.ExecuteAt, loc), intervalValue, intervalField, eventTimeValue(ev.Starts, loc),
+			eventTimeValue(ev.Ends, loc), ev.Status, 0, ev.Charset, ev.Collation, dbInfo.Collate})
+	}
 	return nil
 }
 
 func (e *ShowExec) fetchShowProcedureStatus() error {
+	checker := privilege.GetPrivilegeManager(e.ctx)
+	dbs := e.is.AllSchemas()
+	sort.Sort(infoschema.SchemasSorter(dbs))
+	for _, db := range dbs {
+		if checker != nil && !checker.RequestVerification(e.ctx.GetSessionVars().ActiveRoles, db.Name.L, "", "", mysql.AllPrivMask) {
+			continue
+		}
+		for _, proc := range db.Procedures {
+			createTime := types.NewTime(types.FromGoTime(proc.CreateTime.In(e.ctx.GetSessionVars().Location())), mysql.TypeDatetime, types.DefaultFsp)
+			definer := ""
+			if proc.Definer != nil {
+				definer = proc.Definer.String()
+			}
+			e.appendRow([]interface{}{db.Name.O, proc.Name.O, "PROCEDURE", definer, createTime, createTime,
+				"INVOKER", "", proc.Charset, proc.Collate, db.Collate})
+		}
+	}
 	return nil
 }
 
@@ -35,11 +35,7 @@ func (e *ShowExec) fetchShowStatsExtended() error {
 	dbs := do.InfoSchema().AllSchemas()
 	for _, db := range dbs {
 		for _, tblInfo := range db.Tables {
-			pi := tblInfo.GetPartitionInfo()
-			// Extended statistics for partitioned table is not supported now.
-			if pi != nil {
-				continue
-			}
+			// The global-level extended stats are shown for partitioned tables.
 			e.appendTableForStatsExtended(db.Name.L, tblInfo, h.GetTableStats(tblInfo))
 		}
 	}
@@ -86,7 +82,7 @@ func (e *ShowExec) appendTableForStatsExtended(dbName string, tbl *model.TableIn
 			statsVal = item.StringVals
 		case ast.StatsTypeCardinality:
 			statsType = "cardinality"
-			statsVal = item.StringVals
+			statsVal = fmt.Sprintf("%f", item.ScalarVals)
 		}
 		e.appendRow([]interface{}{
 			dbName,
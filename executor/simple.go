@@ -162,6 +162,14 @@ func (e *SimpleExec) Next(ctx context.Context, req *chunk.Chunk) (err error) {
 		err = e.executeShutdown(x)
 	case *ast.AdminStmt:
 		err = e.executeAdmin(x)
+	case *ast.CreateEventStmt:
+		err = e.executeCreateEvent(ctx, x)
+	case *ast.AlterEventStmt:
+		err = e.executeAlterEvent(ctx, x)
+	case *ast.DropEventStmt:
+		err = e.executeDropEvent(ctx, x)
+	case *ast.XAStmt:
+		err = e.executeXA(ctx, x)
 	}
 	e.done = true
 	return err
@@ -1597,7 +1605,8 @@ func (e *SimpleExec) executeDropStats(s *ast.DropStatsStmt) (err error) {
 
 func (e *SimpleExec) autoNewTxn() bool {
 	switch e.Statement.(type) {
-	case *ast.CreateUserStmt, *ast.AlterUserStmt, *ast.DropUserStmt, *ast.RenameUserStmt:
+	case *ast.CreateUserStmt, *ast.AlterUserStmt, *ast.DropUserStmt, *ast.RenameUserStmt,
+		*ast.CreateEventStmt, *ast.AlterEventStmt, *ast.DropEventStmt:
 		return true
 	}
 	return false
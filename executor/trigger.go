@@ -0,0 +1,263 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/terror"
+	plannercore "github.com/pingcap/tidb/planner/core"
+	"github.com/pingcap/tidb/privilege"
+	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/hint"
+)
+
+// TriggerRunner runs the body of a trigger, it's implemented by the session.
+type TriggerRunner interface {
+	RunTrigger(ctx context.Context, tc *TriggerContext) error
+}
+
+// TriggerContext is the context to run a trigger on the rows of a statement.
+type TriggerContext struct {
+	Trigger *model.TriggerInfo
+	DB      *model.DBInfo
+	Table   *model.TableInfo
+	// OldRow and NewRow are the OLD and NEW rows of the current activation,
+	// the values of NewRow can be changed by a BEFORE trigger.
+	OldRow []types.Datum
+	NewRow []types.Datum
+	// Program is used by the TriggerRunner to cache the parsed body of the
+	// trigger, it's reused by the activations on the following rows.
+	Program interface{}
+
+	exec *triggerExec
+}
+
+// ExecStmt executes a statement of the trigger body as a part of the statement which activates
+// the trigger, the statement is executed with the privileges of the definer of the trigger.
+// It returns the fields and the rows of the statement if it has a result set.
+func (tc *TriggerContext) ExecStmt(ctx context.Context, stmt ast.StmtNode) ([]*ast.ResultField, []chunk.Row, error) {
+	switch stmt.(type) {
+	case *ast.InsertStmt, *ast.UpdateStmt, *ast.DeleteStmt, *ast.SelectStmt, *ast.SetOprStmt, *ast.SetStmt, *ast.DoStmt:
+	default:
+		return nil, nil, ErrCommitNotAllowedInSfOrTrg.GenWithStackByArgs()
+	}
+	parent := tc.exec.b
+	sctx, is := parent.ctx, parent.is
+	err := plannercore.Preprocess(sctx, stmt, plannercore.WithPreprocessorReturn(&plannercore.PreprocessorReturn{InfoSchema: is}))
+	if err != nil {
+		return nil, nil, err
+	}
+	builder, _ := plannercore.NewPlanBuilder().Init(sctx, is, &hint.BlockHintProcessor{})
+	p, err := builder.Build(ctx, stmt)
+	if err != nil {
+		return nil, nil, err
+	}
+	if pm := privilege.GetPrivilegeManager(sctx); pm != nil && tc.Trigger.Definer != nil {
+		if err = plannercore.CheckPrivilegeWithUser(pm, tc.Trigger.Definer, builder.GetVisitInfo()); err != nil {
+			return nil, nil, err
+		}
+	}
+	if logic, ok := p.(plannercore.LogicalPlan); ok {
+		if p, _, err = plannercore.DoOptimize(ctx, sctx, builder.GetOptFlag(), logic); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	b := newExecutorBuilder(sctx, is, nil, parent.snapshotTS, parent.isStaleness, parent.readReplicaScope)
+	b.triggerTables = tc.exec.usedTables
+	e := b.build(p)
+	if b.err != nil {
+		return nil, nil, b.err
+	}
+	for _, tbl := range modifiedTables(e) {
+		if _, ok := tc.exec.usedTables[tbl.Meta().ID]; ok {
+			return nil, nil, ErrCantUpdateUsedTableInSfOrTrg.GenWithStackByArgs(tbl.Meta().Name.O)
+		}
+	}
+	if err = e.Open(ctx); err != nil {
+		terror.Call(e.Close)
+		return nil, nil, err
+	}
+	var rows []chunk.Row
+	chk := newFirstChunk(e)
+	for {
+		if err = Next(ctx, e, chk); err != nil {
+			terror.Call(e.Close)
+			return nil, nil, err
+		}
+		if chk.NumRows() == 0 {
+			break
+		}
+		iter := chunk.NewIterator4Chunk(chk)
+		for r := iter.Begin(); r != iter.End(); r = iter.Next() {
+			rows = append(rows, r)
+		}
+		chk = chunk.Renew(chk, sctx.GetSessionVars().MaxChunkSize)
+	}
+	if err = e.Close(); err != nil {
+		return nil, nil, err
+	}
+	switch stmt.(type) {
+	case *ast.SelectStmt, *ast.SetOprStmt:
+		return colNames2ResultFields(p.Schema(), p.OutputNames(), sctx.GetSessionVars().CurrentDB), rows, nil
+	}
+	return nil, nil, nil
+}
+
+// modifiedTables returns the tables written by the executor.
+func modifiedTables(e Executor) []table.Table {
+	switch x := e.(type) {
+	case *InsertExec:
+		return []table.Table{x.Table}
+	case *ReplaceExec:
+		return []table.Table{x.Table}
+	case *UpdateExec:
+		tbls := make([]table.Table, 0, len(x.tblID2table))
+		for _, tbl := range x.tblID2table {
+			tbls = append(tbls, tbl)
+		}
+		return tbls
+	case *DeleteExec:
+		tbls := make([]table.Table, 0, len(x.tblID2Table))
+		for _, tbl := range x.tblID2Table {
+			tbls = append(tbls, tbl)
+		}
+		return tbls
+	}
+	return nil
+}
+
+// triggerExec activates the triggers of a table on the rows written by a statement.
+type triggerExec struct {
+	b        *executorBuilder
+	tbl      table.Table
+	contexts []*TriggerContext
+	// usedTables are the IDs of the tables modified by the statements which activate
+	// the triggers, they can't be modified by the statements of the trigger bodies.
+	usedTables map[int64]struct{}
+
+	genCols  []*table.Column
+	genExprs []expression.Expression
+}
+
+func (b *executorBuilder) buildTriggerExec(tbl table.Table) *triggerExec {
+	tblInfo := tbl.Meta()
+	// The cascaded foreign key actions don't activate triggers.
+	if len(tblInfo.Triggers) == 0 || b.fkCascadeDepth > 0 {
+		return nil
+	}
+	dbInfo, ok := b.is.SchemaByTable(tblInfo)
+	if !ok {
+		b.err = errors.Errorf("schema of table %s not found", tblInfo.Name.O)
+		return nil
+	}
+	e := &triggerExec{
+		b:          b,
+		tbl:        tbl,
+		contexts:   make([]*TriggerContext, 0, len(tblInfo.Triggers)),
+		usedTables: make(map[int64]struct{}, len(b.triggerTables)+1),
+	}
+	for id := range b.triggerTables {
+		e.usedTables[id] = struct{}{}
+	}
+	e.usedTables[tblInfo.ID] = struct{}{}
+	for _, trig := range tblInfo.Triggers {
+		e.contexts = append(e.contexts, &TriggerContext{Trigger: trig, DB: dbInfo, Table: tblInfo, exec: e})
+	}
+	return e
+}
+
+func (b *executorBuilder) buildTriggerExecsForTables(tblID2table map[int64]table.Table) map[int64]*triggerExec {
+	var triggers map[int64]*triggerExec
+	for tid, tbl := range tblID2table {
+		if e := b.buildTriggerExec(tbl); e != nil {
+			if triggers == nil {
+				triggers = make(map[int64]*triggerExec)
+			}
+			triggers[tid] = e
+		}
+	}
+	return triggers
+}
+
+// fire runs the triggers with the timing and the event on the row in their activation order.
+// The generated columns of the NEW row are recomputed after the BEFORE triggers change it.
+func (e *triggerExec) fire(ctx context.Context, timing model.TriggerTiming, event model.TriggerEvent, oldRow, newRow []types.Datum) error {
+	if e == nil {
+		return nil
+	}
+	sc := e.b.ctx.GetSessionVars().StmtCtx
+	fired := false
+	for _, tc := range e.contexts {
+		if tc.Trigger.Timing != timing || tc.Trigger.Event != event {
+			continue
+		}
+		runner, ok := e.b.ctx.(TriggerRunner)
+		if !ok {
+			return errors.Errorf("triggers are not supported by %T", e.b.ctx)
+		}
+		tc.OldRow, tc.NewRow = oldRow, newRow
+		// The rows written by the trigger are not counted in the result of the statement.
+		counter := sc.SaveRowsCounter()
+		err := runner.RunTrigger(ctx, tc)
+		sc.RestoreRowsCounter(counter)
+		tc.OldRow, tc.NewRow = nil, nil
+		if err != nil {
+			return err
+		}
+		fired = true
+	}
+	if fired && timing == model.TriggerBefore && newRow != nil {
+		return e.updateGeneratedColumns(newRow)
+	}
+	return nil
+}
+
+func (e *triggerExec) updateGeneratedColumns(row []types.Datum) error {
+	if e.genExprs == nil {
+		for _, col := range e.tbl.Cols() {
+			if !col.IsGenerated() {
+				continue
+			}
+			expr, err := expression.ParseSimpleExprWithTableInfo(e.b.ctx, col.GeneratedExprString, e.tbl.Meta())
+			if err != nil {
+				return err
+			}
+			e.genCols = append(e.genCols, col)
+			e.genExprs = append(e.genExprs, expr)
+		}
+		if e.genExprs == nil {
+			e.genExprs = []expression.Expression{}
+		}
+	}
+	sc := e.b.ctx.GetSessionVars().StmtCtx
+	for i, col := range e.genCols {
+		val, err := e.genExprs[i].Eval(chunk.MutRowFromDatums(row).ToRow())
+		if err = sc.HandleTruncate(err); err != nil {
+			return err
+		}
+		if row[col.Offset], err = table.CastValue(e.b.ctx, val, col.ToInfo(), false, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -67,6 +67,10 @@ type UpdateExec struct {
 	tableUpdatable []bool
 	changed        []bool
 	matches        []bool
+
+	fkChecks   map[int64][]*FKCheckExec
+	fkCascades map[int64][]*FKCascadeExec
+	triggers   map[int64]*triggerExec
 }
 
 // prepare `handles`, `tableUpdatable`, `changed` to avoid re-computations.
@@ -189,13 +193,19 @@ func (e *UpdateExec) exec(ctx context.Context, schema *expression.Schema, row, n
 		flags := bAssignFlag[content.Start:content.End]
 
 		// Update row
-		changed, err1 := updateRecord(ctx, e.ctx, handle, oldData, newTableData, flags, tbl, false, e.memTracker)
+		changed, err1 := updateRecord(ctx, e.ctx, handle, oldData, newTableData, flags, tbl, false, e.memTracker, e.triggers[content.TblID])
+		sc := e.ctx.GetSessionVars().StmtCtx
 		if err1 == nil {
 			e.updatedRowKeys[content.Start].Set(handle, changed)
+			if changed {
+				err1 = onUpdateRowForFK(sc, oldData, newTableData, e.fkChecks[content.TblID], e.fkCascades[content.TblID])
+				if err1 != nil {
+					return err1
+				}
+			}
 			continue
 		}
 
-		sc := e.ctx.GetSessionVars().StmtCtx
 		if kv.ErrKeyExists.Equal(err1) && sc.DupKeyAsWarning {
 			sc.AppendWarning(err1)
 			continue
@@ -316,9 +326,21 @@ func (e *UpdateExec) updateRows(ctx context.Context) (int, error) {
 		totalNumRows += chk.NumRows()
 		chk = chunk.Renew(chk, e.maxChunkSize)
 	}
+	if err := e.doFKCascadesAndChecks(ctx); err != nil {
+		return 0, err
+	}
 	return totalNumRows, nil
 }
 
+func (e *UpdateExec) doFKCascadesAndChecks(ctx context.Context) error {
+	for _, content := range e.tblColPosInfos {
+		if err := doFKCascadesAndChecks(ctx, e.fkChecks[content.TblID], e.fkCascades[content.TblID]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (e *UpdateExec) handleErr(colName model.CIStr, rowIdx int, err error) error {
 	if err == nil {
 		return nil
@@ -531,3 +531,180 @@ func (p *rangeFrameWindowProcessor) resetPartialResult() {
 	p.lastStartOffset = 0
 	p.lastEndOffset = 0
 }
+
+// groupsFrameWindowProcessor processes the `GROUPS` frame, the frame bounds
+// are counted in peer groups, which are the rows with equal order by values.
+type groupsFrameWindowProcessor struct {
+	windowFuncs    []aggfuncs.AggFunc
+	partialResults []aggfuncs.PartialResult
+	start          *core.FrameBound
+	end            *core.FrameBound
+	curRowIdx      uint64
+	orderByCols    []*expression.Column
+	cmpFuncs       []expression.CompareFunc
+	// rowGroups stores the peer group index of each row in the partition.
+	rowGroups []uint64
+	// groupOffsets stores the offset of the first row of each peer group,
+	// the last element is the number of rows in the partition.
+	groupOffsets []uint64
+}
+
+// buildPeerGroups splits the rows of the current partition into peer groups.
+func (p *groupsFrameWindowProcessor) buildPeerGroups(ctx sessionctx.Context, rows []chunk.Row) error {
+	p.rowGroups = p.rowGroups[:0]
+	p.groupOffsets = p.groupOffsets[:0]
+	for i := range rows {
+		isPeer := i > 0
+		for j := 0; isPeer && j < len(p.orderByCols); j++ {
+			res, _, err := p.cmpFuncs[j](ctx, p.orderByCols[j], p.orderByCols[j], rows[i-1], rows[i])
+			if err != nil {
+				return err
+			}
+			isPeer = res == 0
+		}
+		if !isPeer {
+			p.groupOffsets = append(p.groupOffsets, uint64(i))
+		}
+		p.rowGroups = append(p.rowGroups, uint64(len(p.groupOffsets)-1))
+	}
+	p.groupOffsets = append(p.groupOffsets, uint64(len(rows)))
+	return nil
+}
+
+func (p *groupsFrameWindowProcessor) getStartOffset(numRows uint64) uint64 {
+	if p.start.UnBounded {
+		return 0
+	}
+	group, numGroups := p.rowGroups[p.curRowIdx], uint64(len(p.groupOffsets)-1)
+	switch p.start.Type {
+	case ast.Preceding:
+		if group >= p.start.Num {
+			return p.groupOffsets[group-p.start.Num]
+		}
+		return 0
+	case ast.Following:
+		if group+p.start.Num < numGroups {
+			return p.groupOffsets[group+p.start.Num]
+		}
+		return numRows
+	case ast.CurrentRow:
+		return p.groupOffsets[group]
+	}
+	// It will never reach here.
+	return 0
+}
+
+func (p *groupsFrameWindowProcessor) getEndOffset(numRows uint64) uint64 {
+	if p.end.UnBounded {
+		return numRows
+	}
+	group, numGroups := p.rowGroups[p.curRowIdx], uint64(len(p.groupOffsets)-1)
+	switch p.end.Type {
+	case ast.Preceding:
+		if group >= p.end.Num {
+			return p.groupOffsets[group-p.end.Num+1]
+		}
+		return 0
+	case ast.Following:
+		if group+p.end.Num < numGroups {
+			return p.groupOffsets[group+p.end.Num+1]
+		}
+		return numRows
+	case ast.CurrentRow:
+		return p.groupOffsets[group+1]
+	}
+	// It will never reach here.
+	return 0
+}
+
+func (p *groupsFrameWindowProcessor) consumeGroupRows(ctx sessionctx.Context, rows []chunk.Row) ([]chunk.Row, error) {
+	return rows, nil
+}
+
+func (p *groupsFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, rows []chunk.Row, chk *chunk.Chunk, remained int) ([]chunk.Row, error) {
+	numRows := uint64(len(rows))
+	var (
+		err                      error
+		initializedSlidingWindow bool
+		start                    uint64
+		end                      uint64
+		lastStart                uint64
+		lastEnd                  uint64
+		shiftStart               uint64
+		shiftEnd                 uint64
+	)
+	if len(p.rowGroups) == 0 {
+		if err = p.buildPeerGroups(ctx, rows); err != nil {
+			return nil, err
+		}
+	}
+	slidingWindowAggFuncs := make([]aggfuncs.SlidingWindowAggFunc, len(p.windowFuncs))
+	for i, windowFunc := range p.windowFuncs {
+		if slidingWindowAggFunc, ok := windowFunc.(aggfuncs.SlidingWindowAggFunc); ok {
+			slidingWindowAggFuncs[i] = slidingWindowAggFunc
+		}
+	}
+	for ; remained > 0; lastStart, lastEnd = start, end {
+		start = p.getStartOffset(numRows)
+		end = p.getEndOffset(numRows)
+		p.curRowIdx++
+		remained--
+		shiftStart = start - lastStart
+		shiftEnd = end - lastEnd
+		if start >= end {
+			for i, windowFunc := range p.windowFuncs {
+				slidingWindowAggFunc := slidingWindowAggFuncs[i]
+				if slidingWindowAggFunc != nil && initializedSlidingWindow {
+					err = slidingWindowAggFunc.Slide(ctx, func(u uint64) chunk.Row {
+						return rows[u]
+					}, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
+					if err != nil {
+						return nil, err
+					}
+				}
+				err = windowFunc.AppendFinalResult2Chunk(ctx, p.partialResults[i], chk)
+				if err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+
+		for i, windowFunc := range p.windowFuncs {
+			slidingWindowAggFunc := slidingWindowAggFuncs[i]
+			if slidingWindowAggFunc != nil && initializedSlidingWindow {
+				err = slidingWindowAggFunc.Slide(ctx, func(u uint64) chunk.Row {
+					return rows[u]
+				}, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
+			} else {
+				if minMaxSlidingWindowAggFunc, ok := windowFunc.(aggfuncs.MaxMinSlidingWindowAggFunc); ok {
+					minMaxSlidingWindowAggFunc.SetWindowStart(start)
+				}
+				_, err = windowFunc.UpdatePartialResult(ctx, rows[start:end], p.partialResults[i])
+			}
+			if err != nil {
+				return nil, err
+			}
+			err = windowFunc.AppendFinalResult2Chunk(ctx, p.partialResults[i], chk)
+			if err != nil {
+				return nil, err
+			}
+			if slidingWindowAggFunc == nil {
+				windowFunc.ResetPartialResult(p.partialResults[i])
+			}
+		}
+		if !initializedSlidingWindow {
+			initializedSlidingWindow = true
+		}
+	}
+	for i, windowFunc := range p.windowFuncs {
+		windowFunc.ResetPartialResult(p.partialResults[i])
+	}
+	return rows, nil
+}
+
+func (p *groupsFrameWindowProcessor) resetPartialResult() {
+	p.curRowIdx = 0
+	p.rowGroups = p.rowGroups[:0]
+	p.groupOffsets = p.groupOffsets[:0]
+}
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/pingcap/tidb/errno"
 	"github.com/pingcap/tidb/testkit"
 )
 
@@ -221,6 +222,27 @@ func doTestWindowFunctions(tk *testkit.TestKit) {
 	tk.MustQuery("select row_number() over w, sum(b) over w from t window w as (rows between 1 preceding and 1 following)").
 		Check(testkit.Rows("1 3", "2 4", "3 5", "4 3"))
 
+	tk.MustExec("drop table if exists tn")
+	tk.MustExec("create table tn (id int, g int, v int)")
+	tk.MustExec("insert into tn values (1, 1, null), (2, 1, 10), (3, 1, null), (4, 2, 20), (5, 2, 20), (6, 2, null), (7, 3, 30)")
+	tk.MustQuery("select id, first_value(v) ignore nulls over (order by id rows between current row and unbounded following), last_value(v) ignore nulls over (order by id) from tn order by id").
+		Check(testkit.Rows("1 10 <nil>", "2 10 10", "3 20 10", "4 20 20", "5 20 20", "6 30 20", "7 30 30"))
+	tk.MustQuery("select id, nth_value(v, 2) from last over w, nth_value(v, 2) from last ignore nulls over w, nth_value(v, 2) ignore nulls over w from tn window w as (order by id rows between 1 preceding and 2 following) order by id").
+		Check(testkit.Rows("1 10 <nil> <nil>", "2 <nil> 10 20", "3 20 20 20", "4 20 20 20", "5 <nil> 20 20", "6 <nil> 20 30", "7 <nil> <nil> <nil>"))
+	tk.MustQuery("select id, lead(v) ignore nulls over w, lag(v) ignore nulls over w, lag(v, 2, -1) ignore nulls over w from tn window w as (order by id) order by id").
+		Check(testkit.Rows("1 10 <nil> -1", "2 20 <nil> -1", "3 20 10 -1", "4 20 10 -1", "5 30 20 10", "6 30 20 20", "7 <nil> 20 20"))
+	tk.MustQuery("select id, count(distinct v) over (order by id rows between 2 preceding and current row), sum(distinct v) over (partition by g) from tn order by id").
+		Check(testkit.Rows("1 0 10", "2 1 10", "3 1 10", "4 2 20", "5 1 20", "6 1 20", "7 2 30"))
+	tk.MustQuery("select id, group_concat(id order by id desc separator '-') over (partition by g), group_concat(distinct v) over (order by id rows between 1 preceding and 1 following) from tn order by id").
+		Check(testkit.Rows("1 3-2-1 10", "2 3-2-1 10", "3 3-2-1 10,20", "4 6-5-4 20", "5 6-5-4 20", "6 6-5-4 20,30", "7 7 30"))
+	tk.MustQuery("select id, sum(id) over (order by g groups between 1 preceding and current row), count(*) over (order by g groups between current row and 1 following), sum(id) over (order by g groups between 2 preceding and 1 preceding) from tn order by id").
+		Check(testkit.Rows("1 6 6 <nil>", "2 6 6 <nil>", "3 6 6 <nil>", "4 21 4 6", "5 21 4 6", "6 21 4 6", "7 22 1 21"))
+	tk.MustQuery("select id, count(*) over (partition by g order by v groups current row), sum(id) over (partition by g order by v groups between 1 following and unbounded following) from tn order by id").
+		Check(testkit.Rows("1 2 2", "2 1 <nil>", "3 2 2", "4 2 <nil>", "5 2 <nil>", "6 1 9", "7 1 <nil>"))
+	// The peer groups are undefined without ORDER BY.
+	tk.MustGetErrCode("select id, count(*) over (partition by g groups between unbounded preceding and current row) from tn", errno.ErrWindowRangeFrameOrderType)
+	tk.MustGetErrCode("select id, sum(id) over w from tn window w as (groups 1 preceding)", errno.ErrWindowRangeFrameOrderType)
+
 	tk.Session().GetSessionVars().MaxChunkSize = 1
 	tk.MustQuery("select a, row_number() over (partition by a) from t").Sort().
 		Check(testkit.Rows("1 1", "1 2", "2 1", "2 2"))
@@ -25,6 +25,7 @@ import (
 	"github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tidb/meta/autoid"
 	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/parser/terror"
 	"github.com/pingcap/tidb/sessionctx"
@@ -50,7 +51,7 @@ var (
 //     1. changed (bool) : does the update really change the row values. e.g. update set i = 1 where i = 1;
 //     2. err (error) : error in the update.
 func updateRecord(ctx context.Context, sctx sessionctx.Context, h kv.Handle, oldData, newData []types.Datum, modified []bool, t table.Table,
-	onDup bool, memTracker *memory.Tracker) (bool, error) {
+	onDup bool, memTracker *memory.Tracker, triggers *triggerExec) (bool, error) {
 	if span := opentracing.SpanFromContext(ctx); span != nil && span.Tracer() != nil {
 		span1 := span.Tracer().StartSpan("executor.updateRecord", opentracing.ChildOf(span.Context()))
 		defer span1.Finish()
@@ -84,6 +85,11 @@ func updateRecord(ctx context.Context, sctx sessionctx.Context, h kv.Handle, old
 		}
 	}
 
+	// The BEFORE UPDATE triggers see the cast values, and may change them.
+	if err = triggers.fire(ctx, model.TriggerBefore, model.TriggerUpdate, oldData, newData); err != nil {
+		return false, err
+	}
+
 	// 2. Handle the bad null error.
 	for i, col := range t.Cols() {
 		var err error
@@ -153,7 +159,8 @@ func updateRecord(ctx context.Context, sctx sessionctx.Context, h kv.Handle, old
 		if txnCtx.IsPessimistic {
 			txnCtx.AddUnchangedRowKey(unchangedRowKey)
 		}
-		return false, nil
+		// The UPDATE triggers are activated even if the row is not changed.
+		return false, triggers.fire(ctx, model.TriggerAfter, model.TriggerUpdate, oldData, newData)
 	}
 
 	// 4. Fill values into on-update-now fields, only if they are really changed.
@@ -196,6 +203,10 @@ func updateRecord(ctx context.Context, sctx sessionctx.Context, h kv.Handle, old
 			if terr, ok := errors.Cause(err).(*terror.Error); sctx.GetSessionVars().StmtCtx.IgnoreNoPartition && ok && terr.Code() == errno.ErrNoPartitionForGivenValue {
 				return false, nil
 			}
+			if sc.DupKeyAsWarning && table.ErrCheckConstraintViolated.Equal(err) {
+				sc.AppendWarning(err)
+				return false, nil
+			}
 			return updated, err
 		}
 	} else {
@@ -204,6 +215,10 @@ func updateRecord(ctx context.Context, sctx sessionctx.Context, h kv.Handle, old
 			if terr, ok := errors.Cause(err).(*terror.Error); sctx.GetSessionVars().StmtCtx.IgnoreNoPartition && ok && terr.Code() == errno.ErrNoPartitionForGivenValue {
 				return false, nil
 			}
+			if sc.DupKeyAsWarning && table.ErrCheckConstraintViolated.Equal(err) {
+				sc.AppendWarning(err)
+				return false, nil
+			}
 			return false, err
 		}
 
@@ -216,7 +231,7 @@ func updateRecord(ctx context.Context, sctx sessionctx.Context, h kv.Handle, old
 	sc.AddUpdatedRows(1)
 	sc.AddCopiedRows(1)
 
-	return true, nil
+	return true, triggers.fire(ctx, model.TriggerAfter, model.TriggerUpdate, oldData, newData)
 }
 
 func rebaseAutoRandomValue(ctx context.Context, sctx sessionctx.Context, t table.Table, newData *types.Datum, col *table.Column) error {
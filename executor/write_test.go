@@ -15,17 +15,23 @@
 package executor_test
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strconv"
 	"testing"
 
+	"github.com/klauspost/compress/zstd"
 	"github.com/pingcap/tidb/config"
 	"github.com/pingcap/tidb/executor"
 	"github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/parser/terror"
 	"github.com/pingcap/tidb/planner/core"
 	"github.com/pingcap/tidb/session"
 	"github.com/pingcap/tidb/sessionctx"
@@ -38,6 +44,7 @@ import (
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util"
 	"github.com/pingcap/tidb/util/mock"
+	"github.com/pingcap/tidb/util/sem"
 	"github.com/stretchr/testify/require"
 )
 
@@ -2129,6 +2136,70 @@ func TestLoadData(t *testing.T) {
 	checkCases(tests, ld, t, tk, ctx, selectSQL, deleteSQL)
 }
 
+func TestLoadDataFromStorage(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int primary key, b varchar(10))")
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "1.csv"), []byte("a,b\n1,x\n2,y"), 0o644))
+	var gzipBuf bytes.Buffer
+	gw := gzip.NewWriter(&gzipBuf)
+	_, err := gw.Write([]byte("a,b\n3,z\n"))
+	require.NoError(t, err)
+	require.NoError(t, gw.Close())
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "2.csv.gz"), gzipBuf.Bytes(), 0o644))
+	zw, err := zstd.NewWriter(nil)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "3.csv.zst"), zw.EncodeAll([]byte("a,b\n4,w\n"), nil), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "other.txt"), []byte("5,v\n"), 0o644))
+
+	ctx := tk.Session().(sessionctx.Context)
+	loadFromStorage := func(sql string) {
+		tk.MustExec(sql)
+		ld, ok := ctx.Value(executor.LoadDataVarKey).(*executor.LoadDataInfo)
+		require.True(t, ok)
+		defer ctx.SetValue(executor.LoadDataVarKey, nil)
+		require.NoError(t, ld.LoadFromStorage(context.Background()))
+		ld.SetMessage()
+	}
+
+	loadFromStorage(fmt.Sprintf("load data infile '%s' into table t fields terminated by ',' ignore 1 lines", filepath.Join(dir, "1.csv")))
+	require.Equal(t, "Records: 2  Deleted: 0  Skipped: 0  Warnings: 0", tk.Session().LastMessage())
+	tk.MustQuery("select * from t").Check(testkit.Rows("1 x", "2 y"))
+
+	tk.MustExec("delete from t")
+	loadFromStorage(fmt.Sprintf("load data infile 'local://%s/*.csv*' into table t fields terminated by ',' ignore 1 lines", filepath.ToSlash(dir)))
+	tk.MustQuery("select * from t").Check(testkit.Rows("1 x", "2 y", "3 z", "4 w"))
+
+	_, err = tk.Exec(fmt.Sprintf("load data infile '%s' into table t", filepath.Join(dir, "*.json")))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "no file matches infile path")
+	_, err = tk.Exec(fmt.Sprintf("load data infile '%s' into table t", filepath.Join(dir, "nonexistent", "1.csv")))
+	require.Error(t, err)
+
+	// A path without a scheme is relative to the working directory of the server, even if it contains
+	// the characters which are special in URLs.
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "sub"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sub", "a:b%c.csv"), []byte("5,v\n"), 0o644))
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	defer func() {
+		require.NoError(t, os.Chdir(wd))
+	}()
+	tk.MustExec("delete from t")
+	loadFromStorage("load data infile 'sub/a:b%c.csv' into table t fields terminated by ','")
+	tk.MustQuery("select * from t").Check(testkit.Rows("5 v"))
+
+	sem.Enable()
+	defer sem.Disable()
+	_, err = tk.Exec(fmt.Sprintf("load data infile '%s' into table t", filepath.Join(dir, "1.csv")))
+	require.True(t, terror.ErrorEqual(err, executor.ErrNotSupportedWithSem))
+}
+
 func TestLoadDataEscape(t *testing.T) {
 	trivialMsg := "Records: 1  Deleted: 0  Skipped: 0  Warnings: 0"
 	store, clean := testkit.CreateMockStore(t)
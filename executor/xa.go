@@ -0,0 +1,327 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/domain/infosync"
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/privilege"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/store/driver/txn"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/dbterror"
+	"github.com/pingcap/tidb/util/logutil"
+	"github.com/pingcap/tidb/util/sqlexec"
+	"go.uber.org/zap"
+)
+
+// xaNonExisting is the state reported when the session is not in an XA transaction.
+const xaNonExisting = "NON-EXISTING"
+
+// xaBranch is a prepared XA transaction branch recorded in mysql.xa_prepared.
+type xaBranch struct {
+	xid     ast.XID
+	startTS uint64
+	// primary is nil if the branch has nothing to commit.
+	primary []byte
+	user    string
+	host    string
+}
+
+func loadXABranches(ctx context.Context, sctx sessionctx.Context, where string, args ...interface{}) ([]*xaBranch, error) {
+	exec := sctx.(sqlexec.RestrictedSQLExecutor)
+	rows, _, err := exec.ExecRestrictedSQL(ctx, nil, "SELECT gtrid, bqual, format_id, start_ts, primary_key, user, host FROM mysql.xa_prepared"+where+" ORDER BY prepare_time", args...)
+	if err != nil {
+		return nil, err
+	}
+	branches := make([]*xaBranch, 0, len(rows))
+	for _, row := range rows {
+		b := &xaBranch{
+			xid:     ast.XID{GTRID: row.GetString(0), BQual: row.GetString(1), FormatID: row.GetUint64(2)},
+			startTS: row.GetUint64(3),
+			user:    row.GetString(5),
+			host:    row.GetString(6),
+		}
+		if !row.IsNull(4) {
+			b.primary = row.GetBytes(4)
+		}
+		branches = append(branches, b)
+	}
+	return branches, nil
+}
+
+func loadXABranch(ctx context.Context, sctx sessionctx.Context, xid *ast.XID) (*xaBranch, error) {
+	branches, err := loadXABranches(ctx, sctx, " WHERE gtrid = %? AND bqual = %? AND format_id = %?", xid.GTRID, xid.BQual, xid.FormatID)
+	if err != nil || len(branches) == 0 {
+		return nil, err
+	}
+	return branches[0], nil
+}
+
+func deleteXABranch(ctx context.Context, sctx sessionctx.Context, xid *ast.XID) error {
+	exec := sctx.(sqlexec.RestrictedSQLExecutor)
+	_, _, err := exec.ExecRestrictedSQL(ctx, nil, "DELETE FROM mysql.xa_prepared WHERE gtrid = %? AND bqual = %? AND format_id = %?", xid.GTRID, xid.BQual, xid.FormatID)
+	return err
+}
+
+// checkXATxn checks that the session works on the XA transaction branch xid in the given state.
+func checkXATxn(vars *variable.SessionVars, xid *ast.XID, state variable.XATxnState) error {
+	if vars.XATxn == nil {
+		return ErrXaerRmfail.GenWithStackByArgs(xaNonExisting)
+	}
+	if vars.XATxn.XID != *xid {
+		return ErrXaerNota
+	}
+	if vars.XATxn.State != state {
+		return ErrXaerRmfail.GenWithStackByArgs(vars.XATxn.State)
+	}
+	return nil
+}
+
+func (e *SimpleExec) executeXA(ctx context.Context, s *ast.XAStmt) error {
+	switch s.Tp {
+	case ast.XAStart:
+		return e.executeXAStart(ctx, s.XID)
+	case ast.XAEnd:
+		vars := e.ctx.GetSessionVars()
+		if err := checkXATxn(vars, s.XID, variable.XAActive); err != nil {
+			return err
+		}
+		vars.XATxn.State = variable.XAIdle
+		return nil
+	case ast.XAPrepare:
+		return e.executeXAPrepare(ctx, s.XID)
+	case ast.XACommit:
+		return e.executeXACommit(ctx, s)
+	case ast.XARollback:
+		return e.executeXARollback(ctx, s.XID)
+	}
+	return errors.Errorf("unexpected XA statement type %d", s.Tp)
+}
+
+func (e *SimpleExec) executeXAStart(ctx context.Context, xid *ast.XID) error {
+	vars := e.ctx.GetSessionVars()
+	if vars.XATxn != nil {
+		return ErrXaerRmfail.GenWithStackByArgs(vars.XATxn.State)
+	}
+	if vars.InTxn() {
+		return ErrXaerOutside
+	}
+	if vars.BinlogClient != nil {
+		return dbterror.ErrNotSupportedYet.GenWithStackByArgs("XA transactions with binlog enabled")
+	}
+	branch, err := loadXABranch(ctx, e.ctx, xid)
+	if err != nil {
+		return err
+	}
+	if branch != nil {
+		return ErrXaerDupid
+	}
+	if err = e.executeBegin(ctx, &ast.BeginStmt{}); err != nil {
+		return err
+	}
+	vars.XATxn = &variable.XATxnInfo{XID: *xid, State: variable.XAActive}
+	return nil
+}
+
+// executeXAPrepare records the branch in mysql.xa_prepared and prewrites the transaction,
+// so the branch survives the session and can be committed or rolled back by any session.
+func (e *SimpleExec) executeXAPrepare(ctx context.Context, xid *ast.XID) error {
+	vars := e.ctx.GetSessionVars()
+	if err := checkXATxn(vars, xid, variable.XAIdle); err != nil {
+		return err
+	}
+	txn, err := e.ctx.Txn(true)
+	if err != nil {
+		return err
+	}
+	var user, host string
+	if vars.User != nil {
+		user, host = vars.User.Username, vars.User.Hostname
+	}
+	record := func(startTS uint64, primary []byte) error {
+		exec := e.ctx.(sqlexec.RestrictedSQLExecutor)
+		_, _, err := exec.ExecRestrictedSQL(ctx, nil, "INSERT INTO mysql.xa_prepared (gtrid, bqual, format_id, start_ts, primary_key, user, host, instance, prepare_time) VALUES (%?, %?, %?, %?, %?, %?, %?, %?, %?)",
+			xid.GTRID, xid.BQual, xid.FormatID, startTS, primary, user, host, xaInstance(), time.Now().UTC())
+		if kv.ErrKeyExists.Equal(err) {
+			return ErrXaerDupid
+		}
+		return err
+	}
+	vars.SetInTxn(false)
+	if txn.IsReadOnly() {
+		err = record(txn.StartTS(), nil)
+	} else {
+		txn.SetOption(kv.XAPrepare, record)
+		// Commit the transaction, it is only prewritten because of the XAPrepare option.
+		err = e.ctx.NewTxn(ctx)
+	}
+	if err != nil {
+		// The branch is rolled back if it fails to prepare.
+		vars.XATxn = nil
+		if errors.ErrorEqual(err, ErrXaerDupid) {
+			return err
+		}
+		if delErr := deleteXABranch(ctx, e.ctx, xid); delErr != nil {
+			logutil.Logger(ctx).Warn("delete XA transaction branch failed", zap.Error(delErr))
+		}
+		return err
+	}
+	vars.XATxn.State = variable.XAPrepared
+	return nil
+}
+
+func (e *SimpleExec) executeXACommit(ctx context.Context, s *ast.XAStmt) error {
+	vars := e.ctx.GetSessionVars()
+	if s.OnePhase {
+		if err := checkXATxn(vars, s.XID, variable.XAIdle); err != nil {
+			return err
+		}
+		// The transaction is committed when the statement finishes, like COMMIT.
+		vars.XATxn = nil
+		vars.SetInTxn(false)
+		return nil
+	}
+	if err := e.checkXAPrepared(s.XID); err != nil {
+		return err
+	}
+	branch, err := loadXABranch(ctx, e.ctx, s.XID)
+	if err != nil {
+		return err
+	}
+	if branch == nil {
+		// The branch has been finished by another session.
+		vars.XATxn = nil
+		return ErrXaerNota
+	}
+	if branch.primary != nil {
+		err = txn.CommitPreparedXA(ctx, e.ctx.GetStore(), branch.primary, branch.startTS)
+		if errors.ErrorEqual(err, txn.ErrXARolledBack) {
+			err = ErrXaRbrollback
+		} else if err != nil {
+			return err
+		}
+	}
+	if delErr := deleteXABranch(ctx, e.ctx, s.XID); delErr != nil {
+		return delErr
+	}
+	vars.XATxn = nil
+	return err
+}
+
+func (e *SimpleExec) executeXARollback(ctx context.Context, xid *ast.XID) error {
+	vars := e.ctx.GetSessionVars()
+	if vars.XATxn != nil && vars.XATxn.XID == *xid && vars.XATxn.State == variable.XAIdle {
+		vars.XATxn = nil
+		return e.executeRollback(&ast.RollbackStmt{})
+	}
+	if err := e.checkXAPrepared(xid); err != nil {
+		return err
+	}
+	branch, err := loadXABranch(ctx, e.ctx, xid)
+	if err != nil {
+		return err
+	}
+	if branch == nil {
+		// The branch has been finished by another session.
+		vars.XATxn = nil
+		return ErrXaerNota
+	}
+	if branch.primary != nil {
+		if err = txn.RollbackPreparedXA(ctx, e.ctx.GetStore(), branch.primary, branch.startTS); err != nil {
+			return err
+		}
+	}
+	if err = deleteXABranch(ctx, e.ctx, xid); err != nil {
+		return err
+	}
+	vars.XATxn = nil
+	return nil
+}
+
+// checkXAPrepared checks that a prepared branch can be committed or rolled back by the session.
+// The session can finish any prepared branch if it's not working on another one.
+func (e *SimpleExec) checkXAPrepared(xid *ast.XID) error {
+	vars := e.ctx.GetSessionVars()
+	if vars.XATxn != nil {
+		return checkXATxn(vars, xid, variable.XAPrepared)
+	}
+	if vars.InTxn() {
+		return ErrXaerOutside
+	}
+	return nil
+}
+
+func xaInstance() string {
+	info, err := infosync.GetServerInfo()
+	if err != nil {
+		return ""
+	}
+	return info.IP + ":" + strconv.FormatUint(uint64(info.Port), 10)
+}
+
+// XARecoverExec lists the prepared XA transaction branches.
+type XARecoverExec struct {
+	baseExecutor
+
+	convertXID bool
+	done       bool
+}
+
+// Next implements the Executor Next interface.
+func (e *XARecoverExec) Next(ctx context.Context, req *chunk.Chunk) error {
+	req.Reset()
+	if e.done {
+		return nil
+	}
+	e.done = true
+	var where string
+	var args []interface{}
+	// Users without XA_RECOVER_ADMIN can only see their own branches.
+	checker := privilege.GetPrivilegeManager(e.ctx)
+	vars := e.ctx.GetSessionVars()
+	if checker != nil && !checker.RequestDynamicVerification(vars.ActiveRoles, "XA_RECOVER_ADMIN", false) {
+		var user, host string
+		if vars.User != nil {
+			user, host = vars.User.Username, vars.User.Hostname
+		}
+		where = " WHERE user = %? AND host = %?"
+		args = []interface{}{user, host}
+	}
+	branches, err := loadXABranches(ctx, e.ctx, where, args...)
+	if err != nil {
+		return err
+	}
+	for _, b := range branches {
+		req.AppendInt64(0, int64(b.xid.FormatID))
+		req.AppendInt64(1, int64(len(b.xid.GTRID)))
+		req.AppendInt64(2, int64(len(b.xid.BQual)))
+		data := b.xid.GTRID + b.xid.BQual
+		if e.convertXID {
+			data = "0x" + strings.ToUpper(hex.EncodeToString([]byte(data)))
+		}
+		req.AppendString(3, data)
+	}
+	return nil
+}
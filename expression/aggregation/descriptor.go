@@ -40,6 +40,9 @@ type AggFuncDesc struct {
 	HasDistinct bool
 	// OrderByItems represents the order by clause used in GROUP_CONCAT
 	OrderByItems []*util.ByItems
+	// IgnoreNull and FromLast are only used by the window functions, see WindowFuncDesc.
+	IgnoreNull bool
+	FromLast   bool
 }
 
 // NewAggFuncDesc creates an aggregation function signature descriptor.
@@ -53,11 +56,21 @@ func NewAggFuncDesc(ctx sessionctx.Context, name string, args []expression.Expre
 }
 
 // NewAggFuncDescForWindowFunc creates an aggregation function from window functions, where baseFuncDesc may be ready.
-func NewAggFuncDescForWindowFunc(ctx sessionctx.Context, Desc *WindowFuncDesc, hasDistinct bool) (*AggFuncDesc, error) {
-	if Desc.RetTp == nil { // safety check
-		return NewAggFuncDesc(ctx, Desc.Name, Desc.Args, hasDistinct)
+func NewAggFuncDescForWindowFunc(ctx sessionctx.Context, desc *WindowFuncDesc) (*AggFuncDesc, error) {
+	var aggDesc *AggFuncDesc
+	if desc.RetTp == nil { // safety check
+		var err error
+		aggDesc, err = NewAggFuncDesc(ctx, desc.Name, desc.Args, desc.HasDistinct)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		aggDesc = &AggFuncDesc{baseFuncDesc: baseFuncDesc{desc.Name, desc.Args, desc.RetTp}, HasDistinct: desc.HasDistinct}
 	}
-	return &AggFuncDesc{baseFuncDesc: baseFuncDesc{Desc.Name, Desc.Args, Desc.RetTp}, HasDistinct: hasDistinct}, nil
+	aggDesc.OrderByItems = desc.OrderByItems
+	aggDesc.IgnoreNull = desc.IgnoreNull
+	aggDesc.FromLast = desc.FromLast
+	return aggDesc, nil
 }
 
 // String implements the fmt.Stringer interface.
@@ -15,16 +15,27 @@
 package aggregation
 
 import (
+	"bytes"
 	"strings"
 
 	"github.com/pingcap/tidb/expression"
 	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/planner/util"
 	"github.com/pingcap/tidb/sessionctx"
 )
 
 // WindowFuncDesc describes a window function signature, only used in planner.
 type WindowFuncDesc struct {
 	baseFuncDesc
+	// HasDistinct represents whether the aggregate window function contains distinct attribute.
+	HasDistinct bool
+	// IgnoreNull represents whether the null values are skipped, it's used by
+	// `first_value`, `last_value`, `nth_value`, `lead` and `lag`.
+	IgnoreNull bool
+	// FromLast represents whether `nth_value` counts rows from the last row of the frame.
+	FromLast bool
+	// OrderByItems represents the order by clause used in GROUP_CONCAT.
+	OrderByItems []*util.ByItems
 }
 
 // NewWindowFuncDesc creates a window function signature descriptor.
@@ -55,7 +66,39 @@ func NewWindowFuncDesc(ctx sessionctx.Context, name string, args []expression.Ex
 	if err != nil {
 		return nil, err
 	}
-	return &WindowFuncDesc{base}, nil
+	return &WindowFuncDesc{baseFuncDesc: base}, nil
+}
+
+// String implements the fmt.Stringer interface.
+func (s *WindowFuncDesc) String() string {
+	buffer := bytes.NewBufferString(s.Name)
+	buffer.WriteString("(")
+	if s.HasDistinct {
+		buffer.WriteString("distinct ")
+	}
+	for i, arg := range s.Args {
+		buffer.WriteString(arg.String())
+		if i+1 != len(s.Args) {
+			buffer.WriteString(", ")
+		}
+	}
+	if len(s.OrderByItems) > 0 {
+		buffer.WriteString(" order by ")
+	}
+	for i, item := range s.OrderByItems {
+		buffer.WriteString(item.String())
+		if i+1 != len(s.OrderByItems) {
+			buffer.WriteString(", ")
+		}
+	}
+	buffer.WriteString(")")
+	if s.FromLast {
+		buffer.WriteString(" from last")
+	}
+	if s.IgnoreNull {
+		buffer.WriteString(" ignore nulls")
+	}
+	return buffer.String()
 }
 
 // noFrameWindowFuncs is the functions that operate on the entire partition,
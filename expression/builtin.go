@@ -39,6 +39,7 @@ import (
 	"github.com/pingcap/tidb/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/types/json"
+	"github.com/pingcap/tidb/types/spatial"
 	"github.com/pingcap/tidb/util/chunk"
 	"github.com/pingcap/tidb/util/collate"
 	"github.com/pingcap/tipb/go-tipb"
@@ -711,6 +712,10 @@ var funcs = map[string]functionClass{
 	ast.Ord:             &ordFunctionClass{baseFunctionClass{ast.Ord, 1, 1}},
 	ast.Position:        &locateFunctionClass{baseFunctionClass{ast.Position, 2, 2}},
 	ast.Quote:           &quoteFunctionClass{baseFunctionClass{ast.Quote, 1, 1}},
+	ast.RegexpInStr:     &regexpInStrFunctionClass{baseFunctionClass{ast.RegexpInStr, 2, 6}},
+	ast.RegexpLike:      &regexpLikeFunctionClass{baseFunctionClass{ast.RegexpLike, 2, 3}},
+	ast.RegexpReplace:   &regexpReplaceFunctionClass{baseFunctionClass{ast.RegexpReplace, 3, 6}},
+	ast.RegexpSubstr:    &regexpSubstrFunctionClass{baseFunctionClass{ast.RegexpSubstr, 2, 5}},
 	ast.Repeat:          &repeatFunctionClass{baseFunctionClass{ast.Repeat, 2, 2}},
 	ast.Replace:         &replaceFunctionClass{baseFunctionClass{ast.Replace, 3, 3}},
 	ast.Reverse:         &reverseFunctionClass{baseFunctionClass{ast.Reverse, 1, 1}},
@@ -856,6 +861,8 @@ var funcs = map[string]functionClass{
 	ast.JSONObject:        &jsonObjectFunctionClass{baseFunctionClass{ast.JSONObject, 0, -1}},
 	ast.JSONArray:         &jsonArrayFunctionClass{baseFunctionClass{ast.JSONArray, 0, -1}},
 	ast.JSONContains:      &jsonContainsFunctionClass{baseFunctionClass{ast.JSONContains, 2, 3}},
+	ast.JSONMemberOf:      &jsonMemberOfFunctionClass{baseFunctionClass{ast.JSONMemberOf, 2, 2}},
+	ast.JSONOverlaps:      &jsonOverlapsFunctionClass{baseFunctionClass{ast.JSONOverlaps, 2, 2}},
 	ast.JSONContainsPath:  &jsonContainsPathFunctionClass{baseFunctionClass{ast.JSONContainsPath, 3, -1}},
 	ast.JSONValid:         &jsonValidFunctionClass{baseFunctionClass{ast.JSONValid, 1, 1}},
 	ast.JSONArrayAppend:   &jsonArrayAppendFunctionClass{baseFunctionClass{ast.JSONArrayAppend, 3, -1}},
@@ -882,6 +889,51 @@ var funcs = map[string]functionClass{
 	ast.NextVal: &nextValFunctionClass{baseFunctionClass{ast.NextVal, 1, 1}},
 	ast.LastVal: &lastValFunctionClass{baseFunctionClass{ast.LastVal, 1, 1}},
 	ast.SetVal:  &setValFunctionClass{baseFunctionClass{ast.SetVal, 2, 2}},
+
+	// Full-text search function.
+	ast.FullTextMatch: &fullTextMatchFunctionClass{baseFunctionClass{ast.FullTextMatch, 4, -1}},
+
+	// spatial functions
+	ast.STGeomFromText:       &geomFromTextFunctionClass{baseFunctionClass{ast.STGeomFromText, 1, 3}, spatial.TypeGeometry},
+	ast.STGeometryFromText:   &geomFromTextFunctionClass{baseFunctionClass{ast.STGeometryFromText, 1, 3}, spatial.TypeGeometry},
+	ast.STPointFromText:      &geomFromTextFunctionClass{baseFunctionClass{ast.STPointFromText, 1, 3}, spatial.TypePoint},
+	ast.STLineFromText:       &geomFromTextFunctionClass{baseFunctionClass{ast.STLineFromText, 1, 3}, spatial.TypeLineString},
+	ast.STLineStringFromText: &geomFromTextFunctionClass{baseFunctionClass{ast.STLineStringFromText, 1, 3}, spatial.TypeLineString},
+	ast.STPolyFromText:       &geomFromTextFunctionClass{baseFunctionClass{ast.STPolyFromText, 1, 3}, spatial.TypePolygon},
+	ast.STPolygonFromText:    &geomFromTextFunctionClass{baseFunctionClass{ast.STPolygonFromText, 1, 3}, spatial.TypePolygon},
+	ast.STGeomFromWKB:        &geomFromWKBFunctionClass{baseFunctionClass{ast.STGeomFromWKB, 1, 3}},
+	ast.STGeometryFromWKB:    &geomFromWKBFunctionClass{baseFunctionClass{ast.STGeometryFromWKB, 1, 3}},
+	ast.STGeomFromGeoJSON:    &geomFromGeoJSONFunctionClass{baseFunctionClass{ast.STGeomFromGeoJSON, 1, 3}},
+	ast.Point:                &pointFunctionClass{baseFunctionClass{ast.Point, 2, 2}},
+	ast.LineString:           &geomConstructorFunctionClass{baseFunctionClass{ast.LineString, 1, -1}, spatial.TypeLineString},
+	ast.Polygon:              &geomConstructorFunctionClass{baseFunctionClass{ast.Polygon, 1, -1}, spatial.TypePolygon},
+	ast.MultiPoint:           &geomConstructorFunctionClass{baseFunctionClass{ast.MultiPoint, 1, -1}, spatial.TypeMultiPoint},
+	ast.MultiLineString:      &geomConstructorFunctionClass{baseFunctionClass{ast.MultiLineString, 1, -1}, spatial.TypeMultiLineString},
+	ast.MultiPolygon:         &geomConstructorFunctionClass{baseFunctionClass{ast.MultiPolygon, 1, -1}, spatial.TypeMultiPolygon},
+	ast.GeomCollection:       &geomConstructorFunctionClass{baseFunctionClass{ast.GeomCollection, 0, -1}, spatial.TypeGeometryCollection},
+	ast.GeometryCollection:   &geomConstructorFunctionClass{baseFunctionClass{ast.GeometryCollection, 0, -1}, spatial.TypeGeometryCollection},
+	ast.STAsText:             &asTextFunctionClass{baseFunctionClass{ast.STAsText, 1, 2}},
+	ast.STAsWKT:              &asTextFunctionClass{baseFunctionClass{ast.STAsWKT, 1, 2}},
+	ast.STAsBinary:           &asBinaryFunctionClass{baseFunctionClass{ast.STAsBinary, 1, 2}},
+	ast.STAsWKB:              &asBinaryFunctionClass{baseFunctionClass{ast.STAsWKB, 1, 2}},
+	ast.STAsGeoJSON:          &asGeoJSONFunctionClass{baseFunctionClass{ast.STAsGeoJSON, 1, 3}},
+	ast.STSRID:               &stSRIDFunctionClass{baseFunctionClass{ast.STSRID, 1, 2}},
+	ast.STX:                  &stCoordFunctionClass{baseFunctionClass{ast.STX, 1, 1}, false},
+	ast.STY:                  &stCoordFunctionClass{baseFunctionClass{ast.STY, 1, 1}, true},
+	ast.STGeometryType:       &stGeometryTypeFunctionClass{baseFunctionClass{ast.STGeometryType, 1, 1}},
+	ast.STEnvelope:           &stEnvelopeFunctionClass{baseFunctionClass{ast.STEnvelope, 1, 1}},
+	ast.STArea:               &geomMeasureFunctionClass{baseFunctionClass{ast.STArea, 1, 1}, spatial.Area},
+	ast.STLength:             &geomMeasureFunctionClass{baseFunctionClass{ast.STLength, 1, 1}, spatial.Length},
+	ast.STContains:           &spatialRelationFunctionClass{baseFunctionClass{ast.STContains, 2, 2}, spatial.Contains},
+	ast.STWithin:             &spatialRelationFunctionClass{baseFunctionClass{ast.STWithin, 2, 2}, spatial.Within},
+	ast.STIntersects:         &spatialRelationFunctionClass{baseFunctionClass{ast.STIntersects, 2, 2}, spatial.Intersects},
+	ast.STDisjoint:           &spatialRelationFunctionClass{baseFunctionClass{ast.STDisjoint, 2, 2}, spatial.Disjoint},
+	ast.STEquals:             &spatialRelationFunctionClass{baseFunctionClass{ast.STEquals, 2, 2}, spatial.Equals},
+	ast.MBRContains:          &spatialRelationFunctionClass{baseFunctionClass{ast.MBRContains, 2, 2}, mbrContains},
+	ast.MBRWithin:            &spatialRelationFunctionClass{baseFunctionClass{ast.MBRWithin, 2, 2}, mbrWithin},
+	ast.MBRIntersects:        &spatialRelationFunctionClass{baseFunctionClass{ast.MBRIntersects, 2, 2}, mbrIntersects},
+	ast.STDistance:           &stDistanceFunctionClass{baseFunctionClass{ast.STDistance, 2, 2}},
+	ast.STDistanceSphere:     &stDistanceSphereFunctionClass{baseFunctionClass{ast.STDistanceSphere, 2, 3}},
 }
 
 // IsFunctionSupported check if given function name is a builtin sql function.
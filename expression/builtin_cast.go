@@ -23,6 +23,7 @@
 package expression
 
 import (
+	"fmt"
 	"math"
 	"strconv"
 	"strings"
@@ -412,6 +413,84 @@ func (c *castAsDurationFunctionClass) getFunction(ctx sessionctx.Context, args [
 	return sig, nil
 }
 
+type castJSONAsArrayFunctionClass struct {
+	baseFunctionClass
+
+	tp *types.FieldType
+}
+
+func (c *castJSONAsArrayFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (sig builtinFunc, err error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	if args[0].GetType().EvalType() != types.ETJson || args[0].GetType().Array {
+		return nil, ErrNotSupportedYet.GenWithStackByArgs("CAST-ing Non-JSON Array type to array")
+	}
+	switch c.tp.Tp {
+	case mysql.TypeLonglong, mysql.TypeDouble:
+	case mysql.TypeVarString, mysql.TypeString:
+		if c.tp.Flen == types.UnspecifiedLength {
+			return nil, ErrNotSupportedYet.GenWithStackByArgs("CAST-ing data to array of char/binary without length")
+		}
+	default:
+		return nil, ErrNotSupportedYet.GenWithStackByArgs(fmt.Sprintf("CAST-ing data to array of %s", types.TypeStr(c.tp.Tp)))
+	}
+	bf, err := newBaseBuiltinFunc(ctx, c.funcName, args, types.ETJson)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp = c.tp
+	elemTp := c.tp.Clone()
+	elemTp.Array = false
+	sig = &builtinCastJSONAsArraySig{bf, elemTp}
+	return sig, nil
+}
+
+// builtinCastJSONAsArraySig converts the elements of the JSON array into the element type and returns them
+// as a JSON array, it's only used by the hidden column of multi-valued index.
+type builtinCastJSONAsArraySig struct {
+	baseBuiltinFunc
+
+	elemTp *types.FieldType
+}
+
+func (b *builtinCastJSONAsArraySig) Clone() builtinFunc {
+	newSig := &builtinCastJSONAsArraySig{elemTp: b.elemTp}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastJSONAsArraySig) evalJSON(row chunk.Row) (res json.BinaryJSON, isNull bool, err error) {
+	val, isNull, err := b.args[0].EvalJSON(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	if val.TypeCode == json.TypeCodeObject {
+		return res, false, ErrNotSupportedYet.GenWithStackByArgs("CAST-ing JSON OBJECT type to array")
+	}
+	elems := []json.BinaryJSON{val}
+	if val.TypeCode == json.TypeCodeArray {
+		elems = make([]json.BinaryJSON, 0, val.GetElemCount())
+		for i := 0; i < val.GetElemCount(); i++ {
+			elems = append(elems, val.ArrayGetElem(i))
+		}
+	}
+	sc := b.ctx.GetSessionVars().StmtCtx
+	arr := make([]interface{}, 0, len(elems))
+	for _, elem := range elems {
+		d, err := types.ConvertJSONToArrayElement(sc, elem, b.elemTp)
+		if err != nil {
+			return res, false, err
+		}
+		j, err := d.ToMysqlJSON()
+		if err != nil {
+			return res, false, err
+		}
+		arr = append(arr, j)
+	}
+	return json.CreateBinary(arr), false, nil
+}
+
 type castAsJSONFunctionClass struct {
 	baseFunctionClass
 
@@ -1848,6 +1927,14 @@ func BuildCastCollationFunction(ctx sessionctx.Context, expr Expression, ec *Exp
 
 // BuildCastFunction builds a CAST ScalarFunction from the Expression.
 func BuildCastFunction(ctx sessionctx.Context, expr Expression, tp *types.FieldType) (res Expression) {
+	res, err := BuildCastFunctionWithCheck(ctx, expr, tp)
+	terror.Log(err)
+	return
+}
+
+// BuildCastFunctionWithCheck builds a CAST ScalarFunction from the Expression and returns the error if the
+// cast is not supported.
+func BuildCastFunctionWithCheck(ctx sessionctx.Context, expr Expression, tp *types.FieldType) (res Expression, err error) {
 	argType := expr.GetType()
 	// If source argument's nullable, then target type should be nullable
 	if !mysql.HasNotNullFlag(argType.Flag) {
@@ -1867,7 +1954,11 @@ func BuildCastFunction(ctx sessionctx.Context, expr Expression, tp *types.FieldT
 	case types.ETDuration:
 		fc = &castAsDurationFunctionClass{baseFunctionClass{ast.Cast, 1, 1}, tp}
 	case types.ETJson:
-		fc = &castAsJSONFunctionClass{baseFunctionClass{ast.Cast, 1, 1}, tp}
+		if tp.Array {
+			fc = &castJSONAsArrayFunctionClass{baseFunctionClass{ast.Cast, 1, 1}, tp}
+		} else {
+			fc = &castAsJSONFunctionClass{baseFunctionClass{ast.Cast, 1, 1}, tp}
+		}
 	case types.ETString:
 		fc = &castAsStringFunctionClass{baseFunctionClass{ast.Cast, 1, 1}, tp}
 		if expr.GetType().Tp == mysql.TypeBit {
@@ -1875,7 +1966,6 @@ func BuildCastFunction(ctx sessionctx.Context, expr Expression, tp *types.FieldT
 		}
 	}
 	f, err := fc.getFunction(ctx, []Expression{expr})
-	terror.Log(err)
 	res = &ScalarFunction{
 		FuncName: model.NewCIStr(ast.Cast),
 		RetType:  tp,
@@ -1887,7 +1977,7 @@ func BuildCastFunction(ctx sessionctx.Context, expr Expression, tp *types.FieldT
 	if tp.EvalType() != types.ETJson {
 		res = FoldConstant(res)
 	}
-	return res
+	return res, err
 }
 
 // WrapWithCastAsInt wraps `expr` with `cast` if the return type of expr is not
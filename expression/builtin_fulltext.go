@@ -0,0 +1,96 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/fulltext"
+)
+
+var (
+	_ functionClass = &fullTextMatchFunctionClass{}
+)
+
+var (
+	_ builtinFunc = &builtinFullTextMatchSig{}
+)
+
+// fullTextMatchFunctionClass is the function class of MATCH (col1, col2, ...) AGAINST (expr [search_modifier]).
+// The arguments are the columns, the search string, the search mode and the parser of the FULLTEXT index.
+type fullTextMatchFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *fullTextMatchFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := make([]types.EvalType, 0, len(args))
+	for range args {
+		argTps = append(argTps, types.ETString)
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETReal, argTps...)
+	if err != nil {
+		return nil, err
+	}
+	sig := &builtinFullTextMatchSig{bf}
+	return sig, nil
+}
+
+type builtinFullTextMatchSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinFullTextMatchSig) Clone() builtinFunc {
+	newSig := &builtinFullTextMatchSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalReal evals the relevance of the row to the search string, 0 means the row doesn't match.
+// See https://dev.mysql.com/doc/refman/8.0/en/fulltext-search.html
+func (b *builtinFullTextMatchSig) evalReal(row chunk.Row) (float64, bool, error) {
+	colCnt := len(b.args) - 3
+	texts := make([]string, 0, colCnt)
+	for _, arg := range b.args[:colCnt] {
+		text, isNull, err := arg.EvalString(b.ctx, row)
+		if err != nil {
+			return 0, true, err
+		}
+		if !isNull {
+			texts = append(texts, text)
+		}
+	}
+	against, isNull, err := b.args[colCnt].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return 0, err != nil, err
+	}
+	mode, _, err := b.args[colCnt+1].EvalString(b.ctx, row)
+	if err != nil {
+		return 0, true, err
+	}
+	parser, _, err := b.args[colCnt+2].EvalString(b.ctx, row)
+	if err != nil {
+		return 0, true, err
+	}
+	tk, err := fulltext.NewTokenizer(parser)
+	if err != nil {
+		return 0, true, err
+	}
+	query := fulltext.ParseQuery(tk, against, mode == fulltext.ModeBoolean)
+	return query.Score(texts...), false, nil
+}
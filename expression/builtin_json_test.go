@@ -467,6 +467,75 @@ func TestJSONContains(t *testing.T) {
 	}
 }
 
+func TestJSONMemberOf(t *testing.T) {
+	ctx := createContext(t)
+	fc := funcs[ast.JSONMemberOf]
+	tbl := []struct {
+		input    []interface{}
+		expected interface{}
+	}{
+		{[]interface{}{nil, `[1, 2]`}, nil},
+		{[]interface{}{1, nil}, nil},
+		{[]interface{}{1, `[1, 2]`}, 1},
+		{[]interface{}{3, `[1, 2]`}, 0},
+		{[]interface{}{"a", `["a", "b"]`}, 1},
+		{[]interface{}{"[1]", `[[1], 2]`}, 0},
+		{[]interface{}{1.5, `[1.5]`}, 1},
+		{[]interface{}{1, `1`}, 1},
+		{[]interface{}{1, `"1"`}, 0},
+	}
+	for _, tt := range tbl {
+		f, err := fc.getFunction(ctx, datumsToConstants(types.MakeDatums(tt.input...)))
+		require.NoError(t, err)
+		d, err := evalBuiltinFunc(f, chunk.Row{})
+		require.NoError(t, err)
+		if tt.expected == nil {
+			require.True(t, d.IsNull())
+		} else {
+			require.Equal(t, int64(tt.expected.(int)), d.GetInt64())
+		}
+	}
+	_, err := fc.getFunction(ctx, datumsToConstants(types.MakeDatums(1, 1)))
+	require.True(t, json.ErrInvalidJSONData.Equal(err))
+}
+
+func TestJSONOverlaps(t *testing.T) {
+	ctx := createContext(t)
+	fc := funcs[ast.JSONOverlaps]
+	tbl := []struct {
+		input    []interface{}
+		expected interface{}
+		err      error
+	}{
+		{[]interface{}{nil, `[1, 2]`}, nil, nil},
+		{[]interface{}{`[1, 2]`, nil}, nil, nil},
+		{[]interface{}{`[1, 2]`, `[2, 3]`}, 1, nil},
+		{[]interface{}{`[1, 2]`, `[3, 4]`}, 0, nil},
+		{[]interface{}{`[1, 2]`, `2`}, 1, nil},
+		{[]interface{}{`{"a": 1, "b": 2}`, `{"b": 2}`}, 1, nil},
+		{[]interface{}{`{"a": 1}`, `{"a": 2}`}, 0, nil},
+		{[]interface{}{`1`, `1`}, 1, nil},
+		{[]interface{}{`[1, 2]`, `a:1`}, nil, json.ErrInvalidJSONText},
+	}
+	for _, tt := range tbl {
+		f, err := fc.getFunction(ctx, datumsToConstants(types.MakeDatums(tt.input...)))
+		require.NoError(t, err)
+		d, err := evalBuiltinFunc(f, chunk.Row{})
+		if tt.err != nil {
+			require.True(t, tt.err.(*terror.Error).Equal(err))
+			continue
+		}
+		require.NoError(t, err)
+		if tt.expected == nil {
+			require.True(t, d.IsNull())
+		} else {
+			require.Equal(t, int64(tt.expected.(int)), d.GetInt64())
+		}
+	}
+	_, err := fc.getFunction(ctx, datumsToConstants(types.MakeDatums(1, `[1]`)))
+	require.True(t, json.ErrInvalidJSONData.Equal(err))
+}
+
 func TestJSONContainsPath(t *testing.T) {
 	ctx := createContext(t)
 	fc := funcs[ast.JSONContainsPath]
@@ -76,6 +76,14 @@ var vecBuiltinJSONCases = map[string][]vecExprBenchCase{
 		{retEvalType: types.ETInt, childrenTypes: []types.EvalType{types.ETJson, types.ETJson, types.ETString}, geners: []dataGenerator{nil, nil, &constStrGener{"$.abc"}}},
 		{retEvalType: types.ETInt, childrenTypes: []types.EvalType{types.ETJson, types.ETJson, types.ETString}, geners: []dataGenerator{nil, nil, &constStrGener{"$.key"}}},
 	},
+	ast.JSONMemberOf: {
+		{retEvalType: types.ETInt, childrenTypes: []types.EvalType{types.ETJson, types.ETJson}},
+		{retEvalType: types.ETInt, childrenTypes: []types.EvalType{types.ETJson, types.ETJson}, geners: []dataGenerator{nil, &constJSONGener{"[1, \"a\", [2], {\"b\": 3}]"}}},
+	},
+	ast.JSONOverlaps: {
+		{retEvalType: types.ETInt, childrenTypes: []types.EvalType{types.ETJson, types.ETJson}},
+		{retEvalType: types.ETInt, childrenTypes: []types.EvalType{types.ETJson, types.ETJson}, geners: []dataGenerator{nil, &constJSONGener{"[1, \"a\", [2], {\"b\": 3}]"}}},
+	},
 	ast.JSONObject: {
 		{
 			retEvalType: types.ETJson,
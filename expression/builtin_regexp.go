@@ -0,0 +1,510 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/pingcap/tidb/parser/charset"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/collate"
+	"github.com/pingcap/tipb/go-tipb"
+)
+
+var (
+	_ functionClass = &regexpLikeFunctionClass{}
+	_ functionClass = &regexpInStrFunctionClass{}
+	_ functionClass = &regexpSubstrFunctionClass{}
+	_ functionClass = &regexpReplaceFunctionClass{}
+)
+
+var (
+	_ builtinFunc = &builtinRegexpLikeSig{}
+	_ builtinFunc = &builtinRegexpInStrSig{}
+	_ builtinFunc = &builtinRegexpSubstrSig{}
+	_ builtinFunc = &builtinRegexpReplaceSig{}
+)
+
+const (
+	regexpInvalidMatchType    = "Invalid match type"
+	regexpIndexOutOfBounds    = "Index out of bounds in regular expression search"
+	regexpInvalidReturnOption = "Incorrect arguments to regexp_instr: return_option must be 1 or 0"
+)
+
+// regexpBaseFuncSig is the base of the REGEXP_LIKE, REGEXP_INSTR, REGEXP_SUBSTR and REGEXP_REPLACE signatures.
+// The positions are counted in bytes for the binary collation and in characters for the others.
+type regexpBaseFuncSig struct {
+	baseBuiltinFunc
+	// matchTypeIdx is the offset of the optional match_type argument.
+	matchTypeIdx int
+	binCollation bool
+	ciCollation  bool
+
+	// The compiled regexp is memorized when both the pattern and the match type are constant. The signature may be
+	// evaluated concurrently, so the regexp is compiled only once.
+	memorizedRegexp *regexp.Regexp
+	memorizedErr    error
+	memorizedOnce   sync.Once
+}
+
+func newRegexpBaseFuncSig(bf baseBuiltinFunc, matchTypeIdx int) regexpBaseFuncSig {
+	return regexpBaseFuncSig{
+		baseBuiltinFunc: bf,
+		matchTypeIdx:    matchTypeIdx,
+		binCollation:    bf.collation == charset.CollationBin,
+		ciCollation:     collate.IsCICollation(bf.collation),
+	}
+}
+
+// regexpBaseFromPB sets the collation of the regexp function decoded from the coprocessor request.
+// The collation of the return type can't tell the case sensitivity, so it is derived from the arguments again.
+func regexpBaseFromPB(ctx sessionctx.Context, bf baseBuiltinFunc, binSig bool) baseBuiltinFunc {
+	if binSig {
+		bf.SetCharsetAndCollation(charset.CharsetBin, charset.CollationBin)
+		return bf
+	}
+	bf.SetCharsetAndCollation(DeriveCollationFromExprs(ctx, bf.args[0], bf.args[1]))
+	return bf
+}
+
+func (re *regexpBaseFuncSig) clone(from *regexpBaseFuncSig) {
+	re.cloneFrom(&from.baseBuiltinFunc)
+	re.matchTypeIdx = from.matchTypeIdx
+	re.binCollation = from.binCollation
+	re.ciCollation = from.ciCollation
+}
+
+func (re *regexpBaseFuncSig) setRegexpPbCode(binSig, utf8Sig tipb.ScalarFuncSig) {
+	if re.binCollation {
+		re.setPbCode(binSig)
+	} else {
+		re.setPbCode(utf8Sig)
+	}
+}
+
+// buildRegexpFlags converts the MySQL match type to the flags of the Go regexp syntax.
+// The case sensitivity follows the collation unless it is overridden by 'c' or 'i', the rightmost one wins.
+// The binary strings are always compared in case-sensitive fashion.
+func (re *regexpBaseFuncSig) buildRegexpFlags(matchType string) (string, error) {
+	ci := re.ciCollation && !re.binCollation
+	multiLine, dotAll := false, false
+	for _, c := range matchType {
+		switch c {
+		case 'c':
+			ci = false
+		case 'i':
+			ci = !re.binCollation
+		case 'm':
+			multiLine = true
+		case 'n':
+			dotAll = true
+		case 'u':
+			// Go regexp only recognizes '\n' as the line terminator, which is the Unix-only line ending mode.
+		default:
+			return "", ErrRegexp.GenWithStackByArgs(regexpInvalidMatchType)
+		}
+	}
+	var flags strings.Builder
+	if ci {
+		flags.WriteByte('i')
+	}
+	if multiLine {
+		flags.WriteByte('m')
+	}
+	if dotAll {
+		flags.WriteByte('s')
+	}
+	if flags.Len() == 0 {
+		return "", nil
+	}
+	return "(?" + flags.String() + ")", nil
+}
+
+func (re *regexpBaseFuncSig) compile(pat, matchType string) (*regexp.Regexp, error) {
+	flags, err := re.buildRegexpFlags(matchType)
+	if err != nil {
+		return nil, err
+	}
+	reg, err := regexp.Compile(flags + pat)
+	if err != nil {
+		return nil, ErrRegexp.GenWithStackByArgs(err.Error())
+	}
+	return reg, nil
+}
+
+func (re *regexpBaseFuncSig) canMemorize() bool {
+	sc := re.ctx.GetSessionVars().StmtCtx
+	if !re.args[1].ConstItem(sc) {
+		return false
+	}
+	return re.matchTypeIdx >= len(re.args) || re.args[re.matchTypeIdx].ConstItem(sc)
+}
+
+func (re *regexpBaseFuncSig) getRegexp(pat, matchType string) (*regexp.Regexp, error) {
+	if !re.canMemorize() {
+		return re.compile(pat, matchType)
+	}
+	re.memorizedOnce.Do(func() {
+		re.memorizedRegexp, re.memorizedErr = re.compile(pat, matchType)
+	})
+	return re.memorizedRegexp, re.memorizedErr
+}
+
+func (re *regexpBaseFuncSig) evalOptionalString(row chunk.Row, idx int) (string, bool, error) {
+	if idx >= len(re.args) {
+		return "", false, nil
+	}
+	return re.args[idx].EvalString(re.ctx, row)
+}
+
+func (re *regexpBaseFuncSig) evalOptionalInt(row chunk.Row, idx int, defaultVal int64) (int64, bool, error) {
+	if idx >= len(re.args) {
+		return defaultVal, false, nil
+	}
+	return re.args[idx].EvalInt(re.ctx, row)
+}
+
+// evalRegexpAndExpr evaluates the first argument and the regexp built by the pattern and the match type.
+func (re *regexpBaseFuncSig) evalRegexpAndExpr(row chunk.Row) (*regexp.Regexp, string, bool, error) {
+	expr, isNull, err := re.args[0].EvalString(re.ctx, row)
+	if isNull || err != nil {
+		return nil, "", true, err
+	}
+	pat, isNull, err := re.args[1].EvalString(re.ctx, row)
+	if isNull || err != nil {
+		return nil, "", true, err
+	}
+	matchType, isNull, err := re.evalOptionalString(row, re.matchTypeIdx)
+	if isNull || err != nil {
+		return nil, "", true, err
+	}
+	reg, err := re.getRegexp(pat, matchType)
+	if err != nil {
+		return nil, "", true, err
+	}
+	return reg, expr, false, nil
+}
+
+// locateStart returns the byte offset of the 1-based position pos of expr.
+func (re *regexpBaseFuncSig) locateStart(expr string, pos int64) (int, error) {
+	length := int64(len(expr))
+	if !re.binCollation {
+		length = int64(utf8.RuneCountInString(expr))
+	}
+	// The position 1 is valid for the empty string.
+	if pos < 1 || (pos > length && pos != 1) {
+		return 0, ErrRegexp.GenWithStackByArgs(regexpIndexOutOfBounds)
+	}
+	if re.binCollation {
+		return int(pos - 1), nil
+	}
+	offset := 0
+	for i := int64(1); i < pos; i++ {
+		_, size := utf8.DecodeRuneInString(expr[offset:])
+		offset += size
+	}
+	return offset, nil
+}
+
+// toPosition converts the byte offset of expr to the 1-based position.
+func (re *regexpBaseFuncSig) toPosition(expr string, offset int) int64 {
+	if re.binCollation {
+		return int64(offset) + 1
+	}
+	return int64(utf8.RuneCountInString(expr[:offset])) + 1
+}
+
+// findOccurrence returns the byte offsets of the occurrence-th match of reg in expr starting from pos.
+func (re *regexpBaseFuncSig) findOccurrence(reg *regexp.Regexp, expr string, pos, occurrence int64) ([]int, error) {
+	start, err := re.locateStart(expr, pos)
+	if err != nil {
+		return nil, err
+	}
+	if occurrence < 1 {
+		occurrence = 1
+	}
+	matches := reg.FindAllStringSubmatchIndex(expr[start:], int(occurrence))
+	if int64(len(matches)) < occurrence {
+		return nil, nil
+	}
+	match := matches[occurrence-1]
+	for i := range match {
+		if match[i] >= 0 {
+			match[i] += start
+		}
+	}
+	return match, nil
+}
+
+func (re *regexpBaseFuncSig) instr(reg *regexp.Regexp, expr string, pos, occurrence, returnOption int64) (int64, error) {
+	if returnOption != 0 && returnOption != 1 {
+		return 0, ErrRegexp.GenWithStackByArgs(regexpInvalidReturnOption)
+	}
+	match, err := re.findOccurrence(reg, expr, pos, occurrence)
+	if err != nil || match == nil {
+		return 0, err
+	}
+	return re.toPosition(expr, match[returnOption]), nil
+}
+
+func (re *regexpBaseFuncSig) substr(reg *regexp.Regexp, expr string, pos, occurrence int64) (string, bool, error) {
+	match, err := re.findOccurrence(reg, expr, pos, occurrence)
+	if err != nil || match == nil {
+		return "", true, err
+	}
+	return expr[match[0]:match[1]], false, nil
+}
+
+// replace replaces the occurrence-th match of reg in expr starting from pos, all the matches are replaced
+// if occurrence is 0. The replacement can refer to the capturing groups by $1, $2 and so on.
+func (re *regexpBaseFuncSig) replace(reg *regexp.Regexp, expr, repl string, pos, occurrence int64) (string, error) {
+	if occurrence < 1 {
+		start, err := re.locateStart(expr, pos)
+		if err != nil {
+			return "", err
+		}
+		return expr[:start] + reg.ReplaceAllString(expr[start:], repl), nil
+	}
+	match, err := re.findOccurrence(reg, expr, pos, occurrence)
+	if err != nil || match == nil {
+		return expr, err
+	}
+	dst := make([]byte, 0, len(expr)+len(repl))
+	dst = append(dst, expr[:match[0]]...)
+	dst = reg.ExpandString(dst, repl, expr, match)
+	dst = append(dst, expr[match[1]:]...)
+	return string(dst), nil
+}
+
+type regexpLikeFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *regexpLikeFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := []types.EvalType{types.ETString, types.ETString}
+	if len(args) == 3 {
+		argTps = append(argTps, types.ETString)
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETInt, argTps...)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.Flen = 1
+	sig := newBuiltinRegexpLikeSig(bf)
+	sig.setRegexpPbCode(tipb.ScalarFuncSig_RegexpLikeSig, tipb.ScalarFuncSig_RegexpLikeUTF8Sig)
+	return sig, nil
+}
+
+type builtinRegexpLikeSig struct {
+	regexpBaseFuncSig
+}
+
+func newBuiltinRegexpLikeSig(bf baseBuiltinFunc) *builtinRegexpLikeSig {
+	return &builtinRegexpLikeSig{newRegexpBaseFuncSig(bf, 2)}
+}
+
+func (b *builtinRegexpLikeSig) Clone() builtinFunc {
+	newSig := &builtinRegexpLikeSig{}
+	newSig.clone(&b.regexpBaseFuncSig)
+	return newSig
+}
+
+// evalInt evals `REGEXP_LIKE(expr, pat[, match_type])`.
+// See https://dev.mysql.com/doc/refman/8.0/en/regexp.html#function_regexp-like
+func (b *builtinRegexpLikeSig) evalInt(row chunk.Row) (int64, bool, error) {
+	reg, expr, isNull, err := b.evalRegexpAndExpr(row)
+	if isNull || err != nil {
+		return 0, true, err
+	}
+	return boolToInt64(reg.MatchString(expr)), false, nil
+}
+
+type regexpInStrFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *regexpInStrFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := []types.EvalType{types.ETString, types.ETString, types.ETInt, types.ETInt, types.ETInt, types.ETString}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETInt, argTps[:len(args)]...)
+	if err != nil {
+		return nil, err
+	}
+	sig := newBuiltinRegexpInStrSig(bf)
+	sig.setRegexpPbCode(tipb.ScalarFuncSig_RegexpInStrSig, tipb.ScalarFuncSig_RegexpInStrUTF8Sig)
+	return sig, nil
+}
+
+type builtinRegexpInStrSig struct {
+	regexpBaseFuncSig
+}
+
+func newBuiltinRegexpInStrSig(bf baseBuiltinFunc) *builtinRegexpInStrSig {
+	return &builtinRegexpInStrSig{newRegexpBaseFuncSig(bf, 5)}
+}
+
+func (b *builtinRegexpInStrSig) Clone() builtinFunc {
+	newSig := &builtinRegexpInStrSig{}
+	newSig.clone(&b.regexpBaseFuncSig)
+	return newSig
+}
+
+// evalInt evals `REGEXP_INSTR(expr, pat[, pos[, occurrence[, return_option[, match_type]]]])`.
+// See https://dev.mysql.com/doc/refman/8.0/en/regexp.html#function_regexp-instr
+func (b *builtinRegexpInStrSig) evalInt(row chunk.Row) (int64, bool, error) {
+	reg, expr, isNull, err := b.evalRegexpAndExpr(row)
+	if isNull || err != nil {
+		return 0, true, err
+	}
+	pos, isNull, err := b.evalOptionalInt(row, 2, 1)
+	if isNull || err != nil {
+		return 0, true, err
+	}
+	occurrence, isNull, err := b.evalOptionalInt(row, 3, 1)
+	if isNull || err != nil {
+		return 0, true, err
+	}
+	returnOption, isNull, err := b.evalOptionalInt(row, 4, 0)
+	if isNull || err != nil {
+		return 0, true, err
+	}
+	res, err := b.instr(reg, expr, pos, occurrence, returnOption)
+	if err != nil {
+		return 0, true, err
+	}
+	return res, false, nil
+}
+
+type regexpSubstrFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *regexpSubstrFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := []types.EvalType{types.ETString, types.ETString, types.ETInt, types.ETInt, types.ETString}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, argTps[:len(args)]...)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.Flen = args[0].GetType().Flen
+	SetBinFlagOrBinStr(args[0].GetType(), bf.tp)
+	sig := newBuiltinRegexpSubstrSig(bf)
+	sig.setRegexpPbCode(tipb.ScalarFuncSig_RegexpSubstrSig, tipb.ScalarFuncSig_RegexpSubstrUTF8Sig)
+	return sig, nil
+}
+
+type builtinRegexpSubstrSig struct {
+	regexpBaseFuncSig
+}
+
+func newBuiltinRegexpSubstrSig(bf baseBuiltinFunc) *builtinRegexpSubstrSig {
+	return &builtinRegexpSubstrSig{newRegexpBaseFuncSig(bf, 4)}
+}
+
+func (b *builtinRegexpSubstrSig) Clone() builtinFunc {
+	newSig := &builtinRegexpSubstrSig{}
+	newSig.clone(&b.regexpBaseFuncSig)
+	return newSig
+}
+
+// evalString evals `REGEXP_SUBSTR(expr, pat[, pos[, occurrence[, match_type]]])`.
+// See https://dev.mysql.com/doc/refman/8.0/en/regexp.html#function_regexp-substr
+func (b *builtinRegexpSubstrSig) evalString(row chunk.Row) (string, bool, error) {
+	reg, expr, isNull, err := b.evalRegexpAndExpr(row)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	pos, isNull, err := b.evalOptionalInt(row, 2, 1)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	occurrence, isNull, err := b.evalOptionalInt(row, 3, 1)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	return b.substr(reg, expr, pos, occurrence)
+}
+
+type regexpReplaceFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *regexpReplaceFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := []types.EvalType{types.ETString, types.ETString, types.ETString, types.ETInt, types.ETInt, types.ETString}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, argTps[:len(args)]...)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.Flen = types.UnspecifiedLength
+	SetBinFlagOrBinStr(args[0].GetType(), bf.tp)
+	sig := newBuiltinRegexpReplaceSig(bf)
+	sig.setRegexpPbCode(tipb.ScalarFuncSig_RegexpReplaceSig, tipb.ScalarFuncSig_RegexpReplaceUTF8Sig)
+	return sig, nil
+}
+
+type builtinRegexpReplaceSig struct {
+	regexpBaseFuncSig
+}
+
+func newBuiltinRegexpReplaceSig(bf baseBuiltinFunc) *builtinRegexpReplaceSig {
+	return &builtinRegexpReplaceSig{newRegexpBaseFuncSig(bf, 5)}
+}
+
+func (b *builtinRegexpReplaceSig) Clone() builtinFunc {
+	newSig := &builtinRegexpReplaceSig{}
+	newSig.clone(&b.regexpBaseFuncSig)
+	return newSig
+}
+
+// evalString evals `REGEXP_REPLACE(expr, pat, repl[, pos[, occurrence[, match_type]]])`.
+// See https://dev.mysql.com/doc/refman/8.0/en/regexp.html#function_regexp-replace
+func (b *builtinRegexpReplaceSig) evalString(row chunk.Row) (string, bool, error) {
+	reg, expr, isNull, err := b.evalRegexpAndExpr(row)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	repl, isNull, err := b.args[2].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	pos, isNull, err := b.evalOptionalInt(row, 3, 1)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	occurrence, isNull, err := b.evalOptionalInt(row, 4, 0)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	res, err := b.replace(reg, expr, repl, pos, occurrence)
+	if err != nil {
+		return "", true, err
+	}
+	return res, false, nil
+}
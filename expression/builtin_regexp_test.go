@@ -0,0 +1,147 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/terror"
+	"github.com/pingcap/tidb/testkit/testutil"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/stretchr/testify/require"
+)
+
+type regexpTestCase struct {
+	args   []interface{}
+	expect interface{}
+	err    error
+}
+
+func testRegexpFunction(t *testing.T, funcName string, tests []regexpTestCase) {
+	ctx := createContext(t)
+	for _, tt := range tests {
+		f, err := funcs[funcName].getFunction(ctx, datumsToConstants(types.MakeDatums(tt.args...)))
+		require.NoError(t, err)
+		res, err := evalBuiltinFunc(f, chunk.Row{})
+		comment := fmt.Sprintf("%s%v", funcName, tt.args)
+		if tt.err != nil {
+			require.True(t, terror.ErrorEqual(err, tt.err), comment)
+			continue
+		}
+		require.NoError(t, err, comment)
+		testutil.DatumEqual(t, types.NewDatum(tt.expect), res, comment)
+	}
+}
+
+func TestRegexpLike(t *testing.T) {
+	testRegexpFunction(t, ast.RegexpLike, []regexpTestCase{
+		{[]interface{}{"abc", "b"}, 1, nil},
+		{[]interface{}{"abc", "^b"}, 0, nil},
+		{[]interface{}{"abc", "B"}, 0, nil},
+		{[]interface{}{"abc", "B", "i"}, 1, nil},
+		{[]interface{}{"abc", "B", "ic"}, 0, nil},
+		{[]interface{}{"abc", "B", "ci"}, 1, nil},
+		{[]interface{}{"a\nb", "^b"}, 0, nil},
+		{[]interface{}{"a\nb", "^b", "m"}, 1, nil},
+		{[]interface{}{"a\nb", "a.b"}, 0, nil},
+		{[]interface{}{"a\nb", "a.b", "n"}, 1, nil},
+		{[]interface{}{"a\nb", "a.b", "nu"}, 1, nil},
+		{[]interface{}{nil, "a"}, nil, nil},
+		{[]interface{}{"a", nil}, nil, nil},
+		{[]interface{}{"a", "a", nil}, nil, nil},
+		{[]interface{}{"a", "a", "x"}, nil, ErrRegexp},
+		{[]interface{}{"a", "("}, nil, ErrRegexp},
+	})
+}
+
+func TestRegexpInStr(t *testing.T) {
+	testRegexpFunction(t, ast.RegexpInStr, []regexpTestCase{
+		{[]interface{}{"dog cat dog", "dog"}, 1, nil},
+		{[]interface{}{"dog cat dog", "dog", 2}, 9, nil},
+		{[]interface{}{"dog cat dog", "dog", 1, 2}, 9, nil},
+		{[]interface{}{"dog cat dog", "dog", 1, 3}, 0, nil},
+		{[]interface{}{"dog cat dog", "dog", 1, 1, 1}, 4, nil},
+		{[]interface{}{"dog cat dog", "DOG", 1, 2, 0, "i"}, 9, nil},
+		{[]interface{}{"aa aaa aaaa", "a{4}"}, 8, nil},
+		{[]interface{}{"你好世界", "世"}, 3, nil},
+		{[]interface{}{"你好世界", "世", 3, 1, 1}, 4, nil},
+		{[]interface{}{"", "^$"}, 1, nil},
+		{[]interface{}{"abc", "b", 0}, nil, ErrRegexp},
+		{[]interface{}{"abc", "b", 4}, nil, ErrRegexp},
+		{[]interface{}{"abc", "b", 1, 1, 2}, nil, ErrRegexp},
+		{[]interface{}{"abc", "b", nil}, nil, nil},
+	})
+}
+
+func TestRegexpSubstr(t *testing.T) {
+	testRegexpFunction(t, ast.RegexpSubstr, []regexpTestCase{
+		{[]interface{}{"abc def ghi", "[a-z]+"}, "abc", nil},
+		{[]interface{}{"abc def ghi", "[a-z]+", 1, 3}, "ghi", nil},
+		{[]interface{}{"abc def ghi", "[a-z]+", 5}, "def", nil},
+		{[]interface{}{"abc def ghi", "[a-z]+", 1, 4}, nil, nil},
+		{[]interface{}{"abc def ghi", "[A-Z]+", 1, 2, "i"}, "def", nil},
+		{[]interface{}{"你好世界", ".", 3}, "世", nil},
+		{[]interface{}{"abc", "x"}, nil, nil},
+		{[]interface{}{"abc", "b", 5}, nil, ErrRegexp},
+		{[]interface{}{nil, "b"}, nil, nil},
+	})
+}
+
+func TestRegexpReplace(t *testing.T) {
+	testRegexpFunction(t, ast.RegexpReplace, []regexpTestCase{
+		{[]interface{}{"a b c", "b", "X"}, "a X c", nil},
+		{[]interface{}{"abc def ghi", "[a-z]+", "X"}, "X X X", nil},
+		{[]interface{}{"abc def ghi", "[a-z]+", "X", 1, 2}, "abc X ghi", nil},
+		{[]interface{}{"abc def ghi", "[a-z]+", "X", 2}, "aX X X", nil},
+		{[]interface{}{"abc def ghi", "[a-z]+", "X", 1, 4}, "abc def ghi", nil},
+		{[]interface{}{"abc def", "([a-z]+) ([a-z]+)", "$2 $1"}, "def abc", nil},
+		{[]interface{}{"ABC abc", "abc", "X", 1, 0, "i"}, "X X", nil},
+		{[]interface{}{"你好世界", ".", "X", 3, 1}, "你好X界", nil},
+		{[]interface{}{"abc", "b", nil}, nil, nil},
+		{[]interface{}{"abc", "b", "X", 0}, nil, ErrRegexp},
+		{[]interface{}{"abc", "b", "X", 1, 0, "z"}, nil, ErrRegexp},
+	})
+}
+
+func TestRegexpConcurrentEval(t *testing.T) {
+	ctx := createContext(t)
+	// The signature is shared by the workers of the parallel projection, they memorize the regexp of the constant
+	// pattern concurrently.
+	f, err := funcs[ast.RegexpLike].getFunction(ctx, datumsToConstants(types.MakeDatums("abc", "B", "i")))
+	require.NoError(t, err)
+	const concurrency = 8
+	results := make([]types.Datum, concurrency)
+	errs := make([]error, concurrency)
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			results[i], errs[i] = evalBuiltinFunc(f, chunk.Row{})
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+	for i := 0; i < concurrency; i++ {
+		require.NoError(t, errs[i])
+		testutil.DatumEqual(t, types.NewDatum(1), results[i])
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"regexp"
+
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+)
+
+// vecEvalArgs evaluates all the arguments into the buffers, the buffers should be put back by putArgBufs.
+func (re *regexpBaseFuncSig) vecEvalArgs(input *chunk.Chunk) ([]*chunk.Column, error) {
+	bufs := make([]*chunk.Column, 0, len(re.args))
+	for _, arg := range re.args {
+		buf, err := re.bufAllocator.get()
+		if err != nil {
+			re.putArgBufs(bufs)
+			return nil, err
+		}
+		bufs = append(bufs, buf)
+		if arg.GetType().EvalType() == types.ETInt {
+			err = arg.VecEvalInt(re.ctx, input, buf)
+		} else {
+			err = arg.VecEvalString(re.ctx, input, buf)
+		}
+		if err != nil {
+			re.putArgBufs(bufs)
+			return nil, err
+		}
+	}
+	return bufs, nil
+}
+
+func (re *regexpBaseFuncSig) putArgBufs(bufs []*chunk.Column) {
+	for _, buf := range bufs {
+		re.bufAllocator.put(buf)
+	}
+}
+
+// getRegexpOfRow returns the regexp built by the pattern and the match type of the i-th row.
+func (re *regexpBaseFuncSig) getRegexpOfRow(bufs []*chunk.Column, i int) (*regexp.Regexp, error) {
+	matchType := ""
+	if re.matchTypeIdx < len(bufs) {
+		matchType = bufs[re.matchTypeIdx].GetString(i)
+	}
+	return re.getRegexp(bufs[1].GetString(i), matchType)
+}
+
+func getOptionalInt(bufs []*chunk.Column, idx, row int, defaultVal int64) int64 {
+	if idx >= len(bufs) {
+		return defaultVal
+	}
+	return bufs[idx].GetInt64(row)
+}
+
+func (b *builtinRegexpLikeSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinRegexpLikeSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	bufs, err := b.vecEvalArgs(input)
+	if err != nil {
+		return err
+	}
+	defer b.putArgBufs(bufs)
+
+	result.ResizeInt64(n, false)
+	result.MergeNulls(bufs...)
+	i64s := result.Int64s()
+	for i := 0; i < n; i++ {
+		if result.IsNull(i) {
+			continue
+		}
+		reg, err := b.getRegexpOfRow(bufs, i)
+		if err != nil {
+			return err
+		}
+		i64s[i] = boolToInt64(reg.MatchString(bufs[0].GetString(i)))
+	}
+	return nil
+}
+
+func (b *builtinRegexpInStrSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinRegexpInStrSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	bufs, err := b.vecEvalArgs(input)
+	if err != nil {
+		return err
+	}
+	defer b.putArgBufs(bufs)
+
+	result.ResizeInt64(n, false)
+	result.MergeNulls(bufs...)
+	i64s := result.Int64s()
+	for i := 0; i < n; i++ {
+		if result.IsNull(i) {
+			continue
+		}
+		reg, err := b.getRegexpOfRow(bufs, i)
+		if err != nil {
+			return err
+		}
+		pos := getOptionalInt(bufs, 2, i, 1)
+		occurrence := getOptionalInt(bufs, 3, i, 1)
+		returnOption := getOptionalInt(bufs, 4, i, 0)
+		i64s[i], err = b.instr(reg, bufs[0].GetString(i), pos, occurrence, returnOption)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *builtinRegexpSubstrSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinRegexpSubstrSig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	bufs, err := b.vecEvalArgs(input)
+	if err != nil {
+		return err
+	}
+	defer b.putArgBufs(bufs)
+
+	result.ReserveString(n)
+	for i := 0; i < n; i++ {
+		if hasNullInRow(bufs, i) {
+			result.AppendNull()
+			continue
+		}
+		reg, err := b.getRegexpOfRow(bufs, i)
+		if err != nil {
+			return err
+		}
+		pos := getOptionalInt(bufs, 2, i, 1)
+		occurrence := getOptionalInt(bufs, 3, i, 1)
+		res, isNull, err := b.substr(reg, bufs[0].GetString(i), pos, occurrence)
+		if err != nil {
+			return err
+		}
+		if isNull {
+			result.AppendNull()
+			continue
+		}
+		result.AppendString(res)
+	}
+	return nil
+}
+
+func (b *builtinRegexpReplaceSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinRegexpReplaceSig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	bufs, err := b.vecEvalArgs(input)
+	if err != nil {
+		return err
+	}
+	defer b.putArgBufs(bufs)
+
+	result.ReserveString(n)
+	for i := 0; i < n; i++ {
+		if hasNullInRow(bufs, i) {
+			result.AppendNull()
+			continue
+		}
+		reg, err := b.getRegexpOfRow(bufs, i)
+		if err != nil {
+			return err
+		}
+		pos := getOptionalInt(bufs, 3, i, 1)
+		occurrence := getOptionalInt(bufs, 4, i, 0)
+		res, err := b.replace(reg, bufs[0].GetString(i), bufs[2].GetString(i), pos, occurrence)
+		if err != nil {
+			return err
+		}
+		result.AppendString(res)
+	}
+	return nil
+}
+
+func hasNullInRow(bufs []*chunk.Column, row int) bool {
+	for _, buf := range bufs {
+		if buf.IsNull(row) {
+			return true
+		}
+	}
+	return false
+}
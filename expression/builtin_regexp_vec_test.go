@@ -0,0 +1,61 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/types"
+)
+
+var regexpPatternGener = newSelectStringGener([]string{"a", "[0-9]+", "^[a-z]", "[A-Z]{2}", "(a|b)c"})
+
+var regexpMatchTypeGener = newSelectStringGener([]string{"", "c", "i", "m", "n", "imn"})
+
+var vecBuiltinRegexpCases = map[string][]vecExprBenchCase{
+	ast.RegexpLike: {
+		{retEvalType: types.ETInt, childrenTypes: []types.EvalType{types.ETString, types.ETString},
+			geners: []dataGenerator{nil, regexpPatternGener}},
+		{retEvalType: types.ETInt, childrenTypes: []types.EvalType{types.ETString, types.ETString, types.ETString},
+			geners: []dataGenerator{nil, regexpPatternGener, regexpMatchTypeGener}},
+	},
+	ast.RegexpInStr: {
+		{retEvalType: types.ETInt, childrenTypes: []types.EvalType{types.ETString, types.ETString},
+			geners: []dataGenerator{nil, regexpPatternGener}},
+		{retEvalType: types.ETInt, childrenTypes: []types.EvalType{types.ETString, types.ETString, types.ETInt, types.ETInt, types.ETInt, types.ETString},
+			geners: []dataGenerator{nil, regexpPatternGener, newRangeInt64Gener(1, 10), newRangeInt64Gener(0, 3), newRangeInt64Gener(0, 2), regexpMatchTypeGener}},
+	},
+	ast.RegexpSubstr: {
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString, types.ETString},
+			geners: []dataGenerator{nil, regexpPatternGener}},
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString, types.ETString, types.ETInt, types.ETInt, types.ETString},
+			geners: []dataGenerator{nil, regexpPatternGener, newRangeInt64Gener(1, 10), newRangeInt64Gener(0, 3), regexpMatchTypeGener}},
+	},
+	ast.RegexpReplace: {
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString, types.ETString, types.ETString},
+			geners: []dataGenerator{nil, regexpPatternGener, nil}},
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString, types.ETString, types.ETString, types.ETInt, types.ETInt, types.ETString},
+			geners: []dataGenerator{nil, regexpPatternGener, nil, newRangeInt64Gener(1, 10), newRangeInt64Gener(0, 3), regexpMatchTypeGener}},
+	},
+}
+
+func TestVectorizedBuiltinRegexpFunc(t *testing.T) {
+	testVectorizedBuiltinFunc(t, vecBuiltinRegexpCases)
+}
+
+func BenchmarkVectorizedBuiltinRegexpFunc(b *testing.B) {
+	benchmarkVectorizedBuiltinFunc(b, vecBuiltinRegexpCases)
+}
@@ -0,0 +1,993 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/types/json"
+	"github.com/pingcap/tidb/types/spatial"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/hack"
+)
+
+var (
+	_ functionClass = &geomFromTextFunctionClass{}
+	_ functionClass = &geomFromWKBFunctionClass{}
+	_ functionClass = &geomFromGeoJSONFunctionClass{}
+	_ functionClass = &pointFunctionClass{}
+	_ functionClass = &geomConstructorFunctionClass{}
+	_ functionClass = &asTextFunctionClass{}
+	_ functionClass = &asBinaryFunctionClass{}
+	_ functionClass = &asGeoJSONFunctionClass{}
+	_ functionClass = &stSRIDFunctionClass{}
+	_ functionClass = &stCoordFunctionClass{}
+	_ functionClass = &stGeometryTypeFunctionClass{}
+	_ functionClass = &stEnvelopeFunctionClass{}
+	_ functionClass = &geomMeasureFunctionClass{}
+	_ functionClass = &spatialRelationFunctionClass{}
+	_ functionClass = &stDistanceFunctionClass{}
+	_ functionClass = &stDistanceSphereFunctionClass{}
+)
+
+var (
+	_ builtinFunc = &builtinGeomFromTextSig{}
+	_ builtinFunc = &builtinGeomFromWKBSig{}
+	_ builtinFunc = &builtinGeomFromGeoJSONSig{}
+	_ builtinFunc = &builtinPointSig{}
+	_ builtinFunc = &builtinGeomConstructorSig{}
+	_ builtinFunc = &builtinAsTextSig{}
+	_ builtinFunc = &builtinAsBinarySig{}
+	_ builtinFunc = &builtinAsGeoJSONSig{}
+	_ builtinFunc = &builtinSTSRIDSig{}
+	_ builtinFunc = &builtinSTSetSRIDSig{}
+	_ builtinFunc = &builtinSTCoordSig{}
+	_ builtinFunc = &builtinSTGeometryTypeSig{}
+	_ builtinFunc = &builtinSTEnvelopeSig{}
+	_ builtinFunc = &builtinGeomMeasureSig{}
+	_ builtinFunc = &builtinSpatialRelationSig{}
+	_ builtinFunc = &builtinSTDistanceSig{}
+	_ builtinFunc = &builtinSTDistanceSphereSig{}
+)
+
+// setGeometryRetType sets the return type of a function returning the geometries of the type.
+func setGeometryRetType(tp *types.FieldType, geomType byte) {
+	tp.Tp = mysql.TypeGeometry
+	tp.GeomType = geomType
+	tp.Flen = mysql.MaxBlobWidth
+	tp.Decimal = 0
+	types.SetBinChsClnFlag(tp)
+}
+
+// evalGeometry evaluates the argument as a geometry in the storage format.
+func evalGeometry(ctx sessionctx.Context, arg Expression, row chunk.Row, funcName string) (*spatial.Geometry, bool, error) {
+	data, isNull, err := arg.EvalString(ctx, row)
+	if isNull || err != nil {
+		return nil, true, err
+	}
+	g, err := spatial.Unmarshal(hack.Slice(data))
+	if err != nil {
+		return nil, true, spatial.ErrInvalidGISData.GenWithStackByArgs(funcName)
+	}
+	return g, false, nil
+}
+
+// evalGeometries evaluates the two geometry arguments of a binary spatial function, which must have the same SRID.
+func evalGeometries(ctx sessionctx.Context, args []Expression, row chunk.Row, funcName string) (a, b *spatial.Geometry, isNull bool, err error) {
+	a, isNull, err = evalGeometry(ctx, args[0], row, funcName)
+	if isNull || err != nil {
+		return nil, nil, true, err
+	}
+	b, isNull, err = evalGeometry(ctx, args[1], row, funcName)
+	if isNull || err != nil {
+		return nil, nil, true, err
+	}
+	if err = spatial.CheckSameSRID(funcName, a, b); err != nil {
+		return nil, nil, true, err
+	}
+	return a, b, false, nil
+}
+
+// evalSRID evaluates the optional SRID argument, the SRID is 0 if the argument is absent.
+func evalSRID(ctx sessionctx.Context, args []Expression, idx int, row chunk.Row) (uint32, bool, error) {
+	if idx >= len(args) {
+		return spatial.SRIDCartesian, false, nil
+	}
+	srid, isNull, err := args[idx].EvalInt(ctx, row)
+	if isNull || err != nil {
+		return 0, true, err
+	}
+	if srid < 0 || srid > math.MaxUint32 {
+		return 0, true, spatial.ErrSRSNotFound.GenWithStackByArgs(srid)
+	}
+	return uint32(srid), false, spatial.CheckSRID(uint32(srid))
+}
+
+// evalAxisOrder evaluates the optional options argument of the functions converting geometries from or to WKT
+// and WKB, and returns whether the coordinates should be swapped. The only option is axis-order, and the
+// geographic coordinates are in the latitude-longitude order by default.
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-wkt-functions.html
+func evalAxisOrder(ctx sessionctx.Context, args []Expression, idx int, row chunk.Row, srid uint32, funcName string) (bool, bool, error) {
+	latLong := true
+	if idx < len(args) {
+		options, isNull, err := args[idx].EvalString(ctx, row)
+		if isNull || err != nil {
+			return false, true, err
+		}
+		for _, opt := range strings.Split(options, ",") {
+			if strings.TrimSpace(opt) == "" {
+				continue
+			}
+			kv := strings.SplitN(opt, "=", 2)
+			if len(kv) != 2 || !strings.EqualFold(strings.TrimSpace(kv[0]), "axis-order") {
+				return false, true, errIncorrectArgs.GenWithStackByArgs(funcName)
+			}
+			switch strings.ToLower(strings.TrimSpace(kv[1])) {
+			case "lat-long", "srid-defined":
+				latLong = true
+			case "long-lat":
+				latLong = false
+			default:
+				return false, true, errIncorrectArgs.GenWithStackByArgs(funcName)
+			}
+		}
+	}
+	return latLong && spatial.IsGeographic(srid), false, nil
+}
+
+// geomFromTextFunctionClass is the function class of ST_GeomFromText(wkt [, srid [, options]]) and the functions
+// which only accept the geometries of a specific type, like ST_PointFromText.
+type geomFromTextFunctionClass struct {
+	baseFunctionClass
+	geomType byte
+}
+
+func (c *geomFromTextFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := []types.EvalType{types.ETString, types.ETInt, types.ETString}[:len(args)]
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, argTps...)
+	if err != nil {
+		return nil, err
+	}
+	setGeometryRetType(bf.tp, c.geomType)
+	sig := &builtinGeomFromTextSig{bf, c.funcName, c.geomType}
+	return sig, nil
+}
+
+type builtinGeomFromTextSig struct {
+	baseBuiltinFunc
+	funcName string
+	geomType byte
+}
+
+func (b *builtinGeomFromTextSig) Clone() builtinFunc {
+	newSig := &builtinGeomFromTextSig{funcName: b.funcName, geomType: b.geomType}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinGeomFromTextSig) evalString(row chunk.Row) (string, bool, error) {
+	text, isNull, err := b.args[0].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	srid, isNull, err := evalSRID(b.ctx, b.args, 1, row)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	swap, isNull, err := evalAxisOrder(b.ctx, b.args, 2, row, srid, b.funcName)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	g, err := spatial.ParseWKT(text, srid, swap, b.funcName)
+	if err != nil {
+		return "", true, err
+	}
+	if !g.IsInstanceOf(b.geomType) {
+		return "", true, spatial.ErrInvalidGISData.GenWithStackByArgs(b.funcName)
+	}
+	return string(g.Marshal()), false, nil
+}
+
+// geomFromWKBFunctionClass is the function class of ST_GeomFromWKB(wkb [, srid [, options]]).
+type geomFromWKBFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *geomFromWKBFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := []types.EvalType{types.ETString, types.ETInt, types.ETString}[:len(args)]
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, argTps...)
+	if err != nil {
+		return nil, err
+	}
+	setGeometryRetType(bf.tp, spatial.TypeGeometry)
+	sig := &builtinGeomFromWKBSig{bf, c.funcName}
+	return sig, nil
+}
+
+type builtinGeomFromWKBSig struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *builtinGeomFromWKBSig) Clone() builtinFunc {
+	newSig := &builtinGeomFromWKBSig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinGeomFromWKBSig) evalString(row chunk.Row) (string, bool, error) {
+	data, isNull, err := b.args[0].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	srid, isNull, err := evalSRID(b.ctx, b.args, 1, row)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	swap, isNull, err := evalAxisOrder(b.ctx, b.args, 2, row, srid, b.funcName)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	g, err := spatial.ParseWKB(hack.Slice(data), srid, swap)
+	if err != nil {
+		return "", true, err
+	}
+	return string(g.Marshal()), false, nil
+}
+
+// geomFromGeoJSONFunctionClass is the function class of ST_GeomFromGeoJSON(str [, options [, srid]]).
+// See https://dev.mysql.com/doc/refman/8.0/en/spatial-geojson-functions.html
+type geomFromGeoJSONFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *geomFromGeoJSONFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := []types.EvalType{types.ETString, types.ETInt, types.ETInt}[:len(args)]
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, argTps...)
+	if err != nil {
+		return nil, err
+	}
+	setGeometryRetType(bf.tp, spatial.TypeGeometry)
+	sig := &builtinGeomFromGeoJSONSig{bf, c.funcName}
+	return sig, nil
+}
+
+type builtinGeomFromGeoJSONSig struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *builtinGeomFromGeoJSONSig) Clone() builtinFunc {
+	newSig := &builtinGeomFromGeoJSONSig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinGeomFromGeoJSONSig) evalString(row chunk.Row) (string, bool, error) {
+	data, isNull, err := b.args[0].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	if len(b.args) > 1 {
+		// The options specify how to handle the coordinates of higher dimensions, which are always discarded.
+		options, isNull, err := b.args[1].EvalInt(b.ctx, row)
+		if isNull || err != nil {
+			return "", true, err
+		}
+		if options < 1 || options > 4 {
+			return "", true, errIncorrectArgs.GenWithStackByArgs(b.funcName)
+		}
+	}
+	// The coordinates of GeoJSON are in WGS 84 by default.
+	srid := spatial.SRIDWGS84
+	if len(b.args) > 2 {
+		srid, isNull, err = evalSRID(b.ctx, b.args, 2, row)
+		if isNull || err != nil {
+			return "", true, err
+		}
+	}
+	g, err := spatial.ParseGeoJSON(hack.Slice(data), srid)
+	if err != nil {
+		return "", true, err
+	}
+	return string(g.Marshal()), false, nil
+}
+
+// pointFunctionClass is the function class of Point(x, y).
+type pointFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *pointFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, types.ETReal, types.ETReal)
+	if err != nil {
+		return nil, err
+	}
+	setGeometryRetType(bf.tp, spatial.TypePoint)
+	sig := &builtinPointSig{bf, c.funcName}
+	return sig, nil
+}
+
+type builtinPointSig struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *builtinPointSig) Clone() builtinFunc {
+	newSig := &builtinPointSig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinPointSig) evalString(row chunk.Row) (string, bool, error) {
+	x, isNull, err := b.args[0].EvalReal(b.ctx, row)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	y, isNull, err := b.args[1].EvalReal(b.ctx, row)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	return string(spatial.NewPoint(x, y, spatial.SRIDCartesian).Marshal()), false, nil
+}
+
+// geomConstructorFunctionClass is the function class of the functions constructing a geometry from other
+// geometries, like LineString(pt [, pt] ...) and Polygon(ls [, ls] ...).
+type geomConstructorFunctionClass struct {
+	baseFunctionClass
+	geomType byte
+}
+
+func (c *geomConstructorFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := make([]types.EvalType, 0, len(args))
+	for range args {
+		argTps = append(argTps, types.ETString)
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, argTps...)
+	if err != nil {
+		return nil, err
+	}
+	setGeometryRetType(bf.tp, c.geomType)
+	sig := &builtinGeomConstructorSig{bf, c.funcName, c.geomType}
+	return sig, nil
+}
+
+type builtinGeomConstructorSig struct {
+	baseBuiltinFunc
+	funcName string
+	geomType byte
+}
+
+func (b *builtinGeomConstructorSig) Clone() builtinFunc {
+	newSig := &builtinGeomConstructorSig{funcName: b.funcName, geomType: b.geomType}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinGeomConstructorSig) evalString(row chunk.Row) (string, bool, error) {
+	res := &spatial.Geometry{Type: b.geomType}
+	for i, arg := range b.args {
+		g, isNull, err := evalGeometry(b.ctx, arg, row, b.funcName)
+		if isNull || err != nil {
+			return "", true, err
+		}
+		if i == 0 {
+			res.SRID = g.SRID
+		} else if res.SRID != g.SRID {
+			return "", true, spatial.ErrDifferentSRIDs.GenWithStackByArgs(b.funcName, res.SRID, g.SRID)
+		}
+		switch b.geomType {
+		case spatial.TypeLineString:
+			if g.Type != spatial.TypePoint {
+				return "", true, spatial.ErrInvalidGISData.GenWithStackByArgs(b.funcName)
+			}
+			if i == 0 {
+				res.Coords = [][]spatial.Point{nil}
+			}
+			res.Coords[0] = append(res.Coords[0], g.Coords[0][0])
+		case spatial.TypePolygon:
+			// The rings of a polygon are given as linestrings.
+			if g.Type != spatial.TypeLineString {
+				return "", true, spatial.ErrInvalidGISData.GenWithStackByArgs(b.funcName)
+			}
+			res.Coords = append(res.Coords, g.Coords[0])
+		default:
+			res.Geoms = append(res.Geoms, g)
+		}
+	}
+	if err := res.Validate(b.funcName); err != nil {
+		return "", true, err
+	}
+	return string(res.Marshal()), false, nil
+}
+
+// asTextFunctionClass is the function class of ST_AsText(g [, options]).
+type asTextFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *asTextFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := []types.EvalType{types.ETString, types.ETString}[:len(args)]
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, argTps...)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.Flen = mysql.MaxBlobWidth
+	sig := &builtinAsTextSig{bf, c.funcName}
+	return sig, nil
+}
+
+type builtinAsTextSig struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *builtinAsTextSig) Clone() builtinFunc {
+	newSig := &builtinAsTextSig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinAsTextSig) evalString(row chunk.Row) (string, bool, error) {
+	g, isNull, err := evalGeometry(b.ctx, b.args[0], row, b.funcName)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	swap, isNull, err := evalAxisOrder(b.ctx, b.args, 1, row, g.SRID, b.funcName)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	if swap {
+		g = g.SwapXY()
+	}
+	return g.WKT(), false, nil
+}
+
+// asBinaryFunctionClass is the function class of ST_AsBinary(g [, options]).
+type asBinaryFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *asBinaryFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := []types.EvalType{types.ETString, types.ETString}[:len(args)]
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, argTps...)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.Flen = mysql.MaxBlobWidth
+	types.SetBinChsClnFlag(bf.tp)
+	sig := &builtinAsBinarySig{bf, c.funcName}
+	return sig, nil
+}
+
+type builtinAsBinarySig struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *builtinAsBinarySig) Clone() builtinFunc {
+	newSig := &builtinAsBinarySig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinAsBinarySig) evalString(row chunk.Row) (string, bool, error) {
+	g, isNull, err := evalGeometry(b.ctx, b.args[0], row, b.funcName)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	swap, isNull, err := evalAxisOrder(b.ctx, b.args, 1, row, g.SRID, b.funcName)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	if swap {
+		g = g.SwapXY()
+	}
+	return string(g.WKB()), false, nil
+}
+
+// asGeoJSONFunctionClass is the function class of ST_AsGeoJSON(g [, max_dec_digits [, options]]).
+// See https://dev.mysql.com/doc/refman/8.0/en/spatial-geojson-functions.html
+type asGeoJSONFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *asGeoJSONFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := []types.EvalType{types.ETString, types.ETInt, types.ETInt}[:len(args)]
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETJson, argTps...)
+	if err != nil {
+		return nil, err
+	}
+	sig := &builtinAsGeoJSONSig{bf, c.funcName}
+	return sig, nil
+}
+
+type builtinAsGeoJSONSig struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *builtinAsGeoJSONSig) Clone() builtinFunc {
+	newSig := &builtinAsGeoJSONSig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// The options of ST_AsGeoJSON.
+const (
+	geoJSONOptionBBox      = 1
+	geoJSONOptionShortCRS  = 2
+	geoJSONOptionLongCRS   = 4
+	geoJSONOptionsAllFlags = geoJSONOptionBBox | geoJSONOptionShortCRS | geoJSONOptionLongCRS
+)
+
+func (b *builtinAsGeoJSONSig) evalJSON(row chunk.Row) (json.BinaryJSON, bool, error) {
+	g, isNull, err := evalGeometry(b.ctx, b.args[0], row, b.funcName)
+	if isNull || err != nil {
+		return json.BinaryJSON{}, true, err
+	}
+	// The coordinates aren't rounded by default.
+	maxDecimals, options := int64(-1), int64(0)
+	if len(b.args) > 1 {
+		maxDecimals, isNull, err = b.args[1].EvalInt(b.ctx, row)
+		if isNull || err != nil {
+			return json.BinaryJSON{}, true, err
+		}
+		if maxDecimals < 0 {
+			return json.BinaryJSON{}, true, errIncorrectArgs.GenWithStackByArgs(b.funcName)
+		}
+		// A float64 has no more than 17 significant decimal digits.
+		if maxDecimals > 17 {
+			maxDecimals = -1
+		}
+	}
+	if len(b.args) > 2 {
+		options, isNull, err = b.args[2].EvalInt(b.ctx, row)
+		if isNull || err != nil {
+			return json.BinaryJSON{}, true, err
+		}
+		if options < 0 || options > geoJSONOptionsAllFlags {
+			return json.BinaryJSON{}, true, errIncorrectArgs.GenWithStackByArgs(b.funcName)
+		}
+	}
+	obj := g.GeoJSON(int(maxDecimals))
+	if r := g.MBR(); options&geoJSONOptionBBox != 0 && !r.Empty {
+		obj["bbox"] = []interface{}{r.MinX, r.MinY, r.MaxX, r.MaxY}
+	}
+	if options&(geoJSONOptionShortCRS|geoJSONOptionLongCRS) != 0 && g.SRID != spatial.SRIDCartesian {
+		name := fmt.Sprintf("EPSG:%d", g.SRID)
+		if options&geoJSONOptionLongCRS != 0 {
+			name = fmt.Sprintf("urn:ogc:def:crs:EPSG::%d", g.SRID)
+		}
+		obj["crs"] = map[string]interface{}{"type": "name", "properties": map[string]interface{}{"name": name}}
+	}
+	return json.CreateBinary(obj), false, nil
+}
+
+// stSRIDFunctionClass is the function class of ST_SRID(g [, srid]), it returns the SRID of the geometry, or
+// the geometry with the new SRID if the second argument is given.
+type stSRIDFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stSRIDFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	if len(args) == 1 {
+		bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETInt, types.ETString)
+		if err != nil {
+			return nil, err
+		}
+		bf.tp.Flen = 10
+		bf.tp.Flag |= mysql.UnsignedFlag
+		sig := &builtinSTSRIDSig{bf, c.funcName}
+		return sig, nil
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, types.ETString, types.ETInt)
+	if err != nil {
+		return nil, err
+	}
+	setGeometryRetType(bf.tp, spatial.TypeGeometry)
+	sig := &builtinSTSetSRIDSig{bf, c.funcName}
+	return sig, nil
+}
+
+type builtinSTSRIDSig struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *builtinSTSRIDSig) Clone() builtinFunc {
+	newSig := &builtinSTSRIDSig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinSTSRIDSig) evalInt(row chunk.Row) (int64, bool, error) {
+	g, isNull, err := evalGeometry(b.ctx, b.args[0], row, b.funcName)
+	if isNull || err != nil {
+		return 0, true, err
+	}
+	return int64(g.SRID), false, nil
+}
+
+type builtinSTSetSRIDSig struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *builtinSTSetSRIDSig) Clone() builtinFunc {
+	newSig := &builtinSTSetSRIDSig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinSTSetSRIDSig) evalString(row chunk.Row) (string, bool, error) {
+	g, isNull, err := evalGeometry(b.ctx, b.args[0], row, b.funcName)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	srid, isNull, err := evalSRID(b.ctx, b.args, 1, row)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	g.SRID = srid
+	// The coordinates may be out of the range of the new SRID.
+	if err = g.Validate(b.funcName); err != nil {
+		return "", true, err
+	}
+	return string(g.Marshal()), false, nil
+}
+
+// stCoordFunctionClass is the function class of ST_X(p) and ST_Y(p). The coordinates of a geographic point are
+// in the order of its spatial reference system, so ST_X returns the latitude and ST_Y returns the longitude.
+type stCoordFunctionClass struct {
+	baseFunctionClass
+	isY bool
+}
+
+func (c *stCoordFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETReal, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	sig := &builtinSTCoordSig{bf, c.funcName, c.isY}
+	return sig, nil
+}
+
+type builtinSTCoordSig struct {
+	baseBuiltinFunc
+	funcName string
+	isY      bool
+}
+
+func (b *builtinSTCoordSig) Clone() builtinFunc {
+	newSig := &builtinSTCoordSig{funcName: b.funcName, isY: b.isY}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinSTCoordSig) evalReal(row chunk.Row) (float64, bool, error) {
+	g, isNull, err := evalGeometry(b.ctx, b.args[0], row, b.funcName)
+	if isNull || err != nil {
+		return 0, true, err
+	}
+	if g.Type != spatial.TypePoint {
+		return 0, true, spatial.ErrUnsupportedArgument.GenWithStackByArgs(b.funcName)
+	}
+	p := g.Coords[0][0]
+	if b.isY != spatial.IsGeographic(g.SRID) {
+		return p.Y, false, nil
+	}
+	return p.X, false, nil
+}
+
+// stGeometryTypeFunctionClass is the function class of ST_GeometryType(g).
+type stGeometryTypeFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stGeometryTypeFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.Flen = len("MULTILINESTRING")
+	sig := &builtinSTGeometryTypeSig{bf, c.funcName}
+	return sig, nil
+}
+
+type builtinSTGeometryTypeSig struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *builtinSTGeometryTypeSig) Clone() builtinFunc {
+	newSig := &builtinSTGeometryTypeSig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinSTGeometryTypeSig) evalString(row chunk.Row) (string, bool, error) {
+	g, isNull, err := evalGeometry(b.ctx, b.args[0], row, b.funcName)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	return g.TypeName(), false, nil
+}
+
+// stEnvelopeFunctionClass is the function class of ST_Envelope(g).
+type stEnvelopeFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stEnvelopeFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	setGeometryRetType(bf.tp, spatial.TypeGeometry)
+	sig := &builtinSTEnvelopeSig{bf, c.funcName}
+	return sig, nil
+}
+
+type builtinSTEnvelopeSig struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *builtinSTEnvelopeSig) Clone() builtinFunc {
+	newSig := &builtinSTEnvelopeSig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinSTEnvelopeSig) evalString(row chunk.Row) (string, bool, error) {
+	g, isNull, err := evalGeometry(b.ctx, b.args[0], row, b.funcName)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	if spatial.IsGeographic(g.SRID) {
+		return "", true, spatial.ErrNotImplementedForGeographicSRS.GenWithStackByArgs(b.funcName, g.TypeName())
+	}
+	return string(g.Envelope().Marshal()), false, nil
+}
+
+// geomMeasureFunctionClass is the function class of ST_Area(g) and ST_Length(g).
+type geomMeasureFunctionClass struct {
+	baseFunctionClass
+	measure func(g *spatial.Geometry) (float64, error)
+}
+
+func (c *geomMeasureFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETReal, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	sig := &builtinGeomMeasureSig{bf, c.funcName, c.measure}
+	return sig, nil
+}
+
+type builtinGeomMeasureSig struct {
+	baseBuiltinFunc
+	funcName string
+	measure  func(g *spatial.Geometry) (float64, error)
+}
+
+func (b *builtinGeomMeasureSig) Clone() builtinFunc {
+	newSig := &builtinGeomMeasureSig{funcName: b.funcName, measure: b.measure}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinGeomMeasureSig) evalReal(row chunk.Row) (float64, bool, error) {
+	g, isNull, err := evalGeometry(b.ctx, b.args[0], row, b.funcName)
+	if isNull || err != nil {
+		return 0, true, err
+	}
+	res, err := b.measure(g)
+	if err != nil {
+		return 0, true, err
+	}
+	return res, false, nil
+}
+
+// spatialRelationFunctionClass is the function class of the functions testing the spatial relation between two
+// geometries, like ST_Contains(g1, g2) and MBRContains(g1, g2).
+type spatialRelationFunctionClass struct {
+	baseFunctionClass
+	relation func(a, b *spatial.Geometry) bool
+}
+
+func (c *spatialRelationFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETInt, types.ETString, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.Flen = 1
+	sig := &builtinSpatialRelationSig{bf, c.funcName, c.relation}
+	return sig, nil
+}
+
+type builtinSpatialRelationSig struct {
+	baseBuiltinFunc
+	funcName string
+	relation func(a, b *spatial.Geometry) bool
+}
+
+func (b *builtinSpatialRelationSig) Clone() builtinFunc {
+	newSig := &builtinSpatialRelationSig{funcName: b.funcName, relation: b.relation}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinSpatialRelationSig) evalInt(row chunk.Row) (int64, bool, error) {
+	g1, g2, isNull, err := evalGeometries(b.ctx, b.args, row, b.funcName)
+	if isNull || err != nil {
+		return 0, true, err
+	}
+	if b.relation(g1, g2) {
+		return 1, false, nil
+	}
+	return 0, false, nil
+}
+
+func mbrContains(a, b *spatial.Geometry) bool {
+	return a.MBR().Contains(b.MBR())
+}
+
+func mbrWithin(a, b *spatial.Geometry) bool {
+	return b.MBR().Contains(a.MBR())
+}
+
+func mbrIntersects(a, b *spatial.Geometry) bool {
+	return a.MBR().Intersects(b.MBR())
+}
+
+// stDistanceFunctionClass is the function class of ST_Distance(g1, g2).
+type stDistanceFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stDistanceFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETReal, types.ETString, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	sig := &builtinSTDistanceSig{bf, c.funcName}
+	return sig, nil
+}
+
+type builtinSTDistanceSig struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *builtinSTDistanceSig) Clone() builtinFunc {
+	newSig := &builtinSTDistanceSig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalReal evals the minimum distance between the geometries, NULL is returned if either geometry is empty.
+func (b *builtinSTDistanceSig) evalReal(row chunk.Row) (float64, bool, error) {
+	g1, g2, isNull, err := evalGeometries(b.ctx, b.args, row, b.funcName)
+	if isNull || err != nil {
+		return 0, true, err
+	}
+	d, err := spatial.Distance(g1, g2)
+	if err != nil || math.IsNaN(d) {
+		return 0, true, err
+	}
+	return d, false, nil
+}
+
+// stDistanceSphereFunctionClass is the function class of ST_Distance_Sphere(g1, g2 [, radius]).
+type stDistanceSphereFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stDistanceSphereFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := []types.EvalType{types.ETString, types.ETString, types.ETReal}[:len(args)]
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETReal, argTps...)
+	if err != nil {
+		return nil, err
+	}
+	sig := &builtinSTDistanceSphereSig{bf, c.funcName}
+	return sig, nil
+}
+
+type builtinSTDistanceSphereSig struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *builtinSTDistanceSphereSig) Clone() builtinFunc {
+	newSig := &builtinSTDistanceSphereSig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalReal evals the great-circle distance in meters between the points.
+// See https://dev.mysql.com/doc/refman/8.0/en/spatial-convenience-functions.html#function_st-distance-sphere
+func (b *builtinSTDistanceSphereSig) evalReal(row chunk.Row) (float64, bool, error) {
+	g1, g2, isNull, err := evalGeometries(b.ctx, b.args, row, b.funcName)
+	if isNull || err != nil {
+		return 0, true, err
+	}
+	radius := spatial.EarthRadius
+	if len(b.args) > 2 {
+		radius, isNull, err = b.args[2].EvalReal(b.ctx, row)
+		if isNull || err != nil {
+			return 0, true, err
+		}
+	}
+	d, err := spatial.DistanceSphere(g1, g2, radius)
+	if err != nil {
+		return 0, true, err
+	}
+	return d, false, nil
+}
@@ -672,6 +672,14 @@ func getSignatureByPB(ctx sessionctx.Context, sigCode tipb.ScalarFuncSig, tp *ti
 	// 	f = &builtinRegexpSig{base}
 	// case tipb.ScalarFuncSig_RegexpUTF8Sig:
 	// 	f = &builtinRegexpUTF8Sig{base}
+	case tipb.ScalarFuncSig_RegexpLikeSig, tipb.ScalarFuncSig_RegexpLikeUTF8Sig:
+		f = newBuiltinRegexpLikeSig(regexpBaseFromPB(ctx, base, sigCode == tipb.ScalarFuncSig_RegexpLikeSig))
+	case tipb.ScalarFuncSig_RegexpInStrSig, tipb.ScalarFuncSig_RegexpInStrUTF8Sig:
+		f = newBuiltinRegexpInStrSig(regexpBaseFromPB(ctx, base, sigCode == tipb.ScalarFuncSig_RegexpInStrSig))
+	case tipb.ScalarFuncSig_RegexpSubstrSig, tipb.ScalarFuncSig_RegexpSubstrUTF8Sig:
+		f = newBuiltinRegexpSubstrSig(regexpBaseFromPB(ctx, base, sigCode == tipb.ScalarFuncSig_RegexpSubstrSig))
+	case tipb.ScalarFuncSig_RegexpReplaceSig, tipb.ScalarFuncSig_RegexpReplaceUTF8Sig:
+		f = newBuiltinRegexpReplaceSig(regexpBaseFromPB(ctx, base, sigCode == tipb.ScalarFuncSig_RegexpReplaceSig))
 	case tipb.ScalarFuncSig_JsonExtractSig:
 		f = &builtinJSONExtractSig{base}
 	case tipb.ScalarFuncSig_JsonUnquoteSig:
@@ -291,6 +291,65 @@ func TestLikeFunc2Pb(t *testing.T) {
 	}
 }
 
+func TestRegexpFunc2Pb(t *testing.T) {
+	sc := new(stmtctx.StatementContext)
+	client := new(mock.Client)
+	ctx := mock.NewContext()
+
+	stringColumn := genColumn(mysql.TypeVarString, 1)
+	stringColumn.RetType.Charset = charset.CharsetUTF8MB4
+	stringColumn.RetType.Collate = charset.CollationUTF8MB4
+	binaryColumn := genColumn(mysql.TypeVarString, 2)
+	binaryColumn.RetType.Charset = charset.CharsetBin
+	binaryColumn.RetType.Collate = charset.CollationBin
+	intColumn := genColumn(mysql.TypeLonglong, 3)
+
+	cases := []struct {
+		name string
+		args []Expression
+		sig  tipb.ScalarFuncSig
+	}{
+		{ast.RegexpLike, []Expression{stringColumn, stringColumn}, tipb.ScalarFuncSig_RegexpLikeUTF8Sig},
+		{ast.RegexpLike, []Expression{binaryColumn, stringColumn}, tipb.ScalarFuncSig_RegexpLikeSig},
+		{ast.RegexpInStr, []Expression{stringColumn, stringColumn, intColumn}, tipb.ScalarFuncSig_RegexpInStrUTF8Sig},
+		{ast.RegexpInStr, []Expression{binaryColumn, binaryColumn, intColumn}, tipb.ScalarFuncSig_RegexpInStrSig},
+		{ast.RegexpSubstr, []Expression{stringColumn, stringColumn}, tipb.ScalarFuncSig_RegexpSubstrUTF8Sig},
+		{ast.RegexpSubstr, []Expression{binaryColumn, stringColumn}, tipb.ScalarFuncSig_RegexpSubstrSig},
+		{ast.RegexpReplace, []Expression{stringColumn, stringColumn, stringColumn}, tipb.ScalarFuncSig_RegexpReplaceUTF8Sig},
+		{ast.RegexpReplace, []Expression{binaryColumn, stringColumn, stringColumn}, tipb.ScalarFuncSig_RegexpReplaceSig},
+	}
+	exprs := make([]Expression, 0, len(cases))
+	for _, c := range cases {
+		fc, err := NewFunction(ctx, c.name, types.NewFieldType(mysql.TypeUnspecified), c.args...)
+		require.NoError(t, err)
+		exprs = append(exprs, fc)
+	}
+
+	// The regexp functions are not pushed down to TiKV when they are in the blacklist.
+	oldBlacklist := DefaultExprPushDownBlacklist.Load()
+	defer DefaultExprPushDownBlacklist.Store(oldBlacklist)
+	DefaultExprPushDownBlacklist.Store(map[string]uint32{
+		ast.RegexpLike:    1 << kv.TiKV,
+		ast.RegexpInStr:   1 << kv.TiKV,
+		ast.RegexpSubstr:  1 << kv.TiKV,
+		ast.RegexpReplace: 1 << kv.TiKV,
+	})
+	pushed, remained := PushDownExprs(sc, exprs, client, kv.TiKV)
+	require.Len(t, pushed, 0)
+	require.Len(t, remained, len(exprs))
+
+	DefaultExprPushDownBlacklist.Store(make(map[string]uint32))
+	pushed, remained = PushDownExprs(sc, exprs, client, kv.TiKV)
+	require.Len(t, pushed, len(exprs))
+	require.Len(t, remained, 0)
+	pbExprs, err := ExpressionsToPBList(sc, exprs, client)
+	require.NoError(t, err)
+	require.Len(t, pbExprs, len(cases))
+	for i, pbExpr := range pbExprs {
+		require.Equalf(t, cases[i].sig, pbExpr.Sig, "function: %s", cases[i].name)
+	}
+}
+
 func TestArithmeticalFunc2Pb(t *testing.T) {
 	var arithmeticalFuncs = make([]Expression, 0)
 	sc := new(stmtctx.StatementContext)
@@ -45,6 +45,7 @@ import (
 	"github.com/pingcap/tidb/testkit"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/types/json"
+	"github.com/pingcap/tidb/types/spatial"
 	"github.com/pingcap/tidb/util/codec"
 	"github.com/pingcap/tidb/util/collate"
 	"github.com/pingcap/tidb/util/kvcache"
@@ -1004,6 +1005,55 @@ func TestEncryptionBuiltin(t *testing.T) {
 	result.Check(testkit.Rows("<nil>"))
 }
 
+func TestSpatialBuiltin(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t (id int primary key, p point srid 4326, g geometry)")
+	tk.MustExec("insert into t values (1, st_geomfromtext('POINT(40 116.4)', 4326), st_geomfromtext('POLYGON((0 0,10 0,10 10,0 10,0 0))')), (2, null, null)")
+
+	// The geographic coordinates are in the latitude-longitude order by default.
+	tk.MustQuery("select st_astext(p), st_astext(p, 'axis-order=long-lat'), st_x(p), st_y(p), st_srid(p), st_asgeojson(p) from t where id = 1").Check(testkit.Rows(
+		`POINT(40 116.4) POINT(116.4 40) 40 116.4 4326 {"coordinates": [116.4, 40], "type": "Point"}`))
+	tk.MustQuery("select hex(p), hex(st_asbinary(p, 'axis-order=long-lat')) from t where id = 1").Check(testkit.Rows(
+		"E610000001010000009A99999999195D400000000000004440 01010000009A99999999195D400000000000004440"))
+	tk.MustQuery("select st_astext(st_geomfromgeojson('{\"type\":\"Point\",\"coordinates\":[116.4,40]}'))").Check(testkit.Rows("POINT(40 116.4)"))
+	tk.MustQuery("select st_area(g), st_astext(st_envelope(g)), st_geometrytype(g), st_asgeojson(g, 2, 1) from t where id = 1").Check(testkit.Rows(
+		`100 POLYGON((0 0,10 0,10 10,0 10,0 0)) POLYGON {"bbox": [0, 0, 10, 10], "coordinates": [[[0, 0], [10, 0], [10, 10], [0, 10], [0, 0]]], "type": "Polygon"}`))
+	tk.MustQuery("select st_astext(p), st_srid(g), st_contains(g, point(1, 1)) from t where id = 2").Check(testkit.Rows("<nil> <nil> <nil>"))
+
+	tk.MustQuery("select point(1, 2) = st_geomfromtext('POINT(1 2)'), st_astext(linestring(point(0, 0), point(1, 1))), st_astext(st_geomfromwkb(st_asbinary(point(1, 2))))").Check(testkit.Rows(
+		"1 LINESTRING(0 0,1 1) POINT(1 2)"))
+	tk.MustQuery("select st_astext(polygon(linestring(point(0, 0), point(1, 0), point(1, 1), point(0, 0)))), st_astext(geomcollection())").Check(testkit.Rows(
+		"POLYGON((0 0,1 0,1 1,0 0)) GEOMETRYCOLLECTION EMPTY"))
+	tk.MustQuery("select st_distance(point(0, 0), point(3, 4)), st_distance(point(0, 0), geomcollection()), st_length(linestring(point(0, 0), point(3, 4)))").Check(testkit.Rows(
+		"5 <nil> 5"))
+	tk.MustQuery("select st_contains(g, point(1, 1)), st_within(point(11, 1), g), st_intersects(g, linestring(point(5, 5), point(20, 20))), mbrcontains(g, point(10, 10)) from t where id = 1").Check(testkit.Rows(
+		"1 0 1 1"))
+	// The coordinates are kept, and the X coordinate becomes the longitude.
+	tk.MustQuery("select st_astext(st_srid(point(1, 2), 4326)), st_astext(st_srid(point(1, 2), 4326), 'axis-order=long-lat')").Check(testkit.Rows("POINT(2 1) POINT(1 2)"))
+
+	for _, c := range []struct {
+		sql string
+		err *terror.Error
+	}{
+		{"select st_contains(point(1, 1), st_geomfromtext('POINT(1 1)', 4326))", spatial.ErrDifferentSRIDs},
+		{"select st_geomfromtext('POINT(1)')", spatial.ErrInvalidGISData},
+		{"select st_geomfromtext('POINT(1 1)', 3857)", spatial.ErrSRSNotFound},
+		{"select st_geomfromtext('POINT(100 1)', 4326)", spatial.ErrLatitudeOutOfRange},
+		{"select st_envelope(st_geomfromtext('POINT(1 1)', 4326))", spatial.ErrNotImplementedForGeographicSRS},
+		{"select st_distance_sphere(point(0, 0), point(1, 1), 0)", spatial.ErrNonPositiveRadius},
+	} {
+		err := tk.QueryToErr(c.sql)
+		require.True(t, c.err.Equal(err), "sql: %s, err: %v", c.sql, err)
+	}
+	tk.MustGetErrCode("insert into t values (3, point(1, 2), null)", errno.ErrWrongSridForColumn)
+	tk.MustGetErrCode("insert into t values (3, st_geomfromtext('LINESTRING(0 0,1 1)', 4326), null)", errno.ErrCantCreateGeometryObject)
+}
+
 func TestOpBuiltin(t *testing.T) {
 	store, clean := testkit.CreateMockStore(t)
 	defer clean()
@@ -3009,7 +3059,8 @@ func TestForeignKeyVar(t *testing.T) {
 	tk := testkit.NewTestKit(t, store)
 
 	tk.MustExec("SET FOREIGN_KEY_CHECKS=1")
-	tk.MustQuery("SHOW WARNINGS").Check(testkit.Rows("Warning 8047 variable 'foreign_key_checks' does not yet support value: 1"))
+	tk.MustQuery("SHOW WARNINGS").Check(testkit.Rows())
+	tk.MustQuery("SELECT @@foreign_key_checks").Check(testkit.Rows("1"))
 }
 
 func TestUserVarMockWindFunc(t *testing.T) {
@@ -3543,7 +3594,11 @@ func TestExprPushdownBlacklist(t *testing.T) {
 
 	tk := testkit.NewTestKit(t, store)
 	tk.MustQuery(`select * from mysql.expr_pushdown_blacklist`).Check(testkit.Rows(
-		"date_add tiflash DST(daylight saving time) does not take effect in TiFlash date_add"))
+		"date_add tiflash DST(daylight saving time) does not take effect in TiFlash date_add",
+		"regexp_like tikv The match type and collation of regexp_like are not verified in TiKV",
+		"regexp_instr tikv The match type and collation of regexp_instr are not verified in TiKV",
+		"regexp_substr tikv The match type and collation of regexp_substr are not verified in TiKV",
+		"regexp_replace tikv The match type and collation of regexp_replace are not verified in TiKV"))
 
 	tk.MustExec("use test")
 	tk.MustExec("drop table if exists t")
@@ -7058,3 +7113,84 @@ func TestIssue32488(t *testing.T) {
 	tk.MustQuery("select binary upper(a), lower(a) from t order by upper(a);").Check([][]interface{}{{"İ i"}, {"Ʞ ʞ"}})
 	tk.MustQuery("select distinct upper(a), lower(a) from t order by upper(a);").Check([][]interface{}{{"İ i"}, {"Ʞ ʞ"}})
 }
+
+func TestRegexpFunctions(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t (id int, a varchar(20) collate utf8mb4_general_ci, b varchar(20) collate utf8mb4_bin, c varbinary(20))")
+	tk.MustExec("insert into t values (1, 'Hello World', 'Hello World', 'Hello World'), (2, 'hello tidb', 'hello tidb', 'hello tidb'), (3, null, null, null)")
+
+	// The case sensitivity follows the collation unless the match type overrides it.
+	tk.MustQuery("select id, regexp_like(a, '^hello'), regexp_like(b, '^hello'), regexp_like(c, '^hello') from t order by id").
+		Check(testkit.Rows("1 1 0 0", "2 1 1 1", "3 <nil> <nil> <nil>"))
+	tk.MustQuery("select id, regexp_like(a, '^hello', 'c'), regexp_like(b, '^hello', 'i'), regexp_like(c, '^hello', 'i') from t order by id").
+		Check(testkit.Rows("1 0 1 0", "2 1 1 1", "3 <nil> <nil> <nil>"))
+	tk.MustQuery("select regexp_instr(a, 'o', 1, 2), regexp_instr(a, 'o', 1, 2, 1), regexp_substr(a, '[a-z]+', 1, 2), regexp_replace(a, 'o', '0') from t where id = 1").
+		Check(testkit.Rows("8 9 World Hell0 W0rld"))
+	tk.MustQuery("select regexp_replace(b, '(\\\\w+) (\\\\w+)', '$2 $1'), regexp_substr(b, 'x') from t where id = 2").
+		Check(testkit.Rows("tidb hello <nil>"))
+	err := tk.QueryToErr("select regexp_like('a', 'a', 'x')")
+	require.EqualError(t, err, "[expression:1139]Got error 'Invalid match type' from regexp")
+	err = tk.QueryToErr("select regexp_instr('a', 'a', 3)")
+	require.EqualError(t, err, "[expression:1139]Got error 'Index out of bounds in regular expression search' from regexp")
+	err = tk.QueryToErr("select regexp_replace('a', '(', 'b')")
+	require.Error(t, err)
+
+	// The regexp functions are not pushed down to TiKV by default.
+	sql := "explain format = 'brief' select id from t where regexp_like(a, '^hello') and regexp_instr(b, 'o') > 0 and regexp_substr(a, 'w.*') = 'world' and regexp_replace(c, 'l+', 'L') = 'HeLo WorLd'"
+	rows := tk.MustQuery(sql).Rows()
+	require.Regexp(t, "Selection", fmt.Sprintf("%v", rows[1][0]))
+	require.Equal(t, "root", fmt.Sprintf("%v", rows[1][2]))
+
+	// They can be pushed down to the coprocessor after being removed from the blacklist.
+	tk.MustExec("delete from mysql.expr_pushdown_blacklist where name like 'regexp\\_%'")
+	tk.MustExec("admin reload expr_pushdown_blacklist")
+	defer func() {
+		tk.MustExec("insert into mysql.expr_pushdown_blacklist values('regexp_like', 'tikv', ''), ('regexp_instr', 'tikv', ''), ('regexp_substr', 'tikv', ''), ('regexp_replace', 'tikv', '')")
+		tk.MustExec("admin reload expr_pushdown_blacklist")
+	}()
+	rows = tk.MustQuery(sql).Rows()
+	require.Equal(t, "cop[tikv]", fmt.Sprintf("%v", rows[2][2]))
+	require.Regexp(t, "Selection", fmt.Sprintf("%v", rows[2][0]))
+	tk.MustQuery("select id from t where regexp_like(a, '^hello') and regexp_instr(b, 'o') > 0 and regexp_substr(a, 'w.*') = 'world' and regexp_replace(c, 'l+', 'L') = 'HeLo WorLd'").
+		Check(testkit.Rows("1"))
+	tk.MustQuery("select id from t where regexp_like(a, 'TIDB$') and regexp_like(b, 'TIDB$', 'i')").Check(testkit.Rows("2"))
+}
+
+func TestRegexpFunctionsParallelProjection(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t (id int, a varchar(20))")
+	values := make([]string, 0, 1000)
+	for i := 0; i < 1000; i++ {
+		values = append(values, fmt.Sprintf("(%d, 'abc%d')", i, i))
+	}
+	tk.MustExec("insert into t values " + strings.Join(values, ","))
+
+	// The projection workers evaluate the same signatures concurrently, which memorize the regexp of the constant pattern.
+	tk.MustExec("set @@tidb_projection_concurrency = 4")
+	tk.MustExec("set @@tidb_init_chunk_size = 1")
+	tk.MustExec("set @@tidb_max_chunk_size = 32")
+	for i := 0; i < 5; i++ {
+		rows := tk.MustQuery("select regexp_like(a, '1$'), regexp_instr(a, '[0-9]'), regexp_substr(a, '[0-9]+'), regexp_replace(a, 'b', 'B') from t order by id").Rows()
+		require.Len(t, rows, 1000)
+		matched := 0
+		for id, row := range rows {
+			if row[0] == "1" {
+				matched++
+			}
+			require.Equal(t, "4", row[1])
+			require.Equal(t, strconv.Itoa(id), row[2])
+			require.Equal(t, fmt.Sprintf("aBc%d", id), row[3])
+		}
+		require.Equal(t, 100, matched)
+	}
+}
@@ -32,6 +32,14 @@ var (
 	ErrPlacementPolicyExists = dbterror.ClassSchema.NewStd(mysql.ErrPlacementPolicyExists)
 	// ErrPlacementPolicyNotExists return for placement_policy policy not exists.
 	ErrPlacementPolicyNotExists = dbterror.ClassSchema.NewStd(mysql.ErrPlacementPolicyNotExists)
+	// ErrProcedureExists returns for stored procedure already exists.
+	ErrProcedureExists = dbterror.ClassSchema.NewStd(mysql.ErrSpAlreadyExists)
+	// ErrProcedureNotExists returns for stored procedure not exists.
+	ErrProcedureNotExists = dbterror.ClassSchema.NewStd(mysql.ErrSpDoesNotExist)
+	// ErrTriggerExists returns for trigger already exists.
+	ErrTriggerExists = dbterror.ClassSchema.NewStd(mysql.ErrTrgAlreadyExists)
+	// ErrTriggerNotExists returns for trigger not exists.
+	ErrTriggerNotExists = dbterror.ClassSchema.NewStd(mysql.ErrTrgDoesNotExist)
 	// ErrReservedSyntax  for internal syntax.
 	ErrReservedSyntax = dbterror.ClassSchema.NewStd(mysql.ErrReservedSyntax)
 	// ErrTableExists returns for table already exists.
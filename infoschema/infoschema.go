@@ -17,6 +17,7 @@ package infoschema
 import (
 	"fmt"
 	"sort"
+	"strings"
 	"sync"
 
 	"github.com/pingcap/tidb/ddl/placement"
@@ -24,6 +25,7 @@ import (
 	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/table/tables"
 	"github.com/pingcap/tidb/util"
 )
 
@@ -59,6 +61,8 @@ type InfoSchema interface {
 	RuleBundles() []*placement.Bundle
 	// AllPlacementPolicies returns all placement policies
 	AllPlacementPolicies() []*model.PolicyInfo
+	// GetTableReferredForeignKeys gets the foreign keys in other tables that refer to the table.
+	GetTableReferredForeignKeys(schema, table string) []*model.ReferredFKInfo
 }
 
 type sortedTables []table.Table
@@ -108,6 +112,17 @@ type infoSchema struct {
 
 	// schemaMetaVersion is the version of schema, and we should check version when change schema.
 	schemaMetaVersion int64
+
+	// referredForeignKeyMap records the foreign keys that refer to a table, it's built lazily
+	// because only the DML statements with foreign key checks need it.
+	referredForeignKeyOnce sync.Once
+	referredForeignKeyMap  map[schemaAndTableName][]*model.ReferredFKInfo
+}
+
+// schemaAndTableName contains the lower-case schema name and table name.
+type schemaAndTableName struct {
+	schema string
+	table  string
 }
 
 // MockInfoSchema only serves for test.
@@ -291,6 +306,48 @@ func (is *infoSchema) SchemaTables(schema model.CIStr) (tables []table.Table) {
 	return
 }
 
+// GetTableReferredForeignKeys implements InfoSchema.GetTableReferredForeignKeys
+func (is *infoSchema) GetTableReferredForeignKeys(schema, table string) []*model.ReferredFKInfo {
+	is.referredForeignKeyOnce.Do(is.buildReferredForeignKeyMap)
+	return is.referredForeignKeyMap[schemaAndTableName{schema: strings.ToLower(schema), table: strings.ToLower(table)}]
+}
+
+func (is *infoSchema) buildReferredForeignKeyMap() {
+	is.referredForeignKeyMap = make(map[schemaAndTableName][]*model.ReferredFKInfo)
+	for _, v := range is.schemaMap {
+		for _, tbl := range v.tables {
+			for _, fk := range tbl.Meta().ForeignKeys {
+				if fk.State != model.StatePublic {
+					continue
+				}
+				refSchema := fk.RefSchema
+				if refSchema.L == "" {
+					refSchema = v.dbInfo.Name
+				}
+				name := schemaAndTableName{schema: refSchema.L, table: fk.RefTable.L}
+				is.referredForeignKeyMap[name] = append(is.referredForeignKeyMap[name], &model.ReferredFKInfo{
+					Cols:        fk.RefCols,
+					ChildSchema: v.dbInfo.Name,
+					ChildTable:  tbl.Meta().Name,
+					ChildFKName: fk.Name,
+				})
+			}
+		}
+	}
+	// Keep the order stable, so that the foreign key actions are executed in a deterministic order.
+	for _, fks := range is.referredForeignKeyMap {
+		sort.Slice(fks, func(i, j int) bool {
+			if fks[i].ChildSchema.L != fks[j].ChildSchema.L {
+				return fks[i].ChildSchema.L < fks[j].ChildSchema.L
+			}
+			if fks[i].ChildTable.L != fks[j].ChildTable.L {
+				return fks[i].ChildTable.L < fks[j].ChildTable.L
+			}
+			return fks[i].ChildFKName.L < fks[j].ChildFKName.L
+		})
+	}
+}
+
 // FindTableByPartitionID finds the partition-table info by the partitionID.
 // FindTableByPartitionID will traverse all the tables to find the partitionID partition in which partition-table.
 func (is *infoSchema) FindTableByPartitionID(partitionID int64) (table.Table, *model.DBInfo, *model.PartitionDefinition) {
@@ -612,3 +669,66 @@ func (ts *TemporaryTableAttachedInfoSchema) SchemaByTable(tableInfo *model.Table
 
 	return ts.InfoSchema.SchemaByTable(tableInfo)
 }
+
+// HypoIndexAttachedInfoSchema implements InfoSchema, it attaches the hypothetical indexes to the tables.
+// A hypothetical index only exists in the metadata of the tables returned by TableByName and TableByID,
+// so the optimizer can take it into account, but it has no data and can never be read or written.
+type HypoIndexAttachedInfoSchema struct {
+	InfoSchema
+	// HypoIndexes maps the table IDs to the hypothetical indexes of the tables.
+	HypoIndexes map[int64][]*model.IndexInfo
+}
+
+// TableByName implements InfoSchema.TableByName
+func (hs *HypoIndexAttachedInfoSchema) TableByName(schema, table model.CIStr) (table.Table, error) {
+	tbl, err := hs.InfoSchema.TableByName(schema, table)
+	if err != nil {
+		return nil, err
+	}
+	return hs.attachHypoIndexes(tbl)
+}
+
+// TableByID implements InfoSchema.TableByID
+func (hs *HypoIndexAttachedInfoSchema) TableByID(id int64) (table.Table, bool) {
+	tbl, ok := hs.InfoSchema.TableByID(id)
+	if !ok {
+		return nil, false
+	}
+	tbl, err := hs.attachHypoIndexes(tbl)
+	return tbl, err == nil
+}
+
+func (hs *HypoIndexAttachedInfoSchema) attachHypoIndexes(tbl table.Table) (table.Table, error) {
+	hypoIndexes := hs.HypoIndexes[tbl.Meta().ID]
+	if len(hypoIndexes) == 0 {
+		return tbl, nil
+	}
+	tblInfo := tbl.Meta().Clone()
+	for _, idxInfo := range hypoIndexes {
+		if idxInfo = resolveHypoIndex(tblInfo, idxInfo); idxInfo != nil {
+			// The IDs are allocated after the ones of the real indexes, so they never collide with the IDs of the
+			// indexes and their statistics.
+			tblInfo.MaxIndexID++
+			idxInfo.ID = tblInfo.MaxIndexID
+			tblInfo.Indices = append(tblInfo.Indices, idxInfo)
+		}
+	}
+	return tables.TableFromMeta(tbl.Allocators(nil), tblInfo)
+}
+
+// resolveHypoIndex resolves the offsets of the columns of the hypothetical index in the table, since the table
+// may be altered after the index is created. It returns nil if the index isn't valid for the table anymore.
+func resolveHypoIndex(tblInfo *model.TableInfo, idxInfo *model.IndexInfo) *model.IndexInfo {
+	if tblInfo.FindIndexByName(idxInfo.Name.L) != nil {
+		return nil
+	}
+	idxInfo = idxInfo.Clone()
+	for _, idxCol := range idxInfo.Columns {
+		col := model.FindColumnInfo(tblInfo.Columns, idxCol.Name.L)
+		if col == nil || col.State != model.StatePublic {
+			return nil
+		}
+		idxCol.Offset = col.Offset
+	}
+	return idxInfo
+}
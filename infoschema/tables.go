@@ -80,7 +80,8 @@ const (
 	tablePlugins    = "PLUGINS"
 	// TableConstraints is the string constant of TABLE_CONSTRAINTS.
 	TableConstraints = "TABLE_CONSTRAINTS"
-	tableTriggers    = "TRIGGERS"
+	// TableTriggers is the string constant of infoschema table.
+	TableTriggers = "TRIGGERS"
 	// TableUserPrivileges is the string constant of infoschema user privilege table.
 	TableUserPrivileges   = "USER_PRIVILEGES"
 	tableSchemaPrivileges = "SCHEMA_PRIVILEGES"
@@ -90,9 +91,11 @@ const (
 	TableEngines = "ENGINES"
 	// TableViews is the string constant of infoschema table.
 	TableViews           = "VIEWS"
-	tableRoutines        = "ROUTINES"
-	tableParameters      = "PARAMETERS"
-	tableEvents          = "EVENTS"
+	// TableRoutines is the string constant of infoschema table.
+	TableRoutines   = "ROUTINES"
+	tableParameters = "PARAMETERS"
+	// TableEvents is the string constant of infoschema table.
+	TableEvents          = "EVENTS"
 	tableGlobalStatus    = "GLOBAL_STATUS"
 	tableGlobalVariables = "GLOBAL_VARIABLES"
 	tableSessionStatus   = "SESSION_STATUS"
@@ -178,6 +181,10 @@ const (
 	TableAttributes = "ATTRIBUTES"
 	// TablePlacementPolicies is the string constant of placement policies table.
 	TablePlacementPolicies = "PLACEMENT_POLICIES"
+	// TableCheckConstraints is the string constant of CHECK_CONSTRAINTS.
+	TableCheckConstraints = "CHECK_CONSTRAINTS"
+	// TableEventHistory is the string constant of the execution history of the scheduled events.
+	TableEventHistory = "EVENT_HISTORY"
 )
 
 const (
@@ -212,16 +219,16 @@ var tableIDMap = map[string]int64{
 	TableSessionVar:                         autoid.InformationSchemaDBID + 14,
 	tablePlugins:                            autoid.InformationSchemaDBID + 15,
 	TableConstraints:                        autoid.InformationSchemaDBID + 16,
-	tableTriggers:                           autoid.InformationSchemaDBID + 17,
+	TableTriggers:                           autoid.InformationSchemaDBID + 17,
 	TableUserPrivileges:                     autoid.InformationSchemaDBID + 18,
 	tableSchemaPrivileges:                   autoid.InformationSchemaDBID + 19,
 	tableTablePrivileges:                    autoid.InformationSchemaDBID + 20,
 	tableColumnPrivileges:                   autoid.InformationSchemaDBID + 21,
 	TableEngines:                            autoid.InformationSchemaDBID + 22,
 	TableViews:                              autoid.InformationSchemaDBID + 23,
-	tableRoutines:                           autoid.InformationSchemaDBID + 24,
+	TableRoutines:                           autoid.InformationSchemaDBID + 24,
 	tableParameters:                         autoid.InformationSchemaDBID + 25,
-	tableEvents:                             autoid.InformationSchemaDBID + 26,
+	TableEvents:                             autoid.InformationSchemaDBID + 26,
 	tableGlobalStatus:                       autoid.InformationSchemaDBID + 27,
 	tableGlobalVariables:                    autoid.InformationSchemaDBID + 28,
 	tableSessionStatus:                      autoid.InformationSchemaDBID + 29,
@@ -276,6 +283,8 @@ var tableIDMap = map[string]int64{
 	TableAttributes:                      autoid.InformationSchemaDBID + 77,
 	TableTiDBHotRegionsHistory:           autoid.InformationSchemaDBID + 78,
 	TablePlacementPolicies:               autoid.InformationSchemaDBID + 79,
+	TableCheckConstraints:                autoid.InformationSchemaDBID + 80,
+	TableEventHistory:                    autoid.InformationSchemaDBID + 81,
 }
 
 type columnInfo struct {
@@ -732,6 +741,16 @@ var tableEventsCols = []columnInfo{
 	{name: "DATABASE_COLLATION", tp: mysql.TypeVarchar, size: 32, flag: mysql.NotNullFlag},
 }
 
+var tableEventHistoryCols = []columnInfo{
+	{name: "EVENT_SCHEMA", tp: mysql.TypeVarchar, size: 64, flag: mysql.NotNullFlag},
+	{name: "EVENT_NAME", tp: mysql.TypeVarchar, size: 64, flag: mysql.NotNullFlag},
+	{name: "INSTANCE", tp: mysql.TypeVarchar, size: 64},
+	{name: "START_TIME", tp: mysql.TypeDatetime, size: 26, decimal: 6, flag: mysql.NotNullFlag},
+	{name: "END_TIME", tp: mysql.TypeDatetime, size: 26, decimal: 6},
+	{name: "STATE", tp: mysql.TypeVarchar, size: 16, flag: mysql.NotNullFlag},
+	{name: "ERROR_MESSAGE", tp: mysql.TypeBlob, size: types.UnspecifiedLength},
+}
+
 var tableGlobalStatusCols = []columnInfo{
 	{name: "VARIABLE_NAME", tp: mysql.TypeVarchar, size: 64, flag: mysql.NotNullFlag},
 	{name: "VARIABLE_VALUE", tp: mysql.TypeVarchar, size: 1024},
@@ -1464,6 +1483,13 @@ var tablePlacementPoliciesCols = []columnInfo{
 	{name: "LEARNERS", tp: mysql.TypeLonglong, size: 64},
 }
 
+var tableCheckConstraintsCols = []columnInfo{
+	{name: "CONSTRAINT_CATALOG", tp: mysql.TypeVarchar, size: 64, flag: mysql.NotNullFlag},
+	{name: "CONSTRAINT_SCHEMA", tp: mysql.TypeVarchar, size: 64, flag: mysql.NotNullFlag},
+	{name: "CONSTRAINT_NAME", tp: mysql.TypeVarchar, size: 64, flag: mysql.NotNullFlag},
+	{name: "CHECK_CLAUSE", tp: mysql.TypeLongBlob, size: types.UnspecifiedLength, flag: mysql.NotNullFlag},
+}
+
 // GetShardingInfo returns a nil or description string for the sharding information of given TableInfo.
 // The returned description string may be:
 //  - "NOT_SHARDED": for tables that SHARD_ROW_ID_BITS is not specified.
@@ -1496,6 +1522,8 @@ const (
 	PrimaryConstraint = "PRIMARY"
 	// UniqueKeyType is the string constant of UNIQUE.
 	UniqueKeyType = "UNIQUE"
+	// CheckConstraintType is the string constant of CHECK.
+	CheckConstraintType = "CHECK"
 )
 
 // ServerInfo represents the basic server information of single cluster component
@@ -1779,16 +1807,16 @@ var tableNameToColumns = map[string][]columnInfo{
 	TableSessionVar:                         sessionVarCols,
 	tablePlugins:                            pluginsCols,
 	TableConstraints:                        tableConstraintsCols,
-	tableTriggers:                           tableTriggersCols,
+	TableTriggers:                           tableTriggersCols,
 	TableUserPrivileges:                     tableUserPrivilegesCols,
 	tableSchemaPrivileges:                   tableSchemaPrivilegesCols,
 	tableTablePrivileges:                    tableTablePrivilegesCols,
 	tableColumnPrivileges:                   tableColumnPrivilegesCols,
 	TableEngines:                            tableEnginesCols,
 	TableViews:                              tableViewsCols,
-	tableRoutines:                           tableRoutinesCols,
+	TableRoutines:                           tableRoutinesCols,
 	tableParameters:                         tableParametersCols,
-	tableEvents:                             tableEventsCols,
+	TableEvents:                             tableEventsCols,
 	tableGlobalStatus:                       tableGlobalStatusCols,
 	tableGlobalVariables:                    tableGlobalVariablesCols,
 	tableSessionStatus:                      tableSessionStatusCols,
@@ -1834,6 +1862,8 @@ var tableNameToColumns = map[string][]columnInfo{
 	TableDataLockWaits:                      tableDataLockWaitsCols,
 	TableAttributes:                         tableAttributesCols,
 	TablePlacementPolicies:                  tablePlacementPoliciesCols,
+	TableCheckConstraints:                   tableCheckConstraintsCols,
+	TableEventHistory:                       tableEventHistoryCols,
 }
 
 func createInfoSchemaTable(_ autoid.Allocators, meta *model.TableInfo) (table.Table, error) {
@@ -1875,14 +1905,12 @@ func (it *infoschemaTable) getRows(ctx sessionctx.Context, cols []*table.Column)
 	sort.Sort(SchemasSorter(dbs))
 	switch it.meta.Name.O {
 	case tableFiles:
-	case tablePlugins, tableTriggers:
-	case tableRoutines:
+	case tablePlugins:
 	// TODO: Fill the following tables.
 	case tableSchemaPrivileges:
 	case tableTablePrivileges:
 	case tableColumnPrivileges:
 	case tableParameters:
-	case tableEvents:
 	case tableGlobalStatus:
 	case tableGlobalVariables:
 	case tableSessionStatus:
@@ -81,6 +81,9 @@ const (
 	TableToColumnMaps
 	// AssertionLevel controls how strict the assertions on data during transactions should be.
 	AssertionLevel
+	// XAPrepare makes Commit prewrite the transaction as a prepared XA transaction branch without committing it.
+	// The value is a func(startTS uint64, primary []byte) error, it's called before the prewrite to record the branch.
+	XAPrepare
 )
 
 // ReplicaReadType is the type of replica to read data from
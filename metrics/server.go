@@ -35,6 +35,14 @@ var (
 			Help:      "Counters of packet IO bytes.",
 		}, []string{LblType})
 
+	CompressedPacketIOCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "tidb",
+			Subsystem: "server",
+			Name:      "compressed_packet_io_bytes",
+			Help:      "Counters of payload bytes before and after compression in the compressed protocol.",
+		}, []string{LblType, LblAlgorithm, LblPayload})
+
 	QueryDurationHistogram = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Namespace: "tidb",
@@ -508,6 +508,7 @@ const (
 	ColumnOptionColumnFormat
 	ColumnOptionStorage
 	ColumnOptionAutoRandom
+	ColumnOptionSrid
 )
 
 var (
@@ -523,9 +524,10 @@ type ColumnOption struct {
 	node
 
 	Tp ColumnOptionType
-	// Expr is used for ColumnOptionDefaultValue/ColumnOptionOnUpdateColumnOptionGenerated.
+	// Expr is used for ColumnOptionDefaultValue/ColumnOptionOnUpdateColumnOptionGenerated/ColumnOptionSrid.
 	// For ColumnOptionDefaultValue or ColumnOptionOnUpdate, it's the target value.
 	// For ColumnOptionGenerated, it's the target expression.
+	// For ColumnOptionSrid, it's the spatial reference system ID of the geometry column.
 	Expr ExprNode
 	// Stored is only for ColumnOptionGenerated, default is false.
 	Stored bool
@@ -633,6 +635,11 @@ func (n *ColumnOption) Restore(ctx *format.RestoreCtx) error {
 			}
 			return nil
 		})
+	case ColumnOptionSrid:
+		ctx.WriteKeyWord("SRID ")
+		if err := n.Expr.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while splicing ColumnOption SRID Expr")
+		}
 	default:
 		return errors.New("An error occurred while splicing ColumnOption")
 	}
@@ -768,6 +775,7 @@ const (
 	ConstraintForeignKey
 	ConstraintFulltext
 	ConstraintCheck
+	ConstraintSpatial
 )
 
 // Constraint is constraint for table definition.
@@ -822,6 +830,8 @@ func (n *Constraint) Restore(ctx *format.RestoreCtx) error {
 		ctx.WriteKeyWord("UNIQUE INDEX")
 	case ConstraintFulltext:
 		ctx.WriteKeyWord("FULLTEXT")
+	case ConstraintSpatial:
+		ctx.WriteKeyWord("SPATIAL")
 	case ConstraintCheck:
 		if n.Name != "" {
 			ctx.WriteKeyWord("CONSTRAINT ")
@@ -1719,11 +1729,17 @@ type DropIndexStmt struct {
 	IndexName string
 	Table     *TableName
 	LockAlg   *IndexLockAndAlgorithm
+	// IsHypo indicates it drops a hypothetical index of the session.
+	IsHypo bool
 }
 
 // Restore implements Node interface.
 func (n *DropIndexStmt) Restore(ctx *format.RestoreCtx) error {
-	ctx.WriteKeyWord("DROP INDEX ")
+	if n.IsHypo {
+		ctx.WriteKeyWord("DROP HYPO INDEX ")
+	} else {
+		ctx.WriteKeyWord("DROP INDEX ")
+	}
 	if n.IfExists {
 		_ = ctx.WriteWithSpecialComments("", func() error {
 			ctx.WriteKeyWord("IF EXISTS ")
@@ -3369,6 +3385,17 @@ type SubPartitionDefinition struct {
 	Options []*TableOption
 }
 
+// Comment returns the comment option given to this subpartition.
+// The second return value indicates if the comment option exists.
+func (spd *SubPartitionDefinition) Comment() (string, bool) {
+	for _, opt := range spd.Options {
+		if opt.Tp == TableOptionComment {
+			return opt.StrValue, true
+		}
+	}
+	return "", false
+}
+
 func (spd *SubPartitionDefinition) Restore(ctx *format.RestoreCtx) error {
 	ctx.WriteKeyWord("SUBPARTITION ")
 	ctx.WriteName(spd.Name.O)
@@ -21,6 +21,7 @@ import (
 	"github.com/pingcap/tidb/parser/format"
 	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/parser/types"
 )
 
 var (
@@ -1020,6 +1021,154 @@ func (s *TableSample) Accept(v Visitor) (node Node, ok bool) {
 	return v.Leave(s)
 }
 
+// JSONTableColumnType is the kind of a column definition in JSON_TABLE.
+type JSONTableColumnType int8
+
+const (
+	// JSONTableColumnPath is `name type PATH path [on_empty] [on_error]`.
+	JSONTableColumnPath JSONTableColumnType = iota
+	// JSONTableColumnOrdinality is `name FOR ORDINALITY`.
+	JSONTableColumnOrdinality
+	// JSONTableColumnExistsPath is `name type EXISTS PATH path`.
+	JSONTableColumnExistsPath
+	// JSONTableColumnNested is `NESTED [PATH] path COLUMNS (...)`.
+	JSONTableColumnNested
+)
+
+// JSONTableOnResponseType is the behavior of a JSON_TABLE column when the path is empty or fails to convert.
+type JSONTableOnResponseType int8
+
+const (
+	// JSONTableOnResponseNull sets the column to NULL, it's also the implicit behavior.
+	JSONTableOnResponseNull JSONTableOnResponseType = iota
+	// JSONTableOnResponseError raises an error.
+	JSONTableOnResponseError
+	// JSONTableOnResponseDefault sets the column to the default value.
+	JSONTableOnResponseDefault
+)
+
+// JSONTableOnResponse is the `{NULL | ERROR | DEFAULT json_string} ON {EMPTY | ERROR}` clause of a JSON_TABLE column.
+type JSONTableOnResponse struct {
+	Tp      JSONTableOnResponseType
+	Default string
+}
+
+func (n *JSONTableOnResponse) restore(ctx *format.RestoreCtx, event string) {
+	switch n.Tp {
+	case JSONTableOnResponseNull:
+		ctx.WriteKeyWord(" NULL")
+	case JSONTableOnResponseError:
+		ctx.WriteKeyWord(" ERROR")
+	case JSONTableOnResponseDefault:
+		ctx.WriteKeyWord(" DEFAULT ")
+		ctx.WriteString(n.Default)
+	}
+	ctx.WriteKeyWord(" ON ")
+	ctx.WriteKeyWord(event)
+}
+
+// JSONTableColumn is a column definition in JSON_TABLE.
+type JSONTableColumn struct {
+	Tp   JSONTableColumnType
+	Name model.CIStr
+	// FieldType is the type of the column, it's nil for ordinality and nested columns.
+	FieldType *types.FieldType
+	Path      string
+	OnEmpty   *JSONTableOnResponse
+	OnError   *JSONTableOnResponse
+	// Columns is the nested column list of a nested column.
+	Columns []*JSONTableColumn
+}
+
+// Restore implements Node interface.
+func (n *JSONTableColumn) Restore(ctx *format.RestoreCtx) error {
+	if n.Tp == JSONTableColumnNested {
+		ctx.WriteKeyWord("NESTED PATH ")
+		ctx.WriteString(n.Path)
+		ctx.WriteKeyWord(" COLUMNS ")
+		return restoreJSONTableColumns(ctx, n.Columns)
+	}
+	ctx.WriteName(n.Name.O)
+	if n.Tp == JSONTableColumnOrdinality {
+		ctx.WriteKeyWord(" FOR ORDINALITY")
+		return nil
+	}
+	ctx.WritePlain(" ")
+	if err := n.FieldType.Restore(ctx); err != nil {
+		return errors.Annotatef(err, "An error occurred while restore JSONTableColumn.FieldType")
+	}
+	if n.Tp == JSONTableColumnExistsPath {
+		ctx.WriteKeyWord(" EXISTS")
+	}
+	ctx.WriteKeyWord(" PATH ")
+	ctx.WriteString(n.Path)
+	if n.OnEmpty != nil {
+		n.OnEmpty.restore(ctx, "EMPTY")
+	}
+	if n.OnError != nil {
+		n.OnError.restore(ctx, "ERROR")
+	}
+	return nil
+}
+
+func restoreJSONTableColumns(ctx *format.RestoreCtx, cols []*JSONTableColumn) error {
+	ctx.WritePlain("(")
+	for i, col := range cols {
+		if i > 0 {
+			ctx.WritePlain(", ")
+		}
+		if err := col.Restore(ctx); err != nil {
+			return errors.Annotatef(err, "An error occurred while restore JSONTable.Columns[%d]", i)
+		}
+	}
+	ctx.WritePlain(")")
+	return nil
+}
+
+// JSONTable is the JSON_TABLE(expr, path COLUMNS (...)) table function.
+// See https://dev.mysql.com/doc/refman/8.0/en/json-table-functions.html
+type JSONTable struct {
+	node
+
+	Expr    ExprNode
+	Path    string
+	Columns []*JSONTableColumn
+}
+
+func (*JSONTable) resultSet() {}
+
+// Restore implements Node interface.
+func (n *JSONTable) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("JSON_TABLE")
+	ctx.WritePlain("(")
+	if err := n.Expr.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore JSONTable.Expr")
+	}
+	ctx.WritePlain(", ")
+	ctx.WriteString(n.Path)
+	ctx.WriteKeyWord(" COLUMNS ")
+	if err := restoreJSONTableColumns(ctx, n.Columns); err != nil {
+		return err
+	}
+	ctx.WritePlain(")")
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *JSONTable) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*JSONTable)
+	node, ok := n.Expr.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Expr = node.(ExprNode)
+	return v.Leave(n)
+}
+
 type SelectStmtKind uint8
 
 const (
@@ -1499,6 +1648,14 @@ func (n *SelectStmt) Accept(v Visitor) (Node, bool) {
 		}
 	}
 
+	if n.SelectIntoOpt != nil {
+		node, ok := n.SelectIntoOpt.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.SelectIntoOpt = node.(*SelectIntoOption)
+	}
+
 	return v.Leave(n)
 }
 
@@ -3103,12 +3260,68 @@ type SelectIntoOption struct {
 	FileName   string
 	FieldsInfo *FieldsClause
 	LinesInfo  *LinesClause
+	// Vars are the targets of SELECT ... INTO var_list, each of them is either
+	// a user variable (*VariableExpr) or a local variable of a stored procedure (*ColumnNameExpr).
+	Vars []ExprNode
+	// FileOptions are the options of SELECT ... INTO OUTFILE, e.g. FORMAT = 'parquet'.
+	FileOptions []*SelectIntoFileOption
+}
+
+// SelectIntoFileOptionType is the type of an option of SELECT ... INTO OUTFILE.
+type SelectIntoFileOptionType int
+
+const (
+	// SelectIntoFileFormat is the format of the output files, 'csv' or 'parquet'.
+	SelectIntoFileFormat SelectIntoFileOptionType = iota + 1
+	// SelectIntoFileCompression is the compression of the output files.
+	SelectIntoFileCompression
+	// SelectIntoFileMaxSize is the max size in bytes of each output file.
+	SelectIntoFileMaxSize
+)
+
+// SelectIntoFileOption is an option of SELECT ... INTO OUTFILE.
+type SelectIntoFileOption struct {
+	Tp        SelectIntoFileOptionType
+	StrValue  string
+	UintValue uint64
+}
+
+// Restore implements Node interface.
+func (n *SelectIntoFileOption) Restore(ctx *format.RestoreCtx) error {
+	switch n.Tp {
+	case SelectIntoFileFormat:
+		ctx.WriteKeyWord("FORMAT ")
+		ctx.WritePlain("= ")
+		ctx.WriteString(n.StrValue)
+	case SelectIntoFileCompression:
+		ctx.WriteKeyWord("COMPRESSION ")
+		ctx.WritePlain("= ")
+		ctx.WriteString(n.StrValue)
+	case SelectIntoFileMaxSize:
+		ctx.WriteKeyWord("MAX_FILE_SIZE ")
+		ctx.WritePlainf("= %d", n.UintValue)
+	default:
+		return errors.Errorf("invalid SelectIntoFileOption: %d", n.Tp)
+	}
+	return nil
 }
 
 // Restore implements Node interface.
 func (n *SelectIntoOption) Restore(ctx *format.RestoreCtx) error {
+	if n.Tp == SelectIntoVars {
+		ctx.WriteKeyWord("INTO ")
+		for i, v := range n.Vars {
+			if i != 0 {
+				ctx.WritePlain(",")
+			}
+			if err := v.Restore(ctx); err != nil {
+				return errors.Annotatef(err, "An error occurred while restore SelectInto.Vars[%d]", i)
+			}
+		}
+		return nil
+	}
 	if n.Tp != SelectIntoOutfile {
-		// only support SELECT/TABLE/VALUES ... INTO OUTFILE statement now
+		// only support SELECT/TABLE/VALUES ... INTO OUTFILE and INTO var_list statement now
 		return errors.New("Unsupported SelectionInto type")
 	}
 
@@ -3124,6 +3337,12 @@ func (n *SelectIntoOption) Restore(ctx *format.RestoreCtx) error {
 			return errors.Annotate(err, "An error occurred while restore SelectInto.LinesInfo")
 		}
 	}
+	for i, opt := range n.FileOptions {
+		ctx.WritePlain(" ")
+		if err := opt.Restore(ctx); err != nil {
+			return errors.Annotatef(err, "An error occurred while restore SelectInto.FileOptions[%d]", i)
+		}
+	}
 	return nil
 }
 
@@ -3133,6 +3352,14 @@ func (n *SelectIntoOption) Accept(v Visitor) (Node, bool) {
 	if skipChildren {
 		return v.Leave(newNode)
 	}
+	n = newNode.(*SelectIntoOption)
+	for i, val := range n.Vars {
+		node, ok := val.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Vars[i] = node.(ExprNode)
+	}
 	return v.Leave(n)
 }
 
@@ -3200,6 +3427,8 @@ func (n *FrameClause) Restore(ctx *format.RestoreCtx) error {
 		ctx.WriteKeyWord("ROWS")
 	case Ranges:
 		ctx.WriteKeyWord("RANGE")
+	case Groups:
+		ctx.WriteKeyWord("GROUPS")
 	default:
 		return errors.New("Unsupported window function frame type")
 	}
@@ -211,6 +211,10 @@ const (
 	Ord             = "ord"
 	Position        = "position"
 	Quote           = "quote"
+	RegexpInStr     = "regexp_instr"
+	RegexpLike      = "regexp_like"
+	RegexpReplace   = "regexp_replace"
+	RegexpSubstr    = "regexp_substr"
 	Repeat          = "repeat"
 	Replace         = "replace"
 	Reverse         = "reverse"
@@ -328,6 +332,8 @@ const (
 	JSONRemove        = "json_remove"
 	JSONContains      = "json_contains"
 	JSONContainsPath  = "json_contains_path"
+	JSONMemberOf      = "json_memberof"
+	JSONOverlaps      = "json_overlaps"
 	JSONValid         = "json_valid"
 	JSONArrayAppend   = "json_array_append"
 	JSONArrayInsert   = "json_array_insert"
@@ -352,6 +358,51 @@ const (
 	NextVal = "nextval"
 	LastVal = "lastval"
 	SetVal  = "setval"
+
+	// Full-text search function, it's rewritten from MATCH (...) AGAINST (...).
+	FullTextMatch = "match"
+
+	// spatial functions
+	STGeomFromText       = "st_geomfromtext"
+	STGeometryFromText   = "st_geometryfromtext"
+	STPointFromText      = "st_pointfromtext"
+	STLineFromText       = "st_linefromtext"
+	STLineStringFromText = "st_linestringfromtext"
+	STPolyFromText       = "st_polyfromtext"
+	STPolygonFromText    = "st_polygonfromtext"
+	STGeomFromWKB        = "st_geomfromwkb"
+	STGeometryFromWKB    = "st_geometryfromwkb"
+	STGeomFromGeoJSON    = "st_geomfromgeojson"
+	Point                = "point"
+	LineString           = "linestring"
+	Polygon              = "polygon"
+	MultiPoint           = "multipoint"
+	MultiLineString      = "multilinestring"
+	MultiPolygon         = "multipolygon"
+	GeomCollection       = "geomcollection"
+	GeometryCollection   = "geometrycollection"
+	STAsText             = "st_astext"
+	STAsWKT              = "st_aswkt"
+	STAsBinary           = "st_asbinary"
+	STAsWKB              = "st_aswkb"
+	STAsGeoJSON          = "st_asgeojson"
+	STSRID               = "st_srid"
+	STX                  = "st_x"
+	STY                  = "st_y"
+	STGeometryType       = "st_geometrytype"
+	STEnvelope           = "st_envelope"
+	STArea               = "st_area"
+	STLength             = "st_length"
+	STContains           = "st_contains"
+	STWithin             = "st_within"
+	STIntersects         = "st_intersects"
+	STDisjoint           = "st_disjoint"
+	STEquals             = "st_equals"
+	MBRContains          = "mbrcontains"
+	MBRWithin            = "mbrwithin"
+	MBRIntersects        = "mbrintersects"
+	STDistance           = "st_distance"
+	STDistanceSphere     = "st_distance_sphere"
 )
 
 type FuncCallExprType int8
@@ -391,6 +442,20 @@ func (n *FuncCallExpr) Restore(ctx *format.RestoreCtx) error {
 		return nil
 	}
 
+	// MEMBER OF is an operator in the grammar, restore it as the operator.
+	if n.FnName.L == JSONMemberOf {
+		if err := n.Args[0].Restore(ctx); err != nil {
+			return errors.Annotatef(err, "An error occurred while restore FuncCallExpr.Args[0]")
+		}
+		ctx.WriteKeyWord(" MEMBER OF ")
+		ctx.WritePlain("(")
+		if err := n.Args[1].Restore(ctx); err != nil {
+			return errors.Annotatef(err, "An error occurred while restore FuncCallExpr.Args[1]")
+		}
+		ctx.WritePlain(")")
+		return nil
+	}
+
 	if len(n.Schema.String()) != 0 {
 		ctx.WriteName(n.Schema.O)
 		ctx.WritePlain(".")
@@ -569,6 +634,9 @@ func (n *FuncCastExpr) Restore(ctx *format.RestoreCtx) error {
 		}
 		ctx.WriteKeyWord(" AS ")
 		n.Tp.RestoreAsCastType(ctx, n.ExplicitCharSet)
+		if n.Tp.Array {
+			ctx.WriteKeyWord(" ARRAY")
+		}
 		ctx.WritePlain(")")
 	case CastConvertFunction:
 		ctx.WriteKeyWord("CONVERT")
@@ -596,6 +664,9 @@ func (n *FuncCastExpr) Format(w io.Writer) {
 		n.Expr.Format(w)
 		fmt.Fprint(w, " AS ")
 		n.Tp.FormatAsCastType(w, n.ExplicitCharSet)
+		if n.Tp.Array {
+			fmt.Fprint(w, " ARRAY")
+		}
 		fmt.Fprint(w, ")")
 	case CastConvertFunction:
 		fmt.Fprint(w, "CONVERT(")
@@ -850,15 +921,14 @@ type WindowFuncExpr struct {
 	F string
 	// Args is the function args.
 	Args []ExprNode
-	// Distinct cannot be true for most window functions, except `max` and `min`.
-	// We need to raise error if it is not allowed to be true.
+	// Distinct indicates whether the aggregate window function only processes distinct values.
 	Distinct bool
 	// IgnoreNull indicates how to handle null value.
-	// MySQL only supports `RESPECT NULLS`, so we need to raise error if it is true.
 	IgnoreNull bool
 	// FromLast indicates the calculation direction of this window function.
-	// MySQL only supports calculation from first, so we need to raise error if it is true.
 	FromLast bool
+	// Order is only used in GROUP_CONCAT.
+	Order *OrderByClause
 	// Spec is the specification of this window.
 	Spec WindowSpec
 }
@@ -867,14 +937,37 @@ type WindowFuncExpr struct {
 func (n *WindowFuncExpr) Restore(ctx *format.RestoreCtx) error {
 	ctx.WriteKeyWord(n.F)
 	ctx.WritePlain("(")
-	for i, v := range n.Args {
-		if i != 0 {
-			ctx.WritePlain(", ")
-		} else if n.Distinct {
-			ctx.WriteKeyWord("DISTINCT ")
+	if n.Distinct {
+		ctx.WriteKeyWord("DISTINCT ")
+	}
+	switch strings.ToLower(n.F) {
+	case AggFuncGroupConcat:
+		for i := 0; i < len(n.Args)-1; i++ {
+			if i != 0 {
+				ctx.WritePlain(", ")
+			}
+			if err := n.Args[i].Restore(ctx); err != nil {
+				return errors.Annotatef(err, "An error occurred while restore WindowFuncExpr.Args[%d]", i)
+			}
 		}
-		if err := v.Restore(ctx); err != nil {
-			return errors.Annotatef(err, "An error occurred while restore WindowFuncExpr.Args[%d]", i)
+		if n.Order != nil {
+			ctx.WritePlain(" ")
+			if err := n.Order.Restore(ctx); err != nil {
+				return errors.Annotate(err, "An error occurred while restore WindowFuncExpr.Order")
+			}
+		}
+		ctx.WriteKeyWord(" SEPARATOR ")
+		if err := n.Args[len(n.Args)-1].Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore WindowFuncExpr.Args SEPARATOR")
+		}
+	default:
+		for i, v := range n.Args {
+			if i != 0 {
+				ctx.WritePlain(", ")
+			}
+			if err := v.Restore(ctx); err != nil {
+				return errors.Annotatef(err, "An error occurred while restore WindowFuncExpr.Args[%d]", i)
+			}
 		}
 	}
 	ctx.WritePlain(")")
@@ -911,6 +1004,13 @@ func (n *WindowFuncExpr) Accept(v Visitor) (Node, bool) {
 		}
 		n.Args[i] = node.(ExprNode)
 	}
+	if n.Order != nil {
+		node, ok := n.Order.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Order = node.(*OrderByClause)
+	}
 	node, ok := n.Spec.Accept(v)
 	if !ok {
 		return n, false
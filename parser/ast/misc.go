@@ -47,6 +47,7 @@ var (
 	_ StmtNode = &SetDefaultRoleStmt{}
 	_ StmtNode = &SetStmt{}
 	_ StmtNode = &UseStmt{}
+	_ StmtNode = &XAStmt{}
 	_ StmtNode = &FlushStmt{}
 	_ StmtNode = &KillStmt{}
 	_ StmtNode = &CreateBindingStmt{}
@@ -632,6 +633,94 @@ func (n *RollbackStmt) Accept(v Visitor) (Node, bool) {
 	return v.Leave(n)
 }
 
+// XID identifies a branch of an XA transaction.
+// See https://dev.mysql.com/doc/refman/8.0/en/xa-statements.html
+type XID struct {
+	GTRID    string
+	BQual    string
+	FormatID uint64
+}
+
+// Restore writes the XID in the form of gtrid[, bqual[, formatID]].
+func (n *XID) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteString(n.GTRID)
+	if n.BQual != "" || n.FormatID != 1 {
+		ctx.WritePlain(",")
+		ctx.WriteString(n.BQual)
+	}
+	if n.FormatID != 1 {
+		ctx.WritePlainf(",%d", n.FormatID)
+	}
+	return nil
+}
+
+// XAStmtType is the type of an XA statement.
+type XAStmtType int
+
+// XA statement types.
+const (
+	XAStart XAStmtType = iota + 1
+	XAEnd
+	XAPrepare
+	XACommit
+	XARollback
+	XARecover
+)
+
+// XAStmt is a statement to manage XA transactions.
+// See https://dev.mysql.com/doc/refman/8.0/en/xa-statements.html
+type XAStmt struct {
+	stmtNode
+
+	Tp  XAStmtType
+	XID *XID
+	// OnePhase is set for XA COMMIT xid ONE PHASE.
+	OnePhase bool
+	// ConvertXID is set for XA RECOVER CONVERT XID.
+	ConvertXID bool
+}
+
+// Restore implements Node interface.
+func (n *XAStmt) Restore(ctx *format.RestoreCtx) error {
+	switch n.Tp {
+	case XAStart:
+		ctx.WriteKeyWord("XA START ")
+	case XAEnd:
+		ctx.WriteKeyWord("XA END ")
+	case XAPrepare:
+		ctx.WriteKeyWord("XA PREPARE ")
+	case XACommit:
+		ctx.WriteKeyWord("XA COMMIT ")
+	case XARollback:
+		ctx.WriteKeyWord("XA ROLLBACK ")
+	case XARecover:
+		ctx.WriteKeyWord("XA RECOVER")
+		if n.ConvertXID {
+			ctx.WriteKeyWord(" CONVERT XID")
+		}
+		return nil
+	default:
+		return errors.Errorf("invalid XA statement type: %d", n.Tp)
+	}
+	if err := n.XID.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore XAStmt.XID")
+	}
+	if n.OnePhase {
+		ctx.WriteKeyWord(" ONE PHASE")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *XAStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*XAStmt)
+	return v.Leave(n)
+}
+
 // UseStmt is a statement to use the DBName database as the current database.
 // See https://dev.mysql.com/doc/refman/5.7/en/use.html
 type UseStmt struct {
@@ -3430,7 +3519,7 @@ func (n *TableOptimizerHint) Restore(ctx *format.RestoreCtx) error {
 		ctx.WritePlainf("%d", n.HintData.(uint64))
 	case "nth_plan":
 		ctx.WritePlainf("%d", n.HintData.(int64))
-	case "tidb_hj", "tidb_smj", "tidb_inlj", "hash_join", "merge_join", "inl_join", "broadcast_join", "broadcast_join_local", "inl_hash_join", "inl_merge_join":
+	case "tidb_hj", "tidb_smj", "tidb_inlj", "hash_join", "merge_join", "inl_join", "broadcast_join", "broadcast_join_local", "inl_hash_join", "inl_merge_join", "leading":
 		for i, table := range n.Tables {
 			if i != 0 {
 				ctx.WritePlain(", ")
@@ -0,0 +1,1100 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ast
+
+import (
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/parser/format"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/types"
+)
+
+var (
+	_ DDLNode = &CreateProcedureStmt{}
+	_ DDLNode = &DropProcedureStmt{}
+	_ DDLNode = &CreateTriggerStmt{}
+	_ DDLNode = &DropTriggerStmt{}
+
+	_ StmtNode = &CreateEventStmt{}
+	_ StmtNode = &AlterEventStmt{}
+	_ StmtNode = &DropEventStmt{}
+
+	_ StmtNode = &ProcedureBlock{}
+	_ StmtNode = &ProcedureVarDecl{}
+	_ StmtNode = &ProcedureCursorDecl{}
+	_ StmtNode = &ProcedureHandlerDecl{}
+	_ StmtNode = &ProcedureIfStmt{}
+	_ StmtNode = &ProcedureLoopStmt{}
+	_ StmtNode = &ProcedureJumpStmt{}
+	_ StmtNode = &ProcedureOpenStmt{}
+	_ StmtNode = &ProcedureFetchStmt{}
+	_ StmtNode = &ProcedureCloseStmt{}
+
+	_ Node = &StoredProcedureParam{}
+	_ Node = &ProcedureCondition{}
+	_ Node = &ProcedureIfBranch{}
+	_ Node = &EventSchedule{}
+)
+
+// StoredProcedureParam is a parameter of a stored procedure.
+type StoredProcedureParam struct {
+	node
+
+	Mode model.ProcedureParamMode
+	Name string
+	Tp   *types.FieldType
+}
+
+// Restore implements Node interface.
+func (n *StoredProcedureParam) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord(n.Mode.String())
+	ctx.WritePlain(" ")
+	ctx.WriteName(n.Name)
+	ctx.WritePlain(" ")
+	if err := n.Tp.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore StoredProcedureParam.Tp")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *StoredProcedureParam) Accept(v Visitor) (Node, bool) {
+	newNode, _ := v.Enter(n)
+	return v.Leave(newNode)
+}
+
+// CreateProcedureStmt is a statement to create a stored procedure.
+// See https://dev.mysql.com/doc/refman/8.0/en/create-procedure.html
+type CreateProcedureStmt struct {
+	ddlNode
+
+	IfNotExists   bool
+	ProcedureName *TableName
+	Params        []*StoredProcedureParam
+	Body          StmtNode
+}
+
+// Restore implements Node interface.
+func (n *CreateProcedureStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("CREATE PROCEDURE ")
+	if n.IfNotExists {
+		ctx.WriteKeyWord("IF NOT EXISTS ")
+	}
+	if err := n.ProcedureName.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateProcedureStmt.ProcedureName")
+	}
+	ctx.WritePlain("(")
+	for i, param := range n.Params {
+		if i != 0 {
+			ctx.WritePlain(",")
+		}
+		if err := param.Restore(ctx); err != nil {
+			return errors.Annotatef(err, "An error occurred while restore CreateProcedureStmt.Params[%d]", i)
+		}
+	}
+	ctx.WritePlain(") ")
+	if err := n.Body.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateProcedureStmt.Body")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *CreateProcedureStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*CreateProcedureStmt)
+	node, ok := n.ProcedureName.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.ProcedureName = node.(*TableName)
+	for i, param := range n.Params {
+		node, ok := param.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Params[i] = node.(*StoredProcedureParam)
+	}
+	node, ok = n.Body.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Body = node.(StmtNode)
+	return v.Leave(n)
+}
+
+// DropProcedureStmt is a statement to drop a stored procedure.
+// See https://dev.mysql.com/doc/refman/8.0/en/drop-procedure.html
+type DropProcedureStmt struct {
+	ddlNode
+
+	IfExists      bool
+	ProcedureName *TableName
+}
+
+// Restore implements Node interface.
+func (n *DropProcedureStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("DROP PROCEDURE ")
+	if n.IfExists {
+		ctx.WriteKeyWord("IF EXISTS ")
+	}
+	if err := n.ProcedureName.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore DropProcedureStmt.ProcedureName")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *DropProcedureStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*DropProcedureStmt)
+	node, ok := n.ProcedureName.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.ProcedureName = node.(*TableName)
+	return v.Leave(n)
+}
+
+// TriggerOrder is the FOLLOWS or PRECEDES clause of a CREATE TRIGGER statement.
+type TriggerOrder struct {
+	Precedes bool
+	Trigger  model.CIStr
+}
+
+// CreateTriggerStmt is a statement to create a row-level trigger.
+// See https://dev.mysql.com/doc/refman/8.0/en/create-trigger.html
+type CreateTriggerStmt struct {
+	ddlNode
+
+	IfNotExists bool
+	TriggerName *TableName
+	Timing      model.TriggerTiming
+	Event       model.TriggerEvent
+	Table       *TableName
+	Order       *TriggerOrder
+	Body        StmtNode
+}
+
+// Restore implements Node interface.
+func (n *CreateTriggerStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("CREATE TRIGGER ")
+	if n.IfNotExists {
+		ctx.WriteKeyWord("IF NOT EXISTS ")
+	}
+	if err := n.TriggerName.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateTriggerStmt.TriggerName")
+	}
+	ctx.WritePlain(" ")
+	ctx.WriteKeyWord(n.Timing.String())
+	ctx.WritePlain(" ")
+	ctx.WriteKeyWord(n.Event.String())
+	ctx.WriteKeyWord(" ON ")
+	if err := n.Table.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateTriggerStmt.Table")
+	}
+	ctx.WriteKeyWord(" FOR EACH ROW ")
+	if n.Order != nil {
+		if n.Order.Precedes {
+			ctx.WriteKeyWord("PRECEDES ")
+		} else {
+			ctx.WriteKeyWord("FOLLOWS ")
+		}
+		ctx.WriteName(n.Order.Trigger.O)
+		ctx.WritePlain(" ")
+	}
+	if err := n.Body.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateTriggerStmt.Body")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *CreateTriggerStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*CreateTriggerStmt)
+	node, ok := n.TriggerName.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.TriggerName = node.(*TableName)
+	node, ok = n.Table.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Table = node.(*TableName)
+	node, ok = n.Body.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Body = node.(StmtNode)
+	return v.Leave(n)
+}
+
+// DropTriggerStmt is a statement to drop a trigger.
+// See https://dev.mysql.com/doc/refman/8.0/en/drop-trigger.html
+type DropTriggerStmt struct {
+	ddlNode
+
+	IfExists    bool
+	TriggerName *TableName
+}
+
+// Restore implements Node interface.
+func (n *DropTriggerStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("DROP TRIGGER ")
+	if n.IfExists {
+		ctx.WriteKeyWord("IF EXISTS ")
+	}
+	if err := n.TriggerName.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore DropTriggerStmt.TriggerName")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *DropTriggerStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*DropTriggerStmt)
+	node, ok := n.TriggerName.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.TriggerName = node.(*TableName)
+	return v.Leave(n)
+}
+
+// EventStatus is the ENABLE or DISABLE clause of an event.
+type EventStatus int
+
+// EventStatus values.
+const (
+	EventStatusNone EventStatus = iota
+	EventStatusEnable
+	EventStatusDisable
+	EventStatusSlavesideDisable
+)
+
+// String implements fmt.Stringer interface, it returns the status shown in information_schema.EVENTS.
+func (s EventStatus) String() string {
+	switch s {
+	case EventStatusEnable:
+		return "ENABLED"
+	case EventStatusDisable:
+		return "DISABLED"
+	case EventStatusSlavesideDisable:
+		return "SLAVESIDE_DISABLED"
+	}
+	return ""
+}
+
+func (s EventStatus) restore(ctx *format.RestoreCtx) {
+	switch s {
+	case EventStatusEnable:
+		ctx.WriteKeyWord(" ENABLE")
+	case EventStatusDisable:
+		ctx.WriteKeyWord(" DISABLE")
+	case EventStatusSlavesideDisable:
+		ctx.WriteKeyWord(" DISABLE ON SLAVE")
+	}
+}
+
+// EventCompletion is the ON COMPLETION clause of an event.
+type EventCompletion int
+
+// EventCompletion values.
+const (
+	EventCompletionNone EventCompletion = iota
+	EventCompletionNotPreserve
+	EventCompletionPreserve
+)
+
+// String implements fmt.Stringer interface, it returns the value shown in information_schema.EVENTS.
+func (c EventCompletion) String() string {
+	switch c {
+	case EventCompletionNotPreserve:
+		return "NOT PRESERVE"
+	case EventCompletionPreserve:
+		return "PRESERVE"
+	}
+	return ""
+}
+
+func (c EventCompletion) restore(ctx *format.RestoreCtx) {
+	if c != EventCompletionNone {
+		ctx.WriteKeyWord(" ON COMPLETION ")
+		ctx.WriteKeyWord(c.String())
+	}
+}
+
+// EventSchedule is the schedule of an event, the event fires once AT a timestamp,
+// or fires EVERY interval between STARTS and ENDS.
+type EventSchedule struct {
+	node
+
+	At     ExprNode
+	Every  ExprNode
+	Unit   TimeUnitType
+	Starts ExprNode
+	Ends   ExprNode
+}
+
+// Restore implements Node interface.
+func (n *EventSchedule) Restore(ctx *format.RestoreCtx) error {
+	if n.At != nil {
+		ctx.WriteKeyWord("AT ")
+		if err := n.At.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore EventSchedule.At")
+		}
+		return nil
+	}
+	ctx.WriteKeyWord("EVERY ")
+	if err := n.Every.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore EventSchedule.Every")
+	}
+	ctx.WritePlain(" ")
+	ctx.WriteKeyWord(n.Unit.String())
+	if n.Starts != nil {
+		ctx.WriteKeyWord(" STARTS ")
+		if err := n.Starts.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore EventSchedule.Starts")
+		}
+	}
+	if n.Ends != nil {
+		ctx.WriteKeyWord(" ENDS ")
+		if err := n.Ends.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore EventSchedule.Ends")
+		}
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *EventSchedule) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*EventSchedule)
+	for _, expr := range []*ExprNode{&n.At, &n.Every, &n.Starts, &n.Ends} {
+		if *expr == nil {
+			continue
+		}
+		node, ok := (*expr).Accept(v)
+		if !ok {
+			return n, false
+		}
+		*expr = node.(ExprNode)
+	}
+	return v.Leave(n)
+}
+
+// CreateEventStmt is a statement to create a scheduled event.
+// See https://dev.mysql.com/doc/refman/8.0/en/create-event.html
+type CreateEventStmt struct {
+	stmtNode
+
+	IfNotExists bool
+	EventName   *TableName
+	Schedule    *EventSchedule
+	Completion  EventCompletion
+	Status      EventStatus
+	Comment     *string
+	Body        StmtNode
+}
+
+// Restore implements Node interface.
+func (n *CreateEventStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("CREATE EVENT ")
+	if n.IfNotExists {
+		ctx.WriteKeyWord("IF NOT EXISTS ")
+	}
+	if err := n.EventName.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateEventStmt.EventName")
+	}
+	ctx.WriteKeyWord(" ON SCHEDULE ")
+	if err := n.Schedule.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateEventStmt.Schedule")
+	}
+	n.Completion.restore(ctx)
+	n.Status.restore(ctx)
+	if n.Comment != nil {
+		ctx.WriteKeyWord(" COMMENT ")
+		ctx.WriteString(*n.Comment)
+	}
+	ctx.WriteKeyWord(" DO ")
+	if err := n.Body.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateEventStmt.Body")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *CreateEventStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*CreateEventStmt)
+	node, ok := n.EventName.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.EventName = node.(*TableName)
+	node, ok = n.Schedule.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Schedule = node.(*EventSchedule)
+	node, ok = n.Body.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Body = node.(StmtNode)
+	return v.Leave(n)
+}
+
+// AlterEventStmt is a statement to change a scheduled event, the clauses not specified are unchanged.
+// See https://dev.mysql.com/doc/refman/8.0/en/alter-event.html
+type AlterEventStmt struct {
+	stmtNode
+
+	EventName  *TableName
+	Schedule   *EventSchedule
+	Completion EventCompletion
+	RenameTo   *TableName
+	Status     EventStatus
+	Comment    *string
+	Body       StmtNode
+}
+
+// Restore implements Node interface.
+func (n *AlterEventStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("ALTER EVENT ")
+	if err := n.EventName.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore AlterEventStmt.EventName")
+	}
+	if n.Schedule != nil {
+		ctx.WriteKeyWord(" ON SCHEDULE ")
+		if err := n.Schedule.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore AlterEventStmt.Schedule")
+		}
+	}
+	n.Completion.restore(ctx)
+	if n.RenameTo != nil {
+		ctx.WriteKeyWord(" RENAME TO ")
+		if err := n.RenameTo.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore AlterEventStmt.RenameTo")
+		}
+	}
+	n.Status.restore(ctx)
+	if n.Comment != nil {
+		ctx.WriteKeyWord(" COMMENT ")
+		ctx.WriteString(*n.Comment)
+	}
+	if n.Body != nil {
+		ctx.WriteKeyWord(" DO ")
+		if err := n.Body.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore AlterEventStmt.Body")
+		}
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *AlterEventStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*AlterEventStmt)
+	node, ok := n.EventName.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.EventName = node.(*TableName)
+	if n.Schedule != nil {
+		node, ok = n.Schedule.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Schedule = node.(*EventSchedule)
+	}
+	if n.RenameTo != nil {
+		node, ok = n.RenameTo.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.RenameTo = node.(*TableName)
+	}
+	if n.Body != nil {
+		node, ok = n.Body.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Body = node.(StmtNode)
+	}
+	return v.Leave(n)
+}
+
+// DropEventStmt is a statement to drop a scheduled event.
+// See https://dev.mysql.com/doc/refman/8.0/en/drop-event.html
+type DropEventStmt struct {
+	stmtNode
+
+	IfExists  bool
+	EventName *TableName
+}
+
+// Restore implements Node interface.
+func (n *DropEventStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("DROP EVENT ")
+	if n.IfExists {
+		ctx.WriteKeyWord("IF EXISTS ")
+	}
+	if err := n.EventName.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore DropEventStmt.EventName")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *DropEventStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*DropEventStmt)
+	node, ok := n.EventName.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.EventName = node.(*TableName)
+	return v.Leave(n)
+}
+
+func restoreProcedureStmts(ctx *format.RestoreCtx, stmts []StmtNode) error {
+	for i, stmt := range stmts {
+		if err := stmt.Restore(ctx); err != nil {
+			return errors.Annotatef(err, "An error occurred while restore procedure statement [%d]", i)
+		}
+		ctx.WritePlain("; ")
+	}
+	return nil
+}
+
+func acceptProcedureStmts(v Visitor, stmts []StmtNode) bool {
+	for i, stmt := range stmts {
+		node, ok := stmt.Accept(v)
+		if !ok {
+			return false
+		}
+		stmts[i] = node.(StmtNode)
+	}
+	return true
+}
+
+func restoreProcedureLabel(ctx *format.RestoreCtx, label string) {
+	if label != "" {
+		ctx.WriteName(label)
+		ctx.WritePlain(": ")
+	}
+}
+
+// ProcedureBlock is a BEGIN ... END compound statement inside a stored procedure.
+// Stmts contains the declarations followed by the other statements of the block.
+type ProcedureBlock struct {
+	stmtNode
+
+	Label string
+	Stmts []StmtNode
+}
+
+// Restore implements Node interface.
+func (n *ProcedureBlock) Restore(ctx *format.RestoreCtx) error {
+	restoreProcedureLabel(ctx, n.Label)
+	ctx.WriteKeyWord("BEGIN ")
+	if err := restoreProcedureStmts(ctx, n.Stmts); err != nil {
+		return err
+	}
+	ctx.WriteKeyWord("END")
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *ProcedureBlock) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*ProcedureBlock)
+	if !acceptProcedureStmts(v, n.Stmts) {
+		return n, false
+	}
+	return v.Leave(n)
+}
+
+// ProcedureVarDecl is a DECLARE statement of local variables.
+type ProcedureVarDecl struct {
+	stmtNode
+
+	Names   []string
+	Tp      *types.FieldType
+	Default ExprNode
+}
+
+// Restore implements Node interface.
+func (n *ProcedureVarDecl) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("DECLARE ")
+	for i, name := range n.Names {
+		if i != 0 {
+			ctx.WritePlain(",")
+		}
+		ctx.WriteName(name)
+	}
+	ctx.WritePlain(" ")
+	if err := n.Tp.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore ProcedureVarDecl.Tp")
+	}
+	if n.Default != nil {
+		ctx.WriteKeyWord(" DEFAULT ")
+		if err := n.Default.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore ProcedureVarDecl.Default")
+		}
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *ProcedureVarDecl) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*ProcedureVarDecl)
+	if n.Default != nil {
+		node, ok := n.Default.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Default = node.(ExprNode)
+	}
+	return v.Leave(n)
+}
+
+// ProcedureCursorDecl is a DECLARE ... CURSOR FOR statement.
+type ProcedureCursorDecl struct {
+	stmtNode
+
+	Name  string
+	Query StmtNode
+}
+
+// Restore implements Node interface.
+func (n *ProcedureCursorDecl) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("DECLARE ")
+	ctx.WriteName(n.Name)
+	ctx.WriteKeyWord(" CURSOR FOR ")
+	if err := n.Query.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore ProcedureCursorDecl.Query")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *ProcedureCursorDecl) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*ProcedureCursorDecl)
+	node, ok := n.Query.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Query = node.(StmtNode)
+	return v.Leave(n)
+}
+
+// ProcedureConditionType is the type of the condition of a handler.
+type ProcedureConditionType int
+
+// ProcedureConditionType types.
+const (
+	ProcedureConditionErrorCode ProcedureConditionType = iota
+	ProcedureConditionSQLState
+	ProcedureConditionSQLWarning
+	ProcedureConditionNotFound
+	ProcedureConditionSQLException
+)
+
+// ProcedureCondition is a condition which activates a handler.
+type ProcedureCondition struct {
+	node
+
+	Tp        ProcedureConditionType
+	SQLState  string
+	ErrorCode uint64
+}
+
+// Restore implements Node interface.
+func (n *ProcedureCondition) Restore(ctx *format.RestoreCtx) error {
+	switch n.Tp {
+	case ProcedureConditionErrorCode:
+		ctx.WritePlainf("%d", n.ErrorCode)
+	case ProcedureConditionSQLState:
+		ctx.WriteKeyWord("SQLSTATE ")
+		ctx.WriteString(n.SQLState)
+	case ProcedureConditionSQLWarning:
+		ctx.WriteKeyWord("SQLWARNING")
+	case ProcedureConditionNotFound:
+		ctx.WriteKeyWord("NOT FOUND")
+	case ProcedureConditionSQLException:
+		ctx.WriteKeyWord("SQLEXCEPTION")
+	default:
+		return errors.New("Unsupported procedure handler condition")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *ProcedureCondition) Accept(v Visitor) (Node, bool) {
+	newNode, _ := v.Enter(n)
+	return v.Leave(newNode)
+}
+
+// ProcedureHandlerAction is the action of a handler after its statement is executed.
+type ProcedureHandlerAction int
+
+// ProcedureHandlerAction types.
+const (
+	ProcedureHandlerContinue ProcedureHandlerAction = iota
+	ProcedureHandlerExit
+)
+
+// ProcedureHandlerDecl is a DECLARE ... HANDLER statement.
+type ProcedureHandlerDecl struct {
+	stmtNode
+
+	Action     ProcedureHandlerAction
+	Conditions []*ProcedureCondition
+	Stmt       StmtNode
+}
+
+// Restore implements Node interface.
+func (n *ProcedureHandlerDecl) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("DECLARE ")
+	if n.Action == ProcedureHandlerExit {
+		ctx.WriteKeyWord("EXIT")
+	} else {
+		ctx.WriteKeyWord("CONTINUE")
+	}
+	ctx.WriteKeyWord(" HANDLER FOR ")
+	for i, cond := range n.Conditions {
+		if i != 0 {
+			ctx.WritePlain(",")
+		}
+		if err := cond.Restore(ctx); err != nil {
+			return errors.Annotatef(err, "An error occurred while restore ProcedureHandlerDecl.Conditions[%d]", i)
+		}
+	}
+	ctx.WritePlain(" ")
+	if err := n.Stmt.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore ProcedureHandlerDecl.Stmt")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *ProcedureHandlerDecl) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*ProcedureHandlerDecl)
+	node, ok := n.Stmt.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Stmt = node.(StmtNode)
+	return v.Leave(n)
+}
+
+// ProcedureIfBranch is an IF or ELSEIF branch of a ProcedureIfStmt.
+type ProcedureIfBranch struct {
+	node
+
+	Cond  ExprNode
+	Stmts []StmtNode
+}
+
+// Restore implements Node interface.
+func (n *ProcedureIfBranch) Restore(ctx *format.RestoreCtx) error {
+	if err := n.Cond.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore ProcedureIfBranch.Cond")
+	}
+	ctx.WriteKeyWord(" THEN ")
+	return restoreProcedureStmts(ctx, n.Stmts)
+}
+
+// Accept implements Node Accept interface.
+func (n *ProcedureIfBranch) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*ProcedureIfBranch)
+	node, ok := n.Cond.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Cond = node.(ExprNode)
+	if !acceptProcedureStmts(v, n.Stmts) {
+		return n, false
+	}
+	return v.Leave(n)
+}
+
+// ProcedureIfStmt is an IF ... THEN ... [ELSEIF ...] [ELSE ...] END IF statement.
+type ProcedureIfStmt struct {
+	stmtNode
+
+	Branches []*ProcedureIfBranch
+	Else     []StmtNode
+}
+
+// Restore implements Node interface.
+func (n *ProcedureIfStmt) Restore(ctx *format.RestoreCtx) error {
+	for i, branch := range n.Branches {
+		if i == 0 {
+			ctx.WriteKeyWord("IF ")
+		} else {
+			ctx.WriteKeyWord("ELSEIF ")
+		}
+		if err := branch.Restore(ctx); err != nil {
+			return errors.Annotatef(err, "An error occurred while restore ProcedureIfStmt.Branches[%d]", i)
+		}
+	}
+	if n.Else != nil {
+		ctx.WriteKeyWord("ELSE ")
+		if err := restoreProcedureStmts(ctx, n.Else); err != nil {
+			return err
+		}
+	}
+	ctx.WriteKeyWord("END IF")
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *ProcedureIfStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*ProcedureIfStmt)
+	for i, branch := range n.Branches {
+		node, ok := branch.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Branches[i] = node.(*ProcedureIfBranch)
+	}
+	if !acceptProcedureStmts(v, n.Else) {
+		return n, false
+	}
+	return v.Leave(n)
+}
+
+// ProcedureLoopType is the type of a loop statement.
+type ProcedureLoopType int
+
+// ProcedureLoopType types.
+const (
+	ProcedureLoopWhile ProcedureLoopType = iota
+	ProcedureLoopRepeat
+	ProcedureLoopLoop
+)
+
+// ProcedureLoopStmt is a WHILE, REPEAT or LOOP statement.
+// Cond is the condition to continue a WHILE loop or to stop a REPEAT loop,
+// it is nil for LOOP.
+type ProcedureLoopStmt struct {
+	stmtNode
+
+	Tp    ProcedureLoopType
+	Label string
+	Cond  ExprNode
+	Stmts []StmtNode
+}
+
+// Restore implements Node interface.
+func (n *ProcedureLoopStmt) Restore(ctx *format.RestoreCtx) error {
+	restoreProcedureLabel(ctx, n.Label)
+	switch n.Tp {
+	case ProcedureLoopWhile:
+		ctx.WriteKeyWord("WHILE ")
+		if err := n.Cond.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore ProcedureLoopStmt.Cond")
+		}
+		ctx.WriteKeyWord(" DO ")
+		if err := restoreProcedureStmts(ctx, n.Stmts); err != nil {
+			return err
+		}
+		ctx.WriteKeyWord("END WHILE")
+	case ProcedureLoopRepeat:
+		ctx.WriteKeyWord("REPEAT ")
+		if err := restoreProcedureStmts(ctx, n.Stmts); err != nil {
+			return err
+		}
+		ctx.WriteKeyWord("UNTIL ")
+		if err := n.Cond.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore ProcedureLoopStmt.Cond")
+		}
+		ctx.WriteKeyWord(" END REPEAT")
+	case ProcedureLoopLoop:
+		ctx.WriteKeyWord("LOOP ")
+		if err := restoreProcedureStmts(ctx, n.Stmts); err != nil {
+			return err
+		}
+		ctx.WriteKeyWord("END LOOP")
+	default:
+		return errors.New("Unsupported procedure loop type")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *ProcedureLoopStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*ProcedureLoopStmt)
+	if n.Cond != nil {
+		node, ok := n.Cond.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Cond = node.(ExprNode)
+	}
+	if !acceptProcedureStmts(v, n.Stmts) {
+		return n, false
+	}
+	return v.Leave(n)
+}
+
+// ProcedureJumpStmt is a LEAVE or ITERATE statement.
+type ProcedureJumpStmt struct {
+	stmtNode
+
+	IsIterate bool
+	Label     string
+}
+
+// Restore implements Node interface.
+func (n *ProcedureJumpStmt) Restore(ctx *format.RestoreCtx) error {
+	if n.IsIterate {
+		ctx.WriteKeyWord("ITERATE ")
+	} else {
+		ctx.WriteKeyWord("LEAVE ")
+	}
+	ctx.WriteName(n.Label)
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *ProcedureJumpStmt) Accept(v Visitor) (Node, bool) {
+	newNode, _ := v.Enter(n)
+	return v.Leave(newNode)
+}
+
+// ProcedureOpenStmt is an OPEN cursor statement.
+type ProcedureOpenStmt struct {
+	stmtNode
+
+	Cursor string
+}
+
+// Restore implements Node interface.
+func (n *ProcedureOpenStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("OPEN ")
+	ctx.WriteName(n.Cursor)
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *ProcedureOpenStmt) Accept(v Visitor) (Node, bool) {
+	newNode, _ := v.Enter(n)
+	return v.Leave(newNode)
+}
+
+// ProcedureFetchStmt is a FETCH cursor INTO statement.
+type ProcedureFetchStmt struct {
+	stmtNode
+
+	Cursor string
+	Vars   []string
+}
+
+// Restore implements Node interface.
+func (n *ProcedureFetchStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("FETCH ")
+	ctx.WriteName(n.Cursor)
+	ctx.WriteKeyWord(" INTO ")
+	for i, name := range n.Vars {
+		if i != 0 {
+			ctx.WritePlain(",")
+		}
+		ctx.WriteName(name)
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *ProcedureFetchStmt) Accept(v Visitor) (Node, bool) {
+	newNode, _ := v.Enter(n)
+	return v.Leave(newNode)
+}
+
+// ProcedureCloseStmt is a CLOSE cursor statement.
+type ProcedureCloseStmt struct {
+	stmtNode
+
+	Cursor string
+}
+
+// Restore implements Node interface.
+func (n *ProcedureCloseStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("CLOSE ")
+	ctx.WriteName(n.Cursor)
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *ProcedureCloseStmt) Accept(v Visitor) (Node, bool) {
+	newNode, _ := v.Enter(n)
+	return v.Leave(newNode)
+}
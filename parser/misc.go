@@ -151,11 +151,13 @@ var tokenMap = map[string]int{
 	"ANALYZE":                  analyze,
 	"AND":                      and,
 	"ANY":                      any,
+	"ARRAY":                    array,
 	"APPROX_COUNT_DISTINCT":    approxCountDistinct,
 	"APPROX_PERCENTILE":        approxPercentile,
 	"AS":                       as,
 	"ASC":                      asc,
 	"ASCII":                    ascii,
+	"AT":                       at,
 	"ATTRIBUTES":               attributes,
 	"STATS_OPTIONS":            statsOptions,
 	"STATS_SAMPLE_RATE":        statsSampleRate,
@@ -171,6 +173,7 @@ var tokenMap = map[string]int{
 	"BACKUP":                   backup,
 	"BACKUPS":                  backups,
 	"BEGIN":                    begin,
+	"BEFORE":                   before,
 	"BETWEEN":                  between,
 	"BERNOULLI":                bernoulli,
 	"BIGINT":                   bigIntType,
@@ -229,6 +232,7 @@ var tokenMap = map[string]int{
 	"COMMIT":                   commit,
 	"COMMITTED":                committed,
 	"COMPACT":                  compact,
+	"COMPLETION":               completion,
 	"COMPRESSED":               compressed,
 	"COMPRESSION":              compression,
 	"CONCURRENCY":              concurrency,
@@ -299,15 +303,18 @@ var tokenMap = map[string]int{
 	"DRAINER":                  drainer,
 	"DROP":                     drop,
 	"DUAL":                     dual,
+	"EACH":                     each,
 	"DUMP":                     dump,
 	"DUPLICATE":                duplicate,
 	"DYNAMIC":                  dynamic,
 	"ELSE":                     elseKwd,
+	"EMPTY":                    emptyKwd,
 	"ENABLE":                   enable,
 	"ENABLED":                  enabled,
 	"ENCLOSED":                 enclosed,
 	"ENCRYPTION":               encryption,
 	"END":                      end,
+	"ENDS":                     ends,
 	"ENFORCED":                 enforced,
 	"ENGINE":                   engine,
 	"ENGINES":                  engines,
@@ -318,6 +325,7 @@ var tokenMap = map[string]int{
 	"ESCAPED":                  escaped,
 	"EVENT":                    event,
 	"EVENTS":                   events,
+	"EVERY":                    every,
 	"EVOLVE":                   evolve,
 	"EXACT":                    exact,
 	"EXCEPT":                   except,
@@ -355,6 +363,9 @@ var tokenMap = map[string]int{
 	"FUNCTION":                 function,
 	"GENERAL":                  general,
 	"GENERATED":                generated,
+	"GEOMCOLLECTION":           geomCollection,
+	"GEOMETRY":                 geometryType,
+	"GEOMETRYCOLLECTION":       geometryCollection,
 	"GET_FORMAT":               getFormat,
 	"GLOBAL":                   global,
 	"GRANT":                    grant,
@@ -372,6 +383,7 @@ var tokenMap = map[string]int{
 	"HOUR_MINUTE":              hourMinute,
 	"HOUR_SECOND":              hourSecond,
 	"HOUR":                     hour,
+	"HYPO":                     hypo,
 	"IDENTIFIED":               identified,
 	"IF":                       ifKwd,
 	"IGNORE":                   ignore,
@@ -413,6 +425,7 @@ var tokenMap = map[string]int{
 	"JSON_ARRAYAGG":            jsonArrayagg,
 	"JSON_OBJECTAGG":           jsonObjectAgg,
 	"JSON":                     jsonType,
+	"JSON_TABLE":               jsonTable,
 	"KEY_BLOCK_SIZE":           keyBlockSize,
 	"KEY":                      key,
 	"KEYS":                     keys,
@@ -435,6 +448,7 @@ var tokenMap = map[string]int{
 	"LIMIT":                    limit,
 	"LINEAR":                   linear,
 	"LINES":                    lines,
+	"LINESTRING":               lineString,
 	"LIST":                     list,
 	"LOAD":                     load,
 	"LOCAL":                    local,
@@ -451,6 +465,7 @@ var tokenMap = map[string]int{
 	"MASTER":                   master,
 	"MATCH":                    match,
 	"MAX_CONNECTIONS_PER_HOUR": maxConnectionsPerHour,
+	"MAX_FILE_SIZE":            maxFileSize,
 	"MAX_IDXNUM":               max_idxnum,
 	"MAX_MINUTES":              max_minutes,
 	"MAX_QUERIES_PER_HOUR":     maxQueriesPerHour,
@@ -463,9 +478,11 @@ var tokenMap = map[string]int{
 	"MEDIUMBLOB":               mediumblobType,
 	"MEDIUMINT":                mediumIntType,
 	"MEDIUMTEXT":               mediumtextType,
+	"MEMBER":                   member,
 	"MEMORY":                   memory,
 	"MERGE":                    merge,
 	"MICROSECOND":              microsecond,
+	"MIGRATE":                  migrate,
 	"MIN_ROWS":                 minRows,
 	"MIN":                      min,
 	"MINUTE_MICROSECOND":       minuteMicrosecond,
@@ -476,10 +493,14 @@ var tokenMap = map[string]int{
 	"MODE":                     mode,
 	"MODIFY":                   modify,
 	"MONTH":                    month,
+	"MULTILINESTRING":          multiLineString,
+	"MULTIPOINT":               multiPoint,
+	"MULTIPOLYGON":             multiPolygon,
 	"NAMES":                    names,
 	"NATIONAL":                 national,
 	"NATURAL":                  natural,
 	"NCHAR":                    ncharType,
+	"NESTED":                   nested,
 	"NEVER":                    never,
 	"NEXT_ROW_ID":              next_row_id,
 	"NEXT":                     next,
@@ -507,6 +528,7 @@ var tokenMap = map[string]int{
 	"OFFSET":                   offset,
 	"ON_DUPLICATE":             onDuplicate,
 	"ON":                       on,
+	"ONE":                      one,
 	"ONLINE":                   online,
 	"ONLY":                     only,
 	"OPEN":                     open,
@@ -518,6 +540,7 @@ var tokenMap = map[string]int{
 	"OPTIONALLY":               optionally,
 	"OR":                       or,
 	"ORDER":                    order,
+	"ORDINALITY":               ordinality,
 	"OUTER":                    outer,
 	"OUTFILE":                  outfile,
 	"PACK_KEYS":                packKeys,
@@ -528,15 +551,19 @@ var tokenMap = map[string]int{
 	"PARTITIONING":             partitioning,
 	"PARTITIONS":               partitions,
 	"PASSWORD":                 password,
+	"PATH":                     pathKwd,
 	"PERCENT":                  percent,
 	"PER_DB":                   per_db,
 	"PER_TABLE":                per_table,
 	"PESSIMISTIC":              pessimistic,
+	"PHASE":                    phase,
 	"PLACEMENT":                placement,
 	"PLAN":                     plan,
 	"PLAN_CACHE":               planCache,
 	"PLUGINS":                  plugins,
+	"POINT":                    point,
 	"POLICY":                   policy,
+	"POLYGON":                  polygon,
 	"POSITION":                 position,
 	"PRE_SPLIT_REGIONS":        preSplitRegions,
 	"PRECEDING":                preceding,
@@ -661,10 +688,12 @@ var tokenMap = map[string]int{
 	"SQL_TSI_WEEK":             sqlTsiWeek,
 	"SQL_TSI_YEAR":             sqlTsiYear,
 	"SQL":                      sql,
+	"SRID":                     srid,
 	"SSL":                      ssl,
 	"STALENESS":                staleness,
 	"START":                    start,
 	"STARTING":                 starting,
+	"STARTS":                   starts,
 	"STATISTICS":               statistics,
 	"STATS_AUTO_RECALC":        statsAutoRecalc,
 	"STATS_BUCKETS":            statsBuckets,
@@ -697,6 +726,7 @@ var tokenMap = map[string]int{
 	"SUBSTRING":                substring,
 	"SUM":                      sum,
 	"SUPER":                    super,
+	"SUSPEND":                  suspend,
 	"SWAPS":                    swaps,
 	"SWITCHES":                 switchesSym,
 	"SYSTEM":                   system,
@@ -792,11 +822,33 @@ var tokenMap = map[string]int{
 	"WITHOUT":                  without,
 	"WRITE":                    write,
 	"X509":                     x509,
+	"XA":                       xa,
+	"XID":                      xid,
 	"XOR":                      xor,
 	"YEAR_MONTH":               yearMonth,
 	"YEAR":                     yearType,
 	"ZEROFILL":                 zerofill,
 	"WAIT":                     wait,
+	"OUT":                      out,
+	"INOUT":                    inout,
+	"DECLARE":                  declare,
+	"CURSOR":                   cursor,
+	"HANDLER":                  handler,
+	"CLOSE":                    closeKwd,
+	"LEAVE":                    leave,
+	"ITERATE":                  iterate,
+	"LOOP":                     loop,
+	"WHILE":                    while,
+	"ELSEIF":                   elseIf,
+	"UNTIL":                    until,
+	"CONTINUE":                 continueKwd,
+	"EXIT":                     exit,
+	"SQLEXCEPTION":             sqlexception,
+	"SQLWARNING":               sqlwarning,
+	"SQLSTATE":                 sqlstate,
+	"FOUND":                    found,
+	"FOLLOWS":                  follows,
+	"PRECEDES":                 precedes,
 }
 
 // See https://dev.mysql.com/doc/refman/5.7/en/function-resolution.html for details.
@@ -931,6 +983,7 @@ var hintTokenMap = map[string]int{
 	"USE_CASCADES":            hintUseCascades,
 	"NTH_PLAN":                hintNthPlan,
 	"FORCE_INDEX":             hintForceIndex,
+	"LEADING":                 hintLeading,
 
 	// TiDB hint aliases
 	"TIDB_HJ":   hintHashJoin,
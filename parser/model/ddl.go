@@ -95,6 +95,14 @@ const (
 	ActionAlterTableStatsOptions        ActionType = 58
 	ActionAlterNoCacheTable             ActionType = 59
 	ActionCreateTables                  ActionType = 60
+	ActionReorganizePartition           ActionType = 61
+	ActionAlterTablePartitioning        ActionType = 62
+	ActionRemovePartitioning            ActionType = 63
+	ActionMultiSchemaChange             ActionType = 64
+	ActionCreateProcedure               ActionType = 65
+	ActionDropProcedure                 ActionType = 66
+	ActionCreateTrigger                 ActionType = 67
+	ActionDropTrigger                   ActionType = 68
 )
 
 var actionMap = map[ActionType]string{
@@ -156,7 +164,15 @@ var actionMap = map[ActionType]string{
 	ActionAlterTablePlacement:           "alter table placement",
 	ActionAlterCacheTable:               "alter table cache",
 	ActionAlterNoCacheTable:             "alter table nocache",
+	ActionReorganizePartition:           "alter table reorganize partition",
+	ActionAlterTablePartitioning:        "alter table partition by",
+	ActionRemovePartitioning:            "alter table remove partitioning",
+	ActionMultiSchemaChange:             "alter table multi-schema change",
 	ActionAlterTableStatsOptions:        "alter table statistics options",
+	ActionCreateProcedure:               "create procedure",
+	ActionDropProcedure:                 "drop procedure",
+	ActionCreateTrigger:                 "create trigger",
+	ActionDropTrigger:                   "drop trigger",
 
 	// `ActionAlterTableAlterPartition` is removed and will never be used.
 	// Just left a tombstone here for compatibility.
@@ -256,6 +272,105 @@ func NewDDLReorgMeta() *DDLReorgMeta {
 // MultiSchemaInfo keeps some information for multi schema change.
 type MultiSchemaInfo struct {
 	Warnings []*errors.Error
+
+	// SubJobs are the sub-jobs of an ActionMultiSchemaChange job, they are run in order.
+	SubJobs []*SubJob `json:"sub_jobs"`
+	// Revertible is true until all the sub-jobs reach their last revertible schema state.
+	Revertible bool `json:"revertible"`
+
+	// The following fields are only used to check the conflicts between the sub-jobs
+	// before the job is put into the queue.
+	AddColumns      []CIStr `json:"-"`
+	DropColumns     []CIStr `json:"-"`
+	ModifyColumns   []CIStr `json:"-"`
+	PositionColumns []CIStr `json:"-"`
+	RelativeColumns []CIStr `json:"-"`
+	AddIndexes      []CIStr `json:"-"`
+	DropIndexes     []CIStr `json:"-"`
+	AlterIndexes    []CIStr `json:"-"`
+}
+
+// NewMultiSchemaInfo new a MultiSchemaInfo for an ActionMultiSchemaChange job.
+func NewMultiSchemaInfo() *MultiSchemaInfo {
+	return &MultiSchemaInfo{
+		SubJobs:    nil,
+		Revertible: true,
+	}
+}
+
+// SubJob is a representation of one DDL schema change. A Job may contain zero (when multi-schema change is not applicable) or more SubJobs.
+type SubJob struct {
+	Type        ActionType      `json:"type"`
+	Args        []interface{}   `json:"-"`
+	RawArgs     json.RawMessage `json:"raw_args"`
+	SchemaState SchemaState     `json:"schema_state"`
+	SnapshotVer uint64          `json:"snapshot_ver"`
+	Revertible  bool            `json:"revertible"`
+	State       JobState        `json:"state"`
+	RowCount    int64           `json:"row_count"`
+	CtxVars     []interface{}   `json:"-"`
+	SchemaVer   int64           `json:"schema_version"`
+}
+
+// IsNormal returns true if the sub-job is normally running.
+func (sub *SubJob) IsNormal() bool {
+	switch sub.State {
+	case JobStateCancelling, JobStateCancelled,
+		JobStateRollingback, JobStateRollbackDone:
+		return false
+	default:
+		return true
+	}
+}
+
+// IsFinished returns true if the job is done.
+func (sub *SubJob) IsFinished() bool {
+	return sub.State == JobStateDone ||
+		sub.State == JobStateRollbackDone ||
+		sub.State == JobStateCancelled
+}
+
+// ToProxyJob converts a sub-job to a proxy job, so that the sub-job can be run by the handler of its type.
+func (sub *SubJob) ToProxyJob(parentJob *Job) Job {
+	return Job{
+		ID:              parentJob.ID,
+		Type:            sub.Type,
+		SchemaID:        parentJob.SchemaID,
+		TableID:         parentJob.TableID,
+		SchemaName:      parentJob.SchemaName,
+		State:           sub.State,
+		Error:           nil,
+		ErrorCount:      0,
+		RowCount:        sub.RowCount,
+		CtxVars:         sub.CtxVars,
+		Args:            sub.Args,
+		RawArgs:         sub.RawArgs,
+		SchemaState:     sub.SchemaState,
+		SnapshotVer:     sub.SnapshotVer,
+		RealStartTS:     parentJob.RealStartTS,
+		StartTS:         parentJob.StartTS,
+		DependencyID:    parentJob.DependencyID,
+		Query:           parentJob.Query,
+		BinlogInfo:      parentJob.BinlogInfo,
+		Version:         parentJob.Version,
+		ReorgMeta:       parentJob.ReorgMeta,
+		MultiSchemaInfo: &MultiSchemaInfo{Revertible: sub.Revertible},
+		Priority:        parentJob.Priority,
+		SeqNum:          parentJob.SeqNum,
+	}
+}
+
+// FromProxyJob converts a proxy job back to the sub-job after it is run.
+func (sub *SubJob) FromProxyJob(proxyJob *Job, ver int64) {
+	sub.Revertible = proxyJob.MultiSchemaInfo.Revertible
+	sub.SchemaState = proxyJob.SchemaState
+	sub.SnapshotVer = proxyJob.SnapshotVer
+	sub.Args = proxyJob.Args
+	sub.State = proxyJob.State
+	sub.RowCount = proxyJob.RowCount
+	if ver != 0 {
+		sub.SchemaVer = ver
+	}
 }
 
 // Job is for a DDL operation.
@@ -310,6 +425,14 @@ type Job struct {
 	SeqNum uint64 `json:"seq_num"`
 }
 
+// MarkNonRevertible marks the current sub-job of a multi-schema change non-revertible.
+// It means the sub-job has reached its last revertible schema state and it waits for the other sub-jobs.
+func (job *Job) MarkNonRevertible() {
+	if job.MultiSchemaInfo != nil {
+		job.MultiSchemaInfo.Revertible = false
+	}
+}
+
 // FinishTableJob is called when a job is finished.
 // It updates the job's state information and adds tblInfo to the binlog.
 func (job *Job) FinishTableJob(jobState JobState, schemaState SchemaState, ver int64, tblInfo *TableInfo) {
@@ -378,6 +501,18 @@ func (job *Job) Encode(updateRawArgs bool) ([]byte, error) {
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
+		if job.MultiSchemaInfo != nil {
+			for _, sub := range job.MultiSchemaInfo.SubJobs {
+				// Only update the args of the sub-jobs which have been decoded or changed.
+				if sub.Args == nil {
+					continue
+				}
+				sub.RawArgs, err = json.Marshal(sub.Args)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+			}
+		}
 	}
 
 	var b []byte
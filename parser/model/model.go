@@ -115,7 +115,10 @@ type ColumnInfo struct {
 	State               SchemaState `json:"state"`
 	Comment             string      `json:"comment"`
 	// A hidden column is used internally(expression index) and are not accessible by users.
-	Hidden           bool `json:"hidden"`
+	Hidden bool `json:"hidden"`
+	// Srid is the SRID attribute of the geometry column, all the values of the column must have the SRID.
+	// Nil means the values of any SRID are accepted.
+	Srid             *uint32 `json:"srid,omitempty"`
 	*ChangeStateInfo `json:"change_state_info"`
 	// Version means the version of the column info.
 	// Version = 0: For OriginDefaultValue and DefaultValue of timestamp column will stores the default time in system time zone.
@@ -362,6 +365,10 @@ type TableInfo struct {
 
 	// StatsOptions is used when do analyze/auto-analyze for each table
 	StatsOptions *StatsOptions `json:"stats_options"`
+
+	// Triggers are listed in the order in which they are activated
+	// for the same timing and event.
+	Triggers []*TriggerInfo `json:"triggers,omitempty"`
 }
 type TableCacheStatusType int
 
@@ -552,9 +559,34 @@ func (t *TableInfo) Clone() *TableInfo {
 		nt.ForeignKeys[i] = t.ForeignKeys[i].Clone()
 	}
 
+	if t.Constraints != nil {
+		nt.Constraints = make([]*ConstraintInfo, len(t.Constraints))
+		for i := range t.Constraints {
+			nt.Constraints[i] = t.Constraints[i].Clone()
+		}
+	}
+
+	if t.Triggers != nil {
+		nt.Triggers = make([]*TriggerInfo, len(t.Triggers))
+		for i := range t.Triggers {
+			nt.Triggers[i] = t.Triggers[i].Clone()
+		}
+	}
+
 	return &nt
 }
 
+// FindTrigger finds the trigger by name, it returns nil if not found.
+func (t *TableInfo) FindTrigger(name string) *TriggerInfo {
+	name = strings.ToLower(name)
+	for _, trig := range t.Triggers {
+		if trig.Name.L == name {
+			return trig
+		}
+	}
+	return nil
+}
+
 // GetPkName will return the pk name if pk exists.
 func (t *TableInfo) GetPkName() CIStr {
 	for _, colInfo := range t.Columns {
@@ -777,6 +809,124 @@ type ViewInfo struct {
 	Cols        []CIStr            `json:"view_cols"`
 }
 
+// ProcedureParamMode is the mode of a stored procedure parameter.
+type ProcedureParamMode int
+
+// ProcedureParamMode types.
+const (
+	ProcedureParamIn ProcedureParamMode = iota
+	ProcedureParamOut
+	ProcedureParamInOut
+)
+
+// String implements fmt.Stringer interface.
+func (m ProcedureParamMode) String() string {
+	switch m {
+	case ProcedureParamOut:
+		return "OUT"
+	case ProcedureParamInOut:
+		return "INOUT"
+	default:
+		return "IN"
+	}
+}
+
+// ProcedureParamInfo provides meta data describing a stored procedure parameter.
+type ProcedureParamInfo struct {
+	Name CIStr              `json:"name"`
+	Mode ProcedureParamMode `json:"mode"`
+	Tp   *types.FieldType   `json:"type"`
+}
+
+// ProcedureInfo provides meta data describing a stored procedure.
+type ProcedureInfo struct {
+	ID      int64                 `json:"id"`
+	Name    CIStr                 `json:"name"`
+	Params  []*ProcedureParamInfo `json:"params"`
+	Body    string                `json:"body"`
+	Definer *auth.UserIdentity    `json:"definer"`
+	// SQLMode is the sql_mode of the session which creates the procedure,
+	// the body is parsed and executed with it.
+	SQLMode string `json:"sql_mode"`
+	// Charset and Collate are the character_set_client and collation_connection
+	// of the session which creates the procedure.
+	Charset    string    `json:"charset"`
+	Collate    string    `json:"collate"`
+	CreateTime time.Time `json:"create_time"`
+}
+
+// Clone clones ProcedureInfo.
+func (p *ProcedureInfo) Clone() *ProcedureInfo {
+	nProc := *p
+	nProc.Params = make([]*ProcedureParamInfo, len(p.Params))
+	for i, param := range p.Params {
+		nParam := *param
+		nParam.Tp = param.Tp.Clone()
+		nProc.Params[i] = &nParam
+	}
+	return &nProc
+}
+
+// TriggerTiming is the action time of a trigger.
+type TriggerTiming int
+
+// TriggerTiming types.
+const (
+	TriggerBefore TriggerTiming = iota
+	TriggerAfter
+)
+
+// String implements fmt.Stringer interface.
+func (t TriggerTiming) String() string {
+	if t == TriggerAfter {
+		return "AFTER"
+	}
+	return "BEFORE"
+}
+
+// TriggerEvent is the kind of operation which activates a trigger.
+type TriggerEvent int
+
+// TriggerEvent types.
+const (
+	TriggerInsert TriggerEvent = iota
+	TriggerUpdate
+	TriggerDelete
+)
+
+// String implements fmt.Stringer interface.
+func (e TriggerEvent) String() string {
+	switch e {
+	case TriggerUpdate:
+		return "UPDATE"
+	case TriggerDelete:
+		return "DELETE"
+	default:
+		return "INSERT"
+	}
+}
+
+// TriggerInfo provides meta data describing a row-level trigger.
+type TriggerInfo struct {
+	ID      int64              `json:"id"`
+	Name    CIStr              `json:"name"`
+	Timing  TriggerTiming      `json:"timing"`
+	Event   TriggerEvent       `json:"event"`
+	Body    string             `json:"body"`
+	Definer *auth.UserIdentity `json:"definer"`
+	// SQLMode, Charset and Collate have the same meaning as the ones in ProcedureInfo.
+	SQLMode    string    `json:"sql_mode"`
+	Charset    string    `json:"charset"`
+	Collate    string    `json:"collate"`
+	CreateTime time.Time `json:"create_time"`
+}
+
+// Clone clones TriggerInfo.
+func (t *TriggerInfo) Clone() *TriggerInfo {
+	nt := *t
+	return &nt
+}
+
 const (
 	DefaultSequenceCacheBool          = true
 	DefaultSequenceCycleBool          = false
@@ -808,6 +958,9 @@ type PartitionType int
 
 // Partition types.
 const (
+	// PartitionTypeNone is only used as the single partition of a non-partitioned table, when the table is being
+	// partitioned or the partitioning of it is being removed.
+	PartitionTypeNone       PartitionType = 0
 	PartitionTypeRange      PartitionType = 1
 	PartitionTypeHash       PartitionType = 2
 	PartitionTypeList       PartitionType = 3
@@ -827,6 +980,8 @@ func (p PartitionType) String() string {
 		return "KEY"
 	case PartitionTypeSystemTime:
 		return "SYSTEM_TIME"
+	case PartitionTypeNone:
+		return "NONE"
 	default:
 		return ""
 	}
@@ -851,6 +1006,69 @@ type PartitionInfo struct {
 	DroppingDefinitions []PartitionDefinition `json:"dropping_definitions"`
 	States              []PartitionState      `json:"states"`
 	Num                 uint64                `json:"num"`
+	// DDLState is the state of the reorganize partition in progress, the DroppingDefinitions
+	// are being reorganized into the AddingDefinitions when it's not StateNone.
+	DDLState SchemaState `json:"ddl_state"`
+	// DDLType, DDLExpr and DDLColumns are the partitioning of the AddingDefinitions before they are swapped with
+	// the Definitions, and the partitioning of the DroppingDefinitions after that.
+	DDLType    PartitionType `json:"ddl_type"`
+	DDLExpr    string        `json:"ddl_expr"`
+	DDLColumns []CIStr       `json:"ddl_columns"`
+	// DDLChangedIndex is filled when the table is being partitioned or the partitioning is being removed.
+	// The indexes that need to be rebuilt (i.e. the global indexes) are replaced by new indexes, the value
+	// is true for the new indexes which belong to the AddingDefinitions, and false for the replaced ones.
+	DDLChangedIndex map[int64]bool `json:"ddl_changed_index"`
+
+	// SubType, SubExpr, SubColumns and SubNum describe the subpartitioning of a table created with
+	// `PARTITION BY ... SUBPARTITION BY`, SubNum is 0 when the table is not subpartitioned.
+	// For a subpartitioned table, ParentDefinitions are the first level partitions, which have no
+	// physical IDs, and Definitions are the subpartitions, the subpartitions of ParentDefinitions[i]
+	// are Definitions[i*SubNum : (i+1)*SubNum].
+	SubType           PartitionType         `json:"sub_type"`
+	SubExpr           string                `json:"sub_expr"`
+	SubColumns        []CIStr               `json:"sub_columns"`
+	SubNum            uint64                `json:"sub_num"`
+	ParentDefinitions []PartitionDefinition `json:"parent_definitions"`
+}
+
+// IsSubPartitioned returns true if the table has two levels of partitioning.
+func (pi *PartitionInfo) IsSubPartitioned() bool {
+	return pi.SubNum > 0
+}
+
+// FirstLevel returns the partitioning of the first level, whose Definitions are the ParentDefinitions
+// of a subpartitioned table. It returns pi itself if the table is not subpartitioned.
+func (pi *PartitionInfo) FirstLevel() *PartitionInfo {
+	if !pi.IsSubPartitioned() {
+		return pi
+	}
+	npi := *pi
+	npi.Definitions = pi.ParentDefinitions
+	npi.Num = uint64(len(pi.ParentDefinitions))
+	npi.SubType, npi.SubExpr, npi.SubColumns, npi.SubNum, npi.ParentDefinitions = PartitionTypeNone, "", nil, 0, nil
+	return &npi
+}
+
+// SubLevel returns the partitioning of the subpartitions of a single first level partition,
+// the Definitions are the subpartitions of the first partition. It returns nil if the table is
+// not subpartitioned.
+func (pi *PartitionInfo) SubLevel() *PartitionInfo {
+	if !pi.IsSubPartitioned() {
+		return nil
+	}
+	return &PartitionInfo{
+		Type:        pi.SubType,
+		Expr:        pi.SubExpr,
+		Columns:     pi.SubColumns,
+		Enable:      pi.Enable,
+		Definitions: pi.Definitions[:pi.SubNum],
+		Num:         pi.SubNum,
+	}
+}
+
+// SubDefinitions returns the subpartitions of the i-th first level partition.
+func (pi *PartitionInfo) SubDefinitions(i int) []PartitionDefinition {
+	return pi.Definitions[uint64(i)*pi.SubNum : uint64(i+1)*pi.SubNum]
 }
 
 // GetNameByID gets the partition name by ID.
@@ -993,6 +1211,8 @@ func (t IndexType) String() string {
 		return "HASH"
 	case IndexTypeRtree:
 		return "RTREE"
+	case IndexTypeHypo:
+		return "HYPO"
 	default:
 		return ""
 	}
@@ -1004,6 +1224,8 @@ const (
 	IndexTypeBtree
 	IndexTypeHash
 	IndexTypeRtree
+	// IndexTypeHypo is the type of the hypothetical index, which only exists in the metadata seen by the optimizer.
+	IndexTypeHypo
 )
 
 // IndexInfo provides meta data describing a DB index.
@@ -1021,6 +1243,11 @@ type IndexInfo struct {
 	Primary   bool           `json:"is_primary"`   // Whether the index is primary key.
 	Invisible bool           `json:"is_invisible"` // Whether the index is invisible.
 	Global    bool           `json:"is_global"`    // Whether the index is global.
+	MVIndex   bool           `json:"mv_index"`     // Whether the index is multi-valued index.
+	FullText  bool           `json:"is_fulltext"`  // Whether the index is FULLTEXT index.
+	Spatial   bool           `json:"is_spatial"`   // Whether the index is SPATIAL index.
+	// FullTextParser is the name of the parser of the FULLTEXT index, the empty name means the default parser.
+	FullTextParser string `json:"fulltext_parser,omitempty"`
 }
 
 // Clone clones IndexInfo.
@@ -1077,14 +1304,25 @@ func (t *TableInfo) FindConstraintInfoByName(constrName string) *ConstraintInfo
 
 // FKInfo provides meta data describing a foreign key constraint.
 type FKInfo struct {
-	ID       int64       `json:"id"`
-	Name     CIStr       `json:"fk_name"`
-	RefTable CIStr       `json:"ref_table"`
-	RefCols  []CIStr     `json:"ref_cols"`
-	Cols     []CIStr     `json:"cols"`
-	OnDelete int         `json:"on_delete"`
-	OnUpdate int         `json:"on_update"`
-	State    SchemaState `json:"state"`
+	ID   int64 `json:"id"`
+	Name CIStr `json:"fk_name"`
+	// RefSchema is empty when the referenced table is in the same schema as the child table.
+	RefSchema CIStr       `json:"ref_schema"`
+	RefTable  CIStr       `json:"ref_table"`
+	RefCols   []CIStr     `json:"ref_cols"`
+	Cols      []CIStr     `json:"cols"`
+	OnDelete  int         `json:"on_delete"`
+	OnUpdate  int         `json:"on_update"`
+	State     SchemaState `json:"state"`
+}
+
+// ReferredFKInfo provides the foreign key in the child table that refers to the parent table.
+type ReferredFKInfo struct {
+	// Cols are the referred columns in the parent table.
+	Cols        []CIStr `json:"cols"`
+	ChildSchema CIStr   `json:"child_schema"`
+	ChildTable  CIStr   `json:"child_table"`
+	ChildFKName CIStr   `json:"child_fk_name"`
 }
 
 // Clone clones FKInfo.
@@ -1099,15 +1337,42 @@ func (fk *FKInfo) Clone() *FKInfo {
 	return &nfk
 }
 
+// String returns the definition of the foreign key in the child table `db`.`tb`,
+// it's used in the error message of the foreign key constraint checks.
+func (fk *FKInfo) String(db, tb string) string {
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "`%s`.`%s`, CONSTRAINT `%s` FOREIGN KEY (", db, tb, fk.Name.O)
+	for i, col := range fk.Cols {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString("`" + col.O + "`")
+	}
+	buf.WriteString(") REFERENCES `")
+	if fk.RefSchema.L != "" && fk.RefSchema.L != strings.ToLower(db) {
+		buf.WriteString(fk.RefSchema.O + "`.`")
+	}
+	buf.WriteString(fk.RefTable.O + "` (")
+	for i, col := range fk.RefCols {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString("`" + col.O + "`")
+	}
+	buf.WriteString(")")
+	return buf.String()
+}
+
 // DBInfo provides meta data describing a DB.
 type DBInfo struct {
-	ID                 int64          `json:"id"`      // Database ID
-	Name               CIStr          `json:"db_name"` // DB name.
-	Charset            string         `json:"charset"`
-	Collate            string         `json:"collate"`
-	Tables             []*TableInfo   `json:"-"` // Tables in the DB.
-	State              SchemaState    `json:"state"`
-	PlacementPolicyRef *PolicyRefInfo `json:"policy_ref_info"`
+	ID                 int64            `json:"id"`      // Database ID
+	Name               CIStr            `json:"db_name"` // DB name.
+	Charset            string           `json:"charset"`
+	Collate            string           `json:"collate"`
+	Tables             []*TableInfo     `json:"-"` // Tables in the DB.
+	State              SchemaState      `json:"state"`
+	PlacementPolicyRef *PolicyRefInfo   `json:"policy_ref_info"`
+	Procedures         []*ProcedureInfo `json:"procedures,omitempty"` // Stored procedures in the DB.
 }
 
 // Clone clones DBInfo.
@@ -1117,9 +1382,26 @@ func (db *DBInfo) Clone() *DBInfo {
 	for i := range db.Tables {
 		newInfo.Tables[i] = db.Tables[i].Clone()
 	}
+	if db.Procedures != nil {
+		newInfo.Procedures = make([]*ProcedureInfo, len(db.Procedures))
+		for i := range db.Procedures {
+			newInfo.Procedures[i] = db.Procedures[i].Clone()
+		}
+	}
 	return &newInfo
 }
 
+// FindProcedure finds the stored procedure by name, it returns nil if not found.
+func (db *DBInfo) FindProcedure(name string) *ProcedureInfo {
+	name = strings.ToLower(name)
+	for _, proc := range db.Procedures {
+		if proc.Name.L == name {
+			return proc
+		}
+	}
+	return nil
+}
+
 // Copy shallow copies DBInfo.
 func (db *DBInfo) Copy() *DBInfo {
 	newInfo := *db
@@ -155,8 +155,26 @@ const (
 	ClientPluginAuth
 	ClientConnectAtts
 	ClientPluginAuthLenencClientData
+	ClientCanHandleExpiredPasswords
+	ClientSessionTrack
+	ClientDeprecateEOF
+	ClientOptionalResultsetMetadata
+	ClientZstdCompressionAlgorithm
 )
 
+// Compression algorithms of the compressed client/server protocol.
+const (
+	CompressionNone = iota
+	CompressionZlib
+	CompressionZstd
+)
+
+// DefaultZstdCompressionLevel is the zstd level used when the client does not specify one.
+const DefaultZstdCompressionLevel = 3
+
+// MinCompressLength is the payload length below which the compressed protocol sends data uncompressed.
+const MinCompressLength = 50
+
 // Cache type information.
 const (
 	TypeNoCache byte = 0xff
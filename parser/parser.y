@@ -52,6 +52,7 @@ import (
 	/*yy:token "%c"     */
 	identifier "identifier"
 	asof       "AS OF"
+	memberof   "MEMBER OF"
 
 	/*yy:token "_%c"    */
 	underscoreCS "UNDERSCORE_CHARSET"
@@ -78,6 +79,7 @@ import (
 	and               "AND"
 	as                "AS"
 	asc               "ASC"
+	before            "BEFORE"
 	between           "BETWEEN"
 	bigIntType        "BIGINT"
 	binaryType        "BINARY"
@@ -122,6 +124,7 @@ import (
 	doubleType        "DOUBLE"
 	drop              "DROP"
 	dual              "DUAL"
+	each              "EACH"
 	elseKwd           "ELSE"
 	enclosed          "ENCLOSED"
 	escaped           "ESCAPED"
@@ -166,6 +169,7 @@ import (
 	int4Type          "INT4"
 	int8Type          "INT8"
 	join              "JOIN"
+	jsonTable         "JSON_TABLE"
 	key               "KEY"
 	keys              "KEYS"
 	kill              "KILL"
@@ -285,6 +289,8 @@ import (
 	yearMonth         "YEAR_MONTH"
 	zerofill          "ZEROFILL"
 	natural           "NATURAL"
+	out               "OUT"
+	inout             "INOUT"
 
 	/* The following tokens belong to UnReservedKeyword. Notice: make sure these tokens are contained in UnReservedKeyword. */
 	account               "ACCOUNT"
@@ -296,6 +302,7 @@ import (
 	algorithm             "ALGORITHM"
 	always                "ALWAYS"
 	any                   "ANY"
+	array                 "ARRAY"
 	ascii                 "ASCII"
 	attributes            "ATTRIBUTES"
 	statsOptions          "STATS_OPTIONS"
@@ -376,6 +383,7 @@ import (
 	do                    "DO"
 	duplicate             "DUPLICATE"
 	dynamic               "DYNAMIC"
+	emptyKwd              "EMPTY"
 	enable                "ENABLE"
 	enabled               "ENABLED"
 	encryption            "ENCRYPTION"
@@ -414,6 +422,7 @@ import (
 	history               "HISTORY"
 	hosts                 "HOSTS"
 	hour                  "HOUR"
+	hypo                  "HYPO"
 	identified            "IDENTIFIED"
 	identSQLErrors        "ERRORS"
 	importKwd             "IMPORT"
@@ -444,6 +453,7 @@ import (
 	location              "LOCATION"
 	logs                  "LOGS"
 	master                "MASTER"
+	maxFileSize           "MAX_FILE_SIZE"
 	max_idxnum            "MAX_IDXNUM"
 	max_minutes           "MAX_MINUTES"
 	maxConnectionsPerHour "MAX_CONNECTIONS_PER_HOUR"
@@ -452,6 +462,7 @@ import (
 	maxUpdatesPerHour     "MAX_UPDATES_PER_HOUR"
 	maxUserConnections    "MAX_USER_CONNECTIONS"
 	mb                    "MB"
+	member                "MEMBER"
 	memory                "MEMORY"
 	merge                 "MERGE"
 	microsecond           "MICROSECOND"
@@ -464,6 +475,7 @@ import (
 	names                 "NAMES"
 	national              "NATIONAL"
 	ncharType             "NCHAR"
+	nested                "NESTED"
 	never                 "NEVER"
 	next                  "NEXT"
 	nextval               "NEXTVAL"
@@ -485,6 +497,7 @@ import (
 	only                  "ONLY"
 	open                  "OPEN"
 	optional              "OPTIONAL"
+	ordinality            "ORDINALITY"
 	packKeys              "PACK_KEYS"
 	pageSym               "PAGE"
 	parser                "PARSER"
@@ -492,6 +505,7 @@ import (
 	partitioning          "PARTITIONING"
 	partitions            "PARTITIONS"
 	password              "PASSWORD"
+	pathKwd               "PATH"
 	percent               "PERCENT"
 	per_db                "PER_DB"
 	per_table             "PER_TABLE"
@@ -624,6 +638,45 @@ import (
 	x509                  "X509"
 	yearType              "YEAR"
 	wait                  "WAIT"
+	declare               "DECLARE"
+	cursor                "CURSOR"
+	handler               "HANDLER"
+	closeKwd              "CLOSE"
+	leave                 "LEAVE"
+	iterate               "ITERATE"
+	loop                  "LOOP"
+	while                 "WHILE"
+	elseIf                "ELSEIF"
+	until                 "UNTIL"
+	continueKwd           "CONTINUE"
+	exit                  "EXIT"
+	sqlexception          "SQLEXCEPTION"
+	sqlwarning            "SQLWARNING"
+	sqlstate              "SQLSTATE"
+	found                 "FOUND"
+	follows               "FOLLOWS"
+	precedes              "PRECEDES"
+	at                    "AT"
+	every                 "EVERY"
+	starts                "STARTS"
+	ends                  "ENDS"
+	completion            "COMPLETION"
+	xa                    "XA"
+	xid                   "XID"
+	suspend               "SUSPEND"
+	migrate               "MIGRATE"
+	phase                 "PHASE"
+	one                   "ONE"
+	geometryType          "GEOMETRY"
+	point                 "POINT"
+	lineString            "LINESTRING"
+	polygon               "POLYGON"
+	multiPoint            "MULTIPOINT"
+	multiLineString       "MULTILINESTRING"
+	multiPolygon          "MULTIPOLYGON"
+	geometryCollection    "GEOMETRYCOLLECTION"
+	geomCollection        "GEOMCOLLECTION"
+	srid                  "SRID"
 
 	/* The following tokens belong to NotKeywordToken. Notice: make sure these tokens are contained in NotKeywordToken. */
 	addDate               "ADDDATE"
@@ -816,6 +869,7 @@ import (
 	ExprOrDefault          "expression or default"
 	PredicateExpr          "Predicate expression factor"
 	SetExpr                "Set variable statement value's expression"
+	SelectIntoVar          "SELECT INTO variable"
 	BitExpr                "bit expression"
 	SimpleExpr             "simple expression"
 	SimpleIdent            "Simple Identifier expression"
@@ -873,6 +927,9 @@ import (
 	DropStatsStmt              "DROP STATS statement"
 	DropTableStmt              "DROP TABLE statement"
 	DropSequenceStmt           "DROP SEQUENCE statement"
+	DropProcedureStmt          "DROP PROCEDURE statement"
+	DropTriggerStmt            "DROP TRIGGER statement"
+	DropEventStmt              "DROP EVENT statement"
 	DropUserStmt               "DROP USER"
 	DropRoleStmt               "DROP ROLE"
 	DropViewStmt               "DROP VIEW statement"
@@ -893,6 +950,16 @@ import (
 	GrantRoleStmt              "Grant role statement"
 	InsertIntoStmt             "INSERT INTO statement"
 	CallStmt                   "CALL statement"
+	CreateProcedureStmt        "CREATE PROCEDURE statement"
+	CreateTriggerStmt          "CREATE TRIGGER statement"
+	CreateEventStmt            "CREATE EVENT statement"
+	AlterEventStmt             "ALTER EVENT statement"
+	XAStmt                     "XA statement"
+	ProcedureStatement         "statement inside a stored procedure"
+	ProcedureDecl              "declaration inside a stored procedure"
+	ProcedureSQLStmt           "SQL statement inside a stored procedure"
+	ProcedureCompoundStmt      "compound statement inside a stored procedure"
+	ProcedureCursorQuery       "stored procedure cursor query"
 	IndexAdviseStmt            "INDEX ADVISE statement"
 	KillStmt                   "Kill statement"
 	LoadDataStmt               "Load data statement"
@@ -951,6 +1018,7 @@ import (
 	AnalyzeOptionList                      "Analyze option list"
 	AnalyzeOptionListOpt                   "Optional analyze option list"
 	AnyOrAll                               "Any or All for subquery"
+	ArrayKwdOpt                            "Optional ARRAY keyword for multi-valued index"
 	Assignment                             "assignment"
 	AssignmentList                         "assignment list"
 	AssignmentListOpt                      "assignment list opt"
@@ -1057,6 +1125,10 @@ import (
 	InsertValues                           "Rest part of INSERT/REPLACE INTO statement"
 	JoinTable                              "join table"
 	JoinType                               "join type"
+	JSONTableColumn                        "JSON_TABLE column definition"
+	JSONTableColumnList                    "JSON_TABLE column definition list"
+	JSONTableOnResponse                    "JSON_TABLE column on empty or on error behavior"
+	JSONTableOnResponseOpt                 "JSON_TABLE column on empty and on error clauses optional"
 	KillOrKillTiDB                         "Kill or Kill TiDB"
 	LocationLabelList                      "location label name list"
 	LikeTableWithOrWithoutParen            "LIKE table_name or ( LIKE table_name )"
@@ -1146,6 +1218,33 @@ import (
 	SelectStmtFromTable                    "SELECT statement from table"
 	SelectStmtGroup                        "SELECT statement optional GROUP BY clause"
 	SelectStmtIntoOption                   "SELECT statement into clause"
+	SelectStmtIntoClause                   "SELECT statement non-empty into clause"
+	SelectIntoVarList                      "SELECT INTO variable list"
+	SelectIntoFileOptions                  "SELECT INTO OUTFILE options"
+	SelectIntoFileOption                   "SELECT INTO OUTFILE option"
+	ProcedureParamListOpt                  "stored procedure parameter list opt"
+	TriggerTiming                          "trigger action time"
+	TriggerEvent                           "trigger event"
+	TriggerOrderOpt                        "trigger FOLLOWS or PRECEDES clause opt"
+	EventSchedule                          "event schedule"
+	EventStartsOpt                         "event STARTS clause opt"
+	EventEndsOpt                           "event ENDS clause opt"
+	EventCompletionOpt                     "event ON COMPLETION clause opt"
+	EventStatusOpt                         "event ENABLE or DISABLE clause opt"
+	EventCommentOpt                        "event COMMENT clause opt"
+	EventRenameOpt                         "event RENAME TO clause opt"
+	EventDoOpt                             "event DO clause opt"
+	XID                                    "XA transaction identifier"
+	ProcedureParamList                     "stored procedure parameter list"
+	ProcedureParam                         "stored procedure parameter"
+	ProcedureStatementList                 "stored procedure statement list"
+	ProcedureDeclList                      "stored procedure declaration list"
+	ProcedureElseIfList                    "stored procedure ELSEIF list"
+	ProcedureElseOpt                       "stored procedure ELSE opt"
+	ProcedureVarDefaultOpt                 "stored procedure variable default value opt"
+	ProcedureHandlerAction                 "stored procedure handler action"
+	ProcedureConditionList                 "stored procedure handler condition list"
+	ProcedureCondition                     "stored procedure handler condition"
 	SequenceOption                         "Create sequence option"
 	SequenceOptionList                     "Create sequence option list"
 	SetRoleOpt                             "Set role options"
@@ -1278,6 +1377,8 @@ import (
 	BlobType                               "Blob types"
 	TextType                               "Text types"
 	DateAndTimeType                        "Date and Time types"
+	SpatialType                            "Spatial types"
+	GeometryTypeName                       "Geometry type name"
 	OptFieldLen                            "Field length or empty"
 	FieldLen                               "Field length"
 	FieldOpts                              "Field type definition option list"
@@ -1321,6 +1422,7 @@ import (
 
 %type	<ident>
 	AsOpt             "AS or EmptyString"
+	XIDString         "gtrid or bqual of XA transaction identifier"
 	KeyOrIndex        "{KEY|INDEX}"
 	ColumnKeywordOpt  "Column keyword or empty"
 	PrimaryOpt        "Optional primary keyword"
@@ -1378,6 +1480,7 @@ import (
 	ColumnFormat                    "Column format"
 	DBName                          "Database Name"
 	PolicyName                      "Placement Policy Name"
+	ProcedureLabelEndOpt            "stored procedure end label opt"
 	ExplainFormatType               "explain format type"
 	FieldAsName                     "Field alias name"
 	FieldAsNameOpt                  "Field alias name opt"
@@ -1412,6 +1515,7 @@ import (
 %precedence lowerThanSetKeyword
 %precedence set
 %precedence selectKwd
+%precedence into
 %precedence lowerThanSelectStmt
 %precedence lowerThanInsertValues
 %precedence insertValues
@@ -2966,6 +3070,10 @@ ColumnOption:
 	{
 		$$ = &ast.ColumnOption{Tp: ast.ColumnOptionAutoIncrement}
 	}
+|	"SRID" LengthNum
+	{
+		$$ = &ast.ColumnOption{Tp: ast.ColumnOptionSrid, Expr: ast.NewValueExpr($2, "", "")}
+	}
 |	PrimaryOpt "KEY"
 	{
 		// KEY is normally a synonym for INDEX. The key attribute PRIMARY KEY
@@ -3163,6 +3271,19 @@ ConstraintElem:
 		}
 		$$ = c
 	}
+|	"SPATIAL" KeyOrIndexOpt IndexName '(' IndexPartSpecificationList ')' IndexOptionList
+	{
+		c := &ast.Constraint{
+			Tp:           ast.ConstraintSpatial,
+			Keys:         $5.([]*ast.IndexPartSpecification),
+			Name:         $3.(*ast.NullString).String,
+			IsEmptyIndex: $3.(*ast.NullString).Empty,
+		}
+		if $7 != nil {
+			c.Option = $7.(*ast.IndexOption)
+		}
+		$$ = c
+	}
 |	KeyOrIndex IfNotExists IndexNameAndTypeOpt '(' IndexPartSpecificationList ')' IndexOptionList
 	{
 		c := &ast.Constraint{
@@ -4443,6 +4564,10 @@ DropIndexStmt:
 		}
 		$$ = &ast.DropIndexStmt{IfExists: $3.(bool), IndexName: $4, Table: $6.(*ast.TableName), LockAlg: indexLockAndAlgorithm}
 	}
+|	"DROP" "HYPO" "INDEX" IfExists Identifier "ON" TableName
+	{
+		$$ = &ast.DropIndexStmt{IfExists: $4.(bool), IndexName: $5, Table: $7.(*ast.TableName), IsHypo: true}
+	}
 
 DropTableStmt:
 	"DROP" OptTemporary TableOrTables IfExists TableNameList RestrictOrCascadeOpt
@@ -5444,6 +5569,10 @@ PredicateExpr:
 	{
 		$$ = &ast.PatternRegexpExpr{Expr: $1, Pattern: $3, Not: !$2.(bool)}
 	}
+|	BitExpr memberof '(' SimpleExpr ')'
+	{
+		$$ = &ast.FuncCallExpr{FnName: model.NewCIStr(ast.JSONMemberOf), Args: []ast.ExprNode{$1, $4}}
+	}
 |	BitExpr
 
 RegexpSym:
@@ -5724,6 +5853,10 @@ IndexTypeName:
 	{
 		$$ = model.IndexTypeRtree
 	}
+|	"HYPO"
+	{
+		$$ = model.IndexTypeHypo
+	}
 
 IndexInvisible:
 	"VISIBLE"
@@ -5745,6 +5878,7 @@ Identifier:
 UnReservedKeyword:
 	"ACTION"
 |	"ADVISE"
+|	"ARRAY"
 |	"ASCII"
 |	"ATTRIBUTES"
 |	"STATS_OPTIONS"
@@ -5784,6 +5918,7 @@ UnReservedKeyword:
 |	"DO"
 |	"DUPLICATE"
 |	"DYNAMIC"
+|	"EMPTY"
 |	"ENCRYPTION"
 |	"END"
 |	"ENFORCED"
@@ -5809,6 +5944,7 @@ UnReservedKeyword:
 |	"HASH"
 |	"HELP"
 |	"HOUR"
+|	"HYPO"
 |	"INSERT_METHOD"
 |	"LESS"
 |	"LOCAL"
@@ -5819,6 +5955,7 @@ UnReservedKeyword:
 |	"PACK_KEYS"
 |	"PARSER"
 |	"PASSWORD" %prec lowerThanEq
+|	"PATH"
 |	"PREPARE"
 |	"PRE_SPLIT_REGIONS"
 |	"PROXY"
@@ -5872,6 +6009,7 @@ UnReservedKeyword:
 |	"MIN_ROWS"
 |	"NATIONAL"
 |	"NCHAR"
+|	"NESTED"
 |	"ROW_FORMAT"
 |	"QUARTER"
 |	"GRANTS"
@@ -6032,6 +6170,7 @@ UnReservedKeyword:
 |	"SEQUENCE"
 |	"MAX_MINUTES"
 |	"MAX_IDXNUM"
+|	"MAX_FILE_SIZE"
 |	"PER_TABLE"
 |	"PER_DB"
 |	"NEXT"
@@ -6043,6 +6182,7 @@ UnReservedKeyword:
 |	"BACKUPS"
 |	"CONCURRENCY"
 |	"MB"
+|	"MEMBER"
 |	"ONLINE"
 |	"RATE_LIMIT"
 |	"RESTORE"
@@ -6072,6 +6212,7 @@ UnReservedKeyword:
 |	"RESUME"
 |	"OFF"
 |	"OPTIONAL"
+|	"ORDINALITY"
 |	"REQUIRED"
 |	"PURGE"
 |	"SKIP"
@@ -6079,6 +6220,45 @@ UnReservedKeyword:
 |	"CLUSTERED"
 |	"NONCLUSTERED"
 |	"PRESERVE"
+|	"DECLARE"
+|	"CURSOR"
+|	"HANDLER"
+|	"CLOSE"
+|	"LEAVE"
+|	"ITERATE"
+|	"LOOP"
+|	"WHILE"
+|	"ELSEIF"
+|	"UNTIL"
+|	"CONTINUE"
+|	"EXIT"
+|	"SQLEXCEPTION"
+|	"SQLWARNING"
+|	"SQLSTATE"
+|	"FOUND"
+|	"FOLLOWS"
+|	"PRECEDES"
+|	"AT"
+|	"EVERY"
+|	"STARTS"
+|	"ENDS"
+|	"COMPLETION"
+|	"XA"
+|	"XID"
+|	"SUSPEND"
+|	"MIGRATE"
+|	"PHASE"
+|	"ONE"
+|	"GEOMETRY"
+|	"POINT"
+|	"LINESTRING"
+|	"POLYGON"
+|	"MULTIPOINT"
+|	"MULTILINESTRING"
+|	"MULTIPOLYGON"
+|	"GEOMETRYCOLLECTION"
+|	"GEOMCOLLECTION"
+|	"SRID"
 
 TiDBKeyword:
 	"ADMIN"
@@ -6206,6 +6386,83 @@ NotKeywordToken:
 |	"LEARNER_CONSTRAINTS"
 |	"VOTER_CONSTRAINTS"
 
+/************************************************************************************
+ *
+ *  XA Statements
+ *  See https://dev.mysql.com/doc/refman/8.0/en/xa-statements.html
+ *
+ **********************************************************************************/
+XAStmt:
+	"XA" XAStartKwd XID XAStartOptionOpt
+	{
+		$$ = &ast.XAStmt{Tp: ast.XAStart, XID: $3.(*ast.XID)}
+	}
+|	"XA" "END" XID XAEndOptionOpt
+	{
+		$$ = &ast.XAStmt{Tp: ast.XAEnd, XID: $3.(*ast.XID)}
+	}
+|	"XA" "PREPARE" XID
+	{
+		$$ = &ast.XAStmt{Tp: ast.XAPrepare, XID: $3.(*ast.XID)}
+	}
+|	"XA" "COMMIT" XID
+	{
+		$$ = &ast.XAStmt{Tp: ast.XACommit, XID: $3.(*ast.XID)}
+	}
+|	"XA" "COMMIT" XID "ONE" "PHASE"
+	{
+		$$ = &ast.XAStmt{Tp: ast.XACommit, XID: $3.(*ast.XID), OnePhase: true}
+	}
+|	"XA" "ROLLBACK" XID
+	{
+		$$ = &ast.XAStmt{Tp: ast.XARollback, XID: $3.(*ast.XID)}
+	}
+|	"XA" "RECOVER"
+	{
+		$$ = &ast.XAStmt{Tp: ast.XARecover}
+	}
+|	"XA" "RECOVER" "CONVERT" "XID"
+	{
+		$$ = &ast.XAStmt{Tp: ast.XARecover, ConvertXID: true}
+	}
+
+XAStartKwd:
+	"START"
+|	"BEGIN"
+
+/* JOIN and RESUME are accepted and ignored, as MySQL does. */
+XAStartOptionOpt:
+	%prec empty
+|	"JOIN"
+|	"RESUME"
+
+/* SUSPEND [FOR MIGRATE] is accepted and ignored, as MySQL does. */
+XAEndOptionOpt:
+	%prec empty
+|	"SUSPEND"
+|	"SUSPEND" "FOR" "MIGRATE"
+
+XID:
+	XIDString
+	{
+		$$ = &ast.XID{GTRID: $1, FormatID: 1}
+	}
+|	XIDString ',' XIDString
+	{
+		$$ = &ast.XID{GTRID: $1, BQual: $3, FormatID: 1}
+	}
+|	XIDString ',' XIDString ',' LengthNum
+	{
+		$$ = &ast.XID{GTRID: $1, BQual: $3, FormatID: $5.(uint64)}
+	}
+
+XIDString:
+	stringLit
+|	hexLit
+	{
+		$$ = $1.(ast.BinaryLiteral).ToString()
+	}
+
 /************************************************************************************
  *
  *  Call Statements
@@ -6255,6 +6512,518 @@ ProcedureCall:
 		}
 	}
 
+/************************************************************************************
+ *
+ *  Stored Procedure Statements
+ *
+ **********************************************************************************/
+CreateProcedureStmt:
+	"CREATE" "PROCEDURE" IfNotExists TableName '(' ProcedureParamListOpt ')' ProcedureStatement
+	{
+		$$ = &ast.CreateProcedureStmt{
+			IfNotExists:   $3.(bool),
+			ProcedureName: $4.(*ast.TableName),
+			Params:        $6.([]*ast.StoredProcedureParam),
+			Body:          $8,
+		}
+	}
+
+DropProcedureStmt:
+	"DROP" "PROCEDURE" IfExists TableName
+	{
+		$$ = &ast.DropProcedureStmt{
+			IfExists:      $3.(bool),
+			ProcedureName: $4.(*ast.TableName),
+		}
+	}
+
+/************************************************************************************
+ *
+ *  Trigger Statements
+ *
+ **********************************************************************************/
+CreateTriggerStmt:
+	"CREATE" "TRIGGER" IfNotExists TableName TriggerTiming TriggerEvent "ON" TableName "FOR" "EACH" "ROW" TriggerOrderOpt ProcedureStatement
+	{
+		x := &ast.CreateTriggerStmt{
+			IfNotExists: $3.(bool),
+			TriggerName: $4.(*ast.TableName),
+			Timing:      $5.(model.TriggerTiming),
+			Event:       $6.(model.TriggerEvent),
+			Table:       $8.(*ast.TableName),
+			Body:        $13,
+		}
+		if $12 != nil {
+			x.Order = $12.(*ast.TriggerOrder)
+		}
+		$$ = x
+	}
+
+DropTriggerStmt:
+	"DROP" "TRIGGER" IfExists TableName
+	{
+		$$ = &ast.DropTriggerStmt{
+			IfExists:    $3.(bool),
+			TriggerName: $4.(*ast.TableName),
+		}
+	}
+
+TriggerTiming:
+	"BEFORE"
+	{
+		$$ = model.TriggerBefore
+	}
+|	"AFTER"
+	{
+		$$ = model.TriggerAfter
+	}
+
+TriggerEvent:
+	"INSERT"
+	{
+		$$ = model.TriggerInsert
+	}
+|	"UPDATE"
+	{
+		$$ = model.TriggerUpdate
+	}
+|	"DELETE"
+	{
+		$$ = model.TriggerDelete
+	}
+
+TriggerOrderOpt:
+	{
+		$$ = nil
+	}
+|	"FOLLOWS" Identifier
+	{
+		$$ = &ast.TriggerOrder{Trigger: model.NewCIStr($2)}
+	}
+|	"PRECEDES" Identifier
+	{
+		$$ = &ast.TriggerOrder{Precedes: true, Trigger: model.NewCIStr($2)}
+	}
+
+/************************************************************************************
+ *
+ *  Event Statements
+ *
+ **********************************************************************************/
+CreateEventStmt:
+	"CREATE" "EVENT" IfNotExists TableName "ON" "SCHEDULE" EventSchedule EventCompletionOpt EventStatusOpt EventCommentOpt "DO" ProcedureStatement
+	{
+		x := &ast.CreateEventStmt{
+			IfNotExists: $3.(bool),
+			EventName:   $4.(*ast.TableName),
+			Schedule:    $7.(*ast.EventSchedule),
+			Completion:  $8.(ast.EventCompletion),
+			Status:      $9.(ast.EventStatus),
+			Body:        $12,
+		}
+		if $10 != nil {
+			x.Comment = $10.(*string)
+		}
+		$$ = x
+	}
+
+AlterEventStmt:
+	"ALTER" "EVENT" TableName "ON" "SCHEDULE" EventSchedule EventCompletionOpt EventRenameOpt EventStatusOpt EventCommentOpt EventDoOpt
+	{
+		x := &ast.AlterEventStmt{
+			EventName:  $3.(*ast.TableName),
+			Schedule:   $6.(*ast.EventSchedule),
+			Completion: $7.(ast.EventCompletion),
+			Status:     $9.(ast.EventStatus),
+		}
+		if $8 != nil {
+			x.RenameTo = $8.(*ast.TableName)
+		}
+		if $10 != nil {
+			x.Comment = $10.(*string)
+		}
+		if $11 != nil {
+			x.Body = $11.(ast.StmtNode)
+		}
+		$$ = x
+	}
+|	"ALTER" "EVENT" TableName EventCompletionOpt EventRenameOpt EventStatusOpt EventCommentOpt EventDoOpt
+	{
+		x := &ast.AlterEventStmt{
+			EventName:  $3.(*ast.TableName),
+			Completion: $4.(ast.EventCompletion),
+			Status:     $6.(ast.EventStatus),
+		}
+		if $5 != nil {
+			x.RenameTo = $5.(*ast.TableName)
+		}
+		if $7 != nil {
+			x.Comment = $7.(*string)
+		}
+		if $8 != nil {
+			x.Body = $8.(ast.StmtNode)
+		}
+		$$ = x
+	}
+
+DropEventStmt:
+	"DROP" "EVENT" IfExists TableName
+	{
+		$$ = &ast.DropEventStmt{
+			IfExists:  $3.(bool),
+			EventName: $4.(*ast.TableName),
+		}
+	}
+
+EventSchedule:
+	"AT" Expression
+	{
+		$$ = &ast.EventSchedule{At: $2}
+	}
+|	"EVERY" Expression TimeUnit EventStartsOpt EventEndsOpt
+	{
+		x := &ast.EventSchedule{
+			Every: $2,
+			Unit:  $3.(ast.TimeUnitType),
+		}
+		if $4 != nil {
+			x.Starts = $4.(ast.ExprNode)
+		}
+		if $5 != nil {
+			x.Ends = $5.(ast.ExprNode)
+		}
+		$$ = x
+	}
+
+EventStartsOpt:
+	{
+		$$ = nil
+	}
+|	"STARTS" Expression
+	{
+		$$ = $2
+	}
+
+EventEndsOpt:
+	{
+		$$ = nil
+	}
+|	"ENDS" Expression
+	{
+		$$ = $2
+	}
+
+EventCompletionOpt:
+	{
+		$$ = ast.EventCompletionNone
+	}
+|	"ON" "COMPLETION" "PRESERVE"
+	{
+		$$ = ast.EventCompletionPreserve
+	}
+|	"ON" "COMPLETION" "NOT" "PRESERVE"
+	{
+		$$ = ast.EventCompletionNotPreserve
+	}
+
+EventRenameOpt:
+	{
+		$$ = nil
+	}
+|	"RENAME" "TO" TableName
+	{
+		$$ = $3
+	}
+
+EventStatusOpt:
+	{
+		$$ = ast.EventStatusNone
+	}
+|	"ENABLE"
+	{
+		$$ = ast.EventStatusEnable
+	}
+|	"DISABLE"
+	{
+		$$ = ast.EventStatusDisable
+	}
+|	"DISABLE" "ON" "SLAVE"
+	{
+		$$ = ast.EventStatusSlavesideDisable
+	}
+
+EventCommentOpt:
+	{
+		$$ = nil
+	}
+|	"COMMENT" stringLit
+	{
+		comment := $2
+		$$ = &comment
+	}
+
+EventDoOpt:
+	{
+		$$ = nil
+	}
+|	"DO" ProcedureStatement
+	{
+		$$ = $2
+	}
+
+ProcedureParamListOpt:
+	{
+		$$ = []*ast.StoredProcedureParam{}
+	}
+|	ProcedureParamList
+
+ProcedureParamList:
+	ProcedureParam
+	{
+		$$ = []*ast.StoredProcedureParam{$1.(*ast.StoredProcedureParam)}
+	}
+|	ProcedureParamList ',' ProcedureParam
+	{
+		$$ = append($1.([]*ast.StoredProcedureParam), $3.(*ast.StoredProcedureParam))
+	}
+
+ProcedureParam:
+	Identifier Type
+	{
+		$$ = &ast.StoredProcedureParam{Mode: model.ProcedureParamIn, Name: $1, Tp: $2.(*types.FieldType)}
+	}
+|	"IN" Identifier Type
+	{
+		$$ = &ast.StoredProcedureParam{Mode: model.ProcedureParamIn, Name: $2, Tp: $3.(*types.FieldType)}
+	}
+|	"OUT" Identifier Type
+	{
+		$$ = &ast.StoredProcedureParam{Mode: model.ProcedureParamOut, Name: $2, Tp: $3.(*types.FieldType)}
+	}
+|	"INOUT" Identifier Type
+	{
+		$$ = &ast.StoredProcedureParam{Mode: model.ProcedureParamInOut, Name: $2, Tp: $3.(*types.FieldType)}
+	}
+
+ProcedureStatementList:
+	{
+		$$ = []ast.StmtNode{}
+	}
+|	ProcedureStatementList ProcedureStatement ';'
+	{
+		$$ = append($1.([]ast.StmtNode), $2)
+	}
+
+ProcedureDeclList:
+	{
+		$$ = []ast.StmtNode{}
+	}
+|	ProcedureDeclList ProcedureDecl ';'
+	{
+		$$ = append($1.([]ast.StmtNode), $2)
+	}
+
+ProcedureDecl:
+	"DECLARE" IdentList Type ProcedureVarDefaultOpt
+	{
+		x := &ast.ProcedureVarDecl{
+			Names: procedureVarNames($2.([]model.CIStr)),
+			Tp:    $3.(*types.FieldType),
+		}
+		if $4 != nil {
+			x.Default = $4.(ast.ExprNode)
+		}
+		$$ = x
+	}
+|	"DECLARE" Identifier "CURSOR" "FOR" ProcedureCursorQuery
+	{
+		$$ = &ast.ProcedureCursorDecl{Name: $2, Query: $5}
+	}
+|	"DECLARE" ProcedureHandlerAction "HANDLER" "FOR" ProcedureConditionList ProcedureStatement
+	{
+		$$ = &ast.ProcedureHandlerDecl{
+			Action:     $2.(ast.ProcedureHandlerAction),
+			Conditions: $5.([]*ast.ProcedureCondition),
+			Stmt:       $6,
+		}
+	}
+
+ProcedureStatement:
+	ProcedureSQLStmt
+|	ProcedureCompoundStmt
+|	identifier ':' ProcedureCompoundStmt ProcedureLabelEndOpt
+	{
+		if $4 != "" && !strings.EqualFold($1, $4) {
+			yylex.AppendError(yylex.Errorf("End-label %s without match", $4))
+			return 1
+		}
+		switch x := $3.(type) {
+		case *ast.ProcedureBlock:
+			x.Label = $1
+		case *ast.ProcedureLoopStmt:
+			x.Label = $1
+		}
+		$$ = $3
+	}
+|	"IF" Expression "THEN" ProcedureStatementList ProcedureElseIfList ProcedureElseOpt "END" "IF"
+	{
+		branches := []*ast.ProcedureIfBranch{{Cond: $2, Stmts: $4.([]ast.StmtNode)}}
+		x := &ast.ProcedureIfStmt{
+			Branches: append(branches, $5.([]*ast.ProcedureIfBranch)...),
+		}
+		if $6 != nil {
+			x.Else = $6.([]ast.StmtNode)
+		}
+		$$ = x
+	}
+|	"LEAVE" Identifier
+	{
+		$$ = &ast.ProcedureJumpStmt{Label: $2}
+	}
+|	"ITERATE" Identifier
+	{
+		$$ = &ast.ProcedureJumpStmt{IsIterate: true, Label: $2}
+	}
+|	"OPEN" Identifier
+	{
+		$$ = &ast.ProcedureOpenStmt{Cursor: $2}
+	}
+|	"CLOSE" Identifier
+	{
+		$$ = &ast.ProcedureCloseStmt{Cursor: $2}
+	}
+|	"FETCH" Identifier "INTO" IdentList
+	{
+		$$ = &ast.ProcedureFetchStmt{Cursor: $2, Vars: procedureVarNames($4.([]model.CIStr))}
+	}
+|	"FETCH" "FROM" Identifier "INTO" IdentList
+	{
+		$$ = &ast.ProcedureFetchStmt{Cursor: $3, Vars: procedureVarNames($5.([]model.CIStr))}
+	}
+|	"FETCH" "NEXT" "FROM" Identifier "INTO" IdentList
+	{
+		$$ = &ast.ProcedureFetchStmt{Cursor: $4, Vars: procedureVarNames($6.([]model.CIStr))}
+	}
+
+ProcedureCompoundStmt:
+	"BEGIN" ProcedureDeclList ProcedureStatementList "END"
+	{
+		$$ = &ast.ProcedureBlock{Stmts: append($2.([]ast.StmtNode), $3.([]ast.StmtNode)...)}
+	}
+|	"WHILE" Expression "DO" ProcedureStatementList "END" "WHILE"
+	{
+		$$ = &ast.ProcedureLoopStmt{Tp: ast.ProcedureLoopWhile, Cond: $2, Stmts: $4.([]ast.StmtNode)}
+	}
+|	"REPEAT" ProcedureStatementList "UNTIL" Expression "END" "REPEAT"
+	{
+		$$ = &ast.ProcedureLoopStmt{Tp: ast.ProcedureLoopRepeat, Cond: $4, Stmts: $2.([]ast.StmtNode)}
+	}
+|	"LOOP" ProcedureStatementList "END" "LOOP"
+	{
+		$$ = &ast.ProcedureLoopStmt{Tp: ast.ProcedureLoopLoop, Stmts: $2.([]ast.StmtNode)}
+	}
+
+ProcedureLabelEndOpt:
+	{
+		$$ = ""
+	}
+|	Identifier
+
+ProcedureElseIfList:
+	{
+		$$ = []*ast.ProcedureIfBranch{}
+	}
+|	ProcedureElseIfList "ELSEIF" Expression "THEN" ProcedureStatementList
+	{
+		$$ = append($1.([]*ast.ProcedureIfBranch), &ast.ProcedureIfBranch{Cond: $3, Stmts: $5.([]ast.StmtNode)})
+	}
+
+ProcedureElseOpt:
+	{
+		$$ = nil
+	}
+|	"ELSE" ProcedureStatementList
+	{
+		$$ = $2
+	}
+
+ProcedureVarDefaultOpt:
+	{
+		$$ = nil
+	}
+|	"DEFAULT" Expression
+	{
+		$$ = $2
+	}
+
+ProcedureCursorQuery:
+	SelectStmt
+|	SelectStmtWithClause
+|	SetOprStmt
+
+ProcedureHandlerAction:
+	"CONTINUE"
+	{
+		$$ = ast.ProcedureHandlerContinue
+	}
+|	"EXIT"
+	{
+		$$ = ast.ProcedureHandlerExit
+	}
+
+ProcedureConditionList:
+	ProcedureCondition
+	{
+		$$ = []*ast.ProcedureCondition{$1.(*ast.ProcedureCondition)}
+	}
+|	ProcedureConditionList ',' ProcedureCondition
+	{
+		$$ = append($1.([]*ast.ProcedureCondition), $3.(*ast.ProcedureCondition))
+	}
+
+ProcedureCondition:
+	NUM
+	{
+		$$ = &ast.ProcedureCondition{Tp: ast.ProcedureConditionErrorCode, ErrorCode: getUint64FromNUM($1)}
+	}
+|	"SQLSTATE" stringLit
+	{
+		$$ = &ast.ProcedureCondition{Tp: ast.ProcedureConditionSQLState, SQLState: $2}
+	}
+|	"SQLSTATE" "VALUE" stringLit
+	{
+		$$ = &ast.ProcedureCondition{Tp: ast.ProcedureConditionSQLState, SQLState: $3}
+	}
+|	"SQLWARNING"
+	{
+		$$ = &ast.ProcedureCondition{Tp: ast.ProcedureConditionSQLWarning}
+	}
+|	"NOT" "FOUND"
+	{
+		$$ = &ast.ProcedureCondition{Tp: ast.ProcedureConditionNotFound}
+	}
+|	"SQLEXCEPTION"
+	{
+		$$ = &ast.ProcedureCondition{Tp: ast.ProcedureConditionSQLException}
+	}
+
+ProcedureSQLStmt:
+	CallStmt
+|	CommitStmt
+|	CreateTableStmt
+|	DeleteFromStmt
+|	DoStmt
+|	DropTableStmt
+|	InsertIntoStmt
+|	ReplaceIntoStmt
+|	RollbackStmt
+|	SelectStmt
+|	SelectStmtWithClause
+|	SetOprStmt
+|	SetStmt
+|	TruncateTableStmt
+|	UpdateStmt
+
 /************************************************************************************
  *
  *  Insert Statements
@@ -6836,10 +7605,11 @@ SimpleExpr:
 			FunctionType: ast.CastBinaryOperator,
 		}
 	}
-|	builtinCast '(' Expression "AS" CastType ')'
+|	builtinCast '(' Expression "AS" CastType ArrayKwdOpt ')'
 	{
 		/* See https://dev.mysql.com/doc/refman/5.7/en/cast-functions.html#function_cast */
 		tp := $5.(*types.FieldType)
+		tp.Array = $6.(bool)
 		defaultFlen, defaultDecimal := mysql.GetDefaultFieldLengthAndDecimalForCast(tp.Tp)
 		if tp.Flen == types.UnspecifiedLength {
 			tp.Flen = defaultFlen
@@ -6979,6 +7749,14 @@ FunctionNameConflict:
 |	"USER"
 |	"WEEK"
 |	"YEAR"
+|	"POINT"
+|	"LINESTRING"
+|	"POLYGON"
+|	"MULTIPOINT"
+|	"MULTILINESTRING"
+|	"MULTIPOLYGON"
+|	"GEOMETRYCOLLECTION"
+|	"GEOMCOLLECTION"
 
 OptionalBraces:
 	{}
@@ -7366,9 +8144,13 @@ SumExpr:
 			$$ = &ast.AggregateFuncExpr{F: $1, Args: []ast.ExprNode{$4}}
 		}
 	}
-|	builtinCount '(' DistinctKwd ExpressionList ')'
+|	builtinCount '(' DistinctKwd ExpressionList ')' OptWindowingClause
 	{
-		$$ = &ast.AggregateFuncExpr{F: $1, Args: $4.([]ast.ExprNode), Distinct: true}
+		if $6 != nil {
+			$$ = &ast.WindowFuncExpr{F: $1, Args: $4.([]ast.ExprNode), Distinct: true, Spec: *($6.(*ast.WindowSpec))}
+		} else {
+			$$ = &ast.AggregateFuncExpr{F: $1, Args: $4.([]ast.ExprNode), Distinct: true}
+		}
 	}
 |	builtinCount '(' "ALL" Expression ')' OptWindowingClause
 	{
@@ -7400,7 +8182,11 @@ SumExpr:
 		args := $4.([]ast.ExprNode)
 		args = append(args, $6.(ast.ExprNode))
 		if $8 != nil {
-			$$ = &ast.WindowFuncExpr{F: $1, Args: args, Distinct: $3.(bool), Spec: *($8.(*ast.WindowSpec))}
+			windowFunc := &ast.WindowFuncExpr{F: $1, Args: args, Distinct: $3.(bool), Spec: *($8.(*ast.WindowSpec))}
+			if $5 != nil {
+				windowFunc.Order = $5.(*ast.OrderByClause)
+			}
+			$$ = windowFunc
 		} else {
 			agg := &ast.AggregateFuncExpr{F: $1, Args: args, Distinct: $3.(bool)}
 			if $5 != nil {
@@ -8087,6 +8873,24 @@ SelectStmtBasic:
 		}
 		$$ = st
 	}
+|	"SELECT" SelectStmtOpts SelectStmtFieldList SelectStmtIntoClause
+	{
+		st := &ast.SelectStmt{
+			SelectStmtOpts: $2.(*ast.SelectStmtOpts),
+			Distinct:       $2.(*ast.SelectStmtOpts).Distinct,
+			Fields:         $3.(*ast.FieldList),
+			Kind:           ast.SelectStmtKindSelect,
+			SelectIntoOpt:  $4.(*ast.SelectIntoOption),
+		}
+		if st.SelectStmtOpts.TableHints != nil {
+			st.TableHints = st.SelectStmtOpts.TableHints
+		}
+		lastField := st.Fields.Fields[len(st.Fields.Fields)-1]
+		if lastField.Expr != nil && lastField.AsName.O == "" {
+			lastField.SetText(parser.lexer.client, parser.src[lastField.Offset:parser.endOffset(&yyS[yypt])])
+		}
+		$$ = st
+	}
 
 SelectStmtFromDualTable:
 	SelectStmtBasic FromDual WhereClauseOptional
@@ -8749,6 +9553,88 @@ TableFactor:
 		j.ExplicitParens = true
 		$$ = $2
 	}
+|	"JSON_TABLE" '(' Expression ',' stringLit "COLUMNS" '(' JSONTableColumnList ')' ')' TableAsName
+	{
+		jt := &ast.JSONTable{Expr: $3, Path: $5, Columns: $8.([]*ast.JSONTableColumn)}
+		$$ = &ast.TableSource{Source: jt, AsName: $11.(model.CIStr)}
+	}
+
+JSONTableColumnList:
+	JSONTableColumn
+	{
+		$$ = []*ast.JSONTableColumn{$1.(*ast.JSONTableColumn)}
+	}
+|	JSONTableColumnList ',' JSONTableColumn
+	{
+		$$ = append($1.([]*ast.JSONTableColumn), $3.(*ast.JSONTableColumn))
+	}
+
+JSONTableColumn:
+	Identifier "FOR" "ORDINALITY"
+	{
+		$$ = &ast.JSONTableColumn{Tp: ast.JSONTableColumnOrdinality, Name: model.NewCIStr($1)}
+	}
+|	Identifier Type "PATH" stringLit JSONTableOnResponseOpt
+	{
+		onResponse := $5.([]*ast.JSONTableOnResponse)
+		$$ = &ast.JSONTableColumn{
+			Tp:        ast.JSONTableColumnPath,
+			Name:      model.NewCIStr($1),
+			FieldType: $2.(*types.FieldType),
+			Path:      $4,
+			OnEmpty:   onResponse[0],
+			OnError:   onResponse[1],
+		}
+	}
+|	Identifier Type "EXISTS" "PATH" stringLit
+	{
+		$$ = &ast.JSONTableColumn{
+			Tp:        ast.JSONTableColumnExistsPath,
+			Name:      model.NewCIStr($1),
+			FieldType: $2.(*types.FieldType),
+			Path:      $5,
+		}
+	}
+|	"NESTED" stringLit "COLUMNS" '(' JSONTableColumnList ')'
+	{
+		$$ = &ast.JSONTableColumn{Tp: ast.JSONTableColumnNested, Path: $2, Columns: $5.([]*ast.JSONTableColumn)}
+	}
+|	"NESTED" "PATH" stringLit "COLUMNS" '(' JSONTableColumnList ')'
+	{
+		$$ = &ast.JSONTableColumn{Tp: ast.JSONTableColumnNested, Path: $3, Columns: $6.([]*ast.JSONTableColumn)}
+	}
+
+JSONTableOnResponseOpt:
+	/* empty */
+	{
+		$$ = []*ast.JSONTableOnResponse{nil, nil}
+	}
+|	JSONTableOnResponse "ON" "EMPTY"
+	{
+		$$ = []*ast.JSONTableOnResponse{$1.(*ast.JSONTableOnResponse), nil}
+	}
+|	JSONTableOnResponse "ON" "ERROR"
+	{
+		$$ = []*ast.JSONTableOnResponse{nil, $1.(*ast.JSONTableOnResponse)}
+	}
+|	JSONTableOnResponse "ON" "EMPTY" JSONTableOnResponse "ON" "ERROR"
+	{
+		$$ = []*ast.JSONTableOnResponse{$1.(*ast.JSONTableOnResponse), $4.(*ast.JSONTableOnResponse)}
+	}
+
+JSONTableOnResponse:
+	"NULL"
+	{
+		$$ = &ast.JSONTableOnResponse{Tp: ast.JSONTableOnResponseNull}
+	}
+|	"ERROR"
+	{
+		$$ = &ast.JSONTableOnResponse{Tp: ast.JSONTableOnResponseError}
+	}
+|	"DEFAULT" stringLit
+	{
+		$$ = &ast.JSONTableOnResponse{Tp: ast.JSONTableOnResponseDefault, Default: $2}
+	}
 
 PartitionNameListOpt:
 	/* empty */
@@ -9145,7 +10031,10 @@ SelectStmtIntoOption:
 	{
 		$$ = nil
 	}
-|	"INTO" "OUTFILE" stringLit Fields Lines
+|	SelectStmtIntoClause
+
+SelectStmtIntoClause:
+	"INTO" "OUTFILE" stringLit Fields Lines SelectIntoFileOptions
 	{
 		x := &ast.SelectIntoOption{
 			Tp:       ast.SelectIntoOutfile,
@@ -9157,9 +10046,73 @@ SelectStmtIntoOption:
 		if $5 != nil {
 			x.LinesInfo = $5.(*ast.LinesClause)
 		}
+		if $6 != nil {
+			x.FileOptions = $6.([]*ast.SelectIntoFileOption)
+		}
 
 		$$ = x
 	}
+|	"INTO" SelectIntoVarList
+	{
+		$$ = &ast.SelectIntoOption{
+			Tp:   ast.SelectIntoVars,
+			Vars: $2.([]ast.ExprNode),
+		}
+	}
+
+SelectIntoFileOptions:
+	%prec empty
+	{
+		$$ = nil
+	}
+|	SelectIntoFileOptions SelectIntoFileOption
+	{
+		var opts []*ast.SelectIntoFileOption
+		if $1 != nil {
+			opts = $1.([]*ast.SelectIntoFileOption)
+		}
+		$$ = append(opts, $2.(*ast.SelectIntoFileOption))
+	}
+
+SelectIntoFileOption:
+	"FORMAT" EqOpt stringLit
+	{
+		$$ = &ast.SelectIntoFileOption{
+			Tp:       ast.SelectIntoFileFormat,
+			StrValue: strings.ToLower($3),
+		}
+	}
+|	"COMPRESSION" EqOpt stringLit
+	{
+		$$ = &ast.SelectIntoFileOption{
+			Tp:       ast.SelectIntoFileCompression,
+			StrValue: strings.ToLower($3),
+		}
+	}
+|	"MAX_FILE_SIZE" EqOpt LengthNum
+	{
+		$$ = &ast.SelectIntoFileOption{
+			Tp:        ast.SelectIntoFileMaxSize,
+			UintValue: $3.(uint64),
+		}
+	}
+
+SelectIntoVarList:
+	SelectIntoVar
+	{
+		$$ = []ast.ExprNode{$1}
+	}
+|	SelectIntoVarList ',' SelectIntoVar
+	{
+		$$ = append($1.([]ast.ExprNode), $3)
+	}
+
+SelectIntoVar:
+	UserVariable
+|	Identifier
+	{
+		$$ = &ast.ColumnNameExpr{Name: &ast.ColumnName{Name: model.NewCIStr($1)}}
+	}
 
 // See https://dev.mysql.com/doc/refman/5.7/en/subqueries.html
 SubSelect:
@@ -10939,6 +11892,7 @@ Statement:
 	EmptyStmt
 |	AdminStmt
 |	AlterDatabaseStmt
+|	AlterEventStmt
 |	AlterTableStmt
 |	AlterUserStmt
 |	AlterImportStmt
@@ -10966,6 +11920,9 @@ Statement:
 |	CreatePolicyStmt
 |	CreateSequenceStmt
 |	CreateStatisticsStmt
+|	CreateProcedureStmt
+|	CreateTriggerStmt
+|	CreateEventStmt
 |	DoStmt
 |	DropDatabaseStmt
 |	DropImportStmt
@@ -10973,6 +11930,9 @@ Statement:
 |	DropTableStmt
 |	DropPolicyStmt
 |	DropSequenceStmt
+|	DropProcedureStmt
+|	DropTriggerStmt
+|	DropEventStmt
 |	DropViewStmt
 |	DropUserStmt
 |	DropRoleStmt
@@ -11031,6 +11991,7 @@ Statement:
 |	UseStmt
 |	UnlockTablesStmt
 |	LockTablesStmt
+|	XAStmt
 |	ShutdownStmt
 |	RestartStmt
 |	HelpStmt
@@ -11459,6 +12420,7 @@ Type:
 	NumericType
 |	StringType
 |	DateAndTimeType
+|	SpatialType
 
 NumericType:
 	IntegerType OptFieldLen FieldOpts
@@ -11606,6 +12568,15 @@ BooleanType:
 		$$ = mysql.TypeTiny
 	}
 
+ArrayKwdOpt:
+	{
+		$$ = false
+	}
+|	"ARRAY"
+	{
+		$$ = true
+	}
+
 OptInteger:
 	{}
 |	"INTEGER"
@@ -11968,6 +12939,55 @@ DateAndTimeType:
 		$$ = x
 	}
 
+SpatialType:
+	GeometryTypeName
+	{
+		x := types.NewFieldType(mysql.TypeGeometry)
+		x.GeomType = $1.(byte)
+		x.Charset = charset.CharsetBin
+		x.Collate = charset.CollationBin
+		x.Flag |= mysql.BinaryFlag
+		$$ = x
+	}
+
+GeometryTypeName:
+	"GEOMETRY"
+	{
+		$$ = types.GeomTypeGeometry
+	}
+|	"POINT"
+	{
+		$$ = types.GeomTypePoint
+	}
+|	"LINESTRING"
+	{
+		$$ = types.GeomTypeLineString
+	}
+|	"POLYGON"
+	{
+		$$ = types.GeomTypePolygon
+	}
+|	"MULTIPOINT"
+	{
+		$$ = types.GeomTypeMultiPoint
+	}
+|	"MULTILINESTRING"
+	{
+		$$ = types.GeomTypeMultiLineString
+	}
+|	"MULTIPOLYGON"
+	{
+		$$ = types.GeomTypeMultiPolygon
+	}
+|	"GEOMETRYCOLLECTION"
+	{
+		$$ = types.GeomTypeGeometryCollection
+	}
+|	"GEOMCOLLECTION"
+	{
+		$$ = types.GeomTypeGeometryCollection
+	}
+
 FieldLen:
 	'(' LengthNum ')'
 	{
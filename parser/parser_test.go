@@ -37,12 +37,12 @@ func TestSimple(t *testing.T) {
 	p := parser.New()
 
 	reservedKws := []string{
-		"add", "all", "alter", "analyze", "and", "as", "asc", "between", "bigint",
+		"add", "all", "alter", "analyze", "and", "as", "asc", "before", "between", "bigint",
 		"binary", "blob", "both", "by", "call", "cascade", "case", "change", "character", "check", "collate",
 		"column", "constraint", "convert", "create", "cross", "current_date", "current_time",
 		"current_timestamp", "current_user", "database", "databases", "day_hour", "day_microsecond",
 		"day_minute", "day_second", "decimal", "default", "delete", "desc", "describe",
-		"distinct", "distinctRow", "div", "double", "drop", "dual", "else", "enclosed", "escaped",
+		"distinct", "distinctRow", "div", "double", "drop", "dual", "each", "else", "enclosed", "escaped",
 		"exists", "explain", "false", "float", "fetch", "for", "force", "foreign", "from",
 		"fulltext", "grant", "group", "having", "hour_microsecond", "hour_minute",
 		"hour_second", "if", "ignore", "in", "index", "infile", "inner", "insert", "int", "into", "integer",
@@ -60,7 +60,7 @@ func TestSimple(t *testing.T) {
 		"delayed", "high_priority", "low_priority",
 		"cumeDist", "denseRank", "firstValue", "lag", "lastValue", "lead", "nthValue", "ntile",
 		"over", "percentRank", "rank", "row", "rows", "rowNumber", "window", "linear",
-		"match", "until", "placement", "tablesample", "attributes",
+		"match", "until", "placement", "tablesample", "attributes", "json_table",
 		// TODO: support the following keywords
 		// "with",
 	}
@@ -98,7 +98,7 @@ func TestSimple(t *testing.T) {
 		"max_connections_per_hour", "max_queries_per_hour", "max_updates_per_hour", "max_user_connections", "event", "reload", "routine", "temporary",
 		"following", "preceding", "unbounded", "respect", "nulls", "current", "last", "against", "expansion",
 		"chain", "error", "general", "nvarchar", "pack_keys", "p", "shard_row_id_bits", "pre_split_regions",
-		"constraints", "role", "replicas", "policy", "s3", "strict", "running", "stop", "preserve", "placement",
+		"constraints", "role", "replicas", "policy", "s3", "strict", "running", "stop", "preserve", "placement", "array", "member",
 	}
 	for _, kw := range unreservedKws {
 		src := fmt.Sprintf("SELECT %s FROM tbl;", kw)
@@ -693,6 +693,17 @@ func TestDMLStmt(t *testing.T) {
 		{"select a,b,a+b from t into outfile '/tmp/result.txt' fields terminated BY ',' optionally enclosed BY '\"' lines starting by 'xy' terminated BY '\r'", true, "SELECT `a`,`b`,`a`+`b` FROM `t` INTO OUTFILE '/tmp/result.txt' FIELDS TERMINATED BY ',' OPTIONALLY ENCLOSED BY '\"' LINES STARTING BY 'xy' TERMINATED BY '\r'"},
 		{"select a,b,a+b from t into outfile '/tmp/result.txt' fields terminated BY ',' enclosed BY '\"' lines starting by 'xy' terminated BY '\r'", true, "SELECT `a`,`b`,`a`+`b` FROM `t` INTO OUTFILE '/tmp/result.txt' FIELDS TERMINATED BY ',' ENCLOSED BY '\"' LINES STARTING BY 'xy' TERMINATED BY '\r'"},
 
+		{"select a from t into outfile 's3://bucket/prefix/result.csv.gz' fields terminated by ',' format = 'CSV' compression 'gzip'", true, "SELECT `a` FROM `t` INTO OUTFILE 's3://bucket/prefix/result.csv.gz' FIELDS TERMINATED BY ',' FORMAT = 'csv' COMPRESSION = 'gzip'"},
+		{"select a from t into outfile 'gcs://bucket/result.parquet' format 'parquet' max_file_size = 1048576", true, "SELECT `a` FROM `t` INTO OUTFILE 'gcs://bucket/result.parquet' FORMAT = 'parquet' MAX_FILE_SIZE = 1048576"},
+		{"table t into outfile '/tmp/result.parquet' format 'parquet' compression 'zstd'", true, "TABLE `t` INTO OUTFILE '/tmp/result.parquet' FORMAT = 'parquet' COMPRESSION = 'zstd'"},
+		{"select a from t into outfile '/tmp/result.txt' max_file_size = 'abc'", false, ""},
+		// select into variables
+		{"select a, b from t into @x, @y", true, "SELECT `a`,`b` FROM `t` INTO @`x`,@`y`"},
+		{"select a, b into @x, y from t where a > 1", true, "SELECT `a`,`b` FROM `t` WHERE `a`>1 INTO @`x`,`y`"},
+		{"select 1 into @a", true, "SELECT 1 INTO @`a`"},
+		{"select 1 into outfile 'a.txt' from t", true, "SELECT 1 FROM `t` INTO OUTFILE 'a.txt'"},
+		{"select 1 into", false, ""},
+
 		// from join
 		{"SELECT * from t1, t2, t3", true, "SELECT * FROM ((`t1`) JOIN `t2`) JOIN `t3`"},
 		{"select * from t1 join t2 left join t3 on t2.id = t3.id", true, "SELECT * FROM (`t1` JOIN `t2`) LEFT JOIN `t3` ON `t2`.`id`=`t3`.`id`"},
@@ -1284,6 +1295,115 @@ func TestDBAStmt(t *testing.T) {
 	RunTest(t, table, false)
 }
 
+func TestStoredProcedure(t *testing.T) {
+	table := []testCase{
+		{"create procedure p() select 1", true, "CREATE PROCEDURE `p`() SELECT 1"},
+		{"create procedure if not exists test.p(a int, in b varchar(10), out c int, inout d double) begin end", true, "CREATE PROCEDURE IF NOT EXISTS `test`.`p`(IN `a` INT,IN `b` VARCHAR(10),OUT `c` INT,INOUT `d` DOUBLE) BEGIN END"},
+		{"create procedure p(out int) begin end", false, ""},
+		{"create procedure p begin end", false, ""},
+		{"drop procedure p", true, "DROP PROCEDURE `p`"},
+		{"drop procedure if exists test.p", true, "DROP PROCEDURE IF EXISTS `test`.`p`"},
+
+		// declarations
+		{"create procedure p() begin declare a, b int default 1; declare c varchar(10); end", true, "CREATE PROCEDURE `p`() BEGIN DECLARE `a`,`b` INT DEFAULT 1; DECLARE `c` VARCHAR(10); END"},
+		{"create procedure p() begin declare cur cursor for select a from t where a > 1; end", true, "CREATE PROCEDURE `p`() BEGIN DECLARE `cur` CURSOR FOR SELECT `a` FROM `t` WHERE `a`>1; END"},
+		{"create procedure p() begin declare continue handler for not found set done = 1; end", true, "CREATE PROCEDURE `p`() BEGIN DECLARE CONTINUE HANDLER FOR NOT FOUND SET @@SESSION.`done`=1; END"},
+		{"create procedure p() begin declare exit handler for sqlexception, sqlwarning, 1062, sqlstate '23000', sqlstate value '42S02' begin rollback; end; end", true, "CREATE PROCEDURE `p`() BEGIN DECLARE EXIT HANDLER FOR SQLEXCEPTION,SQLWARNING,1062,SQLSTATE '23000',SQLSTATE '42S02' BEGIN ROLLBACK; END; END"},
+		{"create procedure p() begin declare handler for not found set done = 1; end", false, ""},
+		{"create procedure p() begin select 1; declare a int; end", false, ""},
+		{"create procedure p() declare a int", false, ""},
+		{"create procedure p(a int) begin if a > 1 then declare b int; end if; end", false, ""},
+
+		// flow control
+		{"create procedure p(a int) begin if a > 1 then select 1; elseif a > 0 then select 2; else select 3; end if; end", true, "CREATE PROCEDURE `p`(IN `a` INT) BEGIN IF `a`>1 THEN SELECT 1; ELSEIF `a`>0 THEN SELECT 2; ELSE SELECT 3; END IF; END"},
+		{"create procedure p(a int) if a > 1 then select 1; end if", true, "CREATE PROCEDURE `p`(IN `a` INT) IF `a`>1 THEN SELECT 1; END IF"},
+		{"create procedure p() begin declare i int default 0; while i < 10 do set i = i + 1; end while; end", true, "CREATE PROCEDURE `p`() BEGIN DECLARE `i` INT DEFAULT 0; WHILE `i`<10 DO SET @@SESSION.`i`=`i`+1; END WHILE; END"},
+		{"create procedure p() begin repeat insert into t values (1); until (select count(*) from t) > 3 end repeat; end", true, "CREATE PROCEDURE `p`() BEGIN REPEAT INSERT INTO `t` VALUES (1); UNTIL (SELECT COUNT(1) FROM `t`)>3 END REPEAT; END"},
+		{"create procedure p() lbl: loop leave lbl; iterate lbl; end loop lbl", true, "CREATE PROCEDURE `p`() `lbl`: LOOP LEAVE `lbl`; ITERATE `lbl`; END LOOP"},
+		{"create procedure p() lbl: begin leave lbl; end", true, "CREATE PROCEDURE `p`() `lbl`: BEGIN LEAVE `lbl`; END"},
+		{"create procedure p() lbl: begin leave lbl; end other", false, ""},
+
+		// cursors
+		{"create procedure p() begin open cur; fetch cur into a, b; fetch next from cur into a; fetch from cur into a; close cur; end", true, "CREATE PROCEDURE `p`() BEGIN OPEN `cur`; FETCH `cur` INTO `a`,`b`; FETCH `cur` INTO `a`; FETCH `cur` INTO `a`; CLOSE `cur`; END"},
+		{"create procedure p() begin select a, b into x, @y from t limit 1; end", true, "CREATE PROCEDURE `p`() BEGIN SELECT `a`,`b` FROM `t` LIMIT 1 INTO `x`,@`y`; END"},
+
+		// statements which are not allowed in a stored procedure
+		{"create procedure p() begin create database d; end", false, ""},
+		{"create procedure p() begin begin; end", false, ""},
+
+		// procedure related keywords can still be used as identifiers
+		{"create table t (declare int, cursor int, handler int, loop int, while int, leave int, exit int, continue int, found int)", true, "CREATE TABLE `t` (`declare` INT,`cursor` INT,`handler` INT,`loop` INT,`while` INT,`leave` INT,`exit` INT,`continue` INT,`found` INT)"},
+	}
+	RunTest(t, table, false)
+}
+
+func TestTrigger(t *testing.T) {
+	table := []testCase{
+		{"create trigger trg before insert on t for each row set new.a = new.a + 1", true, "CREATE TRIGGER `trg` BEFORE INSERT ON `t` FOR EACH ROW SET @@SESSION.`new.a`=`new`.`a`+1"},
+		{"create trigger if not exists test.trg after update on test.t for each row begin insert into log values (old.a, new.a); end", true, "CREATE TRIGGER IF NOT EXISTS `test`.`trg` AFTER UPDATE ON `test`.`t` FOR EACH ROW BEGIN INSERT INTO `log` VALUES (`old`.`a`,`new`.`a`); END"},
+		{"create trigger trg after delete on t for each row follows other delete from log where a = old.a", true, "CREATE TRIGGER `trg` AFTER DELETE ON `t` FOR EACH ROW FOLLOWS `other` DELETE FROM `log` WHERE `a`=`old`.`a`"},
+		{"create trigger trg before delete on t for each row precedes other begin end", true, "CREATE TRIGGER `trg` BEFORE DELETE ON `t` FOR EACH ROW PRECEDES `other` BEGIN END"},
+		{"create trigger trg before insert on t for each row if new.a < 0 then set new.a = 0; end if", true, "CREATE TRIGGER `trg` BEFORE INSERT ON `t` FOR EACH ROW IF `new`.`a`<0 THEN SET @@SESSION.`new.a`=0; END IF"},
+		{"create trigger trg before insert on t for each row follows other lbl: begin end", true, "CREATE TRIGGER `trg` BEFORE INSERT ON `t` FOR EACH ROW FOLLOWS `other` `lbl`: BEGIN END"},
+		{"create trigger trg insert on t for each row begin end", false, ""},
+		{"create trigger trg before truncate on t for each row begin end", false, ""},
+		{"create trigger trg before insert on t begin end", false, ""},
+		{"create trigger trg before insert on t for each row create database d", false, ""},
+		{"drop trigger trg", true, "DROP TRIGGER `trg`"},
+		{"drop trigger if exists test.trg", true, "DROP TRIGGER IF EXISTS `test`.`trg`"},
+
+		// FOLLOWS and PRECEDES can still be used as identifiers
+		{"create table t (follows int, precedes int)", true, "CREATE TABLE `t` (`follows` INT,`precedes` INT)"},
+	}
+	RunTest(t, table, false)
+}
+
+func TestEvent(t *testing.T) {
+	table := []testCase{
+		{"create event e on schedule at '2022-01-01 00:00:00' do delete from t", true, "CREATE EVENT `e` ON SCHEDULE AT _UTF8MB4'2022-01-01 00:00:00' DO DELETE FROM `t`"},
+		{"create event if not exists test.e on schedule at current_timestamp + interval 1 hour on completion preserve do insert into t values (1)", true, "CREATE EVENT IF NOT EXISTS `test`.`e` ON SCHEDULE AT DATE_ADD(CURRENT_TIMESTAMP(), INTERVAL 1 HOUR) ON COMPLETION PRESERVE DO INSERT INTO `t` VALUES (1)"},
+		{"create event e on schedule every 1 day starts now() ends now() + interval 1 month on completion not preserve disable comment 'cleanup' do begin delete from t; end", true, "CREATE EVENT `e` ON SCHEDULE EVERY 1 DAY STARTS NOW() ENDS DATE_ADD(NOW(), INTERVAL 1 MONTH) ON COMPLETION NOT PRESERVE DISABLE COMMENT 'cleanup' DO BEGIN DELETE FROM `t`; END"},
+		{"create event e on schedule every '1:30' hour_minute disable on slave do delete from t", true, "CREATE EVENT `e` ON SCHEDULE EVERY _UTF8MB4'1:30' HOUR_MINUTE DISABLE ON SLAVE DO DELETE FROM `t`"},
+		{"create event e on schedule every 1 day do delete from t", true, "CREATE EVENT `e` ON SCHEDULE EVERY 1 DAY DO DELETE FROM `t`"},
+		{"create event e on schedule every 1 day", false, ""},
+		{"create event e on schedule at now() starts now() do delete from t", false, ""},
+		{"create event e do delete from t", false, ""},
+		{"alter event e on schedule every 2 hour enable", true, "ALTER EVENT `e` ON SCHEDULE EVERY 2 HOUR ENABLE"},
+		{"alter event test.e on completion preserve rename to test.e2 comment '' do delete from t", true, "ALTER EVENT `test`.`e` ON COMPLETION PRESERVE RENAME TO `test`.`e2` COMMENT '' DO DELETE FROM `t`"},
+		{"alter event e disable", true, "ALTER EVENT `e` DISABLE"},
+		{"drop event e", true, "DROP EVENT `e`"},
+		{"drop event if exists test.e", true, "DROP EVENT IF EXISTS `test`.`e`"},
+
+		// The new keywords can still be used as identifiers
+		{"create table t (at int, every int, starts int, ends int, completion int)", true, "CREATE TABLE `t` (`at` INT,`every` INT,`starts` INT,`ends` INT,`completion` INT)"},
+	}
+	RunTest(t, table, false)
+}
+
+func TestXA(t *testing.T) {
+	table := []testCase{
+		{"xa start 'abc'", true, "XA START 'abc'"},
+		{"xa begin 'abc', 'def' join", true, "XA START 'abc','def'"},
+		{"xa start 'abc', 'def', 3 resume", true, "XA START 'abc','def',3"},
+		{"xa start x'616263', '', 2", true, "XA START 'abc','',2"},
+		{"xa end 'abc'", true, "XA END 'abc'"},
+		{"xa end 'abc' suspend for migrate", true, "XA END 'abc'"},
+		{"xa prepare 'abc', 'def'", true, "XA PREPARE 'abc','def'"},
+		{"xa commit 'abc'", true, "XA COMMIT 'abc'"},
+		{"xa commit 'abc' one phase", true, "XA COMMIT 'abc' ONE PHASE"},
+		{"xa rollback 'abc'", true, "XA ROLLBACK 'abc'"},
+		{"xa recover", true, "XA RECOVER"},
+		{"xa recover convert xid", true, "XA RECOVER CONVERT XID"},
+		{"xa start", false, ""},
+		{"xa start abc", false, ""},
+		{"xa prepare 'abc', 'def', -1", false, ""},
+
+		// The new keywords can still be used as identifiers
+		{"create table t (xa int, xid int, suspend int, migrate int, phase int, one int)", true, "CREATE TABLE `t` (`xa` INT,`xid` INT,`suspend` INT,`migrate` INT,`phase` INT,`one` INT)"},
+	}
+	RunTest(t, table, false)
+}
+
 func TestSetVariable(t *testing.T) {
 	table := []struct {
 		Input    string
@@ -1570,6 +1690,17 @@ func TestBuiltin(t *testing.T) {
 		{"select cast('2000' as year);", true, "SELECT CAST(_UTF8MB4'2000' AS YEAR)"},
 		{"select cast(time '2000' as year);", true, "SELECT CAST(TIME '2000' AS YEAR)"},
 
+		// for cast as array
+		{"select cast(j->'$.a' as unsigned array) from t;", true, "SELECT CAST(JSON_EXTRACT(`j`, _UTF8MB4'$.a') AS UNSIGNED ARRAY) FROM `t`"},
+		{"select cast(j as char(10) array) from t;", true, "SELECT CAST(`j` AS CHAR(10) ARRAY) FROM `t`"},
+		{"select convert(j, unsigned array) from t;", false, ""},
+
+		// for member of
+		{"select 1 member of (j) from t;", true, "SELECT 1 MEMBER OF (`j`) FROM `t`"},
+		{"select * from t where 'a' member of (j->'$.tags');", true, "SELECT * FROM `t` WHERE _UTF8MB4'a' MEMBER OF (JSON_EXTRACT(`j`, _UTF8MB4'$.tags'))"},
+		{"select 1 member of j from t;", false, ""},
+		{"select json_overlaps(j, '[1, 2]') from t;", true, "SELECT JSON_OVERLAPS(`j`, _UTF8MB4'[1, 2]') FROM `t`"},
+
 		// for last_insert_id
 		{"SELECT last_insert_id();", true, "SELECT LAST_INSERT_ID()"},
 		{"SELECT last_insert_id(1);", true, "SELECT LAST_INSERT_ID(1)"},
@@ -2915,6 +3046,8 @@ func TestDDL(t *testing.T) {
 		{"ALTER TABLE t ADD FULLTEXT KEY `FullText` (`name` ASC)", true, "ALTER TABLE `t` ADD FULLTEXT `FullText`(`name`)"},
 		{"ALTER TABLE t ADD FULLTEXT `FullText` (`name` ASC)", true, "ALTER TABLE `t` ADD FULLTEXT `FullText`(`name`)"},
 		{"ALTER TABLE t ADD FULLTEXT INDEX `FullText` (`name` ASC)", true, "ALTER TABLE `t` ADD FULLTEXT `FullText`(`name`)"},
+		{"ALTER TABLE t ADD SPATIAL KEY `sp` (`g`)", true, "ALTER TABLE `t` ADD SPATIAL `sp`(`g`)"},
+		{"ALTER TABLE t ADD SPATIAL INDEX (g) COMMENT 'zone'", true, "ALTER TABLE `t` ADD SPATIAL(`g`) COMMENT 'zone'"},
 		{"ALTER TABLE t ADD INDEX (a) USING BTREE COMMENT 'a'", true, "ALTER TABLE `t` ADD INDEX(`a`) USING BTREE COMMENT 'a'"},
 		{"ALTER TABLE t ADD INDEX IF NOT EXISTS (a) USING BTREE COMMENT 'a'", true, "ALTER TABLE `t` ADD INDEX IF NOT EXISTS(`a`) USING BTREE COMMENT 'a'"},
 		{"ALTER TABLE t ADD INDEX (a) USING RTREE COMMENT 'a'", true, "ALTER TABLE `t` ADD INDEX(`a`) USING RTREE COMMENT 'a'"},
@@ -3067,6 +3200,8 @@ func TestDDL(t *testing.T) {
 		{"CREATE UNIQUE INDEX ident ON d_n.t_n ( ident , ident ASC ) TYPE BTREE", true, "CREATE UNIQUE INDEX `ident` ON `d_n`.`t_n` (`ident`, `ident`) USING BTREE"},
 		{"CREATE UNIQUE INDEX ident ON d_n.t_n ( ident , ident ASC ) TYPE HASH", true, "CREATE UNIQUE INDEX `ident` ON `d_n`.`t_n` (`ident`, `ident`) USING HASH"},
 		{"CREATE UNIQUE INDEX ident ON d_n.t_n ( ident , ident ASC ) TYPE RTREE", true, "CREATE UNIQUE INDEX `ident` ON `d_n`.`t_n` (`ident`, `ident`) USING RTREE"},
+		{"CREATE INDEX ident ON t ( a , b ) TYPE HYPO", true, "CREATE INDEX `ident` ON `t` (`a`, `b`) USING HYPO"},
+		{"CREATE INDEX ident USING HYPO ON t ( a )", true, "CREATE INDEX `ident` ON `t` (`a`) USING HYPO"},
 		{"CREATE UNIQUE INDEX ident TYPE BTREE ON d_n.t_n ( ident , ident ASC )", true, "CREATE UNIQUE INDEX `ident` ON `d_n`.`t_n` (`ident`, `ident`) USING BTREE"},
 		{"CREATE UNIQUE INDEX ident USING BTREE ON d_n.t_n ( ident , ident ASC )", true, "CREATE UNIQUE INDEX `ident` ON `d_n`.`t_n` (`ident`, `ident`) USING BTREE"},
 		{"CREATE SPATIAL INDEX idx ON t (a)", true, "CREATE SPATIAL INDEX `idx` ON `t` (`a`)"},
@@ -3111,6 +3246,9 @@ func TestDDL(t *testing.T) {
 		{"drop index if exists a on t", true, "DROP INDEX IF EXISTS `a` ON `t`"},
 		{"drop index if exists a on db.t", true, "DROP INDEX IF EXISTS `a` ON `db`.`t`"},
 		{"drop index if exists a on db.`tb-ttb`", true, "DROP INDEX IF EXISTS `a` ON `db`.`tb-ttb`"},
+		{"drop hypo index a on t", true, "DROP HYPO INDEX `a` ON `t`"},
+		{"drop hypo index if exists a on db.t", true, "DROP HYPO INDEX IF EXISTS `a` ON `db`.`t`"},
+		{"drop hypo index a on t algorithm = inplace", false, ""},
 		{"drop index idx on t algorithm = default", true, "DROP INDEX `idx` ON `t`"},
 		{"drop index idx on t algorithm default", true, "DROP INDEX `idx` ON `t`"},
 		{"drop index idx on t algorithm = inplace", true, "DROP INDEX `idx` ON `t` ALGORITHM = INPLACE"},
@@ -3389,6 +3527,7 @@ func TestDDL(t *testing.T) {
 		{"create table a(a int, b int, key(a, (b+1)));", true, "CREATE TABLE `a` (`a` INT,`b` INT,INDEX(`a`, (`b`+1)))"},
 		{"create table a(a int, b int, key((a+1), b));", true, "CREATE TABLE `a` (`a` INT,`b` INT,INDEX((`a`+1), `b`))"},
 		{"create table a(a int, b int, key((a + 1) desc));", true, "CREATE TABLE `a` (`a` INT,`b` INT,INDEX((`a`+1)))"},
+		{"create table a(j json, key((cast(j->'$.tags' as unsigned array))));", true, "CREATE TABLE `a` (`j` JSON,INDEX((CAST(JSON_EXTRACT(`j`, _UTF8MB4'$.tags') AS UNSIGNED ARRAY))))"},
 
 		// for create sequence
 		{"create sequence sequence", true, "CREATE SEQUENCE `sequence`"},
@@ -4224,6 +4363,15 @@ func TestType(t *testing.T) {
 
 		// for json type
 		{`create table t (a JSON);`, true, "CREATE TABLE `t` (`a` JSON)"},
+
+		// for spatial types
+		{"create table t (g geometry, p point not null srid 4326, l linestring, pg polygon srid 0)", true, "CREATE TABLE `t` (`g` GEOMETRY,`p` POINT NOT NULL SRID 4326,`l` LINESTRING,`pg` POLYGON SRID 0)"},
+		{"create table t (p point not null srid 4326, spatial key sp(p), spatial index (p))", true, "CREATE TABLE `t` (`p` POINT NOT NULL SRID 4326,SPATIAL `sp`(`p`),SPATIAL(`p`))"},
+		{"create table t (a multipoint, b multilinestring, c multipolygon, d geometrycollection, e geomcollection)", true, "CREATE TABLE `t` (`a` MULTIPOINT,`b` MULTILINESTRING,`c` MULTIPOLYGON,`d` GEOMCOLLECTION,`e` GEOMCOLLECTION)"},
+		{"create table t (g geometry srid)", false, ""},
+		{"create table t (point int, polygon int, srid int)", true, "CREATE TABLE `t` (`point` INT,`polygon` INT,`srid` INT)"},
+		{"select point(1, 2), polygon(linestring(point(0, 0), point(1, 1), point(0, 0)))", true, "SELECT POINT(1, 2),POLYGON(LINESTRING(POINT(0, 0), POINT(1, 1), POINT(0, 0)))"},
+		{"select st_contains(g, point(1, 2)) from t", true, "SELECT ST_CONTAINS(`g`, POINT(1, 2)) FROM `t`"},
 	}
 	RunTest(t, table, false)
 }
@@ -5342,6 +5490,32 @@ func TestTableSample(t *testing.T) {
 	}
 }
 
+func TestJSONTable(t *testing.T) {
+	table := []testCase{
+		// positive test cases
+		{`select * from json_table('[1, 2]', '$[*]' columns (a int path '$')) as jt`, true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[1, 2]', '$[*]' COLUMNS (`a` INT PATH '$')) AS `jt`"},
+		{`select * from json_table('[1, 2]', '$[*]' columns (a int path '$')) jt`, true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[1, 2]', '$[*]' COLUMNS (`a` INT PATH '$')) AS `jt`"},
+		{`select * from json_table('[]', '$[*]' columns (id for ordinality, b varchar(10) exists path '$.b')) as jt`, true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[]', '$[*]' COLUMNS (`id` FOR ORDINALITY, `b` VARCHAR(10) EXISTS PATH '$.b')) AS `jt`"},
+		{`select * from json_table('[]', '$[*]' columns (a int path '$.a' null on empty)) as jt`, true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[]', '$[*]' COLUMNS (`a` INT PATH '$.a' NULL ON EMPTY)) AS `jt`"},
+		{`select * from json_table('[]', '$[*]' columns (a int path '$.a' error on error)) as jt`, true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[]', '$[*]' COLUMNS (`a` INT PATH '$.a' ERROR ON ERROR)) AS `jt`"},
+		{`select * from json_table('[]', '$[*]' columns (a int path '$.a' default '1' on empty default '2' on error)) as jt`, true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[]', '$[*]' COLUMNS (`a` INT PATH '$.a' DEFAULT '1' ON EMPTY DEFAULT '2' ON ERROR)) AS `jt`"},
+		{`select * from json_table('[]', '$[*]' columns (a int path '$.a', nested path '$.b[*]' columns (b int path '$'), nested '$.c' columns (c json path '$'))) as jt`, true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[]', '$[*]' COLUMNS (`a` INT PATH '$.a', NESTED PATH '$.b[*]' COLUMNS (`b` INT PATH '$'), NESTED PATH '$.c' COLUMNS (`c` JSON PATH '$'))) AS `jt`"},
+		{`select * from json_table('[]', '$[*]' columns (nested int path '$', path int path '$', ordinality int path '$')) as jt`, true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[]', '$[*]' COLUMNS (`nested` INT PATH '$', `path` INT PATH '$', `ordinality` INT PATH '$')) AS `jt`"},
+		{`select t.a, jt.b from t, json_table(t.doc, '$[*]' columns (b int path '$')) as jt where t.a > 1`, true, "SELECT `t`.`a`,`jt`.`b` FROM (`t`) JOIN JSON_TABLE(`t`.`doc`, '$[*]' COLUMNS (`b` INT PATH '$')) AS `jt` WHERE `t`.`a`>1"},
+		{`select * from t left join json_table(t.doc, '$' columns (b int path '$.b')) as jt on true`, true, "SELECT * FROM `t` LEFT JOIN JSON_TABLE(`t`.`doc`, '$' COLUMNS (`b` INT PATH '$.b')) AS `jt` ON TRUE"},
+
+		// negative test cases
+		{`select * from json_table('[]', '$[*]' columns (a int path '$'))`, false, ""},
+		{`select * from json_table('[]', '$[*]' columns ()) as jt`, false, ""},
+		{`select * from json_table('[]', '$[*]' columns (a int)) as jt`, false, ""},
+		{`select * from json_table('[]', '$[*]' columns (a int path '$' error on error null on empty)) as jt`, false, ""},
+		{`select * from json_table('[]', '$[*]' columns (a int exists path '$' null on empty)) as jt`, false, ""},
+		{`select * from json_table('[]', '$[*]' columns (a int path '$' default 1 on empty)) as jt`, false, ""},
+		{`select * from json_table('[]', concat('$', '[*]') columns (a int path '$')) as jt`, false, ""},
+	}
+	RunTest(t, table, false)
+}
+
 func TestGeneratedColumn(t *testing.T) {
 	tests := []struct {
 		input string
@@ -5703,6 +5877,9 @@ func TestWindowFunctions(t *testing.T) {
 		{`SELECT COUNT(*) OVER() AS country_profit FROM sales;`, true, "SELECT COUNT(1) OVER () AS `country_profit` FROM `sales`"},
 		{`SELECT MAX(profit) OVER() AS country_profit FROM sales;`, true, "SELECT MAX(`profit`) OVER () AS `country_profit` FROM `sales`"},
 		{`SELECT MIN(profit) OVER() AS country_profit FROM sales;`, true, "SELECT MIN(`profit`) OVER () AS `country_profit` FROM `sales`"},
+		{`SELECT COUNT(DISTINCT profit) OVER() AS country_profit FROM sales;`, true, "SELECT COUNT(DISTINCT `profit`) OVER () AS `country_profit` FROM `sales`"},
+		{`SELECT GROUP_CONCAT(product ORDER BY year SEPARATOR ';') OVER() FROM sales;`, true, "SELECT GROUP_CONCAT(`product` ORDER BY `year` SEPARATOR ';') OVER () FROM `sales`"},
+		{`SELECT GROUP_CONCAT(DISTINCT product, country) OVER(PARTITION BY year) FROM sales;`, true, "SELECT GROUP_CONCAT(DISTINCT `product`, `country` SEPARATOR ',') OVER (PARTITION BY `year`) FROM `sales`"},
 		{`SELECT SUM(profit) OVER() AS country_profit FROM sales;`, true, "SELECT SUM(`profit`) OVER () AS `country_profit` FROM `sales`"},
 		{`SELECT ROW_NUMBER() OVER(PARTITION BY country) AS row_num1 FROM sales;`, true, "SELECT ROW_NUMBER() OVER (PARTITION BY `country`) AS `row_num1` FROM `sales`"},
 		{`SELECT ROW_NUMBER() OVER(PARTITION BY country, d ORDER BY year, product) AS row_num2 FROM sales;`, true, "SELECT ROW_NUMBER() OVER (PARTITION BY `country`, `d` ORDER BY `year`,`product`) AS `row_num2` FROM `sales`"},
@@ -5713,6 +5890,7 @@ func TestWindowFunctions(t *testing.T) {
 		{`SELECT AVG(val) OVER (PARTITION BY subject ORDER BY time ROWS BETWEEN 1 PRECEDING AND 1 FOLLOWING) FROM t;`, true, "SELECT AVG(`val`) OVER (PARTITION BY `subject` ORDER BY `time` ROWS BETWEEN 1 PRECEDING AND 1 FOLLOWING) FROM `t`"},
 		{`SELECT AVG(val) OVER (ROWS BETWEEN 1 PRECEDING AND 1 FOLLOWING) FROM t;`, true, "SELECT AVG(`val`) OVER (ROWS BETWEEN 1 PRECEDING AND 1 FOLLOWING) FROM `t`"},
 		{`SELECT AVG(val) OVER (ROWS BETWEEN 1 PRECEDING AND UNBOUNDED FOLLOWING) FROM t;`, true, "SELECT AVG(`val`) OVER (ROWS BETWEEN 1 PRECEDING AND UNBOUNDED FOLLOWING) FROM `t`"},
+		{`SELECT AVG(val) OVER (ORDER BY time GROUPS BETWEEN 1 PRECEDING AND CURRENT ROW) FROM t;`, true, "SELECT AVG(`val`) OVER (ORDER BY `time` GROUPS BETWEEN 1 PRECEDING AND CURRENT ROW) FROM `t`"},
 		{`SELECT AVG(val) OVER (RANGE BETWEEN INTERVAL 5 DAY PRECEDING AND INTERVAL '2:30' MINUTE_SECOND FOLLOWING) FROM t;`, true, "SELECT AVG(`val`) OVER (RANGE BETWEEN INTERVAL 5 DAY PRECEDING AND INTERVAL _UTF8MB4'2:30' MINUTE_SECOND FOLLOWING) FROM `t`"},
 		{`SELECT AVG(val) OVER (RANGE BETWEEN CURRENT ROW AND CURRENT ROW) FROM t;`, true, "SELECT AVG(`val`) OVER (RANGE BETWEEN CURRENT ROW AND CURRENT ROW) FROM `t`"},
 		{`SELECT AVG(val) OVER (RANGE CURRENT ROW) FROM t;`, true, "SELECT AVG(`val`) OVER (RANGE BETWEEN CURRENT ROW AND CURRENT ROW) FROM `t`"},
@@ -46,6 +46,42 @@ type FieldType struct {
 	Collate string
 	// Elems is the element list for enum and set type.
 	Elems []string
+	// Array indicates the values are arrays of the type, it's only used by the
+	// CAST(... AS ... ARRAY) of multi-valued index, whose values are JSON arrays.
+	Array bool
+	// GeomType is the subtype of the GEOMETRY type, such as POINT and POLYGON.
+	GeomType byte
+}
+
+// The subtypes of the GEOMETRY type, the values are the same as the geometry type codes of WKB.
+const (
+	GeomTypeGeometry byte = iota
+	GeomTypePoint
+	GeomTypeLineString
+	GeomTypePolygon
+	GeomTypeMultiPoint
+	GeomTypeMultiLineString
+	GeomTypeMultiPolygon
+	GeomTypeGeometryCollection
+)
+
+var geomType2Str = []string{
+	GeomTypeGeometry:           "geometry",
+	GeomTypePoint:              "point",
+	GeomTypeLineString:         "linestring",
+	GeomTypePolygon:            "polygon",
+	GeomTypeMultiPoint:         "multipoint",
+	GeomTypeMultiLineString:    "multilinestring",
+	GeomTypeMultiPolygon:       "multipolygon",
+	GeomTypeGeometryCollection: "geomcollection",
+}
+
+// GeomTypeToStr converts the subtype of the GEOMETRY type to its name.
+func GeomTypeToStr(tp byte) string {
+	if int(tp) < len(geomType2Str) {
+		return geomType2Str[tp]
+	}
+	return geomType2Str[GeomTypeGeometry]
 }
 
 // NewFieldType returns a FieldType,
@@ -79,7 +115,9 @@ func (ft *FieldType) Equal(other *FieldType) bool {
 		ft.Charset == other.Charset &&
 		ft.Collate == other.Collate &&
 		flenEqual &&
-		mysql.HasUnsignedFlag(ft.Flag) == mysql.HasUnsignedFlag(other.Flag)
+		mysql.HasUnsignedFlag(ft.Flag) == mysql.HasUnsignedFlag(other.Flag) &&
+		ft.Array == other.Array &&
+		ft.GeomType == other.GeomType
 	if !partialEqual || len(ft.Elems) != len(other.Elems) {
 		return false
 	}
@@ -93,6 +131,9 @@ func (ft *FieldType) Equal(other *FieldType) bool {
 
 // EvalType gets the type in evaluation.
 func (ft *FieldType) EvalType() EvalType {
+	if ft.Array {
+		return ETJson
+	}
 	switch ft.Tp {
 	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong, mysql.TypeLonglong,
 		mysql.TypeBit, mysql.TypeYear:
@@ -133,6 +174,9 @@ func (ft *FieldType) Init(tp byte) {
 // This is used for showing column type in infoschema.
 func (ft *FieldType) CompactStr() string {
 	ts := TypeToStr(ft.Tp, ft.Charset)
+	if ft.Tp == mysql.TypeGeometry {
+		ts = GeomTypeToStr(ft.GeomType)
+	}
 	suffix := ""
 
 	defaultFlen, defaultDecimal := mysql.GetDefaultFieldLengthAndDecimal(ft.Tp)
@@ -222,6 +266,10 @@ func (ft *FieldType) String() string {
 
 // Restore implements Node interface.
 func (ft *FieldType) Restore(ctx *format.RestoreCtx) error {
+	if ft.Tp == mysql.TypeGeometry {
+		ctx.WriteKeyWord(GeomTypeToStr(ft.GeomType))
+		return nil
+	}
 	ctx.WriteKeyWord(TypeToStr(ft.Tp, ft.Charset))
 
 	precision := UnspecifiedLength
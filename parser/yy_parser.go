@@ -24,6 +24,7 @@ import (
 	"github.com/pingcap/tidb/parser/ast"
 	"github.com/pingcap/tidb/parser/auth"
 	"github.com/pingcap/tidb/parser/charset"
+	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/parser/terror"
 	"github.com/pingcap/tidb/parser/types"
@@ -331,6 +332,15 @@ func getUint64FromNUM(num interface{}) uint64 {
 	return 0
 }
 
+// procedureVarNames converts the identifiers of local variables to their names.
+func procedureVarNames(idents []model.CIStr) []string {
+	names := make([]string, 0, len(idents))
+	for _, ident := range idents {
+		names = append(names, ident.O)
+	}
+	return names
+}
+
 func getInt64FromNUM(num interface{}) (val int64, errMsg string) {
 	switch v := num.(type) {
 	case int64:
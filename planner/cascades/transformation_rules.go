@@ -2562,6 +2562,13 @@ func (r *MergeAdjacentWindow) Match(expr *memo.ExprIter) bool {
 				}
 			}
 		}
+		for _, by := range funDesc.OrderByItems {
+			for _, c := range expression.ExtractColumns(by.Expr) {
+				if _, ok := nextWindowChildrenExistedCols[c.UniqueID]; !ok {
+					return false
+				}
+			}
+		}
 	}
 	return true
 }
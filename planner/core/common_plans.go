@@ -1028,6 +1028,9 @@ type Insert struct {
 	AllAssignmentsAreConstant bool
 
 	RowLen int
+
+	FKChecks   []*FKCheck
+	FKCascades []*FKCascade
 }
 
 // Update represents Update plan.
@@ -1049,6 +1052,10 @@ type Update struct {
 	PartitionedTable []table.PartitionedTable
 
 	tblID2Table map[int64]table.Table
+
+	// FKChecks and FKCascades hold the foreign key triggers of the updated tables, keyed by the table ID.
+	FKChecks   map[int64][]*FKCheck
+	FKCascades map[int64][]*FKCascade
 }
 
 // Delete represents a delete plan.
@@ -1060,6 +1067,10 @@ type Delete struct {
 	SelectPlan PhysicalPlan
 
 	TblColPosInfos TblColPosInfoSlice
+
+	// FKChecks and FKCascades hold the foreign key triggers of the deleted tables, keyed by the table ID.
+	FKChecks   map[int64][]*FKCheck
+	FKCascades map[int64][]*FKCascade
 }
 
 // AnalyzeInfo is used to store the database name, table name and partition name of analyze task.
@@ -1144,6 +1155,13 @@ type PlanReplayer struct {
 	File     string
 }
 
+// XARecover represents a XA RECOVER plan.
+type XARecover struct {
+	baseSchemaProducer
+
+	ConvertXID bool
+}
+
 // IndexAdvise represents a index advise plan.
 type IndexAdvise struct {
 	baseSchemaProducer
@@ -1188,7 +1206,9 @@ type SelectInto struct {
 	baseSchemaProducer
 
 	TargetPlan Plan
-	IntoOpt    *ast.SelectIntoOption
+	// TargetNames are the output names of TargetPlan.
+	TargetNames types.NameSlice
+	IntoOpt     *ast.SelectIntoOption
 }
 
 // Explain represents a explain plan.
@@ -1406,17 +1426,18 @@ func (e *Explain) explainPlanInRowFormat(p Plan, taskType, driverSide, indent st
 		}
 		err = e.explainPlanInRowFormat(x.tablePlan, "cop[tikv]", "(Probe)", childIndent, true)
 	case *Insert:
-		if x.SelectPlan != nil {
-			err = e.explainPlanInRowFormat(x.SelectPlan, "root", "", childIndent, true)
+		fkPlans := make([]Plan, 0, len(x.FKChecks)+len(x.FKCascades))
+		for _, check := range x.FKChecks {
+			fkPlans = append(fkPlans, check)
 		}
-	case *Update:
-		if x.SelectPlan != nil {
-			err = e.explainPlanInRowFormat(x.SelectPlan, "root", "", childIndent, true)
+		for _, cascade := range x.FKCascades {
+			fkPlans = append(fkPlans, cascade)
 		}
+		err = e.explainDMLPlanInRowFormat(x.SelectPlan, fkPlans, childIndent)
+	case *Update:
+		err = e.explainDMLPlanInRowFormat(x.SelectPlan, flattenFKPlans(x.FKChecks, x.FKCascades), childIndent)
 	case *Delete:
-		if x.SelectPlan != nil {
-			err = e.explainPlanInRowFormat(x.SelectPlan, "root", "", childIndent, true)
-		}
+		err = e.explainDMLPlanInRowFormat(x.SelectPlan, flattenFKPlans(x.FKChecks, x.FKCascades), childIndent)
 	case *Execute:
 		if x.Plan != nil {
 			err = e.explainPlanInRowFormat(x.Plan, "root", "", indent, true)
@@ -1429,6 +1450,23 @@ func (e *Explain) explainPlanInRowFormat(p Plan, taskType, driverSide, indent st
 	return
 }
 
+// explainDMLPlanInRowFormat explains the select plan and the foreign key triggers of the DML plan.
+func (e *Explain) explainDMLPlanInRowFormat(selectPlan PhysicalPlan, fkPlans []Plan, childIndent string) (err error) {
+	if selectPlan != nil {
+		err = e.explainPlanInRowFormat(selectPlan, "root", "", childIndent, len(fkPlans) == 0)
+		if err != nil {
+			return
+		}
+	}
+	for i, p := range fkPlans {
+		err = e.explainPlanInRowFormat(p, "root", "", childIndent, i == len(fkPlans)-1)
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
 func getRuntimeInfo(ctx sessionctx.Context, p Plan, runtimeStatsColl *execdetails.RuntimeStatsColl) (actRows, analyzeInfo, memoryInfo, diskInfo string) {
 	if runtimeStatsColl == nil {
 		runtimeStatsColl = ctx.GetSessionVars().StmtCtx.RuntimeStatsColl
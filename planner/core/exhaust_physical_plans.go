@@ -830,7 +830,10 @@ func (p *LogicalJoin) buildIndexJoinInner2TableScan(
 func (p *LogicalJoin) buildIndexJoinInner2IndexScan(
 	prop *property.PhysicalProperty, ds *DataSource, innerJoinKeys, outerJoinKeys []*expression.Column,
 	outerIdx int, us *LogicalUnionScan, avgInnerRowCnt float64) (joins []PhysicalPlan) {
-	helper, keyOff2IdxOff := p.getIndexJoinBuildHelper(ds, innerJoinKeys, func(path *util.AccessPath) bool { return !path.IsTablePath() }, outerJoinKeys)
+	helper, keyOff2IdxOff := p.getIndexJoinBuildHelper(ds, innerJoinKeys, func(path *util.AccessPath) bool {
+		// The multi-valued, FULLTEXT and SPATIAL indexes can only be accessed by the ranges built from their own predicates.
+		return !path.IsTablePath() && (path.Index == nil || (!path.Index.MVIndex && !path.Index.FullText && !path.Index.Spatial))
+	}, outerJoinKeys)
 	if helper == nil {
 		return nil
 	}
@@ -109,7 +109,11 @@ func (p *PhysicalIndexScan) AccessObject(normalized bool) string {
 		}
 	}
 	if len(p.Index.Columns) > 0 {
-		buffer.WriteString(", index:" + p.Index.Name.O + "(")
+		if p.Index.Tp == model.IndexTypeHypo {
+			buffer.WriteString(", hypo index:" + p.Index.Name.O + "(")
+		} else {
+			buffer.WriteString(", index:" + p.Index.Name.O + "(")
+		}
 		for i, idxCol := range p.Index.Columns {
 			if tblCol := p.Table.Columns[idxCol.Offset]; tblCol.Hidden {
 				buffer.WriteString(tblCol.GeneratedExprString)
@@ -496,6 +500,9 @@ func (p *PhysicalIndexLookUpReader) accessObject(sctx sessionctx.Context) string
 
 // ExplainInfo implements Plan interface.
 func (p *PhysicalIndexMergeReader) ExplainInfo() string {
+	if p.IsIntersectionType {
+		return "type: intersection"
+	}
 	return ""
 }
 
@@ -549,6 +556,20 @@ func (p *PhysicalTableDual) ExplainInfo() string {
 	return str.String()
 }
 
+// ExplainInfo implements Plan interface.
+func (p *PhysicalJSONTable) ExplainInfo() string {
+	return explainJSONTable(p.Expr, p.RootPath)
+}
+
+// ExplainInfo implements Plan interface.
+func (p *LogicalJSONTable) ExplainInfo() string {
+	return explainJSONTable(p.Expr, p.RootPath)
+}
+
+func explainJSONTable(expr expression.Expression, root *JSONTableNestedPath) string {
+	return fmt.Sprintf("expr:%s, path:%s", expr.ExplainInfo(), root.Path.String())
+}
+
 // ExplainInfo implements Plan interface.
 func (p *PhysicalSort) ExplainInfo() string {
 	buffer := bytes.NewBufferString("")
@@ -861,9 +882,12 @@ func (p *PhysicalWindow) ExplainInfo() string {
 		if !isFirst {
 			buffer.WriteString(" ")
 		}
-		if p.Frame.Type == ast.Rows {
+		switch p.Frame.Type {
+		case ast.Rows:
 			buffer.WriteString("rows")
-		} else {
+		case ast.Groups:
+			buffer.WriteString("groups")
+		default:
 			buffer.WriteString("range")
 		}
 		buffer.WriteString(" between ")
@@ -43,6 +43,7 @@ import (
 	"github.com/pingcap/tidb/util/chunk"
 	"github.com/pingcap/tidb/util/codec"
 	"github.com/pingcap/tidb/util/collate"
+	"github.com/pingcap/tidb/util/fulltext"
 	"github.com/pingcap/tidb/util/hint"
 	"github.com/pingcap/tidb/util/sem"
 	"github.com/pingcap/tidb/util/stringutil"
@@ -1124,7 +1125,11 @@ func (er *expressionRewriter) Leave(originInNode ast.Node) (retNode ast.Node, ok
 			return retNode, false
 		}
 
-		castFunction := expression.BuildCastFunction(er.sctx, arg, v.Tp)
+		castFunction, err := expression.BuildCastFunctionWithCheck(er.sctx, arg, v.Tp)
+		if err != nil {
+			er.err = err
+			return retNode, false
+		}
 		if v.Tp.EvalType() == types.ETString {
 			castFunction.SetCoercibility(expression.CoercibilityImplicit)
 			if v.Tp.Charset == charset.CharsetASCII {
@@ -1157,6 +1162,8 @@ func (er *expressionRewriter) Leave(originInNode ast.Node) (retNode ast.Node, ok
 		er.isTrueToScalarFunc(v)
 	case *ast.DefaultExpr:
 		er.evalDefaultExpr(v)
+	case *ast.MatchAgainst:
+		er.matchAgainstToExpression(v)
 	// TODO: Perhaps we don't need to transcode these back to generic integers/strings
 	case *ast.TrimDirectionExpr:
 		er.ctxStackAppend(&expression.Constant{
@@ -1933,6 +1940,85 @@ func (er *expressionRewriter) toColumn(v *ast.ColumnName) {
 	er.err = ErrUnknownColumn.GenWithStackByArgs(v.String(), clauseMsg[er.b.curClause])
 }
 
+// matchAgainstToExpression rewrites MATCH (...) AGAINST (...) to the full-text search function. Like MySQL,
+// the columns must be exactly the columns of a FULLTEXT index, whose parser is used to tokenize the texts.
+func (er *expressionRewriter) matchAgainstToExpression(v *ast.MatchAgainst) {
+	stkLen := len(er.ctxStack)
+	colCnt := len(v.ColumnNames)
+	against := er.ctxStack[stkLen-1]
+	if !against.ConstItem(er.sctx.GetSessionVars().StmtCtx) {
+		er.err = ErrWrongArguments.GenWithStackByArgs("AGAINST")
+		return
+	}
+	if v.Modifier.WithQueryExpansion() {
+		er.err = ErrNotSupportedYet.GenWithStackByArgs("WITH QUERY EXPANSION")
+		return
+	}
+	idx := er.findFullTextIndex(er.ctxStack[stkLen-colCnt-1:stkLen-1], er.ctxNameStk[stkLen-colCnt-1:stkLen-1])
+	if idx == nil {
+		er.err = ErrFtMatchingKeyNotFound
+		return
+	}
+	tk, err := fulltext.NewTokenizer(idx.FullTextParser)
+	if err != nil {
+		er.err = err
+		return
+	}
+	mode := fulltext.ModeNaturalLanguage
+	if v.Modifier.IsBooleanMode() {
+		mode = fulltext.ModeBoolean
+	}
+	args := make([]expression.Expression, 0, colCnt+3)
+	args = append(args, er.ctxStack[stkLen-colCnt-1:]...)
+	args = append(args,
+		expression.DatumToConstant(types.NewStringDatum(mode), mysql.TypeVarString, 0),
+		expression.DatumToConstant(types.NewStringDatum(tk.Name()), mysql.TypeVarString, 0))
+	function, err := er.newFunction(ast.FullTextMatch, types.NewFieldType(mysql.TypeDouble), args...)
+	if err != nil {
+		er.err = err
+		return
+	}
+	er.ctxStackPop(colCnt + 1)
+	er.ctxStackAppend(function, types.EmptyName)
+}
+
+// findFullTextIndex finds the public FULLTEXT index whose columns are the same as the columns of MATCH.
+func (er *expressionRewriter) findFullTextIndex(cols []expression.Expression, names []*types.FieldName) *model.IndexInfo {
+	if er.b == nil || er.b.is == nil {
+		return nil
+	}
+	colNames := make(map[string]struct{}, len(cols))
+	for i, arg := range cols {
+		if _, ok := arg.(*expression.Column); !ok || names[i] == nil || names[i].OrigTblName.L == "" {
+			return nil
+		}
+		if names[i].DBName.L != names[0].DBName.L || names[i].OrigTblName.L != names[0].OrigTblName.L {
+			return nil
+		}
+		colNames[names[i].OrigColName.L] = struct{}{}
+	}
+	tbl, err := er.b.is.TableByName(names[0].DBName, names[0].OrigTblName)
+	if err != nil {
+		return nil
+	}
+	for _, idx := range tbl.Meta().Indices {
+		if !idx.FullText || idx.State != model.StatePublic || len(idx.Columns) != len(colNames) {
+			continue
+		}
+		matched := true
+		for _, idxCol := range idx.Columns {
+			if _, ok := colNames[idxCol.Name.L]; !ok {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return idx
+		}
+	}
+	return nil
+}
+
 func findFieldNameFromNaturalUsingJoin(p LogicalPlan, v *ast.ColumnName) (col *expression.Column, name *types.FieldName, err error) {
 	switch x := p.(type) {
 	case *LogicalLimit, *LogicalSelection, *LogicalTopN, *LogicalSort, *LogicalMaxOneRow:
@@ -23,6 +23,7 @@ import (
 	"github.com/pingcap/tidb/expression"
 	"github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/charset"
 	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/planner/property"
@@ -30,10 +31,14 @@ import (
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/statistics"
+	"github.com/pingcap/tidb/tablecodec"
 	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/types/json"
+	"github.com/pingcap/tidb/types/spatial"
 	tidbutil "github.com/pingcap/tidb/util"
 	"github.com/pingcap/tidb/util/chunk"
 	"github.com/pingcap/tidb/util/collate"
+	"github.com/pingcap/tidb/util/fulltext"
 	"github.com/pingcap/tidb/util/logutil"
 	"github.com/pingcap/tidb/util/ranger"
 	"github.com/pingcap/tidb/util/set"
@@ -152,6 +157,16 @@ func (p *LogicalTableDual) findBestTask(prop *property.PhysicalProperty, planCou
 	return &rootTask{p: dual, isEmpty: p.RowCount == 0}, 1, nil
 }
 
+func (p *LogicalJSONTable) findBestTask(prop *property.PhysicalProperty, planCounter *PlanCounterTp, opt *physicalOptimizeOp) (task, int64, error) {
+	if !prop.IsEmpty() || planCounter.Empty() {
+		return invalidTask, 0, nil
+	}
+	jt := PhysicalJSONTable{Expr: p.Expr, RootPath: p.RootPath}.Init(p.ctx, p.stats, p.blockOffset)
+	jt.SetSchema(p.schema)
+	planCounter.Dec(1)
+	return &rootTask{p: jt}, 1, nil
+}
+
 func (p *LogicalShow) findBestTask(prop *property.PhysicalProperty, planCounter *PlanCounterTp, opt *physicalOptimizeOp) (task, int64, error) {
 	if !prop.IsEmpty() || planCounter.Empty() {
 		return invalidTask, 0, nil
@@ -578,6 +593,11 @@ func (ds *DataSource) getIndexCandidate(path *util.AccessPath, prop *property.Ph
 	candidate.isMatchProp = ds.isMatchProp(path, prop)
 	candidate.accessCondsColMap = util.ExtractCol2Len(path.AccessConds, path.IdxCols, path.IdxColLens)
 	candidate.indexCondsColMap = util.ExtractCol2Len(append(path.AccessConds, path.IndexFilters...), path.FullIdxCols, path.FullIdxColLens)
+	if path.Index.MVIndex || path.Index.FullText || path.Index.Spatial {
+		// The access condition of the multi-valued, FULLTEXT or SPATIAL index is on the original columns rather than
+		// the index column, but the range is built on the index column.
+		candidate.accessCondsColMap = util.Col2Len{path.IdxCols[0].UniqueID: path.IdxColLens[0]}
+	}
 	return candidate
 }
 
@@ -817,6 +837,10 @@ func (ds *DataSource) findBestTask(prop *property.PhysicalProperty, planCounter
 	for _, candidate := range candidates {
 		path := candidate.path
 		if path.PartialIndexPaths != nil {
+			// TiFlash storage do not support index scan.
+			if ds.preferStoreType&preferTiFlash != 0 {
+				continue
+			}
 			idxMergeTask, err := ds.convertToIndexMergeScan(prop, candidate, opt)
 			if err != nil {
 				return nil, 0, err
@@ -1024,6 +1048,10 @@ func (ds *DataSource) convertToIndexMergeScan(prop *property.PhysicalProperty, c
 		}
 		scans = append(scans, scan)
 		totalCost += partialCost
+		if path.IndexMergeIsIntersection {
+			// The handles from all the partial plans are intersected in TiDB.
+			totalCost += scan.statsInfo().RowCount * ds.ctx.GetSessionVars().CPUFactor
+		}
 	}
 	totalRowCount := path.CountAfterAccess
 	if prop.ExpectedCnt < ds.stats.RowCount {
@@ -1036,6 +1064,7 @@ func (ds *DataSource) convertToIndexMergeScan(prop *property.PhysicalProperty, c
 	totalCost += partialCost
 	cop.tablePlan = ts
 	cop.idxMergePartPlans = scans
+	cop.idxMergeIsIntersection = path.IndexMergeIsIntersection
 	cop.cst = totalCost
 	if remainingFilters != nil {
 		cop.rootTaskConds = remainingFilters
@@ -1727,6 +1756,9 @@ func getMostCorrCol4Index(path *util.AccessPath, histColl *statistics.Table, thr
 		colSet.Insert(col.UniqueID)
 		curCorr := float64(0)
 		for _, item := range histColl.ExtendedStats.Stats {
+			if item.Tp != ast.StatsTypeCorrelation {
+				continue
+			}
 			if (col.ID == item.ColIDs[0] && path.FullIdxCols[0].ID == item.ColIDs[1]) ||
 				(col.ID == item.ColIDs[1] && path.FullIdxCols[0].ID == item.ColIDs[0]) {
 				curCorr = item.ScalarVals
@@ -2262,3 +2294,503 @@ func appendCandidate(lp LogicalPlan, task task, prop *property.PhysicalProperty,
 	}
 	opt.appendCandidate(lp, task.plan(), prop)
 }
+
+// fillMVIndexPaths fills the access paths of the multi-valued indexes, which store one entry for each
+// element of a JSON array. Such an index can only be accessed by the point ranges built from a MEMBER OF,
+// JSON_CONTAINS or JSON_OVERLAPS predicate on its indexed expression, so the path is removed when no such
+// predicate exists. A predicate with more than one value needs the handles of all the ranges to be
+// deduplicated, so an IndexMerge union path is returned for it, and it should be appended to the possible
+// access paths after the regular paths are derived.
+func (ds *DataSource) fillMVIndexPaths(conds []expression.Expression) ([]*util.AccessPath, error) {
+	var indexMergePaths []*util.AccessPath
+	paths := make([]*util.AccessPath, 0, len(ds.possibleAccessPaths))
+	for _, path := range ds.possibleAccessPaths {
+		if path.IsTablePath() || !path.Index.MVIndex {
+			paths = append(paths, path)
+			continue
+		}
+		values, elemTp, cond := ds.collectMVIndexValues(path.Index, conds)
+		if len(values) == 0 {
+			continue
+		}
+		// The ranges are built from the values of the constants, so the plan can't be cached.
+		ds.ctx.GetSessionVars().StmtCtx.SkipPlanCache = true
+		if len(values) == 1 {
+			if err := ds.fillMVIndexPath(path, values[0], elemTp, cond); err != nil {
+				return nil, err
+			}
+			path.TableFilters = ds.pushedDownConds
+			paths = append(paths, path)
+			continue
+		}
+		partialPaths := make([]*util.AccessPath, 0, len(values))
+		indexMergePath := &util.AccessPath{TableFilters: ds.pushedDownConds}
+		for _, value := range values {
+			partialPath := &util.AccessPath{Index: path.Index}
+			if err := ds.fillMVIndexPath(partialPath, value, elemTp, cond); err != nil {
+				return nil, err
+			}
+			partialPaths = append(partialPaths, partialPath)
+			indexMergePath.CountAfterAccess += partialPath.CountAfterAccess
+		}
+		indexMergePath.PartialIndexPaths = partialPaths
+		indexMergePath.CountAfterAccess = math.Min(indexMergePath.CountAfterAccess, float64(ds.statisticTable.Count))
+		indexMergePaths = append(indexMergePaths, indexMergePath)
+	}
+	if len(paths) == 0 {
+		// All the available indexes are unusable multi-valued indexes, fall back to the table scan.
+		tablePath := &util.AccessPath{StoreType: kv.TiKV}
+		fillContentForTablePath(tablePath, ds.tableInfo)
+		paths = append(paths, tablePath)
+	}
+	ds.possibleAccessPaths = paths
+	return indexMergePaths, nil
+}
+
+// fillMVIndexPath fills the path of a multi-valued index with the point range of the value.
+func (ds *DataSource) fillMVIndexPath(path *util.AccessPath, value types.Datum, elemTp *types.FieldType, cond expression.Expression) (err error) {
+	// The index stores the array elements, so the scan reads them with the element type rather than
+	// the array type of the hidden column.
+	elemCol := &expression.Column{
+		ID:       ds.tableInfo.Columns[path.Index.Columns[0].Offset].ID,
+		RetType:  elemTp,
+		UniqueID: ds.ctx.GetSessionVars().AllocPlanColumnID(),
+	}
+	path.IdxCols, path.IdxColLens = []*expression.Column{elemCol}, []int{types.UnspecifiedLength}
+	path.FullIdxCols, path.FullIdxColLens = expression.IndexInfo2Cols(ds.Columns, ds.schema.Columns, path.Index)
+	path.FullIdxCols[0] = elemCol
+	path.Ranges = []*ranger.Range{{
+		LowVal:    []types.Datum{value},
+		HighVal:   []types.Datum{value},
+		Collators: []collate.Collator{collate.GetCollator(elemTp.Collate)},
+	}}
+	path.AccessConds = []expression.Expression{cond}
+	path.CountAfterAccess, err = ds.tableStats.HistColl.GetRowCountByIndexRanges(ds.ctx, path.Index.ID, path.Ranges)
+	return err
+}
+
+// collectMVIndexValues finds the predicate which can access the multi-valued index, and returns the values
+// to look up in the index. The values of MEMBER OF and JSON_CONTAINS are preferred because they need only one
+// range. For JSON_CONTAINS with several elements, only the first one is used since the rows containing all of
+// them must contain it, and the predicate itself is still evaluated as a filter.
+func (ds *DataSource) collectMVIndexValues(idx *model.IndexInfo, conds []expression.Expression) (values []types.Datum, elemTp *types.FieldType, cond expression.Expression) {
+	colInfo := ds.tableInfo.Columns[idx.Columns[0].Offset]
+	if !colInfo.FieldType.Array {
+		return nil, nil, nil
+	}
+	var target expression.Expression
+	for _, col := range ds.TblCols {
+		if col.ID == colInfo.ID {
+			if castFunc, ok := col.VirtualExpr.(*expression.ScalarFunction); ok && castFunc.FuncName.L == ast.Cast {
+				target = castFunc.GetArgs()[0]
+			}
+			break
+		}
+	}
+	if target == nil {
+		return nil, nil, nil
+	}
+	elemTp = colInfo.FieldType.Clone()
+	elemTp.Array = false
+
+	for _, expr := range conds {
+		sf, ok := expr.(*expression.ScalarFunction)
+		if !ok {
+			continue
+		}
+		args := sf.GetArgs()
+		var elems []json.BinaryJSON
+		switch sf.FuncName.L {
+		case ast.JSONMemberOf:
+			if !args[1].Equal(ds.ctx, target) {
+				continue
+			}
+			// An array is compared as a whole by MEMBER OF, which can't be found in the index.
+			if elems = ds.jsonElemsOfConstant(args[0]); len(elems) != 1 || elems[0].TypeCode == json.TypeCodeArray {
+				continue
+			}
+		case ast.JSONContains:
+			if len(args) != 2 || !args[0].Equal(ds.ctx, target) {
+				continue
+			}
+			elems = ds.jsonElemsOfConstant(args[1])
+		case ast.JSONOverlaps:
+			if args[0].Equal(ds.ctx, target) {
+				elems = ds.jsonElemsOfConstant(args[1])
+			} else if args[1].Equal(ds.ctx, target) {
+				elems = ds.jsonElemsOfConstant(args[0])
+			} else {
+				continue
+			}
+		default:
+			continue
+		}
+		sc := ds.ctx.GetSessionVars().StmtCtx
+		vals := make([]types.Datum, 0, len(elems))
+		for _, elem := range elems {
+			// The element which can't be converted to the index type is never stored in the index.
+			d, err := types.ConvertJSONToArrayElement(sc, elem, elemTp)
+			if err != nil {
+				continue
+			}
+			vals = append(vals, d)
+		}
+		if len(vals) == 0 {
+			continue
+		}
+		if sf.FuncName.L != ast.JSONOverlaps {
+			return vals[:1], elemTp, expr
+		}
+		if values == nil {
+			values, cond = vals, expr
+		}
+	}
+	return values, elemTp, cond
+}
+
+// jsonElemsOfConstant returns the elements of the JSON constant, a scalar is regarded as an array with a single
+// element. Nil is returned if the expression isn't a constant or it's a JSON object.
+func (ds *DataSource) jsonElemsOfConstant(expr expression.Expression) []json.BinaryJSON {
+	if expr.GetType().EvalType() != types.ETJson || !expr.ConstItem(ds.ctx.GetSessionVars().StmtCtx) {
+		return nil
+	}
+	j, isNull, err := expr.EvalJSON(ds.ctx, chunk.Row{})
+	if isNull || err != nil {
+		return nil
+	}
+	switch j.TypeCode {
+	case json.TypeCodeObject:
+		return nil
+	case json.TypeCodeArray:
+		elems := make([]json.BinaryJSON, 0, j.GetElemCount())
+		for i := 0; i < j.GetElemCount(); i++ {
+			elems = append(elems, j.ArrayGetElem(i))
+		}
+		return elems
+	}
+	return []json.BinaryJSON{j}
+}
+
+// fillFullTextIndexPaths fills the access paths of the FULLTEXT indexes, which store one entry for each token of
+// the indexed texts. Such an index can only be accessed by the tokens of a MATCH ... AGAINST predicate on its
+// columns, so the path is removed when no such predicate exists. The rows matching several tokens are found by
+// an IndexMerge union path, which should be appended to the possible access paths after the regular paths are
+// derived, like the paths of the multi-valued indexes.
+func (ds *DataSource) fillFullTextIndexPaths(conds []expression.Expression) ([]*util.AccessPath, error) {
+	var indexMergePaths []*util.AccessPath
+	paths := make([]*util.AccessPath, 0, len(ds.possibleAccessPaths))
+	for _, path := range ds.possibleAccessPaths {
+		if path.IsTablePath() || !path.Index.FullText {
+			paths = append(paths, path)
+			continue
+		}
+		tokens, cond := ds.collectFullTextTokens(path.Index, conds)
+		if len(tokens) == 0 {
+			continue
+		}
+		// The ranges are built from the search string, so the plan can't be cached.
+		ds.ctx.GetSessionVars().StmtCtx.SkipPlanCache = true
+		if len(tokens) == 1 && !tokens[0].Prefix {
+			if err := ds.fillFullTextIndexPath(path, tokens[0], cond); err != nil {
+				return nil, err
+			}
+			path.TableFilters = ds.pushedDownConds
+			paths = append(paths, path)
+			continue
+		}
+		// A prefix token matches several tokens of a row, so the handles need to be deduplicated as well.
+		partialPaths := make([]*util.AccessPath, 0, len(tokens))
+		indexMergePath := &util.AccessPath{TableFilters: ds.pushedDownConds}
+		for _, token := range tokens {
+			partialPath := &util.AccessPath{Index: path.Index}
+			if err := ds.fillFullTextIndexPath(partialPath, token, cond); err != nil {
+				return nil, err
+			}
+			partialPaths = append(partialPaths, partialPath)
+			indexMergePath.CountAfterAccess += partialPath.CountAfterAccess
+		}
+		indexMergePath.PartialIndexPaths = partialPaths
+		indexMergePath.CountAfterAccess = math.Min(indexMergePath.CountAfterAccess, float64(ds.statisticTable.Count))
+		indexMergePaths = append(indexMergePaths, indexMergePath)
+	}
+	if len(paths) == 0 {
+		// All the available indexes are unusable FULLTEXT indexes, fall back to the table scan.
+		tablePath := &util.AccessPath{StoreType: kv.TiKV}
+		fillContentForTablePath(tablePath, ds.tableInfo)
+		paths = append(paths, tablePath)
+	}
+	ds.possibleAccessPaths = paths
+	return indexMergePaths, nil
+}
+
+// fillFullTextIndexPath fills the path of a FULLTEXT index with the range of the token. A prefix token is
+// looked up by the range of all the tokens starting with it.
+func (ds *DataSource) fillFullTextIndexPath(path *util.AccessPath, token fulltext.Token, cond expression.Expression) (err error) {
+	// The index stores the tokens in its first column and NULL in the others, so the scan reads them with
+	// the binary string type rather than the types of the indexed columns.
+	tokenTp := types.NewFieldType(mysql.TypeVarString)
+	tokenTp.Charset, tokenTp.Collate = charset.CharsetBin, charset.CollationBin
+	tokenTp.Flag |= mysql.BinaryFlag
+	path.FullIdxCols = make([]*expression.Column, 0, len(path.Index.Columns))
+	path.FullIdxColLens = make([]int, 0, len(path.Index.Columns))
+	for i, idxCol := range path.Index.Columns {
+		col := &expression.Column{
+			ID:       ds.tableInfo.Columns[idxCol.Offset].ID,
+			RetType:  &ds.tableInfo.Columns[idxCol.Offset].FieldType,
+			UniqueID: ds.ctx.GetSessionVars().AllocPlanColumnID(),
+		}
+		if i == 0 {
+			col.RetType = tokenTp
+		}
+		path.FullIdxCols = append(path.FullIdxCols, col)
+		path.FullIdxColLens = append(path.FullIdxColLens, types.UnspecifiedLength)
+	}
+	path.IdxCols, path.IdxColLens = path.FullIdxCols[:1], path.FullIdxColLens[:1]
+	ran := &ranger.Range{
+		LowVal:    []types.Datum{tablecodec.FullTextTokenDatum(token.Text)},
+		HighVal:   []types.Datum{tablecodec.FullTextTokenDatum(token.Text)},
+		Collators: []collate.Collator{collate.GetCollator(charset.CollationBin)},
+	}
+	if token.Prefix {
+		ran.HighVal[0] = tablecodec.FullTextTokenDatum(string(kv.Key(token.Text).PrefixNext()))
+		ran.HighExclude = true
+	}
+	path.Ranges = []*ranger.Range{ran}
+	path.AccessConds = []expression.Expression{cond}
+	path.CountAfterAccess, err = ds.tableStats.HistColl.GetRowCountByIndexRanges(ds.ctx, path.Index.ID, path.Ranges)
+	return err
+}
+
+// collectFullTextTokens finds the MATCH ... AGAINST predicate on the columns of the FULLTEXT index, and returns
+// the tokens to look up in the index. The predicate itself is still evaluated as a filter, so the tokens only
+// need to cover all the matched rows.
+func (ds *DataSource) collectFullTextTokens(idx *model.IndexInfo, conds []expression.Expression) ([]fulltext.Token, expression.Expression) {
+	tk, err := fulltext.NewTokenizer(idx.FullTextParser)
+	if err != nil {
+		return nil, nil
+	}
+	idxColIDs := make(map[int64]struct{}, len(idx.Columns))
+	for _, idxCol := range idx.Columns {
+		idxColIDs[ds.tableInfo.Columns[idxCol.Offset].ID] = struct{}{}
+	}
+	sc := ds.ctx.GetSessionVars().StmtCtx
+	for _, expr := range conds {
+		sf, ok := expr.(*expression.ScalarFunction)
+		if !ok || sf.FuncName.L != ast.FullTextMatch {
+			continue
+		}
+		args := sf.GetArgs()
+		colCnt := len(args) - 3
+		if colCnt != len(idx.Columns) {
+			continue
+		}
+		matched := true
+		for _, arg := range args[:colCnt] {
+			col, ok := arg.(*expression.Column)
+			if !ok {
+				matched = false
+				break
+			}
+			if _, ok := idxColIDs[col.ID]; !ok {
+				matched = false
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+		var strs [3]string
+		for i, arg := range args[colCnt:] {
+			if !arg.ConstItem(sc) {
+				matched = false
+				break
+			}
+			s, isNull, err := arg.EvalString(ds.ctx, chunk.Row{})
+			if isNull || err != nil {
+				matched = false
+				break
+			}
+			strs[i] = s
+		}
+		against, mode, parser := strs[0], strs[1], strs[2]
+		if !matched || parser != tk.Name() {
+			continue
+		}
+		if tokens, ok := fulltext.ParseQuery(tk, against, mode == fulltext.ModeBoolean).IndexTokens(); ok {
+			return tokens, expr
+		}
+	}
+	return nil, nil
+}
+
+// fillSpatialIndexPaths fills the access paths of the SPATIAL indexes, which store the IDs of the cells covering
+// the MBR of each geometry. Such an index can only be accessed by a spatial predicate between its column and a
+// constant geometry, so the path is removed when no such predicate exists. A geometry may be covered by several
+// cells in the ranges, so the handles are deduplicated by an IndexMerge union path, which should be appended to
+// the possible access paths after the regular paths are derived, like the paths of the FULLTEXT indexes.
+func (ds *DataSource) fillSpatialIndexPaths(conds []expression.Expression) ([]*util.AccessPath, error) {
+	var indexMergePaths []*util.AccessPath
+	paths := make([]*util.AccessPath, 0, len(ds.possibleAccessPaths))
+	for _, path := range ds.possibleAccessPaths {
+		if path.IsTablePath() || !path.Index.Spatial {
+			paths = append(paths, path)
+			continue
+		}
+		ranges, cond := ds.buildSpatialRanges(path.Index, conds)
+		if len(ranges) == 0 {
+			continue
+		}
+		// The ranges are built from the constant geometry, so the plan can't be cached.
+		ds.ctx.GetSessionVars().StmtCtx.SkipPlanCache = true
+		partialPath := &util.AccessPath{Index: path.Index}
+		// The index stores the cell IDs rather than the geometries, so the scan reads them as unsigned integers.
+		cellTp := types.NewFieldType(mysql.TypeLonglong)
+		cellTp.Flag |= mysql.UnsignedFlag | mysql.NotNullFlag
+		partialPath.FullIdxCols = []*expression.Column{{
+			ID:       ds.tableInfo.Columns[path.Index.Columns[0].Offset].ID,
+			RetType:  cellTp,
+			UniqueID: ds.ctx.GetSessionVars().AllocPlanColumnID(),
+		}}
+		partialPath.FullIdxColLens = []int{types.UnspecifiedLength}
+		partialPath.IdxCols, partialPath.IdxColLens = partialPath.FullIdxCols, partialPath.FullIdxColLens
+		partialPath.Ranges = ranges
+		partialPath.AccessConds = []expression.Expression{cond}
+		var err error
+		partialPath.CountAfterAccess, err = ds.tableStats.HistColl.GetRowCountByIndexRanges(ds.ctx, path.Index.ID, ranges)
+		if err != nil {
+			return nil, err
+		}
+		indexMergePaths = append(indexMergePaths, &util.AccessPath{
+			PartialIndexPaths: []*util.AccessPath{partialPath},
+			TableFilters:      ds.pushedDownConds,
+			CountAfterAccess:  math.Min(partialPath.CountAfterAccess, float64(ds.statisticTable.Count)),
+		})
+	}
+	if len(paths) == 0 {
+		// All the available indexes are unusable SPATIAL indexes, fall back to the table scan.
+		tablePath := &util.AccessPath{StoreType: kv.TiKV}
+		fillContentForTablePath(tablePath, ds.tableInfo)
+		paths = append(paths, tablePath)
+	}
+	ds.possibleAccessPaths = paths
+	return indexMergePaths, nil
+}
+
+// buildSpatialRanges finds the spatial predicate which can be answered by the SPATIAL index, and returns the
+// ranges of the cells to look up. The predicate is either a relation implying that the geometries intersect,
+// such as ST_Contains(col, g), or a distance limit, such as ST_Distance_Sphere(col, g) < d. The predicate
+// itself is still evaluated as a filter, so the ranges only need to cover all the matched rows.
+func (ds *DataSource) buildSpatialRanges(idx *model.IndexInfo, conds []expression.Expression) ([]*ranger.Range, expression.Expression) {
+	col := ds.tableInfo.Columns[idx.Columns[0].Offset]
+	// The index can't be used if the column may store the geometries of different SRIDs.
+	if col.Srid == nil {
+		return nil, nil
+	}
+	for _, expr := range conds {
+		r, ok := ds.spatialQueryRect(col, expr)
+		if !ok || r.Empty {
+			continue
+		}
+		cellRanges := spatial.QueryRanges(r, *col.Srid)
+		ranges := make([]*ranger.Range, 0, len(cellRanges))
+		for _, cr := range cellRanges {
+			ranges = append(ranges, &ranger.Range{
+				LowVal:    []types.Datum{types.NewUintDatum(cr.Low)},
+				HighVal:   []types.Datum{types.NewUintDatum(cr.High)},
+				Collators: []collate.Collator{collate.GetBinaryCollator()},
+			})
+		}
+		return ranges, expr
+	}
+	return nil, nil
+}
+
+// spatialQueryRect returns the rectangle which contains the MBRs of all the geometries of the column satisfying
+// the predicate, or false if the predicate can't be answered by the SPATIAL index on the column.
+func (ds *DataSource) spatialQueryRect(col *model.ColumnInfo, expr expression.Expression) (spatial.Rect, bool) {
+	sf, ok := expr.(*expression.ScalarFunction)
+	if !ok {
+		return spatial.Rect{}, false
+	}
+	args := sf.GetArgs()
+	switch sf.FuncName.L {
+	case ast.STContains, ast.STWithin, ast.STIntersects, ast.STEquals,
+		ast.MBRContains, ast.MBRWithin, ast.MBRIntersects:
+		// All the relations imply that the geometries intersect.
+		if g := ds.spatialConstOfColumn(col, args[0], args[1]); g != nil {
+			return g.MBR(), true
+		}
+		if g := ds.spatialConstOfColumn(col, args[1], args[0]); g != nil {
+			return g.MBR(), true
+		}
+	case ast.LT, ast.LE:
+		return ds.spatialDistanceRect(col, args[0], args[1])
+	case ast.GT, ast.GE:
+		return ds.spatialDistanceRect(col, args[1], args[0])
+	}
+	return spatial.Rect{}, false
+}
+
+// spatialDistanceRect returns the rectangle of the predicate dist <= limit, where dist is ST_Distance or
+// ST_Distance_Sphere between the column and a constant geometry.
+func (ds *DataSource) spatialDistanceRect(col *model.ColumnInfo, dist, limit expression.Expression) (spatial.Rect, bool) {
+	sf, ok := dist.(*expression.ScalarFunction)
+	if !ok || (sf.FuncName.L != ast.STDistance && sf.FuncName.L != ast.STDistanceSphere) {
+		return spatial.Rect{}, false
+	}
+	d, ok := ds.evalConstReal(limit)
+	if !ok || d < 0 {
+		return spatial.Rect{}, false
+	}
+	args := sf.GetArgs()
+	g := ds.spatialConstOfColumn(col, args[0], args[1])
+	if g == nil {
+		g = ds.spatialConstOfColumn(col, args[1], args[0])
+	}
+	if g == nil {
+		return spatial.Rect{}, false
+	}
+	if sf.FuncName.L == ast.STDistance {
+		if spatial.IsGeographic(g.SRID) {
+			return g.MBR().ExpandOnSphere(d, spatial.EarthRadius), true
+		}
+		return g.MBR().Expand(d), true
+	}
+	radius := spatial.EarthRadius
+	if len(args) > 2 {
+		if radius, ok = ds.evalConstReal(args[2]); !ok || radius <= 0 {
+			return spatial.Rect{}, false
+		}
+	}
+	// ST_Distance_Sphere treats the coordinates as longitude and latitude regardless of the SRID.
+	return g.MBR().ExpandOnSphere(d, radius), true
+}
+
+// spatialConstOfColumn returns the constant geometry if the expressions are the column and a constant geometry
+// of the same SRID as the column.
+func (ds *DataSource) spatialConstOfColumn(col *model.ColumnInfo, colExpr, constExpr expression.Expression) *spatial.Geometry {
+	c, ok := colExpr.(*expression.Column)
+	if !ok || c.ID != col.ID || !constExpr.ConstItem(ds.ctx.GetSessionVars().StmtCtx) {
+		return nil
+	}
+	data, isNull, err := constExpr.EvalString(ds.ctx, chunk.Row{})
+	if isNull || err != nil {
+		return nil
+	}
+	g, err := spatial.Unmarshal([]byte(data))
+	if err != nil || g.SRID != *col.Srid {
+		return nil
+	}
+	return g
+}
+
+func (ds *DataSource) evalConstReal(expr expression.Expression) (float64, bool) {
+	if !expr.ConstItem(ds.ctx.GetSessionVars().StmtCtx) {
+		return 0, false
+	}
+	f, isNull, err := expr.EvalReal(ds.ctx, chunk.Row{})
+	if isNull || err != nil {
+		return 0, false
+	}
+	return f, true
+}
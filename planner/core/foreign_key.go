@@ -0,0 +1,441 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/types"
+)
+
+// FKCheck indicates a foreign key constraint check of the modified rows.
+// When the child table is inserted or updated, the referred rows must exist in the parent table (CheckExist is true).
+// When the parent table is deleted or updated, the referring rows must not exist in the child table (CheckExist is false).
+type FKCheck struct {
+	baseSchemaProducer
+
+	FK         *model.FKInfo
+	ReferredFK *model.ReferredFKInfo
+	// Tbl is the table to be looked up, it's nil when the parent table doesn't exist.
+	Tbl table.Table
+	// Idx is the index of Tbl used to look up the rows. When both Idx is nil and HandleLookup
+	// is false, the rows are looked up by scanning the whole table.
+	Idx          *model.IndexInfo
+	HandleLookup bool
+	// Cols are the columns of the modified table whose values will be looked up.
+	Cols []model.CIStr
+	// LookupCols are the columns of Tbl corresponding to Cols.
+	LookupCols []*model.ColumnInfo
+
+	CheckExist bool
+
+	// ChildDB and ChildTable are the names of the table which owns the foreign key.
+	ChildDB    model.CIStr
+	ChildTable model.CIStr
+}
+
+// FKCascadeType indicates in which event the foreign key cascade is triggered.
+type FKCascadeType int8
+
+const (
+	// FKCascadeOnDelete indicates the cascade is triggered by deleting rows from the parent table.
+	FKCascadeOnDelete FKCascadeType = iota
+	// FKCascadeOnUpdate indicates the cascade is triggered by updating rows of the parent table.
+	FKCascadeOnUpdate
+)
+
+// String implements fmt.Stringer interface.
+func (tp FKCascadeType) String() string {
+	if tp == FKCascadeOnDelete {
+		return "on_delete"
+	}
+	return "on_update"
+}
+
+// FKCascade indicates a foreign key cascade action (CASCADE or SET NULL) on the child table,
+// which is triggered by the modification of the parent table.
+type FKCascade struct {
+	baseSchemaProducer
+
+	Tp         FKCascadeType
+	ReferredFK *model.ReferredFKInfo
+	ChildTable table.Table
+	FK         *model.FKInfo
+}
+
+// AccessObject implements dataAccesser interface.
+func (p *FKCheck) AccessObject(_ bool) string {
+	if p.Tbl == nil {
+		return ""
+	}
+	var buffer strings.Builder
+	buffer.WriteString("table:")
+	buffer.WriteString(p.Tbl.Meta().Name.O)
+	if p.Idx != nil {
+		buffer.WriteString(", index:")
+		buffer.WriteString(p.Idx.Name.O)
+	} else if p.HandleLookup {
+		buffer.WriteString(", handle")
+	}
+	return buffer.String()
+}
+
+// OperatorInfo implements dataAccesser interface.
+func (p *FKCheck) OperatorInfo(_ bool) string {
+	var buffer strings.Builder
+	buffer.WriteString("foreign_key:")
+	buffer.WriteString(p.FK.Name.O)
+	if p.CheckExist {
+		buffer.WriteString(", check_exist")
+	} else {
+		buffer.WriteString(", check_not_exist")
+	}
+	return buffer.String()
+}
+
+// ExplainInfo implements Plan interface.
+func (p *FKCheck) ExplainInfo() string {
+	return p.AccessObject(false) + ", " + p.OperatorInfo(false)
+}
+
+// AccessObject implements dataAccesser interface.
+func (p *FKCascade) AccessObject(_ bool) string {
+	return "table:" + p.ChildTable.Meta().Name.O
+}
+
+// OperatorInfo implements dataAccesser interface.
+func (p *FKCascade) OperatorInfo(_ bool) string {
+	var opt ast.ReferOptionType
+	if p.Tp == FKCascadeOnDelete {
+		opt = ast.ReferOptionType(p.FK.OnDelete)
+	} else {
+		opt = ast.ReferOptionType(p.FK.OnUpdate)
+	}
+	return "foreign_key:" + p.FK.Name.O + ", " + p.Tp.String() + ":" + opt.String()
+}
+
+// ExplainInfo implements Plan interface.
+func (p *FKCascade) ExplainInfo() string {
+	return p.AccessObject(false) + ", " + p.OperatorInfo(false)
+}
+
+func (p *Insert) buildOnInsertFKTriggers(ctx sessionctx.Context, is infoschema.InfoSchema, dbName string) {
+	tblInfo := p.Table.Meta()
+	referredFKs := is.GetTableReferredForeignKeys(dbName, tblInfo.Name.L)
+	if !needFKTriggers(ctx, len(tblInfo.ForeignKeys) > 0 || len(referredFKs) > 0) {
+		return
+	}
+	for _, fk := range tblInfo.ForeignKeys {
+		if fk.State != model.StatePublic {
+			continue
+		}
+		p.FKChecks = append(p.FKChecks, buildFKCheckOnModifyChildTable(ctx, is, dbName, tblInfo.Name, fk))
+	}
+	if !p.IsReplace && len(p.OnDuplicate) == 0 {
+		return
+	}
+	tp := FKCascadeOnDelete
+	var updateCols map[string]struct{}
+	if !p.IsReplace {
+		tp = FKCascadeOnUpdate
+		updateCols = make(map[string]struct{}, len(p.OnDuplicate))
+		for _, assign := range p.OnDuplicate {
+			updateCols[assign.ColName.L] = struct{}{}
+		}
+	}
+	for _, referredFK := range referredFKs {
+		if tp == FKCascadeOnUpdate && !isAnyColUpdated(updateCols, referredFK.Cols) {
+			continue
+		}
+		check, cascade := buildFKTriggerOnModifyParentTable(ctx, is, referredFK, tp)
+		if check != nil {
+			p.FKChecks = append(p.FKChecks, check)
+		}
+		if cascade != nil {
+			p.FKCascades = append(p.FKCascades, cascade)
+		}
+	}
+}
+
+func (updt *Update) buildOnUpdateFKTriggers(ctx sessionctx.Context, is infoschema.InfoSchema, tblID2table map[int64]table.Table) {
+	updateCols := make(map[int64]map[string]struct{}, len(tblID2table))
+	for _, assign := range updt.OrderedList {
+		for _, content := range updt.TblColPosInfos {
+			if assign.Col.Index < content.Start || assign.Col.Index >= content.End {
+				continue
+			}
+			tbl := tblID2table[content.TblID]
+			if tbl == nil {
+				continue
+			}
+			cols, ok := updateCols[content.TblID]
+			if !ok {
+				cols = make(map[string]struct{})
+				updateCols[content.TblID] = cols
+			}
+			cols[tbl.WritableCols()[assign.Col.Index-content.Start].Name.L] = struct{}{}
+		}
+	}
+	for tid, tbl := range tblID2table {
+		if tbl == nil {
+			continue
+		}
+		tblInfo := tbl.Meta()
+		dbInfo, ok := is.SchemaByTable(tblInfo)
+		if !ok {
+			continue
+		}
+		referredFKs := is.GetTableReferredForeignKeys(dbInfo.Name.L, tblInfo.Name.L)
+		if !needFKTriggers(ctx, len(tblInfo.ForeignKeys) > 0 || len(referredFKs) > 0) {
+			continue
+		}
+		cols := updateCols[tid]
+		for _, fk := range tblInfo.ForeignKeys {
+			if fk.State != model.StatePublic || !isAnyColUpdated(cols, fk.Cols) {
+				continue
+			}
+			if updt.FKChecks == nil {
+				updt.FKChecks = make(map[int64][]*FKCheck)
+			}
+			updt.FKChecks[tid] = append(updt.FKChecks[tid], buildFKCheckOnModifyChildTable(ctx, is, dbInfo.Name.O, tblInfo.Name, fk))
+		}
+		for _, referredFK := range referredFKs {
+			if !isAnyColUpdated(cols, referredFK.Cols) {
+				continue
+			}
+			check, cascade := buildFKTriggerOnModifyParentTable(ctx, is, referredFK, FKCascadeOnUpdate)
+			if check != nil {
+				if updt.FKChecks == nil {
+					updt.FKChecks = make(map[int64][]*FKCheck)
+				}
+				updt.FKChecks[tid] = append(updt.FKChecks[tid], check)
+			}
+			if cascade != nil {
+				if updt.FKCascades == nil {
+					updt.FKCascades = make(map[int64][]*FKCascade)
+				}
+				updt.FKCascades[tid] = append(updt.FKCascades[tid], cascade)
+			}
+		}
+	}
+}
+
+func (del *Delete) buildOnDeleteFKTriggers(ctx sessionctx.Context, is infoschema.InfoSchema, tblID2table map[int64]table.Table) {
+	for tid, tbl := range tblID2table {
+		if tbl == nil {
+			continue
+		}
+		tblInfo := tbl.Meta()
+		dbInfo, ok := is.SchemaByTable(tblInfo)
+		if !ok {
+			continue
+		}
+		referredFKs := is.GetTableReferredForeignKeys(dbInfo.Name.L, tblInfo.Name.L)
+		// The foreign keys of the child table never block deleting the child rows.
+		if !needFKTriggers(ctx, len(referredFKs) > 0) {
+			continue
+		}
+		for _, referredFK := range referredFKs {
+			check, cascade := buildFKTriggerOnModifyParentTable(ctx, is, referredFK, FKCascadeOnDelete)
+			if check != nil {
+				if del.FKChecks == nil {
+					del.FKChecks = make(map[int64][]*FKCheck)
+				}
+				del.FKChecks[tid] = append(del.FKChecks[tid], check)
+			}
+			if cascade != nil {
+				if del.FKCascades == nil {
+					del.FKCascades = make(map[int64][]*FKCascade)
+				}
+				del.FKCascades[tid] = append(del.FKCascades[tid], cascade)
+			}
+		}
+	}
+}
+
+// needFKTriggers returns whether the foreign key checks and cascades should be built for
+// modifying the table which has or is referred by foreign keys.
+func needFKTriggers(ctx sessionctx.Context, hasFKs bool) bool {
+	if !hasFKs {
+		return false
+	}
+	// The foreign key triggers depend on the value of foreign_key_checks and the schema
+	// of other tables, so the plan can't be cached.
+	ctx.GetSessionVars().StmtCtx.SkipPlanCache = true
+	return ctx.GetSessionVars().ForeignKeyChecks
+}
+
+func isAnyColUpdated(updateCols map[string]struct{}, cols []model.CIStr) bool {
+	for _, col := range cols {
+		if _, ok := updateCols[col.L]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// buildFKCheckOnModifyChildTable builds the check that the rows of the child table `dbName`.`tblName`
+// refer to existing rows in the parent table.
+func buildFKCheckOnModifyChildTable(ctx sessionctx.Context, is infoschema.InfoSchema, dbName string, tblName model.CIStr, fk *model.FKInfo) *FKCheck {
+	check := FKCheck{
+		FK:         fk,
+		Cols:       fk.Cols,
+		CheckExist: true,
+		ChildDB:    model.NewCIStr(dbName),
+		ChildTable: tblName,
+	}.Init(ctx)
+	refSchema := fk.RefSchema
+	if refSchema.L == "" {
+		refSchema = check.ChildDB
+	}
+	parent, err := is.TableByName(refSchema, fk.RefTable)
+	if err != nil {
+		// Nothing can be referred in a nonexistent table.
+		return check
+	}
+	lookupCols, ok := findLookupCols(parent.Meta(), fk.RefCols)
+	if !ok {
+		return check
+	}
+	check.Tbl, check.LookupCols = parent, lookupCols
+	check.Idx, check.HandleLookup = findFKLookupPath(parent.Meta(), fk.RefCols)
+	return check
+}
+
+// buildFKTriggerOnModifyParentTable builds the check or the cascade of the child table, which is
+// triggered by deleting or updating the rows of the parent table.
+func buildFKTriggerOnModifyParentTable(ctx sessionctx.Context, is infoschema.InfoSchema, referredFK *model.ReferredFKInfo, tp FKCascadeType) (*FKCheck, *FKCascade) {
+	child, err := is.TableByName(referredFK.ChildSchema, referredFK.ChildTable)
+	if err != nil {
+		return nil, nil
+	}
+	var fk *model.FKInfo
+	for _, childFK := range child.Meta().ForeignKeys {
+		if childFK.Name.L == referredFK.ChildFKName.L && childFK.State == model.StatePublic {
+			fk = childFK
+			break
+		}
+	}
+	if fk == nil {
+		return nil, nil
+	}
+	opt := ast.ReferOptionType(fk.OnDelete)
+	if tp == FKCascadeOnUpdate {
+		opt = ast.ReferOptionType(fk.OnUpdate)
+	}
+	switch opt {
+	case ast.ReferOptionCascade, ast.ReferOptionSetNull:
+		cascade := FKCascade{
+			Tp:         tp,
+			ReferredFK: referredFK,
+			ChildTable: child,
+			FK:         fk,
+		}.Init(ctx)
+		return nil, cascade
+	}
+	// RESTRICT, NO ACTION and SET DEFAULT are all treated as RESTRICT.
+	lookupCols, ok := findLookupCols(child.Meta(), fk.Cols)
+	if !ok {
+		return nil, nil
+	}
+	check := FKCheck{
+		FK:         fk,
+		ReferredFK: referredFK,
+		Tbl:        child,
+		Cols:       referredFK.Cols,
+		LookupCols: lookupCols,
+		CheckExist: false,
+		ChildDB:    referredFK.ChildSchema,
+		ChildTable: referredFK.ChildTable,
+	}.Init(ctx)
+	check.Idx, check.HandleLookup = findFKLookupPath(child.Meta(), fk.Cols)
+	return check, nil
+}
+
+func findLookupCols(tblInfo *model.TableInfo, names []model.CIStr) ([]*model.ColumnInfo, bool) {
+	cols := make([]*model.ColumnInfo, 0, len(names))
+	for _, name := range names {
+		col := model.FindColumnInfo(tblInfo.Columns, name.L)
+		if col == nil || col.State != model.StatePublic {
+			return nil, false
+		}
+		cols = append(cols, col)
+	}
+	return cols, true
+}
+
+// findFKLookupPath finds the way to look up the rows by the values of cols. The rows can be
+// looked up by the handle when the cols are the int handle or the prefix of the clustered
+// primary key, or by the index whose prefix columns are cols.
+func findFKLookupPath(tblInfo *model.TableInfo, cols []model.CIStr) (*model.IndexInfo, bool) {
+	if tblInfo.PKIsHandle && len(cols) == 1 {
+		if pk := tblInfo.GetPkColInfo(); pk != nil && pk.Name.L == cols[0].L {
+			return nil, true
+		}
+	}
+	for _, idx := range tblInfo.Indices {
+		if idx.State != model.StatePublic || !isIndexPrefixCols(idx, cols) {
+			continue
+		}
+		if idx.Primary && tblInfo.IsCommonHandle {
+			return nil, true
+		}
+		return idx, false
+	}
+	return nil, false
+}
+
+func isIndexPrefixCols(idx *model.IndexInfo, cols []model.CIStr) bool {
+	if len(idx.Columns) < len(cols) {
+		return false
+	}
+	for i, col := range cols {
+		idxCol := idx.Columns[i]
+		if idxCol.Name.L != col.L || idxCol.Length != types.UnspecifiedLength {
+			return false
+		}
+	}
+	return true
+}
+
+// flattenFKPlans returns the foreign key checks and cascades in the order of the table ID.
+func flattenFKPlans(fkChecks map[int64][]*FKCheck, fkCascades map[int64][]*FKCascade) []Plan {
+	tids := make([]int64, 0, len(fkChecks)+len(fkCascades))
+	for tid := range fkChecks {
+		tids = append(tids, tid)
+	}
+	for tid := range fkCascades {
+		if _, ok := fkChecks[tid]; !ok {
+			tids = append(tids, tid)
+		}
+	}
+	sort.Slice(tids, func(i, j int) bool { return tids[i] < tids[j] })
+	plans := make([]Plan, 0, len(tids))
+	for _, tid := range tids {
+		for _, check := range fkChecks[tid] {
+			plans = append(plans, check)
+		}
+		for _, cascade := range fkCascades[tid] {
+			plans = append(plans, cascade)
+		}
+	}
+	return plans
+}
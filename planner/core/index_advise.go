@@ -0,0 +1,244 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/planner/util"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/util/hint"
+)
+
+// IndexableColumns are the columns of a table which may be accessed by an index in a statement.
+type IndexableColumns struct {
+	DBName    model.CIStr
+	TableInfo *model.TableInfo
+	// EqualCols are compared by the equal conditions, such as `a = 1`, `a IN (1, 2)`, `a IS NULL`, or the join
+	// keys like `t1.a = t2.a`.
+	EqualCols []*model.ColumnInfo
+	// RangeCols are compared by the range conditions, such as `a > 1` or `a LIKE 'x%'`.
+	RangeCols []*model.ColumnInfo
+	// OrderCols are the leading columns of ORDER BY or GROUP BY, which are all in the table.
+	OrderCols []*model.ColumnInfo
+}
+
+func (ic *IndexableColumns) addEqualCol(col *model.ColumnInfo) {
+	ic.EqualCols = appendColumnIfAbsent(ic.EqualCols, col)
+}
+
+func (ic *IndexableColumns) addRangeCol(col *model.ColumnInfo) {
+	ic.RangeCols = appendColumnIfAbsent(ic.RangeCols, col)
+}
+
+func appendColumnIfAbsent(cols []*model.ColumnInfo, col *model.ColumnInfo) []*model.ColumnInfo {
+	for _, c := range cols {
+		if c.ID == col.ID {
+			return cols
+		}
+	}
+	return append(cols, col)
+}
+
+// indexableColumnsExtractor collects the indexable columns from a logical plan.
+type indexableColumnsExtractor struct {
+	// sources maps the unique IDs of the columns produced by the data sources to their tables and columns.
+	sources map[int64]indexableSourceColumn
+	results []*IndexableColumns
+}
+
+type indexableSourceColumn struct {
+	result *IndexableColumns
+	col    *model.ColumnInfo
+}
+
+// ExtractIndexableColumns builds the logical plan of the statement, and returns the indexable columns of the
+// tables it accesses. Only the plain columns of the tables are extracted, the statement should have been
+// preprocessed with the infoschema.
+func ExtractIndexableColumns(ctx context.Context, sctx sessionctx.Context, node ast.Node, is infoschema.InfoSchema) ([]*IndexableColumns, error) {
+	sctx.GetSessionVars().PlanID = 0
+	sctx.GetSessionVars().PlanColumnID = 0
+	hintProcessor := &hint.BlockHintProcessor{Ctx: sctx}
+	node.Accept(hintProcessor)
+	builder, _ := NewPlanBuilder().Init(sctx, is, hintProcessor)
+	p, err := builder.Build(ctx, node)
+	if err != nil {
+		return nil, err
+	}
+	logic, ok := p.(LogicalPlan)
+	if !ok {
+		return nil, nil
+	}
+	e := &indexableColumnsExtractor{sources: make(map[int64]indexableSourceColumn)}
+	e.collectSources(logic)
+	e.extract(logic)
+	return e.results, nil
+}
+
+func (e *indexableColumnsExtractor) collectSources(p LogicalPlan) {
+	if ds, ok := p.(*DataSource); ok && ds.table.Type().IsNormalTable() {
+		var result *IndexableColumns
+		// A table may be accessed several times, such as a self join, the columns are merged.
+		for _, r := range e.results {
+			if r.TableInfo.ID == ds.tableInfo.ID {
+				result = r
+			}
+		}
+		if result == nil {
+			result = &IndexableColumns{DBName: ds.DBName, TableInfo: ds.tableInfo}
+			e.results = append(e.results, result)
+		}
+		for i, col := range ds.schema.Columns {
+			if ds.Columns[i].ID != model.ExtraHandleID {
+				e.sources[col.UniqueID] = indexableSourceColumn{result: result, col: ds.Columns[i]}
+			}
+		}
+	}
+	for _, child := range p.Children() {
+		e.collectSources(child)
+	}
+}
+
+func (e *indexableColumnsExtractor) extract(p LogicalPlan) {
+	switch x := p.(type) {
+	case *LogicalSelection:
+		e.extractConds(x.Conditions)
+	case *LogicalJoin:
+		e.extractJoin(x)
+	case *LogicalApply:
+		e.extractJoin(&x.LogicalJoin)
+	case *LogicalSort:
+		e.extractOrder(byItemsToExprs(x.ByItems))
+	case *LogicalTopN:
+		e.extractOrder(byItemsToExprs(x.ByItems))
+	case *LogicalAggregation:
+		e.extractOrder(x.GroupByItems)
+	}
+	for _, child := range p.Children() {
+		e.extract(child)
+	}
+}
+
+func (e *indexableColumnsExtractor) extractJoin(join *LogicalJoin) {
+	for _, cond := range join.EqualConditions {
+		e.extractConds([]expression.Expression{cond})
+	}
+	e.extractConds(join.LeftConditions)
+	e.extractConds(join.RightConditions)
+	e.extractConds(join.OtherConditions)
+}
+
+func (e *indexableColumnsExtractor) extractConds(conds []expression.Expression) {
+	for _, cond := range conds {
+		e.extractCNFItems(expression.SplitCNFItems(cond))
+	}
+}
+
+func (e *indexableColumnsExtractor) extractCNFItems(items []expression.Expression) {
+	for _, item := range items {
+		sf, ok := item.(*expression.ScalarFunction)
+		if !ok {
+			continue
+		}
+		args := sf.GetArgs()
+		switch sf.FuncName.L {
+		case ast.EQ, ast.NullEQ:
+			lCol, lOk := e.sourceColumn(args[0])
+			rCol, rOk := e.sourceColumn(args[1])
+			switch {
+			case lOk && rOk:
+				// The join keys of different tables can be accessed by index join.
+				if lCol.result != rCol.result {
+					lCol.result.addEqualCol(lCol.col)
+					rCol.result.addEqualCol(rCol.col)
+				}
+			case lOk && isColumnFree(args[1]):
+				lCol.result.addEqualCol(lCol.col)
+			case rOk && isColumnFree(args[0]):
+				rCol.result.addEqualCol(rCol.col)
+			}
+		case ast.In:
+			if col, ok := e.sourceColumn(args[0]); ok && isColumnFree(args[1:]...) {
+				col.result.addEqualCol(col.col)
+			}
+		case ast.IsNull:
+			if col, ok := e.sourceColumn(args[0]); ok {
+				col.result.addEqualCol(col.col)
+			}
+		case ast.LT, ast.LE, ast.GT, ast.GE:
+			if col, ok := e.sourceColumn(args[0]); ok && isColumnFree(args[1]) {
+				col.result.addRangeCol(col.col)
+			} else if col, ok := e.sourceColumn(args[1]); ok && isColumnFree(args[0]) {
+				col.result.addRangeCol(col.col)
+			}
+		case ast.Like:
+			if col, ok := e.sourceColumn(args[0]); ok && isColumnFree(args[1]) {
+				col.result.addRangeCol(col.col)
+			}
+		}
+	}
+}
+
+// extractOrder extracts the leading columns of the ORDER BY or GROUP BY items, which can be provided in order
+// by an index when they are all from the same table.
+func (e *indexableColumnsExtractor) extractOrder(items []expression.Expression) {
+	var (
+		result *IndexableColumns
+		cols   []*model.ColumnInfo
+	)
+	for _, item := range items {
+		col, ok := e.sourceColumn(item)
+		if !ok || (result != nil && col.result != result) {
+			break
+		}
+		result = col.result
+		cols = appendColumnIfAbsent(cols, col.col)
+	}
+	if result != nil && len(result.OrderCols) == 0 {
+		result.OrderCols = cols
+	}
+}
+
+func (e *indexableColumnsExtractor) sourceColumn(expr expression.Expression) (indexableSourceColumn, bool) {
+	col, ok := expr.(*expression.Column)
+	if !ok {
+		return indexableSourceColumn{}, false
+	}
+	source, ok := e.sources[col.UniqueID]
+	return source, ok
+}
+
+// isColumnFree returns whether the expressions are free of the columns, so they are constants during an index
+// lookup, the correlated columns are allowed.
+func isColumnFree(exprs ...expression.Expression) bool {
+	for _, expr := range exprs {
+		if len(expression.ExtractColumns(expr)) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func byItemsToExprs(items []*util.ByItems) []expression.Expression {
+	exprs := make([]expression.Expression, 0, len(items))
+	for _, item := range items {
+		exprs = append(exprs, item.Expr)
+	}
+	return exprs
+}
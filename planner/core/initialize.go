@@ -244,6 +244,18 @@ func (p Insert) Init(ctx sessionctx.Context) *Insert {
 	return &p
 }
 
+// Init initializes FKCheck.
+func (p FKCheck) Init(ctx sessionctx.Context) *FKCheck {
+	p.basePlan = newBasePlan(ctx, plancodec.TypeForeignKeyCheck, 0)
+	return &p
+}
+
+// Init initializes FKCascade.
+func (p FKCascade) Init(ctx sessionctx.Context) *FKCascade {
+	p.basePlan = newBasePlan(ctx, plancodec.TypeForeignKeyCascade, 0)
+	return &p
+}
+
 // Init initializes LoadData.
 func (p LoadData) Init(ctx sessionctx.Context) *LoadData {
 	p.basePlan = newBasePlan(ctx, plancodec.TypeLoadData, 0)
@@ -304,6 +316,19 @@ func (p PhysicalIndexScan) Init(ctx sessionctx.Context, offset int) *PhysicalInd
 	return &p
 }
 
+// Init initializes LogicalJSONTable.
+func (p LogicalJSONTable) Init(ctx sessionctx.Context, offset int) *LogicalJSONTable {
+	p.baseLogicalPlan = newBaseLogicalPlan(ctx, plancodec.TypeJSONTable, &p, offset)
+	return &p
+}
+
+// Init initializes PhysicalJSONTable.
+func (p PhysicalJSONTable) Init(ctx sessionctx.Context, stats *property.StatsInfo, offset int) *PhysicalJSONTable {
+	p.basePhysicalPlan = newBasePhysicalPlan(ctx, plancodec.TypeJSONTable, &p, offset)
+	p.stats = stats
+	return &p
+}
+
 // Init initializes LogicalMemTable.
 func (p LogicalMemTable) Init(ctx sessionctx.Context, offset int) *LogicalMemTable {
 	p.baseLogicalPlan = newBaseLogicalPlan(ctx, plancodec.TypeMemTableScan, &p, offset)
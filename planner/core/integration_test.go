@@ -25,6 +25,7 @@ import (
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/tidb/config"
 	"github.com/pingcap/tidb/domain"
+	"github.com/pingcap/tidb/errno"
 	"github.com/pingcap/tidb/expression"
 	"github.com/pingcap/tidb/infoschema"
 	"github.com/pingcap/tidb/parser/auth"
@@ -3565,6 +3566,38 @@ func TestExtendedStatsSwitch(t *testing.T) {
 	))
 }
 
+func TestExtendedStatsEstimation(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(zip int, city int, x int)")
+	vals := make([]string, 0, 200)
+	for i := 0; i < 200; i++ {
+		vals = append(vals, fmt.Sprintf("(%d,%d,%d)", i/5, i/5%4, i%5))
+	}
+	tk.MustExec("insert into t values " + strings.Join(vals, ","))
+	tk.MustExec("set session tidb_enable_extended_stats = on")
+	tk.MustExec("alter table t add stats_extended s1 dependency(zip,city)")
+	tk.MustExec("alter table t add stats_extended s2 cardinality(city,x)")
+	tk.MustExec("analyze table t")
+
+	estRows := func(sql string) string {
+		return tk.MustQuery("explain format = 'brief' " + sql).Rows()[0][1].(string)
+	}
+	// Column zip determines column city, so the selectivity of city should not be multiplied.
+	require.Equal(t, "5.00", estRows("select * from t where zip = 1 and city = 1"))
+	// The NDV of (city, x) is the product of their NDVs since they are independent.
+	require.Equal(t, "20.00", estRows("select city, x, count(*) from t group by city, x"))
+	require.Equal(t, "10.00", estRows("select * from t where city = 1 and x = 1"))
+
+	tk.MustExec("set session tidb_enable_extended_stats = off")
+	require.Equal(t, "1.25", estRows("select * from t where zip = 1 and city = 1"))
+	require.Equal(t, "5.00", estRows("select city, x, count(*) from t group by city, x"))
+	require.Equal(t, "10.00", estRows("select * from t where city = 1 and x = 1"))
+}
+
 func TestOrderByNotInSelectDistinct(t *testing.T) {
 	store, clean := testkit.CreateMockStore(t)
 	defer clean()
@@ -4419,6 +4452,99 @@ func TestIndexMergeTableFilter(t *testing.T) {
 	))
 }
 
+func TestIndexMergeIntersection(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(id int primary key, a int, b int, c int, d varchar(20), key(a), key(b), key(c), key(d(2)))")
+	tk.MustExec("insert into t values(1,1,1,1,'aaa'),(2,1,2,2,'aab'),(3,2,1,3,'abc'),(4,1,1,4,'aab'),(5,3,3,5,'bbb')")
+
+	// Without the hint, the intersection is not chosen with the pseudo statistics.
+	tk.MustQuery("explain format = 'brief' select * from t where a = 1 and b = 1").Check(testkit.Rows(
+		"IndexLookUp 0.01 root  ",
+		"├─IndexRangeScan(Build) 10.00 cop[tikv] table:t, index:a(a) range:[1,1], keep order:false, stats:pseudo",
+		"└─Selection(Probe) 0.01 cop[tikv]  eq(test.t.b, 1)",
+		"  └─TableRowIDScan 10.00 cop[tikv] table:t keep order:false, stats:pseudo",
+	))
+	tk.MustQuery("explain format = 'brief' select /*+ use_index_merge(t, a, b) */ * from t where a = 1 and b = 1").Check(testkit.Rows(
+		"IndexMerge 0.01 root  type: intersection",
+		"├─IndexRangeScan(Build) 10.00 cop[tikv] table:t, index:a(a) range:[1,1], keep order:false, stats:pseudo",
+		"├─IndexRangeScan(Build) 10.00 cop[tikv] table:t, index:b(b) range:[1,1], keep order:false, stats:pseudo",
+		"└─TableRowIDScan(Probe) 0.01 cop[tikv] table:t keep order:false, stats:pseudo",
+	))
+	tk.MustQuery("select /*+ use_index_merge(t, a, b) */ * from t where a = 1 and b = 1").Sort().Check(testkit.Rows("1 1 1 1 aaa", "4 1 1 4 aab"))
+	tk.MustQuery("explain format = 'brief' select /*+ use_index_merge(t, a, b) */ * from t where a = 1 and b = 1 and c > 1").Check(testkit.Rows(
+		"IndexMerge 0.01 root  type: intersection",
+		"├─IndexRangeScan(Build) 10.00 cop[tikv] table:t, index:a(a) range:[1,1], keep order:false, stats:pseudo",
+		"├─IndexRangeScan(Build) 10.00 cop[tikv] table:t, index:b(b) range:[1,1], keep order:false, stats:pseudo",
+		"└─Selection(Probe) 0.01 cop[tikv]  gt(test.t.c, 1)",
+		"  └─TableRowIDScan 0.01 cop[tikv] table:t keep order:false, stats:pseudo",
+	))
+	tk.MustQuery("select /*+ use_index_merge(t, a, b) */ * from t where a = 1 and b = 1 and c > 1").Check(testkit.Rows("4 1 1 4 aab"))
+	tk.MustQuery("explain format = 'brief' select /*+ use_index_merge(t, a, b, c) */ * from t where a = 1 and b = 1 and c = 1").Check(testkit.Rows(
+		"IndexMerge 0.00 root  type: intersection",
+		"├─IndexRangeScan(Build) 10.00 cop[tikv] table:t, index:a(a) range:[1,1], keep order:false, stats:pseudo",
+		"├─IndexRangeScan(Build) 10.00 cop[tikv] table:t, index:b(b) range:[1,1], keep order:false, stats:pseudo",
+		"├─IndexRangeScan(Build) 10.00 cop[tikv] table:t, index:c(c) range:[1,1], keep order:false, stats:pseudo",
+		"└─TableRowIDScan(Probe) 0.00 cop[tikv] table:t keep order:false, stats:pseudo",
+	))
+	tk.MustQuery("select /*+ use_index_merge(t, a, b, c) */ * from t where a = 1 and b = 1 and c = 1").Check(testkit.Rows("1 1 1 1 aaa"))
+	// The condition on the prefix index column is also checked after the table lookup.
+	tk.MustQuery("explain format = 'brief' select /*+ use_index_merge(t, a, d) */ * from t where a = 1 and d = 'aab'").Check(testkit.Rows(
+		"IndexMerge 0.01 root  type: intersection",
+		"├─IndexRangeScan(Build) 10.00 cop[tikv] table:t, index:a(a) range:[1,1], keep order:false, stats:pseudo",
+		"├─IndexRangeScan(Build) 10.00 cop[tikv] table:t, index:d(d) range:[\"aa\",\"aa\"], keep order:false, stats:pseudo",
+		"└─Selection(Probe) 0.01 cop[tikv]  eq(test.t.d, \"aab\")",
+		"  └─TableRowIDScan 0.01 cop[tikv] table:t keep order:false, stats:pseudo",
+	))
+	tk.MustQuery("select /*+ use_index_merge(t, a, d) */ * from t where a = 1 and d = 'aab'").Sort().Check(testkit.Rows("2 1 2 2 aab", "4 1 1 4 aab"))
+
+	// At least two indexes are needed to intersect the handles.
+	tk.MustQuery("explain format = 'brief' select /*+ use_index_merge(t, a) */ * from t where a = 1 and b = 1").Check(testkit.Rows(
+		"IndexLookUp 0.01 root  ",
+		"├─IndexRangeScan(Build) 10.00 cop[tikv] table:t, index:a(a) range:[1,1], keep order:false, stats:pseudo",
+		"└─Selection(Probe) 0.01 cop[tikv]  eq(test.t.b, 1)",
+		"  └─TableRowIDScan 10.00 cop[tikv] table:t keep order:false, stats:pseudo",
+	))
+	tk.MustQuery("show warnings").Check(testkit.Rows("Warning 1105 IndexMerge is inapplicable."))
+	tk.MustQuery("explain format = 'brief' select /*+ use_index_merge(t) */ * from t where a = 1 and id = 1").Check(testkit.Rows(
+		"Selection 0.10 root  eq(test.t.a, 1)",
+		"└─Point_Get 1.00 root table:t handle:1",
+	))
+
+	tk.MustExec("set @@tidb_enable_index_merge = 0")
+	tk.MustQuery("explain format = 'brief' select * from t where a = 1 and b = 1").Check(testkit.Rows(
+		"IndexLookUp 0.01 root  ",
+		"├─IndexRangeScan(Build) 10.00 cop[tikv] table:t, index:a(a) range:[1,1], keep order:false, stats:pseudo",
+		"└─Selection(Probe) 0.01 cop[tikv]  eq(test.t.b, 1)",
+		"  └─TableRowIDScan 10.00 cop[tikv] table:t keep order:false, stats:pseudo",
+	))
+	tk.MustQuery("explain format = 'brief' select /*+ use_index_merge(t, a, b) */ * from t where a = 1 and b = 1").Check(testkit.Rows(
+		"IndexMerge 0.01 root  type: intersection",
+		"├─IndexRangeScan(Build) 10.00 cop[tikv] table:t, index:a(a) range:[1,1], keep order:false, stats:pseudo",
+		"├─IndexRangeScan(Build) 10.00 cop[tikv] table:t, index:b(b) range:[1,1], keep order:false, stats:pseudo",
+		"└─TableRowIDScan(Probe) 0.01 cop[tikv] table:t keep order:false, stats:pseudo",
+	))
+	tk.MustExec("set @@tidb_enable_index_merge = 1")
+
+	// With the real statistics, the intersection is chosen when it reads much fewer rows than any single index.
+	tk.MustExec("drop table if exists t1")
+	tk.MustExec("create table t1(id int primary key, a int, b int, key(a), key(b))")
+	values := make([]string, 0, 1000)
+	for i := 0; i < 1000; i++ {
+		values = append(values, fmt.Sprintf("(%d, %d, %d)", i, i%20, i/20%20))
+	}
+	tk.MustExec("insert into t1 values " + strings.Join(values, ","))
+	tk.MustExec("set @@tidb_analyze_version = 1")
+	tk.MustExec("analyze table t1")
+	rows := tk.MustQuery("explain format = 'brief' select * from t1 where a = 1 and b = 1").Rows()
+	require.Equal(t, "IndexMerge", rows[0][0])
+	require.Equal(t, "type: intersection", rows[0][4])
+	tk.MustQuery("select * from t1 where a = 1 and b = 1 order by id").Check(testkit.Rows("21 1 1", "421 1 1", "821 1 1"))
+}
+
 func TestIssue22850(t *testing.T) {
 	store, clean := testkit.CreateMockStore(t)
 	defer clean()
@@ -6208,3 +6334,424 @@ func TestIssue32632(t *testing.T) {
 	tk.MustExec("drop table if exists partsupp")
 	tk.MustExec("drop table if exists supplier")
 }
+
+func TestMultiValuedIndex(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t (id int primary key, a int, j json, index idx((cast(j->'$.tags' as unsigned array))), index idx2((cast(j->'$.names' as char(10) array))))")
+	tk.MustExec(`insert into t values (1, 1, '{"tags": [1, 2, 3], "names": ["x"]}'), (2, 2, '{"tags": [3, 4], "names": ["x", "y"]}'), (3, 3, '{"tags": []}'), (4, 4, '{}'), (5, 5, '{"tags": 5}')`)
+
+	tk.MustQuery("explain format = 'brief' select id from t where 3 member of (j->'$.tags')").Check(testkit.Rows(
+		"Projection 8.00 root  test.t.id",
+		"└─Selection 8.00 root  json_memberof(cast(3, json BINARY), json_extract(test.t.j, \"$.tags\"))",
+		"  └─IndexLookUp 10.00 root  ",
+		"    ├─IndexRangeScan(Build) 10.00 cop[tikv] table:t, index:idx(cast(json_extract(`j`, _utf8mb4'$.tags') as unsigned array)) range:[3,3], keep order:false, stats:pseudo",
+		"    └─TableRowIDScan(Probe) 10.00 cop[tikv] table:t keep order:false, stats:pseudo"))
+	tk.MustQuery("explain format = 'brief' select id from t where json_overlaps(j->'$.tags', '[1, 5]')").Check(testkit.Rows(
+		"Projection 16.00 root  test.t.id",
+		"└─Selection 16.00 root  json_overlaps(json_extract(test.t.j, \"$.tags\"), cast(\"[1, 5]\", json BINARY))",
+		"  └─IndexMerge 20.00 root  ",
+		"    ├─IndexRangeScan(Build) 10.00 cop[tikv] table:t, index:idx(cast(json_extract(`j`, _utf8mb4'$.tags') as unsigned array)) range:[1,1], keep order:false, stats:pseudo",
+		"    ├─IndexRangeScan(Build) 10.00 cop[tikv] table:t, index:idx(cast(json_extract(`j`, _utf8mb4'$.tags') as unsigned array)) range:[5,5], keep order:false, stats:pseudo",
+		"    └─TableRowIDScan(Probe) 20.00 cop[tikv] table:t keep order:false, stats:pseudo"))
+	// The multi-valued index can't be used without the JSON predicates, even if it's forced.
+	tk.MustQuery("explain format = 'brief' select id from t use index(idx) where a = 1").Check(testkit.Rows(
+		"Projection 10.00 root  test.t.id",
+		"└─TableReader 10.00 root  data:Selection",
+		"  └─Selection 10.00 cop[tikv]  eq(test.t.a, 1)",
+		"    └─TableFullScan 10000.00 cop[tikv] table:t keep order:false, stats:pseudo"))
+
+	tk.MustQuery("select id from t where 3 member of (j->'$.tags')").Sort().Check(testkit.Rows("1", "2"))
+	tk.MustQuery("select id from t where json_contains(j->'$.tags', '[3, 4]')").Check(testkit.Rows("2"))
+	tk.MustQuery("select id from t where json_contains(j->'$.tags', '5')").Check(testkit.Rows("5"))
+	tk.MustQuery("select id from t where json_overlaps(j->'$.tags', '[1, 4, 5]')").Sort().Check(testkit.Rows("1", "2", "5"))
+	tk.MustQuery("select id from t where json_overlaps('[2]', j->'$.tags') and a > 1").Check(testkit.Rows())
+	tk.MustQuery("select id from t where 'y' member of (j->'$.names')").Check(testkit.Rows("2"))
+	tk.MustQuery("select id from t where 'a' member of (j->'$.tags')").Check(testkit.Rows())
+	tk.MustQuery("select count(*) from t use index(idx)").Check(testkit.Rows("5"))
+
+	tk.MustExec("prepare st from 'select id from t where ? member of (j->\\'$.tags\\')'")
+	tk.MustExec("set @a = 3")
+	tk.MustQuery("execute st using @a").Sort().Check(testkit.Rows("1", "2"))
+	tk.MustExec("set @a = 5")
+	tk.MustQuery("execute st using @a").Check(testkit.Rows("5"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("0"))
+
+	tk.MustExec("update t set a = a + 10 where 3 member of (j->'$.tags')")
+	tk.MustExec("delete from t where json_overlaps(j->'$.tags', '[1, 5]')")
+	tk.MustQuery("select id, a from t").Check(testkit.Rows("2 12", "3 3", "4 4"))
+	tk.MustQuery("select id from t where 3 member of (j->'$.tags')").Check(testkit.Rows("2"))
+	tk.MustExec("analyze table t")
+	tk.MustExec("admin check table t")
+}
+
+func TestFullTextIndex(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t, t2")
+	tk.MustExec("create table t (id int primary key, a int, title varchar(100), body text, fulltext index ft(title, body), fulltext index ft_title(title))")
+	tk.MustExec(`insert into t values (1, 1, 'MySQL Tutorial', 'DBMS stands for DataBase ...'), (2, 2, 'How To Use MySQL Well', 'After you went through a ...'),
+		(3, 3, 'Optimizing MySQL', 'In this tutorial, we show ...'), (4, 4, '1001 MySQL Tricks', '1. Never run mysqld as root. 2. ...'),
+		(5, 5, 'MySQL vs. YourSQL', 'In the following database comparison ...'), (6, 6, 'MySQL Security', 'When configured properly, MySQL ...'), (7, 7, NULL, NULL)`)
+
+	tk.MustQuery("explain format = 'brief' select id from t where match(title, body) against ('database')").Check(testkit.Rows(
+		"Projection 8.00 root  test.t.id",
+		"└─Selection 8.00 root  match(test.t.title, test.t.body, \"database\", \"natural language mode\", \"whitespace\")",
+		"  └─IndexLookUp 10.00 root  ",
+		"    ├─IndexRangeScan(Build) 10.00 cop[tikv] table:t, index:ft(title, body) range:[\"database\",\"database\"], keep order:false, stats:pseudo",
+		"    └─TableRowIDScan(Probe) 10.00 cop[tikv] table:t keep order:false, stats:pseudo"))
+	tk.MustQuery("explain format = 'brief' select id from t where match(title, body) against ('database tutorial')").Check(testkit.Rows(
+		"Projection 16.00 root  test.t.id",
+		"└─Selection 16.00 root  match(test.t.title, test.t.body, \"database tutorial\", \"natural language mode\", \"whitespace\")",
+		"  └─IndexMerge 20.00 root  ",
+		"    ├─IndexRangeScan(Build) 10.00 cop[tikv] table:t, index:ft(title, body) range:[\"database\",\"database\"], keep order:false, stats:pseudo",
+		"    ├─IndexRangeScan(Build) 10.00 cop[tikv] table:t, index:ft(title, body) range:[\"tutorial\",\"tutorial\"], keep order:false, stats:pseudo",
+		"    └─TableRowIDScan(Probe) 20.00 cop[tikv] table:t keep order:false, stats:pseudo"))
+	tk.MustQuery("explain format = 'brief' select id from t where match(title) against ('+my* -yoursql' in boolean mode)").Check(testkit.Rows(
+		"Projection 200.00 root  test.t.id",
+		"└─Selection 200.00 root  match(test.t.title, \"+my* -yoursql\", \"boolean mode\", \"whitespace\")",
+		"  └─IndexMerge 250.00 root  ",
+		"    ├─IndexRangeScan(Build) 250.00 cop[tikv] table:t, index:ft_title(title) range:[\"my\",\"mz\"), keep order:false, stats:pseudo",
+		"    └─TableRowIDScan(Probe) 250.00 cop[tikv] table:t keep order:false, stats:pseudo"))
+	// The FULLTEXT index can't be used without MATCH ... AGAINST, even if it's forced.
+	tk.MustQuery("explain format = 'brief' select id from t use index(ft) where a = 1").Check(testkit.Rows(
+		"Projection 10.00 root  test.t.id",
+		"└─TableReader 10.00 root  data:Selection",
+		"  └─Selection 10.00 cop[tikv]  eq(test.t.a, 1)",
+		"    └─TableFullScan 10000.00 cop[tikv] table:t keep order:false, stats:pseudo"))
+
+	tk.MustQuery("select id, match(title, body) against ('database tutorial') as score from t where match(title, body) against ('database tutorial') order by score desc").Check(testkit.Rows(
+		"1 0.8944271909999159", "3 0.5", "5 0.4472135954999579"))
+	tk.MustQuery("select id from t where match(body, title) against ('security')").Check(testkit.Rows("6"))
+	tk.MustQuery("select id from t where match(title) against ('+my* -yoursql' in boolean mode)").Sort().Check(testkit.Rows("1", "2", "3", "4", "6"))
+	tk.MustQuery("select id from t where match(title, body) against ('\"following database\"' in boolean mode)").Check(testkit.Rows("5"))
+	tk.MustQuery("select id from t where match(title, body) against ('\"database following\"' in boolean mode)").Check(testkit.Rows())
+	tk.MustQuery("select id from t where match(title, body) against ('the')").Check(testkit.Rows())
+	tk.MustQuery("select count(*) from t use index(ft)").Check(testkit.Rows("7"))
+
+	tk.MustExec("prepare st from 'select id from t where match(title, body) against (?)'")
+	tk.MustExec("set @a = 'tutorial'")
+	tk.MustQuery("execute st using @a").Sort().Check(testkit.Rows("1", "3"))
+	tk.MustExec("set @a = 'security'")
+	tk.MustQuery("execute st using @a").Check(testkit.Rows("6"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("0"))
+
+	tk.MustExec("update t set title = 'Database design' where id = 2")
+	tk.MustExec("delete from t where match(title, body) against ('tutorial')")
+	tk.MustQuery("select id from t where match(title, body) against ('database')").Sort().Check(testkit.Rows("2", "5"))
+	tk.MustExec("admin check table t")
+
+	tk.MustGetErrCode("select id from t where match(body) against ('database')", mysql.ErrFtMatchingKeyNotFound)
+	tk.MustGetErrCode("select id from t where match(title, body) against (title)", mysql.ErrWrongArguments)
+	tk.MustGetErrCode("select id from t where match(title, body) against ('database' with query expansion)", mysql.ErrNotSupportedYet)
+
+	tk.MustExec("create table t2 (id int primary key, c varchar(100), fulltext index ft(c) with parser ngram)")
+	tk.MustExec("insert into t2 values (1, '数据库的全文检索'), (2, '全文索引'), (3, '数据库')")
+	tk.MustQuery("explain format = 'brief' select id from t2 where match(c) against ('+全文检索' in boolean mode)").Check(testkit.Rows(
+		"Projection 8.00 root  test.t2.id",
+		"└─Selection 8.00 root  match(test.t2.c, \"+全文检索\", \"boolean mode\", \"ngram\")",
+		"  └─IndexLookUp 10.00 root  ",
+		"    ├─IndexRangeScan(Build) 10.00 cop[tikv] table:t2, index:ft(c) range:[\"全文\",\"全文\"], keep order:false, stats:pseudo",
+		"    └─TableRowIDScan(Probe) 10.00 cop[tikv] table:t2 keep order:false, stats:pseudo"))
+	tk.MustQuery("select id from t2 where match(c) against ('+全文检索' in boolean mode)").Check(testkit.Rows("1"))
+	tk.MustQuery("select id from t2 where match(c) against ('全文检索')").Sort().Check(testkit.Rows("1", "2"))
+	tk.MustQuery("select id from t2 where match(c) against ('数据库')").Sort().Check(testkit.Rows("1", "3"))
+}
+
+func TestSpatialIndex(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t, t2")
+	tk.MustExec("create table t (id int primary key, p point not null srid 4326, spatial index sp(p))")
+	tk.MustExec(`insert into t values (1, st_geomfromtext('POINT(40 116.4)', 4326)), (2, st_geomfromtext('POINT(31.2 121.5)', 4326)),
+		(3, st_geomfromtext('POINT(40.01 116.41)', 4326)), (4, st_geomfromtext('POINT(-33.9 151.2)', 4326))`)
+
+	queries := []string{
+		"select id from t %s where st_distance_sphere(p, st_geomfromtext('POINT(40 116.4)', 4326)) < 5000",
+		"select id from t %s where st_distance(st_geomfromtext('POINT(31.2 121.5)', 4326), p) <= 1000",
+		"select id from t %s where st_contains(st_geomfromtext('POLYGON((39 116,41 116,41 117,39 117,39 116))', 4326), p)",
+		"select id from t %s where st_within(p, st_geomfromtext('POLYGON((30 115,41 115,41 122,30 122,30 115))', 4326))",
+		"select id from t %s where mbrintersects(p, st_geomfromtext('POLYGON((-40 150,-30 150,-30 152,-40 152,-40 150))', 4326))",
+	}
+	results := [][]string{{"1", "3"}, {"2"}, {"1", "3"}, {"1", "2", "3"}, {"4"}}
+	for i, query := range queries {
+		sql := fmt.Sprintf(query, "")
+		require.True(t, tk.HasPlan(sql, "IndexMerge"), sql)
+		require.True(t, tk.MustUseIndex(sql, "sp(p)"), sql)
+		tk.MustQuery(sql).Sort().Check(testkit.RowsWithSep(" ", results[i]...))
+		tk.MustQuery(fmt.Sprintf(query, "use index()")).Sort().Check(testkit.RowsWithSep(" ", results[i]...))
+	}
+	// The row count is bounded by the rows accessed by the SPATIAL index.
+	rows := tk.MustQuery("explain format = 'brief' " + fmt.Sprintf(queries[0], "")).Rows()
+	require.Equal(t, []interface{}{"└─Selection", "872.00"}, rows[1][:2])
+	require.Equal(t, []interface{}{"  └─IndexMerge", "1090.00"}, rows[2][:2])
+	// The SPATIAL index can't be used by the geometries of other SRIDs or by the predicates out of the index range.
+	require.False(t, tk.HasPlan("select id from t where st_contains(point(1, 1), p)", "IndexMerge"))
+	require.False(t, tk.HasPlan("select id from t where st_distance_sphere(p, st_geomfromtext('POINT(40 116.4)', 4326)) > 5000", "IndexMerge"))
+	require.False(t, tk.HasPlan("select id from t use index(sp) where id = 1", "IndexMerge"))
+
+	tk.MustExec("update t set p = st_geomfromtext('POINT(31.3 121.6)', 4326) where id = 1")
+	tk.MustExec("delete from t where id = 3")
+	tk.MustQuery(fmt.Sprintf(queries[1], "")).Check(testkit.Rows("2"))
+	tk.MustQuery("select id from t where st_distance_sphere(p, st_geomfromtext('POINT(31.2 121.5)', 4326)) <= 20000").Sort().Check(testkit.Rows("1", "2"))
+	tk.MustExec("admin check table t")
+
+	tk.MustExec("prepare st from 'select id from t where st_distance_sphere(p, st_geomfromtext(?, 4326)) < 5000'")
+	tk.MustExec("set @a = 'POINT(31.2 121.5)'")
+	tk.MustQuery("execute st using @a").Check(testkit.Rows("2"))
+	tk.MustExec("set @a = 'POINT(-33.9 151.2)'")
+	tk.MustQuery("execute st using @a").Check(testkit.Rows("4"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("0"))
+
+	// The SPATIAL index on the column without SRID isn't used.
+	tk.MustExec("create table t2 (id int primary key, g geometry not null, spatial index sp(g))")
+	tk.MustExec("insert into t2 values (1, point(1, 1)), (2, point(5, 5))")
+	require.False(t, tk.HasPlan("select id from t2 where st_contains(st_geomfromtext('POLYGON((0 0,2 0,2 2,0 2,0 0))'), g)", "IndexMerge"))
+	tk.MustQuery("select id from t2 where st_contains(st_geomfromtext('POLYGON((0 0,2 0,2 2,0 2,0 0))'), g)").Check(testkit.Rows("1"))
+}
+
+func TestHypoIndex(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t, t2")
+	tk.MustExec("create table t (a int, b int, c varchar(20), key idx_b(b))")
+	for i := 0; i < 100; i++ {
+		tk.MustExec(fmt.Sprintf("insert into t values (%d, %d, 'c%d')", i, i%10, i))
+	}
+	tk.MustExec("analyze table t")
+
+	tk.MustExec("create index idx_a on t(a) type hypo")
+	tk.MustExec("create unique index idx_c on t(c) using hypo")
+	tk.MustGetErrCode("create index idx_a on t(b) type hypo", mysql.ErrDupKeyName)
+	tk.MustGetErrCode("create index idx_b on t(a) type hypo", mysql.ErrDupKeyName)
+	tk.MustExec("create index if not exists idx_a on t(b) type hypo")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1061 index already exist idx_a"))
+	tk.MustGetErrCode("create index idx_d on t(d) type hypo", mysql.ErrKeyColumnDoesNotExits)
+	tk.MustGetErrCode("create index idx_d on t((a + 1)) type hypo", errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("alter table t add index idx_d(a) using hypo", errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("create table t2 (a int, key(a) using hypo)", errno.ErrUnsupportedDDLOperation)
+
+	// The row counts of the hypothetical indexes are estimated by the statistics of the columns.
+	tk.MustQuery("explain format = 'brief' select * from t where a = 1").Check(testkit.Rows(
+		"IndexLookUp 1.00 root  ",
+		"├─IndexRangeScan(Build) 1.00 cop[tikv] table:t, hypo index:idx_a(a) range:[1,1], keep order:false",
+		"└─TableRowIDScan(Probe) 1.00 cop[tikv] table:t keep order:false"))
+	tk.MustQuery("explain format = 'brief' select a from t where a > 95").Check(testkit.Rows(
+		"IndexReader 4.00 root  index:IndexRangeScan",
+		"└─IndexRangeScan 4.00 cop[tikv] table:t, hypo index:idx_a(a) range:(95,+inf], keep order:false"))
+	tk.MustQuery("explain format = 'brief' select * from t where c = 'c1'").Check(testkit.Rows(
+		"Point_Get 1.00 root table:t, hypo index:idx_c(c) "))
+	tk.MustQuery("explain format = 'brief' select * from t where c in ('c1', 'c2')").Check(testkit.Rows(
+		"Batch_Point_Get 2.00 root table:t, hypo index:idx_c(c) keep order:false, desc:false"))
+	require.True(t, tk.HasPlan("select * from t where a = 1 or b = 1", "IndexMerge"))
+
+	// The hypothetical indexes can't be used to execute the statements, so they are only considered by EXPLAIN.
+	for _, sql := range []string{
+		"explain analyze select * from t where a = 1",
+		"explain analyze select * from t where c = 'c1'",
+		"explain analyze select * from t where c in ('c1', 'c2')",
+	} {
+		tk.MustGetErrCode(sql, errno.ErrExecHypoIndex)
+	}
+	tk.MustQuery("select * from t where a = 1").Check(testkit.Rows("1 1 c1"))
+	tk.MustQuery("select * from t where c in ('c1', 'c2')").Sort().Check(testkit.Rows("1 1 c1", "2 2 c2"))
+	require.False(t, tk.HasPlan("select * from t where a = 1 and b = 1", "IndexMerge"))
+	tk.MustGetErrCode("select * from t use index(idx_a) where a = 1", mysql.ErrKeyDoesNotExist)
+	tk.MustExec("insert into t values (100, 0, 'c100')")
+	tk.MustExec("update t set c = 'c101' where a = 100")
+	tk.MustExec("admin check table t")
+	tk.MustQuery("show index from t").Check(testkit.Rows("t 1 idx_b 1 b A 0 <nil> <nil> YES BTREE   YES <nil> NO"))
+
+	// The hypothetical indexes are only seen by the session.
+	tk2 := testkit.NewTestKit(t, store)
+	tk2.MustExec("use test")
+	require.False(t, tk2.MustUseIndex("select * from t where a = 1", "idx_a(a)"))
+
+	// The hypothetical index is ignored after its column is dropped.
+	tk.MustExec("alter table t drop column c")
+	tk.MustQuery("explain format = 'brief' select * from t where b = 1").Check(testkit.Rows(
+		"IndexLookUp 10.00 root  ",
+		"├─IndexRangeScan(Build) 10.00 cop[tikv] table:t, index:idx_b(b) range:[1,1], keep order:false",
+		"└─TableRowIDScan(Probe) 10.00 cop[tikv] table:t keep order:false"))
+	tk.MustExec("drop hypo index idx_c on t")
+
+	tk.MustExec("drop hypo index idx_a on t")
+	require.False(t, tk.MustUseIndex("select * from t where a = 1", "idx_a(a)"))
+	tk.MustGetErrCode("drop hypo index idx_a on t", mysql.ErrCantDropFieldOrKey)
+	tk.MustExec("drop hypo index if exists idx_a on t")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1091 index idx_a doesn't exist"))
+	tk.MustGetErrCode("drop hypo index if exists idx_a on t_not_exists", mysql.ErrNoSuchTable)
+}
+
+func TestOuterJoinReorder(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table f (id int primary key, d1 int, d2 int, d3 int, v int)")
+	tk.MustExec("create table d1 (id int primary key, a int)")
+	tk.MustExec("create table d2 (id int primary key, b int)")
+	tk.MustExec("create table d3 (id int primary key, c int)")
+	tk.MustExec("insert into f values (1, 1, 1, 1, 10), (2, 2, 2, 2, 20), (3, 9, 1, 2, 30)")
+	tk.MustExec("insert into d1 values (1, 100), (2, 200)")
+	tk.MustExec("insert into d2 values (1, 1), (2, 2)")
+	tk.MustExec("insert into d3 values (1, 1), (2, 2)")
+
+	// The left join is no longer a barrier of the join reorder.
+	sql := "select * from f left join d1 on f.d1 = d1.id join d2 on f.d2 = d2.id join d3 on f.d3 = d3.id"
+	tk.MustExec("set @@tidb_opt_join_reorder_threshold = 0")
+	tk.MustQuery("explain format = 'brief' " + sql).Check(testkit.Rows(
+		"HashJoin 19492.21 root  inner join, equal:[eq(test.f.d3, test.d3.id)]",
+		"├─TableReader(Build) 10000.00 root  data:TableFullScan",
+		"│ └─TableFullScan 10000.00 cop[tikv] table:d3 keep order:false, stats:pseudo",
+		"└─HashJoin(Probe) 15593.77 root  inner join, equal:[eq(test.f.d2, test.d2.id)]",
+		"  ├─TableReader(Build) 10000.00 root  data:TableFullScan",
+		"  │ └─TableFullScan 10000.00 cop[tikv] table:d2 keep order:false, stats:pseudo",
+		"  └─HashJoin(Probe) 12475.01 root  left outer join, equal:[eq(test.f.d1, test.d1.id)]",
+		"    ├─TableReader(Build) 10000.00 root  data:TableFullScan",
+		"    │ └─TableFullScan 10000.00 cop[tikv] table:d1 keep order:false, stats:pseudo",
+		"    └─TableReader(Probe) 9980.01 root  data:Selection",
+		"      └─Selection 9980.01 cop[tikv]  not(isnull(test.f.d2)), not(isnull(test.f.d3))",
+		"        └─TableFullScan 10000.00 cop[tikv] table:f keep order:false, stats:pseudo"))
+	tk.MustQuery(sql).Sort().Check(testkit.Rows(
+		"1 1 1 1 10 1 100 1 1 1 1",
+		"2 2 2 2 20 2 200 2 2 2 2",
+		"3 9 1 2 30 <nil> <nil> 1 1 2 2"))
+	tk.MustExec("set @@tidb_opt_join_reorder_threshold = 10")
+	tk.MustQuery("explain format = 'brief' " + sql).Check(testkit.Rows(
+		"Projection 19492.21 root  test.f.id, test.f.d1, test.f.d2, test.f.d3, test.f.v, test.d1.id, test.d1.a, test.d2.id, test.d2.b, test.d3.id, test.d3.c",
+		"└─HashJoin 19492.21 root  left outer join, equal:[eq(test.f.d1, test.d1.id)]",
+		"  ├─TableReader(Build) 10000.00 root  data:TableFullScan",
+		"  │ └─TableFullScan 10000.00 cop[tikv] table:d1 keep order:false, stats:pseudo",
+		"  └─HashJoin(Probe) 15593.77 root  inner join, equal:[eq(test.f.d3, test.d3.id)]",
+		"    ├─TableReader(Build) 10000.00 root  data:TableFullScan",
+		"    │ └─TableFullScan 10000.00 cop[tikv] table:d3 keep order:false, stats:pseudo",
+		"    └─HashJoin(Probe) 12475.01 root  inner join, equal:[eq(test.f.d2, test.d2.id)]",
+		"      ├─TableReader(Build) 9980.01 root  data:Selection",
+		"      │ └─Selection 9980.01 cop[tikv]  not(isnull(test.f.d2)), not(isnull(test.f.d3))",
+		"      │   └─TableFullScan 10000.00 cop[tikv] table:f keep order:false, stats:pseudo",
+		"      └─TableReader(Probe) 10000.00 root  data:TableFullScan",
+		"        └─TableFullScan 10000.00 cop[tikv] table:d2 keep order:false, stats:pseudo"))
+	tk.MustQuery(sql).Sort().Check(testkit.Rows(
+		"1 1 1 1 10 1 100 1 1 1 1",
+		"2 2 2 2 20 2 200 2 2 2 2",
+		"3 9 1 2 30 <nil> <nil> 1 1 2 2"))
+
+	for _, threshold := range []string{"0", "10"} {
+		tk.MustExec("set @@tidb_opt_join_reorder_threshold = " + threshold)
+		// The inner side of the left join is referred by the inner join, so the left join is kept.
+		tk.MustQuery("select f.id, d1.a, d2.id from f left join d1 on f.d1 = d1.id join d2 on ifnull(d1.a, 1) = d2.id").Check(testkit.Rows(
+			"3 <nil> 1"))
+		tk.MustQuery("select f.id, d1.a, d2.b, d3.c from d1 right join f on f.d1 = d1.id and d1.a > 100 join d2 on f.d2 = d2.id left join d3 on d1.id = d3.id").Sort().Check(testkit.Rows(
+			"1 <nil> 1 <nil>",
+			"2 200 2 2",
+			"3 <nil> 1 <nil>"))
+	}
+}
+
+func TestLeadingHint(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table f (id int primary key, d1 int, d2 int, d3 int, v int)")
+	tk.MustExec("create table d1 (id int primary key, a int)")
+	tk.MustExec("create table d2 (id int primary key, b int)")
+	tk.MustExec("create table d3 (id int primary key, c int)")
+
+	// Both the greedy and the DP join reorder start with the leading tables.
+	sql := "select /*+ leading(d3, f) */ * from f left join d1 on f.d1 = d1.id join d2 on f.d2 = d2.id join d3 on f.d3 = d3.id"
+	tk.MustExec("set @@tidb_opt_join_reorder_threshold = 0")
+	tk.MustQuery("explain format = 'brief' " + sql).Check(testkit.Rows(
+		"Projection 19492.21 root  test.f.id, test.f.d1, test.f.d2, test.f.d3, test.f.v, test.d1.id, test.d1.a, test.d2.id, test.d2.b, test.d3.id, test.d3.c",
+		"└─HashJoin 19492.21 root  inner join, equal:[eq(test.f.d2, test.d2.id)]",
+		"  ├─TableReader(Build) 10000.00 root  data:TableFullScan",
+		"  │ └─TableFullScan 10000.00 cop[tikv] table:d2 keep order:false, stats:pseudo",
+		"  └─HashJoin(Probe) 15593.77 root  left outer join, equal:[eq(test.f.d1, test.d1.id)]",
+		"    ├─TableReader(Build) 10000.00 root  data:TableFullScan",
+		"    │ └─TableFullScan 10000.00 cop[tikv] table:d1 keep order:false, stats:pseudo",
+		"    └─HashJoin(Probe) 12475.01 root  inner join, equal:[eq(test.d3.id, test.f.d3)]",
+		"      ├─TableReader(Build) 9980.01 root  data:Selection",
+		"      │ └─Selection 9980.01 cop[tikv]  not(isnull(test.f.d2)), not(isnull(test.f.d3))",
+		"      │   └─TableFullScan 10000.00 cop[tikv] table:f keep order:false, stats:pseudo",
+		"      └─TableReader(Probe) 10000.00 root  data:TableFullScan",
+		"        └─TableFullScan 10000.00 cop[tikv] table:d3 keep order:false, stats:pseudo"))
+	tk.MustExec("set @@tidb_opt_join_reorder_threshold = 10")
+	tk.MustQuery("explain format = 'brief' " + sql).Check(testkit.Rows(
+		"Projection 19492.21 root  test.f.id, test.f.d1, test.f.d2, test.f.d3, test.f.v, test.d1.id, test.d1.a, test.d2.id, test.d2.b, test.d3.id, test.d3.c",
+		"└─HashJoin 19492.21 root  left outer join, equal:[eq(test.f.d1, test.d1.id)]",
+		"  ├─TableReader(Build) 10000.00 root  data:TableFullScan",
+		"  │ └─TableFullScan 10000.00 cop[tikv] table:d1 keep order:false, stats:pseudo",
+		"  └─HashJoin(Probe) 15593.77 root  inner join, equal:[eq(test.f.d2, test.d2.id)]",
+		"    ├─TableReader(Build) 10000.00 root  data:TableFullScan",
+		"    │ └─TableFullScan 10000.00 cop[tikv] table:d2 keep order:false, stats:pseudo",
+		"    └─HashJoin(Probe) 12475.01 root  inner join, equal:[eq(test.d3.id, test.f.d3)]",
+		"      ├─TableReader(Build) 9980.01 root  data:Selection",
+		"      │ └─Selection 9980.01 cop[tikv]  not(isnull(test.f.d2)), not(isnull(test.f.d3))",
+		"      │   └─TableFullScan 10000.00 cop[tikv] table:f keep order:false, stats:pseudo",
+		"      └─TableReader(Probe) 10000.00 root  data:TableFullScan",
+		"        └─TableFullScan 10000.00 cop[tikv] table:d3 keep order:false, stats:pseudo"))
+	tk.MustQuery("show warnings").Check(testkit.Rows())
+
+	for _, threshold := range []string{"0", "10"} {
+		tk.MustExec("set @@tidb_opt_join_reorder_threshold = " + threshold)
+		// The leading tables are joined by a cartesian join if they are not connected.
+		tk.MustQuery("explain format = 'brief' select /*+ leading(d2, d3) */ * from f join d2 on f.d2 = d2.id join d3 on f.d3 = d3.id").Check(testkit.Rows(
+			"Projection 124750125.00 root  test.f.id, test.f.d1, test.f.d2, test.f.d3, test.f.v, test.d2.id, test.d2.b, test.d3.id, test.d3.c",
+			"└─HashJoin 124750125.00 root  inner join, equal:[eq(test.d2.id, test.f.d2) eq(test.d3.id, test.f.d3)]",
+			"  ├─TableReader(Build) 9980.01 root  data:Selection",
+			"  │ └─Selection 9980.01 cop[tikv]  not(isnull(test.f.d2)), not(isnull(test.f.d3))",
+			"  │   └─TableFullScan 10000.00 cop[tikv] table:f keep order:false, stats:pseudo",
+			"  └─HashJoin(Probe) 100000000.00 root  CARTESIAN inner join",
+			"    ├─TableReader(Build) 10000.00 root  data:TableFullScan",
+			"    │ └─TableFullScan 10000.00 cop[tikv] table:d3 keep order:false, stats:pseudo",
+			"    └─TableReader(Probe) 10000.00 root  data:TableFullScan",
+			"      └─TableFullScan 10000.00 cop[tikv] table:d2 keep order:false, stats:pseudo"))
+		tk.MustQuery("show warnings").Check(testkit.Rows())
+		tk.MustQuery("explain format = 'brief' select /*+ leading(f, d1) */ * from f left join d1 on f.d1 = d1.id join d2 on f.d2 = d2.id join d3 on f.d3 = d3.id").Check(testkit.Rows(
+			"HashJoin 19492.21 root  inner join, equal:[eq(test.f.d3, test.d3.id)]",
+			"├─TableReader(Build) 10000.00 root  data:TableFullScan",
+			"│ └─TableFullScan 10000.00 cop[tikv] table:d3 keep order:false, stats:pseudo",
+			"└─HashJoin(Probe) 15593.77 root  inner join, equal:[eq(test.f.d2, test.d2.id)]",
+			"  ├─TableReader(Build) 10000.00 root  data:TableFullScan",
+			"  │ └─TableFullScan 10000.00 cop[tikv] table:d2 keep order:false, stats:pseudo",
+			"  └─HashJoin(Probe) 12475.01 root  left outer join, equal:[eq(test.f.d1, test.d1.id)]",
+			"    ├─TableReader(Build) 10000.00 root  data:TableFullScan",
+			"    │ └─TableFullScan 10000.00 cop[tikv] table:d1 keep order:false, stats:pseudo",
+			"    └─TableReader(Probe) 9980.01 root  data:Selection",
+			"      └─Selection 9980.01 cop[tikv]  not(isnull(test.f.d2)), not(isnull(test.f.d3))",
+			"        └─TableFullScan 10000.00 cop[tikv] table:f keep order:false, stats:pseudo"))
+		tk.MustQuery("show warnings").Check(testkit.Rows())
+
+		// The inner side of a left join can't be joined before the outer side.
+		tk.MustExec("explain select /*+ leading(d1, f) */ * from f left join d1 on f.d1 = d1.id join d2 on f.d2 = d2.id")
+		tk.MustQuery("show warnings").Check(testkit.Rows(
+			"Warning 1815 Optimizer Hint /*+ LEADING(d1, f) */ is inapplicable, check whether the tables are in the same join group and the outer joins are kept in order"))
+		tk.MustExec("explain select /*+ leading(f), hash_join(f, d2) */ * from f join d2 on f.d2 = d2.id join d3 on f.d3 = d3.id")
+		tk.MustQuery("show warnings").Check(testkit.Rows(
+			"Warning 1815 Optimizer Hint /*+ LEADING(f) */ is inapplicable, check whether the tables are in the same join group and the outer joins are kept in order"))
+		tk.MustExec("explain select /*+ leading(f, d2) */ * from f straight_join d2 on f.d2 = d2.id")
+		tk.MustQuery("show warnings").Check(testkit.Rows(
+			"Warning 1815 Optimizer Hint /*+ LEADING(f, d2) */ is inapplicable, check whether the tables are in the same join group and the outer joins are kept in order"))
+	}
+
+	tk.MustExec("explain select /*+ leading(t9) */ * from f join d2 on f.d2 = d2.id")
+	tk.MustQuery("show warnings").Check(testkit.Rows(
+		"Warning 1815 There are no matching table names for (t9) in optimizer hint /*+ LEADING(t9) */. Maybe you can use the table alias name",
+		"Warning 1815 Optimizer Hint /*+ LEADING(t9) */ is inapplicable, check whether the tables are in the same join group and the outer joins are kept in order"))
+	tk.MustExec("explain select /*+ leading(f, d2), leading(d2) */ * from f join d2 on f.d2 = d2.id")
+	tk.MustQuery("show warnings").Check(testkit.Rows(
+		"Warning 1815 Optimizer Hint /*+ LEADING(d2) */ is inapplicable, only one LEADING hint takes effect in a query block"))
+	tk.MustExec("explain select /*+ leading() */ * from f join d2 on f.d2 = d2.id")
+	tk.MustQuery("show warnings").Check(testkit.Rows(
+		"Warning 1815 Hint leading() is inapplicable. Please specify the table names in the arguments."))
+}
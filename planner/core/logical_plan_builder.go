@@ -35,6 +35,7 @@ import (
 	"github.com/pingcap/tidb/metrics"
 	"github.com/pingcap/tidb/parser"
 	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/charset"
 	"github.com/pingcap/tidb/parser/format"
 	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
@@ -50,6 +51,7 @@ import (
 	"github.com/pingcap/tidb/table/tables"
 	"github.com/pingcap/tidb/table/temptable"
 	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/types/json"
 	driver "github.com/pingcap/tidb/types/parser_driver"
 	util2 "github.com/pingcap/tidb/util"
 	"github.com/pingcap/tidb/util/chunk"
@@ -111,6 +113,8 @@ const (
 	HintIgnorePlanCache = "ignore_plan_cache"
 	// HintLimitToCop is a hint enforce pushing limit or topn to coprocessor.
 	HintLimitToCop = "limit_to_cop"
+	// HintLeading specifies the set of tables to be used as the prefix in the join order.
+	HintLeading = "leading"
 )
 
 const (
@@ -339,6 +343,10 @@ func (b *PlanBuilder) buildResultSetNode(ctx context.Context, node ast.ResultSet
 		case *ast.TableName:
 			p, err = b.buildDataSource(ctx, v, &x.AsName)
 			isTableName = true
+		case *ast.JSONTable:
+			p, err = b.buildJSONTable(ctx, v, x.AsName)
+			// JSON_TABLE is not a select block either.
+			isTableName = true
 		default:
 			err = ErrUnsupportedType.GenWithStackByArgs(v)
 		}
@@ -378,6 +386,119 @@ func (b *PlanBuilder) buildResultSetNode(ctx context.Context, node ast.ResultSet
 	}
 }
 
+// buildJSONTable builds LogicalJSONTable for the JSON_TABLE table function.
+// The columns of the tables on the left side of the JSON_TABLE can be referenced in its expression as correlated
+// columns, see buildJoin.
+func (b *PlanBuilder) buildJSONTable(ctx context.Context, jt *ast.JSONTable, asName model.CIStr) (LogicalPlan, error) {
+	// The expression can only reference the outer columns, so we rewrite it upon a plan without any column.
+	mockPlan := LogicalTableDual{RowCount: 1}.Init(b.ctx, b.getSelectOffset())
+	mockPlan.SetSchema(expression.NewSchema())
+	expr, np, err := b.rewrite(ctx, jt.Expr, mockPlan, nil, true)
+	if err != nil {
+		return nil, err
+	}
+	if np != mockPlan {
+		return nil, errors.New("JSON_TABLE doesn't support subqueries in its expression yet")
+	}
+	if expr.GetType().EvalType() != types.ETJson {
+		expr = expression.BuildCastFunction(b.ctx, expr, types.NewFieldType(mysql.TypeJSON))
+	}
+
+	p := LogicalJSONTable{Expr: expr}.Init(b.ctx, b.getSelectOffset())
+	schema := expression.NewSchema()
+	names := make(types.NameSlice, 0, len(jt.Columns))
+	p.RootPath, err = b.buildJSONTableNestedPath(jt.Path, jt.Columns, asName, schema, &names)
+	if err != nil {
+		return nil, err
+	}
+	p.SetSchema(schema)
+	p.names = names
+	b.handleHelper.pushMap(nil)
+	return p, nil
+}
+
+func (b *PlanBuilder) buildJSONTableNestedPath(path string, cols []*ast.JSONTableColumn, asName model.CIStr,
+	schema *expression.Schema, names *types.NameSlice) (*JSONTableNestedPath, error) {
+	pathExpr, err := json.ParseJSONPathExpr(path)
+	if err != nil {
+		return nil, err
+	}
+	nestedPath := &JSONTableNestedPath{Path: pathExpr}
+	for _, col := range cols {
+		if col.Tp == ast.JSONTableColumnNested {
+			child, err := b.buildJSONTableNestedPath(col.Path, col.Columns, asName, schema, names)
+			if err != nil {
+				return nil, err
+			}
+			nestedPath.NestedPaths = append(nestedPath.NestedPaths, child)
+			continue
+		}
+		column, tp, err := b.buildJSONTableColumn(col, schema.Len())
+		if err != nil {
+			return nil, err
+		}
+		nestedPath.Columns = append(nestedPath.Columns, column)
+		schema.Append(&expression.Column{
+			UniqueID: b.ctx.GetSessionVars().AllocPlanColumnID(),
+			RetType:  tp,
+		})
+		*names = append(*names, &types.FieldName{TblName: asName, ColName: col.Name, OrigColName: col.Name})
+	}
+	return nestedPath, nil
+}
+
+func (b *PlanBuilder) buildJSONTableColumn(col *ast.JSONTableColumn, offset int) (*JSONTableColumn, *types.FieldType, error) {
+	column := &JSONTableColumn{Tp: col.Tp, Name: col.Name, Offset: offset, OnEmpty: col.OnEmpty, OnError: col.OnError}
+	if col.Tp == ast.JSONTableColumnOrdinality {
+		tp := types.NewFieldType(mysql.TypeLong)
+		tp.Flag |= mysql.UnsignedFlag | mysql.NotNullFlag
+		tp.Flen, tp.Decimal = mysql.GetDefaultFieldLengthAndDecimal(mysql.TypeLong)
+		tp.Flen--
+		types.SetBinChsClnFlag(tp)
+		return column, tp, nil
+	}
+
+	tp := col.FieldType.Clone()
+	if tp.EvalType() == types.ETString && tp.Charset == "" {
+		tp.Charset, tp.Collate = mysql.DefaultCharset, mysql.DefaultCollationName
+	} else if tp.EvalType() != types.ETString {
+		tp.Charset, tp.Collate = charset.CharsetBin, charset.CollationBin
+	}
+	if tp.Collate == "" {
+		collation, err := charset.GetDefaultCollation(tp.Charset)
+		if err != nil {
+			return nil, nil, err
+		}
+		tp.Collate = collation
+	}
+	defaultFlen, defaultDecimal := mysql.GetDefaultFieldLengthAndDecimal(tp.Tp)
+	if tp.Flen == types.UnspecifiedLength {
+		tp.Flen = defaultFlen
+	}
+	if tp.Decimal == types.UnspecifiedLength {
+		tp.Decimal = defaultDecimal
+	}
+	var err error
+	column.Path, err = json.ParseJSONPathExpr(col.Path)
+	if err != nil {
+		return nil, nil, err
+	}
+	sc := b.ctx.GetSessionVars().StmtCtx
+	if col.OnEmpty != nil && col.OnEmpty.Tp == ast.JSONTableOnResponseDefault {
+		d := types.NewStringDatum(col.OnEmpty.Default)
+		if column.DefaultOnEmpty, err = d.ConvertTo(sc, tp); err != nil {
+			return nil, nil, err
+		}
+	}
+	if col.OnError != nil && col.OnError.Tp == ast.JSONTableOnResponseDefault {
+		d := types.NewStringDatum(col.OnError.Default)
+		if column.DefaultOnError, err = d.ConvertTo(sc, tp); err != nil {
+			return nil, nil, err
+		}
+	}
+	return column, tp, nil
+}
+
 // pushDownConstExpr checks if the condition is from filter condition, if true, push it down to both
 // children of join, whatever the join type is; if false, push it down to inner child of outer join,
 // and both children of non-outer-join.
@@ -593,8 +714,14 @@ func (p *LogicalJoin) setPreferredJoinType(hintInfo *tableHintInfo) {
 		p.ctx.GetSessionVars().StmtCtx.AppendWarning(warning)
 		p.preferJoinType = 0
 	}
+	if len(hintInfo.leadingJoinOrder) > 0 {
+		// The LEADING hint is applied by the join reorder rule, here we only check
+		// whether the hinted tables exist.
+		hintInfo.matchTableName([]*hintTableInfo{lhsAlias, rhsAlias}, hintInfo.leadingJoinOrder)
+		p.preferJoinOrder = true
+	}
 	// set hintInfo for further usage if this hint info can be used.
-	if p.preferJoinType != 0 {
+	if p.preferJoinType != 0 || p.preferJoinOrder {
 		p.hintInfo = hintInfo
 	}
 }
@@ -683,7 +810,7 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (Logica
 		return nil, err
 	}
 
-	rightPlan, err := b.buildResultSetNode(ctx, joinNode.Right)
+	rightPlan, isLateral, err := b.buildJoinRightSide(ctx, joinNode, leftPlan)
 	if err != nil {
 		return nil, err
 	}
@@ -697,7 +824,17 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (Logica
 	handleMap2 := b.handleHelper.popMap()
 	b.handleHelper.mergeAndPush(handleMap1, handleMap2)
 
-	joinPlan := LogicalJoin{StraightJoin: joinNode.StraightJoin || b.inStraightJoin}.Init(b.ctx, b.getSelectOffset())
+	var joinPlan *LogicalJoin
+	if isLateral {
+		// The right side references the columns of the left side, so it has to be evaluated for every row of the
+		// left side by an Apply.
+		b.optFlag = b.optFlag | flagBuildKeyInfo | flagDecorrelate
+		setIsInApplyForCTE(rightPlan)
+		ap := LogicalApply{LogicalJoin: LogicalJoin{StraightJoin: joinNode.StraightJoin || b.inStraightJoin}}.Init(b.ctx, b.getSelectOffset())
+		joinPlan = &ap.LogicalJoin
+	} else {
+		joinPlan = LogicalJoin{StraightJoin: joinNode.StraightJoin || b.inStraightJoin}.Init(b.ctx, b.getSelectOffset())
+	}
 	joinPlan.SetChildren(leftPlan, rightPlan)
 	joinPlan.SetSchema(expression.MergeSchema(leftPlan.Schema(), rightPlan.Schema()))
 	joinPlan.names = make([]*types.FieldName, leftPlan.Schema().Len()+rightPlan.Schema().Len())
@@ -799,7 +936,7 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (Logica
 		// possible decorrelate optimizations. The ON clause is actually treated as a WHERE clause now.
 		if joinPlan.JoinType == InnerJoin {
 			sel := LogicalSelection{Conditions: onCondition}.Init(b.ctx, b.getSelectOffset())
-			sel.SetChildren(joinPlan)
+			sel.SetChildren(joinPlan.self)
 			return sel, nil
 		}
 		joinPlan.AttachOnConds(onCondition)
@@ -809,7 +946,37 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (Logica
 		joinPlan.cartesianJoin = true
 	}
 
-	return joinPlan, nil
+	return joinPlan.self, nil
+}
+
+// buildJoinRightSide builds the right side of the join. If the right side is a JSON_TABLE, it can reference the
+// columns of the left side like a LATERAL derived table, and isLateral reports whether it does.
+func (b *PlanBuilder) buildJoinRightSide(ctx context.Context, joinNode *ast.Join, leftPlan LogicalPlan) (rightPlan LogicalPlan, isLateral bool, err error) {
+	ts, ok := joinNode.Right.(*ast.TableSource)
+	if !ok {
+		rightPlan, err = b.buildResultSetNode(ctx, joinNode.Right)
+		return rightPlan, false, err
+	}
+	if _, ok = ts.Source.(*ast.JSONTable); !ok {
+		rightPlan, err = b.buildResultSetNode(ctx, joinNode.Right)
+		return rightPlan, false, err
+	}
+
+	b.outerSchemas = append(b.outerSchemas, leftPlan.Schema().Clone())
+	b.outerNames = append(b.outerNames, leftPlan.OutputNames())
+	rightPlan, err = b.buildResultSetNode(ctx, joinNode.Right)
+	b.outerSchemas = b.outerSchemas[0 : len(b.outerSchemas)-1]
+	b.outerNames = b.outerNames[0 : len(b.outerNames)-1]
+	if err != nil {
+		return nil, false, err
+	}
+	if len(extractCorColumnsBySchema4LogicalPlan(rightPlan, leftPlan.Schema())) == 0 {
+		return rightPlan, false, nil
+	}
+	if joinNode.Tp == ast.RightJoin {
+		return nil, false, ErrTableFunctionForbiddenJoinType.GenWithStackByArgs(ts.AsName.O)
+	}
+	return rightPlan, true, nil
 }
 
 // buildUsingClause eliminate the redundant columns and ordering columns based
@@ -3305,12 +3472,13 @@ func (b *PlanBuilder) pushTableHints(hints []*ast.TableOptimizerHint, currentLev
 		aggHints                                                                                              aggHintInfo
 		timeRangeHint                                                                                         ast.HintTimeRange
 		limitHints                                                                                            limitHintInfo
+		leadingJoinOrder                                                                                      []hintTableInfo
 	)
 	for _, hint := range hints {
 		// Set warning for the hint that requires the table name.
 		switch hint.HintName.L {
 		case TiDBMergeJoin, HintSMJ, TiDBIndexNestedLoopJoin, HintINLJ, HintINLHJ, HintINLMJ,
-			TiDBHashJoin, HintHJ, HintUseIndex, HintIgnoreIndex, HintForceIndex, HintIndexMerge:
+			TiDBHashJoin, HintHJ, HintUseIndex, HintIgnoreIndex, HintForceIndex, HintIndexMerge, HintLeading:
 			if len(hint.Tables) == 0 {
 				b.pushHintWithoutTableWarning(hint)
 				continue
@@ -3409,6 +3577,14 @@ func (b *PlanBuilder) pushTableHints(hints []*ast.TableOptimizerHint, currentLev
 			timeRangeHint = hint.HintData.(ast.HintTimeRange)
 		case HintLimitToCop:
 			limitHints.preferLimitToCop = true
+		case HintLeading:
+			if leadingJoinOrder != nil {
+				b.ctx.GetSessionVars().StmtCtx.AppendWarning(ErrInternal.GenWithStack(
+					"Optimizer Hint %s is inapplicable, only one LEADING hint takes effect in a query block",
+					restore2JoinHint(HintLeading, tableNames2HintTableInfo(b.ctx, hint.HintName.L, hint.Tables, b.hintProcessor, currentLevel))))
+				continue
+			}
+			leadingJoinOrder = tableNames2HintTableInfo(b.ctx, hint.HintName.L, hint.Tables, b.hintProcessor, currentLevel)
 		default:
 			// ignore hints that not implemented
 		}
@@ -3426,6 +3602,7 @@ func (b *PlanBuilder) pushTableHints(hints []*ast.TableOptimizerHint, currentLev
 		indexMergeHintList:          indexMergeHintList,
 		timeRangeHint:               timeRangeHint,
 		limitHints:                  limitHints,
+		leadingJoinOrder:            leadingJoinOrder,
 	})
 }
 
@@ -3447,6 +3624,7 @@ func (b *PlanBuilder) popTableHints() {
 	b.appendUnmatchedJoinHintWarning(HintBCJ, TiDBBroadCastJoin, hintInfo.broadcastJoinTables)
 	b.appendUnmatchedJoinHintWarning(HintBCJPreferLocal, "", hintInfo.broadcastJoinPreferredLocal)
 	b.appendUnmatchedJoinHintWarning(HintHJ, TiDBHashJoin, hintInfo.hashJoinTables)
+	b.appendUnmatchedJoinHintWarning(HintLeading, "", hintInfo.leadingJoinOrder)
 	b.appendUnmatchedStorageHintWarning(hintInfo.tiflashTables, hintInfo.tikvTables)
 	b.tableHintInfo = b.tableHintInfo[:len(b.tableHintInfo)-1]
 }
@@ -4013,11 +4191,13 @@ func (b *PlanBuilder) buildDataSource(ctx context.Context, tn *ast.TableName, as
 		if len(tn.PartitionNames) > 0 {
 			pids := make(map[int64]struct{}, len(tn.PartitionNames))
 			for _, name := range tn.PartitionNames {
-				pid, err := tables.FindPartitionByName(tableInfo, name.L)
+				partIDs, err := tables.FindPartitionIDsByName(tableInfo, name.L)
 				if err != nil {
 					return nil, err
 				}
-				pids[pid] = struct{}{}
+				for _, pid := range partIDs {
+					pids[pid] = struct{}{}
+				}
 			}
 			pt = tables.NewPartitionTableWithGivenSets(pt, pids)
 		}
@@ -4128,7 +4308,7 @@ func (b *PlanBuilder) buildDataSource(ctx context.Context, tn *ast.TableName, as
 		indexMergeHints:     indexMergeHints,
 		possibleAccessPaths: possiblePaths,
 		Columns:             make([]*model.ColumnInfo, 0, len(columns)),
-		partitionNames:      tn.PartitionNames,
+		partitionNames:      expandSubPartitionNames(tableInfo.GetPartitionInfo(), tn.PartitionNames),
 		TblCols:             make([]*expression.Column, 0, len(columns)),
 		preferPartitions:    make(map[int][]model.CIStr),
 		is:                  b.is,
@@ -4803,9 +4983,13 @@ func (b *PlanBuilder) buildUpdate(ctx context.Context, update *ast.UpdateStmt) (
 		tblID2table[id], _ = b.is.TableByID(id)
 	}
 	updt.TblColPosInfos, err = buildColumns2Handle(updt.OutputNames(), tblID2Handle, tblID2table, true)
+	if err != nil {
+		return nil, err
+	}
 	updt.PartitionedTable = b.partitionedTable
 	updt.tblID2Table = tblID2table
-	return updt, err
+	updt.buildOnUpdateFKTriggers(b.ctx, b.is, tblID2table)
+	return updt, nil
 }
 
 type tblUpdateInfo struct {
@@ -5251,7 +5435,11 @@ func (b *PlanBuilder) buildDelete(ctx context.Context, ds *ast.DeleteStmt) (Plan
 		tblID2table[id], _ = b.is.TableByID(id)
 	}
 	del.TblColPosInfos, err = buildColumns2Handle(del.names, tblID2Handle, tblID2table, false)
-	return del, err
+	if err != nil {
+		return nil, err
+	}
+	del.buildOnDeleteFKTriggers(b.ctx, b.is, tblID2table)
+	return del, nil
 }
 
 func resolveIndicesForTblID2Handle(tblID2Handle map[int64][]HandleCols, schema *expression.Schema) (map[int64][]HandleCols, error) {
@@ -5444,7 +5632,7 @@ func (b *PlanBuilder) buildByItemsForWindow(
 }
 
 // buildWindowFunctionFrameBound builds the bounds of window function frames.
-// For type `Rows`, the bound expr must be an unsigned integer.
+// For type `Rows` and `Groups`, the bound expr must be an unsigned integer.
 // For type `Range`, the bound expr must be temporal or numeric types.
 func (b *PlanBuilder) buildWindowFunctionFrameBound(ctx context.Context, spec *ast.WindowSpec, orderByItems []property.SortItem, boundClause *ast.FrameBound) (*FrameBound, error) {
 	frameType := spec.Frame.Type
@@ -5453,7 +5641,7 @@ func (b *PlanBuilder) buildWindowFunctionFrameBound(ctx context.Context, spec *a
 		return bound, nil
 	}
 
-	if frameType == ast.Rows {
+	if frameType == ast.Rows || frameType == ast.Groups {
 		if bound.Type == ast.CurrentRow {
 			return bound, nil
 		}
@@ -5573,9 +5761,6 @@ func (b *PlanBuilder) buildWindowFunctionFrame(ctx context.Context, spec *ast.Wi
 
 func (b *PlanBuilder) checkWindowFuncArgs(ctx context.Context, p LogicalPlan, windowFuncExprs []*ast.WindowFuncExpr, windowAggMap map[*ast.AggregateFuncExpr]int) error {
 	for _, windowFuncExpr := range windowFuncExprs {
-		if strings.ToLower(windowFuncExpr.F) == ast.AggFuncGroupConcat {
-			return ErrNotSupportedYet.GenWithStackByArgs("group_concat as window function")
-		}
 		args, err := b.buildArgs4WindowFunc(ctx, p, windowFuncExpr.Args, windowAggMap)
 		if err != nil {
 			return err
@@ -5657,6 +5842,12 @@ func (b *PlanBuilder) buildWindowFunctions(ctx context.Context, p LogicalPlan, g
 		spec, funcs := window.spec, window.funcs
 		for _, windowFunc := range funcs {
 			args = append(args, windowFunc.Args...)
+			// The order by items of GROUP_CONCAT are built into the projection along with the args.
+			orderExprs, err := b.resolveWindowFuncOrderBy(windowFunc)
+			if err != nil {
+				return nil, nil, err
+			}
+			args = append(args, orderExprs...)
 		}
 		np, partitionBy, orderBy, args, err := b.buildProjectionForWindow(ctx, p, spec, args, aggMap)
 		if err != nil {
@@ -5699,6 +5890,15 @@ func (b *PlanBuilder) buildWindowFunctions(ctx context.Context, p LogicalPlan, g
 				return nil, nil, ErrWrongArguments.GenWithStackByArgs(strings.ToLower(windowFunc.F))
 			}
 			preArgs += len(windowFunc.Args)
+			desc.HasDistinct = windowFunc.Distinct
+			desc.IgnoreNull = windowFunc.IgnoreNull
+			desc.FromLast = windowFunc.FromLast
+			if windowFunc.Order != nil {
+				for _, byItem := range windowFunc.Order.Items {
+					desc.OrderByItems = append(desc.OrderByItems, &util.ByItems{Expr: args[preArgs], Desc: byItem.Desc})
+					preArgs++
+				}
+			}
 			desc.WrapCastForAggArgs(b.ctx)
 			descs = append(descs, desc)
 			windowMap[windowFunc] = schema.Len()
@@ -5716,19 +5916,33 @@ func (b *PlanBuilder) buildWindowFunctions(ctx context.Context, p LogicalPlan, g
 	return p, windowMap, nil
 }
 
+// resolveWindowFuncOrderBy resolves the position expressions in the order by clause of GROUP_CONCAT,
+// the returned expressions are in the same order as the order by items.
+func (b *PlanBuilder) resolveWindowFuncOrderBy(windowFunc *ast.WindowFuncExpr) ([]ast.ExprNode, error) {
+	if windowFunc.Order == nil {
+		return nil, nil
+	}
+	resolver := &aggOrderByResolver{
+		ctx:  b.ctx,
+		args: windowFunc.Args[:len(windowFunc.Args)-1], // the last argument is SEPARATOR, remove it.
+	}
+	exprs := make([]ast.ExprNode, 0, len(windowFunc.Order.Items))
+	for _, byItem := range windowFunc.Order.Items {
+		resolver.exprDepth = 0
+		resolver.err = nil
+		retExpr, _ := byItem.Expr.Accept(resolver)
+		if resolver.err != nil {
+			return nil, errors.Trace(resolver.err)
+		}
+		exprs = append(exprs, retExpr.(ast.ExprNode))
+	}
+	return exprs, nil
+}
+
 // checkOriginWindowFuncs checks the validity for original window specifications for a group of functions.
 // Because the grouped specification is different from them, we should especially check them before build window frame.
 func (b *PlanBuilder) checkOriginWindowFuncs(funcs []*ast.WindowFuncExpr, orderByItems []property.SortItem) error {
 	for _, f := range funcs {
-		if f.IgnoreNull {
-			return ErrNotSupportedYet.GenWithStackByArgs("IGNORE NULLS")
-		}
-		if f.Distinct {
-			return ErrNotSupportedYet.GenWithStackByArgs("<window function>(DISTINCT ..)")
-		}
-		if f.FromLast {
-			return ErrNotSupportedYet.GenWithStackByArgs("FROM LAST")
-		}
 		spec := &f.Spec
 		if f.Spec.Name.L != "" {
 			spec = b.windowSpecs[f.Spec.Name.L]
@@ -5745,8 +5959,9 @@ func (b *PlanBuilder) checkOriginWindowSpec(spec *ast.WindowSpec, orderByItems [
 	if spec.Frame == nil {
 		return nil
 	}
-	if spec.Frame.Type == ast.Groups {
-		return ErrNotSupportedYet.GenWithStackByArgs("GROUPS")
+	// The peer groups of GROUPS frames are defined by the order by items.
+	if spec.Frame.Type == ast.Groups && len(orderByItems) == 0 {
+		return ErrWindowRangeFrameOrderType.GenWithStackByArgs(getWindowName(spec.Name.O))
 	}
 	start, end := spec.Frame.Extent.Start, spec.Frame.Extent.End
 	if start.Type == ast.Following && start.UnBounded {
@@ -5779,7 +5994,7 @@ func (b *PlanBuilder) checkOriginWindowFrameBound(bound *ast.FrameBound, spec *a
 	}
 
 	frameType := spec.Frame.Type
-	if frameType == ast.Rows {
+	if frameType == ast.Rows || frameType == ast.Groups {
 		if bound.Unit != ast.TimeUnitInvalid {
 			return ErrWindowRowsIntervalUse.GenWithStackByArgs(getWindowName(spec.Name.O))
 		}
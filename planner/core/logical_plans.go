@@ -31,6 +31,7 @@ import (
 	"github.com/pingcap/tidb/statistics"
 	"github.com/pingcap/tidb/table"
 	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/types/json"
 	"github.com/pingcap/tidb/util/logutil"
 	"github.com/pingcap/tidb/util/ranger"
 	"go.uber.org/zap"
@@ -44,6 +45,7 @@ var (
 	_ LogicalPlan = &LogicalApply{}
 	_ LogicalPlan = &LogicalMaxOneRow{}
 	_ LogicalPlan = &LogicalTableDual{}
+	_ LogicalPlan = &LogicalJSONTable{}
 	_ LogicalPlan = &DataSource{}
 	_ LogicalPlan = &TiKVSingleGather{}
 	_ LogicalPlan = &LogicalTableScan{}
@@ -136,8 +138,9 @@ type LogicalJoin struct {
 	StraightJoin  bool
 
 	// hintInfo stores the join algorithm hint information specified by client.
-	hintInfo       *tableHintInfo
-	preferJoinType uint
+	hintInfo        *tableHintInfo
+	preferJoinType  uint
+	preferJoinOrder bool
 
 	EqualConditions []*expression.ScalarFunction
 	LeftConditions  expression.CNFExprs
@@ -502,6 +505,43 @@ type LogicalTableDual struct {
 	RowCount int
 }
 
+// JSONTableColumn is a column of JSON_TABLE which takes its value from the row path it belongs to.
+type JSONTableColumn struct {
+	Tp   ast.JSONTableColumnType
+	Name model.CIStr
+	// Offset is the offset of the column in the schema of JSON_TABLE.
+	Offset int
+	// Path is the path to extract the value from the current row path, it's unused for ordinality columns.
+	Path    json.PathExpression
+	OnEmpty *ast.JSONTableOnResponse
+	OnError *ast.JSONTableOnResponse
+	// DefaultOnEmpty and DefaultOnError are the converted default values of the `DEFAULT ... ON EMPTY|ERROR` clauses.
+	DefaultOnEmpty types.Datum
+	DefaultOnError types.Datum
+}
+
+// JSONTableNestedPath is a row path of JSON_TABLE. Each value matched by the path produces at least one row,
+// and the rows of the nested paths are joined with it.
+type JSONTableNestedPath struct {
+	Path        json.PathExpression
+	Columns     []*JSONTableColumn
+	NestedPaths []*JSONTableNestedPath
+}
+
+// LogicalJSONTable represents the JSON_TABLE table function, it turns the JSON document evaluated by Expr into rows.
+type LogicalJSONTable struct {
+	logicalSchemaProducer
+
+	// Expr is the JSON document, it can only reference the correlated columns from the left side of a join.
+	Expr     expression.Expression
+	RootPath *JSONTableNestedPath
+}
+
+// ExtractCorrelatedCols implements LogicalPlan interface.
+func (p *LogicalJSONTable) ExtractCorrelatedCols() []*expression.CorrelatedColumn {
+	return expression.ExtractCorColumns(p.Expr)
+}
+
 // LogicalMemTable represents a memory table or virtual table
 // Some memory tables wants to take the ownership of some predications
 // e.g
@@ -715,7 +755,7 @@ func (ds *DataSource) Convert2Gathers() (gathers []LogicalPlan) {
 	tg := ds.buildTableGather()
 	gathers = append(gathers, tg)
 	for _, path := range ds.possibleAccessPaths {
-		if !path.IsIntHandlePath {
+		if !path.IsIntHandlePath && !path.Index.MVIndex && !path.Index.FullText && !path.Index.Spatial {
 			path.FullIdxCols, path.FullIdxColLens = expression.IndexInfo2Cols(ds.Columns, ds.schema.Columns, path.Index)
 			path.IdxCols, path.IdxColLens = expression.IndexInfo2PrefixCols(ds.Columns, ds.schema.Columns, path.Index)
 			// If index columns can cover all of the needed columns, we can use a IndexGather + IndexScan.
@@ -907,7 +947,11 @@ func (ds *DataSource) fillIndexPath(path *util.AccessPath, conds []expression.Ex
 				path.ConstCols[i] = res.ColumnValues[i] != nil
 			}
 		}
-		path.CountAfterAccess, err = ds.tableStats.HistColl.GetRowCountByIndexRanges(ds.ctx, path.Index.ID, path.Ranges)
+		if path.Index.Tp == model.IndexTypeHypo {
+			path.CountAfterAccess, err = ds.getHypoIndexRowCount(path.AccessConds)
+		} else {
+			path.CountAfterAccess, err = ds.tableStats.HistColl.GetRowCountByIndexRanges(ds.ctx, path.Index.ID, path.Ranges)
+		}
 		if err != nil {
 			return err
 		}
@@ -917,6 +961,19 @@ func (ds *DataSource) fillIndexPath(path *util.AccessPath, conds []expression.Ex
 	return nil
 }
 
+// getHypoIndexRowCount estimates the row count of the access conditions of a hypothetical index. A hypothetical
+// index has no statistics, so the row count is derived from the statistics of its columns.
+func (ds *DataSource) getHypoIndexRowCount(accessConds []expression.Expression) (float64, error) {
+	if len(accessConds) == 0 {
+		return float64(ds.statisticTable.Count), nil
+	}
+	selectivity, _, err := ds.tableStats.HistColl.Selectivity(ds.ctx, accessConds, nil)
+	if err != nil {
+		return 0, err
+	}
+	return selectivity * float64(ds.statisticTable.Count), nil
+}
+
 // deriveIndexPathStats will fulfill the information that the AccessPath need.
 // conds is the conditions used to generate the DetachRangeResult for path.
 // isIm indicates whether this function is called to generate the partial path for IndexMerge.
@@ -1167,6 +1224,9 @@ func (p *LogicalWindow) ExtractCorrelatedCols() []*expression.CorrelatedColumn {
 		for _, arg := range windowFunc.Args {
 			corCols = append(corCols, expression.ExtractCorColumns(arg)...)
 		}
+		for _, by := range windowFunc.OrderByItems {
+			corCols = append(corCols, expression.ExtractCorColumns(by.Expr)...)
+		}
 	}
 	if p.Frame != nil {
 		if p.Frame.Start != nil {
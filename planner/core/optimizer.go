@@ -164,6 +164,21 @@ func CheckPrivilege(activeRoles []*auth.RoleIdentity, pm privilege.Manager, vs [
 	return nil
 }
 
+// CheckPrivilegeWithUser checks the privilege for the specified user rather than the current one,
+// it's used to execute the statements of a trigger with the privileges of its definer.
+func CheckPrivilegeWithUser(pm privilege.Manager, user *auth.UserIdentity, vs []visitInfo) error {
+	for _, v := range vs {
+		if v.privilege == mysql.ExtendedPriv {
+			if !pm.RequestDynamicVerificationWithUser(v.dynamicPriv, v.dynamicWithGrant, user) {
+				return ErrPrivilegeCheckFail.GenWithStackByArgs(v.dynamicPriv)
+			}
+		} else if !pm.RequestVerificationWithUser(v.db, v.table, v.column, v.privilege, user) {
+			return ErrPrivilegeCheckFail.GenWithStackByArgs(v.privilege.String())
+		}
+	}
+	return nil
+}
+
 // VisitInfo4PrivCheck generates privilege check infos because privilege check of local temporary tables is different
 // with normal tables. `CREATE` statement needs `CREATE TEMPORARY TABLE` privilege from the database, and subsequent
 // statements do not need any privileges.
@@ -19,6 +19,7 @@ import (
 	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/table/tables"
 	"github.com/pingcap/tidb/types"
 )
 
@@ -35,18 +36,79 @@ func PartitionPruning(ctx sessionctx.Context, tbl table.PartitionedTable, conds
 	for i, cond := range conds {
 		conds[i] = expression.PushDownNot(ctx, cond)
 	}
+	pe, err := tbl.(partitionTable).PartitionExpr()
+	if err != nil {
+		return nil, err
+	}
+	if pi.IsSubPartitioned() {
+		return s.pruneSubPartitions(ctx, pi, pe, partitionNames, conds, columns, names)
+	}
+	return s.prunePartitionLevel(ctx, pi, pe, partitionNames, conds, columns, names)
+}
+
+// prunePartitionLevel finds the used partitions of a single level of partitioning, pe is the partition expression of pi.
+func (s *partitionProcessor) prunePartitionLevel(ctx sessionctx.Context, pi *model.PartitionInfo, pe *tables.PartitionExpr, partitionNames []model.CIStr,
+	conds []expression.Expression, columns []*expression.Column, names types.NameSlice) ([]int, error) {
 	switch pi.Type {
 	case model.PartitionTypeHash:
-		return s.pruneHashPartition(ctx, tbl, partitionNames, conds, columns, names)
+		return s.pruneHashPartition(ctx, pi, partitionNames, conds, columns, names)
 	case model.PartitionTypeRange:
-		rangeOr, _, err := s.pruneRangePartition(ctx, pi, tbl, conds, columns, names, nil)
+		rangeOr, _, err := s.pruneRangePartition(ctx, pi, pe, conds, columns, names, nil)
 		if err != nil {
 			return nil, err
 		}
 		ret := s.convertToIntSlice(rangeOr, pi, partitionNames)
 		return ret, nil
 	case model.PartitionTypeList:
-		return s.pruneListPartition(ctx, tbl, partitionNames, conds)
+		return s.pruneListPartition(ctx, pi, pe, partitionNames, conds)
+	case model.PartitionTypeKey:
+		return s.findUsedKeyPartitions(ctx, pi, pe, partitionNames, conds, columns)
 	}
 	return []int{FullRange}, nil
 }
+
+// pruneSubPartitions finds the used subpartitions of a subpartitioned table. The first level partitions and the
+// subpartitions are pruned separately, and the used subpartitions of the used first level partitions are returned
+// as the idx in pi.Definitions like PartitionPruning.
+func (s *partitionProcessor) pruneSubPartitions(ctx sessionctx.Context, pi *model.PartitionInfo, pe *tables.PartitionExpr, partitionNames []model.CIStr,
+	conds []expression.Expression, columns []*expression.Column, names types.NameSlice) ([]int, error) {
+	firstLevel, subLevel := pi.FirstLevel(), pi.SubLevel()
+	used, err := s.prunePartitionLevel(ctx, firstLevel, pe, nil, conds, columns, names)
+	if err != nil {
+		return nil, err
+	}
+	subUsed, err := s.prunePartitionLevel(ctx, subLevel, pe.SubPartition, nil, conds, columns, names)
+	if err != nil {
+		return nil, err
+	}
+	isFullRange := func(used []int) bool {
+		return len(used) == 1 && used[0] == FullRange
+	}
+	if isFullRange(used) && isFullRange(subUsed) && len(partitionNames) == 0 {
+		return []int{FullRange}, nil
+	}
+	allIdxs := func(n int) []int {
+		ret := make([]int, n)
+		for i := range ret {
+			ret[i] = i
+		}
+		return ret
+	}
+	if isFullRange(used) {
+		used = allIdxs(len(firstLevel.Definitions))
+	}
+	if isFullRange(subUsed) {
+		subUsed = allIdxs(len(subLevel.Definitions))
+	}
+	ret := make([]int, 0, len(used)*len(subUsed))
+	for _, i := range used {
+		for _, j := range subUsed {
+			idx := i*int(pi.SubNum) + j
+			if len(partitionNames) > 0 && !s.findByName(partitionNames, pi.Definitions[idx].Name.L) {
+				continue
+			}
+			ret = append(ret, idx)
+		}
+	}
+	return ret, nil
+}
@@ -21,6 +21,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/pingcap/tidb/parser/mysql"
 	plannercore "github.com/pingcap/tidb/planner/core"
 	"github.com/pingcap/tidb/sessionctx/variable"
 	"github.com/pingcap/tidb/testkit"
@@ -718,3 +719,95 @@ func TestIssue32007(t *testing.T) {
 	tk.MustExec("set @@tidb_partition_prune_mode='dynamic'")
 	tk.MustQuery("select * from t3 where t3.a <> ALL (select t1.a from t1 partition (p0)) order by t3.a").Sort().Check(testkit.Rows("10 10", "11 11", "12 12", "13 13", "14 14", "15 15", "16 16", "17 17", "18 18", "19 19", "20 20", "21 21", "22 22", "23 23", "5 5", "6 6", "7 7", "8 8", "9 9"))
 }
+
+func TestKeyPartitionPruning(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set @@tidb_partition_prune_mode='dynamic'")
+	tk.MustExec("create table t (a int, b varchar(10), c int, key (c)) partition by key(a, b) partitions 5")
+	tk.MustExec("insert into t values (1, 'a', 1), (2, 'b', 2), (3, 'c', 3), (4, 'd', 4), (5, 'e', 5), (6, 'f', 6), (null, null, 7)")
+	tk.MustQuery("select * from t partition (p0)").Sort().Check(testkit.Rows("3 c 3", "5 e 5"))
+	tk.MustQuery("select * from t partition (p3)").Sort().Check(testkit.Rows("1 a 1"))
+
+	require.Equal(t, "partition:p3", tk.MustQuery("explain format='brief' select * from t where a = 1 and b = 'a'").Rows()[0][3])
+	require.Equal(t, "partition:p1,p3,p4", tk.MustQuery("explain format='brief' select * from t where a in (1, 2) and b in ('a', 'b')").Rows()[0][3])
+	require.Equal(t, "partition:p2", tk.MustQuery("explain format='brief' select * from t where a is null and b is null").Rows()[0][3])
+	require.Equal(t, "partition:all", tk.MustQuery("explain format='brief' select * from t where a = 1").Rows()[0][3])
+	require.Equal(t, "partition:dual", tk.MustQuery("explain format='brief' select * from t partition (p0) where a = 1 and b = 'a'").Rows()[0][3])
+	tk.MustQuery("select * from t where a in (1, 2) and b in ('a', 'b')").Sort().Check(testkit.Rows("1 a 1", "2 b 2"))
+	tk.MustQuery("select * from t where a is null and b is null").Check(testkit.Rows("<nil> <nil> 7"))
+}
+
+func TestMultiColumnRangeColumnsPartitionPruning(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set @@tidb_partition_prune_mode='dynamic'")
+	tk.MustExec(`create table t (a int, b varchar(10), c int) partition by range columns(a, b) (
+		partition p0 values less than (10, 'm'),
+		partition p1 values less than (10, maxvalue),
+		partition p2 values less than (20, 'c'),
+		partition p3 values less than (maxvalue, maxvalue))`)
+	tk.MustExec("insert into t values (1, 'z', 1), (10, 'a', 2), (10, 'n', 3), (10, null, 4), (15, 'a', 5), (20, 'b', 6), (20, 'c', 7), (null, 'a', 8)")
+	tk.MustQuery("select c from t partition (p0)").Sort().Check(testkit.Rows("1", "2", "4", "8"))
+	tk.MustQuery("select c from t partition (p1)").Check(testkit.Rows("3"))
+	tk.MustQuery("select c from t partition (p2)").Sort().Check(testkit.Rows("5", "6"))
+	tk.MustQuery("select c from t partition (p3)").Check(testkit.Rows("7"))
+
+	require.Equal(t, "partition:p0,p1,p2", tk.MustQuery("explain format='brief' select * from t where a = 10").Rows()[0][3])
+	require.Equal(t, "partition:p1", tk.MustQuery("explain format='brief' select * from t where a = 10 and b = 'n'").Rows()[0][3])
+	require.Equal(t, "partition:p2,p3", tk.MustQuery("explain format='brief' select * from t where a > 10").Rows()[0][3])
+	require.Equal(t, "partition:p0", tk.MustQuery("explain format='brief' select * from t where a < 10").Rows()[0][3])
+	require.Equal(t, "partition:p2", tk.MustQuery("explain format='brief' select * from t where a = 20 and b < 'c'").Rows()[0][3])
+	require.Equal(t, "partition:p0", tk.MustQuery("explain format='brief' select * from t where a is null").Rows()[0][3])
+	tk.MustQuery("select c from t where a = 10").Sort().Check(testkit.Rows("2", "3", "4"))
+	tk.MustQuery("select c from t where a > 10").Sort().Check(testkit.Rows("5", "6", "7"))
+	tk.MustQuery("select c from t where a = 20 and b < 'c'").Check(testkit.Rows("6"))
+	tk.MustQuery("select c from t where (a, b) > (10, 'z')").Sort().Check(testkit.Rows("5", "6", "7"))
+
+	tk.MustExec(`create table t2 (a int, b int) partition by range columns(a, b) (
+		partition p0 values less than (10, 10),
+		partition p1 values less than (20, 20))`)
+	tk.MustGetErrCode("insert into t2 values (20, 20)", mysql.ErrNoPartitionForGivenValue)
+	tk.MustExec("insert into t2 values (10, 10), (20, 19)")
+	tk.MustQuery("select * from t2 partition (p1)").Sort().Check(testkit.Rows("10 10", "20 19"))
+}
+
+func TestSubPartitionPruning(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set @@tidb_partition_prune_mode='dynamic'")
+	tk.MustExec(`create table t (a int, b int, c int) partition by range (a) subpartition by hash (b) subpartitions 3 (
+		partition p0 values less than (10),
+		partition p1 values less than (20),
+		partition p2 values less than maxvalue)`)
+	tk.MustExec("insert into t values (1, 1, 1), (2, 2, 2), (3, 3, 3), (11, 1, 4), (12, 2, 5), (21, 4, 6), (null, null, 7)")
+	tk.MustQuery("select c from t partition (p0)").Sort().Check(testkit.Rows("1", "2", "3", "7"))
+	tk.MustQuery("select c from t partition (p0sp1)").Check(testkit.Rows("1"))
+	tk.MustQuery("select c from t partition (p2sp1)").Check(testkit.Rows("6"))
+
+	require.Equal(t, "partition:p1sp1", tk.MustQuery("explain format='brief' select * from t where a = 11 and b = 1").Rows()[0][3])
+	require.Equal(t, "partition:p0sp1,p1sp1,p2sp1", tk.MustQuery("explain format='brief' select * from t where b = 4").Rows()[0][3])
+	require.Equal(t, "partition:p1sp0,p1sp1,p1sp2", tk.MustQuery("explain format='brief' select * from t where a between 10 and 15").Rows()[0][3])
+	require.Equal(t, "partition:p0sp1,p0sp2,p1sp1,p1sp2", tk.MustQuery("explain format='brief' select * from t where a < 20 and b in (1, 2)").Rows()[0][3])
+	require.Equal(t, "partition:p0sp0", tk.MustQuery("explain format='brief' select * from t where a is null and b is null").Rows()[0][3])
+	require.Equal(t, "partition:all", tk.MustQuery("explain format='brief' select * from t where c = 1").Rows()[0][3])
+	require.Equal(t, "partition:dual", tk.MustQuery("explain format='brief' select * from t partition (p0) where a = 11").Rows()[0][3])
+	tk.MustQuery("select c from t where a < 20 and b in (1, 2)").Sort().Check(testkit.Rows("1", "2", "4", "5"))
+	tk.MustQuery("select c from t where b = 4").Check(testkit.Rows("6"))
+	tk.MustQuery("select c from t where a is null").Check(testkit.Rows("7"))
+
+	tk.MustExec("set @@tidb_enable_list_partition = ON")
+	tk.MustExec(`create table t2 (a int, b varchar(10)) partition by list (a) subpartition by key (b) subpartitions 2 (
+		partition p0 values in (1, 2),
+		partition p1 values in (3, 4))`)
+	tk.MustExec("insert into t2 values (1, 'a'), (2, 'b'), (3, 'c'), (4, 'd')")
+	require.Equal(t, "partition:p1sp0,p1sp1", tk.MustQuery("explain format='brief' select * from t2 where a = 3").Rows()[0][3])
+	tk.MustQuery("select * from t2 where a = 3").Check(testkit.Rows("3 c"))
+	tk.MustQuery("select * from t2 where a in (1, 4) and b in ('a', 'd')").Sort().Check(testkit.Rows("1 a", "4 d"))
+}
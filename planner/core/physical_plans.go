@@ -43,6 +43,7 @@ var (
 	_ PhysicalPlan = &PhysicalTopN{}
 	_ PhysicalPlan = &PhysicalMaxOneRow{}
 	_ PhysicalPlan = &PhysicalTableDual{}
+	_ PhysicalPlan = &PhysicalJSONTable{}
 	_ PhysicalPlan = &PhysicalUnionAll{}
 	_ PhysicalPlan = &PhysicalSort{}
 	_ PhysicalPlan = &NominalSort{}
@@ -334,6 +335,9 @@ type PhysicalIndexMergeReader struct {
 	partialPlans []PhysicalPlan
 	// tablePlan is a PhysicalTableScan to get the table tuples. Current, it must be not nil.
 	tablePlan PhysicalPlan
+	// IsIntersectionType means whether it's intersection type or union type.
+	// Intersection type is for expressions connected by `AND` and union type is for `OR`.
+	IsIntersectionType bool
 
 	// Used by partition table.
 	PartitionInfo PartitionInfo
@@ -1265,6 +1269,19 @@ func (p *PhysicalTableDual) SetOutputNames(names types.NameSlice) {
 	p.names = names
 }
 
+// PhysicalJSONTable is the physical operator of JSON_TABLE.
+type PhysicalJSONTable struct {
+	physicalSchemaProducer
+
+	Expr     expression.Expression
+	RootPath *JSONTableNestedPath
+}
+
+// ExtractCorrelatedCols implements PhysicalPlan interface.
+func (p *PhysicalJSONTable) ExtractCorrelatedCols() []*expression.CorrelatedColumn {
+	return expression.ExtractCorColumns(p.Expr)
+}
+
 // PhysicalWindow is the physical operator of window function.
 type PhysicalWindow struct {
 	physicalSchemaProducer
@@ -1282,6 +1299,9 @@ func (p *PhysicalWindow) ExtractCorrelatedCols() []*expression.CorrelatedColumn
 		for _, arg := range windowFunc.Args {
 			corCols = append(corCols, expression.ExtractCorColumns(arg)...)
 		}
+		for _, by := range windowFunc.OrderByItems {
+			corCols = append(corCols, expression.ExtractCorColumns(by.Expr)...)
+		}
 	}
 	if p.Frame != nil {
 		if p.Frame.Start != nil {
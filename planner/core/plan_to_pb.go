@@ -328,6 +328,11 @@ func (p *PhysicalIndexScan) ToPB(ctx sessionctx.Context, _ kv.StoreType) (*tipb.
 			columns = append(columns, model.NewExtraPhysTblIDColInfo())
 		} else if col.ID == model.ExtraPidColID {
 			columns = append(columns, model.NewExtraPartitionIDColInfo())
+		} else if p.Index.Spatial {
+			// The SPATIAL index stores the cell IDs, which are read with the type of the index column.
+			colInfo := FindColumnInfoByID(tableColumns, col.ID).Clone()
+			colInfo.FieldType = *col.RetType
+			columns = append(columns, colInfo)
 		} else {
 			columns = append(columns, FindColumnInfoByID(tableColumns, col.ID))
 		}
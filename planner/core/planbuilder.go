@@ -97,6 +97,7 @@ type tableHintInfo struct {
 	indexMergeHintList          []indexHintInfo
 	timeRangeHint               ast.HintTimeRange
 	limitHints                  limitHintInfo
+	leadingJoinOrder            []hintTableInfo
 }
 
 type limitHintInfo struct {
@@ -182,7 +183,7 @@ func tableNames2HintTableInfo(ctx sessionctx.Context, hintName string, hintTable
 			tableInfo.dbName = defaultDBName
 		}
 		switch hintName {
-		case TiDBMergeJoin, HintSMJ, TiDBIndexNestedLoopJoin, HintINLJ, HintINLHJ, HintINLMJ, TiDBHashJoin, HintHJ:
+		case TiDBMergeJoin, HintSMJ, TiDBIndexNestedLoopJoin, HintINLJ, HintINLHJ, HintINLMJ, TiDBHashJoin, HintHJ, HintLeading:
 			if len(tableInfo.partitions) > 0 {
 				isInapplicable = true
 			}
@@ -706,6 +707,11 @@ func (b *PlanBuilder) Build(ctx context.Context, node ast.Node) (Plan, error) {
 		return b.buildIndexAdvise(x), nil
 	case *ast.PlanReplayerStmt:
 		return b.buildPlanReplayer(x), nil
+	case *ast.XAStmt:
+		if x.Tp == ast.XARecover {
+			return b.buildXARecover(x), nil
+		}
+		return b.buildSimple(ctx, x)
 	case *ast.PrepareStmt:
 		return b.buildPrepare(x), nil
 	case *ast.SelectStmt:
@@ -731,7 +737,7 @@ func (b *PlanBuilder) Build(ctx context.Context, node ast.Node) (Plan, error) {
 		*ast.BeginStmt, *ast.CommitStmt, *ast.RollbackStmt, *ast.CreateUserStmt, *ast.SetPwdStmt, *ast.AlterInstanceStmt,
 		*ast.GrantStmt, *ast.DropUserStmt, *ast.AlterUserStmt, *ast.RevokeStmt, *ast.KillStmt, *ast.DropStatsStmt,
 		*ast.GrantRoleStmt, *ast.RevokeRoleStmt, *ast.SetRoleStmt, *ast.SetDefaultRoleStmt, *ast.ShutdownStmt,
-		*ast.RenameUserStmt:
+		*ast.RenameUserStmt, *ast.CreateEventStmt, *ast.AlterEventStmt, *ast.DropEventStmt:
 		return b.buildSimple(ctx, node.(ast.StmtNode))
 	case ast.DDLNode:
 		return b.buildDDL(ctx, x)
@@ -1103,6 +1109,16 @@ func getPossibleAccessPaths(ctx sessionctx.Context, tableHints *tableHintInfo, i
 			if tblInfo.IsCommonHandle && index.Primary {
 				continue
 			}
+			if index.Tp == model.IndexTypeHypo {
+				// The hypothetical indexes have no data, so they are only considered by EXPLAIN, and the plans
+				// using them can't be cached.
+				if !ctx.GetSessionVars().StmtCtx.InExplainStmt {
+					continue
+				}
+				ctx.GetSessionVars().StmtCtx.SkipPlanCache = true
+				publicPaths = append(publicPaths, &util.AccessPath{Index: index})
+				continue
+			}
 			if check && latestIndexes == nil {
 				latestIndexes, check, err = getLatestIndexInfo(ctx, tblInfo.ID, 0)
 				if err != nil {
@@ -1607,6 +1623,10 @@ func (b *PlanBuilder) buildPhysicalIndexLookUpReaders(ctx context.Context, dbNam
 			// Skip checking clustered index.
 			continue
 		}
+		if idxInfo.MVIndex || idxInfo.FullText || idxInfo.Spatial {
+			// Skip checking multi-valued, FULLTEXT and SPATIAL indexes, their entries don't map to the rows one by one.
+			continue
+		}
 		if idxInfo.State != model.StatePublic {
 			logutil.Logger(ctx).Info("build physical index lookup reader, the index isn't public",
 				zap.String("index", idxInfo.Name.O),
@@ -1840,6 +1860,7 @@ func GetPhysicalIDsAndPartitionNames(tblInfo *model.TableInfo, partitionNames []
 		}
 		return ids, names, nil
 	}
+	partitionNames = expandSubPartitionNames(pi, partitionNames)
 	ids := make([]int64, 0, len(partitionNames))
 	names := make([]string, 0, len(partitionNames))
 	for _, name := range partitionNames {
@@ -2927,7 +2948,7 @@ func (b *PlanBuilder) buildShow(ctx context.Context, show *ast.ShowStmt) (Plan,
 			// Avoid building Selection.
 			show.Pattern = nil
 		}
-	case ast.ShowTableStatus:
+	case ast.ShowTableStatus, ast.ShowTriggers, ast.ShowEvents:
 		if p.DBName == "" {
 			return nil, ErrNoDB
 		}
@@ -3055,6 +3076,15 @@ func (b *PlanBuilder) buildSimple(ctx context.Context, node ast.StmtNode) (Plan,
 	case *ast.RenameUserStmt:
 		err := ErrSpecificAccessDenied.GenWithStackByArgs("CREATE USER")
 		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.CreateUserPriv, "", "", "", err)
+	case *ast.CreateEventStmt:
+		b.visitInfo = b.appendEventVisitInfo(b.visitInfo, raw.EventName.Schema.L)
+	case *ast.AlterEventStmt:
+		b.visitInfo = b.appendEventVisitInfo(b.visitInfo, raw.EventName.Schema.L)
+		if raw.RenameTo != nil {
+			b.visitInfo = b.appendEventVisitInfo(b.visitInfo, raw.RenameTo.Schema.L)
+		}
+	case *ast.DropEventStmt:
+		b.visitInfo = b.appendEventVisitInfo(b.visitInfo, raw.EventName.Schema.L)
 	case *ast.GrantStmt:
 		var err error
 		b.visitInfo, err = collectVisitInfoFromGrantStmt(b.ctx, b.visitInfo, raw)
@@ -3137,6 +3167,15 @@ func (b *PlanBuilder) buildSimple(ctx context.Context, node ast.StmtNode) (Plan,
 	return p, nil
 }
 
+// appendEventVisitInfo appends the EVENT privilege on the schema of an event.
+func (b *PlanBuilder) appendEventVisitInfo(vi []visitInfo, schema string) []visitInfo {
+	var authErr error
+	if user := b.ctx.GetSessionVars().User; user != nil {
+		authErr = ErrDBaccessDenied.GenWithStackByArgs(user.AuthUsername, user.AuthHostname, schema)
+	}
+	return appendVisitInfo(vi, mysql.EventPriv, schema, "", "", authErr)
+}
+
 func collectVisitInfoFromRevokeStmt(sctx sessionctx.Context, vi []visitInfo, stmt *ast.RevokeStmt) ([]visitInfo, error) {
 	// To use REVOKE, you must have the GRANT OPTION privilege,
 	// and you must have the privileges that you are granting.
@@ -3358,11 +3397,13 @@ func (b *PlanBuilder) buildInsert(ctx context.Context, insert *ast.InsertStmt) (
 		givenPartitionSets := make(map[int64]struct{}, len(insert.PartitionNames))
 		// check partition by name.
 		for _, name := range insert.PartitionNames {
-			id, err := tables.FindPartitionByName(tableInfo, name.L)
+			ids, err := tables.FindPartitionIDsByName(tableInfo, name.L)
 			if err != nil {
 				return nil, err
 			}
-			givenPartitionSets[id] = struct{}{}
+			for _, id := range ids {
+				givenPartitionSets[id] = struct{}{}
+			}
 		}
 		pt := tableInPlan.(table.PartitionedTable)
 		insertPlan.Table = tables.NewPartitionTableWithGivenSets(pt, givenPartitionSets)
@@ -3447,6 +3488,7 @@ func (b *PlanBuilder) buildInsert(ctx context.Context, insert *ast.InsertStmt) (
 	if err != nil {
 		return nil, err
 	}
+	insertPlan.buildOnInsertFKTriggers(b.ctx, b.is, tn.DBInfo.Name.O)
 
 	err = insertPlan.ResolveIndices()
 	return insertPlan, err
@@ -3843,6 +3885,10 @@ func (b *PlanBuilder) buildLoadData(ctx context.Context, ld *ast.LoadDataStmt) (
 	if p.OnDuplicate == ast.OnDuplicateKeyHandlingReplace {
 		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.DeletePriv, p.Table.Schema.O, p.Table.Name.O, "", deleteErr)
 	}
+	if !p.IsLocal {
+		// The files of LOAD DATA INFILE are read by the server, which requires the FILE privilege.
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.FilePriv, "", "", "", ErrSpecificAccessDenied.GenWithStackByArgs("FILE"))
+	}
 	tableInfo := p.Table.TableInfo
 	tableInPlan, ok := b.is.TableByID(tableInfo.ID)
 	if !ok {
@@ -4293,6 +4339,42 @@ func (b *PlanBuilder) buildDDL(ctx context.Context, node ast.DDLNode) (Plan, err
 		}
 		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.CreatePriv, v.Name.Schema.L,
 			v.Name.Name.L, "", authErr)
+	case *ast.CreateProcedureStmt:
+		if b.ctx.GetSessionVars().User != nil {
+			authErr = ErrDBaccessDenied.GenWithStackByArgs(b.ctx.GetSessionVars().User.AuthUsername,
+				b.ctx.GetSessionVars().User.AuthHostname, v.ProcedureName.Schema.L)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.CreateRoutinePriv, v.ProcedureName.Schema.L,
+			"", "", authErr)
+	case *ast.DropProcedureStmt:
+		if b.ctx.GetSessionVars().User != nil {
+			authErr = ErrDBaccessDenied.GenWithStackByArgs(b.ctx.GetSessionVars().User.AuthUsername,
+				b.ctx.GetSessionVars().User.AuthHostname, v.ProcedureName.Schema.L)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.AlterRoutinePriv, v.ProcedureName.Schema.L,
+			"", "", authErr)
+	case *ast.CreateTriggerStmt:
+		if b.ctx.GetSessionVars().User != nil {
+			authErr = ErrTableaccessDenied.GenWithStackByArgs("TRIGGER", b.ctx.GetSessionVars().User.AuthUsername,
+				b.ctx.GetSessionVars().User.AuthHostname, v.Table.Name.L)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.TriggerPriv, v.Table.Schema.L,
+			v.Table.Name.L, "", authErr)
+	case *ast.DropTriggerStmt:
+		// The TRIGGER privilege is checked on the table which the trigger belongs to.
+		var tblName string
+		for _, tbl := range b.is.SchemaTables(v.TriggerName.Schema) {
+			if tbl.Meta().FindTrigger(v.TriggerName.Name.L) != nil {
+				tblName = tbl.Meta().Name.L
+				break
+			}
+		}
+		if b.ctx.GetSessionVars().User != nil {
+			authErr = ErrTableaccessDenied.GenWithStackByArgs("TRIGGER", b.ctx.GetSessionVars().User.AuthUsername,
+				b.ctx.GetSessionVars().User.AuthHostname, tblName)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.TriggerPriv, v.TriggerName.Schema.L,
+			tblName, "", authErr)
 	case *ast.DropDatabaseStmt:
 		if b.ctx.GetSessionVars().User != nil {
 			authErr = ErrDBaccessDenied.GenWithStackByArgs(b.ctx.GetSessionVars().User.AuthUsername,
@@ -4498,19 +4580,25 @@ func (b *PlanBuilder) buildExplain(ctx context.Context, explain *ast.ExplainStmt
 }
 
 func (b *PlanBuilder) buildSelectInto(ctx context.Context, sel *ast.SelectStmt) (Plan, error) {
-	if sem.IsEnabled() {
+	// The path of OUTFILE is checked by the executor, which permits only the cloud storages when SEM is enabled.
+	if sem.IsEnabled() && sel.SelectIntoOpt.Tp != ast.SelectIntoOutfile {
 		return nil, ErrNotSupportedWithSem.GenWithStackByArgs("SELECT INTO")
 	}
+	if sel.SelectIntoOpt.Tp == ast.SelectIntoVars {
+		// Assigning variables is done by the session, which runs the SELECT statement without the INTO clause.
+		return nil, ErrNotSupportedYet.GenWithStackByArgs("SELECT ... INTO variables in this context")
+	}
 	selectIntoInfo := sel.SelectIntoOpt
 	sel.SelectIntoOpt = nil
-	targetPlan, _, err := OptimizeAstNode(ctx, b.ctx, sel, b.is)
+	targetPlan, names, err := OptimizeAstNode(ctx, b.ctx, sel, b.is)
 	if err != nil {
 		return nil, err
 	}
 	b.visitInfo = appendVisitInfo(b.visitInfo, mysql.FilePriv, "", "", "", ErrSpecificAccessDenied.GenWithStackByArgs("FILE"))
 	return &SelectInto{
-		TargetPlan: targetPlan,
-		IntoOpt:    selectIntoInfo,
+		TargetPlan:  targetPlan,
+		TargetNames: names,
+		IntoOpt:     selectIntoInfo,
 	}, nil
 }
 
@@ -4759,6 +4847,18 @@ func (b *PlanBuilder) buildPlanReplayer(pc *ast.PlanReplayerStmt) Plan {
 	return p
 }
 
+func (b *PlanBuilder) buildXARecover(xa *ast.XAStmt) Plan {
+	p := &XARecover{ConvertXID: xa.ConvertXID}
+	schema := newColumnsWithNames(4)
+	schema.Append(buildColumnWithName("", "formatID", mysql.TypeLonglong, 20))
+	schema.Append(buildColumnWithName("", "gtrid_length", mysql.TypeLonglong, 20))
+	schema.Append(buildColumnWithName("", "bqual_length", mysql.TypeLonglong, 20))
+	schema.Append(buildColumnWithName("", "data", mysql.TypeVarchar, 128))
+	p.SetSchema(schema.col2Schema())
+	p.names = schema.names
+	return p
+}
+
 func buildChecksumTableSchema() (*expression.Schema, []*types.FieldName) {
 	schema := newColumnsWithNames(5)
 	schema.Append(buildColumnWithName("", "Db_name", mysql.TypeVarchar, 128))
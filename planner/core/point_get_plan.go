@@ -157,6 +157,10 @@ func (p *PointGetPlan) AccessObject(normalized bool) string {
 			buffer.WriteString(", clustered index:")
 			buffer.WriteString(p.IndexInfo.Name.O)
 			buffer.WriteString("(")
+		} else if p.IndexInfo.Tp == model.IndexTypeHypo {
+			buffer.WriteString(", hypo index:")
+			buffer.WriteString(p.IndexInfo.Name.O)
+			buffer.WriteString("(")
 		} else {
 			buffer.WriteString(", index:")
 			buffer.WriteString(p.IndexInfo.Name.O)
@@ -357,6 +361,8 @@ func (p *BatchPointGetPlan) AccessObject(_ bool) string {
 	if p.IndexInfo != nil {
 		if p.IndexInfo.Primary && p.TblInfo.IsCommonHandle {
 			buffer.WriteString(", clustered index:" + p.IndexInfo.Name.O + "(")
+		} else if p.IndexInfo.Tp == model.IndexTypeHypo {
+			buffer.WriteString(", hypo index:" + p.IndexInfo.Name.O + "(")
 		} else {
 			buffer.WriteString(", index:" + p.IndexInfo.Name.O + "(")
 		}
@@ -574,7 +580,10 @@ func newBatchPointGetPlan(
 	stmtCtx := ctx.GetSessionVars().StmtCtx
 	statsInfo := &property.StatsInfo{RowCount: float64(len(patternInExpr.List))}
 	var partitionExpr *tables.PartitionExpr
-	if tbl.GetPartitionInfo() != nil {
+	if pi := tbl.GetPartitionInfo(); pi != nil {
+		if pi.IsSubPartitioned() {
+			return nil
+		}
 		partitionExpr = getPartitionExpr(ctx, tbl)
 		if partitionExpr == nil {
 			return nil
@@ -1450,6 +1459,7 @@ func buildPointUpdatePlan(ctx sessionctx.Context, pointPlan PhysicalPlan, dbName
 	updatePlan.tblID2Table = map[int64]table.Table{
 		tbl.ID: t,
 	}
+	updatePlan.buildOnUpdateFKTriggers(ctx, is, updatePlan.tblID2Table)
 	if tbl.GetPartitionInfo() != nil {
 		pt := t.(table.PartitionedTable)
 		var updateTableList []*ast.TableName
@@ -1459,11 +1469,13 @@ func buildPointUpdatePlan(ctx sessionctx.Context, pointPlan PhysicalPlan, dbName
 			if len(updateTable.PartitionNames) > 0 {
 				pids := make(map[int64]struct{}, len(updateTable.PartitionNames))
 				for _, name := range updateTable.PartitionNames {
-					pid, err := tables.FindPartitionByName(tbl, name.L)
+					partIDs, err := tables.FindPartitionIDsByName(tbl, name.L)
 					if err != nil {
 						return updatePlan
 					}
-					pids[pid] = struct{}{}
+					for _, pid := range partIDs {
+						pids[pid] = struct{}{}
+					}
 				}
 				pt = tables.NewPartitionTableWithGivenSets(pt, pids)
 			}
@@ -1557,6 +1569,11 @@ func buildPointDeletePlan(ctx sessionctx.Context, pointPlan PhysicalPlan, dbName
 			},
 		},
 	}.Init(ctx)
+	is := ctx.GetInfoSchema().(infoschema.InfoSchema)
+	t, _ := is.TableByID(tbl.ID)
+	delPlan.buildOnDeleteFKTriggers(ctx, is, map[int64]table.Table{
+		tbl.ID: t,
+	})
 	return delPlan
 }
 
@@ -1611,7 +1628,8 @@ func getPartitionInfo(ctx sessionctx.Context, tbl *model.TableInfo, pairs []name
 	}
 
 	pi := tbl.GetPartitionInfo()
-	if pi == nil {
+	// The rows of a subpartitioned table are not located by the point get.
+	if pi == nil || pi.IsSubPartitioned() {
 		return nil, 0, false
 	}
 
@@ -153,6 +153,9 @@ const (
 	inSequenceFunction
 	// initTxnContextProvider is set when we should init txn context in preprocess
 	initTxnContextProvider
+	// inMultiValuedIndexPart is set when visiting the key part of a multi-valued index,
+	// which is the only place that CAST(... AS ... ARRAY) can be used.
+	inMultiValuedIndexPart
 )
 
 // Make linter happy.
@@ -292,6 +295,47 @@ func (p *preprocessor) Enter(in ast.Node) (out ast.Node, skipChildren bool) {
 		p.stmtTp = TypeDrop
 		p.flag |= inCreateOrDropTable
 		p.checkDropSequenceGrammar(node)
+	case *ast.CreateProcedureStmt:
+		p.stmtTp = TypeCreate
+		p.resolveProcedureName(node.ProcedureName)
+		// The body refers to the local variables of the procedure, it is resolved when the procedure is called.
+		return in, true
+	case *ast.DropProcedureStmt:
+		p.stmtTp = TypeDrop
+		p.resolveProcedureName(node.ProcedureName)
+		return in, true
+	case *ast.CreateTriggerStmt:
+		p.stmtTp = TypeCreate
+		p.resolveProcedureName(node.Table)
+		// The trigger is created in the schema of its table by default.
+		if node.TriggerName.Schema.L == "" {
+			node.TriggerName.Schema = node.Table.Schema
+		}
+		// The body refers to the NEW and OLD rows, it is resolved when the trigger is activated.
+		return in, true
+	case *ast.DropTriggerStmt:
+		p.stmtTp = TypeDrop
+		p.resolveProcedureName(node.TriggerName)
+		return in, true
+	case *ast.CreateEventStmt:
+		p.stmtTp = TypeCreate
+		p.resolveProcedureName(node.EventName)
+		// The body is executed by the event scheduler, it is resolved when the event fires.
+		return in, true
+	case *ast.AlterEventStmt:
+		p.resolveProcedureName(node.EventName)
+		if node.RenameTo != nil {
+			p.resolveProcedureName(node.RenameTo)
+		}
+		return in, true
+	case *ast.DropEventStmt:
+		p.stmtTp = TypeDrop
+		p.resolveProcedureName(node.EventName)
+		return in, true
+	case *ast.IndexPartSpecification:
+		if cast, ok := node.Expr.(*ast.FuncCastExpr); ok && cast.Tp.Array {
+			p.flag |= inMultiValuedIndexPart
+		}
 	case *ast.FuncCastExpr:
 		p.checkFuncCastExpr(node)
 	case *ast.FuncCallExpr:
@@ -500,6 +544,8 @@ func (p *preprocessor) Leave(in ast.Node) (out ast.Node, ok bool) {
 		p.flag &= ^inCreateOrDropTable
 	case *ast.DropTableStmt, *ast.AlterTableStmt, *ast.RenameTableStmt:
 		p.flag &= ^inCreateOrDropTable
+	case *ast.IndexPartSpecification:
+		p.flag &= ^inMultiValuedIndexPart
 	case *driver.ParamMarkerExpr:
 		if p.flag&inPrepare == 0 {
 			p.err = parser.ErrSyntax.GenWithStack("syntax error, unexpected '?'")
@@ -1586,7 +1632,25 @@ func (p *preprocessor) resolveCreateSequenceStmt(stmt *ast.CreateSequenceStmt) {
 	}
 }
 
+func (p *preprocessor) resolveProcedureName(name *ast.TableName) {
+	if name.Schema.L != "" {
+		return
+	}
+	currentDB := p.ctx.GetSessionVars().CurrentDB
+	if currentDB == "" {
+		p.err = errors.Trace(ErrNoDB)
+		return
+	}
+	name.Schema = model.NewCIStr(currentDB)
+}
+
 func (p *preprocessor) checkFuncCastExpr(node *ast.FuncCastExpr) {
+	if node.Tp.Array {
+		if p.flag&inMultiValuedIndexPart == 0 {
+			p.err = ErrNotSupportedYet.GenWithStackByArgs("Use of CAST( .. AS .. ARRAY) outside of functional index in CREATE(non-SELECT)/ALTER TABLE or in general expressions")
+		}
+		return
+	}
 	if node.Tp.EvalType() == types.ETDecimal {
 		if node.Tp.Flen >= node.Tp.Decimal && node.Tp.Flen <= mysql.MaxDecimalWidth && node.Tp.Decimal <= mysql.MaxDecimalScale {
 			// valid
@@ -481,6 +481,12 @@ func (p *PhysicalWindow) ResolveIndices() (err error) {
 				return err
 			}
 		}
+		for _, by := range desc.OrderByItems {
+			by.Expr, err = by.Expr.ResolveIndices(p.children[0].Schema())
+			if err != nil {
+				return err
+			}
+		}
 	}
 	if p.Frame != nil {
 		for i := range p.Frame.Start.CalcFuncs {
@@ -532,6 +532,9 @@ func (p *LogicalWindow) extractUsedCols(parentUsedCols []*expression.Column) []*
 		for _, arg := range desc.Args {
 			parentUsedCols = append(parentUsedCols, expression.ExtractColumns(arg)...)
 		}
+		for _, by := range desc.OrderByItems {
+			parentUsedCols = append(parentUsedCols, expression.ExtractColumns(by.Expr)...)
+		}
 	}
 	for _, by := range p.PartitionBy {
 		parentUsedCols = append(parentUsedCols, by.Col)
@@ -284,6 +284,9 @@ func (p *LogicalWindow) replaceExprColumns(replace map[string]*expression.Column
 		for _, arg := range desc.Args {
 			ResolveExprAndReplace(arg, replace)
 		}
+		for _, by := range desc.OrderByItems {
+			ResolveExprAndReplace(by.Expr, replace)
+		}
 	}
 	for _, item := range p.PartitionBy {
 		resolveColumnAndReplace(item.Col, replace)
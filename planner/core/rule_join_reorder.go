@@ -21,6 +21,7 @@ import (
 	"sort"
 
 	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/parser/ast"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/util/plancodec"
 	"github.com/pingcap/tidb/util/tracing"
@@ -30,26 +31,123 @@ import (
 // InnerJoins to construct a join group. This join group is further used to
 // construct a new join order based on a reorder algorithm.
 //
+// If withOuterJoin is true, the outer side of a LeftOuterJoin or a
+// RightOuterJoin with equal conditions is extracted as well, while its inner
+// side is kept as a single node of the group, see outerJoinEdge for details.
+//
 // For example: "InnerJoin(InnerJoin(a, b), LeftJoin(c, d))"
-// results in a join group {a, b, LeftJoin(c, d)}.
-func extractJoinGroup(p LogicalPlan) (group []LogicalPlan, eqEdges []*expression.ScalarFunction, otherConds []expression.Expression) {
+// results in a join group {a, b, LeftJoin(c, d)} without outer joins,
+// or {a, b, c, d} with outer joins.
+func extractJoinGroup(p LogicalPlan, withOuterJoin bool) *joinGroupResult {
 	join, isJoin := p.(*LogicalJoin)
-	if !isJoin || join.preferJoinType > uint(0) || join.JoinType != InnerJoin || join.StraightJoin {
-		return []LogicalPlan{p}, nil, nil
-	}
-
-	lhsGroup, lhsEqualConds, lhsOtherConds := extractJoinGroup(join.children[0])
-	rhsGroup, rhsEqualConds, rhsOtherConds := extractJoinGroup(join.children[1])
-
-	group = append(group, lhsGroup...)
-	group = append(group, rhsGroup...)
-	eqEdges = append(eqEdges, join.EqualConditions...)
-	eqEdges = append(eqEdges, lhsEqualConds...)
-	eqEdges = append(eqEdges, rhsEqualConds...)
-	otherConds = append(otherConds, join.OtherConditions...)
-	otherConds = append(otherConds, lhsOtherConds...)
-	otherConds = append(otherConds, rhsOtherConds...)
-	return group, eqEdges, otherConds
+	if !isJoin || join.preferJoinType > uint(0) || join.StraightJoin {
+		return &joinGroupResult{group: []LogicalPlan{p}}
+	}
+	switch join.JoinType {
+	case InnerJoin:
+	case LeftOuterJoin, RightOuterJoin:
+		// The DefaultValues of the inner side are set by the aggregation push
+		// down, we keep such joins as they are.
+		if !withOuterJoin || len(join.EqualConditions) == 0 || join.DefaultValues != nil {
+			return &joinGroupResult{group: []LogicalPlan{p}}
+		}
+	default:
+		return &joinGroupResult{group: []LogicalPlan{p}}
+	}
+
+	result := &joinGroupResult{}
+	if join.JoinType == InnerJoin {
+		result.merge(extractJoinGroup(join.children[0], withOuterJoin))
+		result.merge(extractJoinGroup(join.children[1], withOuterJoin))
+		result.eqEdges = append(result.eqEdges, join.EqualConditions...)
+		result.otherConds = append(result.otherConds, join.OtherConditions...)
+		return result
+	}
+
+	outer, inner := join.children[0], join.children[1]
+	if join.JoinType == RightOuterJoin {
+		outer, inner = inner, outer
+	}
+	result.merge(extractJoinGroup(outer, withOuterJoin))
+	result.group = append(result.group, inner)
+	conds := make([]expression.Expression, 0, len(join.LeftConditions)+len(join.RightConditions)+len(join.OtherConditions))
+	conds = append(conds, join.LeftConditions...)
+	conds = append(conds, join.RightConditions...)
+	conds = append(conds, join.OtherConditions...)
+	result.outerJoins = append(result.outerJoins, &outerJoinEdge{
+		inner:      inner,
+		eqConds:    join.EqualConditions,
+		otherConds: conds,
+	})
+	return result
+}
+
+// joinGroupResult is the result of extractJoinGroup.
+type joinGroupResult struct {
+	group      []LogicalPlan
+	eqEdges    []*expression.ScalarFunction
+	otherConds []expression.Expression
+	outerJoins []*outerJoinEdge
+}
+
+func (r *joinGroupResult) merge(other *joinGroupResult) {
+	r.group = append(r.group, other.group...)
+	r.eqEdges = append(r.eqEdges, other.eqEdges...)
+	r.otherConds = append(r.otherConds, other.otherConds...)
+	r.outerJoins = append(r.outerJoins, other.outerJoins...)
+}
+
+// outerJoinEdge describes the inner side of an outer join in a join group.
+// The inner side can only be joined as the right child of a LeftOuterJoin,
+// whose left child is a join tree that covers all the other columns used by
+// the outer join conditions.
+//
+// Since (A join B on p(A, B)) left join C on p(A, C) equals to
+// (A left join C on p(A, C)) join B on p(A, B), this is safe as long as the
+// outer join conditions only refer to one other node of the group and the
+// inner side is not referred by the conditions of the inner joins, which is
+// checked by checkOuterJoins.
+type outerJoinEdge struct {
+	inner      LogicalPlan
+	eqConds    []*expression.ScalarFunction
+	otherConds []expression.Expression
+}
+
+// checkOuterJoins checks whether all the outer joins in the group can be
+// reordered, see outerJoinEdge for details. Unless the LEADING hint is
+// specified, the outer joins are only reordered together with inner joins,
+// since there is little to gain from reordering a chain of outer joins.
+func (r *joinGroupResult) checkOuterJoins(hasLeadingHint bool) bool {
+	if len(r.group)-len(r.outerJoins) < 2 && !hasLeadingHint {
+		return false
+	}
+	innerConds := expression.ScalarFuncs2Exprs(r.eqEdges)
+	innerConds = append(innerConds, r.otherConds...)
+	innerCondCols := expression.ExtractColumnsFromExpressions(nil, innerConds, nil)
+	for _, edge := range r.outerJoins {
+		for _, col := range innerCondCols {
+			if edge.inner.Schema().Contains(col) {
+				return false
+			}
+		}
+		conds := expression.ScalarFuncs2Exprs(edge.eqConds)
+		conds = append(conds, edge.otherConds...)
+		dependency := -1
+		for _, col := range expression.ExtractColumnsFromExpressions(nil, conds, nil) {
+			if edge.inner.Schema().Contains(col) {
+				continue
+			}
+			idx, err := findNodeIndexInGroup(r.group, col)
+			if err != nil || (dependency != -1 && dependency != idx) {
+				return false
+			}
+			dependency = idx
+		}
+		if dependency == -1 {
+			return false
+		}
+	}
+	return true
 }
 
 type joinReOrderSolver struct {
@@ -60,29 +158,69 @@ type jrNode struct {
 	cumCost float64
 }
 
+// leadingHintState records whether a LEADING hint met during the join reorder
+// has been applied.
+type leadingHintState struct {
+	tables  []hintTableInfo
+	applied bool
+}
+
 func (s *joinReOrderSolver) optimize(ctx context.Context, p LogicalPlan, opt *logicalOptimizeOp) (LogicalPlan, error) {
 	tracer := &joinReorderTrace{cost: map[string]float64{}, opt: opt}
 	tracer.traceJoinReorder(p)
-	p, err := s.optimizeRecursive(p.SCtx(), p, tracer)
+	// The tableHintInfo of a query block may be copied, so we identify a
+	// LEADING hint by its first table.
+	leadingHints := make(map[*hintTableInfo]*leadingHintState)
+	p, err := s.optimizeRecursive(p.SCtx(), p, tracer, leadingHints)
 	tracer.traceJoinReorder(p)
 	appendJoinReorderTraceStep(tracer, p, opt)
+	for _, state := range leadingHints {
+		if !state.applied {
+			p.SCtx().GetSessionVars().StmtCtx.AppendWarning(ErrInternal.GenWithStack(
+				"Optimizer Hint %s is inapplicable, check whether the tables are in the same join group and the outer joins are kept in order",
+				restore2JoinHint(HintLeading, state.tables)))
+		}
+	}
 	return p, err
 }
 
 // optimizeRecursive recursively collects join groups and applies join reorder algorithm for each group.
-func (s *joinReOrderSolver) optimizeRecursive(ctx sessionctx.Context, p LogicalPlan, tracer *joinReorderTrace) (LogicalPlan, error) {
+func (s *joinReOrderSolver) optimizeRecursive(ctx sessionctx.Context, p LogicalPlan, tracer *joinReorderTrace, leadingHints map[*hintTableInfo]*leadingHintState) (LogicalPlan, error) {
 	var err error
-	curJoinGroup, eqEdges, otherConds := extractJoinGroup(p)
+	var leadingHint *leadingHintState
+	if join, ok := p.(*LogicalJoin); ok && join.preferJoinOrder {
+		key := &join.hintInfo.leadingJoinOrder[0]
+		leadingHint = leadingHints[key]
+		if leadingHint == nil {
+			leadingHint = &leadingHintState{tables: join.hintInfo.leadingJoinOrder}
+			leadingHints[key] = leadingHint
+		}
+	}
+	result := extractJoinGroup(p, true)
+	if len(result.outerJoins) > 0 && !result.checkOuterJoins(leadingHint != nil) {
+		result = extractJoinGroup(p, false)
+	}
+	curJoinGroup, eqEdges := result.group, result.eqEdges
 	if len(curJoinGroup) > 1 {
 		for i := range curJoinGroup {
-			curJoinGroup[i], err = s.optimizeRecursive(ctx, curJoinGroup[i], tracer)
+			newNode, err := s.optimizeRecursive(ctx, curJoinGroup[i], tracer, leadingHints)
 			if err != nil {
 				return nil, err
 			}
+			for _, edge := range result.outerJoins {
+				if edge.inner == curJoinGroup[i] {
+					edge.inner = newNode
+				}
+			}
+			curJoinGroup[i] = newNode
 		}
 		baseGroupSolver := &baseSingleGroupJoinOrderSolver{
 			ctx:        ctx,
-			otherConds: otherConds,
+			otherConds: result.otherConds,
+			outerJoins: result.outerJoins,
+		}
+		if leadingHint != nil && !leadingHint.applied {
+			leadingHint.applied, curJoinGroup, eqEdges = baseGroupSolver.generateLeadingJoinGroup(curJoinGroup, eqEdges, leadingHint.tables, p.SelectBlockOffset())
 		}
 		originalSchema := p.Schema()
 		if len(curJoinGroup) > ctx.GetSessionVars().TiDBOptJoinReorderThreshold {
@@ -124,7 +262,7 @@ func (s *joinReOrderSolver) optimizeRecursive(ctx sessionctx.Context, p LogicalP
 	}
 	newChildren := make([]LogicalPlan, 0, len(p.Children()))
 	for _, child := range p.Children() {
-		newChild, err := s.optimizeRecursive(ctx, child, tracer)
+		newChild, err := s.optimizeRecursive(ctx, child, tracer, leadingHints)
 		if err != nil {
 			return nil, err
 		}
@@ -139,6 +277,10 @@ type baseSingleGroupJoinOrderSolver struct {
 	ctx          sessionctx.Context
 	curJoinGroup []*jrNode
 	otherConds   []expression.Expression
+	outerJoins   []*outerJoinEdge
+	// leadingJoinGroup is the join tree built by the LEADING hint, the other
+	// nodes of the group are joined to it one by one.
+	leadingJoinGroup LogicalPlan
 }
 
 // baseNodeCumCost calculate the cumulative cost of the node in the join group.
@@ -196,6 +338,158 @@ func (s *baseSingleGroupJoinOrderSolver) newJoinWithEdges(lChild, rChild Logical
 	return newJoin
 }
 
+// checkConnectionAndMakeJoin checks whether the two nodes can be joined by
+// the equal edges or as an outer join, and makes the join if so. It returns
+// the new join and the other conditions which are not used by the join.
+func (s *baseSingleGroupJoinOrderSolver) checkConnectionAndMakeJoin(leftNode, rightNode LogicalPlan, eqEdges []*expression.ScalarFunction) (LogicalPlan, []expression.Expression) {
+	if s.outerJoinEdgeOf(leftNode) != nil || s.outerJoinEdgeOf(rightNode) != nil {
+		newJoin := s.makeOuterJoin(leftNode, rightNode)
+		if newJoin == nil {
+			return nil, nil
+		}
+		return newJoin, s.otherConds
+	}
+	var usedEdges []*expression.ScalarFunction
+	remainOtherConds := make([]expression.Expression, len(s.otherConds))
+	copy(remainOtherConds, s.otherConds)
+	for _, edge := range eqEdges {
+		lCol := edge.GetArgs()[0].(*expression.Column)
+		rCol := edge.GetArgs()[1].(*expression.Column)
+		if leftNode.Schema().Contains(lCol) && rightNode.Schema().Contains(rCol) {
+			usedEdges = append(usedEdges, edge)
+		} else if rightNode.Schema().Contains(lCol) && leftNode.Schema().Contains(rCol) {
+			newSf := expression.NewFunctionInternal(s.ctx, ast.EQ, edge.GetType(), rCol, lCol).(*expression.ScalarFunction)
+			usedEdges = append(usedEdges, newSf)
+		}
+	}
+	if len(usedEdges) == 0 {
+		return nil, nil
+	}
+	var otherConds []expression.Expression
+	mergedSchema := expression.MergeSchema(leftNode.Schema(), rightNode.Schema())
+	remainOtherConds, otherConds = expression.FilterOutInPlace(remainOtherConds, func(expr expression.Expression) bool {
+		return expression.ExprFromSchema(expr, mergedSchema)
+	})
+	return s.newJoinWithEdges(leftNode, rightNode, usedEdges, otherConds), remainOtherConds
+}
+
+// outerJoinEdgeOf returns the outer join edge if the node is the inner side
+// of an outer join in the group.
+func (s *baseSingleGroupJoinOrderSolver) outerJoinEdgeOf(node LogicalPlan) *outerJoinEdge {
+	for _, edge := range s.outerJoins {
+		if edge.inner == node {
+			return edge
+		}
+	}
+	return nil
+}
+
+// makeOuterJoin makes a LeftOuterJoin whose right child is the inner side of
+// an outer join, either of the two nodes can be the inner side. It returns
+// nil if the two nodes can't be joined in this way.
+func (s *baseSingleGroupJoinOrderSolver) makeOuterJoin(lNode, rNode LogicalPlan) LogicalPlan {
+	if s.outerJoinEdgeOf(rNode) == nil {
+		lNode, rNode = rNode, lNode
+	}
+	edge := s.outerJoinEdgeOf(rNode)
+	if edge == nil || s.outerJoinEdgeOf(lNode) != nil {
+		return nil
+	}
+	mergedSchema := expression.MergeSchema(lNode.Schema(), rNode.Schema())
+	for _, cond := range edge.eqConds {
+		if !expression.ExprFromSchema(cond, mergedSchema) {
+			return nil
+		}
+	}
+	for _, cond := range edge.otherConds {
+		if !expression.ExprFromSchema(cond, mergedSchema) {
+			return nil
+		}
+	}
+	join := s.newCartesianJoin(lNode, rNode)
+	join.JoinType = LeftOuterJoin
+	resetNotNullFlag(join.schema, lNode.Schema().Len(), join.schema.Len())
+	for _, cond := range edge.eqConds {
+		lCol := cond.GetArgs()[0].(*expression.Column)
+		rCol := cond.GetArgs()[1].(*expression.Column)
+		if rNode.Schema().Contains(lCol) {
+			cond = expression.NewFunctionInternal(s.ctx, ast.EQ, cond.GetType(), rCol, lCol).(*expression.ScalarFunction)
+		}
+		join.EqualConditions = append(join.EqualConditions, cond)
+	}
+	for _, cond := range edge.otherConds {
+		switch {
+		case expression.ExprFromSchema(cond, lNode.Schema()):
+			join.LeftConditions = append(join.LeftConditions, cond)
+		case expression.ExprFromSchema(cond, rNode.Schema()):
+			join.RightConditions = append(join.RightConditions, cond)
+		default:
+			join.OtherConditions = append(join.OtherConditions, cond)
+		}
+	}
+	return join
+}
+
+// generateLeadingJoinGroup joins the nodes specified by the LEADING hint in
+// the hint order, and returns the new join group which starts with the
+// leading join tree, together with the equal edges that are not used yet.
+// The join group is returned unchanged if the hint can't be applied to it.
+func (s *baseSingleGroupJoinOrderSolver) generateLeadingJoinGroup(curJoinGroup []LogicalPlan, eqEdges []*expression.ScalarFunction,
+	hintTables []hintTableInfo, blockOffset int) (bool, []LogicalPlan, []*expression.ScalarFunction) {
+	leadingNodes := make([]LogicalPlan, 0, len(hintTables))
+	remainNodes := make([]LogicalPlan, len(curJoinGroup))
+	copy(remainNodes, curJoinGroup)
+	for _, hintTbl := range hintTables {
+		found := false
+		for i, node := range remainNodes {
+			alias := extractTableAlias(node, blockOffset)
+			if alias != nil && alias.dbName.L == hintTbl.dbName.L && alias.tblName.L == hintTbl.tblName.L && alias.selectOffset == hintTbl.selectOffset {
+				leadingNodes = append(leadingNodes, node)
+				remainNodes = append(remainNodes[:i], remainNodes[i+1:]...)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false, curJoinGroup, eqEdges
+		}
+	}
+	// The inner side of an outer join can't be the first one to join.
+	if s.outerJoinEdgeOf(leadingNodes[0]) != nil {
+		return false, curJoinGroup, eqEdges
+	}
+	originalOtherConds := s.otherConds
+	s.otherConds = make([]expression.Expression, len(originalOtherConds))
+	copy(s.otherConds, originalOtherConds)
+	leadingJoin := leadingNodes[0]
+	for _, node := range leadingNodes[1:] {
+		newJoin, remainOtherConds := s.checkConnectionAndMakeJoin(leadingJoin, node, eqEdges)
+		if newJoin == nil {
+			if s.outerJoinEdgeOf(node) != nil {
+				s.otherConds = originalOtherConds
+				return false, curJoinGroup, eqEdges
+			}
+			// The hinted nodes are not connected by equal edges, make a cartesian join for them.
+			mergedSchema := expression.MergeSchema(leadingJoin.Schema(), node.Schema())
+			var usedOtherConds []expression.Expression
+			remainOtherConds, usedOtherConds = expression.FilterOutInPlace(s.otherConds, func(expr expression.Expression) bool {
+				return expression.ExprFromSchema(expr, mergedSchema)
+			})
+			newJoin = s.newJoinWithEdges(leadingJoin, node, nil, usedOtherConds)
+		}
+		leadingJoin = newJoin
+		s.otherConds = remainOtherConds
+	}
+	remainEdges := make([]*expression.ScalarFunction, 0, len(eqEdges))
+	for _, edge := range eqEdges {
+		if !expression.ExprFromSchema(edge, leadingJoin.Schema()) {
+			remainEdges = append(remainEdges, edge)
+		}
+	}
+	s.leadingJoinGroup = leadingJoin
+	return true, append([]LogicalPlan{leadingJoin}, remainNodes...), remainEdges
+}
+
 // calcJoinCumCost calculates the cumulative cost of the join node.
 func (s *baseSingleGroupJoinOrderSolver) calcJoinCumCost(join LogicalPlan, lNode, rNode *jrNode) float64 {
 	return join.statsInfo().RowCount + lNode.cumCost + rNode.cumCost
@@ -75,6 +75,34 @@ func (s *joinReorderDPSolver) solve(joinGroup []LogicalPlan, eqConds []expressio
 		}
 		addEqEdge(lIdx, rIdx, sf)
 	}
+	// The inner side of an outer join is connected to the node that its join conditions depend on.
+	for _, edge := range s.outerJoins {
+		innerIdx := -1
+		for i, node := range joinGroup {
+			if node == edge.inner {
+				innerIdx = i
+				break
+			}
+		}
+		// The outer join is already made for the LEADING hint.
+		if innerIdx == -1 {
+			continue
+		}
+		conds := expression.ScalarFuncs2Exprs(edge.eqConds)
+		conds = append(conds, edge.otherConds...)
+		for _, col := range expression.ExtractColumnsFromExpressions(nil, conds, nil) {
+			if edge.inner.Schema().Contains(col) {
+				continue
+			}
+			idx, err := findNodeIndexInGroup(joinGroup, col)
+			if err != nil {
+				return nil, err
+			}
+			adjacents[innerIdx] = append(adjacents[innerIdx], idx)
+			adjacents[idx] = append(adjacents[idx], innerIdx)
+			break
+		}
+	}
 	totalNonEqEdges := make([]joinGroupNonEqEdge, 0, len(s.otherConds))
 	for _, cond := range s.otherConds {
 		cols := expression.ExtractColumns(cond)
@@ -165,14 +193,23 @@ func (s *joinReorderDPSolver) dpGraph(visitID2NodeID, nodeID2VisitID []int, join
 	nodeCnt := uint(len(visitID2NodeID))
 	bestPlan := make([]*jrNode, 1<<nodeCnt)
 	// bestPlan[s] is nil can be treated as bestCost[s] = +inf.
+	leadingMask := uint(0)
 	for i := uint(0); i < nodeCnt; i++ {
 		bestPlan[1<<i] = s.curJoinGroup[visitID2NodeID[i]]
+		if joinGroup[visitID2NodeID[i]] == s.leadingJoinGroup {
+			leadingMask = 1 << i
+		}
 	}
 	// Enumerate the nodeBitmap from small to big, make sure that S1 must be enumerated before S2 if S1 belongs to S2.
 	for nodeBitmap := uint(1); nodeBitmap < (1 << nodeCnt); nodeBitmap++ {
 		if bits.OnesCount(nodeBitmap) == 1 {
 			continue
 		}
+		// When there is a leading join group, the other nodes are joined to it
+		// one by one, so only the subsets containing it are considered.
+		if leadingMask != 0 && nodeBitmap&leadingMask == 0 {
+			continue
+		}
 		// This loop can iterate all its subset.
 		for sub := (nodeBitmap - 1) & nodeBitmap; sub > 0; sub = (sub - 1) & nodeBitmap {
 			remain := nodeBitmap ^ sub
@@ -183,17 +220,33 @@ func (s *joinReorderDPSolver) dpGraph(visitID2NodeID, nodeID2VisitID []int, join
 			if bestPlan[sub] == nil || bestPlan[remain] == nil {
 				continue
 			}
-			// Get the edge connecting the two parts.
-			usedEdges, otherConds := s.nodesAreConnected(sub, remain, nodeID2VisitID, totalEqEdges, totalNonEqEdges)
-			// Here we only check equal condition currently.
-			if len(usedEdges) == 0 {
-				continue
+			leftPlan, rightPlan := bestPlan[sub], bestPlan[remain]
+			if remain&leadingMask != 0 {
+				leftPlan, rightPlan = rightPlan, leftPlan
 			}
-			join, err := s.newJoinWithEdge(bestPlan[sub].p, bestPlan[remain].p, usedEdges, otherConds)
-			if err != nil {
-				return nil, err
+			var join LogicalPlan
+			if s.outerJoinEdgeOf(leftPlan.p) != nil || s.outerJoinEdgeOf(rightPlan.p) != nil {
+				join = s.makeOuterJoin(leftPlan.p, rightPlan.p)
+				if join == nil {
+					continue
+				}
+				if _, err := join.recursiveDeriveStats(nil); err != nil {
+					return nil, err
+				}
+			} else {
+				// Get the edge connecting the two parts.
+				usedEdges, otherConds := s.nodesAreConnected(sub, remain, nodeID2VisitID, totalEqEdges, totalNonEqEdges)
+				// Here we only check equal condition currently.
+				if len(usedEdges) == 0 {
+					continue
+				}
+				var err error
+				join, err = s.newJoinWithEdge(leftPlan.p, rightPlan.p, usedEdges, otherConds)
+				if err != nil {
+					return nil, err
+				}
 			}
-			curCost := s.calcJoinCumCost(join, bestPlan[sub], bestPlan[remain])
+			curCost := s.calcJoinCumCost(join, leftPlan, rightPlan)
 			tracer.appendLogicalJoinCost(join, curCost)
 			if bestPlan[nodeBitmap] == nil {
 				bestPlan[nodeBitmap] = &jrNode{
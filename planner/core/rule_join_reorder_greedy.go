@@ -19,7 +19,6 @@ import (
 	"sort"
 
 	"github.com/pingcap/tidb/expression"
-	"github.com/pingcap/tidb/parser/ast"
 )
 
 type joinReorderGreedySolver struct {
@@ -55,6 +54,10 @@ func (s *joinReorderGreedySolver) solve(joinNodePlans []LogicalPlan, tracer *joi
 		tracer.appendLogicalJoinCost(node, cost)
 	}
 	sort.SliceStable(s.curJoinGroup, func(i, j int) bool {
+		// The leading join group specified by the LEADING hint is always joined first.
+		if isLeading := s.curJoinGroup[i].p == s.leadingJoinGroup; isLeading != (s.curJoinGroup[j].p == s.leadingJoinGroup) {
+			return isLeading
+		}
 		return s.curJoinGroup[i].cumCost < s.curJoinGroup[j].cumCost
 	})
 
@@ -71,15 +74,24 @@ func (s *joinReorderGreedySolver) solve(joinNodePlans []LogicalPlan, tracer *joi
 }
 
 func (s *joinReorderGreedySolver) constructConnectedJoinTree(tracer *joinReorderTrace) (*jrNode, error) {
-	curJoinTree := s.curJoinGroup[0]
-	s.curJoinGroup = s.curJoinGroup[1:]
+	// The inner side of an outer join can't be the start of a join tree, it
+	// is joined after the nodes that its join conditions depend on.
+	startIdx := 0
+	for i, node := range s.curJoinGroup {
+		if s.outerJoinEdgeOf(node.p) == nil {
+			startIdx = i
+			break
+		}
+	}
+	curJoinTree := s.curJoinGroup[startIdx]
+	s.curJoinGroup = append(s.curJoinGroup[:startIdx], s.curJoinGroup[startIdx+1:]...)
 	for {
 		bestCost := math.MaxFloat64
 		bestIdx := -1
 		var finalRemainOthers []expression.Expression
 		var bestJoin LogicalPlan
 		for i, node := range s.curJoinGroup {
-			newJoin, remainOthers := s.checkConnectionAndMakeJoin(curJoinTree.p, node.p)
+			newJoin, remainOthers := s.checkConnectionAndMakeJoin(curJoinTree.p, node.p, s.eqEdges)
 			if newJoin == nil {
 				continue
 			}
@@ -109,28 +121,3 @@ func (s *joinReorderGreedySolver) constructConnectedJoinTree(tracer *joinReorder
 	}
 	return curJoinTree, nil
 }
-
-func (s *joinReorderGreedySolver) checkConnectionAndMakeJoin(leftNode, rightNode LogicalPlan) (LogicalPlan, []expression.Expression) {
-	var usedEdges []*expression.ScalarFunction
-	remainOtherConds := make([]expression.Expression, len(s.otherConds))
-	copy(remainOtherConds, s.otherConds)
-	for _, edge := range s.eqEdges {
-		lCol := edge.GetArgs()[0].(*expression.Column)
-		rCol := edge.GetArgs()[1].(*expression.Column)
-		if leftNode.Schema().Contains(lCol) && rightNode.Schema().Contains(rCol) {
-			usedEdges = append(usedEdges, edge)
-		} else if rightNode.Schema().Contains(lCol) && leftNode.Schema().Contains(rCol) {
-			newSf := expression.NewFunctionInternal(s.ctx, ast.EQ, edge.GetType(), rCol, lCol).(*expression.ScalarFunction)
-			usedEdges = append(usedEdges, newSf)
-		}
-	}
-	if len(usedEdges) == 0 {
-		return nil, nil
-	}
-	var otherConds []expression.Expression
-	mergedSchema := expression.MergeSchema(leftNode.Schema(), rightNode.Schema())
-	remainOtherConds, otherConds = expression.FilterOutInPlace(remainOtherConds, func(expr expression.Expression) bool {
-		return expression.ExprFromSchema(expr, mergedSchema)
-	})
-	return s.newJoinWithEdges(leftNode, rightNode, usedEdges, otherConds), remainOtherConds
-}
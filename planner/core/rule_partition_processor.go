@@ -33,6 +33,7 @@ import (
 	"github.com/pingcap/tidb/table/tables"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/collate"
 	"github.com/pingcap/tidb/util/math"
 	"github.com/pingcap/tidb/util/plancodec"
 	"github.com/pingcap/tidb/util/ranger"
@@ -122,9 +123,8 @@ func generateHashPartitionExpr(ctx sessionctx.Context, pi *model.PartitionInfo,
 	return exprs[0], nil
 }
 
-func (s *partitionProcessor) findUsedPartitions(ctx sessionctx.Context, tbl table.Table, partitionNames []model.CIStr,
+func (s *partitionProcessor) findUsedPartitions(ctx sessionctx.Context, pi *model.PartitionInfo, partitionNames []model.CIStr,
 	conds []expression.Expression, columns []*expression.Column, names types.NameSlice) ([]int, []expression.Expression, error) {
-	pi := tbl.Meta().Partition
 	pe, err := generateHashPartitionExpr(ctx, pi, columns, names)
 	if err != nil {
 		return nil, nil, err
@@ -273,9 +273,9 @@ func convertToRangeOr(used []int, pi *model.PartitionInfo) partitionRangeOR {
 	return ret
 }
 
-func (s *partitionProcessor) pruneHashPartition(ctx sessionctx.Context, tbl table.Table, partitionNames []model.CIStr,
+func (s *partitionProcessor) pruneHashPartition(ctx sessionctx.Context, pi *model.PartitionInfo, partitionNames []model.CIStr,
 	conds []expression.Expression, columns []*expression.Column, names types.NameSlice) ([]int, error) {
-	used, _, err := s.findUsedPartitions(ctx, tbl, partitionNames, conds, columns, names)
+	used, _, err := s.findUsedPartitions(ctx, pi, partitionNames, conds, columns, names)
 	if err != nil {
 		return nil, err
 	}
@@ -340,7 +340,7 @@ func (s *partitionProcessor) processHashPartition(ds *DataSource, pi *model.Part
 	if err != nil {
 		return nil, err
 	}
-	used, err := s.pruneHashPartition(ds.SCtx(), ds.table, ds.partitionNames, ds.allConds, ds.TblCols, names)
+	used, err := s.pruneHashPartition(ds.SCtx(), pi, ds.partitionNames, ds.allConds, ds.TblCols, names)
 	if err != nil {
 		return nil, err
 	}
@@ -353,6 +353,75 @@ func (s *partitionProcessor) processHashPartition(ds *DataSource, pi *model.Part
 	return tableDual, nil
 }
 
+// findUsedKeyPartitions finds the used partitions of key partition. Only the points on all the key partition
+// columns, e.g. `a = 1 and b = 2` or `a in (1, 2) and b = 3`, can be located by the hashing.
+func (s *partitionProcessor) findUsedKeyPartitions(ctx sessionctx.Context, pi *model.PartitionInfo, pe *tables.PartitionExpr,
+	partitionNames []model.CIStr, conds []expression.Expression, columns []*expression.Column) ([]int, error) {
+	keyPrune := pe.ForKeyPruning
+	full := s.convertToIntSlice(fullRange(len(pi.Definitions)), pi, partitionNames)
+	partCols := make([]*expression.Column, 0, len(keyPrune.KeyPartCols))
+	colLen := make([]int, 0, len(keyPrune.KeyPartCols))
+	for _, keyCol := range keyPrune.KeyPartCols {
+		var partCol *expression.Column
+		for _, col := range columns {
+			if col.ID == keyCol.ID {
+				partCol = col
+				break
+			}
+		}
+		if partCol == nil {
+			return full, nil
+		}
+		partCols = append(partCols, partCol)
+		colLen = append(colLen, types.UnspecifiedLength)
+	}
+	detachedResult, err := ranger.DetachCondAndBuildRangeForPartition(ctx, conds, partCols, colLen)
+	if err != nil {
+		return nil, err
+	}
+	used := make([]int, 0, len(detachedResult.Ranges))
+	for _, r := range detachedResult.Ranges {
+		if len(r.LowVal) != len(partCols) || !r.IsPointNullable(ctx) {
+			return full, nil
+		}
+		idx, err := keyPrune.LocateKeyPartition(ctx.GetSessionVars().StmtCtx, len(pi.Definitions), r.LowVal)
+		if err != nil {
+			// The point can't be hashed like the column value, e.g. the type of the point is different.
+			return full, nil
+		}
+		if len(partitionNames) > 0 && !s.findByName(partitionNames, pi.Definitions[idx].Name.L) {
+			continue
+		}
+		used = append(used, idx)
+	}
+	sort.Ints(used)
+	ret := used[:0]
+	for i := 0; i < len(used); i++ {
+		if i == 0 || used[i] != used[i-1] {
+			ret = append(ret, used[i])
+		}
+	}
+	return ret, nil
+}
+
+func (s *partitionProcessor) processKeyPartition(ds *DataSource, pi *model.PartitionInfo, opt *logicalOptimizeOp) (LogicalPlan, error) {
+	pe, err := ds.table.(partitionTable).PartitionExpr()
+	if err != nil {
+		return nil, err
+	}
+	used, err := s.findUsedKeyPartitions(ds.SCtx(), pi, pe, ds.partitionNames, ds.allConds, ds.TblCols)
+	if err != nil {
+		return nil, err
+	}
+	if len(used) > 0 {
+		return s.makeUnionAllChildren(ds, pi, convertToRangeOr(used, pi), opt)
+	}
+	tableDual := LogicalTableDual{RowCount: 0}.Init(ds.SCtx(), ds.blockOffset)
+	tableDual.schema = ds.Schema()
+	appendNoPartitionChildTraceStep(ds, tableDual, opt)
+	return tableDual, nil
+}
+
 // listPartitionPruner uses to prune partition for list partition.
 type listPartitionPruner struct {
 	*partitionProcessor
@@ -364,7 +433,7 @@ type listPartitionPruner struct {
 	listPrune       *tables.ForListPruning
 }
 
-func newListPartitionPruner(ctx sessionctx.Context, tbl table.Table, partitionNames []model.CIStr,
+func newListPartitionPruner(ctx sessionctx.Context, pi *model.PartitionInfo, partitionNames []model.CIStr,
 	s *partitionProcessor, conds []expression.Expression, pruneList *tables.ForListPruning) *listPartitionPruner {
 	colIDToUniqueID := make(map[int64]int64)
 	for _, cond := range conds {
@@ -378,7 +447,7 @@ func newListPartitionPruner(ctx sessionctx.Context, tbl table.Table, partitionNa
 	return &listPartitionPruner{
 		partitionProcessor: s,
 		ctx:                ctx,
-		pi:                 tbl.Meta().Partition,
+		pi:                 pi,
 		partitionNames:     partitionNames,
 		colIDToUniqueID:    colIDToUniqueID,
 		fullRange:          fullRange,
@@ -590,16 +659,11 @@ func (l *listPartitionPruner) findUsedListPartitions(conds []expression.Expressi
 	return used, nil
 }
 
-func (s *partitionProcessor) findUsedListPartitions(ctx sessionctx.Context, tbl table.Table, partitionNames []model.CIStr,
-	conds []expression.Expression) ([]int, error) {
-	pi := tbl.Meta().Partition
-	partExpr, err := tbl.(partitionTable).PartitionExpr()
-	if err != nil {
-		return nil, err
-	}
-
-	listPruner := newListPartitionPruner(ctx, tbl, partitionNames, s, conds, partExpr.ForListPruning)
+func (s *partitionProcessor) findUsedListPartitions(ctx sessionctx.Context, pi *model.PartitionInfo, partExpr *tables.PartitionExpr,
+	partitionNames []model.CIStr, conds []expression.Expression) ([]int, error) {
+	listPruner := newListPartitionPruner(ctx, pi, partitionNames, s, conds, partExpr.ForListPruning)
 	var used map[int]struct{}
+	var err error
 	if partExpr.ForListPruning.ColPrunes == nil {
 		used, err = listPruner.findUsedListPartitions(conds)
 	} else {
@@ -620,9 +684,9 @@ func (s *partitionProcessor) findUsedListPartitions(ctx sessionctx.Context, tbl
 	return ret, nil
 }
 
-func (s *partitionProcessor) pruneListPartition(ctx sessionctx.Context, tbl table.Table, partitionNames []model.CIStr,
-	conds []expression.Expression) ([]int, error) {
-	used, err := s.findUsedListPartitions(ctx, tbl, partitionNames, conds)
+func (s *partitionProcessor) pruneListPartition(ctx sessionctx.Context, pi *model.PartitionInfo, pe *tables.PartitionExpr,
+	partitionNames []model.CIStr, conds []expression.Expression) ([]int, error) {
+	used, err := s.findUsedListPartitions(ctx, pi, pe, partitionNames, conds)
 	if err != nil {
 		return nil, err
 	}
@@ -640,6 +704,9 @@ func (s *partitionProcessor) prune(ds *DataSource, opt *logicalOptimizeOp) (Logi
 	for i, cond := range ds.allConds {
 		ds.allConds[i] = expression.PushDownNot(ds.ctx, cond)
 	}
+	if pi.IsSubPartitioned() {
+		return s.processSubPartition(ds, pi, opt)
+	}
 	// Try to locate partition directly for hash partition.
 	switch pi.Type {
 	case model.PartitionTypeRange:
@@ -648,9 +715,11 @@ func (s *partitionProcessor) prune(ds *DataSource, opt *logicalOptimizeOp) (Logi
 		return s.processHashPartition(ds, pi, opt)
 	case model.PartitionTypeList:
 		return s.processListPartition(ds, pi, opt)
+	case model.PartitionTypeKey:
+		return s.processKeyPartition(ds, pi, opt)
 	}
 
-	// We haven't implement partition by key and so on.
+	// We haven't implement partition by system time and so on.
 	return s.makeUnionAllChildren(ds, pi, fullRange(len(pi.Definitions)), opt)
 }
 
@@ -664,6 +733,31 @@ func (s *partitionProcessor) findByName(partitionNames []model.CIStr, partitionN
 	return false
 }
 
+// expandSubPartitionNames replaces the names of the first level partitions of a subpartitioned table
+// with the names of their subpartitions, so that the names can be matched with pi.Definitions.
+func expandSubPartitionNames(pi *model.PartitionInfo, partitionNames []model.CIStr) []model.CIStr {
+	if pi == nil || !pi.IsSubPartitioned() || len(partitionNames) == 0 {
+		return partitionNames
+	}
+	ret := make([]model.CIStr, 0, len(partitionNames))
+	for _, name := range partitionNames {
+		found := false
+		for i, def := range pi.ParentDefinitions {
+			if def.Name.L == name.L {
+				for _, subDef := range pi.SubDefinitions(i) {
+					ret = append(ret, subDef.Name)
+				}
+				found = true
+				break
+			}
+		}
+		if !found {
+			ret = append(ret, name)
+		}
+	}
+	return ret
+}
+
 func (*partitionProcessor) name() string {
 	return "partition_processor"
 }
@@ -822,13 +916,8 @@ func intersectionRange(start, end, newStart, newEnd int) (int, int) {
 	return s, e
 }
 
-func (s *partitionProcessor) pruneRangePartition(ctx sessionctx.Context, pi *model.PartitionInfo, tbl table.PartitionedTable, conds []expression.Expression,
+func (s *partitionProcessor) pruneRangePartition(ctx sessionctx.Context, pi *model.PartitionInfo, partExpr *tables.PartitionExpr, conds []expression.Expression,
 	columns []*expression.Column, names types.NameSlice, condsToBePruned *[]expression.Expression) (partitionRangeOR, []expression.Expression, error) {
-	partExpr, err := tbl.(partitionTable).PartitionExpr()
-	if err != nil {
-		return nil, nil, err
-	}
-
 	// Partition by range columns.
 	if len(pi.Columns) > 0 {
 		result, err := s.pruneRangeColumnsPartition(ctx, conds, pi, partExpr, columns, names)
@@ -885,7 +974,11 @@ func (s *partitionProcessor) pruneRangePartition(ctx sessionctx.Context, pi *mod
 }
 
 func (s *partitionProcessor) processRangePartition(ds *DataSource, pi *model.PartitionInfo, opt *logicalOptimizeOp) (LogicalPlan, error) {
-	used, prunedConds, err := s.pruneRangePartition(ds.ctx, pi, ds.table.(table.PartitionedTable), ds.allConds, ds.TblCols, ds.names, &ds.pushedDownConds)
+	pe, err := ds.table.(partitionTable).PartitionExpr()
+	if err != nil {
+		return nil, err
+	}
+	used, prunedConds, err := s.pruneRangePartition(ds.ctx, pi, pe, ds.allConds, ds.TblCols, ds.names, &ds.pushedDownConds)
 	if err != nil {
 		return nil, err
 	}
@@ -896,7 +989,11 @@ func (s *partitionProcessor) processRangePartition(ds *DataSource, pi *model.Par
 }
 
 func (s *partitionProcessor) processListPartition(ds *DataSource, pi *model.PartitionInfo, opt *logicalOptimizeOp) (LogicalPlan, error) {
-	used, err := s.pruneListPartition(ds.SCtx(), ds.table, ds.partitionNames, ds.allConds)
+	pe, err := ds.table.(partitionTable).PartitionExpr()
+	if err != nil {
+		return nil, err
+	}
+	used, err := s.pruneListPartition(ds.SCtx(), pi, pe, ds.partitionNames, ds.allConds)
 	if err != nil {
 		return nil, err
 	}
@@ -909,6 +1006,22 @@ func (s *partitionProcessor) processListPartition(ds *DataSource, pi *model.Part
 	return tableDual, nil
 }
 
+func (s *partitionProcessor) processSubPartition(ds *DataSource, pi *model.PartitionInfo, opt *logicalOptimizeOp) (LogicalPlan, error) {
+	names, err := s.reconstructTableColNames(ds)
+	if err != nil {
+		return nil, err
+	}
+	pe, err := ds.table.(partitionTable).PartitionExpr()
+	if err != nil {
+		return nil, err
+	}
+	used, err := s.pruneSubPartitions(ds.SCtx(), pi, pe, ds.partitionNames, ds.allConds, ds.TblCols, names)
+	if err != nil {
+		return nil, err
+	}
+	return s.makeUnionAllChildren(ds, pi, convertToRangeOr(used, pi), opt)
+}
+
 // makePartitionByFnCol extracts the column and function information in 'partition by ... fn(col)'.
 func makePartitionByFnCol(sctx sessionctx.Context, columns []*expression.Column, names types.NameSlice, partitionExpr string) (*expression.Column, *expression.ScalarFunction, monotoneMode, error) {
 	monotonous := monotoneModeInvalid
@@ -1504,7 +1617,7 @@ func (s *partitionProcessor) pruneRangeColumnsPartition(ctx sessionctx.Context,
 	result := fullRange(len(pi.Definitions))
 
 	if len(pi.Columns) != 1 {
-		return result, nil
+		return pruneMultiRangeColumnsPartition(ctx, conds, pi, pe.ForRangeColumnsPruning, columns, names)
 	}
 
 	pruner, err := makeRangeColumnPruner(columns, names, pi, pe.ForRangeColumnsPruning)
@@ -1529,11 +1642,96 @@ func makeRangeColumnPruner(columns []*expression.Column, names types.NameSlice,
 	partCol := schema.Columns[idx]
 	data := make([]expression.Expression, len(from.LessThan))
 	for i := 0; i < len(from.LessThan); i++ {
-		if from.LessThan[i] != nil {
-			data[i] = from.LessThan[i].Clone()
+		if from.LessThan[i][0] != nil {
+			data[i] = from.LessThan[i][0].Clone()
+		}
+	}
+	maxvalue := len(data) > 0 && data[len(data)-1] == nil
+	return &rangeColumnsPruner{data, partCol, maxvalue}, nil
+}
+
+// pruneMultiRangeColumnsPartition prunes the range columns partition with multiple columns. The ranges built on the
+// partition columns are located by comparing their low and high tuples with the tuples of VALUES LESS THAN.
+func pruneMultiRangeColumnsPartition(ctx sessionctx.Context, conds []expression.Expression, pi *model.PartitionInfo,
+	from *tables.ForRangeColumnsPruning, columns []*expression.Column, names types.NameSlice) (partitionRangeOR, error) {
+	result := fullRange(len(pi.Definitions))
+	sc := ctx.GetSessionVars().StmtCtx
+	partCols := make([]*expression.Column, 0, len(pi.Columns))
+	colLen := make([]int, 0, len(pi.Columns))
+	for _, col := range pi.Columns {
+		idx := expression.FindFieldNameIdxByColName(names, col.L)
+		if idx < 0 {
+			return result, nil
+		}
+		partCols = append(partCols, columns[idx])
+		colLen = append(colLen, types.UnspecifiedLength)
+	}
+	// MAXVALUE is regarded as the MaxValue datum, which is greater than any value.
+	lessThan := make([][]types.Datum, len(from.LessThan))
+	for i, exprs := range from.LessThan {
+		lessThan[i] = make([]types.Datum, len(exprs))
+		for j, expr := range exprs {
+			if expr == nil {
+				lessThan[i][j] = types.MaxValueDatum()
+				continue
+			}
+			d, err := expr.Eval(chunk.Row{})
+			if err != nil {
+				return result, nil
+			}
+			if partCols[j].RetType.EvalType() != types.ETString {
+				if d, err = d.ConvertTo(sc, partCols[j].RetType); err != nil {
+					return result, nil
+				}
+			}
+			lessThan[i][j] = d
+		}
+	}
+
+	detachedResult, err := ranger.DetachCondAndBuildRangeForPartition(ctx, conds, partCols, colLen)
+	if err != nil {
+		return nil, err
+	}
+	var cmpErr error
+	// compare compares VALUES LESS THAN of the i-th partition with the prefix tuple vals.
+	compare := func(i int, vals []types.Datum) int {
+		for j := range vals {
+			cmp, err := lessThan[i][j].Compare(sc, &vals[j], collate.GetCollator(partCols[j].RetType.Collate))
+			if err != nil {
+				cmpErr = err
+				return 0
+			}
+			if cmp != 0 {
+				return cmp
+			}
 		}
+		return 0
+	}
+	length := len(pi.Definitions)
+	used := make(partitionRangeOR, 0, len(detachedResult.Ranges))
+	for _, r := range detachedResult.Ranges {
+		// The i-th partition contains the tuples in [LessThan[i-1], LessThan[i]). The columns missed in the range
+		// are regarded as -inf in the low tuple and +inf in the high tuple.
+		start := sort.Search(length, func(i int) bool {
+			cmp := compare(i, r.LowVal)
+			return cmp > 0 || (cmp == 0 && len(r.LowVal) < len(partCols) && !r.LowExclude)
+		})
+		end := sort.Search(length, func(i int) bool {
+			cmp := compare(i, r.HighVal)
+			return cmp > 0 || (cmp == 0 && r.HighExclude)
+		})
+		// The end-th partition contains the high tuple.
+		if end < length {
+			end++
+		}
+		if start < end {
+			used = append(used, partitionRange{start, end})
+		}
+	}
+	if cmpErr != nil {
+		return result, nil
 	}
-	return &rangeColumnsPruner{data, partCol, from.MaxValue}, nil
+	return used.simplify(), nil
 }
 
 func (p *rangeColumnsPruner) fullRange() partitionRangeOR {
@@ -106,6 +106,16 @@ func (p *LogicalShowDDLJobs) DeriveStats(childStats []*property.StatsInfo, selfS
 	return p.stats, nil
 }
 
+// DeriveStats implement LogicalPlan DeriveStats interface.
+func (p *LogicalJSONTable) DeriveStats(childStats []*property.StatsInfo, selfSchema *expression.Schema, childSchema []*expression.Schema, _ [][]*expression.Column) (*property.StatsInfo, error) {
+	if p.stats != nil {
+		return p.stats, nil
+	}
+	// The number of rows depends on the JSON document, use a fake count for now.
+	p.stats = getFakeStats(selfSchema)
+	return p.stats, nil
+}
+
 // RecursiveDeriveStats4Test is a exporter just for test.
 func RecursiveDeriveStats4Test(p LogicalPlan) (*property.StatsInfo, error) {
 	return p.recursiveDeriveStats(nil)
@@ -217,9 +227,71 @@ func (ds *DataSource) getGroupNDVs(colGroups [][]*expression.Column) []property.
 			}
 		}
 	}
+	if ds.ctx.GetSessionVars().EnableExtendedStats {
+		ndvs = ds.appendExtStatsGroupNDVs(ndvs, colGroups)
+	}
+	return ndvs
+}
+
+// appendExtStatsGroupNDVs appends the NDVs of the column groups which are collected by the cardinality extended stats
+// and not covered by any index.
+func (ds *DataSource) appendExtStatsGroupNDVs(ndvs []property.GroupNDV, colGroups [][]*expression.Column) []property.GroupNDV {
+	extStats := ds.tableStats.HistColl.ExtendedStats
+	if extStats == nil || len(extStats.Stats) == 0 {
+		return ndvs
+	}
+	colID2UniqueID := make(map[int64]int64, ds.schema.Len())
+	for _, col := range ds.schema.Columns {
+		colID2UniqueID[col.ID] = col.UniqueID
+	}
+	for _, item := range extStats.Stats {
+		if item.Tp != ast.StatsTypeCardinality || item.ScalarVals < 1 {
+			continue
+		}
+		cols := make([]int64, 0, len(item.ColIDs))
+		for _, colID := range item.ColIDs {
+			uniqueID, ok := colID2UniqueID[colID]
+			if !ok {
+				break
+			}
+			cols = append(cols, uniqueID)
+		}
+		if len(cols) != len(item.ColIDs) {
+			continue
+		}
+		sort.Slice(cols, func(i, j int) bool {
+			return cols[i] < cols[j]
+		})
+		for _, g := range colGroups {
+			if len(g) != len(cols) || !isSameGroupCols(g, cols) {
+				continue
+			}
+			covered := false
+			for _, ndv := range ndvs {
+				if len(ndv.Cols) == len(cols) && isSameGroupCols(g, ndv.Cols) {
+					covered = true
+					break
+				}
+			}
+			if !covered {
+				ndvs = append(ndvs, property.GroupNDV{Cols: cols, NDV: item.ScalarVals})
+			}
+			break
+		}
+	}
 	return ndvs
 }
 
+// isSameGroupCols checks whether the sorted column group consists of the sorted unique IDs.
+func isSameGroupCols(g []*expression.Column, uniqueIDs []int64) bool {
+	for i, col := range g {
+		if col.UniqueID != uniqueIDs[i] {
+			return false
+		}
+	}
+	return true
+}
+
 func (ds *DataSource) initStats(colGroups [][]*expression.Column) {
 	if ds.tableStats != nil {
 		// Reload GroupNDVs since colGroups may have changed.
@@ -276,8 +348,12 @@ func (ds *DataSource) derivePathStatsAndTryHeuristics() error {
 			}
 			path.IsSingleScan = true
 		} else {
-			ds.deriveIndexPathStats(path, ds.pushedDownConds, false)
-			path.IsSingleScan = ds.isCoveringIndex(ds.schema.Columns, path.FullIdxCols, path.FullIdxColLens, ds.tableInfo)
+			// The access condition of the multi-valued, FULLTEXT or SPATIAL index isn't counted in the stats of the data source,
+			// so its row count can't be adjusted by them.
+			invertedIndex := path.Index.MVIndex || path.Index.FullText || path.Index.Spatial
+			ds.deriveIndexPathStats(path, ds.pushedDownConds, invertedIndex)
+			// The multi-valued, FULLTEXT or SPATIAL index doesn't store the indexed values themselves, so it always needs to read the table.
+			path.IsSingleScan = !invertedIndex && ds.isCoveringIndex(ds.schema.Columns, path.FullIdxCols, path.FullIdxColLens, ds.tableInfo)
 		}
 		// Try some heuristic rules to select access path.
 		if len(path.Ranges) == 0 {
@@ -376,6 +452,27 @@ func (ds *DataSource) derivePathStatsAndTryHeuristics() error {
 	return nil
 }
 
+// adjustStatsByInvertedIndexPaths bounds the row count of the DataSource by the multi-valued, FULLTEXT and SPATIAL
+// index paths. The selectivity of their predicates, such as MEMBER OF, MATCH ... AGAINST or ST_Contains, can't be
+// estimated by the column stats, but the rows accessed by the index paths are the upper bound of the rows returned
+// by the DataSource.
+func (ds *DataSource) adjustStatsByInvertedIndexPaths(indexMergePaths ...[]*util.AccessPath) {
+	minRowCount := ds.stats.RowCount
+	for _, path := range ds.possibleAccessPaths {
+		if !path.IsTablePath() && (path.Index.MVIndex || path.Index.FullText || path.Index.Spatial) {
+			minRowCount = math.Min(minRowCount, path.CountAfterAccess)
+		}
+	}
+	for _, paths := range indexMergePaths {
+		for _, path := range paths {
+			minRowCount = math.Min(minRowCount, path.CountAfterAccess)
+		}
+	}
+	if minRowCount < ds.stats.RowCount {
+		ds.stats = ds.tableStats.ScaleByExpectCnt(minRowCount)
+	}
+}
+
 // DeriveStats implement LogicalPlan DeriveStats interface.
 func (ds *DataSource) DeriveStats(childStats []*property.StatsInfo, selfSchema *expression.Schema, childSchema []*expression.Schema, colGroups [][]*expression.Column) (*property.StatsInfo, error) {
 	if ds.stats != nil && len(colGroups) == 0 {
@@ -392,8 +489,21 @@ func (ds *DataSource) DeriveStats(childStats []*property.StatsInfo, selfSchema *
 	for i, expr := range ds.pushedDownConds {
 		ds.pushedDownConds[i] = expression.PushDownNot(ds.ctx, expr)
 	}
+	// The predicates of the multi-valued index, such as MEMBER OF, can't be pushed down, so all the conditions are used.
+	mvIndexMergePaths, err := ds.fillMVIndexPaths(ds.allConds)
+	if err != nil {
+		return nil, err
+	}
+	fullTextIndexMergePaths, err := ds.fillFullTextIndexPaths(ds.allConds)
+	if err != nil {
+		return nil, err
+	}
+	spatialIndexMergePaths, err := ds.fillSpatialIndexPaths(ds.allConds)
+	if err != nil {
+		return nil, err
+	}
 	for _, path := range ds.possibleAccessPaths {
-		if path.IsTablePath() {
+		if path.IsTablePath() || path.Index.MVIndex || path.Index.FullText || path.Index.Spatial {
 			continue
 		}
 		err := ds.fillIndexPath(path, ds.pushedDownConds)
@@ -404,7 +514,8 @@ func (ds *DataSource) DeriveStats(childStats []*property.StatsInfo, selfSchema *
 	// TODO: Can we move ds.deriveStatsByFilter after pruning by heuristics? In this way some computation can be avoided
 	// when ds.possibleAccessPaths are pruned.
 	ds.stats = ds.deriveStatsByFilter(ds.pushedDownConds, ds.possibleAccessPaths)
-	err := ds.derivePathStatsAndTryHeuristics()
+	ds.adjustStatsByInvertedIndexPaths(mvIndexMergePaths, fullTextIndexMergePaths, spatialIndexMergePaths)
+	err = ds.derivePathStatsAndTryHeuristics()
 	if err != nil {
 		return nil, err
 	}
@@ -448,6 +559,12 @@ func (ds *DataSource) DeriveStats(childStats []*property.StatsInfo, selfSchema *
 		if err != nil {
 			return nil, err
 		}
+	} else if isPossibleIdxMerge && sessionAndStmtPermission && ds.tableInfo.TempTableType != model.TempTableLocal {
+		// Some index paths have access conditions, the IndexMerge intersection path is considered with them and
+		// chosen by cost.
+		if path := ds.generateIndexMergeAndPath(len(ds.possibleAccessPaths)); path != nil {
+			ds.possibleAccessPaths = append(ds.possibleAccessPaths, path)
+		}
 	} else if len(ds.indexMergeHints) > 0 {
 		ds.indexMergeHints = nil
 		var msg string
@@ -462,6 +579,9 @@ func (ds *DataSource) DeriveStats(childStats []*property.StatsInfo, selfSchema *
 		stmtCtx.AppendWarning(errors.Errorf(msg))
 		logutil.BgLogger().Debug(msg)
 	}
+	ds.possibleAccessPaths = append(ds.possibleAccessPaths, mvIndexMergePaths...)
+	ds.possibleAccessPaths = append(ds.possibleAccessPaths, fullTextIndexMergePaths...)
+	ds.possibleAccessPaths = append(ds.possibleAccessPaths, spatialIndexMergePaths...)
 	return ds.stats, nil
 }
 
@@ -471,6 +591,12 @@ func (ds *DataSource) generateAndPruneIndexMergePath(indexMergeConds []expressio
 	if err != nil {
 		return err
 	}
+	// Only when no IndexMerge union path is generated, we try to intersect the indexes used by the CNF conditions.
+	if regularPathCount == len(ds.possibleAccessPaths) {
+		if path := ds.generateIndexMergeAndPath(regularPathCount); path != nil {
+			ds.possibleAccessPaths = append(ds.possibleAccessPaths, path)
+		}
+	}
 	// If without hints, it means that `enableIndexMerge` is true
 	if len(ds.indexMergeHints) == 0 {
 		return nil
@@ -674,7 +800,7 @@ func (ds *DataSource) accessPathsForConds(conditions []expression.Expression, us
 			}
 		} else {
 			path.Index = ds.possibleAccessPaths[i].Index
-			if !ds.isInIndexMergeHints(path.Index.Name.L) {
+			if path.Index.MVIndex || path.Index.FullText || path.Index.Spatial || !ds.isInIndexMergeHints(path.Index.Name.L) {
 				continue
 			}
 			err := ds.fillIndexPath(path, conditions)
@@ -753,6 +879,90 @@ func (ds *DataSource) buildIndexMergeOrPath(filters []expression.Expression, par
 	return indexMergePath
 }
 
+// indexMergeIntersectionMinReduction is how many times fewer rows the IndexMerge intersection path must be estimated
+// to read than the best single partial path, to be considered without the USE_INDEX_MERGE hint.
+const indexMergeIntersectionMinReduction = 10
+
+// generateIndexMergeAndPath generates an IndexMerge intersection path from the first normalPathCnt access paths.
+// Every partial path reads the handles matching some of the CNF conditions, and only the handles returned by all
+// the partial paths are used to look up the table. It returns nil if less than two indexes can be used.
+// Without the USE_INDEX_MERGE hint, the path is only generated with real statistics and when it clearly reduces
+// the rows to look up, since the pseudo selectivity of the CNF conditions always makes it look cheaper.
+func (ds *DataSource) generateIndexMergeAndPath(normalPathCnt int) *util.AccessPath {
+	hinted := len(ds.indexMergeHints) > 0
+	if !hinted && ds.statisticTable.Pseudo {
+		return nil
+	}
+	candidates := ds.accessPathsForConds(ds.pushedDownConds, normalPathCnt)
+	if len(candidates) < 2 {
+		return nil
+	}
+	// Prefer the paths returning fewer handles, and skip the paths whose access conditions are all covered by the
+	// paths chosen before.
+	estRowCount := func(path *util.AccessPath) float64 {
+		if len(path.IndexFilters) > 0 {
+			return path.CountAfterIndex
+		}
+		return path.CountAfterAccess
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return estRowCount(candidates[i]) < estRowCount(candidates[j])
+	})
+	partialPaths := make([]*util.AccessPath, 0, len(candidates))
+	coveredConds := make([]expression.Expression, 0, len(ds.pushedDownConds))
+	partialConds := make([]expression.Expression, 0, len(ds.pushedDownConds))
+	for _, path := range candidates {
+		if len(path.AccessConds) == 0 || !hasUncoveredCond(path.AccessConds, coveredConds) {
+			continue
+		}
+		// The index filters are evaluated by the partial index scan, so they must be pushed to TiKV.
+		if len(path.IndexFilters) != 0 && !expression.CanExprsPushDown(ds.ctx.GetSessionVars().StmtCtx, path.IndexFilters, ds.ctx.GetClient(), kv.TiKV) {
+			path.IndexFilters = nil
+		}
+		partialConds = append(partialConds, path.AccessConds...)
+		partialConds = append(partialConds, path.IndexFilters...)
+		// The conditions kept in the table filters, such as the ones on the prefix index columns, are not covered
+		// by this partial path.
+		for _, conds := range [][]expression.Expression{path.AccessConds, path.IndexFilters} {
+			for _, cond := range conds {
+				if !expression.Contains(path.TableFilters, cond) {
+					coveredConds = append(coveredConds, cond)
+				}
+			}
+		}
+		partialPaths = append(partialPaths, path)
+	}
+	if len(partialPaths) < 2 {
+		return nil
+	}
+	indexMergePath := &util.AccessPath{PartialIndexPaths: partialPaths, IndexMergeIsIntersection: true}
+	for _, cond := range ds.pushedDownConds {
+		if !expression.Contains(coveredConds, cond) {
+			indexMergePath.TableFilters = append(indexMergePath.TableFilters, cond)
+		}
+	}
+	sel, _, err := ds.tableStats.HistColl.Selectivity(ds.ctx, partialConds, nil)
+	if err != nil {
+		logutil.BgLogger().Debug("something wrong happened, use the default selectivity", zap.Error(err))
+		sel = SelectionFactor
+	}
+	indexMergePath.CountAfterAccess = sel * ds.tableStats.RowCount
+	if !hinted && indexMergePath.CountAfterAccess*indexMergeIntersectionMinReduction > estRowCount(partialPaths[0]) {
+		return nil
+	}
+	return indexMergePath
+}
+
+// hasUncoveredCond checks whether some of the conditions are not in the covered ones.
+func hasUncoveredCond(conds, coveredConds []expression.Expression) bool {
+	for _, cond := range conds {
+		if !expression.Contains(coveredConds, cond) {
+			return true
+		}
+	}
+	return false
+}
+
 // DeriveStats implement LogicalPlan DeriveStats interface.
 func (p *LogicalSelection) DeriveStats(childStats []*property.StatsInfo, selfSchema *expression.Schema, childSchema []*expression.Schema, _ [][]*expression.Column) (*property.StatsInfo, error) {
 	if p.stats != nil {
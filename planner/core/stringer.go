@@ -191,6 +191,8 @@ func toString(in Plan, strs []string, idxs []int) ([]string, []int) {
 		str = fmt.Sprintf("TopN(%v,%d,%d)", x.ByItems, x.Offset, x.Count)
 	case *LogicalTableDual, *PhysicalTableDual:
 		str = "Dual"
+	case *LogicalJSONTable, *PhysicalJSONTable:
+		str = "JSONTable"
 	case *PhysicalHashAgg:
 		str = "HashAgg"
 	case *PhysicalStreamAgg:
@@ -81,6 +81,23 @@ func GetExecuteForUpdateReadIS(node ast.Node, sctx sessionctx.Context) infoschem
 	return nil
 }
 
+// attachHypoIndexes attaches the hypothetical indexes of the session to the tables seen by the optimizer when
+// planning an EXPLAIN statement. The overlay is put under the local temporary tables, which can't have the
+// hypothetical indexes, so the tables in views can still be resolved without the local temporary tables.
+func attachHypoIndexes(sctx sessionctx.Context, is infoschema.InfoSchema) infoschema.InfoSchema {
+	sessVars := sctx.GetSessionVars()
+	if !sessVars.StmtCtx.InExplainStmt || len(sessVars.HypoIndexes) == 0 {
+		return is
+	}
+	if ts, ok := is.(*infoschema.TemporaryTableAttachedInfoSchema); ok {
+		return &infoschema.TemporaryTableAttachedInfoSchema{
+			InfoSchema:           &infoschema.HypoIndexAttachedInfoSchema{InfoSchema: ts.InfoSchema, HypoIndexes: sessVars.HypoIndexes},
+			LocalTemporaryTables: ts.LocalTemporaryTables,
+		}
+	}
+	return &infoschema.HypoIndexAttachedInfoSchema{InfoSchema: is, HypoIndexes: sessVars.HypoIndexes}
+}
+
 func matchSQLBinding(sctx sessionctx.Context, stmtNode ast.StmtNode) (bindRecord *bindinfo.BindRecord, scope string, matched bool) {
 	useBinding := sctx.GetSessionVars().UsePlanBaselines
 	if !useBinding || stmtNode == nil {
@@ -98,6 +115,7 @@ func matchSQLBinding(sctx sessionctx.Context, stmtNode ast.StmtNode) (bindRecord
 // The node must be prepared first.
 func Optimize(ctx context.Context, sctx sessionctx.Context, node ast.Node, is infoschema.InfoSchema) (plannercore.Plan, types.NameSlice, error) {
 	sessVars := sctx.GetSessionVars()
+	is = attachHypoIndexes(sctx, is)
 
 	if !sctx.GetSessionVars().InRestrictedSQL && variable.RestrictedReadOnly.Load() || variable.VarTiDBSuperReadOnly.Load() {
 		allowed, err := allowInReadOnlyMode(sctx, node)
@@ -387,6 +405,17 @@ func optimize(ctx context.Context, sctx sessionctx.Context, node ast.Node, is in
 	return finalPlan, names, cost, err
 }
 
+// OptimizeWithCost optimizes the statement without the plan bindings and the plan cache, and returns the
+// estimated cost of the physical plan. It's used to compare the plans of a statement under different sets of
+// indexes, and the statement must have been preprocessed with the same infoschema.
+func OptimizeWithCost(ctx context.Context, sctx sessionctx.Context, node ast.Node, is infoschema.InfoSchema) (plannercore.Plan, float64, error) {
+	tableHints := hint.ExtractTableHintsFromStmtNode(node, sctx)
+	stmtHints, _, _ := handleStmtHints(tableHints)
+	sctx.GetSessionVars().StmtCtx.StmtHints = stmtHints
+	p, _, cost, err := optimize(ctx, sctx, node, is)
+	return p, cost, err
+}
+
 // ExtractSelectAndNormalizeDigest extract the select statement and normalize it.
 func ExtractSelectAndNormalizeDigest(stmtNode ast.StmtNode, specifiledDB string) (ast.StmtNode, string, string, error) {
 	switch x := stmtNode.(type) {
@@ -49,6 +49,9 @@ type AccessPath struct {
 	// PartialIndexPaths store all index access paths.
 	// If there are extra filters, store them in TableFilters.
 	PartialIndexPaths []*AccessPath
+	// IndexMergeIsIntersection indicates whether the handles of the partial paths are intersected rather than
+	// united, that is, whether the IndexMerge path is generated from CNF conditions.
+	IndexMergeIsIntersection bool
 
 	StoreType kv.StoreType
 
@@ -1530,6 +1530,11 @@ func TestLoadDataPrivilege(t *testing.T) {
 	err = tk.ExecToErr("LOAD DATA LOCAL INFILE '/tmp/load_data_priv.csv' REPLACE INTO TABLE t_load")
 	require.Error(t, err)
 	require.True(t, terror.ErrorEqual(err, core.ErrTableaccessDenied))
+
+	// LOAD DATA INFILE reads the file on the server, which requires the FILE privilege.
+	err = tk.ExecToErr("LOAD DATA INFILE '/tmp/load_data_priv.csv' INTO TABLE t_load")
+	require.Error(t, err)
+	require.True(t, terror.ErrorEqual(err, core.ErrSpecificAccessDenied))
 }
 
 func TestSelectIntoNoPermissions(t *testing.T) {
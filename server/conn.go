@@ -86,6 +86,7 @@ import (
 	"github.com/pingcap/tidb/util/hack"
 	"github.com/pingcap/tidb/util/logutil"
 	"github.com/pingcap/tidb/util/memory"
+	"github.com/pingcap/tidb/util/sqlexec"
 	topsqlstate "github.com/pingcap/tidb/util/topsql/state"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/tikv/client-go/v2/util"
@@ -189,6 +190,7 @@ type clientConn struct {
 	status        int32             // dispatching/reading/shutdown/waitshutdown
 	lastCode      uint16            // last error code
 	collation     uint8             // collation used by client, may be different from the collation used by database.
+	zstdLevel     int               // zstd compression level requested by client.
 	lastActive    time.Time         // last active time
 	authPlugin    string            // default authentication plugin
 	isUnixSocket  bool              // connection is Unix Socket file
@@ -303,6 +305,14 @@ func (cc *clientConn) handshake(ctx context.Context) error {
 		logutil.Logger(ctx).Debug("flush response to client failed", zap.Error(err))
 		return err
 	}
+
+	// The compressed protocol takes effect after the handshake OK packet, zstd is preferred
+	// if the client supports both algorithms.
+	if cc.capability&mysql.ClientZstdCompressionAlgorithm > 0 {
+		cc.pkt.setCompressionAlgorithm(mysql.CompressionZstd, cc.zstdLevel)
+	} else if cc.capability&mysql.ClientCompress > 0 {
+		cc.pkt.setCompressionAlgorithm(mysql.CompressionZlib, 0)
+	}
 	return err
 }
 
@@ -429,6 +439,7 @@ type handshakeResponse41 struct {
 	Auth       []byte
 	AuthPlugin string
 	Attrs      map[string]string
+	ZstdLevel  int
 }
 
 // parseOldHandshakeResponseHeader parses the old version handshake header HandshakeResponse320
@@ -575,6 +586,7 @@ func parseHandshakeResponseBody(ctx context.Context, packet *handshakeResponse41
 		if num, null, off := parseLengthEncodedInt(data[offset:]); !null {
 			offset += off
 			row := data[offset : offset+int(num)]
+			offset += int(num)
 			attrs, err := parseAttrs(row)
 			if err != nil {
 				logutil.Logger(ctx).Warn("parse attrs failed", zap.Error(err))
@@ -584,6 +596,14 @@ func parseHandshakeResponseBody(ctx context.Context, packet *handshakeResponse41
 		}
 	}
 
+	if packet.Capability&mysql.ClientZstdCompressionAlgorithm > 0 {
+		packet.ZstdLevel = mysql.DefaultZstdCompressionLevel
+		// The compression level is the last byte of the packet, tolerate the clients omitting it.
+		if len(data[offset:]) > 0 && data[offset] >= 1 && data[offset] <= 22 {
+			packet.ZstdLevel = int(data[offset])
+		}
+	}
+
 	return nil
 }
 
@@ -688,6 +708,7 @@ func (cc *clientConn) readOptionalSSLRequestAndHandshakeResponse(ctx context.Con
 	cc.dbname = resp.DBName
 	cc.collation = resp.Collation
 	cc.attrs = resp.Attrs
+	cc.zstdLevel = resp.ZstdLevel
 
 	err = cc.handleAuthPlugin(ctx, &resp)
 	if err != nil {
@@ -1134,7 +1155,7 @@ func (cc *clientConn) Run(ctx context.Context) {
 			terror.Log(err1)
 		}
 		cc.addMetrics(data[0], startTime, err)
-		cc.pkt.sequence = 0
+		cc.pkt.resetSequence()
 	}
 }
 
@@ -1547,29 +1568,6 @@ func (cc *clientConn) writeReq(ctx context.Context, filePath string) error {
 	return cc.flush(ctx)
 }
 
-func insertDataWithCommit(ctx context.Context, prevData,
-	curData []byte, loadDataInfo *executor.LoadDataInfo) ([]byte, error) {
-	var err error
-	var reachLimit bool
-	for {
-		prevData, reachLimit, err = loadDataInfo.InsertData(ctx, prevData, curData)
-		if err != nil {
-			return nil, err
-		}
-		if !reachLimit {
-			break
-		}
-		// push into commit task queue
-		err = loadDataInfo.EnqOneTask(ctx)
-		if err != nil {
-			return prevData, err
-		}
-		curData = prevData
-		prevData = nil
-	}
-	return prevData, nil
-}
-
 // processStream process input stream from network
 func processStream(ctx context.Context, cc *clientConn, loadDataInfo *executor.LoadDataInfo, wg *sync.WaitGroup) {
 	var err error
@@ -1613,7 +1611,7 @@ func processStream(ctx context.Context, cc *clientConn, loadDataInfo *executor.L
 			break
 		}
 		// prepare batch and enqueue task
-		prevData, err = insertDataWithCommit(ctx, prevData, curData, loadDataInfo)
+		prevData, err = loadDataInfo.InsertDataWithCommit(ctx, prevData, curData)
 		if err != nil {
 			break
 		}
@@ -1633,17 +1631,23 @@ func processStream(ctx context.Context, cc *clientConn, loadDataInfo *executor.L
 
 // handleLoadData does the additional work after processing the 'load data' query.
 // It sends client a file path, then reads the file content from client, inserts data into database.
+// For LOAD DATA INFILE without LOCAL, the files are read from the external storage instead.
 func (cc *clientConn) handleLoadData(ctx context.Context, loadDataInfo *executor.LoadDataInfo) error {
-	// If the server handles the load data request, the client has to set the ClientLocalFiles capability.
-	if cc.capability&mysql.ClientLocalFiles == 0 {
-		return errNotAllowedCommand
-	}
 	if loadDataInfo == nil {
 		return errors.New("load data info is empty")
 	}
 	if !loadDataInfo.Table.Meta().IsBaseTable() {
 		return errors.New("can only load data into base tables")
 	}
+	if !loadDataInfo.IsLocal {
+		err := loadDataInfo.LoadFromStorage(ctx)
+		loadDataInfo.SetMessage()
+		return err
+	}
+	// If the server handles the load data request, the client has to set the ClientLocalFiles capability.
+	if cc.capability&mysql.ClientLocalFiles == 0 {
+		return errNotAllowedCommand
+	}
 	err := cc.writeReq(ctx, loadDataInfo.Path)
 	if err != nil {
 		return err
@@ -1731,8 +1735,8 @@ func (cc *clientConn) handleLoadStats(ctx context.Context, loadStatsInfo *execut
 	return loadStatsInfo.Update(data)
 }
 
-// handleIndexAdvise does the index advise work and returns the advise result for index.
-func (cc *clientConn) handleIndexAdvise(ctx context.Context, indexAdviseInfo *executor.IndexAdviseInfo) error {
+// handleIndexAdvise does the index advise work and writes the advise result for index as a result set.
+func (cc *clientConn) handleIndexAdvise(ctx context.Context, indexAdviseInfo *executor.IndexAdviseInfo, status uint16) error {
 	if cc.capability&mysql.ClientLocalFiles == 0 {
 		return errNotAllowedCommand
 	}
@@ -1751,9 +1755,8 @@ func (cc *clientConn) handleIndexAdvise(ctx context.Context, indexAdviseInfo *ex
 	if err := indexAdviseInfo.GetIndexAdvice(ctx, data); err != nil {
 		return err
 	}
-
-	// TODO: Write the rss []ResultSet. It will be done in another PR.
-	return nil
+	_, err = cc.writeResultset(ctx, &tidbResultSet{recordSet: indexAdviseInfo.Result}, false, status, 0)
+	return err
 }
 
 func (cc *clientConn) handlePlanReplayerLoad(ctx context.Context, planReplayerLoadInfo *executor.PlanReplayerLoadInfo) error {
@@ -1976,6 +1979,10 @@ func (cc *clientConn) prefetchPointPlanKeys(ctx context.Context, stmts []ast.Stm
 func (cc *clientConn) handleStmt(ctx context.Context, stmt ast.StmtNode, warns []stmtctx.SQLWarn, lastStmt bool) (bool, error) {
 	ctx = context.WithValue(ctx, execdetails.StmtExecDetailKey, &execdetails.StmtExecDetails{})
 	ctx = context.WithValue(ctx, util.ExecDetailsKey, &util.ExecDetails{})
+	if _, ok := stmt.(*ast.CallStmt); ok {
+		// The result sets of the stored procedure are written as soon as they are produced.
+		ctx = context.WithValue(ctx, sqlexec.ResultSetWriterKey, sqlexec.ResultSetWriter(cc.writeCallResultset))
+	}
 	reg := trace.StartRegion(ctx, "ExecuteStmt")
 	cc.audit(plugin.Starting)
 	rs, err := cc.ctx.ExecuteStmt(ctx, stmt)
@@ -2019,6 +2026,13 @@ func (cc *clientConn) handleStmt(ctx context.Context, stmt ast.StmtNode, warns [
 	return false, nil
 }
 
+// writeCallResultset writes a result set produced by a statement of a stored procedure while the CALL
+// statement is being executed, the result sets are followed by the OK packet of the CALL statement.
+func (cc *clientConn) writeCallResultset(ctx context.Context, rs sqlexec.RecordSet) error {
+	_, err := cc.writeResultset(ctx, &tidbResultSet{recordSet: rs}, false, cc.ctx.Status()|mysql.ServerMoreResultsExists, 0)
+	return err
+}
+
 func (cc *clientConn) handleQuerySpecial(ctx context.Context, status uint16) (bool, error) {
 	handled := false
 	loadDataInfo := cc.ctx.Value(executor.LoadDataVarKey)
@@ -2043,9 +2057,8 @@ func (cc *clientConn) handleQuerySpecial(ctx context.Context, status uint16) (bo
 	if indexAdvise != nil {
 		handled = true
 		defer cc.ctx.SetValue(executor.IndexAdviseVarKey, nil)
-		if err := cc.handleIndexAdvise(ctx, indexAdvise.(*executor.IndexAdviseInfo)); err != nil {
-			return handled, err
-		}
+		// The advice is written as a result set, which isn't followed by an OK packet.
+		return handled, cc.handleIndexAdvise(ctx, indexAdvise.(*executor.IndexAdviseInfo), status)
 	}
 
 	planReplayerLoad := cc.ctx.Value(executor.PlanReplayerLoadVarKey)
@@ -98,6 +98,20 @@ func TestParseHandshakeResponse(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, "pam", p.User)
 	require.Equal(t, "test", p.DBName)
+	require.Equal(t, 0, p.ZstdLevel)
+
+	// The zstd compression level follows the auth plugin name.
+	zstdData := append([]byte{}, data...)
+	zstdData[3] = 0x04
+	zstdData = append(zstdData, 0x07)
+	p = handshakeResponse41{}
+	offset, err = parseHandshakeResponseHeader(context.Background(), &p, zstdData)
+	require.NoError(t, err)
+	require.Equal(t, mysql.ClientZstdCompressionAlgorithm, p.Capability&mysql.ClientZstdCompressionAlgorithm)
+	err = parseHandshakeResponseBody(context.Background(), &p, zstdData, offset)
+	require.NoError(t, err)
+	require.Equal(t, "mysql_native_password", p.AuthPlugin)
+	require.Equal(t, 7, p.ZstdLevel)
 
 	// Test for compatibility of Protocol::HandshakeResponse320
 	data = []byte{
@@ -37,13 +37,18 @@ package server
 
 import (
 	"bufio"
+	"bytes"
+	"compress/zlib"
 	"io"
+	"sync"
 	"time"
 
+	"github.com/klauspost/compress/zstd"
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/metrics"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/parser/terror"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 const defaultWriterSize = 16 * 1024
@@ -53,12 +58,24 @@ var (
 	writePacketBytes = metrics.PacketIOCounter.WithLabelValues("write")
 )
 
+// compressedHeaderLen is the length of the header of a compressed protocol frame:
+// 3 bytes compressed length, 1 byte sequence and 3 bytes uncompressed length.
+const compressedHeaderLen = 7
+
 // packetIO is a helper to read and write data in packet format.
 type packetIO struct {
 	bufReadConn *bufferedReadConn
 	bufWriter   *bufio.Writer
 	sequence    uint8
 	readTimeout time.Duration
+
+	// compressionAlgorithm is one of mysql.CompressionNone, mysql.CompressionZlib and mysql.CompressionZstd.
+	// When compression is enabled, the packets are wrapped into compressed frames by
+	// compressedReader and compressedWriter.
+	compressionAlgorithm int
+	zstdLevel            int
+	compressedSequence   uint8
+	compressedReader     *compressedReader
 }
 
 func newPacketIO(bufReadConn *bufferedReadConn) *packetIO {
@@ -69,7 +86,28 @@ func newPacketIO(bufReadConn *bufferedReadConn) *packetIO {
 
 func (p *packetIO) setBufferedReadConn(bufReadConn *bufferedReadConn) {
 	p.bufReadConn = bufReadConn
-	p.bufWriter = bufio.NewWriterSize(bufReadConn, defaultWriterSize)
+	if p.compressionAlgorithm == mysql.CompressionNone {
+		p.compressedReader = nil
+		p.bufWriter = bufio.NewWriterSize(bufReadConn, defaultWriterSize)
+		return
+	}
+	p.compressedReader = newCompressedReader(bufReadConn, p)
+	p.bufWriter = bufio.NewWriterSize(newCompressedWriter(bufReadConn, p), defaultWriterSize)
+}
+
+// setCompressionAlgorithm switches the connection to the compressed protocol. It must be
+// called after the handshake is finished and the pending packets are flushed.
+func (p *packetIO) setCompressionAlgorithm(algorithm int, zstdLevel int) {
+	p.compressionAlgorithm = algorithm
+	p.zstdLevel = zstdLevel
+	p.compressedSequence = 0
+	p.setBufferedReadConn(p.bufReadConn)
+}
+
+// resetSequence resets the sequence of packets and compressed frames at the beginning of a command.
+func (p *packetIO) resetSequence() {
+	p.sequence = 0
+	p.compressedSequence = 0
 }
 
 func (p *packetIO) setReadTimeout(timeout time.Duration) {
@@ -83,16 +121,22 @@ func (p *packetIO) readOnePacket() ([]byte, error) {
 			return nil, err
 		}
 	}
-	if _, err := io.ReadFull(p.bufReadConn, header[:]); err != nil {
+	var r io.Reader = p.bufReadConn
+	if p.compressedReader != nil {
+		r = p.compressedReader
+	}
+	if _, err := io.ReadFull(r, header[:]); err != nil {
 		return nil, errors.Trace(err)
 	}
 
 	sequence := header[3]
-	if sequence != p.sequence {
+	// MySQL does not check the sequence of the packets inside compressed frames, and
+	// clients may restart it from the sequence of the frames.
+	if sequence != p.sequence && p.compressionAlgorithm == mysql.CompressionNone {
 		return nil, errInvalidSequence.GenWithStack("invalid sequence %d != %d", sequence, p.sequence)
 	}
 
-	p.sequence++
+	p.sequence = sequence + 1
 
 	length := int(uint32(header[0]) | uint32(header[1])<<8 | uint32(header[2])<<16)
 
@@ -102,7 +146,7 @@ func (p *packetIO) readOnePacket() ([]byte, error) {
 			return nil, err
 		}
 	}
-	if _, err := io.ReadFull(p.bufReadConn, data); err != nil {
+	if _, err := io.ReadFull(r, data); err != nil {
 		return nil, errors.Trace(err)
 	}
 	return data, nil
@@ -188,3 +232,228 @@ func (p *packetIO) flush() error {
 	}
 	return err
 }
+
+func compressionAlgorithmName(algorithm int) string {
+	switch algorithm {
+	case mysql.CompressionZlib:
+		return "zlib"
+	case mysql.CompressionZstd:
+		return "zstd"
+	}
+	return "none"
+}
+
+var (
+	zstdDecoderOnce sync.Once
+	zstdDecoder     *zstd.Decoder
+	zstdDecoderErr  error
+
+	zstdEncoders sync.Map // zstd level -> *zstd.Encoder
+)
+
+// getZstdDecoder returns the decoder shared by all connections, DecodeAll is safe for concurrent use.
+func getZstdDecoder() (*zstd.Decoder, error) {
+	zstdDecoderOnce.Do(func() {
+		zstdDecoder, zstdDecoderErr = zstd.NewReader(nil, zstd.WithDecoderMaxMemory(mysql.MaxPayloadLen))
+	})
+	return zstdDecoder, zstdDecoderErr
+}
+
+// getZstdEncoder returns the encoder of the level shared by all connections, EncodeAll is safe for concurrent use.
+func getZstdEncoder(level int) (*zstd.Encoder, error) {
+	if enc, ok := zstdEncoders.Load(level); ok {
+		return enc.(*zstd.Encoder), nil
+	}
+	enc, err := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(level)), zstd.WithEncoderConcurrency(1))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	actual, _ := zstdEncoders.LoadOrStore(level, enc)
+	return actual.(*zstd.Encoder), nil
+}
+
+// compressedReader reads the frames of the compressed protocol and returns the uncompressed stream of packets.
+// See https://dev.mysql.com/doc/internals/en/compressed-packet-header.html
+type compressedReader struct {
+	r   io.Reader
+	pkt *packetIO
+	// buf holds the uncompressed data which is not consumed yet.
+	buf []byte
+
+	uncompressedBytes prometheus.Counter
+	compressedBytes   prometheus.Counter
+}
+
+func newCompressedReader(r io.Reader, pkt *packetIO) *compressedReader {
+	algorithm := compressionAlgorithmName(pkt.compressionAlgorithm)
+	return &compressedReader{
+		r:                 r,
+		pkt:               pkt,
+		uncompressedBytes: metrics.CompressedPacketIOCounter.WithLabelValues("read", algorithm, "uncompressed"),
+		compressedBytes:   metrics.CompressedPacketIOCounter.WithLabelValues("read", algorithm, "compressed"),
+	}
+}
+
+func (cr *compressedReader) Read(data []byte) (int, error) {
+	for len(cr.buf) == 0 {
+		if err := cr.readFrame(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(data, cr.buf)
+	cr.buf = cr.buf[n:]
+	return n, nil
+}
+
+func (cr *compressedReader) readFrame() error {
+	var header [compressedHeaderLen]byte
+	if _, err := io.ReadFull(cr.r, header[:]); err != nil {
+		return err
+	}
+	compressedLen := int(uint32(header[0]) | uint32(header[1])<<8 | uint32(header[2])<<16)
+	cr.pkt.compressedSequence = header[3] + 1
+	uncompressedLen := int(uint32(header[4]) | uint32(header[5])<<8 | uint32(header[6])<<16)
+
+	payload := make([]byte, compressedLen)
+	if _, err := io.ReadFull(cr.r, payload); err != nil {
+		return err
+	}
+	cr.compressedBytes.Add(float64(compressedLen))
+	// An uncompressed length of 0 means the payload is sent without compression.
+	if uncompressedLen == 0 {
+		cr.uncompressedBytes.Add(float64(compressedLen))
+		cr.buf = payload
+		return nil
+	}
+
+	var data []byte
+	switch cr.pkt.compressionAlgorithm {
+	case mysql.CompressionZlib:
+		zr, err := zlib.NewReader(bytes.NewReader(payload))
+		if err != nil {
+			return errors.Trace(err)
+		}
+		data = make([]byte, uncompressedLen)
+		if _, err = io.ReadFull(zr, data); err != nil {
+			return errors.Trace(err)
+		}
+		if err = zr.Close(); err != nil {
+			return errors.Trace(err)
+		}
+	case mysql.CompressionZstd:
+		dec, err := getZstdDecoder()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		data, err = dec.DecodeAll(payload, make([]byte, 0, uncompressedLen))
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if len(data) != uncompressedLen {
+			return errors.Trace(mysql.ErrMalformPacket)
+		}
+	default:
+		return errors.Errorf("unknown compression algorithm %d", cr.pkt.compressionAlgorithm)
+	}
+	cr.uncompressedBytes.Add(float64(uncompressedLen))
+	cr.buf = data
+	return nil
+}
+
+// compressedWriter wraps the stream of packets into frames of the compressed protocol.
+// Every call of Write produces one or more frames, so it is expected to be used under a bufio.Writer.
+type compressedWriter struct {
+	w     io.Writer
+	pkt   *packetIO
+	zw    *zlib.Writer
+	frame bytes.Buffer
+
+	uncompressedBytes prometheus.Counter
+	compressedBytes   prometheus.Counter
+}
+
+func newCompressedWriter(w io.Writer, pkt *packetIO) *compressedWriter {
+	algorithm := compressionAlgorithmName(pkt.compressionAlgorithm)
+	return &compressedWriter{
+		w:                 w,
+		pkt:               pkt,
+		uncompressedBytes: metrics.CompressedPacketIOCounter.WithLabelValues("write", algorithm, "uncompressed"),
+		compressedBytes:   metrics.CompressedPacketIOCounter.WithLabelValues("write", algorithm, "compressed"),
+	}
+}
+
+func (cw *compressedWriter) Write(data []byte) (n int, err error) {
+	for len(data) > 0 {
+		payload := data
+		if len(payload) > mysql.MaxPayloadLen {
+			payload = payload[:mysql.MaxPayloadLen]
+		}
+		if err = cw.writeFrame(payload); err != nil {
+			return n, err
+		}
+		n += len(payload)
+		data = data[len(payload):]
+	}
+	return n, nil
+}
+
+func (cw *compressedWriter) writeFrame(payload []byte) error {
+	cw.frame.Reset()
+	cw.frame.Write(make([]byte, compressedHeaderLen))
+	uncompressedLen := 0
+	// Small payloads are sent uncompressed, and so are the ones compression does not help.
+	if len(payload) >= mysql.MinCompressLength {
+		if err := cw.compress(payload); err != nil {
+			return err
+		}
+		if cw.frame.Len()-compressedHeaderLen < len(payload) {
+			uncompressedLen = len(payload)
+		} else {
+			cw.frame.Truncate(compressedHeaderLen)
+		}
+	}
+	if uncompressedLen == 0 {
+		cw.frame.Write(payload)
+	}
+
+	frame := cw.frame.Bytes()
+	compressedLen := len(frame) - compressedHeaderLen
+	frame[0] = byte(compressedLen)
+	frame[1] = byte(compressedLen >> 8)
+	frame[2] = byte(compressedLen >> 16)
+	frame[3] = cw.pkt.compressedSequence
+	frame[4] = byte(uncompressedLen)
+	frame[5] = byte(uncompressedLen >> 8)
+	frame[6] = byte(uncompressedLen >> 16)
+	if _, err := cw.w.Write(frame); err != nil {
+		return errors.Trace(err)
+	}
+	cw.pkt.compressedSequence++
+	cw.uncompressedBytes.Add(float64(len(payload)))
+	cw.compressedBytes.Add(float64(compressedLen))
+	return nil
+}
+
+// compress appends the compressed payload to the frame.
+func (cw *compressedWriter) compress(payload []byte) error {
+	switch cw.pkt.compressionAlgorithm {
+	case mysql.CompressionZlib:
+		if cw.zw == nil {
+			cw.zw = zlib.NewWriter(&cw.frame)
+		} else {
+			cw.zw.Reset(&cw.frame)
+		}
+		if _, err := cw.zw.Write(payload); err != nil {
+			return errors.Trace(err)
+		}
+		return errors.Trace(cw.zw.Close())
+	case mysql.CompressionZstd:
+		enc, err := getZstdEncoder(cw.pkt.zstdLevel)
+		if err != nil {
+			return err
+		}
+		cw.frame.Write(enc.EncodeAll(payload, nil))
+		return nil
+	}
+	return errors.Errorf("unknown compression algorithm %d", cw.pkt.compressionAlgorithm)
+}
@@ -86,6 +86,45 @@ func TestPacketIORead(t *testing.T) {
 	require.Equal(t, byte(0x0a), bytes[mysql.MaxPayloadLen])
 }
 
+func TestCompressedPacketIO(t *testing.T) {
+	for _, algorithm := range []int{mysql.CompressionZlib, mysql.CompressionZstd} {
+		var outBuffer bytes.Buffer
+		pkt := &packetIO{compressionAlgorithm: algorithm, zstdLevel: mysql.DefaultZstdCompressionLevel}
+		pkt.bufWriter = bufio.NewWriter(newCompressedWriter(&outBuffer, pkt))
+
+		small := []byte{0x00, 0x00, 0x00, 0x00, 0x01, 0x02, 0x03}
+		require.NoError(t, pkt.writePacket(small))
+		require.NoError(t, pkt.flush())
+		// Small payloads are sent uncompressed.
+		require.Equal(t, []byte{0x07, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03, 0x00, 0x00, 0x00, 0x01, 0x02, 0x03}, outBuffer.Bytes())
+		require.Equal(t, uint8(1), pkt.compressedSequence)
+
+		large := make([]byte, 4+100000)
+		for i := 4; i < len(large); i++ {
+			large[i] = byte(i % 7)
+		}
+		require.NoError(t, pkt.writePacket(large))
+		require.NoError(t, pkt.flush())
+		require.Less(t, outBuffer.Len(), 14+len(large)/10)
+
+		brc := newBufferedReadConn(&bytesConn{outBuffer})
+		readPkt := newPacketIO(brc)
+		readPkt.setCompressionAlgorithm(algorithm, mysql.DefaultZstdCompressionLevel)
+		data, err := readPkt.readPacket()
+		require.NoError(t, err)
+		require.Equal(t, []byte{0x01, 0x02, 0x03}, data)
+		data, err = readPkt.readPacket()
+		require.NoError(t, err)
+		require.Equal(t, large[4:], data)
+		require.Equal(t, pkt.compressedSequence, readPkt.compressedSequence)
+		require.Equal(t, uint8(2), readPkt.sequence)
+
+		readPkt.resetSequence()
+		require.Equal(t, uint8(0), readPkt.sequence)
+		require.Equal(t, uint8(0), readPkt.compressedSequence)
+	}
+}
+
 type bytesConn struct {
 	b bytes.Buffer
 }
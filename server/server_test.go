@@ -1817,6 +1817,31 @@ func (cli *testServerClient) runTestMultiStatements(t *testing.T) {
 	})
 }
 
+func (cli *testServerClient) runTestCallProcedure(t *testing.T) {
+	cli.runTestsOnNewDB(t, nil, "CallProcedure", func(dbt *testkit.DBTestKit) {
+		dbt.MustExec("create table t (a int primary key)")
+		dbt.MustExec("insert into t values (1), (2)")
+		readInts := func(rows *sql.Rows) []int {
+			var res []int
+			for rows.Next() {
+				var a int
+				require.NoError(t, rows.Scan(&a))
+				res = append(res, a)
+			}
+			return res
+		}
+
+		dbt.MustExec("create procedure p() begin select a from t order by a; select count(*) from t; end")
+		rows := dbt.MustQuery("call p()")
+		require.Equal(t, []int{1, 2}, readInts(rows))
+		require.True(t, rows.NextResultSet())
+		require.Equal(t, []int{2}, readInts(rows))
+		require.False(t, rows.NextResultSet())
+		require.NoError(t, rows.Err())
+		require.NoError(t, rows.Close())
+	})
+}
+
 func (cli *testServerClient) runTestStmtCount(t *testing.T) {
 	cli.runTestsOnNewDB(t, nil, "StatementCount", func(dbt *testkit.DBTestKit) {
 		originStmtCnt := getStmtCnt(string(cli.getMetrics(t)))
@@ -376,6 +376,13 @@ func TestMultiStatements(t *testing.T) {
 	ts.runTestMultiStatements(t)
 }
 
+func TestCallProcedure(t *testing.T) {
+	ts, cleanup := createTidbTestSuite(t)
+	defer cleanup()
+
+	ts.runTestCallProcedure(t)
+}
+
 func TestSocketForwarding(t *testing.T) {
 	tempDir := t.TempDir()
 	socketFile := tempDir + "/tidbtest.sock" // Unix Socket does not work on Windows, so '/' should be OK
@@ -396,6 +396,57 @@ const (
 		UNIQUE KEY table_version (table_id, version),
 		KEY table_create_time (table_id, create_time)
 	);`
+	// CreateEventTable stores the scheduled events, the times are in UTC.
+	CreateEventTable = `CREATE TABLE IF NOT EXISTS mysql.event (
+		event_schema VARCHAR(64) NOT NULL,
+		event_name VARCHAR(64) NOT NULL,
+		definer VARCHAR(288) NOT NULL DEFAULT '',
+		body LONGTEXT NOT NULL,
+		execute_at DATETIME DEFAULT NULL,
+		interval_value VARCHAR(256) DEFAULT NULL,
+		interval_field VARCHAR(18) DEFAULT NULL,
+		starts DATETIME DEFAULT NULL,
+		ends DATETIME DEFAULT NULL,
+		status VARCHAR(18) NOT NULL DEFAULT 'ENABLED',
+		on_completion VARCHAR(12) NOT NULL DEFAULT 'NOT PRESERVE',
+		sql_mode VARCHAR(8192) NOT NULL DEFAULT '',
+		time_zone VARCHAR(64) NOT NULL DEFAULT 'SYSTEM',
+		character_set_client VARCHAR(32) NOT NULL DEFAULT '',
+		collation_connection VARCHAR(32) NOT NULL DEFAULT '',
+		event_comment VARCHAR(2048) NOT NULL DEFAULT '',
+		created DATETIME NOT NULL,
+		last_altered DATETIME NOT NULL,
+		last_executed DATETIME DEFAULT NULL,
+		next_execute DATETIME DEFAULT NULL,
+		PRIMARY KEY (event_schema, event_name),
+		KEY next_execute (next_execute)
+	);`
+	// CreateEventHistoryTable stores the executions of the scheduled events.
+	CreateEventHistoryTable = `CREATE TABLE IF NOT EXISTS mysql.event_history (
+		event_schema VARCHAR(64) NOT NULL,
+		event_name VARCHAR(64) NOT NULL,
+		instance VARCHAR(512) NOT NULL,
+		start_time DATETIME(6) NOT NULL,
+		end_time DATETIME(6) DEFAULT NULL,
+		state ENUM('running','finished','failed') NOT NULL,
+		error_message TEXT DEFAULT NULL,
+		KEY event_start_time (event_schema, event_name, start_time),
+		KEY start_time (start_time)
+	);`
+	// CreateXAPreparedTable stores the prepared XA transaction branches, so they
+	// can be recovered and committed or rolled back from any session.
+	CreateXAPreparedTable = `CREATE TABLE IF NOT EXISTS mysql.xa_prepared (
+		gtrid VARBINARY(64) NOT NULL,
+		bqual VARBINARY(64) NOT NULL,
+		format_id BIGINT UNSIGNED NOT NULL,
+		start_ts BIGINT UNSIGNED NOT NULL,
+		primary_key BLOB DEFAULT NULL,
+		user VARCHAR(32) NOT NULL DEFAULT '',
+		host VARCHAR(255) NOT NULL DEFAULT '',
+		instance VARCHAR(512) NOT NULL DEFAULT '',
+		prepare_time DATETIME(6) NOT NULL,
+		PRIMARY KEY (gtrid, bqual, format_id)
+	);`
 )
 
 // bootstrap initiates system DB for a store.
@@ -581,11 +632,17 @@ const (
 	version83 = 83
 	// version84 adds the tables mysql.stats_meta_history
 	version84 = 84
+	// version85 adds the tables mysql.event and mysql.event_history
+	version85 = 85
+	// version86 adds the table mysql.xa_prepared
+	version86 = 86
+	// version87 blocks the regexp functions from being pushed down to TiKV by default.
+	version87 = 87
 )
 
 // currentBootstrapVersion is defined as a variable, so we can modify its value for testing.
 // please make sure this is the largest version
-var currentBootstrapVersion int64 = version84
+var currentBootstrapVersion int64 = version87
 
 var (
 	bootstrapVersion = []func(Session, int64){
@@ -673,6 +730,9 @@ var (
 		upgradeToVer82,
 		upgradeToVer83,
 		upgradeToVer84,
+		upgradeToVer85,
+		upgradeToVer86,
+		upgradeToVer87,
 	}
 )
 
@@ -1233,6 +1293,16 @@ func writeDefaultExprPushDownBlacklist(s Session) {
 		"('date_add','tiflash', 'DST(daylight saving time) does not take effect in TiFlash date_add')")
 }
 
+// writeDefaultRegexpPushDownBlacklist writes the regexp functions into mysql.expr_pushdown_blacklist,
+// users can remove them from the blacklist once the TiKV coprocessor supports them.
+func writeDefaultRegexpPushDownBlacklist(s Session) {
+	mustExecute(s, "INSERT HIGH_PRIORITY INTO mysql.expr_pushdown_blacklist VALUES"+
+		"('regexp_like','tikv','The match type and collation of regexp_like are not verified in TiKV'),"+
+		"('regexp_instr','tikv','The match type and collation of regexp_instr are not verified in TiKV'),"+
+		"('regexp_substr','tikv','The match type and collation of regexp_substr are not verified in TiKV'),"+
+		"('regexp_replace','tikv','The match type and collation of regexp_replace are not verified in TiKV')")
+}
+
 func upgradeToVer42(s Session, ver int64) {
 	if ver >= version42 {
 		return
@@ -1742,6 +1812,28 @@ func upgradeToVer84(s Session, ver int64) {
 	doReentrantDDL(s, CreateStatsMetaHistory)
 }
 
+func upgradeToVer85(s Session, ver int64) {
+	if ver >= version85 {
+		return
+	}
+	doReentrantDDL(s, CreateEventTable)
+	doReentrantDDL(s, CreateEventHistoryTable)
+}
+
+func upgradeToVer86(s Session, ver int64) {
+	if ver >= version86 {
+		return
+	}
+	doReentrantDDL(s, CreateXAPreparedTable)
+}
+
+func upgradeToVer87(s Session, ver int64) {
+	if ver >= version87 {
+		return
+	}
+	writeDefaultRegexpPushDownBlacklist(s)
+}
+
 func writeOOMAction(s Session) {
 	comment := "oom-action is `log` by default in v3.0.x, `cancel` by default in v4.0.11+"
 	mustExecute(s, `INSERT HIGH_PRIORITY INTO %n.%n VALUES (%?, %?, %?) ON DUPLICATE KEY UPDATE VARIABLE_VALUE= %?`,
@@ -1832,6 +1924,12 @@ func doDDLWorks(s Session) {
 	mustExecute(s, CreateStatsHistory)
 	// Create stats_meta_history table.
 	mustExecute(s, CreateStatsMetaHistory)
+	// Create event table.
+	mustExecute(s, CreateEventTable)
+	// Create event_history table.
+	mustExecute(s, CreateEventHistoryTable)
+	// Create xa_prepared table.
+	mustExecute(s, CreateXAPreparedTable)
 }
 
 // doDMLWorks executes DML statements in bootstrap stage.
@@ -1914,6 +2012,8 @@ func doDMLWorks(s Session) {
 
 	writeDefaultExprPushDownBlacklist(s)
 
+	writeDefaultRegexpPushDownBlacklist(s)
+
 	writeStmtSummaryVars(s)
 
 	_, err := s.ExecuteInternal(context.Background(), "COMMIT")
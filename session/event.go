@@ -0,0 +1,180 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pingcap/tidb/domain/infosync"
+	"github.com/pingcap/tidb/executor"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/metrics"
+	"github.com/pingcap/tidb/parser/auth"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/privilege"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/util"
+	"github.com/pingcap/tidb/util/logutil"
+	"github.com/pingcap/tidb/util/sqlexec"
+	"go.uber.org/zap"
+)
+
+const (
+	// eventHistoryRetention is how long the execution history of the events is kept.
+	eventHistoryRetention = 7 * 24 * time.Hour
+	eventHistoryGCLease   = time.Hour
+)
+
+// eventScheduler fires the scheduled events which are due. It runs on the owner
+// of the event scheduler, and every execution of an event is claimed by updating
+// next_execute of the event conditionally, so an event never fires twice even if
+// the owner changes.
+type eventScheduler struct {
+	store  kv.Storage
+	se     *session
+	lastGC time.Time
+}
+
+func newEventScheduler(store kv.Storage, se *session) *eventScheduler {
+	se.GetSessionVars().InRestrictedSQL = true
+	return &eventScheduler{store: store, se: se}
+}
+
+func (sch *eventScheduler) fire() {
+	val, err := sch.se.GetSessionVars().GlobalVarsAccessor.GetGlobalSysVar(variable.EventScheduler)
+	if err != nil || !variable.TiDBOptOn(val) {
+		return
+	}
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+	events, err := executor.LoadEvents(ctx, sch.se, "status = %? AND next_execute <= %?", executor.EventEnabled, now)
+	if err != nil {
+		logutil.BgLogger().Warn("load scheduled events failed", zap.Error(err))
+		return
+	}
+	for _, ev := range events {
+		claimed, err := sch.claim(ctx, ev, now)
+		if err != nil {
+			logutil.BgLogger().Warn("claim scheduled event failed",
+				zap.String("schema", ev.Schema), zap.String("event", ev.Name), zap.Error(err))
+			continue
+		}
+		if claimed {
+			go sch.run(ev)
+		}
+	}
+	if now.Sub(sch.lastGC) >= eventHistoryGCLease {
+		sch.lastGC = now
+		_, err = sch.se.ExecuteInternal(ctx, "DELETE FROM mysql.event_history WHERE start_time < %?", now.Add(-eventHistoryRetention))
+		if err != nil {
+			logutil.BgLogger().Warn("gc event history failed", zap.Error(err))
+		}
+	}
+}
+
+// claim moves the event to its next execution, it returns false if the execution
+// has been claimed by another tidb-server or the event has been changed.
+func (sch *eventScheduler) claim(ctx context.Context, ev *executor.EventInfo, now time.Time) (bool, error) {
+	next, err := ev.NextTime(now.Add(time.Second))
+	if err != nil {
+		return false, err
+	}
+	switch {
+	case !next.IsZero():
+		_, err = sch.se.ExecuteInternal(ctx, "UPDATE mysql.event SET last_executed = %?, next_execute = %? WHERE event_schema = %? AND event_name = %? AND next_execute = %?",
+			now, next, ev.Schema, ev.Name, ev.NextExecute)
+	case ev.OnCompletion == executor.EventNotPreserve:
+		_, err = sch.se.ExecuteInternal(ctx, "DELETE FROM mysql.event WHERE event_schema = %? AND event_name = %? AND next_execute = %?",
+			ev.Schema, ev.Name, ev.NextExecute)
+	default:
+		_, err = sch.se.ExecuteInternal(ctx, "UPDATE mysql.event SET last_executed = %?, next_execute = NULL, status = %? WHERE event_schema = %? AND event_name = %? AND next_execute = %?",
+			now, executor.EventDisabled, ev.Schema, ev.Name, ev.NextExecute)
+	}
+	if err != nil {
+		return false, err
+	}
+	return sch.se.GetSessionVars().StmtCtx.AffectedRows() > 0, nil
+}
+
+// run executes the body of the event in a new session with the privileges of the definer,
+// and records the execution in mysql.event_history.
+func (sch *eventScheduler) run(ev *executor.EventInfo) {
+	defer util.Recover(metrics.LabelDomain, "runScheduledEvent", nil, false)
+	ctx := context.Background()
+	se, err := CreateSession(sch.store)
+	if err != nil {
+		logutil.BgLogger().Warn("create session for scheduled event failed", zap.Error(err))
+		return
+	}
+	defer se.Close()
+	s := se.(*session)
+	exec := se.(sqlexec.RestrictedSQLExecutor)
+
+	start := time.Now().UTC()
+	_, _, err = exec.ExecRestrictedSQL(ctx, nil, "INSERT INTO mysql.event_history (event_schema, event_name, instance, start_time, state) VALUES (%?, %?, %?, %?, 'running')",
+		ev.Schema, ev.Name, eventInstance(), start)
+	if err != nil {
+		logutil.BgLogger().Warn("record scheduled event failed", zap.Error(err))
+		return
+	}
+	state, msg := "finished", ""
+	if err = s.runEvent(ctx, ev); err != nil {
+		state, msg = "failed", err.Error()
+		logutil.BgLogger().Info("scheduled event failed", zap.String("schema", ev.Schema), zap.String("event", ev.Name), zap.Error(err))
+	}
+	_, _, err = exec.ExecRestrictedSQL(ctx, nil, "UPDATE mysql.event_history SET end_time = %?, state = %?, error_message = %? WHERE event_schema = %? AND event_name = %? AND start_time = %?",
+		time.Now().UTC(), state, msg, ev.Schema, ev.Name, start)
+	if err != nil {
+		logutil.BgLogger().Warn("record scheduled event failed", zap.Error(err))
+	}
+}
+
+func (s *session) runEvent(ctx context.Context, ev *executor.EventInfo) error {
+	vars := s.sessionVars
+	if pos := strings.LastIndex(ev.Definer, "@"); pos >= 0 {
+		name, host := ev.Definer[:pos], ev.Definer[pos+1:]
+		vars.User = &auth.UserIdentity{Username: name, Hostname: host, AuthUsername: name, AuthHostname: host}
+		if pm := privilege.GetPrivilegeManager(s); pm != nil {
+			vars.ActiveRoles = pm.GetDefaultRoles(name, host)
+		}
+	}
+	if err := vars.SetSystemVar(variable.TimeZone, ev.TimeZone); err != nil {
+		return err
+	}
+	is := s.GetInfoSchema().(infoschema.InfoSchema)
+	dbInfo, ok := is.SchemaByName(model.NewCIStr(ev.Schema))
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(ev.Schema)
+	}
+	e := newProcedureExec(s, nil)
+	e.db = dbInfo
+	body, sqlMode, err := e.parse(ev.Body, ev.SQLMode, ev.Charset, ev.Collation)
+	if err != nil {
+		return err
+	}
+	return e.exec(ctx, body, sqlMode)
+}
+
+func eventInstance() string {
+	info, err := infosync.GetServerInfo()
+	if err != nil {
+		return ""
+	}
+	return info.IP + ":" + strconv.FormatUint(uint64(info.Port), 10)
+}
@@ -0,0 +1,131 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/executor"
+	"github.com/pingcap/tidb/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateAlterDropEvent(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set time_zone = '+00:00'")
+	tk.MustExec("create table t (a int)")
+	tk.MustExec("create event e1 on schedule at '2037-01-01 00:00:00' do insert into t values (1)")
+	tk.MustGetErrCode("create event e1 on schedule every 1 hour do insert into t values (1)", errno.ErrEventAlreadyExists)
+	tk.MustExec("create event if not exists e1 on schedule every 1 hour do insert into t values (1)")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1537 Event 'e1' already exists"))
+	tk.MustExec("create event e2 on schedule every 2 day starts '2036-01-01 00:00:00' ends '2036-02-01 00:00:00' on completion preserve disable comment 'c' do begin insert into t values (2); end")
+	tk.MustQuery("select event_name, event_type, execute_at, interval_value, interval_field, starts, ends, status, on_completion, event_comment from information_schema.events where event_schema = 'test'").Check(testkit.Rows(
+		"e1 ONE TIME 2037-01-01 00:00:00 <nil> <nil> <nil> <nil> ENABLED NOT PRESERVE ",
+		"e2 RECURRING <nil> 2 DAY 2036-01-01 00:00:00 2036-02-01 00:00:00 DISABLED PRESERVE c"))
+	rows := tk.MustQuery("show events").Rows()
+	require.Len(t, rows, 2)
+	require.Equal(t, []interface{}{"test", "e1", "+00:00"}, rows[0][:3])
+	require.Equal(t, "RECURRING", rows[1][4])
+
+	tk.MustGetErrCode("create event e3 on schedule every 0 hour do insert into t values (1)", errno.ErrEventIntervalNotPositiveOrTooBig)
+	tk.MustGetErrCode("create event e3 on schedule every 1 hour starts '2036-01-01' ends '2035-01-01' do insert into t values (1)", errno.ErrEventEndsBeforeStarts)
+	tk.MustGetErrCode("create event e3 on schedule at 'abc' do insert into t values (1)", errno.ErrWrongValue)
+	tk.MustGetErrCode("create event nodb.e3 on schedule at '2037-01-01 00:00:00' do insert into t values (1)", errno.ErrBadDB)
+	tk.MustExec("create event e3 on schedule at '2000-01-01 00:00:00' do insert into t values (1)")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1588 Event execution time is in the past and ON COMPLETION NOT PRESERVE is set. The event was dropped immediately after creation."))
+	tk.MustExec("create event e3 on schedule at '2000-01-01 00:00:00' on completion preserve do insert into t values (1)")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1544 Event execution time is in the past. Event has been disabled"))
+	tk.MustQuery("select status from information_schema.events where event_name = 'e3'").Check(testkit.Rows("DISABLED"))
+
+	tk.MustExec("alter event e1 on schedule every 1 minute starts '2037-01-01 00:00:00' rename to e4 comment 'x'")
+	tk.MustQuery("select event_name, event_type, interval_value, interval_field, starts, event_comment from information_schema.events where event_schema = 'test' and event_name = 'e4'").Check(testkit.Rows(
+		"e4 RECURRING 1 MINUTE 2037-01-01 00:00:00 x"))
+	tk.MustGetErrCode("alter event e4 rename to e4", errno.ErrEventSameName)
+	tk.MustGetErrCode("alter event e4 rename to e2", errno.ErrEventAlreadyExists)
+	tk.MustGetErrCode("alter event e1 enable", errno.ErrEventDoesNotExist)
+	tk.MustGetErrCode("alter event e4 on schedule at '2000-01-01 00:00:00'", errno.ErrEventCannotAlterInThePast)
+	tk.MustExec("alter event e2 enable do insert into t values (3)")
+	tk.MustQuery("select status, event_definition from information_schema.events where event_name = 'e2'").Check(testkit.Rows("ENABLED INSERT INTO `t` VALUES (3)"))
+
+	tk.MustExec("drop event e4")
+	tk.MustGetErrCode("drop event e4", errno.ErrEventDoesNotExist)
+	tk.MustExec("drop event if exists e4")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1539 Unknown event 'e4'"))
+	tk.MustExec("create database db1")
+	tk.MustExec("create event db1.e1 on schedule every 1 hour do select 1")
+	tk.MustExec("drop database db1")
+	tk.MustQuery("select count(*) from mysql.event where event_schema = 'db1'").Check(testkit.Rows("0"))
+}
+
+func TestEventNextTime(t *testing.T) {
+	at := func(s string) time.Time {
+		tm, err := time.Parse("2006-01-02 15:04:05", s)
+		require.NoError(t, err)
+		return tm
+	}
+	ev := &executor.EventInfo{IntervalValue: "1", IntervalField: "MONTH", Starts: at("2022-01-31 10:00:00"), Ends: at("2022-06-01 00:00:00")}
+	next, err := ev.NextTime(at("2022-01-31 10:00:00"))
+	require.NoError(t, err)
+	require.Equal(t, at("2022-01-31 10:00:00"), next)
+	next, err = ev.NextTime(at("2022-03-15 00:00:00"))
+	require.NoError(t, err)
+	require.Equal(t, at("2022-03-31 10:00:00"), next)
+	next, err = ev.NextTime(at("2022-05-31 10:00:01"))
+	require.NoError(t, err)
+	require.True(t, next.IsZero())
+
+	ev = &executor.EventInfo{IntervalValue: "1:30", IntervalField: "HOUR_MINUTE", Starts: at("2022-01-01 00:00:00")}
+	next, err = ev.NextTime(at("2022-01-01 02:00:00"))
+	require.NoError(t, err)
+	require.Equal(t, at("2022-01-01 03:00:00"), next)
+
+	ev = &executor.EventInfo{ExecuteAt: at("2022-01-01 00:00:00")}
+	next, err = ev.NextTime(at("2022-01-01 00:00:01"))
+	require.NoError(t, err)
+	require.True(t, next.IsZero())
+}
+
+func TestEventScheduler(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustQuery("select @@global.event_scheduler").Check(testkit.Rows("0"))
+	tk.MustExec("set @@global.event_scheduler = on")
+	defer tk.MustExec("set @@global.event_scheduler = off")
+	tk.MustExec("create table t (a int)")
+	tk.MustExec("create event e1 on schedule at current_timestamp do insert into t values (1)")
+	tk.MustExec("create event e2 on schedule every 1 second do insert into t values (2)")
+	tk.MustExec("create event e3 on schedule at current_timestamp do insert into nonexistent values (3)")
+	require.Eventually(t, func() bool {
+		return len(tk.MustQuery("select * from t where a = 2").Rows()) >= 2 &&
+			len(tk.MustQuery("select * from information_schema.event_history where state != 'running'").Rows()) >= 4
+	}, 15*time.Second, 100*time.Millisecond)
+	tk.MustExec("drop event e2")
+
+	tk.MustQuery("select a from t where a = 1").Check(testkit.Rows("1"))
+	// The one-time events are dropped after they fire.
+	tk.MustQuery("select event_name from information_schema.events where event_schema = 'test'").Check(testkit.Rows())
+	tk.MustQuery("select state, error_message from information_schema.event_history where event_name = 'e1'").Check(testkit.Rows("finished "))
+	tk.MustQuery("select state, error_message from information_schema.event_history where event_name = 'e3'").Check(testkit.Rows(
+		"failed [schema:1146]Table 'test.nonexistent' doesn't exist"))
+}
@@ -0,0 +1,958 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/ddl"
+	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/executor"
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/parser"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/format"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/parser/terror"
+	plannercore "github.com/pingcap/tidb/planner/core"
+	"github.com/pingcap/tidb/privilege"
+	"github.com/pingcap/tidb/types"
+	driver "github.com/pingcap/tidb/types/parser_driver"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/dbterror"
+	"github.com/pingcap/tidb/util/sqlexec"
+)
+
+var (
+	errProcaccessDenied     = dbterror.ClassSession.NewStd(errno.ErrProcaccessDenied)
+	errSpWrongNoOfArgs      = dbterror.ClassSession.NewStd(errno.ErrSpWrongNoOfArgs)
+	errSpNotVarArg          = dbterror.ClassSession.NewStd(errno.ErrSpNotVarArg)
+	errSpRecursionLimit     = dbterror.ClassSession.NewStd(errno.ErrSpRecursionLimit)
+	errSpUndeclaredVar      = dbterror.ClassSession.NewStd(errno.ErrSpUndeclaredVar)
+	errSpCursorAlreadyOpen  = dbterror.ClassSession.NewStd(errno.ErrSpCursorAlreadyOpen)
+	errSpCursorNotOpen      = dbterror.ClassSession.NewStd(errno.ErrSpCursorNotOpen)
+	errSpWrongNoOfFetchArgs = dbterror.ClassSession.NewStd(errno.ErrSpWrongNoOfFetchArgs)
+	errSpFetchNoData        = dbterror.ClassSession.NewStd(errno.ErrSpFetchNoData)
+	errTooManyRows          = dbterror.ClassSession.NewStd(errno.ErrTooManyRows)
+)
+
+// procedureVar is a parameter or a local variable of a stored procedure,
+// or a column of the NEW or OLD row of a trigger.
+type procedureVar struct {
+	name string
+	tp   *types.FieldType
+	val  types.Datum
+	// row is the NEW or OLD row of a trigger which the column is read from and written to.
+	row    *[]types.Datum
+	offset int
+}
+
+func (v *procedureVar) value() types.Datum {
+	if v.row != nil {
+		return (*v.row)[v.offset]
+	}
+	return v.val
+}
+
+func (v *procedureVar) set(s *session, d types.Datum) error {
+	sc := s.sessionVars.StmtCtx
+	val, err := d.ConvertTo(sc, v.tp)
+	if err = sc.HandleTruncate(err); err != nil {
+		return err
+	}
+	if v.row != nil {
+		(*v.row)[v.offset] = val
+		return nil
+	}
+	v.val = val
+	return nil
+}
+
+// procedureCursor is a cursor of a stored procedure, the rows of the query
+// are fetched when the cursor is opened.
+type procedureCursor struct {
+	decl   *ast.ProcedureCursorDecl
+	open   bool
+	fields []*ast.ResultField
+	rows   []chunk.Row
+	pos    int
+}
+
+func (c *procedureCursor) close() {
+	c.open, c.fields, c.rows, c.pos = false, nil, nil, 0
+}
+
+// procedureScope holds the variables, the cursors and the handlers declared in
+// a BEGIN ... END block. The outermost scope holds the parameters.
+type procedureScope struct {
+	block    *ast.ProcedureBlock
+	vars     map[string]*procedureVar
+	cursors  map[string]*procedureCursor
+	handlers []*ast.ProcedureHandlerDecl
+}
+
+// procedureJump leaves or iterates a labeled statement. An EXIT handler leaves
+// the block where it's declared, which is specified by block.
+type procedureJump struct {
+	label   string
+	iterate bool
+	block   *ast.ProcedureBlock
+}
+
+func (j *procedureJump) leaves(block *ast.ProcedureBlock) bool {
+	return j.block == block || (!j.iterate && j.label != "" && strings.EqualFold(j.label, block.Label))
+}
+
+// procedureBinding binds a reference to a local variable to a value expression,
+// the value is refreshed every time before the statement or the expression is used.
+type procedureBinding struct {
+	expr *driver.ValueExpr
+	v    *procedureVar
+}
+
+type procedureBound struct {
+	node     ast.Node
+	bindings []procedureBinding
+}
+
+// procedureBinder replaces the references to local variables with value expressions.
+type procedureBinder struct {
+	e        *procedureExec
+	bindings []procedureBinding
+}
+
+// Enter implements Visitor interface.
+func (b *procedureBinder) Enter(in ast.Node) (ast.Node, bool) {
+	switch in.(type) {
+	case *ast.SelectIntoOption, *ast.ValuesExpr:
+		// The targets of SELECT ... INTO are assigned rather than evaluated,
+		// and VALUES() always refers to a column.
+		return in, true
+	}
+	return in, false
+}
+
+// Leave implements Visitor interface.
+func (b *procedureBinder) Leave(in ast.Node) (ast.Node, bool) {
+	col, ok := in.(*ast.ColumnNameExpr)
+	if !ok || col.Name.Schema.L != "" {
+		return in, true
+	}
+	name := col.Name.Name.L
+	if col.Name.Table.L != "" {
+		// It may be a column of the NEW or OLD row of a trigger.
+		name = col.Name.Table.L + "." + name
+	}
+	v := b.e.lookupVar(name)
+	if v == nil {
+		return in, true
+	}
+	expr := ast.NewValueExpr(nil, "", "").(*driver.ValueExpr)
+	b.bindings = append(b.bindings, procedureBinding{expr: expr, v: v})
+	return expr, true
+}
+
+// procedureExec executes a stored procedure. The statements of the procedure
+// are executed one by one through the session with the privileges of the invoker.
+type procedureExec struct {
+	s      *session
+	caller *procedureExec
+	proc   *model.ProcedureInfo
+	db     *model.DBInfo
+	scopes []*procedureScope
+	// trigger is set when the procedure is a trigger body or is called by a trigger,
+	// the statements are executed as a part of the statement which activates the trigger.
+	trigger *executor.TriggerContext
+	// rowVars are the columns of the NEW and OLD rows of a trigger, named like "new.a".
+	rowVars map[string]*procedureVar
+
+	// vars and cursors are created once for each declaration, so the bindings
+	// of the statements keep valid when a block is entered again.
+	vars    map[*ast.ProcedureVarDecl][]*procedureVar
+	cursors map[*ast.ProcedureCursorDecl]*procedureCursor
+	bound   map[ast.Node]*procedureBound
+	// intos are the INTO clauses taken from the SELECT statements.
+	intos   map[*ast.SelectStmt]*ast.SelectIntoOption
+	selects map[ast.ExprNode]*ast.SelectStmt
+	sets    map[*ast.VariableAssignment]*ast.SetStmt
+	// writer writes the result sets to the client as soon as they are produced. When it's nil,
+	// the last result set is kept in result and returned as the result of the CALL statement.
+	writer sqlexec.ResultSetWriter
+	result *procedureResult
+}
+
+func newProcedureExec(s *session, caller *procedureExec) *procedureExec {
+	e := &procedureExec{
+		s:       s,
+		caller:  caller,
+		vars:    make(map[*ast.ProcedureVarDecl][]*procedureVar),
+		cursors: make(map[*ast.ProcedureCursorDecl]*procedureCursor),
+		bound:   make(map[ast.Node]*procedureBound),
+		intos:   make(map[*ast.SelectStmt]*ast.SelectIntoOption),
+		selects: make(map[ast.ExprNode]*ast.SelectStmt),
+		sets:    make(map[*ast.VariableAssignment]*ast.SetStmt),
+	}
+	if caller != nil {
+		e.writer = caller.writer
+	}
+	return e
+}
+
+// executeCall executes a CALL statement. The result sets are written by the sqlexec.ResultSetWriter
+// in the context if there is one, otherwise the last result set is returned.
+func (s *session) executeCall(ctx context.Context, call *ast.CallStmt) (sqlexec.RecordSet, error) {
+	e := newProcedureExec(s, nil)
+	e.writer, _ = ctx.Value(sqlexec.ResultSetWriterKey).(sqlexec.ResultSetWriter)
+	if err := e.execCall(ctx, call); err != nil {
+		return nil, err
+	}
+	if e.result == nil {
+		return nil, nil
+	}
+	return e.result, nil
+}
+
+// executeSelectIntoVars executes a SELECT ... INTO statement which assigns user variables.
+func (s *session) executeSelectIntoVars(ctx context.Context, sel *ast.SelectStmt) error {
+	return newProcedureExec(s, nil).execSelectInto(ctx, sel)
+}
+
+func (e *procedureExec) lookupVar(name string) *procedureVar {
+	name = strings.ToLower(name)
+	for i := len(e.scopes) - 1; i >= 0; i-- {
+		if v, ok := e.scopes[i].vars[name]; ok {
+			return v
+		}
+	}
+	return e.rowVars[name]
+}
+
+func (e *procedureExec) lookupCursor(name string) *procedureCursor {
+	name = strings.ToLower(name)
+	for i := len(e.scopes) - 1; i >= 0; i-- {
+		if c, ok := e.scopes[i].cursors[name]; ok {
+			return c
+		}
+	}
+	return nil
+}
+
+func (e *procedureExec) checkKilled() error {
+	if atomic.CompareAndSwapUint32(&e.s.sessionVars.Killed, 1, 0) {
+		return executor.ErrQueryInterrupted
+	}
+	return nil
+}
+
+func (e *procedureExec) execCall(ctx context.Context, call *ast.CallStmt) error {
+	fn := call.Procedure
+	vars := e.s.sessionVars
+	dbName := fn.Schema
+	if dbName.L == "" {
+		if vars.CurrentDB == "" {
+			return errors.Trace(plannercore.ErrNoDB)
+		}
+		dbName = model.NewCIStr(vars.CurrentDB)
+	}
+	fullName := dbName.O + "." + fn.FnName.O
+	is := e.s.GetInfoSchema().(infoschema.InfoSchema)
+	dbInfo, ok := is.SchemaByName(dbName)
+	if !ok {
+		return infoschema.ErrProcedureNotExists.GenWithStackByArgs("PROCEDURE", fullName)
+	}
+	proc := dbInfo.FindProcedure(fn.FnName.L)
+	if proc == nil {
+		return infoschema.ErrProcedureNotExists.GenWithStackByArgs("PROCEDURE", fullName)
+	}
+	if pm := privilege.GetPrivilegeManager(e.s); pm != nil && vars.User != nil {
+		if !pm.RequestVerification(vars.ActiveRoles, dbName.L, "", "", mysql.ExecutePriv) {
+			return errProcaccessDenied.GenWithStackByArgs("execute", vars.User.AuthUsername, vars.User.AuthHostname, fullName)
+		}
+	}
+	for caller := e; caller != nil; caller = caller.caller {
+		if caller.proc != nil && caller.proc.ID == proc.ID {
+			return errSpRecursionLimit.GenWithStackByArgs(0, proc.Name.O)
+		}
+	}
+	if len(fn.Args) != len(proc.Params) {
+		return errSpWrongNoOfArgs.GenWithStackByArgs("PROCEDURE", fullName, len(proc.Params), len(fn.Args))
+	}
+
+	callee := newProcedureExec(e.s, e)
+	callee.proc, callee.db, callee.trigger = proc, dbInfo, e.trigger
+	params := &procedureScope{vars: make(map[string]*procedureVar, len(proc.Params))}
+	for i, param := range proc.Params {
+		v := &procedureVar{name: param.Name.O, tp: param.Tp}
+		if param.Mode != model.ProcedureParamIn && !e.isAssignable(fn.Args[i]) {
+			return errSpNotVarArg.GenWithStackByArgs(i+1, fullName)
+		}
+		if param.Mode != model.ProcedureParamOut {
+			d, err := e.evalExpr(ctx, fn.Args[i])
+			if err != nil {
+				return err
+			}
+			if err = v.set(e.s, d); err != nil {
+				return err
+			}
+		}
+		params.vars[param.Name.L] = v
+	}
+	callee.scopes = []*procedureScope{params}
+	if err := callee.run(ctx); err != nil {
+		return err
+	}
+	for i, param := range proc.Params {
+		if param.Mode == model.ProcedureParamIn {
+			continue
+		}
+		v := params.vars[param.Name.L]
+		if err := e.assign(fn.Args[i], v.value(), v.tp); err != nil {
+			return err
+		}
+	}
+	if callee.result != nil {
+		e.result = callee.result
+	}
+	return nil
+}
+
+// run executes the body of the procedure in the database of the procedure and with its sql_mode.
+func (e *procedureExec) run(ctx context.Context) error {
+	body, sqlMode, err := e.parse(e.proc.Body, e.proc.SQLMode, e.proc.Charset, e.proc.Collate)
+	if err != nil {
+		return err
+	}
+	return e.exec(ctx, body, sqlMode)
+}
+
+// parse parses the body of a procedure or a trigger with the sql_mode when it's created.
+func (e *procedureExec) parse(body, sqlModeStr, charset, collation string) (ast.StmtNode, mysql.SQLMode, error) {
+	sqlMode, err := mysql.GetSQLMode(mysql.FormatSQLModeStr(sqlModeStr))
+	if err != nil {
+		return nil, sqlMode, errors.Trace(err)
+	}
+	p := parserPool.Get().(*parser.Parser)
+	p.SetSQLMode(sqlMode)
+	p.SetParserConfig(e.s.sessionVars.BuildParserConfig())
+	stmt, err := p.ParseOneStmt("CREATE PROCEDURE p() "+body, charset, collation)
+	parserPool.Put(p)
+	if err != nil {
+		return nil, sqlMode, errors.Trace(err)
+	}
+	return stmt.(*ast.CreateProcedureStmt).Body, sqlMode, nil
+}
+
+// exec executes the parsed body in the database of the procedure and with the sql_mode.
+func (e *procedureExec) exec(ctx context.Context, body ast.StmtNode, sqlMode mysql.SQLMode) error {
+	vars := e.s.sessionVars
+	currentDB, currentSQLMode, strictSQLMode := vars.CurrentDB, vars.SQLMode, vars.StrictSQLMode
+	vars.CurrentDB, vars.SQLMode, vars.StrictSQLMode = e.db.Name.O, sqlMode, sqlMode.HasStrictMode()
+	defer func() {
+		vars.CurrentDB, vars.SQLMode, vars.StrictSQLMode = currentDB, currentSQLMode, strictSQLMode
+	}()
+	_, err := e.execStmt(ctx, body)
+	return err
+}
+
+func (e *procedureExec) execStmts(ctx context.Context, stmts []ast.StmtNode) (*procedureJump, error) {
+	for _, stmt := range stmts {
+		jump, err := e.execStmt(ctx, stmt)
+		if err != nil || jump != nil {
+			return jump, err
+		}
+	}
+	return nil, nil
+}
+
+func (e *procedureExec) execStmt(ctx context.Context, stmt ast.StmtNode) (*procedureJump, error) {
+	switch x := stmt.(type) {
+	case *ast.ProcedureBlock:
+		return e.execBlock(ctx, x)
+	case *ast.ProcedureIfStmt:
+		for _, branch := range x.Branches {
+			ok, err := e.evalCond(ctx, branch.Cond)
+			if err != nil {
+				return e.handleError(ctx, err)
+			}
+			if ok {
+				return e.execStmts(ctx, branch.Stmts)
+			}
+		}
+		return e.execStmts(ctx, x.Else)
+	case *ast.ProcedureLoopStmt:
+		return e.execLoop(ctx, x)
+	case *ast.ProcedureJumpStmt:
+		return &procedureJump{label: x.Label, iterate: x.IsIterate}, nil
+	case *ast.ProcedureOpenStmt:
+		return e.handleError(ctx, e.openCursor(ctx, x.Cursor))
+	case *ast.ProcedureFetchStmt:
+		return e.handleError(ctx, e.fetchCursor(x))
+	case *ast.ProcedureCloseStmt:
+		return e.handleError(ctx, e.closeCursor(x.Cursor))
+	default:
+		return e.handleError(ctx, e.execSQL(ctx, stmt))
+	}
+}
+
+func (e *procedureExec) execBlock(ctx context.Context, block *ast.ProcedureBlock) (*procedureJump, error) {
+	scope := &procedureScope{
+		block:   block,
+		vars:    make(map[string]*procedureVar),
+		cursors: make(map[string]*procedureCursor),
+	}
+	e.scopes = append(e.scopes, scope)
+	defer func() {
+		for _, c := range scope.cursors {
+			c.close()
+		}
+		e.scopes = e.scopes[:len(e.scopes)-1]
+	}()
+	for _, stmt := range block.Stmts {
+		var (
+			jump *procedureJump
+			err  error
+		)
+		switch x := stmt.(type) {
+		case *ast.ProcedureVarDecl:
+			jump, err = e.handleError(ctx, e.declareVars(ctx, scope, x))
+		case *ast.ProcedureCursorDecl:
+			c, ok := e.cursors[x]
+			if !ok {
+				c = &procedureCursor{decl: x}
+				e.cursors[x] = c
+			}
+			scope.cursors[strings.ToLower(x.Name)] = c
+		case *ast.ProcedureHandlerDecl:
+			scope.handlers = append(scope.handlers, x)
+		default:
+			jump, err = e.execStmt(ctx, stmt)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if jump != nil {
+			if jump.leaves(block) {
+				return nil, nil
+			}
+			return jump, nil
+		}
+	}
+	return nil, nil
+}
+
+func (e *procedureExec) declareVars(ctx context.Context, scope *procedureScope, decl *ast.ProcedureVarDecl) error {
+	vars, ok := e.vars[decl]
+	if !ok {
+		if err := ddl.CompleteProcedureVarType(e.s, decl.Tp, decl.Names[0], e.db); err != nil {
+			return err
+		}
+		for _, name := range decl.Names {
+			vars = append(vars, &procedureVar{name: name, tp: decl.Tp})
+		}
+		e.vars[decl] = vars
+	}
+	// The default value is evaluated before the variables are visible.
+	var d types.Datum
+	if decl.Default != nil {
+		var err error
+		if d, err = e.evalExpr(ctx, decl.Default); err != nil {
+			return err
+		}
+	}
+	for _, v := range vars {
+		if err := v.set(e.s, d); err != nil {
+			return err
+		}
+		scope.vars[strings.ToLower(v.name)] = v
+	}
+	return nil
+}
+
+func (e *procedureExec) execLoop(ctx context.Context, loop *ast.ProcedureLoopStmt) (*procedureJump, error) {
+	for {
+		if err := e.checkKilled(); err != nil {
+			return nil, err
+		}
+		if loop.Tp == ast.ProcedureLoopWhile {
+			ok, err := e.evalCond(ctx, loop.Cond)
+			if err != nil || !ok {
+				return e.handleError(ctx, err)
+			}
+		}
+		jump, err := e.execStmts(ctx, loop.Stmts)
+		if err != nil {
+			return nil, err
+		}
+		if jump != nil {
+			if jump.label == "" || !strings.EqualFold(jump.label, loop.Label) {
+				return jump, nil
+			}
+			if !jump.iterate {
+				return nil, nil
+			}
+			continue
+		}
+		if loop.Tp == ast.ProcedureLoopRepeat {
+			ok, err := e.evalCond(ctx, loop.Cond)
+			if err != nil || ok {
+				return e.handleError(ctx, err)
+			}
+		}
+	}
+}
+
+// handleError activates the handler of the error. The statement which raises the
+// error is finished, and the execution continues after it for a CONTINUE handler,
+// or leaves the block where the handler is declared for an EXIT handler.
+func (e *procedureExec) handleError(ctx context.Context, err error) (*procedureJump, error) {
+	if err == nil || executor.ErrQueryInterrupted.Equal(err) {
+		return nil, err
+	}
+	idx, handler := e.findHandler(toProcedureSQLError(err))
+	if handler == nil {
+		return nil, err
+	}
+	// The handlers of the block can't handle the errors raised by the statement of a handler.
+	scopes, scope := e.scopes, e.scopes[idx]
+	e.scopes = append(scopes[:idx:idx], &procedureScope{block: scope.block, vars: scope.vars, cursors: scope.cursors})
+	jump, err := e.execStmt(ctx, handler.Stmt)
+	e.scopes = scopes
+	if err != nil || jump != nil {
+		return jump, err
+	}
+	if handler.Action == ast.ProcedureHandlerExit {
+		return &procedureJump{block: scope.block}, nil
+	}
+	return nil, nil
+}
+
+// findHandler finds the most specific handler of the innermost block for the error.
+func (e *procedureExec) findHandler(sqlErr *mysql.SQLError) (int, *ast.ProcedureHandlerDecl) {
+	for i := len(e.scopes) - 1; i >= 0; i-- {
+		var (
+			found    *ast.ProcedureHandlerDecl
+			priority int
+		)
+		for _, handler := range e.scopes[i].handlers {
+			for _, cond := range handler.Conditions {
+				if p := matchProcedureCondition(cond, sqlErr); p > priority {
+					found, priority = handler, p
+				}
+			}
+		}
+		if found != nil {
+			return i, found
+		}
+	}
+	return -1, nil
+}
+
+// matchProcedureCondition returns the priority of the condition if it matches the error,
+// a condition of error code is more specific than SQLSTATE, which is more specific than
+// the classes of SQLSTATE. It returns 0 if the condition doesn't match.
+func matchProcedureCondition(cond *ast.ProcedureCondition, sqlErr *mysql.SQLError) int {
+	class := sqlErr.State[:2]
+	switch cond.Tp {
+	case ast.ProcedureConditionErrorCode:
+		if cond.ErrorCode == uint64(sqlErr.Code) {
+			return 3
+		}
+	case ast.ProcedureConditionSQLState:
+		if cond.SQLState == sqlErr.State {
+			return 2
+		}
+	case ast.ProcedureConditionNotFound:
+		if class == "02" {
+			return 1
+		}
+	case ast.ProcedureConditionSQLWarning:
+		if class == "01" {
+			return 1
+		}
+	case ast.ProcedureConditionSQLException:
+		if class != "00" && class != "01" && class != "02" {
+			return 1
+		}
+	}
+	return 0
+}
+
+func toProcedureSQLError(err error) *mysql.SQLError {
+	switch x := errors.Cause(err).(type) {
+	case *terror.Error:
+		return terror.ToSQLError(x)
+	case *mysql.SQLError:
+		return x
+	default:
+		return mysql.NewErrf(mysql.ErrUnknown, "%s", nil, err.Error())
+	}
+}
+
+// bindStmt binds the local variables of a SQL statement, the text of the
+// statement is restored before binding for the slow log and the statement summary.
+func (e *procedureExec) bindStmt(stmt ast.StmtNode) (ast.StmtNode, error) {
+	if _, ok := e.bound[stmt]; !ok {
+		var sb strings.Builder
+		if err := stmt.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)); err != nil {
+			return nil, errors.Trace(err)
+		}
+		stmt.SetText(nil, sb.String())
+	}
+	return e.bind(stmt).(ast.StmtNode), nil
+}
+
+func (e *procedureExec) bind(node ast.Node) ast.Node {
+	bound, ok := e.bound[node]
+	if !ok {
+		binder := &procedureBinder{e: e}
+		newNode, _ := node.Accept(binder)
+		bound = &procedureBound{node: newNode, bindings: binder.bindings}
+		e.bound[node] = bound
+	}
+	for _, b := range bound.bindings {
+		b.expr.Datum = b.v.value()
+		b.expr.SetType(b.v.tp)
+	}
+	return bound.node
+}
+
+func (e *procedureExec) evalExpr(ctx context.Context, expr ast.ExprNode) (types.Datum, error) {
+	if !hasSubquery(expr) {
+		return expression.EvalAstExpr(e.s, e.bind(expr).(ast.ExprNode))
+	}
+	// An expression with subqueries is evaluated by a SELECT statement.
+	sel, ok := e.selects[expr]
+	if !ok {
+		sel = &ast.SelectStmt{
+			SelectStmtOpts: &ast.SelectStmtOpts{SQLCache: true},
+			Kind:           ast.SelectStmtKindSelect,
+			Fields:         &ast.FieldList{Fields: []*ast.SelectField{{Expr: expr}}},
+		}
+		e.selects[expr] = sel
+	}
+	fields, rows, err := e.query(ctx, sel)
+	if err != nil {
+		return types.Datum{}, err
+	}
+	return rowDatum(rows[0], 0, &fields[0].Column.FieldType), nil
+}
+
+func (e *procedureExec) evalCond(ctx context.Context, expr ast.ExprNode) (bool, error) {
+	d, err := e.evalExpr(ctx, expr)
+	if err != nil || d.IsNull() {
+		return false, err
+	}
+	b, err := d.ToBool(e.s.sessionVars.StmtCtx)
+	return b == 1, err
+}
+
+func hasSubquery(expr ast.ExprNode) bool {
+	checker := &subqueryChecker{}
+	expr.Accept(checker)
+	return checker.found
+}
+
+type subqueryChecker struct {
+	found bool
+}
+
+// Enter implements Visitor interface.
+func (c *subqueryChecker) Enter(in ast.Node) (ast.Node, bool) {
+	if _, ok := in.(*ast.SubqueryExpr); ok {
+		c.found = true
+	}
+	return in, c.found
+}
+
+// Leave implements Visitor interface.
+func (c *subqueryChecker) Leave(in ast.Node) (ast.Node, bool) {
+	return in, true
+}
+
+// query executes a statement and fetches all the rows of it.
+func (e *procedureExec) query(ctx context.Context, stmt ast.StmtNode) ([]*ast.ResultField, []chunk.Row, error) {
+	stmt, err := e.bindStmt(stmt)
+	if err != nil {
+		return nil, nil, err
+	}
+	if e.trigger != nil {
+		return e.trigger.ExecStmt(ctx, stmt)
+	}
+	rs, err := e.s.ExecuteStmt(ctx, stmt)
+	if err != nil || rs == nil {
+		return nil, nil, err
+	}
+	rows, err := drainRecordSet(ctx, e.s, rs, nil)
+	if closeErr := rs.Close(); err == nil {
+		err = closeErr
+	}
+	return rs.Fields(), rows, err
+}
+
+func (e *procedureExec) execSQL(ctx context.Context, stmt ast.StmtNode) error {
+	switch x := stmt.(type) {
+	case *ast.CallStmt:
+		return e.execCall(ctx, x)
+	case *ast.SetStmt:
+		return e.execSet(ctx, x)
+	case *ast.SelectStmt:
+		if _, ok := e.intos[x]; ok || x.SelectIntoOpt != nil {
+			return e.execSelectInto(ctx, x)
+		}
+	}
+	if e.writer != nil && e.trigger == nil {
+		return e.writeResult(ctx, stmt)
+	}
+	fields, rows, err := e.query(ctx, stmt)
+	if err != nil {
+		return err
+	}
+	if fields != nil {
+		if e.trigger != nil {
+			return dbterror.ErrSpNoRetset.GenWithStackByArgs("trigger")
+		}
+		e.result = newProcedureResult(fields, rows, e.s.sessionVars.MaxChunkSize)
+	}
+	return nil
+}
+
+// writeResult executes a statement and writes its result set by the writer, so the rows
+// are sent to the client without being buffered.
+func (e *procedureExec) writeResult(ctx context.Context, stmt ast.StmtNode) error {
+	stmt, err := e.bindStmt(stmt)
+	if err != nil {
+		return err
+	}
+	rs, err := e.s.ExecuteStmt(ctx, stmt)
+	if err != nil || rs == nil {
+		return err
+	}
+	err = e.writer(ctx, rs)
+	if closeErr := rs.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// execSet assigns the local variables, and executes the other assignments by SET statements.
+func (e *procedureExec) execSet(ctx context.Context, set *ast.SetStmt) error {
+	isLocal := func(assign *ast.VariableAssignment) bool {
+		return assign.IsSystem && !assign.IsGlobal && assign.ExtendValue == nil && e.lookupVar(assign.Name) != nil
+	}
+	hasLocal := false
+	for _, assign := range set.Variables {
+		hasLocal = hasLocal || isLocal(assign)
+	}
+	if !hasLocal {
+		_, _, err := e.query(ctx, set)
+		return err
+	}
+	for _, assign := range set.Variables {
+		if isLocal(assign) {
+			d, err := e.evalExpr(ctx, assign.Value)
+			if err != nil {
+				return err
+			}
+			if err = e.lookupVar(assign.Name).set(e.s, d); err != nil {
+				return err
+			}
+			continue
+		}
+		stmt, ok := e.sets[assign]
+		if !ok {
+			stmt = &ast.SetStmt{Variables: []*ast.VariableAssignment{assign}}
+			e.sets[assign] = stmt
+		}
+		if _, _, err := e.query(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// execSelectInto executes the SELECT statement without the INTO clause, and
+// assigns the only row to the variables.
+func (e *procedureExec) execSelectInto(ctx context.Context, sel *ast.SelectStmt) error {
+	into, ok := e.intos[sel]
+	if !ok {
+		into = sel.SelectIntoOpt
+		sel.SelectIntoOpt = nil
+		e.intos[sel] = into
+	}
+	fields, rows, err := e.query(ctx, sel)
+	if err != nil {
+		return err
+	}
+	if len(fields) != len(into.Vars) {
+		return plannercore.ErrWrongNumberOfColumnsInSelect.GenWithStackByArgs()
+	}
+	if len(rows) > 1 {
+		return errTooManyRows.GenWithStackByArgs()
+	}
+	if len(rows) == 0 {
+		// It's a warning unless a handler handles it.
+		err = errSpFetchNoData.GenWithStackByArgs()
+		if _, handler := e.findHandler(toProcedureSQLError(err)); handler != nil {
+			return err
+		}
+		e.s.sessionVars.StmtCtx.AppendWarning(err)
+		return nil
+	}
+	for i, target := range into.Vars {
+		tp := &fields[i].Column.FieldType
+		if err = e.assign(target, rowDatum(rows[0], i, tp), tp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isAssignable checks whether the expression is a user variable or a local variable.
+func (e *procedureExec) isAssignable(expr ast.ExprNode) bool {
+	switch x := expr.(type) {
+	case *ast.VariableExpr:
+		return !x.IsSystem && x.Value == nil
+	case *ast.ColumnNameExpr:
+		return x.Name.Table.L == "" && e.lookupVar(x.Name.Name.L) != nil
+	}
+	return false
+}
+
+// assign assigns the value to a user variable or a local variable.
+func (e *procedureExec) assign(target ast.ExprNode, d types.Datum, tp *types.FieldType) error {
+	switch x := target.(type) {
+	case *ast.VariableExpr:
+		vars := e.s.sessionVars
+		name := strings.ToLower(x.Name)
+		vars.UsersLock.Lock()
+		if d.IsNull() {
+			delete(vars.Users, name)
+			delete(vars.UserVarTypes, name)
+		} else {
+			vars.Users[name] = d
+			vars.UserVarTypes[name] = tp
+		}
+		vars.UsersLock.Unlock()
+		return nil
+	case *ast.ColumnNameExpr:
+		if v := e.lookupVar(x.Name.Name.L); v != nil {
+			return v.set(e.s, d)
+		}
+		return errSpUndeclaredVar.GenWithStackByArgs(x.Name.Name.O)
+	}
+	return errors.Errorf("unexpected assignment target %T", target)
+}
+
+func (e *procedureExec) openCursor(ctx context.Context, name string) error {
+	c := e.lookupCursor(name)
+	if c.open {
+		return errSpCursorAlreadyOpen.GenWithStackByArgs()
+	}
+	fields, rows, err := e.query(ctx, c.decl.Query)
+	if err != nil {
+		return err
+	}
+	c.open, c.fields, c.rows, c.pos = true, fields, rows, 0
+	return nil
+}
+
+func (e *procedureExec) fetchCursor(fetch *ast.ProcedureFetchStmt) error {
+	c := e.lookupCursor(fetch.Cursor)
+	if !c.open {
+		return errSpCursorNotOpen.GenWithStackByArgs()
+	}
+	if len(fetch.Vars) != len(c.fields) {
+		return errSpWrongNoOfFetchArgs.GenWithStackByArgs()
+	}
+	if c.pos >= len(c.rows) {
+		return errSpFetchNoData.GenWithStackByArgs()
+	}
+	row := c.rows[c.pos]
+	c.pos++
+	for i, name := range fetch.Vars {
+		v := e.lookupVar(name)
+		if v == nil {
+			return errSpUndeclaredVar.GenWithStackByArgs(name)
+		}
+		if err := v.set(e.s, rowDatum(row, i, &c.fields[i].Column.FieldType)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *procedureExec) closeCursor(name string) error {
+	c := e.lookupCursor(name)
+	if !c.open {
+		return errSpCursorNotOpen.GenWithStackByArgs()
+	}
+	c.close()
+	return nil
+}
+
+// rowDatum gets a datum which doesn't refer to the memory of the row.
+func rowDatum(row chunk.Row, i int, tp *types.FieldType) types.Datum {
+	d := row.GetDatum(i, tp)
+	return *d.Clone()
+}
+
+// procedureResult is a result set produced by a statement of a stored procedure.
+type procedureResult struct {
+	fields       []*ast.ResultField
+	rows         []chunk.Row
+	idx          int
+	maxChunkSize int
+}
+
+func newProcedureResult(fields []*ast.ResultField, rows []chunk.Row, maxChunkSize int) *procedureResult {
+	return &procedureResult{fields: fields, rows: rows, maxChunkSize: maxChunkSize}
+}
+
+// Fields implements the sqlexec.RecordSet interface.
+func (r *procedureResult) Fields() []*ast.ResultField {
+	return r.fields
+}
+
+// Next implements the sqlexec.RecordSet interface.
+func (r *procedureResult) Next(_ context.Context, chk *chunk.Chunk) error {
+	chk.Reset()
+	for !chk.IsFull() && r.idx < len(r.rows) {
+		chk.AppendRow(r.rows[r.idx])
+		r.idx++
+	}
+	return nil
+}
+
+// NewChunk implements the sqlexec.RecordSet interface.
+func (r *procedureResult) NewChunk(alloc chunk.Allocator) *chunk.Chunk {
+	fieldTypes := make([]*types.FieldType, 0, len(r.fields))
+	for _, field := range r.fields {
+		fieldTypes = append(fieldTypes, &field.Column.FieldType)
+	}
+	if alloc == nil {
+		return chunk.New(fieldTypes, r.maxChunkSize, r.maxChunkSize)
+	}
+	return alloc.Alloc(fieldTypes, r.maxChunkSize, r.maxChunkSize)
+}
+
+// Close implements the sqlexec.RecordSet interface.
+func (r *procedureResult) Close() error {
+	return nil
+}
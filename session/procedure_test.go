@@ -0,0 +1,238 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/parser/auth"
+	"github.com/pingcap/tidb/parser/terror"
+	"github.com/pingcap/tidb/session"
+	"github.com/pingcap/tidb/testkit"
+	"github.com/pingcap/tidb/util/sqlexec"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateDropProcedure(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create procedure p(in a int, out b varchar(10)) begin set b = concat('v', a); end")
+	tk.MustGetErrCode("create procedure p() select 1", errno.ErrSpAlreadyExists)
+	tk.MustExec("create procedure if not exists p() select 1")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1304 PROCEDURE p already exists"))
+	tk.MustQuery("select routine_schema, routine_name, routine_type, routine_definition from information_schema.routines where routine_name = 'p'").Check(
+		testkit.Rows("test p PROCEDURE BEGIN SET @@SESSION.`b`=CONCAT(_UTF8MB4'v', `a`); END"))
+	rows := tk.MustQuery("show procedure status").Rows()
+	require.Len(t, rows, 1)
+	require.Equal(t, []interface{}{"test", "p", "PROCEDURE"}, rows[0][:3])
+	require.Equal(t, []interface{}{"INVOKER", "", "utf8mb4", "utf8mb4_bin", "utf8mb4_bin"}, rows[0][6:])
+
+	tk.MustGetErrCode("create procedure p1(a int, a int) select 1", errno.ErrSpDupParam)
+	tk.MustGetErrCode("create procedure p1() begin declare a int; declare a int; end", errno.ErrSpDupVar)
+	tk.MustGetErrCode("create procedure p1() begin declare c cursor for select 1; declare c cursor for select 2; end", errno.ErrSpDupCurs)
+	tk.MustGetErrCode("create procedure p1() begin declare c cursor for select 1; declare a int; end", errno.ErrSpVarcondAfterCurshndlr)
+	tk.MustGetErrCode("create procedure p1() begin declare exit handler for not found begin end; declare c cursor for select 1; end", errno.ErrSpCursorAfterHandler)
+	tk.MustGetErrCode("create procedure p1() open c", errno.ErrSpCursorMismatch)
+	tk.MustGetErrCode("create procedure p1() l: begin iterate l; end", errno.ErrSpLilabelMismatch)
+	tk.MustGetErrCode("create procedure p1() l: loop l: loop leave l; end loop; end loop", errno.ErrSpLabelRedefine)
+	tk.MustGetErrCode("create procedure p1() begin declare continue handler for sqlstate '00000' begin end; end", errno.ErrSpBadSQLstate)
+	tk.MustGetErrCode("create procedure not_exists.p1() select 1", errno.ErrBadDB)
+
+	tk.MustExec("drop procedure p")
+	tk.MustGetErrCode("drop procedure p", errno.ErrSpDoesNotExist)
+	tk.MustExec("drop procedure if exists p")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1305 PROCEDURE p does not exist"))
+	tk.MustQuery("select count(*) from information_schema.routines where routine_schema = 'test'").Check(testkit.Rows("0"))
+}
+
+func TestCallProcedure(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, v varchar(20))")
+	tk.MustExec(`create procedure fill(in n int)
+begin
+	declare i int default 0;
+	while i < n do
+		set i = i + 1;
+		if i % 2 = 0 then
+			insert into t values (i, concat('even', i));
+		elseif i = 3 then
+			iterate_skip: begin
+				leave iterate_skip;
+			end;
+		else
+			insert into t values (i, concat('odd', i));
+		end if;
+	end while;
+end`)
+	tk.MustExec("call fill(5)")
+	tk.MustQuery("select * from t").Check(testkit.Rows("1 odd1", "2 even2", "4 even4", "5 odd5"))
+	tk.MustGetErrCode("call fill()", errno.ErrSpWrongNoOfArgs)
+	tk.MustGetErrCode("call fill(1, 2)", errno.ErrSpWrongNoOfArgs)
+	tk.MustGetErrCode("call not_exists()", errno.ErrSpDoesNotExist)
+
+	// OUT and INOUT parameters, SELECT ... INTO and REPEAT loops.
+	tk.MustExec(`create procedure stat(out cnt int, inout total varchar(100))
+begin
+	declare s int;
+	select count(*), sum(id) into cnt, s from t;
+	repeat
+		set total = concat(total, '.');
+		set s = s - 5;
+	until s <= 0 end repeat;
+end`)
+	tk.MustExec("set @total = 'x'")
+	tk.MustExec("call stat(@cnt, @total)")
+	tk.MustQuery("select @cnt, @total").Check(testkit.Rows("4 x..."))
+	tk.MustGetErrCode("call stat(1, @total)", errno.ErrSpNotVarArg)
+
+	// A cursor with a NOT FOUND handler.
+	tk.MustExec(`create procedure concat_all(out res varchar(100))
+begin
+	declare done int default 0;
+	declare s varchar(20);
+	declare c cursor for select v from t order by id desc;
+	declare continue handler for not found set done = 1;
+	set res = '';
+	open c;
+	l: loop
+		fetch c into s;
+		if done then
+			leave l;
+		end if;
+		set res = concat(res, s, ',');
+	end loop;
+	close c;
+end`)
+	tk.MustExec("call concat_all(@res)")
+	tk.MustQuery("select @res").Check(testkit.Rows("odd5,even4,even2,odd1,"))
+
+	// An EXIT handler leaves the block where it's declared.
+	tk.MustExec(`create procedure dup(out res int)
+begin
+	set res = 0;
+	begin
+		declare exit handler for 1062 set res = res + 10;
+		insert into t values (1, 'dup');
+		set res = res + 1;
+	end;
+	set res = res + 100;
+end`)
+	tk.MustExec("call dup(@res)")
+	tk.MustQuery("select @res").Check(testkit.Rows("110"))
+
+	// Errors without handlers are returned to the client.
+	tk.MustExec("create procedure dup2() insert into t values (1, 'dup')")
+	tk.MustGetErrCode("call dup2()", errno.ErrDupEntry)
+	tk.MustExec("create procedure fetch_closed() begin declare a int; declare c cursor for select 1; fetch c into a; end")
+	tk.MustGetErrCode("call fetch_closed()", errno.ErrSpCursorNotOpen)
+	tk.MustExec("create procedure fetch_end() begin declare a int; declare c cursor for select 1 from dual where false; open c; fetch c into a; end")
+	tk.MustGetErrCode("call fetch_end()", errno.ErrSpFetchNoData)
+	tk.MustExec("create procedure too_many() begin declare a int; select id into a from t; end")
+	tk.MustGetErrCode("call too_many()", errno.ErrTooManyRows)
+	tk.MustExec("create procedure rec() call rec()")
+	tk.MustGetErrCode("call rec()", errno.ErrSpRecursionLimit)
+
+	// The result set of the procedure, nested calls, and subqueries in conditions.
+	tk.MustExec(`create procedure outer_proc(in n int)
+begin
+	declare x int default 1;
+	call stat(x, @total);
+	if (select count(*) from t where id > n) > 1 then
+		select x, n;
+	end if;
+end`)
+	tk.MustQuery("call outer_proc(1)").Check(testkit.Rows("4 1"))
+	tk.MustExec("call outer_proc(4)")
+
+	// The statements are executed in the database of the procedure.
+	tk.MustExec("create database test2")
+	tk.MustExec("use test2")
+	tk.MustQuery("call test.outer_proc(1)").Check(testkit.Rows("4 1"))
+	tk.MustQuery("select database()").Check(testkit.Rows("test2"))
+
+	// SELECT ... INTO user variables outside procedures.
+	tk.MustExec("select id, v into @a, @b from test.t where id = 2")
+	tk.MustQuery("select @a, @b").Check(testkit.Rows("2 even2"))
+	tk.MustExec("select id into @a from test.t where id = 10")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Warning 1329 No data - zero rows fetched, selected, or processed"))
+	tk.MustGetErrCode("select id into @a from test.t", errno.ErrTooManyRows)
+}
+
+func TestCallProcedureResultSetWriter(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int primary key)")
+	tk.MustExec("insert into t values (1), (2)")
+	tk.MustExec("create procedure p() begin select a from t order by a; select count(*) from t; insert into t values (1); end")
+
+	// Without a writer, only the last result set is returned.
+	tk.MustExec("create procedure p1() begin select a from t order by a; select count(*) from t; end")
+	tk.MustQuery("call p1()").Check(testkit.Rows("2"))
+
+	// With a writer, every result set is written as soon as it's produced.
+	var results [][][]string
+	writer := func(ctx context.Context, rs sqlexec.RecordSet) error {
+		rows, err := session.ResultSetToStringSlice(ctx, tk.Session(), rs)
+		results = append(results, rows)
+		return err
+	}
+	ctx := context.WithValue(context.Background(), sqlexec.ResultSetWriterKey, sqlexec.ResultSetWriter(writer))
+	stmts, err := tk.Session().Parse(ctx, "call p()")
+	require.NoError(t, err)
+	rs, err := tk.Session().ExecuteStmt(ctx, stmts[0])
+	require.Nil(t, rs)
+	require.True(t, terror.ErrorEqual(err, kv.ErrKeyExists), "%v", err)
+	// The result sets produced before the error have been written.
+	require.Equal(t, [][][]string{{{"1"}, {"2"}}, {{"2"}}}, results)
+}
+
+func TestCallProcedurePrivilege(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int)")
+	tk.MustExec("create procedure p() insert into t values (1)")
+	tk.MustExec("create user u")
+
+	tk1 := testkit.NewTestKit(t, store)
+	require.True(t, tk1.Session().Auth(&auth.UserIdentity{Username: "u", Hostname: "localhost"}, nil, nil))
+	tk1.MustGetErrCode("create procedure test.p1() select 1", errno.ErrDBaccessDenied)
+	tk1.MustGetErrCode("call test.p()", errno.ErrProcaccessDenied)
+	tk.MustExec("grant execute on test.* to u")
+	// The statements of the procedure are executed with the privileges of the invoker.
+	tk1.MustGetErrCode("call test.p()", errno.ErrTableaccessDenied)
+	tk.MustExec("grant insert on test.t to u")
+	tk1.MustExec("call test.p()")
+	tk1.MustGetErrCode("drop procedure test.p", errno.ErrDBaccessDenied)
+	tk.MustExec("grant create routine, alter routine on test.* to u")
+	tk1.MustExec("create procedure test.p1() select 1")
+	tk1.MustExec("drop procedure test.p")
+	tk.MustQuery("select a from t").Check(testkit.Rows("1"))
+}
@@ -1835,6 +1835,20 @@ func (s *session) ExecuteStmt(ctx context.Context, stmtNode ast.StmtNode) (sqlex
 	if err := s.validateStatementReadOnlyInStaleness(stmtNode); err != nil {
 		return nil, err
 	}
+	if err := s.validateStatementInXA(stmtNode); err != nil {
+		return nil, err
+	}
+
+	// The statements of a stored procedure are executed one by one, and the
+	// variables are assigned after the SELECT statement without INTO is executed.
+	switch x := stmtNode.(type) {
+	case *ast.CallStmt:
+		return s.executeCall(ctx, x)
+	case *ast.SelectStmt:
+		if x.SelectIntoOpt != nil && x.SelectIntoOpt.Tp == ast.SelectIntoVars {
+			return nil, s.executeSelectIntoVars(ctx, x)
+		}
+	}
 
 	// Uncorrelated subqueries will execute once when building plan, so we reset process info before building plan.
 	cmd32 := atomic.LoadUint32(&s.GetSessionVars().CommandValue)
@@ -1896,6 +1910,27 @@ func (s *session) ExecuteStmt(ctx context.Context, stmtNode ast.StmtNode) (sqlex
 	return recordSet, nil
 }
 
+// validateStatementInXA checks whether the statement can be executed in the current state of
+// the XA transaction branch, the statements which end the transaction implicitly are forbidden,
+// and no statement can access data after XA END.
+func (s *session) validateStatementInXA(stmtNode ast.StmtNode) error {
+	xa := s.sessionVars.XATxn
+	if xa == nil {
+		return nil
+	}
+	switch stmtNode.(type) {
+	case *ast.BeginStmt, *ast.CommitStmt, *ast.RollbackStmt, ast.DDLNode,
+		*ast.CreateUserStmt, *ast.AlterUserStmt, *ast.DropUserStmt, *ast.RenameUserStmt,
+		*ast.CreateEventStmt, *ast.AlterEventStmt, *ast.DropEventStmt:
+		return executor.ErrXaerRmfail.GenWithStackByArgs(xa.State)
+	case ast.DMLNode, *ast.CallStmt:
+		if xa.State != variable.XAActive {
+			return executor.ErrXaerRmfail.GenWithStackByArgs(xa.State)
+		}
+	}
+	return nil
+}
+
 func (s *session) validateStatementReadOnlyInStaleness(stmtNode ast.StmtNode) error {
 	vars := s.GetSessionVars()
 	if !vars.TxnCtx.IsStaleness && vars.TxnReadTS.PeakTxnReadTS() == 0 {
@@ -2896,6 +2931,12 @@ func BootstrapSession(store kv.Storage) (*domain.Domain, error) {
 
 	dom.PlanReplayerLoop()
 
+	se8, err := createSession(store)
+	if err != nil {
+		return nil, err
+	}
+	dom.EventSchedulerLoop(newEventScheduler(store, se8).fire)
+
 	if raw, ok := store.(kv.EtcdBackend); ok {
 		err = raw.StartGCWorker()
 		if err != nil {
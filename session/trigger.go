@@ -0,0 +1,61 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"context"
+
+	"github.com/pingcap/tidb/executor"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+)
+
+// triggerProgram is the parsed body of a trigger, it's cached in the
+// TriggerContext and reused by the activations on the rows of a statement.
+type triggerProgram struct {
+	e       *procedureExec
+	body    ast.StmtNode
+	sqlMode mysql.SQLMode
+}
+
+// RunTrigger implements executor.TriggerRunner interface.
+func (s *session) RunTrigger(ctx context.Context, tc *executor.TriggerContext) error {
+	prog, ok := tc.Program.(*triggerProgram)
+	if !ok {
+		e := newProcedureExec(s, nil)
+		e.db, e.trigger = tc.DB, tc
+		e.rowVars = make(map[string]*procedureVar, 2*len(tc.Table.Columns))
+		for _, col := range tc.Table.Columns {
+			if tc.Trigger.Event != model.TriggerDelete {
+				e.rowVars["new."+col.Name.L] = &procedureVar{name: col.Name.O, tp: &col.FieldType, row: &tc.NewRow, offset: col.Offset}
+			}
+			if tc.Trigger.Event != model.TriggerInsert {
+				e.rowVars["old."+col.Name.L] = &procedureVar{name: col.Name.O, tp: &col.FieldType, row: &tc.OldRow, offset: col.Offset}
+			}
+		}
+		trig := tc.Trigger
+		body, sqlMode, err := e.parse(trig.Body, trig.SQLMode, trig.Charset, trig.Collate)
+		if err != nil {
+			return err
+		}
+		prog = &triggerProgram{e: e, body: body, sqlMode: sqlMode}
+		tc.Program = prog
+	}
+	if err := prog.e.checkKilled(); err != nil {
+		return err
+	}
+	return prog.e.exec(ctx, prog.body, prog.sqlMode)
+}
@@ -0,0 +1,96 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session_test
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateDropTrigger(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b int)")
+	tk.MustExec("create table log (v int)")
+	tk.MustExec("create trigger tr1 before insert on t for each row set new.b = new.a * 2")
+	tk.MustGetErrCode("create trigger tr1 after insert on t for each row insert into log values (new.a)", errno.ErrTrgAlreadyExists)
+	tk.MustExec("create trigger if not exists tr1 after insert on t for each row insert into log values (new.a)")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1359 Trigger already exists"))
+	tk.MustExec("create trigger tr2 before insert on t for each row precedes tr1 set new.a = new.a + 1")
+	tk.MustQuery("select trigger_name, event_manipulation, event_object_table, action_order, action_timing, action_statement from information_schema.triggers where trigger_schema = 'test'").Check(testkit.Rows(
+		"tr2 INSERT t 1 BEFORE SET @@SESSION.`new.a`=`new`.`a`+1",
+		"tr1 INSERT t 2 BEFORE SET @@SESSION.`new.b`=`new`.`a`*2"))
+	rows := tk.MustQuery("show triggers").Rows()
+	require.Len(t, rows, 2)
+	require.Equal(t, []interface{}{"tr2", "INSERT", "t"}, rows[0][:3])
+	require.Equal(t, "BEFORE", rows[0][4])
+
+	tk.MustGetErrCode("create trigger tr3 before insert on t for each row follows tr9 set new.a = 1", errno.ErrReferencedTrgDoesNotExist)
+	tk.MustGetErrCode("create trigger tr3 before delete on t for each row set @x = new.a", errno.ErrTrgNoSuchRowInTrg)
+	tk.MustGetErrCode("create trigger tr3 before insert on t for each row set @x = old.a", errno.ErrTrgNoSuchRowInTrg)
+	tk.MustGetErrCode("create trigger tr3 after insert on t for each row set new.a = 1", errno.ErrTrgCantChangeRow)
+	tk.MustGetErrCode("create trigger tr3 before update on t for each row set old.a = 1", errno.ErrTrgCantChangeRow)
+	tk.MustGetErrCode("create trigger tr3 before insert on t for each row set new.c = 1", errno.ErrBadField)
+	tk.MustGetErrCode("create trigger tr3 before insert on t for each row select 1", errno.ErrSpNoRetset)
+	tk.MustGetErrCode("create trigger tr3 before insert on t for each row commit", errno.ErrCommitNotAllowedInSfOrTrg)
+	tk.MustExec("create view v as select * from t")
+	tk.MustGetErrCode("create trigger tr3 before insert on v for each row set @x = 1", errno.ErrTrgOnViewOrTempTable)
+	tk.MustGetErrCode("create trigger mysql.tr3 before insert on test.t for each row set @x = 1", errno.ErrTrgInWrongSchema)
+
+	tk.MustExec("drop trigger tr2")
+	tk.MustGetErrCode("drop trigger tr2", errno.ErrTrgDoesNotExist)
+	tk.MustExec("drop trigger if exists tr2")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1360 Trigger does not exist"))
+	tk.MustExec("drop trigger test.tr1")
+	tk.MustQuery("select count(*) from information_schema.triggers where trigger_schema = 'test'").Check(testkit.Rows("0"))
+}
+
+func TestTriggerActivation(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, v int, g int as (v + 1))")
+	tk.MustExec("create table log (msg varchar(64))")
+	tk.MustExec("create trigger bi before insert on t for each row begin if new.v < 0 then set new.v = 0; end if; end")
+	tk.MustExec("create trigger ai after insert on t for each row insert into log values (concat('ins ', new.id, ' ', new.g))")
+	tk.MustExec("create trigger bu before update on t for each row set new.v = old.v + new.v")
+	tk.MustExec("create trigger au after update on t for each row insert into log values (concat('upd ', old.v, '->', new.v))")
+	tk.MustExec("create trigger ad after delete on t for each row insert into log values (concat('del ', old.id))")
+
+	tk.MustExec("insert into t (id, v) values (1, -5), (2, 3)")
+	require.Equal(t, uint64(2), tk.Session().AffectedRows())
+	tk.MustQuery("select id, v, g from t order by id").Check(testkit.Rows("1 0 1", "2 3 4"))
+	tk.MustExec("update t set v = 10 where id = 2")
+	tk.MustQuery("select v, g from t where id = 2").Check(testkit.Rows("13 14"))
+	tk.MustExec("insert into t (id, v) values (1, 1) on duplicate key update v = 5")
+	tk.MustQuery("select v from t where id = 1").Check(testkit.Rows("5"))
+	tk.MustExec("delete from t where id = 1")
+	tk.MustExec("replace into t (id, v) values (2, 7)")
+	tk.MustQuery("select * from log").Check(testkit.Rows(
+		"ins 1 1", "ins 2 4", "upd 3->13", "upd 0->5", "del 1", "del 2", "ins 2 8"))
+
+	// The table of the statement which activates the trigger can't be modified by the trigger.
+	tk.MustExec("create trigger ai2 after insert on log for each row delete from t")
+	tk.MustGetErrCode("insert into t (id, v) values (3, 3)", errno.ErrCantUpdateUsedTableInSfOrTrg)
+	tk.MustQuery("select count(*) from t where id = 3").Check(testkit.Rows("0"))
+}
@@ -0,0 +1,138 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session_test
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/parser/auth"
+	"github.com/pingcap/tidb/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestXATransaction(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int primary key, b int)")
+	tk.MustExec("insert into t values (1, 1), (2, 2)")
+
+	// The prepared branch survives the session and is committed by another one.
+	tk1 := testkit.NewTestKit(t, store)
+	tk1.MustExec("use test")
+	tk1.MustExec("xa start 'x1', 'b1'")
+	tk1.MustExec("insert into t values (3, 3)")
+	tk1.MustExec("update t set b = 10 where a = 1")
+	tk1.MustExec("xa end 'x1', 'b1'")
+	tk1.MustExec("xa prepare 'x1', 'b1'")
+	tk1.MustQuery("xa recover").Check(testkit.Rows("1 2 2 x1b1"))
+	tk1.Session().Close()
+
+	tk.MustQuery("xa recover convert xid").Check(testkit.Rows("1 2 2 0x78316231"))
+	tk.MustGetErrCode("xa commit 'x1'", errno.ErrXaerNota)
+	tk.MustExec("xa commit 'x1', 'b1'")
+	tk.MustQuery("xa recover").Check(testkit.Rows())
+	tk.MustQuery("select * from t").Check(testkit.Rows("1 10", "2 2", "3 3"))
+	tk.MustGetErrCode("xa commit 'x1', 'b1'", errno.ErrXaerNota)
+
+	// Roll back a prepared branch of a pessimistic transaction.
+	tk1 = testkit.NewTestKit(t, store)
+	tk1.MustExec("use test")
+	tk1.MustExec("set tidb_txn_mode = 'pessimistic'")
+	tk1.MustExec("xa start 'x2'")
+	tk1.MustExec("delete from t where a = 2")
+	tk1.MustExec("xa end 'x2'")
+	tk1.MustExec("xa prepare 'x2'")
+	tk1.MustExec("xa rollback 'x2'")
+	tk1.MustQuery("select * from t").Check(testkit.Rows("1 10", "2 2", "3 3"))
+
+	// Commit a pessimistic branch in the session which prepares it.
+	tk1.MustExec("xa start 'x3'")
+	tk1.MustExec("update t set b = 20 where a = 2")
+	tk1.MustExec("xa end 'x3'")
+	tk1.MustExec("xa prepare 'x3'")
+	tk1.MustGetErrCode("select * from t", errno.ErrXaerRmfail)
+	tk1.MustExec("xa commit 'x3'")
+	tk.MustQuery("select * from t").Check(testkit.Rows("1 10", "2 20", "3 3"))
+
+	// Commit in one phase and roll back an idle branch.
+	tk1.MustExec("xa start 'x4'")
+	tk1.MustExec("insert into t values (4, 4)")
+	tk1.MustExec("xa end 'x4'")
+	tk1.MustExec("xa commit 'x4' one phase")
+	tk1.MustExec("xa start 'x5'")
+	tk1.MustExec("insert into t values (5, 5)")
+	tk1.MustExec("xa end 'x5'")
+	tk1.MustExec("xa rollback 'x5'")
+	tk.MustQuery("select * from t").Check(testkit.Rows("1 10", "2 20", "3 3", "4 4"))
+
+	// A read-only branch is recorded too.
+	tk1.MustExec("xa start 'x6'")
+	tk1.MustQuery("select count(*) from t").Check(testkit.Rows("4"))
+	tk1.MustExec("xa end 'x6'")
+	tk1.MustExec("xa prepare 'x6'")
+	tk.MustQuery("xa recover").Check(testkit.Rows("1 2 0 x6"))
+
+	// Only the users with XA_RECOVER_ADMIN can see the branches of other users.
+	tk.MustExec("create user 'xa_user'@'%'")
+	tk2 := testkit.NewTestKit(t, store)
+	require.True(t, tk2.Session().Auth(&auth.UserIdentity{Username: "xa_user", Hostname: "%"}, nil, nil))
+	tk2.MustQuery("xa recover").Check(testkit.Rows())
+	tk.MustExec("grant XA_RECOVER_ADMIN on *.* to 'xa_user'@'%'")
+	tk2.MustQuery("xa recover").Check(testkit.Rows("1 2 0 x6"))
+	tk2.MustExec("xa commit 'x6'")
+	tk.MustQuery("xa recover").Check(testkit.Rows())
+}
+
+func TestXATransactionState(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int)")
+
+	tk.MustGetErrCode("xa end 'x'", errno.ErrXaerRmfail)
+	tk.MustGetErrCode("xa prepare 'x'", errno.ErrXaerRmfail)
+	tk.MustGetErrCode("xa rollback 'x'", errno.ErrXaerNota)
+	tk.MustExec("begin")
+	tk.MustGetErrCode("xa start 'x'", errno.ErrXaerOutside)
+	tk.MustExec("rollback")
+
+	tk.MustExec("xa start 'x'")
+	tk.MustGetErrCode("xa start 'y'", errno.ErrXaerRmfail)
+	tk.MustGetErrCode("begin", errno.ErrXaerRmfail)
+	tk.MustGetErrCode("commit", errno.ErrXaerRmfail)
+	tk.MustGetErrCode("create table t2 (a int)", errno.ErrXaerRmfail)
+	tk.MustGetErrCode("xa prepare 'x'", errno.ErrXaerRmfail)
+	tk.MustGetErrCode("xa rollback 'x'", errno.ErrXaerRmfail)
+	tk.MustGetErrCode("xa end 'y'", errno.ErrXaerNota)
+	tk.MustExec("insert into t values (1)")
+	tk.MustExec("xa end 'x'")
+	tk.MustGetErrCode("insert into t values (2)", errno.ErrXaerRmfail)
+	tk.MustGetErrCode("xa commit 'x'", errno.ErrXaerRmfail)
+	tk.MustExec("xa prepare 'x'")
+	tk.MustGetErrCode("xa start 'y'", errno.ErrXaerRmfail)
+
+	// The XID of a prepared branch can't be reused.
+	tk1 := testkit.NewTestKit(t, store)
+	tk1.MustGetErrCode("xa start 'x'", errno.ErrXaerDupid)
+	tk1.MustExec("xa rollback 'x'")
+	tk.MustGetErrCode("xa commit 'x'", errno.ErrXaerNota)
+	tk.MustQuery("select * from t").Check(testkit.Rows())
+}
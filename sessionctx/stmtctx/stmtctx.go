@@ -67,7 +67,10 @@ type StatementContext struct {
 
 	// IsDDLJobInQueue is used to mark whether the DDL job is put into the queue.
 	// If IsDDLJobInQueue is true, it means the DDL job is in the queue of storage, and it can be handled by the DDL worker.
-	IsDDLJobInQueue        bool
+	IsDDLJobInQueue bool
+	// MultiSchemaInfo is used to collect the sub-jobs of a multi-schema change ALTER TABLE statement.
+	// If it is not nil, the DDL jobs are not run but appended to it as sub-jobs.
+	MultiSchemaInfo        *model.MultiSchemaInfo
 	InInsertStmt           bool
 	InUpdateStmt           bool
 	InDeleteStmt           bool
@@ -397,6 +400,49 @@ func (sc *StatementContext) AffectedRows() uint64 {
 	return sc.mu.affectedRows
 }
 
+// RowsCounter is a snapshot of the row counters and the info message of a statement.
+type RowsCounter struct {
+	affectedRows uint64
+	foundRows    uint64
+	records      uint64
+	deleted      uint64
+	updated      uint64
+	copied       uint64
+	touched      uint64
+	message      string
+}
+
+// SaveRowsCounter saves the row counters and the info message, they can be restored by RestoreRowsCounter.
+// The foreign key cascading actions use it to exclude the rows they change from the statement result.
+func (sc *StatementContext) SaveRowsCounter() RowsCounter {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	return RowsCounter{
+		affectedRows: sc.mu.affectedRows,
+		foundRows:    sc.mu.foundRows,
+		records:      sc.mu.records,
+		deleted:      sc.mu.deleted,
+		updated:      sc.mu.updated,
+		copied:       sc.mu.copied,
+		touched:      sc.mu.touched,
+		message:      sc.mu.message,
+	}
+}
+
+// RestoreRowsCounter restores the row counters and the info message saved by SaveRowsCounter.
+func (sc *StatementContext) RestoreRowsCounter(c RowsCounter) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	sc.mu.affectedRows = c.affectedRows
+	sc.mu.foundRows = c.foundRows
+	sc.mu.records = c.records
+	sc.mu.deleted = c.deleted
+	sc.mu.updated = c.updated
+	sc.mu.copied = c.copied
+	sc.mu.touched = c.touched
+	sc.mu.message = c.message
+}
+
 // FoundRows gets found rows.
 func (sc *StatementContext) FoundRows() uint64 {
 	sc.mu.Lock()
@@ -129,6 +129,22 @@ func (r *retryInfoAutoIDs) getCurrent() (int64, bool) {
 	return id, true
 }
 
+// XATxnState is the state of an XA transaction branch.
+type XATxnState string
+
+// XA transaction branch states.
+const (
+	XAActive   XATxnState = "ACTIVE"
+	XAIdle     XATxnState = "IDLE"
+	XAPrepared XATxnState = "PREPARED"
+)
+
+// XATxnInfo is the XA transaction branch that a session works on.
+type XATxnInfo struct {
+	XID   ast.XID
+	State XATxnState
+}
+
 // TransactionContext is used to store variables that has transaction scope.
 type TransactionContext struct {
 	forUpdateTS uint64
@@ -498,6 +514,9 @@ type SessionVars struct {
 	//  TxnCtx Should be reset on transaction finished.
 	TxnCtx *TransactionContext
 
+	// XATxn is the XA transaction branch that the session works on, it's nil if the session is not in an XA transaction.
+	XATxn *XATxnInfo
+
 	// TxnManager is used to manage txn context in session
 	TxnManager interface{}
 
@@ -900,6 +919,10 @@ type SessionVars struct {
 	// PresumeKeyNotExists indicates lazy existence checking is enabled.
 	PresumeKeyNotExists bool
 
+	// ForeignKeyChecks indicates whether to check the foreign key constraints and
+	// execute the foreign key cascading actions in the DML statements.
+	ForeignKeyChecks bool
+
 	// EnableParallelApply indicates that thether to use parallel apply.
 	EnableParallelApply bool
 
@@ -975,6 +998,10 @@ type SessionVars struct {
 	// It's nil if there is no local temporary table.
 	LocalTemporaryTables interface{}
 
+	// HypoIndexes are the hypothetical indexes created in the session, indexed by the IDs of their tables. They
+	// are only attached to the tables seen by the optimizer when planning EXPLAIN statements.
+	HypoIndexes map[int64][]*model.IndexInfo
+
 	// TemporaryTableData stores committed kv values for temporary table for current session.
 	TemporaryTableData TemporaryTableData
 
@@ -393,11 +393,13 @@ func TestForeignKeyChecks(t *testing.T) {
 
 	val, err := sv.Validate(vars, "on", ScopeSession)
 	require.NoError(t, err)
-	require.Equal(t, "OFF", val) // warns and refuses to set ON.
-
-	warn := vars.StmtCtx.GetWarnings()[0].Err
-	require.Equal(t, "[variable:8047]variable 'foreign_key_checks' does not yet support value: on", warn.Error())
+	require.Equal(t, "ON", val)
+	require.Len(t, vars.StmtCtx.GetWarnings(), 0)
 
+	require.NoError(t, sv.SetSessionFromHook(vars, val))
+	require.True(t, vars.ForeignKeyChecks)
+	require.NoError(t, sv.SetSessionFromHook(vars, "OFF"))
+	require.False(t, vars.ForeignKeyChecks)
 }
 
 func TestTxnIsolation(t *testing.T) {
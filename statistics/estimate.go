@@ -33,6 +33,20 @@ func calculateEstimateNDV(h *topNHelper, rowCount uint64) (ndv uint64, scaleRati
 		// Nothing to do, no change with scale ratio
 		return sampleNDV, scaleRatio
 	}
+	return EstimateNDVBySample(sampleSize, sampleNDV, onlyOnceItems, rowCount), scaleRatio
+}
+
+// EstimateNDVBySample estimates the ndv of rowCount rows from a sample of sampleSize rows, which contains sampleNDV
+// distinct values and onlyOnceItems values occurred only once.
+func EstimateNDVBySample(sampleSize, sampleNDV, onlyOnceItems, rowCount uint64) uint64 {
+	if sampleSize == 0 {
+		return 0
+	}
+	if onlyOnceItems == sampleSize {
+		return rowCount
+	} else if onlyOnceItems == 0 {
+		return sampleNDV
+	}
 	// Charikar, Moses, et al. "Towards estimation error guarantees for distinct values."
 	// Proceedings of the nineteenth ACM SIGMOD-SIGACT-SIGART symposium on Principles of database systems. ACM, 2000.
 	// This is GEE in that paper.
@@ -44,8 +58,8 @@ func calculateEstimateNDV(h *topNHelper, rowCount uint64) (ndv uint64, scaleRati
 	N := float64(rowCount)
 	d := float64(sampleNDV)
 
-	ndv = uint64(math.Sqrt(N/n)*f1 + d - f1 + 0.5)
+	ndv := uint64(math.Sqrt(N/n)*f1 + d - f1 + 0.5)
 	ndv = mathutil.MaxUint64(ndv, sampleNDV)
 	ndv = mathutil.MinUint64(ndv, rowCount)
-	return ndv, scaleRatio
+	return ndv
 }
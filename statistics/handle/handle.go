@@ -18,8 +18,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
 	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -41,6 +43,7 @@ import (
 	"github.com/pingcap/tidb/table"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/codec"
 	"github.com/pingcap/tidb/util/logutil"
 	"github.com/pingcap/tidb/util/memory"
 	"github.com/pingcap/tidb/util/sqlexec"
@@ -1645,6 +1648,8 @@ func (h *Handle) ReloadExtendedStatistics() error {
 }
 
 // BuildExtendedStats build extended stats for column groups if needed based on the column samples.
+// The tableID is the ID of the table where the extended stats are defined, which is the ID of the partitioned
+// table rather than that of the partition when building the partition-level extended stats.
 func (h *Handle) BuildExtendedStats(tableID int64, cols []*model.ColumnInfo, collectors []*statistics.SampleCollector) (*statistics.ExtendedStatsColl, error) {
 	ctx := context.Background()
 	const sql = "SELECT name, type, column_ids FROM mysql.stats_extended WHERE table_id = %? and status in (%?, %?)"
@@ -1676,16 +1681,262 @@ func (h *Handle) BuildExtendedStats(tableID int64, cols []*model.ColumnInfo, col
 	return statsColl, nil
 }
 
+// MergePartitionExtStats2GlobalExtStats merges the partition-level extended stats to the global-level extended stats.
+// It must be called after the global-level column stats are saved, since they are used in the merging.
+func (h *Handle) MergePartitionExtStats2GlobalExtStats(globalTableInfo *model.TableInfo) (*statistics.ExtendedStatsColl, error) {
+	ctx := context.Background()
+	const sql = "SELECT name, type, column_ids FROM mysql.stats_extended WHERE table_id = %? and status in (%?, %?)"
+	rows, _, err := h.execRestrictedSQL(ctx, sql, globalTableInfo.ID, StatsStatusAnalyzed, StatsStatusInited)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(rows) == 0 || globalTableInfo.Partition == nil {
+		return nil, nil
+	}
+	globalStats, err := h.TableStatsFromStorage(globalTableInfo, globalTableInfo.ID, true, 0)
+	if err != nil || globalStats == nil {
+		return nil, err
+	}
+	partitionStats := make([]*statistics.Table, 0, len(globalTableInfo.Partition.Definitions))
+	for _, def := range globalTableInfo.Partition.Definitions {
+		partitionTbl, err := h.TableStatsFromStorage(globalTableInfo, def.ID, true, 0)
+		if err != nil {
+			return nil, err
+		}
+		// The partition-level stats are missing, which has been reported when merging the column stats.
+		if partitionTbl == nil {
+			return nil, nil
+		}
+		// An empty partition contributes nothing to the global-level extended stats.
+		if partitionTbl.Count > 0 {
+			partitionStats = append(partitionStats, partitionTbl)
+		}
+	}
+	statsColl := statistics.NewExtendedStatsColl()
+	for _, row := range rows {
+		name := row.GetString(0)
+		item := &statistics.ExtendedStatsItem{Tp: uint8(row.GetInt64(1))}
+		colIDs := row.GetString(2)
+		err := json.Unmarshal([]byte(colIDs), &item.ColIDs)
+		if err != nil {
+			logutil.BgLogger().Error("invalid column_ids in mysql.stats_extended, skip merging extended stats for this row", zap.String("column_ids", colIDs), zap.Error(err))
+			continue
+		}
+		item = mergePartitionExtStatsItem(name, item, globalStats, partitionStats)
+		if item != nil {
+			statsColl.Stats[name] = item
+		}
+	}
+	if len(statsColl.Stats) == 0 {
+		return nil, nil
+	}
+	return statsColl, nil
+}
+
+// mergePartitionExtStatsItem merges the partition-level extended stats item to the global-level one. The correlation
+// and dependency degrees are averaged with the row counts of partitions as weights. For cardinality, the ratio of the
+// column group NDV to the max NDV of the columns in each partition is averaged likewise, and then applied to the
+// global-level column NDVs, since the NDVs of different partitions may overlap.
+func mergePartitionExtStatsItem(name string, item *statistics.ExtendedStatsItem, globalStats *statistics.Table, partitionStats []*statistics.Table) *statistics.ExtendedStatsItem {
+	maxColNDV := func(tbl *statistics.Table) (maxNDV float64, product float64) {
+		product = 1
+		for _, colID := range item.ColIDs {
+			col, ok := tbl.Columns[colID]
+			if !ok {
+				return 0, 0
+			}
+			maxNDV = math.Max(maxNDV, float64(col.Histogram.NDV))
+			product *= float64(col.Histogram.NDV)
+		}
+		return maxNDV, product
+	}
+	var totalCount, scalarVals, degree01, degree10 float64
+	for _, tbl := range partitionStats {
+		if tbl.ExtendedStats == nil {
+			return nil
+		}
+		partItem, ok := tbl.ExtendedStats.Stats[name]
+		if !ok || partItem.Tp != item.Tp || len(partItem.ColIDs) != len(item.ColIDs) {
+			return nil
+		}
+		for i := range item.ColIDs {
+			if partItem.ColIDs[i] != item.ColIDs[i] {
+				return nil
+			}
+		}
+		weight := float64(tbl.Count)
+		switch item.Tp {
+		case ast.StatsTypeCardinality:
+			maxNDV, _ := maxColNDV(tbl)
+			if maxNDV <= 0 {
+				return nil
+			}
+			scalarVals += weight * math.Max(partItem.ScalarVals/maxNDV, 1)
+		case ast.StatsTypeCorrelation:
+			scalarVals += weight * partItem.ScalarVals
+		case ast.StatsTypeDependency:
+			d01, d10, err := partItem.DependencyDegrees()
+			if err != nil {
+				return nil
+			}
+			degree01 += weight * d01
+			degree10 += weight * d10
+		}
+		totalCount += weight
+	}
+	if totalCount == 0 {
+		return nil
+	}
+	switch item.Tp {
+	case ast.StatsTypeCardinality:
+		maxNDV, product := maxColNDV(globalStats)
+		if maxNDV <= 0 {
+			return nil
+		}
+		ndv := maxNDV * scalarVals / totalCount
+		ndv = math.Min(ndv, math.Min(product, float64(globalStats.Count)))
+		item.ScalarVals = math.Round(math.Max(ndv, maxNDV))
+	case ast.StatsTypeCorrelation:
+		item.ScalarVals = scalarVals / totalCount
+	case ast.StatsTypeDependency:
+		var err error
+		item.StringVals, err = statistics.EncodeDependencyDegrees(degree01/totalCount, degree10/totalCount)
+		if err != nil {
+			return nil
+		}
+	}
+	return item
+}
+
 func (h *Handle) fillExtendedStatsItemVals(item *statistics.ExtendedStatsItem, cols []*model.ColumnInfo, collectors []*statistics.SampleCollector) *statistics.ExtendedStatsItem {
 	switch item.Tp {
-	case ast.StatsTypeCardinality, ast.StatsTypeDependency:
-		return nil
+	case ast.StatsTypeCardinality:
+		return h.fillExtStatsCardinalityVals(item, cols, collectors)
+	case ast.StatsTypeDependency:
+		return h.fillExtStatsDependencyVals(item, cols, collectors)
 	case ast.StatsTypeCorrelation:
 		return h.fillExtStatsCorrVals(item, cols, collectors)
 	}
 	return nil
 }
 
+// extStatsSampleRows returns the encoded sampled values of the columns of the extended stats item row by row, and
+// the number of non-null rows of the column group. The rows containing NULL values are skipped, since they can never
+// satisfy the equal conditions which the cardinality and dependency stats are used for.
+func (h *Handle) extStatsSampleRows(item *statistics.ExtendedStatsItem, cols []*model.ColumnInfo, collectors []*statistics.SampleCollector) ([][]string, int64, error) {
+	colOffsets := make([]int, 0, len(item.ColIDs))
+	for _, id := range item.ColIDs {
+		for i, col := range cols {
+			if col.ID == id {
+				colOffsets = append(colOffsets, i)
+				break
+			}
+		}
+	}
+	if len(colOffsets) != len(item.ColIDs) {
+		return nil, 0, errors.Errorf("columns of extended stats are not analyzed")
+	}
+	h.mu.Lock()
+	sc := h.mu.ctx.GetSessionVars().StmtCtx
+	h.mu.Unlock()
+	// Samples of different columns are from the same rows, whose Ordinals are the positions of the rows.
+	rowCount := collectors[colOffsets[0]].Count
+	ordinal2Row := make(map[int][]string, len(collectors[colOffsets[0]].Samples))
+	for i, offset := range colOffsets {
+		rowCount = mathutil.MinInt64(rowCount, collectors[offset].Count)
+		for _, sample := range collectors[offset].Samples {
+			row, ok := ordinal2Row[sample.Ordinal]
+			if i == 0 {
+				row = make([]string, len(colOffsets))
+			} else if !ok || len(row[i-1]) == 0 {
+				continue
+			}
+			key, err := codec.EncodeKey(sc, nil, sample.Value)
+			if err != nil {
+				return nil, 0, err
+			}
+			row[i] = string(key)
+			ordinal2Row[sample.Ordinal] = row
+		}
+	}
+	rows := make([][]string, 0, len(ordinal2Row))
+	for _, row := range ordinal2Row {
+		if len(row[len(row)-1]) > 0 {
+			rows = append(rows, row)
+		}
+	}
+	return rows, rowCount, nil
+}
+
+// fillExtStatsCardinalityVals estimates the number of distinct values of the column group from the samples.
+func (h *Handle) fillExtStatsCardinalityVals(item *statistics.ExtendedStatsItem, cols []*model.ColumnInfo, collectors []*statistics.SampleCollector) *statistics.ExtendedStatsItem {
+	rows, rowCount, err := h.extStatsSampleRows(item, cols, collectors)
+	if err != nil {
+		return nil
+	}
+	groupCnt := make(map[string]int, len(rows))
+	for _, row := range rows {
+		groupCnt[strings.Join(row, "")]++
+	}
+	var onlyOnceItems uint64
+	for _, cnt := range groupCnt {
+		if cnt == 1 {
+			onlyOnceItems++
+		}
+	}
+	sampleSize := uint64(len(rows))
+	if uint64(rowCount) < sampleSize {
+		rowCount = int64(sampleSize)
+	}
+	item.ScalarVals = float64(statistics.EstimateNDVBySample(sampleSize, uint64(len(groupCnt)), onlyOnceItems, uint64(rowCount)))
+	return item
+}
+
+// fillExtStatsDependencyVals computes the functional dependency degrees between the two columns from the samples.
+// The degree of a -> b is the fraction of rows whose value of a determines the value of b, i.e, the rows with the same
+// value of a all have the same value of b.
+func (h *Handle) fillExtStatsDependencyVals(item *statistics.ExtendedStatsItem, cols []*model.ColumnInfo, collectors []*statistics.SampleCollector) *statistics.ExtendedStatsItem {
+	if len(item.ColIDs) != 2 {
+		return nil
+	}
+	rows, _, err := h.extStatsSampleRows(item, cols, collectors)
+	if err != nil {
+		return nil
+	}
+	dependencyDegree := func(from, to int) float64 {
+		if len(rows) == 0 {
+			return 0
+		}
+		type group struct {
+			val        string
+			cnt        int
+			determined bool
+		}
+		groups := make(map[string]*group, len(rows))
+		for _, row := range rows {
+			g, ok := groups[row[from]]
+			if !ok {
+				groups[row[from]] = &group{val: row[to], cnt: 1, determined: true}
+				continue
+			}
+			g.cnt++
+			g.determined = g.determined && g.val == row[to]
+		}
+		determinedCnt := 0
+		for _, g := range groups {
+			if g.determined {
+				determinedCnt += g.cnt
+			}
+		}
+		return float64(determinedCnt) / float64(len(rows))
+	}
+	item.StringVals, err = statistics.EncodeDependencyDegrees(dependencyDegree(0, 1), dependencyDegree(1, 0))
+	if err != nil {
+		return nil
+	}
+	return item
+}
+
 func (h *Handle) fillExtStatsCorrVals(item *statistics.ExtendedStatsItem, cols []*model.ColumnInfo, collectors []*statistics.SampleCollector) *statistics.ExtendedStatsItem {
 	colOffsets := make([]int, 0, 2)
 	for _, id := range item.ColIDs {
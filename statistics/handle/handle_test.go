@@ -2036,6 +2036,50 @@ func TestCorrelationStatsCompute(t *testing.T) {
 	))
 }
 
+func TestCardinalityAndDependencyStatsCompute(t *testing.T) {
+	store, dom, clean := testkit.CreateMockStoreAndDomain(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("set session tidb_enable_extended_stats = on")
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int, b int, c int)")
+	err := tk.ExecToErr("alter table t add stats_extended s1 cardinality(a)")
+	require.Equal(t, "Only support Cardinality statistics type on at least 2 columns", err.Error())
+	err = tk.ExecToErr("alter table t add stats_extended s1 dependency(a,b,c)")
+	require.Equal(t, "Only support Correlation and Dependency statistics types on 2 columns", err.Error())
+	tk.MustExec("alter table t add stats_extended s1 cardinality(a,b,c)")
+	tk.MustExec("alter table t add stats_extended s2 dependency(a,b)")
+	// Column a determines column b, while column c is independent of them.
+	for i := 0; i < 40; i++ {
+		tk.MustExec(fmt.Sprintf("insert into t values(%d,%d,%d),(%d,%d,%d)", i, i%4, i%2, i, i%4, (i+1)%2))
+	}
+	tk.MustExec("insert into t values(null,1,1)")
+	for _, ver := range []int{1, 2} {
+		tk.MustExec(fmt.Sprintf("set @@session.tidb_analyze_version=%d", ver))
+		tk.MustExec("analyze table t")
+		tk.MustQuery("select type, column_ids, stats, status from mysql.stats_extended").Sort().Check(testkit.Rows(
+			"0 [1,2,3] 80.000000 1",
+			"1 [1,2] [1,0] 1",
+		))
+	}
+
+	is := dom.InfoSchema()
+	tbl, err := is.TableByName(model.NewCIStr("test"), model.NewCIStr("t"))
+	require.NoError(t, err)
+	require.NoError(t, dom.StatsHandle().Update(is))
+	statsTbl := dom.StatsHandle().GetTableStats(tbl.Meta())
+	require.Len(t, statsTbl.ExtendedStats.Stats, 2)
+	require.Equal(t, float64(80), statsTbl.ExtendedStats.Stats["s1"].ScalarVals)
+	degree01, degree10, err := statsTbl.ExtendedStats.Stats["s2"].DependencyDegrees()
+	require.NoError(t, err)
+	require.Equal(t, float64(1), degree01)
+	require.Equal(t, float64(0), degree10)
+	tk.MustQuery("show stats_extended where table_name = 't'").Sort().CheckAt([]int{2, 3, 4, 5}, testkit.Rows(
+		"s1 [a,b,c] cardinality 80.000000",
+		"s2 [a,b] dependency [1,0]",
+	))
+}
+
 func TestSyncStatsExtendedRemoval(t *testing.T) {
 	store, dom, clean := testkit.CreateMockStoreAndDomain(t)
 	defer clean()
@@ -2393,10 +2437,34 @@ func TestExtendedStatsPartitionTable(t *testing.T) {
 	tk.MustExec("drop table if exists t1, t2")
 	tk.MustExec("create table t1(a int, b int, c int) partition by range(a) (partition p0 values less than (5), partition p1 values less than (10))")
 	tk.MustExec("create table t2(a int, b int, c int) partition by hash(a) partitions 4")
-	err := tk.ExecToErr("alter table t1 add stats_extended s1 correlation(b,c)")
-	require.Equal(t, "Extended statistics on partitioned tables are not supported now", err.Error())
-	err = tk.ExecToErr("alter table t2 add stats_extended s1 correlation(b,c)")
-	require.Equal(t, "Extended statistics on partitioned tables are not supported now", err.Error())
+	tk.MustExec("alter table t1 add stats_extended s1 cardinality(b,c)")
+	tk.MustExec("alter table t2 add stats_extended s1 dependency(b,c)")
+	tk.MustExec("insert into t1 values(1,1,1),(2,1,1),(3,2,2),(6,2,2),(7,3,3),(8,3,3),(9,4,4)")
+	tk.MustExec("insert into t2 values(1,1,1),(2,1,1),(3,2,2),(6,2,2),(7,3,3),(8,3,4),(9,4,4),(5,4,5)")
+	tk.MustExec("set @@session.tidb_partition_prune_mode = 'dynamic'")
+	tk.MustExec("analyze table t1, t2")
+	tk.MustQuery("select e.type, e.column_ids, e.stats, e.status from mysql.stats_extended e, information_schema.partitions p " +
+		"where e.table_id = p.tidb_partition_id and p.table_name = 't1' order by p.partition_name").Check(testkit.Rows(
+		"0 [2,3] 2.000000 1",
+		"0 [2,3] 3.000000 1",
+	))
+	tk.MustQuery("select e.type, e.column_ids, e.stats, e.status from mysql.stats_extended e, information_schema.partitions p " +
+		"where e.table_id = p.tidb_partition_id and p.table_name = 't2' order by p.partition_name").Check(testkit.Rows(
+		"1 [2,3] [1,1] 1",
+		"1 [2,3] [0.3333333333333333,1] 1",
+		"1 [2,3] [1,1] 1",
+		"1 [2,3] [1,1] 1",
+	))
+	// The global-level extended stats are merged from the partition-level ones.
+	tk.MustQuery("show stats_extended where table_name in ('t1', 't2')").Sort().CheckAt([]int{1, 2, 3, 4, 5}, testkit.Rows(
+		"t1 s1 [b,c] cardinality 4.000000",
+		"t2 s1 [b,c] dependency [0.75,1]",
+	))
+
+	tk.MustExec("alter table t1 drop stats_extended s1")
+	tk.MustQuery("select e.status from mysql.stats_extended e, information_schema.partitions p " +
+		"where e.table_id = p.tidb_partition_id and p.table_name = 't1'").Check(testkit.Rows("2", "2"))
+	tk.MustQuery("show stats_extended where table_name = 't1'").Check(testkit.Rows())
 }
 
 func TestHideIndexUsageSyncLease(t *testing.T) {
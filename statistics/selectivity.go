@@ -316,6 +316,9 @@ func (coll *HistColl) Selectivity(ctx sessionctx.Context, exprs []expression.Exp
 			CETraceExpr(ctx, tableID, "Table Stats-Expression-CNF", expr, ret*float64(coll.Count))
 		}
 	}
+	if ctx.GetSessionVars().EnableExtendedStats {
+		ret *= coll.adjustSelectivityByExtendedStats(ctx, usedSets)
+	}
 
 	// Try to cover Constants
 	if mask > 0 {
@@ -428,6 +431,83 @@ func (coll *HistColl) Selectivity(ctx sessionctx.Context, exprs []expression.Exp
 	return ret, nodes, nil
 }
 
+// adjustSelectivityByExtendedStats returns the factor to adjust the product of the column selectivities, which assumes
+// that the columns are independent, by the cardinality and dependency extended statistics. Only the columns whose
+// conditions are single point ranges are considered.
+func (coll *HistColl) adjustSelectivityByExtendedStats(ctx sessionctx.Context, usedSets []*StatsNode) float64 {
+	if coll.ExtendedStats == nil || len(coll.ExtendedStats.Stats) == 0 {
+		return 1
+	}
+	colID2Node := make(map[int64]*StatsNode)
+	for _, set := range usedSets {
+		if set.Tp != ColType || set.partCover || len(set.Ranges) != 1 || !set.Ranges[0].IsPointNonNullable(ctx) {
+			continue
+		}
+		if col := coll.Columns[set.ID]; col != nil && col.Info != nil {
+			colID2Node[col.Info.ID] = set
+		}
+	}
+	if len(colID2Node) < 2 {
+		return 1
+	}
+	names := make([]string, 0, len(coll.ExtendedStats.Stats))
+	for name := range coll.ExtendedStats.Stats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	adjustment := 1.0
+	// A column is adjusted by one item at most, and cardinality is preferred to dependency.
+	adjustedCols := make(map[int64]struct{})
+	for _, tp := range []uint8{ast.StatsTypeCardinality, ast.StatsTypeDependency} {
+		for _, name := range names {
+			item := coll.ExtendedStats.Stats[name]
+			if item.Tp != tp {
+				continue
+			}
+			sels := make([]float64, 0, len(item.ColIDs))
+			for _, colID := range item.ColIDs {
+				node, ok := colID2Node[colID]
+				if _, adjusted := adjustedCols[colID]; !ok || adjusted {
+					break
+				}
+				sels = append(sels, node.Selectivity)
+			}
+			if len(sels) != len(item.ColIDs) {
+				continue
+			}
+			independent, minSel := 1.0, 1.0
+			for _, sel := range sels {
+				independent *= sel
+				minSel = math.Min(minSel, sel)
+			}
+			if independent <= 0 {
+				continue
+			}
+			var combined float64
+			switch tp {
+			case ast.StatsTypeCardinality:
+				if item.ScalarVals < 1 {
+					continue
+				}
+				// Each distinct value of the column group is assumed to have the same number of rows.
+				combined = math.Min(math.Max(independent, 1/item.ScalarVals), minSel)
+			case ast.StatsTypeDependency:
+				degree01, degree10, err := item.DependencyDegrees()
+				if err != nil || len(sels) != 2 {
+					continue
+				}
+				// If a determines b with degree f, P(a, b) = P(a) * (f + (1 - f) * P(b)).
+				combined = math.Max(sels[0]*(degree01+(1-degree01)*sels[1]), sels[1]*(degree10+(1-degree10)*sels[0]))
+			}
+			adjustment *= combined / independent
+			for _, colID := range item.ColIDs {
+				adjustedCols[colID] = struct{}{}
+			}
+		}
+	}
+	return adjustment
+}
+
 func getMaskAndRanges(ctx sessionctx.Context, exprs []expression.Expression, rangeType ranger.RangeType, lengths []int, cachedPath *planutil.AccessPath, cols ...*expression.Column) (mask int64, ranges []*ranger.Range, partCover bool, err error) {
 	isDNF := false
 	var accessConds, remainedConds []expression.Expression
@@ -15,6 +15,7 @@
 package statistics
 
 import (
+	"encoding/json"
 	"fmt"
 	"math"
 	"sort"
@@ -64,9 +65,8 @@ const (
 // Table represents statistics for a table.
 type Table struct {
 	HistColl
-	Version       uint64
-	Name          string
-	ExtendedStats *ExtendedStatsColl
+	Version uint64
+	Name    string
 	// TblInfoUpdateTS is the UpdateTS of the TableInfo used when filling this struct.
 	// It is the schema version of the corresponding table. It is used to skip redundant
 	// loading of stats, i.e, if the cached stats is already update-to-date with mysql.stats_xxx tables,
@@ -83,6 +83,28 @@ type ExtendedStatsItem struct {
 	StringVals string
 }
 
+// EncodeDependencyDegrees encodes the functional dependency degrees of a Dependency type item into its StringVals.
+// degree01 is the degree of ColIDs[0] -> ColIDs[1], and degree10 is the degree of ColIDs[1] -> ColIDs[0].
+func EncodeDependencyDegrees(degree01, degree10 float64) (string, error) {
+	bytes, err := json.Marshal([]float64{degree01, degree10})
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return string(bytes), nil
+}
+
+// DependencyDegrees decodes the functional dependency degrees from the StringVals of a Dependency type item.
+func (item *ExtendedStatsItem) DependencyDegrees() (degree01, degree10 float64, err error) {
+	var degrees []float64
+	if err = json.Unmarshal([]byte(item.StringVals), &degrees); err != nil {
+		return 0, 0, errors.Trace(err)
+	}
+	if len(degrees) != 2 {
+		return 0, 0, errors.Errorf("invalid dependency degrees %s", item.StringVals)
+	}
+	return degrees[0], degrees[1], nil
+}
+
 // ExtendedStatsColl is a collection of cached items for mysql.stats_extended records.
 type ExtendedStatsColl struct {
 	Stats             map[string]*ExtendedStatsItem
@@ -110,6 +132,8 @@ type HistColl struct {
 	// The physical id is used when try to load column stats from storage.
 	HavePhysicalID bool
 	Pseudo         bool
+	// ExtendedStats holds the extended statistics of the table, the column IDs in them are the IDs of column infos.
+	ExtendedStats *ExtendedStatsColl
 }
 
 // MemoryUsage returns the total memory usage of this Table.
@@ -542,6 +566,7 @@ func (coll *HistColl) GenerateHistCollFromColumnInfo(infos []*model.ColumnInfo,
 		Indices:        newIdxHistMap,
 		ColID2IdxID:    colID2IdxID,
 		Idx2ColumnIDs:  idx2Columns,
+		ExtendedStats:  coll.ExtendedStats,
 	}
 	return newColl
 }
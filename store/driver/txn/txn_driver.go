@@ -42,6 +42,7 @@ type tikvTxn struct {
 	*tikv.KVTxn
 	idxNameCache        map[int64]*model.TableInfo
 	snapshotInterceptor kv.SnapshotInterceptor
+	xaPrepare           func(startTS uint64, primary []byte) error
 }
 
 // NewTiKVTxn returns a new Transaction.
@@ -52,7 +53,7 @@ func NewTiKVTxn(txn *tikv.KVTxn) kv.Transaction {
 	totalLimit := atomic.LoadUint64(&kv.TxnTotalSizeLimit)
 	txn.GetUnionStore().SetEntrySizeLimit(entryLimit, totalLimit)
 
-	return &tikvTxn{txn, make(map[int64]*model.TableInfo), nil}
+	return &tikvTxn{txn, make(map[int64]*model.TableInfo), nil, nil}
 }
 
 func (txn *tikvTxn) GetTableInfo(id int64) *model.TableInfo {
@@ -74,6 +75,9 @@ func (txn *tikvTxn) LockKeys(ctx context.Context, lockCtx *kv.LockCtx, keysInput
 }
 
 func (txn *tikvTxn) Commit(ctx context.Context) error {
+	if txn.xaPrepare != nil {
+		return txn.extractKeyErr(txn.prepareXA(ctx))
+	}
 	err := txn.KVTxn.Commit(ctx)
 	return txn.extractKeyErr(err)
 }
@@ -239,6 +243,8 @@ func (txn *tikvTxn) SetOption(opt int, val interface{}) {
 		txn.KVTxn.SetRPCInterceptor(val.(interceptor.RPCInterceptor))
 	case kv.AssertionLevel:
 		txn.KVTxn.SetAssertionLevel(val.(kvrpcpb.AssertionLevel))
+	case kv.XAPrepare:
+		txn.xaPrepare = val.(func(uint64, []byte) error)
 	}
 }
 
@@ -0,0 +1,182 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package txn
+
+import (
+	"context"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/kvproto/pkg/kvrpcpb"
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/util/logutil"
+	tikverr "github.com/tikv/client-go/v2/error"
+	"github.com/tikv/client-go/v2/oracle"
+	"github.com/tikv/client-go/v2/tikv"
+	"github.com/tikv/client-go/v2/tikvrpc"
+	"github.com/tikv/client-go/v2/txnkv/transaction"
+	tikvutil "github.com/tikv/client-go/v2/util"
+	"go.uber.org/zap"
+)
+
+// xaLockTTL is the TTL in milliseconds of the locks of a prepared XA transaction branch.
+// The locks must survive the client session, so they never expire and are only released
+// by committing or rolling back the primary key.
+const xaLockTTL = 1 << 50
+
+// xaMaxBackoff is the max sleep time in milliseconds to commit or roll back a prepared XA transaction branch.
+const xaMaxBackoff = transaction.CommitSecondaryMaxBackoff
+
+// ErrXARolledBack is returned when committing a prepared XA transaction branch which has been rolled back.
+var ErrXARolledBack = errors.New("the prepared transaction has been rolled back")
+
+// prepareXA prewrites all the mutations of the transaction with locks that never expire,
+// and leaves the transaction uncommitted. xaPrepare is called before the prewrite, so the
+// branch is always recorded before it has any lock in TiKV.
+func (txn *tikvTxn) prepareXA(ctx context.Context) (err error) {
+	if !txn.Valid() {
+		return tikverr.ErrInvalidTxn
+	}
+	sessionID, _ := ctx.Value(tikvutil.SessionID).(uint64)
+	probe := transaction.TxnProbe{KVTxn: txn.KVTxn}
+	// The committer of a pessimistic transaction has been initialized by locking keys,
+	// the primary key of the prewrite must be the same as the pessimistic locks.
+	committer := probe.GetCommitter()
+	if committer == (transaction.CommitterProbe{}) {
+		committer, err = probe.NewCommitter(sessionID)
+	} else {
+		err = committer.InitKeysAndMutations()
+	}
+	if err != nil {
+		txn.rollbackXA()
+		return err
+	}
+	var primary []byte
+	if committer.GetMutations().Len() > 0 {
+		primary = committer.GetPrimaryKey()
+	}
+	if err = txn.xaPrepare(txn.StartTS(), primary); err != nil || primary == nil {
+		txn.rollbackXA()
+		return err
+	}
+	committer.SetLockTTL(xaLockTTL)
+	if err = committer.PrewriteAllMutations(ctx); err != nil {
+		if cleanupErr := committer.CleanupMutations(ctx); cleanupErr != nil {
+			logutil.Logger(ctx).Warn("cleanup prepared XA transaction failed",
+				zap.Uint64("startTS", txn.StartTS()), zap.Error(cleanupErr))
+		}
+		txn.rollbackXA()
+		return err
+	}
+	committer.CloseTTLManager()
+	return nil
+}
+
+// CommitPreparedXA commits a prepared XA transaction branch by committing its primary key,
+// the secondary keys are resolved by the lock resolver when they are read.
+func CommitPreparedXA(ctx context.Context, store kv.Storage, primary []byte, startTS uint64) error {
+	s, ok := store.(tikv.Storage)
+	if !ok {
+		return errors.New("XA transactions are only supported on TiKV")
+	}
+	bo := tikv.NewBackofferWithVars(ctx, xaMaxBackoff, nil)
+	commitTS, err := s.CurrentTimestamp(oracle.GlobalTxnScope)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for {
+		req := tikvrpc.NewRequest(tikvrpc.CmdCommit, &kvrpcpb.CommitRequest{
+			StartVersion:  startTS,
+			Keys:          [][]byte{primary},
+			CommitVersion: commitTS,
+		})
+		resp, err := sendToKey(bo, s, primary, req)
+		if err != nil {
+			return err
+		}
+		keyErr := resp.Resp.(*kvrpcpb.CommitResponse).GetError()
+		if keyErr == nil {
+			return nil
+		}
+		if expired := keyErr.GetCommitTsExpired(); expired != nil {
+			// A reader has pushed the min commit ts of the lock, commit with a newer ts.
+			if commitTS, err = s.CurrentTimestamp(oracle.GlobalTxnScope); err != nil {
+				return errors.Trace(err)
+			}
+			continue
+		}
+		if keyErr.GetRetryable() != "" {
+			// The lock is not found and the transaction is not committed.
+			return ErrXARolledBack
+		}
+		return errors.Errorf("commit prepared XA transaction failed: %s", keyErr)
+	}
+}
+
+// RollbackPreparedXA rolls back a prepared XA transaction branch by rolling back its primary key,
+// the secondary keys are resolved by the lock resolver when they are read.
+func RollbackPreparedXA(ctx context.Context, store kv.Storage, primary []byte, startTS uint64) error {
+	s, ok := store.(tikv.Storage)
+	if !ok {
+		return errors.New("XA transactions are only supported on TiKV")
+	}
+	bo := tikv.NewBackofferWithVars(ctx, xaMaxBackoff, nil)
+	req := tikvrpc.NewRequest(tikvrpc.CmdBatchRollback, &kvrpcpb.BatchRollbackRequest{
+		StartVersion: startTS,
+		Keys:         [][]byte{primary},
+	})
+	resp, err := sendToKey(bo, s, primary, req)
+	if err != nil {
+		return err
+	}
+	if keyErr := resp.Resp.(*kvrpcpb.BatchRollbackResponse).GetError(); keyErr != nil {
+		return errors.Errorf("rollback prepared XA transaction failed: %s", keyErr)
+	}
+	return nil
+}
+
+// sendToKey sends the request to the region of the key and retries on region errors.
+func sendToKey(bo *tikv.Backoffer, s tikv.Storage, key []byte, req *tikvrpc.Request) (*tikvrpc.Response, error) {
+	for {
+		loc, err := s.GetRegionCache().LocateKey(bo, key)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		resp, err := s.SendReq(bo, req, loc.Region, tikv.ReadTimeoutShort)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		regionErr, err := resp.GetRegionError()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if regionErr != nil {
+			if err = bo.Backoff(tikv.BoRegionMiss(), errors.New(regionErr.String())); err != nil {
+				return nil, errors.Trace(err)
+			}
+			continue
+		}
+		if resp.Resp == nil {
+			return nil, errors.Trace(tikverr.ErrBodyMissing)
+		}
+		return resp, nil
+	}
+}
+
+// rollbackXA releases the pessimistic locks and closes the transaction when it's not prepared.
+func (txn *tikvTxn) rollbackXA() {
+	if err := txn.KVTxn.Rollback(); err != nil {
+		logutil.BgLogger().Warn("rollback XA transaction failed", zap.Uint64("startTS", txn.StartTS()), zap.Error(err))
+	}
+}
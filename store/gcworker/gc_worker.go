@@ -414,9 +414,40 @@ func (w *GCWorker) calcGlobalMinStartTS(ctx context.Context) (uint64, error) {
 			globalMinStartTS = minStartTS
 		}
 	}
+
+	// The prepared XA transactions have no running session, but their locks must not be
+	// resolved by GC before they are committed or rolled back.
+	xaMinStartTS, err := w.loadXAPreparedMinStartTS(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if xaMinStartTS < globalMinStartTS {
+		globalMinStartTS = xaMinStartTS
+	}
 	return globalMinStartTS, nil
 }
 
+func (w *GCWorker) loadXAPreparedMinStartTS(ctx context.Context) (uint64, error) {
+	se := createSession(w.store)
+	defer se.Close()
+	rs, err := se.ExecuteInternal(ctx, `SELECT HIGH_PRIORITY MIN(start_ts) FROM mysql.xa_prepared`)
+	if rs != nil {
+		defer terror.Call(rs.Close)
+	}
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	req := rs.NewChunk(nil)
+	err = rs.Next(ctx, req)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if req.NumRows() == 0 || req.GetRow(0).IsNull(0) {
+		return math.MaxUint64, nil
+	}
+	return req.GetRow(0).GetUint64(0), nil
+}
+
 // calcNewSafePoint uses the current global transaction min start timestamp to calculate the new safe point.
 func (w *GCWorker) calcSafePointByMinStartTS(ctx context.Context, safePoint uint64) uint64 {
 	globalMinStartTS, err := w.calcGlobalMinStartTS(ctx)
@@ -285,6 +285,15 @@ func TestMinStartTS(t *testing.T) {
 	require.NoError(t, err)
 	sp = s.gcWorker.calcSafePointByMinStartTS(ctx, now-oracle.ComposeTS(10000, 0))
 	require.Equal(t, now-oracle.ComposeTS(20000, 0)-1, sp)
+
+	// The prepared XA transactions block the safe point too.
+	se := createSession(s.gcWorker.store)
+	defer se.Close()
+	_, err = se.ExecuteInternal(ctx, "INSERT INTO mysql.xa_prepared (gtrid, bqual, format_id, start_ts, prepare_time) VALUES ('xa', '', 1, %?, NOW())",
+		now-oracle.ComposeTS(30000, 0))
+	require.NoError(t, err)
+	sp = s.gcWorker.calcSafePointByMinStartTS(ctx, now-oracle.ComposeTS(10000, 0))
+	require.Equal(t, now-oracle.ComposeTS(30000, 0)-1, sp)
 }
 
 func TestPrepareGC(t *testing.T) {
@@ -36,6 +36,7 @@ import (
 	"github.com/pingcap/tidb/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/types/json"
+	"github.com/pingcap/tidb/types/spatial"
 	"github.com/pingcap/tidb/util/hack"
 	"github.com/pingcap/tidb/util/logutil"
 	"github.com/pingcap/tidb/util/timeutil"
@@ -293,8 +294,18 @@ func handleZeroDatetime(ctx sessionctx.Context, col *model.ColumnInfo, casted ty
 // If the handle of err is changed latter, the behavior of forceIgnoreTruncate also need to change.
 // TODO: change the third arg to TypeField. Not pass ColumnInfo.
 func CastValue(ctx sessionctx.Context, val types.Datum, col *model.ColumnInfo, returnErr, forceIgnoreTruncate bool) (casted types.Datum, err error) {
+	if col.FieldType.Array {
+		// The values of the hidden column of multi-valued index are JSON arrays,
+		// whose elements have been converted by CAST(... AS ... ARRAY) already.
+		return val, nil
+	}
 	sc := ctx.GetSessionVars().StmtCtx
 	casted, err = val.ConvertTo(sc, &col.FieldType)
+	if err == nil && col.Srid != nil && !casted.IsNull() {
+		if srid := spatial.DecodeSRID(casted.GetBytes()); srid != *col.Srid {
+			return casted, ErrWrongSRIDForColumn.GenWithStackByArgs(col.Name.O, srid, *col.Srid)
+		}
+	}
 	// TODO: make sure all truncate errors are handled by ConvertTo.
 	if returnErr && err != nil {
 		return casted, err
@@ -0,0 +1,83 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package table
+
+import (
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/mock"
+)
+
+// Constraint provides meta data describing a check constraint.
+type Constraint struct {
+	*model.ConstraintInfo
+	// ConstraintExpr is the expression built from ExprString, it refers to the table columns by their offsets.
+	ConstraintExpr expression.Expression
+}
+
+// ToConstraint converts model.ConstraintInfo to Constraint.
+func ToConstraint(constraintInfo *model.ConstraintInfo, tblInfo *model.TableInfo) (*Constraint, error) {
+	ctx := mock.NewContext()
+	expr, err := expression.ParseSimpleExprWithTableInfo(ctx, constraintInfo.ExprString, tblInfo)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &Constraint{
+		ConstraintInfo: constraintInfo,
+		ConstraintExpr: expr,
+	}, nil
+}
+
+// IsWritable checks whether the constraint should be checked when writing rows.
+func (c *Constraint) IsWritable() bool {
+	if !c.Enforced {
+		return false
+	}
+	return c.State == model.StateWriteOnly || c.State == model.StateWriteReorganization || c.State == model.StatePublic
+}
+
+// CheckRowConstraint checks whether the row satisfies the writable check constraints.
+// The row is indexed by the column offsets. A constraint is only violated when it evaluates to FALSE,
+// UNKNOWN (NULL) is regarded as satisfied.
+func CheckRowConstraint(sctx sessionctx.Context, constraints []*Constraint, row []types.Datum) error {
+	if len(constraints) == 0 {
+		return nil
+	}
+	r := chunk.MutRowFromDatums(row).ToRow()
+	for _, constraint := range constraints {
+		if !constraint.IsWritable() {
+			continue
+		}
+		val, err := constraint.ConstraintExpr.Eval(r)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if val.IsNull() {
+			continue
+		}
+		ok, err := val.ToBool(sctx.GetSessionVars().StmtCtx)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if ok == 0 {
+			return ErrCheckConstraintViolated.FastGenByArgs(constraint.Name.O)
+		}
+	}
+	return nil
+}
@@ -104,6 +104,10 @@ var (
 	ErrTempTableFull = dbterror.ClassTable.NewStd(mysql.ErrRecordFileFull)
 	// ErrOptOnCacheTable returns when exec unsupported opt at cache mode
 	ErrOptOnCacheTable = dbterror.ClassDDL.NewStd(mysql.ErrOptOnCacheTable)
+	// ErrCheckConstraintViolated returns when a row violates an enforced check constraint.
+	ErrCheckConstraintViolated = dbterror.ClassTable.NewStd(mysql.ErrCheckConstraintViolated)
+	// ErrWrongSRIDForColumn returns when the SRID of a geometry doesn't match the SRID attribute of the column.
+	ErrWrongSRIDForColumn = dbterror.ClassTable.NewStd(mysql.ErrWrongSridForColumn)
 )
 
 // RecordIterFunc is used for low-level record iteration.
@@ -93,6 +93,39 @@ func (c *index) GenIndexKey(sc *stmtctx.StatementContext, indexedValues []types.
 // If the index is unique and there is an existing entry with the same key,
 // Create will return the existing entry's handle as the first return value, ErrKeyExists as the second return value.
 func (c *index) Create(sctx sessionctx.Context, txn kv.Transaction, indexedValues []types.Datum, h kv.Handle, handleRestoreData []types.Datum, opts ...table.CreateIdxOptFunc) (kv.Handle, error) {
+	if !c.idxInfo.MVIndex && !c.idxInfo.FullText && !c.idxInfo.Spatial {
+		return c.create(sctx, txn, indexedValues, h, handleRestoreData, opts...)
+	}
+	groups, err := c.splitIndexValues(sctx.GetSessionVars().StmtCtx, indexedValues)
+	if err != nil {
+		return nil, err
+	}
+	if c.idxInfo.FullText || c.idxInfo.Spatial {
+		// The FULLTEXT and SPATIAL indexes are never used as covering indexes, so the handle isn't restored from them.
+		handleRestoreData = nil
+	}
+	for _, vals := range groups {
+		if _, err := c.create(sctx, txn, vals, h, handleRestoreData, opts...); err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+// splitIndexValues splits the indexed values of a row into the values of its index entries. A multi-valued index
+// writes one entry for each element of the array, a FULLTEXT index writes one entry for each token, and a SPATIAL
+// index writes one entry for each cell covering the geometry.
+func (c *index) splitIndexValues(sc *stmtctx.StatementContext, indexedValues []types.Datum) ([][]types.Datum, error) {
+	if c.idxInfo.FullText {
+		return tablecodec.SplitIndexValuesForFullTextIndex(c.idxInfo, indexedValues)
+	}
+	if c.idxInfo.Spatial {
+		return tablecodec.SplitIndexValuesForSpatialIndex(indexedValues)
+	}
+	return tablecodec.SplitIndexValuesForMVIndex(sc, c.tblInfo, c.idxInfo, indexedValues)
+}
+
+func (c *index) create(sctx sessionctx.Context, txn kv.Transaction, indexedValues []types.Datum, h kv.Handle, handleRestoreData []types.Datum, opts ...table.CreateIdxOptFunc) (kv.Handle, error) {
 	if c.Meta().Unique {
 		txn.CacheTableInfo(c.phyTblID, c.tblInfo)
 	}
@@ -140,14 +173,18 @@ func (c *index) Create(sctx sessionctx.Context, txn kv.Transaction, indexedValue
 	// save the key buffer to reuse.
 	writeBufs.IndexKeyBuf = key
 	c.initNeedRestoreData.Do(func() {
-		c.needRestoredData = NeedRestoredData(c.idxInfo.Columns, c.tblInfo.Columns)
+		// The values of a FULLTEXT or SPATIAL index are the tokens or the cells rather than the column values,
+		// which can't be restored.
+		c.needRestoredData = !c.idxInfo.FullText && !c.idxInfo.Spatial && NeedRestoredData(c.idxInfo.Columns, c.tblInfo.Columns)
 	})
 	idxVal, err := tablecodec.GenIndexValuePortal(sctx.GetSessionVars().StmtCtx, c.tblInfo, c.idxInfo, c.needRestoredData, distinct, opt.Untouched, indexedValues, h, c.phyTblID, handleRestoreData)
 	if err != nil {
 		return nil, err
 	}
 
-	opt.IgnoreAssertion = opt.IgnoreAssertion || c.idxInfo.State != model.StatePublic
+	// The entries of a multi-valued index, a FULLTEXT index or a SPATIAL index may be deleted and re-created in the
+	// same statement, so the assertion is skipped for them.
+	opt.IgnoreAssertion = opt.IgnoreAssertion || c.idxInfo.State != model.StatePublic || c.idxInfo.MVIndex || c.idxInfo.FullText || c.idxInfo.Spatial
 
 	if !distinct || skipCheck || opt.Untouched {
 		err = txn.GetMemBuffer().Set(key, idxVal)
@@ -216,6 +253,22 @@ func (c *index) Create(sctx sessionctx.Context, txn kv.Transaction, indexedValue
 
 // Delete removes the entry for handle h and indexedValues from KV index.
 func (c *index) Delete(sc *stmtctx.StatementContext, txn kv.Transaction, indexedValues []types.Datum, h kv.Handle) error {
+	if !c.idxInfo.MVIndex && !c.idxInfo.FullText && !c.idxInfo.Spatial {
+		return c.delete(sc, txn, indexedValues, h)
+	}
+	groups, err := c.splitIndexValues(sc, indexedValues)
+	if err != nil {
+		return err
+	}
+	for _, vals := range groups {
+		if err := c.delete(sc, txn, vals, h); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *index) delete(sc *stmtctx.StatementContext, txn kv.Transaction, indexedValues []types.Datum, h kv.Handle) error {
 	key, distinct, err := c.GenIndexKey(sc, indexedValues, h, nil)
 	if err != nil {
 		return err
@@ -228,7 +281,7 @@ func (c *index) Delete(sc *stmtctx.StatementContext, txn kv.Transaction, indexed
 	if err != nil {
 		return err
 	}
-	if c.idxInfo.State == model.StatePublic {
+	if c.idxInfo.State == model.StatePublic && !c.idxInfo.MVIndex && !c.idxInfo.FullText && !c.idxInfo.Spatial {
 		// If the index is in public state, delete this index means it must exists.
 		err = txn.SetAssertion(key, kv.SetAssertExist)
 	}
@@ -18,8 +18,10 @@ import (
 	"context"
 	"testing"
 
+	"github.com/pingcap/tidb/config"
 	"github.com/pingcap/tidb/ddl"
 	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/infoschema"
 	"github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tidb/parser"
 	"github.com/pingcap/tidb/parser/ast"
@@ -203,3 +205,135 @@ func TestAssertionWithLazyCheck(t *testing.T) {
 	// `CheckNotExist`. Anyway there should never be assertion failure.
 	tk.MustGetErrCode("insert into t values (4, 3, 3)", errno.ErrDupEntry)
 }
+
+// countIndexKVs counts the KV pairs of the index on test.t, a row can have any number of entries in
+// the multi-valued, FULLTEXT or SPATIAL index.
+func countIndexKVs(t *testing.T, tk *testkit.TestKit, idxName string) int {
+	tbl, err := tk.Session().GetInfoSchema().(infoschema.InfoSchema).TableByName(model.NewCIStr("test"), model.NewCIStr("t"))
+	require.NoError(t, err)
+	idxInfo := tbl.Meta().FindIndexByName(idxName)
+	require.NotNil(t, idxInfo)
+	require.NoError(t, tk.Session().NewTxn(context.Background()))
+	txn, err := tk.Session().Txn(true)
+	require.NoError(t, err)
+	prefix := tablecodec.EncodeTableIndexPrefix(tbl.Meta().ID, idxInfo.ID)
+	it, err := txn.Iter(prefix, prefix.PrefixNext())
+	require.NoError(t, err)
+	defer it.Close()
+	cnt := 0
+	for it.Valid() && it.Key().HasPrefix(prefix) {
+		cnt++
+		require.NoError(t, it.Next())
+	}
+	return cnt
+}
+
+func TestMultiValuedIndex(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("set @@tidb_txn_assertion_level = 'STRICT'")
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t (id int primary key, j json, index idx((cast(j->'$.tags' as unsigned array))))")
+
+	tbl, err := tk.Session().GetInfoSchema().(infoschema.InfoSchema).TableByName(model.NewCIStr("test"), model.NewCIStr("t"))
+	require.NoError(t, err)
+	require.True(t, tbl.Meta().FindIndexByName("idx").MVIndex)
+
+	tk.MustExec(`insert into t values (1, '{"tags": [1, 2, 3]}'), (2, '{"tags": [3, 3, 4]}'), (3, '{"tags": []}'), (4, '{}'), (5, '{"tags": 5}')`)
+	// [1,2,3] + [3,4] + [] + NULL + [5]
+	require.Equal(t, 7, countIndexKVs(t, tk, "idx"))
+
+	tk.MustExec(`update t set j = '{"tags": [2, 6]}' where id = 1`)
+	require.Equal(t, 6, countIndexKVs(t, tk, "idx"))
+	tk.MustExec(`update t set j = '{"tags": [4, 3]}' where id = 2`)
+	require.Equal(t, 6, countIndexKVs(t, tk, "idx"))
+
+	tk.MustExec("delete from t where id in (1, 4)")
+	require.Equal(t, 3, countIndexKVs(t, tk, "idx"))
+
+	tk.MustExec("alter table t add index idx2((cast(j->'$.tags' as unsigned array)))")
+	tk.MustExec("delete from t")
+	require.Equal(t, 0, countIndexKVs(t, tk, "idx"))
+
+	tk.MustGetErrCode(`insert into t values (6, '{"tags": ["a"]}')`, errno.ErrTruncatedWrongValue)
+	tk.MustGetErrCode(`insert into t values (6, '{"tags": {"a": 1}}')`, errno.ErrNotSupportedYet)
+	tk.MustGetErrCode("create table t2 (j json, unique index((cast(j as unsigned array))))", errno.ErrNotSupportedYet)
+	tk.MustGetErrCode("create table t2 (j json, index((cast(j->'$.a' as unsigned array)), (cast(j->'$.b' as unsigned array))))", errno.ErrNotSupportedYet)
+	tk.MustGetErrCode("select cast(j as unsigned array) from t", errno.ErrNotSupportedYet)
+	// Only the JSON_EXTRACT in the multi-valued key part is allowed without allow-expression-index.
+	require.False(t, config.GetGlobalConfig().Experimental.AllowsExpressionIndex)
+	tk.MustGetErrCode("create table t2 (j json, index((json_extract(j, '$.a'))))", errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("create table t2 (j json, index((cast(json_unquote(j->'$.a') as char(10) array))))", errno.ErrUnsupportedDDLOperation)
+}
+
+func TestFullTextIndex(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("set @@tidb_txn_assertion_level = 'STRICT'")
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t (id int primary key, title varchar(100), body text, fulltext index idx(title, body))")
+
+	tk.MustExec("insert into t values (1, 'Apple pie', 'The apple pie recipe'), (2, 'Banana', NULL), (3, NULL, NULL), (4, 'to be or not', 'of the')")
+	// {apple, pie, recipe} + {banana} + {} + {not}
+	require.Equal(t, 5, countIndexKVs(t, tk, "idx"))
+
+	tk.MustExec("update t set body = 'banana split' where id = 2")
+	require.Equal(t, 6, countIndexKVs(t, tk, "idx"))
+	tk.MustExec("update t set title = 'Cherry pie' where id = 1")
+	require.Equal(t, 7, countIndexKVs(t, tk, "idx"))
+
+	tk.MustExec("delete from t where id in (1, 4)")
+	require.Equal(t, 2, countIndexKVs(t, tk, "idx"))
+
+	tk.MustExec("alter table t add fulltext index idx2(body) with parser ngram")
+	// {ba, an, na, sp, pl, li, it}
+	require.Equal(t, 7, countIndexKVs(t, tk, "idx2"))
+	tk.MustExec("admin check table t")
+	tk.MustExec("delete from t")
+	require.Equal(t, 0, countIndexKVs(t, tk, "idx"))
+	require.Equal(t, 0, countIndexKVs(t, tk, "idx2"))
+
+	tk.MustGetErrCode("create table t2 (a int, fulltext index (a))", errno.ErrBadFtColumn)
+	tk.MustGetErrCode("create table t2 (a varbinary(10), fulltext index (a))", errno.ErrBadFtColumn)
+	tk.MustGetErrCode("create table t2 (a varchar(10), fulltext index (a) with parser mecab)", errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("create table t2 (a varchar(10), fulltext index ((lower(a))))", errno.ErrFulltextFunctionalIndex)
+	tk.MustGetErrCode("alter table t add fulltext index ((lower(title)))", errno.ErrFulltextFunctionalIndex)
+	tk.MustGetErrCode("alter table t modify title int", errno.ErrBadFtColumn)
+}
+
+func TestSpatialIndex(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("set @@tidb_txn_assertion_level = 'STRICT'")
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t (id int primary key, g geometry not null srid 0, spatial index idx(g))")
+
+	tk.MustExec("insert into t values (1, point(1, 1)), (2, point(2, 2)), (3, geomcollection())")
+	// The points are covered by a single cell, and the empty geometry is covered by no cell.
+	require.Equal(t, 2, countIndexKVs(t, tk, "idx"))
+	// The polygon across the origin is covered by the 4 cells around the origin.
+	tk.MustExec("insert into t values (4, st_geomfromtext('POLYGON((-1 -1,1 -1,1 1,-1 1,-1 -1))'))")
+	require.Equal(t, 6, countIndexKVs(t, tk, "idx"))
+
+	tk.MustExec("update t set g = point(3, 3) where id = 4")
+	require.Equal(t, 3, countIndexKVs(t, tk, "idx"))
+	tk.MustExec("delete from t where id = 1")
+	require.Equal(t, 2, countIndexKVs(t, tk, "idx"))
+	tk.MustExec("admin check table t")
+
+	tk.MustExec("create table t2 (g geometry not null)")
+	tk.MustQuery("show warnings").Check(testkit.Rows())
+	tk.MustExec("alter table t2 add spatial index idx(g)")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Warning 3674 The spatial index on column 'g' will not be used by the query optimizer since the column does not have an SRID attribute. Consider adding an SRID attribute to the column."))
+	tk.MustGetErrCode("create table t3 (a int not null, spatial index (a))", errno.ErrSpatialMustHaveGeomCol)
+	tk.MustGetErrCode("create table t3 (g geometry, spatial index (g))", errno.ErrSpatialCantHaveNull)
+	tk.MustGetErrCode("create table t3 (g geometry not null, g2 geometry not null, spatial index (g, g2))", errno.ErrTooManyKeyParts)
+	tk.MustGetErrCode("create table t3 (g geometry not null srid 3857)", errno.ErrSrsNotFound)
+	tk.MustGetErrCode("alter table t modify g geometry", errno.ErrSpatialCantHaveNull)
+}
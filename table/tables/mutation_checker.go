@@ -185,6 +185,12 @@ func checkIndexKeys(
 			return errors.New("index not found")
 		}
 
+		// the entries of a multi-valued index store the array elements rather than the column value,
+		// the entries of a FULLTEXT index store the tokens, and the entries of a SPATIAL index store the cells
+		if indexInfo.MVIndex || indexInfo.FullText || indexInfo.Spatial {
+			continue
+		}
+
 		// when we cannot decode the key to get the original value
 		if len(m.value) == 0 && NeedRestoredData(indexInfo.Columns, t.Meta().Columns) {
 			continue
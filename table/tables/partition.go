@@ -15,7 +15,6 @@
 package tables
 
 import (
-	"bytes"
 	"context"
 	stderr "errors"
 	"fmt"
@@ -81,6 +80,10 @@ type partitionedTable struct {
 	partitions      map[int64]*partition
 	evalBufferTypes []*types.FieldType
 	evalBufferPool  sync.Pool
+	// reorgPartitionedTable is the other partition layout when some partitions are being reorganized,
+	// the rows written to the reorgPartitionIDs are also written to it.
+	reorgPartitionedTable *partitionedTable
+	reorgPartitionIDs     map[int64]struct{}
 }
 
 func newPartitionedTable(tbl *TableCommon, tblInfo *model.TableInfo) (table.Table, error) {
@@ -107,12 +110,103 @@ func newPartitionedTable(tbl *TableCommon, tblInfo *model.TableInfo) (table.Tabl
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
+		t.Constraints = tbl.Constraints
 		partitions[p.ID] = &t
 	}
 	ret.partitions = partitions
+	if err := initReorgPartitions(ret, tbl, tblInfo); err != nil {
+		return nil, errors.Trace(err)
+	}
 	return ret, nil
 }
 
+// initReorgPartitions initializes the partition layout which is double written during reorganizing partitions.
+// Before the partition definitions are swapped, the rows of the dropping partitions are also written to the
+// adding partitions; after that, the rows of the added partitions are also written to the dropped partitions,
+// so that the TiDB servers still using the previous schema version can read them.
+func initReorgPartitions(t *partitionedTable, tbl *TableCommon, tblInfo *model.TableInfo) error {
+	pi := tblInfo.GetPartitionInfo()
+	if pi.DDLState == model.StateNone || len(pi.AddingDefinitions) == 0 {
+		return nil
+	}
+	from := pi.DroppingDefinitions
+	if pi.DDLState == model.StateDeleteReorganization {
+		from = pi.AddingDefinitions
+	}
+	reorgTblInfo := GetReorgPartitionedTableInfo(tblInfo)
+	reorgTbl := *tbl
+	reorgTbl.meta = reorgTblInfo
+	ret, err := newPartitionedTable(&reorgTbl, reorgTblInfo)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	t.reorgPartitionedTable = ret.(*partitionedTable)
+	t.reorgPartitionIDs = make(map[int64]struct{}, len(from))
+	for _, def := range from {
+		t.reorgPartitionIDs[def.ID] = struct{}{}
+	}
+	return nil
+}
+
+// GetReorgPartitionedTableInfo returns the table info of the other partition layout when the partitions are being
+// reorganized, i.e. the layout after reorganizing before the definitions are swapped, and the previous one after that.
+func GetReorgPartitionedTableInfo(tblInfo *model.TableInfo) *model.TableInfo {
+	pi := tblInfo.Partition
+	from, to := pi.DroppingDefinitions, pi.AddingDefinitions
+	if pi.DDLState == model.StateDeleteReorganization {
+		from, to = to, from
+	}
+	reorgPI := *pi
+	reorgPI.Type, reorgPI.Expr, reorgPI.Columns = pi.DDLType, pi.DDLExpr, pi.DDLColumns
+	reorgPI.Definitions = ReplacePartitionDefinitions(pi.Definitions, from, to)
+	reorgPI.AddingDefinitions = nil
+	reorgPI.DroppingDefinitions = nil
+	reorgPI.DDLState = model.StateNone
+	reorgPI.DDLType, reorgPI.DDLExpr, reorgPI.DDLColumns = model.PartitionTypeNone, "", nil
+	reorgPI.DDLChangedIndex = nil
+	reorgTblInfo := tblInfo.Clone()
+	reorgTblInfo.Partition = &reorgPI
+	if len(pi.DDLChangedIndex) > 0 {
+		// Only keep the indexes of the other layout, they are always written.
+		indices := reorgTblInfo.Indices[:0]
+		for _, idxInfo := range reorgTblInfo.Indices {
+			if skipReorgIndex(tblInfo, idxInfo) {
+				idxInfo.State = model.StatePublic
+				indices = append(indices, idxInfo)
+			} else if _, ok := pi.DDLChangedIndex[idxInfo.ID]; !ok {
+				indices = append(indices, idxInfo)
+			}
+		}
+		reorgTblInfo.Indices = indices
+	}
+	return reorgTblInfo
+}
+
+// skipReorgIndex reports whether the index belongs to the other partition layout when the table is being partitioned
+// or the partitioning is being removed, see model.PartitionInfo.DDLChangedIndex.
+func skipReorgIndex(tblInfo *model.TableInfo, idxInfo *model.IndexInfo) bool {
+	pi := tblInfo.Partition
+	if pi == nil || len(pi.DDLChangedIndex) == 0 {
+		return false
+	}
+	isNewIndex, ok := pi.DDLChangedIndex[idxInfo.ID]
+	return ok && isNewIndex != (pi.DDLState == model.StateDeleteReorganization)
+}
+
+// ReplacePartitionDefinitions returns the definitions with the consecutive partitions `from` replaced by `to`.
+func ReplacePartitionDefinitions(defs, from, to []model.PartitionDefinition) []model.PartitionDefinition {
+	ret := make([]model.PartitionDefinition, 0, len(defs)-len(from)+len(to))
+	for i := 0; i < len(defs); i++ {
+		if len(from) > 0 && defs[i].ID == from[0].ID {
+			ret = append(ret, to...)
+			i += len(from) - 1
+			continue
+		}
+		ret = append(ret, defs[i])
+	}
+	return ret
+}
+
 func newPartitionExpr(tblInfo *model.TableInfo) (*PartitionExpr, error) {
 	ctx := mock.NewContext()
 	dbName := model.NewCIStr(ctx.GetSessionVars().CurrentDB)
@@ -121,13 +215,38 @@ func newPartitionExpr(tblInfo *model.TableInfo) (*PartitionExpr, error) {
 		return nil, err
 	}
 	pi := tblInfo.GetPartitionInfo()
+	if !pi.IsSubPartitioned() {
+		return generatePartitionExpr(ctx, tblInfo, pi, columns, names)
+	}
+	// The first level is located like a table partitioned by the ParentDefinitions, then the subpartition
+	// is located like a table partitioned by the subpartitions of the first level partition.
+	ret, err := generatePartitionExpr(ctx, tblInfo, pi.FirstLevel(), columns, names)
+	if err != nil {
+		return nil, err
+	}
+	ret.SubPartition, err = generatePartitionExpr(ctx, tblInfo, pi.SubLevel(), columns, names)
+	if err != nil {
+		return nil, err
+	}
+	// The rows can be located only when the columns of both levels are known.
+	ret.ColumnOffset = append(ret.ColumnOffset, ret.SubPartition.ColumnOffset...)
+	return ret, nil
+}
+
+func generatePartitionExpr(ctx sessionctx.Context, tblInfo *model.TableInfo, pi *model.PartitionInfo,
+	columns []*expression.Column, names types.NameSlice) (*PartitionExpr, error) {
 	switch pi.Type {
 	case model.PartitionTypeRange:
 		return generateRangePartitionExpr(ctx, pi, columns, names)
 	case model.PartitionTypeHash:
 		return generateHashPartitionExpr(ctx, pi, columns, names)
 	case model.PartitionTypeList:
-		return generateListPartitionExpr(ctx, tblInfo, columns, names)
+		return generateListPartitionExpr(ctx, tblInfo, pi, columns, names)
+	case model.PartitionTypeKey:
+		return generateKeyPartitionExpr(pi, columns, names)
+	case model.PartitionTypeNone:
+		// All the rows are in the only partition.
+		return &PartitionExpr{}, nil
 	}
 	panic("cannot reach here")
 }
@@ -149,6 +268,10 @@ type PartitionExpr struct {
 	// InValues: x in (1,2); x in (3,4); x in (5,6), used for list partition.
 	InValues []expression.Expression
 	*ForListPruning
+	// Used in the key partition locating and pruning process.
+	*ForKeyPruning
+	// SubPartition is the subpartition expressions of a subpartitioned table.
+	SubPartition *PartitionExpr
 }
 
 func initEvalBufferType(t *partitionedTable) {
@@ -175,23 +298,25 @@ func initEvalBuffer(t *partitionedTable) *chunk.MutRow {
 
 // ForRangeColumnsPruning is used for range partition pruning.
 type ForRangeColumnsPruning struct {
-	LessThan []expression.Expression
-	MaxValue bool
+	// LessThan contains the expressions of [partition][column], the expression is nil for MAXVALUE.
+	LessThan [][]expression.Expression
 }
 
 func dataForRangeColumnsPruning(ctx sessionctx.Context, pi *model.PartitionInfo, schema *expression.Schema, names []*types.FieldName, p *parser.Parser) (*ForRangeColumnsPruning, error) {
 	var res ForRangeColumnsPruning
-	res.LessThan = make([]expression.Expression, len(pi.Definitions))
+	res.LessThan = make([][]expression.Expression, len(pi.Definitions))
 	for i := 0; i < len(pi.Definitions); i++ {
-		if strings.EqualFold(pi.Definitions[i].LessThan[0], "MAXVALUE") {
-			// Use a bool flag instead of math.MaxInt64 to avoid the corner cases.
-			res.MaxValue = true
-		} else {
-			tmp, err := parseSimpleExprWithNames(p, ctx, pi.Definitions[i].LessThan[0], schema, names)
+		res.LessThan[i] = make([]expression.Expression, len(pi.Columns))
+		for j := range pi.Columns {
+			if strings.EqualFold(pi.Definitions[i].LessThan[j], "MAXVALUE") {
+				// Use a nil expression instead of math.MaxInt64 to avoid the corner cases.
+				continue
+			}
+			tmp, err := parseSimpleExprWithNames(p, ctx, pi.Definitions[i].LessThan[j], schema, names)
 			if err != nil {
 				return nil, err
 			}
-			res.LessThan[i] = tmp
+			res.LessThan[i][j] = tmp
 		}
 	}
 	return &res, nil
@@ -256,11 +381,11 @@ type ForListColumnPruning struct {
 
 	// To deal with the location partition failure caused by inconsistent NewCollationEnabled values(see issue #32416).
 	// The following fields are used to delay building valueMap.
-	ctx     sessionctx.Context
-	tblInfo *model.TableInfo
-	schema  *expression.Schema
-	names   types.NameSlice
-	colIdx  int
+	ctx    sessionctx.Context
+	pi     *model.PartitionInfo
+	schema *expression.Schema
+	names  types.NameSlice
+	colIdx int
 }
 
 // ListPartitionGroup indicate the group index of the column value in a partition.
@@ -463,61 +588,75 @@ func fixOldVersionPartitionInfo(sctx sessionctx.Context, str string) (int64, boo
 	return ret, true
 }
 
-// rangePartitionString returns the partition string for a range typed partition.
-func rangePartitionString(pi *model.PartitionInfo) string {
+// rangePartitionLessThanString returns the `partition key < values` string of a range typed partition.
+func rangePartitionLessThanString(pi *model.PartitionInfo, lessThan []string) string {
 	// partition by range expr
 	if len(pi.Columns) == 0 {
-		return pi.Expr
-	}
-
-	// partition by range columns (c1)
-	if len(pi.Columns) == 1 {
-		return "`" + pi.Columns[0].L + "`"
+		if strings.EqualFold(lessThan[0], "MAXVALUE") {
+			// Expr less than maxvalue is always true.
+			return "true"
+		}
+		return fmt.Sprintf("((%s) < (%s))", pi.Expr, lessThan[0])
 	}
 
 	// partition by range columns (c1, c2, ...)
-	panic("create table assert len(columns) = 1")
+	// The tuple (c1, c2) < (v1, MAXVALUE) is the same as (c1) <= (v1).
+	n, op := len(pi.Columns), "<"
+	for i, v := range lessThan {
+		if strings.EqualFold(v, "MAXVALUE") {
+			n, op = i, "<="
+			break
+		}
+	}
+	if n == 0 {
+		return "true"
+	}
+	cols := make([]string, 0, n)
+	for _, col := range pi.Columns[:n] {
+		cols = append(cols, "`"+col.L+"`")
+	}
+	return fmt.Sprintf("((%s) %s (%s))", strings.Join(cols, ","), op, strings.Join(lessThan[:n], ","))
 }
 
 func generateRangePartitionExpr(ctx sessionctx.Context, pi *model.PartitionInfo,
 	columns []*expression.Column, names types.NameSlice) (*PartitionExpr, error) {
 	// The caller should assure partition info is not nil.
 	locateExprs := make([]expression.Expression, 0, len(pi.Definitions))
-	var buf bytes.Buffer
 	p := parser.New()
 	schema := expression.NewSchema(columns...)
-	partStr := rangePartitionString(pi)
 	for i := 0; i < len(pi.Definitions); i++ {
-		if strings.EqualFold(pi.Definitions[i].LessThan[0], "MAXVALUE") {
-			// Expr less than maxvalue is always true.
-			fmt.Fprintf(&buf, "true")
-		} else {
-			fmt.Fprintf(&buf, "((%s) < (%s))", partStr, pi.Definitions[i].LessThan[0])
-		}
-
-		expr, err := parseSimpleExprWithNames(p, ctx, buf.String(), schema, names)
+		exprStr := rangePartitionLessThanString(pi, pi.Definitions[i].LessThan)
+		expr, err := parseSimpleExprWithNames(p, ctx, exprStr, schema, names)
 		if err != nil {
 			// If it got an error here, ddl may hang forever, so this error log is important.
-			logutil.BgLogger().Error("wrong table partition expression", zap.String("expression", buf.String()), zap.Error(err))
+			logutil.BgLogger().Error("wrong table partition expression", zap.String("expression", exprStr), zap.Error(err))
 			return nil, errors.Trace(err)
 		}
 		locateExprs = append(locateExprs, expr)
-		buf.Reset()
 	}
 	ret := &PartitionExpr{
 		UpperBounds: locateExprs,
 	}
 
 	// build column offset.
-	partExp := pi.Expr
-	if len(pi.Columns) == 1 {
-		partExp = "`" + pi.Columns[0].L + "`"
-	}
-	exprs, err := parseSimpleExprWithNames(p, ctx, partExp, schema, names)
-	if err != nil {
-		return nil, err
+	var exprs expression.Expression
+	var partitionCols []*expression.Column
+	if len(pi.Columns) == 0 {
+		var err error
+		exprs, err = parseSimpleExprWithNames(p, ctx, pi.Expr, schema, names)
+		if err != nil {
+			return nil, err
+		}
+		partitionCols = expression.ExtractColumns(exprs)
+	} else {
+		for _, col := range pi.Columns {
+			idx := expression.FindFieldNameIdxByColName(names, col.L)
+			if idx < 0 {
+				return nil, table.ErrUnknownColumn.GenWithStackByArgs(col.L)
+			}
+			partitionCols = append(partitionCols, columns[idx])
+		}
 	}
-	partitionCols := expression.ExtractColumns(exprs)
 	offset := make([]int, len(partitionCols))
 	for i, col := range columns {
 		for j, partitionCol := range partitionCols {
@@ -528,22 +667,19 @@ func generateRangePartitionExpr(ctx sessionctx.Context, pi *model.PartitionInfo,
 	}
 	ret.ColumnOffset = offset
 
-	switch len(pi.Columns) {
-	case 0:
+	if len(pi.Columns) == 0 {
 		tmp, err := dataForRangePruning(ctx, pi)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
 		ret.Expr = exprs
 		ret.ForRangePruning = tmp
-	case 1:
+	} else {
 		tmp, err := dataForRangeColumnsPruning(ctx, pi, schema, names, p)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
 		ret.ForRangeColumnsPruning = tmp
-	default:
-		panic("range column partition currently support only one column")
 	}
 	return ret, nil
 }
@@ -598,19 +734,17 @@ func extractListPartitionExprColumns(ctx sessionctx.Context, pi *model.Partition
 	return partExpr, deDupCols, offset, nil
 }
 
-func generateListPartitionExpr(ctx sessionctx.Context, tblInfo *model.TableInfo,
+func generateListPartitionExpr(ctx sessionctx.Context, tblInfo *model.TableInfo, pi *model.PartitionInfo,
 	columns []*expression.Column, names types.NameSlice) (*PartitionExpr, error) {
-	// The caller should assure partition info is not nil.
-	pi := tblInfo.GetPartitionInfo()
 	partExpr, exprCols, offset, err := extractListPartitionExprColumns(ctx, pi, columns, names)
 	if err != nil {
 		return nil, err
 	}
 	listPrune := &ForListPruning{}
 	if len(pi.Columns) == 0 {
-		err = listPrune.buildListPruner(ctx, tblInfo, exprCols, columns, names)
+		err = listPrune.buildListPruner(ctx, pi, exprCols, columns, names)
 	} else {
-		err = listPrune.buildListColumnsPruner(ctx, tblInfo, columns, names)
+		err = listPrune.buildListColumnsPruner(ctx, tblInfo, pi, columns, names)
 	}
 	if err != nil {
 		return nil, err
@@ -623,9 +757,8 @@ func generateListPartitionExpr(ctx sessionctx.Context, tblInfo *model.TableInfo,
 	return ret, nil
 }
 
-func (lp *ForListPruning) buildListPruner(ctx sessionctx.Context, tblInfo *model.TableInfo, exprCols []*expression.Column,
+func (lp *ForListPruning) buildListPruner(ctx sessionctx.Context, pi *model.PartitionInfo, exprCols []*expression.Column,
 	columns []*expression.Column, names types.NameSlice) error {
-	pi := tblInfo.GetPartitionInfo()
 	schema := expression.NewSchema(columns...)
 	p := parser.New()
 	expr, err := parseSimpleExprWithNames(p, ctx, pi.Expr, schema, names)
@@ -646,16 +779,15 @@ func (lp *ForListPruning) buildListPruner(ctx sessionctx.Context, tblInfo *model
 		}
 		c.Index = idx
 	}
-	err = lp.buildListPartitionValueMap(ctx, tblInfo, schema, names, p)
+	err = lp.buildListPartitionValueMap(ctx, pi, schema, names, p)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (lp *ForListPruning) buildListColumnsPruner(ctx sessionctx.Context, tblInfo *model.TableInfo,
+func (lp *ForListPruning) buildListColumnsPruner(ctx sessionctx.Context, tblInfo *model.TableInfo, pi *model.PartitionInfo,
 	columns []*expression.Column, names types.NameSlice) error {
-	pi := tblInfo.GetPartitionInfo()
 	schema := expression.NewSchema(columns...)
 	colPrunes := make([]*ForListColumnPruning, 0, len(pi.Columns))
 	for colIdx := range pi.Columns {
@@ -669,7 +801,7 @@ func (lp *ForListPruning) buildListColumnsPruner(ctx sessionctx.Context, tblInfo
 		}
 		colPrune := &ForListColumnPruning{
 			ctx:      ctx,
-			tblInfo:  tblInfo,
+			pi:       pi,
 			schema:   schema,
 			names:    names,
 			colIdx:   colIdx,
@@ -688,9 +820,8 @@ func (lp *ForListPruning) buildListColumnsPruner(ctx sessionctx.Context, tblInfo
 // buildListPartitionValueMap builds list partition value map.
 // The map is column value -> partition index.
 // colIdx is the column index in the list columns.
-func (lp *ForListPruning) buildListPartitionValueMap(ctx sessionctx.Context, tblInfo *model.TableInfo,
+func (lp *ForListPruning) buildListPartitionValueMap(ctx sessionctx.Context, pi *model.PartitionInfo,
 	schema *expression.Schema, names types.NameSlice, p *parser.Parser) error {
-	pi := tblInfo.GetPartitionInfo()
 	lp.valueMap = map[int64]int{}
 	lp.nullPartitionIdx = -1
 	for partitionIdx, def := range pi.Definitions {
@@ -764,7 +895,7 @@ func (lp *ForListPruning) locateListColumnsPartitionByRow(ctx sessionctx.Context
 // colIdx is the specified column index in the list columns.
 func (lp *ForListColumnPruning) buildPartitionValueMapAndSorted() error {
 	p := parser.New()
-	pi := lp.tblInfo.GetPartitionInfo()
+	pi := lp.pi
 	sc := lp.ctx.GetSessionVars().StmtCtx
 	for partitionIdx, def := range pi.Definitions {
 		for groupIdx, vs := range def.InValues {
@@ -933,13 +1064,22 @@ func (t *partitionedTable) PartitionExpr() (*PartitionExpr, error) {
 }
 
 func (t *partitionedTable) GetPartitionColumnNames() []model.CIStr {
-	// PARTITION BY {LIST|RANGE} COLUMNS uses columns directly without expressions
 	pi := t.Meta().Partition
-	if len(pi.Columns) > 0 {
-		return pi.Columns
+	colNames := t.getPartitionColumnNames(pi.Columns, t.partitionExpr)
+	if !pi.IsSubPartitioned() {
+		return colNames
 	}
+	subColNames := t.getPartitionColumnNames(pi.SubColumns, t.partitionExpr.SubPartition)
+	return append(append(make([]model.CIStr, 0, len(colNames)+len(subColNames)), colNames...), subColNames...)
+}
 
-	partitionCols := expression.ExtractColumns(t.partitionExpr.Expr)
+func (t *partitionedTable) getPartitionColumnNames(columns []model.CIStr, pe *PartitionExpr) []model.CIStr {
+	// PARTITION BY {LIST|RANGE} COLUMNS uses columns directly without expressions
+	if len(columns) > 0 {
+		return columns
+	}
+
+	partitionCols := expression.ExtractColumns(pe.Expr)
 	colIDs := make([]int64, 0, len(partitionCols))
 	for _, col := range partitionCols {
 		colIDs = append(colIDs, col.ID)
@@ -963,30 +1103,52 @@ func PartitionRecordKey(pid int64, handle int64) kv.Key {
 
 // locatePartition returns the partition ID of the input record.
 func (t *partitionedTable) locatePartition(ctx sessionctx.Context, pi *model.PartitionInfo, r []types.Datum) (int64, error) {
+	if !pi.IsSubPartitioned() {
+		idx, err := t.locatePartitionIdx(ctx, pi, t.partitionExpr, r)
+		if err != nil {
+			return 0, errors.Trace(err)
+		}
+		return pi.Definitions[idx].ID, nil
+	}
+	idx, err := t.locatePartitionIdx(ctx, pi.FirstLevel(), t.partitionExpr, r)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	subIdx, err := t.locatePartitionIdx(ctx, pi.SubLevel(), t.partitionExpr.SubPartition, r)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return pi.SubDefinitions(idx)[subIdx].ID, nil
+}
+
+// locatePartitionIdx returns the index of the partition in pi.Definitions which the input record belongs to,
+// pe is the partition expression of pi.
+func (t *partitionedTable) locatePartitionIdx(ctx sessionctx.Context, pi *model.PartitionInfo, pe *PartitionExpr, r []types.Datum) (int, error) {
 	var err error
 	var idx int
-	switch t.meta.Partition.Type {
+	switch pi.Type {
 	case model.PartitionTypeRange:
 		if len(pi.Columns) == 0 {
-			idx, err = t.locateRangePartition(ctx, pi, r)
+			idx, err = t.locateRangePartition(ctx, pi, pe, r)
 		} else {
-			idx, err = t.locateRangeColumnPartition(ctx, pi, r)
+			idx, err = t.locateRangeColumnPartition(ctx, pi, pe, r)
 		}
 	case model.PartitionTypeHash:
-		idx, err = t.locateHashPartition(ctx, pi, r)
+		idx, err = t.locateHashPartition(ctx, pi, pe, r)
 	case model.PartitionTypeList:
-		idx, err = t.locateListPartition(ctx, pi, r)
-	}
-	if err != nil {
-		return 0, errors.Trace(err)
+		idx, err = t.locateListPartition(ctx, pi, pe, r)
+	case model.PartitionTypeKey:
+		idx, err = t.locateKeyPartition(ctx, pi, pe, r)
+	case model.PartitionTypeNone:
+		idx = 0
 	}
-	return pi.Definitions[idx].ID, nil
+	return idx, err
 }
 
-func (t *partitionedTable) locateRangeColumnPartition(ctx sessionctx.Context, pi *model.PartitionInfo, r []types.Datum) (int, error) {
+func (t *partitionedTable) locateRangeColumnPartition(ctx sessionctx.Context, pi *model.PartitionInfo, pe *PartitionExpr, r []types.Datum) (int, error) {
 	var err error
 	var isNull bool
-	partitionExprs := t.partitionExpr.UpperBounds
+	partitionExprs := pe.UpperBounds
 	evalBuffer := t.evalBufferPool.Get().(*chunk.MutRow)
 	defer t.evalBufferPool.Put(evalBuffer)
 	idx := sort.Search(len(partitionExprs), func(i int) bool {
@@ -1028,22 +1190,22 @@ func (t *partitionedTable) locateRangeColumnPartition(ctx sessionctx.Context, pi
 	return idx, nil
 }
 
-func (t *partitionedTable) locateListPartition(ctx sessionctx.Context, pi *model.PartitionInfo, r []types.Datum) (int, error) {
-	lp := t.partitionExpr.ForListPruning
+func (t *partitionedTable) locateListPartition(ctx sessionctx.Context, pi *model.PartitionInfo, pe *PartitionExpr, r []types.Datum) (int, error) {
+	lp := pe.ForListPruning
 	if len(lp.ColPrunes) == 0 {
 		return lp.locateListPartitionByRow(ctx, r)
 	}
 	return lp.locateListColumnsPartitionByRow(ctx, r)
 }
 
-func (t *partitionedTable) locateRangePartition(ctx sessionctx.Context, pi *model.PartitionInfo, r []types.Datum) (int, error) {
+func (t *partitionedTable) locateRangePartition(ctx sessionctx.Context, pi *model.PartitionInfo, pe *PartitionExpr, r []types.Datum) (int, error) {
 	var (
 		ret    int64
 		val    int64
 		isNull bool
 		err    error
 	)
-	if col, ok := t.partitionExpr.Expr.(*expression.Column); ok {
+	if col, ok := pe.Expr.(*expression.Column); ok {
 		if r[col.Index].IsNull() {
 			isNull = true
 		}
@@ -1052,14 +1214,14 @@ func (t *partitionedTable) locateRangePartition(ctx sessionctx.Context, pi *mode
 		evalBuffer := t.evalBufferPool.Get().(*chunk.MutRow)
 		defer t.evalBufferPool.Put(evalBuffer)
 		evalBuffer.SetDatums(r...)
-		val, isNull, err = t.partitionExpr.Expr.EvalInt(ctx, evalBuffer.ToRow())
+		val, isNull, err = pe.Expr.EvalInt(ctx, evalBuffer.ToRow())
 		if err != nil {
 			return 0, err
 		}
 		ret = val
 	}
-	unsigned := mysql.HasUnsignedFlag(t.partitionExpr.Expr.GetType().Flag)
-	ranges := t.partitionExpr.ForRangePruning
+	unsigned := mysql.HasUnsignedFlag(pe.Expr.GetType().Flag)
+	ranges := pe.ForRangePruning
 	length := len(ranges.LessThan)
 	pos := sort.Search(length, func(i int) bool {
 		if isNull {
@@ -1091,8 +1253,8 @@ func (t *partitionedTable) locateRangePartition(ctx sessionctx.Context, pi *mode
 }
 
 // TODO: supports linear hashing
-func (t *partitionedTable) locateHashPartition(ctx sessionctx.Context, pi *model.PartitionInfo, r []types.Datum) (int, error) {
-	if col, ok := t.partitionExpr.Expr.(*expression.Column); ok {
+func (t *partitionedTable) locateHashPartition(ctx sessionctx.Context, pi *model.PartitionInfo, pe *PartitionExpr, r []types.Datum) (int, error) {
+	if col, ok := pe.Expr.(*expression.Column); ok {
 		var data types.Datum
 		switch r[col.Index].Kind() {
 		case types.KindInt64, types.KindUint64:
@@ -1105,7 +1267,7 @@ func (t *partitionedTable) locateHashPartition(ctx sessionctx.Context, pi *model
 			}
 		}
 		ret := data.GetInt64()
-		ret = ret % int64(t.meta.Partition.Num)
+		ret = ret % int64(pi.Num)
 		if ret < 0 {
 			ret = -ret
 		}
@@ -1114,14 +1276,14 @@ func (t *partitionedTable) locateHashPartition(ctx sessionctx.Context, pi *model
 	evalBuffer := t.evalBufferPool.Get().(*chunk.MutRow)
 	defer t.evalBufferPool.Put(evalBuffer)
 	evalBuffer.SetDatums(r...)
-	ret, isNull, err := t.partitionExpr.Expr.EvalInt(ctx, evalBuffer.ToRow())
+	ret, isNull, err := pe.Expr.EvalInt(ctx, evalBuffer.ToRow())
 	if err != nil {
 		return 0, err
 	}
 	if isNull {
 		return 0, nil
 	}
-	ret = ret % int64(t.meta.Partition.Num)
+	ret = ret % int64(pi.Num)
 	if ret < 0 {
 		ret = -ret
 	}
@@ -1178,7 +1340,64 @@ func partitionedTableAddRecord(ctx sessionctx.Context, t *partitionedTable, r []
 		}
 	}
 	tbl := t.GetPartition(pid)
-	return tbl.AddRecord(ctx, r, opts...)
+	recordID, err = tbl.AddRecord(ctx, r, opts...)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err = t.addReorgRecord(ctx, pid, recordID, r); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return recordID, nil
+}
+
+// addReorgRecord adds the record of the partition `pid` to the reorganized partition layout with the same handle,
+// it's a no-op if the partition isn't being reorganized or the reorganized partitions are not writable yet.
+func (t *partitionedTable) addReorgRecord(ctx sessionctx.Context, pid int64, h kv.Handle, r []types.Datum) error {
+	if _, ok := t.reorgPartitionIDs[pid]; !ok {
+		return nil
+	}
+	if state := t.meta.Partition.DDLState; state == model.StateDeleteOnly {
+		return nil
+	}
+	reorgTbl := t.reorgPartitionedTable
+	reorgPID, err := reorgTbl.locatePartition(ctx, reorgTbl.meta.Partition, r)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !t.meta.PKIsHandle && !t.meta.IsCommonHandle {
+		// Pass the handle as _tidb_rowid, or a new one would be allocated.
+		cols := t.Cols()
+		row := make([]types.Datum, 0, len(cols)+1)
+		row = append(row, r[:len(cols)]...)
+		r = append(row, types.NewIntDatum(h.IntValue()))
+	}
+	_, err = reorgTbl.GetPartition(reorgPID).AddRecord(ctx, r)
+	return errors.Trace(err)
+}
+
+// removeReorgRecord removes the record of the partition `pid` from the reorganized partition layout if it exists.
+func (t *partitionedTable) removeReorgRecord(ctx sessionctx.Context, pid int64, h kv.Handle, r []types.Datum) error {
+	if _, ok := t.reorgPartitionIDs[pid]; !ok {
+		return nil
+	}
+	reorgTbl := t.reorgPartitionedTable
+	reorgPID, err := reorgTbl.locatePartition(ctx, reorgTbl.meta.Partition, r)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	txn, err := ctx.Txn(true)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// The row may not be backfilled yet.
+	_, err = txn.Get(context.Background(), tablecodec.EncodeRecordKey(tablecodec.GenTableRecordPrefix(reorgPID), h))
+	if kv.IsErrNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(reorgTbl.GetPartition(reorgPID).RemoveRecord(ctx, h, r))
 }
 
 // partitionTableWithGivenSets is used for this kind of grammar: partition (p0,p1)
@@ -1222,7 +1441,10 @@ func (t *partitionedTable) RemoveRecord(ctx sessionctx.Context, h kv.Handle, r [
 	}
 
 	tbl := t.GetPartition(pid)
-	return tbl.RemoveRecord(ctx, h, r)
+	if err = tbl.RemoveRecord(ctx, h, r); err != nil {
+		return errors.Trace(err)
+	}
+	return t.removeReorgRecord(ctx, pid, h, r)
 }
 
 func (t *partitionedTable) GetAllPartitionIDs() []int64 {
@@ -1267,10 +1489,13 @@ func partitionedTableUpdateRecord(gctx context.Context, ctx sessionctx.Context,
 	// The old and new data locate in different partitions.
 	// Remove record from old partition and add record to new partition.
 	if from != to {
-		_, err = t.GetPartition(to).AddRecord(ctx, newData)
+		newHandle, err := t.GetPartition(to).AddRecord(ctx, newData)
 		if err != nil {
 			return errors.Trace(err)
 		}
+		if err = t.addReorgRecord(ctx, to, newHandle, newData); err != nil {
+			return errors.Trace(err)
+		}
 		// UpdateRecord should be side effect free, but there're two steps here.
 		// What would happen if step1 succeed but step2 meets error? It's hard
 		// to rollback.
@@ -1282,11 +1507,44 @@ func partitionedTableUpdateRecord(gctx context.Context, ctx sessionctx.Context,
 			logutil.BgLogger().Error("update partition record fails", zap.String("message", "new record inserted while old record is not removed"), zap.Error(err))
 			return errors.Trace(err)
 		}
-		return nil
+		return t.removeReorgRecord(ctx, from, h, currData)
 	}
 
 	tbl := t.GetPartition(to)
-	return tbl.UpdateRecord(gctx, ctx, h, currData, newData, touched)
+	if err = tbl.UpdateRecord(gctx, ctx, h, currData, newData, touched); err != nil {
+		return errors.Trace(err)
+	}
+	if _, ok := t.reorgPartitionIDs[to]; !ok {
+		return nil
+	}
+	// The row may move across the reorganized partitions.
+	if err = t.removeReorgRecord(ctx, to, h, currData); err != nil {
+		return errors.Trace(err)
+	}
+	return t.addReorgRecord(ctx, to, h, newData)
+}
+
+// FindPartitionIDsByName finds the physical partitions in table meta by name. The name of a first level partition of a
+// subpartitioned table refers to all of its subpartitions.
+func FindPartitionIDsByName(meta *model.TableInfo, parName string) ([]int64, error) {
+	pi := meta.Partition
+	if pi.IsSubPartitioned() {
+		for i, def := range pi.ParentDefinitions {
+			if def.Name.L == strings.ToLower(parName) {
+				subDefs := pi.SubDefinitions(i)
+				pids := make([]int64, 0, len(subDefs))
+				for _, subDef := range subDefs {
+					pids = append(pids, subDef.ID)
+				}
+				return pids, nil
+			}
+		}
+	}
+	pid, err := FindPartitionByName(meta, parName)
+	if err != nil {
+		return nil, err
+	}
+	return []int64{pid}, nil
 }
 
 // FindPartitionByName finds partition in table meta by name.
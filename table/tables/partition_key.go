@@ -0,0 +1,280 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tables
+
+import (
+	"encoding/binary"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/collate"
+)
+
+// ForKeyPruning is used for key partition locating and pruning.
+// The rows are located by the same hashing as MySQL, that is the hash_sort() of the collation over the binary
+// format of the field (Field::hash()), so the rows are distributed into the same partitions as MySQL.
+type ForKeyPruning struct {
+	// KeyPartCols is the columns of `PARTITION BY KEY`.
+	KeyPartCols []*expression.Column
+}
+
+func generateKeyPartitionExpr(pi *model.PartitionInfo, columns []*expression.Column, names types.NameSlice) (*PartitionExpr, error) {
+	keyPrune := &ForKeyPruning{KeyPartCols: make([]*expression.Column, 0, len(pi.Columns))}
+	offset := make([]int, 0, len(pi.Columns))
+	for _, col := range pi.Columns {
+		idx := expression.FindFieldNameIdxByColName(names, col.L)
+		if idx < 0 {
+			return nil, table.ErrUnknownColumn.GenWithStackByArgs(col.L)
+		}
+		keyPrune.KeyPartCols = append(keyPrune.KeyPartCols, columns[idx])
+		offset = append(offset, idx)
+	}
+	return &PartitionExpr{
+		ForKeyPruning: keyPrune,
+		ColumnOffset:  offset,
+	}, nil
+}
+
+func (t *partitionedTable) locateKeyPartition(ctx sessionctx.Context, pi *model.PartitionInfo, pe *PartitionExpr, r []types.Datum) (int, error) {
+	kp := pe.ForKeyPruning
+	vals := make([]types.Datum, 0, len(kp.KeyPartCols))
+	for _, col := range kp.KeyPartCols {
+		vals = append(vals, r[col.Index])
+	}
+	return kp.LocateKeyPartition(ctx.GetSessionVars().StmtCtx, len(pi.Definitions), vals)
+}
+
+// LocateKeyPartition locates the partition by the values of the key partition columns.
+func (kp *ForKeyPruning) LocateKeyPartition(sc *stmtctx.StatementContext, numParts int, vals []types.Datum) (int, error) {
+	nr1, nr2 := uint64(1), uint64(4)
+	for i, col := range kp.KeyPartCols {
+		if vals[i].IsNull() {
+			nr1 ^= (nr1 << 1) | 1
+			continue
+		}
+		b, err := keyPartitionFieldBytes(sc, col.RetType, vals[i])
+		if err != nil {
+			return 0, err
+		}
+		nr1, nr2 = keyPartitionHashSort(nr1, nr2, b)
+	}
+	return int(uint64(uint32(nr1)) % uint64(numParts)), nil
+}
+
+// keyPartitionHashSort is the MY_HASH_ADD of MySQL.
+func keyPartitionHashSort(nr1, nr2 uint64, b []byte) (uint64, uint64) {
+	for _, c := range b {
+		nr1 ^= ((nr1&63)+nr2)*uint64(c) + (nr1 << 8)
+		nr2 += 3
+	}
+	return nr1, nr2
+}
+
+// keyPartitionFieldBytes returns the bytes that MySQL hashes for the value of a key partition column.
+func keyPartitionFieldBytes(sc *stmtctx.StatementContext, ft *types.FieldType, d types.Datum) ([]byte, error) {
+	switch ft.Tp {
+	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong, mysql.TypeLonglong:
+		if d.Kind() != types.KindInt64 && d.Kind() != types.KindUint64 {
+			break
+		}
+		return littleEndianBytes(d.GetUint64(), intPackLength(ft.Tp)), nil
+	case mysql.TypeYear:
+		if d.Kind() != types.KindInt64 && d.Kind() != types.KindUint64 {
+			break
+		}
+		// The year is stored as the offset from 1900, and 0 is stored as 0.
+		y := d.GetInt64()
+		if y != 0 {
+			y -= 1900
+		}
+		return []byte{byte(y)}, nil
+	case mysql.TypeFloat:
+		if d.Kind() != types.KindFloat32 && d.Kind() != types.KindFloat64 {
+			break
+		}
+		return littleEndianBytes(uint64(math.Float32bits(float32(d.GetFloat64()))), 4), nil
+	case mysql.TypeDouble:
+		if d.Kind() != types.KindFloat32 && d.Kind() != types.KindFloat64 {
+			break
+		}
+		return littleEndianBytes(math.Float64bits(d.GetFloat64()), 8), nil
+	case mysql.TypeNewDecimal:
+		if d.Kind() != types.KindMysqlDecimal {
+			break
+		}
+		return d.GetMysqlDecimal().ToBin(ft.Flen, ft.Decimal)
+	case mysql.TypeDate:
+		if d.Kind() != types.KindMysqlTime {
+			break
+		}
+		t := d.GetMysqlTime()
+		return littleEndianBytes(uint64(t.Year()*16*32+t.Month()*32+t.Day()), 3), nil
+	case mysql.TypeDatetime:
+		if d.Kind() != types.KindMysqlTime {
+			break
+		}
+		t := d.GetMysqlTime()
+		ymd := uint64((t.Year()*13+t.Month())<<5 | t.Day())
+		hms := uint64(t.Hour()<<12 | t.Minute()<<6 | t.Second())
+		b := bigEndianBytes((ymd<<17|hms)+0x8000000000, 5)
+		return appendTimeFracBytes(b, int64(t.Microsecond()), ft.Decimal), nil
+	case mysql.TypeTimestamp:
+		if d.Kind() != types.KindMysqlTime {
+			break
+		}
+		t := d.GetMysqlTime()
+		var sec, usec int64
+		if !t.IsZero() && sc.TimeZone != nil {
+			// The timestamp is stored as the seconds since the epoch in UTC.
+			if err := t.ConvertTimeZone(sc.TimeZone, time.UTC); err != nil {
+				return nil, err
+			}
+			gt, err := t.GoTime(time.UTC)
+			if err != nil {
+				return nil, err
+			}
+			sec, usec = gt.Unix(), int64(t.Microsecond())
+		}
+		b := bigEndianBytes(uint64(sec), 4)
+		return appendTimeFracBytes(b, usec, ft.Decimal), nil
+	case mysql.TypeDuration:
+		if d.Kind() != types.KindMysqlDuration {
+			break
+		}
+		return timePackedBytes(d.GetMysqlDuration(), ft.Decimal), nil
+	case mysql.TypeBit:
+		var v uint64
+		switch d.Kind() {
+		case types.KindMysqlBit, types.KindBinaryLiteral:
+			var err error
+			if v, err = d.GetBinaryLiteral().ToInt(sc); err != nil {
+				return nil, err
+			}
+		case types.KindInt64, types.KindUint64:
+			v = d.GetUint64()
+		default:
+			return nil, errors.Errorf("unexpected value kind %d of key partition column type %d", d.Kind(), ft.Tp)
+		}
+		return bigEndianBytes(v, (ft.Flen+7)/8), nil
+	case mysql.TypeEnum:
+		packLen := 1
+		if len(ft.Elems) >= 256 {
+			packLen = 2
+		}
+		switch d.Kind() {
+		case types.KindMysqlEnum:
+			return littleEndianBytes(d.GetMysqlEnum().Value, packLen), nil
+		case types.KindInt64, types.KindUint64:
+			return littleEndianBytes(d.GetUint64(), packLen), nil
+		}
+	case mysql.TypeSet:
+		packLen := (len(ft.Elems) + 7) / 8
+		if packLen > 4 {
+			packLen = 8
+		}
+		switch d.Kind() {
+		case types.KindMysqlSet:
+			return littleEndianBytes(d.GetMysqlSet().Value, packLen), nil
+		case types.KindInt64, types.KindUint64:
+			return littleEndianBytes(d.GetUint64(), packLen), nil
+		}
+	case mysql.TypeVarchar, mysql.TypeVarString, mysql.TypeString:
+		if d.Kind() != types.KindString && d.Kind() != types.KindBytes {
+			break
+		}
+		// The hash_sort() of the collations hashes the sort keys, which trims the trailing spaces of PAD SPACE
+		// collations.
+		key := collate.GetCollator(ft.Collate).Key(d.GetString())
+		if collate.NewCollationEnabled() && strings.HasSuffix(ft.Collate, "_general_ci") {
+			// The weights of general_ci are hashed in the order of the low byte and the high byte.
+			for i := 0; i+1 < len(key); i += 2 {
+				key[i], key[i+1] = key[i+1], key[i]
+			}
+		}
+		return key, nil
+	}
+	return nil, errors.Errorf("unexpected value kind %d of key partition column type %d", d.Kind(), ft.Tp)
+}
+
+func intPackLength(tp byte) int {
+	switch tp {
+	case mysql.TypeTiny:
+		return 1
+	case mysql.TypeShort:
+		return 2
+	case mysql.TypeInt24:
+		return 3
+	case mysql.TypeLong:
+		return 4
+	}
+	return 8
+}
+
+// appendTimeFracBytes appends the fractional seconds of DATETIME and TIMESTAMP.
+func appendTimeFracBytes(b []byte, usec int64, fsp int) []byte {
+	switch fsp {
+	case 1, 2:
+		return append(b, byte(usec/10000))
+	case 3, 4:
+		return append(b, bigEndianBytes(uint64(usec/100), 2)...)
+	case 5, 6:
+		return append(b, bigEndianBytes(uint64(usec), 3)...)
+	}
+	return b
+}
+
+// timePackedBytes returns the binary format of TIME, it's the same as my_time_packed_to_binary() of MySQL.
+func timePackedBytes(dur types.Duration, fsp int) []byte {
+	neg := dur.Duration < 0
+	if neg {
+		dur = dur.Neg()
+	}
+	hms := int64(dur.Hour()<<12 | dur.Minute()<<6 | dur.Second())
+	packed := hms<<24 + int64(dur.MicroSecond())
+	if neg {
+		packed = -packed
+	}
+	intPart, fracPart := packed>>24, packed%(1<<24)
+	switch fsp {
+	case 1, 2:
+		return append(bigEndianBytes(uint64(0x800000+intPart), 3), byte(fracPart/10000))
+	case 3, 4:
+		return append(bigEndianBytes(uint64(0x800000+intPart), 3), bigEndianBytes(uint64(fracPart/100), 2)...)
+	case 5, 6:
+		return bigEndianBytes(uint64(packed+0x800000000000), 6)
+	}
+	return bigEndianBytes(uint64(0x800000+intPart), 3)
+}
+
+func littleEndianBytes(v uint64, n int) []byte {
+	b := make([]byte, 8)
+	binary.LittleEndian.PutUint64(b, v)
+	return b[:n]
+}
+
+func bigEndianBytes(v uint64, n int) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, v)
+	return b[8-n:]
+}
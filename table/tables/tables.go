@@ -66,6 +66,7 @@ type TableCommon struct {
 	WritableColumns                 []*table.Column
 	FullHiddenColsAndVisibleColumns []*table.Column
 	indices                         []table.Index
+	Constraints                     []*table.Constraint
 	meta                            *model.TableInfo
 	allocs                          autoid.Allocators
 	sequence                        *sequenceCommon
@@ -149,6 +150,9 @@ func TableFromMeta(allocs autoid.Allocators, tblInfo *model.TableInfo) (table.Ta
 
 	var t TableCommon
 	initTableCommon(&t, tblInfo, tblInfo.ID, columns, allocs)
+	if err := initTableConstraints(&t); err != nil {
+		return nil, err
+	}
 	if tblInfo.GetPartitionInfo() == nil {
 		if err := initTableIndices(&t); err != nil {
 			return nil, err
@@ -184,6 +188,13 @@ func initTableCommon(t *TableCommon, tblInfo *model.TableInfo, physicalTableID i
 func initTableIndices(t *TableCommon) error {
 	tblInfo := t.meta
 	for _, idxInfo := range tblInfo.Indices {
+		if skipReorgIndex(tblInfo, idxInfo) {
+			continue
+		}
+		// The hypothetical index only exists in the metadata seen by the optimizer, it's never written.
+		if idxInfo.Tp == model.IndexTypeHypo {
+			continue
+		}
 		if idxInfo.State == model.StateNone {
 			return table.ErrIndexStateCantNone.GenWithStackByArgs(idxInfo.Name)
 		}
@@ -195,6 +206,22 @@ func initTableIndices(t *TableCommon) error {
 	return nil
 }
 
+// initTableConstraints initializes the check constraints of the TableCommon.
+func initTableConstraints(t *TableCommon) error {
+	tblInfo := t.meta
+	for _, constraintInfo := range tblInfo.Constraints {
+		if constraintInfo.State == model.StateNone {
+			continue
+		}
+		constraint, err := table.ToConstraint(constraintInfo, tblInfo)
+		if err != nil {
+			return err
+		}
+		t.Constraints = append(t.Constraints, constraint)
+	}
+	return nil
+}
+
 func initTableCommonWithIndices(t *TableCommon, tblInfo *model.TableInfo, physicalTableID int64, cols []*table.Column, allocs autoid.Allocators) error {
 	initTableCommon(t, tblInfo, physicalTableID, cols, allocs)
 	return initTableIndices(t)
@@ -330,6 +357,10 @@ func (t *TableCommon) RecordKey(h kv.Handle) kv.Key {
 // `touched` means which columns are really modified, used for secondary indices.
 // Length of `oldData` and `newData` equals to length of `t.WritableCols()`.
 func (t *TableCommon) UpdateRecord(ctx context.Context, sctx sessionctx.Context, h kv.Handle, oldData, newData []types.Datum, touched []bool) error {
+	if err := table.CheckRowConstraint(sctx, t.Constraints, newData); err != nil {
+		return err
+	}
+
 	txn, err := sctx.Txn(true)
 	if err != nil {
 		return err
@@ -673,6 +704,10 @@ func (t *TableCommon) AddRecord(sctx sessionctx.Context, r []types.Datum, opts .
 		fn.ApplyOn(&opt)
 	}
 
+	if err = table.CheckRowConstraint(sctx, t.Constraints, r); err != nil {
+		return nil, err
+	}
+
 	if m := t.Meta(); m.TempTableType != model.TempTableNone {
 		if tmpTable := addTemporaryTable(sctx, m); tmpTable != nil {
 			if err := checkTempTableSize(sctx, tmpTable, m); err != nil {
@@ -32,9 +32,12 @@ import (
 	"github.com/pingcap/tidb/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/structure"
 	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/types/json"
+	"github.com/pingcap/tidb/types/spatial"
 	"github.com/pingcap/tidb/util/codec"
 	"github.com/pingcap/tidb/util/collate"
 	"github.com/pingcap/tidb/util/dbterror"
+	"github.com/pingcap/tidb/util/fulltext"
 	"github.com/pingcap/tidb/util/rowcodec"
 	"github.com/pingcap/tidb/util/stringutil"
 )
@@ -578,7 +581,7 @@ func Unflatten(datum types.Datum, ft *types.FieldType, loc *time.Location) (type
 		datum.SetFloat32(float32(datum.GetFloat64()))
 		return datum, nil
 	case mysql.TypeVarchar, mysql.TypeString, mysql.TypeVarString, mysql.TypeTinyBlob,
-		mysql.TypeMediumBlob, mysql.TypeBlob, mysql.TypeLongBlob:
+		mysql.TypeMediumBlob, mysql.TypeBlob, mysql.TypeLongBlob, mysql.TypeGeometry:
 		datum.SetString(datum.GetString(), ft.Collate)
 	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeYear, mysql.TypeInt24,
 		mysql.TypeLong, mysql.TypeLonglong, mysql.TypeDouble:
@@ -1079,6 +1082,110 @@ func GenIndexKey(sc *stmtctx.StatementContext, tblInfo *model.TableInfo, idxInfo
 	return
 }
 
+// SplitIndexValuesForMVIndex splits the indexed values of a multi-valued index into
+// one group per element of the JSON array column. A NULL array generates a single group
+// with NULL, and an empty array generates no group, so no index entry is written for it.
+func SplitIndexValuesForMVIndex(sc *stmtctx.StatementContext, tblInfo *model.TableInfo, idxInfo *model.IndexInfo, indexedValues []types.Datum) ([][]types.Datum, error) {
+	mvOffset := -1
+	for i, idxCol := range idxInfo.Columns {
+		if tblInfo.Columns[idxCol.Offset].FieldType.Array {
+			mvOffset = i
+			break
+		}
+	}
+	if mvOffset < 0 || indexedValues[mvOffset].IsNull() {
+		return [][]types.Datum{indexedValues}, nil
+	}
+	elemTp := tblInfo.Columns[idxInfo.Columns[mvOffset].Offset].FieldType.Clone()
+	elemTp.Array = false
+	collator := collate.GetCollator(elemTp.Collate)
+
+	arr := indexedValues[mvOffset].GetMysqlJSON()
+	if arr.TypeCode != json.TypeCodeArray {
+		return nil, errors.Errorf("unexpected non-array value %s for multi-valued index", arr.String())
+	}
+	elems := make([]types.Datum, 0, arr.GetElemCount())
+	for i := 0; i < arr.GetElemCount(); i++ {
+		d, err := types.ConvertJSONToArrayElement(sc, arr.ArrayGetElem(i), elemTp)
+		if err != nil {
+			return nil, err
+		}
+		duplicated := false
+		for k := range elems {
+			cmp, err := d.Compare(sc, &elems[k], collator)
+			if err != nil {
+				return nil, err
+			}
+			if cmp == 0 {
+				duplicated = true
+				break
+			}
+		}
+		if !duplicated {
+			elems = append(elems, d)
+		}
+	}
+
+	groups := make([][]types.Datum, 0, len(elems))
+	for _, d := range elems {
+		vals := make([]types.Datum, len(indexedValues))
+		copy(vals, indexedValues)
+		vals[mvOffset] = d
+		groups = append(groups, vals)
+	}
+	return groups, nil
+}
+
+// SplitIndexValuesForFullTextIndex splits the indexed values of a FULLTEXT index into one group per distinct
+// token of the indexed columns. The FULLTEXT index is an inverted index, the posting list of a token is made of
+// the entries keyed by the token followed by the handles of the rows containing it, so a group has the token as
+// the value of the first column and NULL for the others. A row without any token has no entry in the index.
+func SplitIndexValuesForFullTextIndex(idxInfo *model.IndexInfo, indexedValues []types.Datum) ([][]types.Datum, error) {
+	tk, err := fulltext.NewTokenizer(idxInfo.FullTextParser)
+	if err != nil {
+		return nil, err
+	}
+	texts := make([]string, 0, len(indexedValues))
+	for _, d := range indexedValues {
+		if !d.IsNull() {
+			texts = append(texts, d.GetString())
+		}
+	}
+	tokens := fulltext.UniqueTokens(tk, texts...)
+	groups := make([][]types.Datum, 0, len(tokens))
+	for _, token := range tokens {
+		vals := make([]types.Datum, len(indexedValues))
+		vals[0] = FullTextTokenDatum(token)
+		groups = append(groups, vals)
+	}
+	return groups, nil
+}
+
+// SplitIndexValuesForSpatialIndex splits the indexed value of a SPATIAL index into one group per cell covering
+// the geometry, a group has the ID of the cell as the value of the column. The entries of a cell are scanned by
+// the range of the cell IDs when the geometries intersecting a region are searched.
+func SplitIndexValuesForSpatialIndex(indexedValues []types.Datum) ([][]types.Datum, error) {
+	if indexedValues[0].IsNull() {
+		return nil, nil
+	}
+	g, err := spatial.Unmarshal(indexedValues[0].GetBytes())
+	if err != nil {
+		return nil, err
+	}
+	cells := spatial.IndexCells(g)
+	groups := make([][]types.Datum, 0, len(cells))
+	for _, id := range cells {
+		groups = append(groups, []types.Datum{types.NewUintDatum(id)})
+	}
+	return groups, nil
+}
+
+// FullTextTokenDatum returns the datum of the token stored in a FULLTEXT index. The tokens are compared as the
+// binary strings regardless of the collations of the indexed columns, so a prefix of a token is a prefix of its key.
+func FullTextTokenDatum(token string) types.Datum {
+	return types.NewCollationStringDatum(token, charset.CollationBin)
+}
+
 // GenIndexValuePortal is the portal for generating index value.
 // Value layout:
 //		+-- IndexValueVersion0  (with restore data, or common handle, or index is global)
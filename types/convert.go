@@ -671,6 +671,28 @@ func ConvertJSONToDecimal(sc *stmtctx.StatementContext, j json.BinaryJSON) (*MyD
 	return res, errors.Trace(err)
 }
 
+// ConvertJSONToArrayElement converts an element of the JSON array into the element type of CAST(... AS ... ARRAY),
+// which is used by multi-valued index. Only numbers can be converted to the numeric types and only strings can
+// be converted to the string types.
+func ConvertJSONToArrayElement(sc *stmtctx.StatementContext, elem json.BinaryJSON, elemTp *FieldType) (Datum, error) {
+	var d Datum
+	switch elem.TypeCode {
+	case json.TypeCodeInt64, json.TypeCodeUint64, json.TypeCodeFloat64:
+		if elemTp.EvalType() != ETInt && elemTp.EvalType() != ETReal {
+			return d, ErrTruncatedWrongVal.GenWithStackByArgs(TypeStr(elemTp.Tp), elem.String())
+		}
+		d.SetMysqlJSON(elem)
+	case json.TypeCodeString:
+		if elemTp.EvalType() != ETString {
+			return d, ErrTruncatedWrongVal.GenWithStackByArgs(TypeStr(elemTp.Tp), elem.String())
+		}
+		d.SetString(string(elem.GetString()), elemTp.Collate)
+	default:
+		return d, ErrTruncatedWrongVal.GenWithStackByArgs(TypeStr(elemTp.Tp), elem.String())
+	}
+	return d.ConvertTo(sc, elemTp)
+}
+
 // getValidFloatPrefix gets prefix of string which can be successfully parsed as float.
 func getValidFloatPrefix(sc *stmtctx.StatementContext, s string, isFuncCast bool) (valid string, err error) {
 	if isFuncCast && s == "" {
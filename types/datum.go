@@ -31,6 +31,7 @@ import (
 	"github.com/pingcap/tidb/parser/types"
 	"github.com/pingcap/tidb/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/types/json"
+	"github.com/pingcap/tidb/types/spatial"
 	"github.com/pingcap/tidb/util/collate"
 	"github.com/pingcap/tidb/util/hack"
 	"github.com/pingcap/tidb/util/logutil"
@@ -908,6 +909,8 @@ func (d *Datum) ConvertTo(sc *stmtctx.StatementContext, target *FieldType) (Datu
 		return d.convertToMysqlSet(sc, target)
 	case mysql.TypeJSON:
 		return d.convertToMysqlJSON(sc, target)
+	case mysql.TypeGeometry:
+		return d.convertToGeometry(target)
 	case mysql.TypeNull:
 		return Datum{}, nil
 	default:
@@ -1651,6 +1654,26 @@ func (d *Datum) convertToMysqlJSON(sc *stmtctx.StatementContext, target *FieldTy
 	return ret, errors.Trace(err)
 }
 
+// convertToGeometry checks whether the datum is a geometry in the storage format of the target type.
+func (d *Datum) convertToGeometry(target *FieldType) (ret Datum, err error) {
+	var data []byte
+	switch d.k {
+	case KindString, KindBytes, KindBinaryLiteral, KindMysqlBit:
+		data = d.GetBytes()
+	default:
+		return ret, spatial.ErrCantCreateGeometryObject.GenWithStackByArgs()
+	}
+	g, err := spatial.Unmarshal(data)
+	if err != nil {
+		return ret, spatial.ErrCantCreateGeometryObject.GenWithStackByArgs()
+	}
+	if !g.IsInstanceOf(target.GeomType) {
+		return ret, spatial.ErrCantCreateGeometryObject.GenWithStackByArgs()
+	}
+	ret.SetBytes(data)
+	return ret, nil
+}
+
 // ToBool converts to a bool.
 // We will use 1 for true, and 0 for false.
 func (d *Datum) ToBool(sc *stmtctx.StatementContext) (int64, error) {
@@ -228,6 +228,12 @@ func (bj BinaryJSON) Extract(pathExprList []PathExpression) (ret BinaryJSON, fou
 	return
 }
 
+// ExtractAll returns all the values in bj matched by pathExpr in document order.
+// Unlike Extract, the matched values are never autowrapped as an array.
+func (bj BinaryJSON) ExtractAll(pathExpr PathExpression) []BinaryJSON {
+	return bj.extractTo(nil, pathExpr)
+}
+
 func (bj BinaryJSON) extractTo(buf []BinaryJSON, pathExpr PathExpression) []BinaryJSON {
 	if len(pathExpr.legs) == 0 {
 		return append(buf, bj)
@@ -1021,6 +1027,51 @@ func ContainsBinary(obj, target BinaryJSON) bool {
 	}
 }
 
+// OverlapsBinary is the implementation of JSON_OVERLAPS. It returns true if the two documents share any
+// key-value pair or array element. A non-array value is compared as an array with only one element.
+func OverlapsBinary(obj, target BinaryJSON) bool {
+	if obj.TypeCode == TypeCodeObject && target.TypeCode == TypeCodeObject {
+		elemCount := target.GetElemCount()
+		for i := 0; i < elemCount; i++ {
+			if exp, exists := obj.objectSearchKey(target.objectGetKey(i)); exists && CompareBinary(exp, target.objectGetVal(i)) == 0 {
+				return true
+			}
+		}
+		return false
+	}
+	if obj.TypeCode != TypeCodeArray {
+		obj, target = target, obj
+	}
+	if obj.TypeCode != TypeCodeArray {
+		return CompareBinary(obj, target) == 0
+	}
+	if target.TypeCode != TypeCodeArray {
+		return MemberOfBinary(target, obj)
+	}
+	elemCount := target.GetElemCount()
+	for i := 0; i < elemCount; i++ {
+		if MemberOfBinary(target.arrayGetElem(i), obj) {
+			return true
+		}
+	}
+	return false
+}
+
+// MemberOfBinary is the implementation of MEMBER OF. It returns true if the value is an element of the array,
+// a non-array value is compared as an array with only one element.
+func MemberOfBinary(value, array BinaryJSON) bool {
+	if array.TypeCode != TypeCodeArray {
+		return CompareBinary(value, array) == 0
+	}
+	elemCount := array.GetElemCount()
+	for i := 0; i < elemCount; i++ {
+		if CompareBinary(value, array.arrayGetElem(i)) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
 // GetElemDepth for JSON_DEPTH
 // Returns the maximum depth of a JSON document
 // rules referenced by MySQL JSON_DEPTH function
@@ -92,6 +92,30 @@ func TestBinaryJSONExtract(t *testing.T) {
 	}
 }
 
+func TestBinaryJSONExtractAll(t *testing.T) {
+	bj := mustParseBinaryFromString(t, `{"a": [1, "2", {"aa": "bb"}], "b": [{"aa": "cc"}]}`)
+	var tests = []struct {
+		pathExpr string
+		expected []string
+	}{
+		{"$", []string{`{"a": [1, "2", {"aa": "bb"}], "b": [{"aa": "cc"}]}`}},
+		{"$.a", []string{`[1, "2", {"aa": "bb"}]`}},
+		{"$.a[*]", []string{`1`, `"2"`, `{"aa": "bb"}`}},
+		{"$.b[*]", []string{`{"aa": "cc"}`}},
+		{"$**.aa", []string{`"bb"`, `"cc"`}},
+		{"$.c", nil},
+	}
+	for _, test := range tests {
+		pe, err := ParseJSONPathExpr(test.pathExpr)
+		require.NoError(t, err)
+		var result []string
+		for _, elem := range bj.ExtractAll(pe) {
+			result = append(result, elem.String())
+		}
+		require.Equal(t, test.expected, result, test.pathExpr)
+	}
+}
+
 func TestBinaryJSONType(t *testing.T) {
 	var tests = []struct {
 		in  string
@@ -391,6 +415,59 @@ func TestBinaryJSONContains(t *testing.T) {
 	}
 }
 
+func TestBinaryJSONOverlaps(t *testing.T) {
+	var tests = []struct {
+		input    string
+		target   string
+		expected bool
+	}{
+		{`{}`, `{}`, false},
+		{`{"a":1}`, `{"a":1,"b":2}`, true},
+		{`{"a":1}`, `{"a":2}`, false},
+		{`{"a":1}`, `[{"a":1}]`, true},
+		{`{"a":1}`, `1`, false},
+		{`1`, `1`, true},
+		{`1`, `"1"`, false},
+		{`[1,2]`, `2`, true},
+		{`2`, `[1,2]`, true},
+		{`[1,2]`, `[3,2]`, true},
+		{`[1,2]`, `[3,4]`, false},
+		{`[1,[2]]`, `[2]`, false},
+		{`[1,[2]]`, `[[2]]`, true},
+		{`[]`, `[]`, false},
+	}
+
+	for _, test := range tests {
+		obj := mustParseBinaryFromString(t, test.input)
+		target := mustParseBinaryFromString(t, test.target)
+		require.Equal(t, test.expected, OverlapsBinary(obj, target), "%s, %s", test.input, test.target)
+		require.Equal(t, test.expected, OverlapsBinary(target, obj), "%s, %s", test.target, test.input)
+	}
+}
+
+func TestBinaryJSONMemberOf(t *testing.T) {
+	var tests = []struct {
+		value    string
+		array    string
+		expected bool
+	}{
+		{`1`, `[1,2]`, true},
+		{`3`, `[1,2]`, false},
+		{`"a"`, `["a","b"]`, true},
+		{`[1]`, `[[1],2]`, true},
+		{`[1]`, `[1,2]`, false},
+		{`1`, `1`, true},
+		{`{"a":1}`, `{"a":1}`, true},
+		{`1`, `[]`, false},
+	}
+
+	for _, test := range tests {
+		value := mustParseBinaryFromString(t, test.value)
+		array := mustParseBinaryFromString(t, test.array)
+		require.Equal(t, test.expected, MemberOfBinary(value, array), "%s, %s", test.value, test.array)
+	}
+}
+
 func TestBinaryJSONCopy(t *testing.T) {
 	expectedList := []string{
 		`{"a": [1, "2", {"aa": "bb"}, 4, null], "b": true, "c": null}`,
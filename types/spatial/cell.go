@@ -0,0 +1,178 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spatial
+
+import (
+	"math"
+	"sort"
+)
+
+// The spatial index divides the space into a quadtree of cells. A cell of level L is divided into 4 cells
+// of level L+1, and the cell of level 0 is the whole space. The cells are identified like the S2 cell IDs:
+// the ID of a cell of level L is the Morton code of its position, which has 2*L bits, followed by a 1 bit
+// and 2*(cellMaxLevel-L) 0 bits. So the IDs of the descendants of a cell are in a continuous range around
+// the ID of the cell, and a range scan on the index finds all the geometries inside a cell.
+//
+// A geometry is indexed by the cells of the finest level that cover its MBR with at most 4 cells. Two
+// geometries can intersect only if some cells of them intersect, which means one of the cells is the
+// ancestor of the other.
+const (
+	cellMaxLevel = 20
+	// maxCoverCells is the max number of cells covering a geometry.
+	maxCoverCells = 4
+	// cartesianBound is the bound of the space of the Cartesian SRID, which is the bound of the Web Mercator
+	// projection. The geometries outside the bound are indexed by the cells on the edge.
+	cartesianBound = 20037508.3427892
+)
+
+// CellRange is a range of cell IDs, both ends are inclusive.
+type CellRange struct {
+	Low  uint64
+	High uint64
+}
+
+type cell struct {
+	id    uint64
+	level int
+}
+
+func cellLsb(level int) uint64 {
+	return 1 << (2 * (cellMaxLevel - level))
+}
+
+func spaceOf(srid uint32) Rect {
+	if IsGeographic(srid) {
+		return Rect{MinX: -180, MinY: -90, MaxX: 180, MaxY: 90}
+	}
+	return Rect{MinX: -cartesianBound, MinY: -cartesianBound, MaxX: cartesianBound, MaxY: cartesianBound}
+}
+
+// cellPos returns the position of the cell containing the coordinate in the dimension, the positions out of
+// the space are clamped.
+func cellPos(v, min, max float64, level int) uint32 {
+	n := float64(uint32(1) << level)
+	pos := math.Floor((v - min) / (max - min) * n)
+	return uint32(math.Max(0, math.Min(n-1, pos)))
+}
+
+// spreadBits moves the lower 20 bits of v to the even bits.
+func spreadBits(v uint32) uint64 {
+	x := uint64(v)
+	x = (x | x<<16) & 0x0000FFFF0000FFFF
+	x = (x | x<<8) & 0x00FF00FF00FF00FF
+	x = (x | x<<4) & 0x0F0F0F0F0F0F0F0F
+	x = (x | x<<2) & 0x3333333333333333
+	x = (x | x<<1) & 0x5555555555555555
+	return x
+}
+
+func makeCellID(i, j uint32, level int) uint64 {
+	morton := spreadBits(i)<<1 | spreadBits(j)
+	return (morton<<1 | 1) * cellLsb(level)
+}
+
+// coverRect returns the cells of the finest level covering the rectangle with at most maxCoverCells cells.
+func coverRect(r Rect, srid uint32) []cell {
+	if r.Empty {
+		return nil
+	}
+	space := spaceOf(srid)
+	for level := cellMaxLevel; level >= 0; level-- {
+		i0, i1 := cellPos(r.MinX, space.MinX, space.MaxX, level), cellPos(r.MaxX, space.MinX, space.MaxX, level)
+		j0, j1 := cellPos(r.MinY, space.MinY, space.MaxY, level), cellPos(r.MaxY, space.MinY, space.MaxY, level)
+		if int(i1-i0+1)*int(j1-j0+1) > maxCoverCells {
+			continue
+		}
+		cells := make([]cell, 0, maxCoverCells)
+		for i := i0; i <= i1; i++ {
+			for j := j0; j <= j1; j++ {
+				cells = append(cells, cell{id: makeCellID(i, j, level), level: level})
+			}
+		}
+		return cells
+	}
+	return nil
+}
+
+// IndexCells returns the IDs of the cells by which the geometry is indexed, an empty geometry has no cell.
+func IndexCells(g *Geometry) []uint64 {
+	cells := coverRect(g.MBR(), g.SRID)
+	ids := make([]uint64, 0, len(cells))
+	for _, c := range cells {
+		ids = append(ids, c.id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// QueryRanges returns the ranges of the cell IDs of the geometries whose MBR may intersect the rectangle.
+// The ranges are sorted and disjoint.
+func QueryRanges(r Rect, srid uint32) []CellRange {
+	var ranges []CellRange
+	for _, c := range coverRect(r, srid) {
+		lsb := cellLsb(c.level)
+		ranges = append(ranges, CellRange{Low: c.id - lsb + 1, High: c.id + lsb - 1})
+		for level := c.level - 1; level >= 0; level-- {
+			lsb = cellLsb(level)
+			parent := c.id&^(lsb-1) | lsb
+			ranges = append(ranges, CellRange{Low: parent, High: parent})
+		}
+	}
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i].Low < ranges[j].Low })
+	merged := ranges[:0]
+	for _, rg := range ranges {
+		if n := len(merged); n > 0 && rg.Low <= merged[n-1].High+1 {
+			if rg.High > merged[n-1].High {
+				merged[n-1].High = rg.High
+			}
+			continue
+		}
+		merged = append(merged, rg)
+	}
+	return merged
+}
+
+// Expand returns the rectangle expanded by the distance in all directions.
+func (r Rect) Expand(d float64) Rect {
+	if r.Empty {
+		return r
+	}
+	return Rect{MinX: r.MinX - d, MinY: r.MinY - d, MaxX: r.MaxX + d, MaxY: r.MaxY + d}
+}
+
+// ExpandOnSphere returns the rectangle of longitude and latitude in degrees expanded by the great-circle
+// distance on a sphere of the radius. The result covers all the points within the distance.
+func (r Rect) ExpandOnSphere(d, radius float64) Rect {
+	if r.Empty {
+		return r
+	}
+	angle := d / radius
+	dLat := angle * 180 / math.Pi
+	res := Rect{MinX: -180, MinY: math.Max(-90, r.MinY-dLat), MaxX: 180, MaxY: math.Min(90, r.MaxY+dLat)}
+	if angle >= math.Pi/2 || res.MinY <= -90 || res.MaxY >= 90 {
+		return res
+	}
+	maxLat := math.Max(math.Abs(res.MinY), math.Abs(res.MaxY)) * math.Pi / 180
+	sin := math.Sin(angle) / math.Cos(maxLat)
+	if sin >= 1 {
+		return res
+	}
+	dLon := math.Asin(sin) * 180 / math.Pi
+	// The rectangles crossing the antimeridian are not split, the whole range of longitude is used instead.
+	if r.MinX-dLon > -180 && r.MaxX+dLon <= 180 {
+		res.MinX, res.MaxX = r.MinX-dLon, r.MaxX+dLon
+	}
+	return res
+}
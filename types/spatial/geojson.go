@@ -0,0 +1,214 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spatial
+
+import (
+	"encoding/json"
+	"math"
+)
+
+var geoJSONTypeNames = map[byte]string{
+	TypePoint:              "Point",
+	TypeLineString:         "LineString",
+	TypePolygon:            "Polygon",
+	TypeMultiPoint:         "MultiPoint",
+	TypeMultiLineString:    "MultiLineString",
+	TypeMultiPolygon:       "MultiPolygon",
+	TypeGeometryCollection: "GeometryCollection",
+}
+
+// GeoJSON returns the GeoJSON object of the geometry, which consists of map[string]interface{},
+// []interface{}, string and float64. The coordinates are rounded to maxDecimals decimal digits if
+// maxDecimals is not negative.
+func (g *Geometry) GeoJSON(maxDecimals int) map[string]interface{} {
+	obj := map[string]interface{}{"type": geoJSONTypeNames[g.Type]}
+	point := func(p Point) interface{} {
+		return []interface{}{roundCoord(p.X, maxDecimals), roundCoord(p.Y, maxDecimals)}
+	}
+	points := func(ps []Point) interface{} {
+		arr := make([]interface{}, 0, len(ps))
+		for _, p := range ps {
+			arr = append(arr, point(p))
+		}
+		return arr
+	}
+	rings := func(rings [][]Point) interface{} {
+		arr := make([]interface{}, 0, len(rings))
+		for _, ring := range rings {
+			arr = append(arr, points(ring))
+		}
+		return arr
+	}
+	var coords []interface{}
+	switch g.Type {
+	case TypePoint:
+		obj["coordinates"] = point(g.Coords[0][0])
+		return obj
+	case TypeLineString:
+		obj["coordinates"] = points(g.Coords[0])
+		return obj
+	case TypePolygon:
+		obj["coordinates"] = rings(g.Coords)
+		return obj
+	case TypeGeometryCollection:
+		geoms := make([]interface{}, 0, len(g.Geoms))
+		for _, e := range g.Geoms {
+			geoms = append(geoms, e.GeoJSON(maxDecimals))
+		}
+		obj["geometries"] = geoms
+		return obj
+	}
+	for _, e := range g.Geoms {
+		switch e.Type {
+		case TypePoint:
+			coords = append(coords, point(e.Coords[0][0]))
+		case TypeLineString:
+			coords = append(coords, points(e.Coords[0]))
+		default:
+			coords = append(coords, rings(e.Coords))
+		}
+	}
+	obj["coordinates"] = coords
+	return obj
+}
+
+func roundCoord(f float64, decimals int) float64 {
+	if decimals < 0 {
+		return f
+	}
+	pow := math.Pow10(decimals)
+	if r := math.Round(f*pow) / pow; !math.IsInf(r, 0) && !math.IsNaN(r) {
+		return r
+	}
+	return f
+}
+
+type geoJSONObject struct {
+	Type        string            `json:"type"`
+	Coordinates json.RawMessage   `json:"coordinates"`
+	Geometries  []json.RawMessage `json:"geometries"`
+}
+
+// ParseGeoJSON parses the GeoJSON geometry object. The coordinates are treated as longitude and latitude
+// in WGS 84 if the SRID is geographic.
+func ParseGeoJSON(data []byte, srid uint32) (*Geometry, error) {
+	g, err := parseGeoJSON(data, 0)
+	if err != nil {
+		return nil, ErrInvalidGISData.GenWithStackByArgs("st_geomfromgeojson")
+	}
+	g.SRID = srid
+	if err = g.Validate("st_geomfromgeojson"); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+func parseGeoJSON(data []byte, depth int) (*Geometry, error) {
+	if depth > maxNestingDepth {
+		return nil, errInvalidWKB
+	}
+	var obj geoJSONObject
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, err
+	}
+	var tp byte
+	for t, name := range geoJSONTypeNames {
+		if name == obj.Type {
+			tp = t
+		}
+	}
+	g := &Geometry{Type: tp}
+	var err error
+	switch tp {
+	case TypePoint:
+		var p []float64
+		if err = unmarshalCoords(obj.Coordinates, &p); err == nil {
+			g.Coords = [][]Point{toPoints([][]float64{p})}
+		}
+	case TypeLineString:
+		var ps [][]float64
+		err = unmarshalCoords(obj.Coordinates, &ps)
+		g.Coords = [][]Point{toPoints(ps)}
+	case TypePolygon:
+		var rings [][][]float64
+		err = unmarshalCoords(obj.Coordinates, &rings)
+		g.Coords = toRings(rings)
+	case TypeMultiPoint:
+		var ps [][]float64
+		err = unmarshalCoords(obj.Coordinates, &ps)
+		for _, p := range toPoints(ps) {
+			g.Geoms = append(g.Geoms, &Geometry{Type: TypePoint, Coords: [][]Point{{p}}})
+		}
+	case TypeMultiLineString:
+		var lines [][][]float64
+		err = unmarshalCoords(obj.Coordinates, &lines)
+		for _, ps := range lines {
+			g.Geoms = append(g.Geoms, &Geometry{Type: TypeLineString, Coords: [][]Point{toPoints(ps)}})
+		}
+	case TypeMultiPolygon:
+		var polys [][][][]float64
+		err = unmarshalCoords(obj.Coordinates, &polys)
+		for _, rings := range polys {
+			g.Geoms = append(g.Geoms, &Geometry{Type: TypePolygon, Coords: toRings(rings)})
+		}
+	case TypeGeometryCollection:
+		if obj.Geometries == nil {
+			return nil, errInvalidWKB
+		}
+		for _, raw := range obj.Geometries {
+			e, err := parseGeoJSON(raw, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			g.Geoms = append(g.Geoms, e)
+		}
+	default:
+		return nil, errInvalidWKB
+	}
+	if err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+func unmarshalCoords(data json.RawMessage, v interface{}) error {
+	if data == nil {
+		return errInvalidWKB
+	}
+	return json.Unmarshal(data, v)
+}
+
+// toPoints converts the positions to points. A position has at least 2 numbers, the numbers following
+// the longitude and latitude are ignored. A position with less than 2 numbers is converted to NaN,
+// which is rejected by the validation later.
+func toPoints(ps [][]float64) []Point {
+	points := make([]Point, 0, len(ps))
+	for _, p := range ps {
+		if len(p) < 2 {
+			points = append(points, Point{X: math.NaN(), Y: math.NaN()})
+			continue
+		}
+		points = append(points, Point{X: p[0], Y: p[1]})
+	}
+	return points
+}
+
+func toRings(rings [][][]float64) [][]Point {
+	res := make([][]Point, 0, len(rings))
+	for _, ring := range rings {
+		res = append(res, toPoints(ring))
+	}
+	return res
+}
@@ -0,0 +1,285 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spatial
+
+import (
+	"encoding/binary"
+	"math"
+
+	mysql "github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/parser/types"
+	"github.com/pingcap/tidb/util/dbterror"
+)
+
+// The types of the geometries, the values are the same as the geometry type codes of WKB.
+const (
+	TypeGeometry           = types.GeomTypeGeometry
+	TypePoint              = types.GeomTypePoint
+	TypeLineString         = types.GeomTypeLineString
+	TypePolygon            = types.GeomTypePolygon
+	TypeMultiPoint         = types.GeomTypeMultiPoint
+	TypeMultiLineString    = types.GeomTypeMultiLineString
+	TypeMultiPolygon       = types.GeomTypeMultiPolygon
+	TypeGeometryCollection = types.GeomTypeGeometryCollection
+)
+
+// The spatial reference systems supported by TiDB.
+const (
+	// SRIDCartesian is the SRID of the Cartesian plane without units.
+	SRIDCartesian uint32 = 0
+	// SRIDWGS84 is the SRID of the WGS 84 geographic system, whose coordinates are longitude and latitude
+	// in degrees. The X coordinate is the longitude and the Y coordinate is the latitude.
+	SRIDWGS84 uint32 = 4326
+)
+
+// EarthRadius is the default radius of the sphere used by ST_Distance_Sphere, in meters.
+const EarthRadius = 6370986.0
+
+var (
+	// ErrCantCreateGeometryObject means the value isn't a valid geometry.
+	ErrCantCreateGeometryObject = dbterror.ClassTypes.NewStd(mysql.ErrCantCreateGeometryObject)
+	// ErrInvalidGISData means the argument of a spatial function isn't a valid geometry.
+	ErrInvalidGISData = dbterror.ClassTypes.NewStd(mysql.ErrGISInvalidData)
+	// ErrDifferentSRIDs means the geometries of a binary spatial function have different SRIDs.
+	ErrDifferentSRIDs = dbterror.ClassTypes.NewStd(mysql.ErrGISDifferentSRIDs)
+	// ErrUnsupportedArgument means the spatial function doesn't support the types of the geometries.
+	ErrUnsupportedArgument = dbterror.ClassTypes.NewStd(mysql.ErrGISUnsupportedArgument)
+	// ErrSRSNotFound means the SRID isn't supported.
+	ErrSRSNotFound = dbterror.ClassTypes.NewStd(mysql.ErrSrsNotFound)
+	// ErrLongitudeOutOfRange means the longitude of a geographic point is out of range.
+	ErrLongitudeOutOfRange = dbterror.ClassTypes.NewStd(mysql.ErrLongitudeOutOfRange)
+	// ErrLatitudeOutOfRange means the latitude of a geographic point is out of range.
+	ErrLatitudeOutOfRange = dbterror.ClassTypes.NewStd(mysql.ErrLatitudeOutOfRange)
+	// ErrNotImplementedForGeographicSRS means the spatial function doesn't support the geographic SRS.
+	ErrNotImplementedForGeographicSRS = dbterror.ClassTypes.NewStd(mysql.ErrNotImplementedForGeographicSrs)
+	// ErrNonPositiveRadius means the radius of ST_Distance_Sphere isn't positive.
+	ErrNonPositiveRadius = dbterror.ClassTypes.NewStd(mysql.ErrNonpositiveRadius)
+)
+
+// Point is a position in the coordinate system.
+type Point struct {
+	X float64
+	Y float64
+}
+
+// Geometry is a value of the GEOMETRY type.
+type Geometry struct {
+	// Type is the type of the geometry, it's never TypeGeometry.
+	Type byte
+	SRID uint32
+	// Coords are the coordinates of a POINT, LINESTRING or POLYGON. A POINT has a single sequence with a
+	// single point, a LINESTRING has a single sequence, and a POLYGON has its exterior ring followed by its
+	// interior rings.
+	Coords [][]Point
+	// Geoms are the elements of a MULTIPOINT, MULTILINESTRING, MULTIPOLYGON or GEOMETRYCOLLECTION.
+	Geoms []*Geometry
+}
+
+// NewPoint creates a POINT.
+func NewPoint(x, y float64, srid uint32) *Geometry {
+	return &Geometry{Type: TypePoint, SRID: srid, Coords: [][]Point{{{X: x, Y: y}}}}
+}
+
+// IsCollection returns whether the geometry consists of other geometries.
+func (g *Geometry) IsCollection() bool {
+	return g.Type >= TypeMultiPoint
+}
+
+// IsEmpty returns whether the geometry is an empty GEOMETRYCOLLECTION, which is the only empty geometry.
+func (g *Geometry) IsEmpty() bool {
+	if !g.IsCollection() {
+		return false
+	}
+	for _, e := range g.Geoms {
+		if !e.IsEmpty() {
+			return false
+		}
+	}
+	return true
+}
+
+// TypeName returns the name of the type of the geometry used by ST_GeometryType.
+func (g *Geometry) TypeName() string {
+	if g.Type == TypeGeometryCollection {
+		return "GEOMCOLLECTION"
+	}
+	return typeNames[g.Type]
+}
+
+var typeNames = map[byte]string{
+	TypePoint:              "POINT",
+	TypeLineString:         "LINESTRING",
+	TypePolygon:            "POLYGON",
+	TypeMultiPoint:         "MULTIPOINT",
+	TypeMultiLineString:    "MULTILINESTRING",
+	TypeMultiPolygon:       "MULTIPOLYGON",
+	TypeGeometryCollection: "GEOMETRYCOLLECTION",
+}
+
+// elemType returns the type of the elements of a MULTI* geometry.
+func elemType(tp byte) byte {
+	switch tp {
+	case TypeMultiPoint:
+		return TypePoint
+	case TypeMultiLineString:
+		return TypeLineString
+	case TypeMultiPolygon:
+		return TypePolygon
+	}
+	return TypeGeometry
+}
+
+// IsInstanceOf returns whether the geometry can be stored in a column of the type.
+func (g *Geometry) IsInstanceOf(tp byte) bool {
+	return tp == TypeGeometry || tp == g.Type
+}
+
+// IsGeographic returns whether the SRID is a geographic spatial reference system.
+func IsGeographic(srid uint32) bool {
+	return srid == SRIDWGS84
+}
+
+// CheckSRID checks whether the SRID is supported.
+func CheckSRID(srid uint32) error {
+	if srid != SRIDCartesian && srid != SRIDWGS84 {
+		return ErrSRSNotFound.GenWithStackByArgs(srid)
+	}
+	return nil
+}
+
+// Validate checks the structure of the geometry and the ranges of its coordinates. The name of the function
+// is used in the error message.
+func (g *Geometry) Validate(funcName string) error {
+	if err := CheckSRID(g.SRID); err != nil {
+		return err
+	}
+	return g.validateShape(funcName, g.SRID)
+}
+
+func (g *Geometry) validateShape(funcName string, srid uint32) error {
+	invalid := ErrInvalidGISData.GenWithStackByArgs(funcName)
+	switch g.Type {
+	case TypePoint:
+		if len(g.Coords) != 1 || len(g.Coords[0]) != 1 {
+			return invalid
+		}
+	case TypeLineString:
+		if len(g.Coords) != 1 || len(g.Coords[0]) < 2 {
+			return invalid
+		}
+	case TypePolygon:
+		if len(g.Coords) == 0 {
+			return invalid
+		}
+		for _, ring := range g.Coords {
+			// A ring is closed, and it has at least 3 distinct points.
+			if len(ring) < 4 || ring[0] != ring[len(ring)-1] {
+				return invalid
+			}
+		}
+	case TypeMultiPoint, TypeMultiLineString, TypeMultiPolygon, TypeGeometryCollection:
+		if g.Type != TypeGeometryCollection && len(g.Geoms) == 0 {
+			return invalid
+		}
+		for _, e := range g.Geoms {
+			if g.Type != TypeGeometryCollection && e.Type != elemType(g.Type) {
+				return invalid
+			}
+			if err := e.validateShape(funcName, srid); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return invalid
+	}
+	for _, ps := range g.Coords {
+		for _, p := range ps {
+			if math.IsNaN(p.X) || math.IsNaN(p.Y) || math.IsInf(p.X, 0) || math.IsInf(p.Y, 0) {
+				return invalid
+			}
+			if !IsGeographic(srid) {
+				continue
+			}
+			if p.X <= -180 || p.X > 180 {
+				return ErrLongitudeOutOfRange.GenWithStackByArgs(p.X, funcName, -180.0, 180.0)
+			}
+			if p.Y < -90 || p.Y > 90 {
+				return ErrLatitudeOutOfRange.GenWithStackByArgs(p.Y, funcName, -90.0, 90.0)
+			}
+		}
+	}
+	return nil
+}
+
+// SwapXY returns a copy of the geometry whose X and Y coordinates are swapped.
+func (g *Geometry) SwapXY() *Geometry {
+	res := &Geometry{Type: g.Type, SRID: g.SRID}
+	for _, ps := range g.Coords {
+		swapped := make([]Point, 0, len(ps))
+		for _, p := range ps {
+			swapped = append(swapped, Point{X: p.Y, Y: p.X})
+		}
+		res.Coords = append(res.Coords, swapped)
+	}
+	for _, e := range g.Geoms {
+		res.Geoms = append(res.Geoms, e.SwapXY())
+	}
+	return res
+}
+
+// forEachPoint calls the function for all the points of the geometry.
+func (g *Geometry) forEachPoint(fn func(p Point)) {
+	for _, ps := range g.Coords {
+		for _, p := range ps {
+			fn(p)
+		}
+	}
+	for _, e := range g.Geoms {
+		e.forEachPoint(fn)
+	}
+}
+
+// Marshal encodes the geometry to the storage format, which is the same as MySQL: the SRID in 4 bytes of
+// little-endian, followed by the WKB of the geometry.
+func (g *Geometry) Marshal() []byte {
+	buf := make([]byte, 4, 4+g.wkbSize())
+	binary.LittleEndian.PutUint32(buf, g.SRID)
+	return g.appendWKB(buf)
+}
+
+// Unmarshal decodes the geometry from the storage format.
+func Unmarshal(data []byte) (*Geometry, error) {
+	if len(data) < 4 {
+		return nil, ErrCantCreateGeometryObject.GenWithStackByArgs()
+	}
+	srid := binary.LittleEndian.Uint32(data)
+	g, err := ParseWKB(data[4:], srid, false)
+	if err != nil {
+		return nil, ErrCantCreateGeometryObject.GenWithStackByArgs()
+	}
+	return g, nil
+}
+
+// DecodeSRID returns the SRID of the geometry in the storage format, the data must be valid.
+func DecodeSRID(data []byte) uint32 {
+	return binary.LittleEndian.Uint32(data)
+}
+
+// Check checks whether the data is a valid geometry in the storage format.
+func Check(data []byte) error {
+	_, err := Unmarshal(data)
+	return err
+}
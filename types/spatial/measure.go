@@ -0,0 +1,169 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spatial
+
+import "math"
+
+// Distance returns the minimum distance between the geometries. The distance between geographic geometries
+// is the great-circle distance in meters on a sphere of EarthRadius, which is only supported for points.
+// NaN is returned if either geometry is empty.
+func Distance(a, b *Geometry) (float64, error) {
+	pa, pb := a.parts(), b.parts()
+	if pa.isEmpty() || pb.isEmpty() {
+		return math.NaN(), nil
+	}
+	if IsGeographic(a.SRID) {
+		if len(pa.lines) > 0 || len(pa.polys) > 0 || len(pb.lines) > 0 || len(pb.polys) > 0 {
+			return 0, ErrNotImplementedForGeographicSRS.GenWithStackByArgs("st_distance", a.TypeName()+", "+b.TypeName())
+		}
+		return minPointsDistance(pa.points, pb.points, func(p, q Point) float64 { return haversine(p, q, EarthRadius) }), nil
+	}
+	if pa.intersects(pb) {
+		return 0, nil
+	}
+	d := math.Inf(1)
+	pointsA, pointsB := pa.allPoints(), pb.allPoints()
+	// The geometries are disjoint, so the minimum distance is between a point and a segment.
+	for _, pt := range pointsA {
+		for _, q := range pointsB {
+			d = math.Min(d, math.Hypot(pt.X-q.X, pt.Y-q.Y))
+		}
+		forEachEdge(pb.edges(), func(s, e Point) bool {
+			d = math.Min(d, pointSegmentDistance(pt, s, e))
+			return false
+		})
+	}
+	for _, pt := range pointsB {
+		forEachEdge(pa.edges(), func(s, e Point) bool {
+			d = math.Min(d, pointSegmentDistance(pt, s, e))
+			return false
+		})
+	}
+	return d, nil
+}
+
+// DistanceSphere returns the great-circle distance between the points or multipoints on a sphere of the radius.
+// The coordinates are treated as longitude and latitude in degrees regardless of the SRID.
+func DistanceSphere(a, b *Geometry, radius float64) (float64, error) {
+	const funcName = "st_distance_sphere"
+	if radius <= 0 {
+		return 0, ErrNonPositiveRadius.GenWithStackByArgs(funcName)
+	}
+	pa, pb := a.parts(), b.parts()
+	if len(pa.lines) > 0 || len(pa.polys) > 0 || len(pb.lines) > 0 || len(pb.polys) > 0 ||
+		len(pa.points) == 0 || len(pb.points) == 0 {
+		return 0, ErrUnsupportedArgument.GenWithStackByArgs(funcName)
+	}
+	for _, ps := range [][]Point{pa.points, pb.points} {
+		for _, p := range ps {
+			if p.X < -180 || p.X > 180 {
+				return 0, ErrLongitudeOutOfRange.GenWithStackByArgs(p.X, funcName, -180.0, 180.0)
+			}
+			if p.Y < -90 || p.Y > 90 {
+				return 0, ErrLatitudeOutOfRange.GenWithStackByArgs(p.Y, funcName, -90.0, 90.0)
+			}
+		}
+	}
+	return minPointsDistance(pa.points, pb.points, func(p, q Point) float64 { return haversine(p, q, radius) }), nil
+}
+
+// Area returns the area of the polygon or multipolygon.
+func Area(g *Geometry) (float64, error) {
+	if g.Type != TypePolygon && g.Type != TypeMultiPolygon {
+		return 0, ErrUnsupportedArgument.GenWithStackByArgs("st_area")
+	}
+	if IsGeographic(g.SRID) {
+		return 0, ErrNotImplementedForGeographicSRS.GenWithStackByArgs("st_area", g.TypeName())
+	}
+	area := 0.0
+	for _, poly := range g.parts().polys {
+		area += math.Abs(ringArea(poly[0]))
+		for _, hole := range poly[1:] {
+			area -= math.Abs(ringArea(hole))
+		}
+	}
+	return area, nil
+}
+
+// Length returns the length of the linestring or multilinestring. The length of a geographic geometry is in
+// meters on a sphere of EarthRadius.
+func Length(g *Geometry) (float64, error) {
+	if g.Type != TypeLineString && g.Type != TypeMultiLineString {
+		return 0, ErrUnsupportedArgument.GenWithStackByArgs("st_length")
+	}
+	length := 0.0
+	forEachEdge(g.parts().lines, func(a, b Point) bool {
+		if IsGeographic(g.SRID) {
+			length += haversine(a, b, EarthRadius)
+		} else {
+			length += math.Hypot(a.X-b.X, a.Y-b.Y)
+		}
+		return false
+	})
+	return length, nil
+}
+
+func ringArea(ring []Point) float64 {
+	area := 0.0
+	for i := 1; i < len(ring); i++ {
+		area += ring[i-1].X*ring[i].Y - ring[i].X*ring[i-1].Y
+	}
+	return area / 2
+}
+
+func (p *parts) allPoints() []Point {
+	ps := append([]Point(nil), p.points...)
+	for _, line := range p.edges() {
+		ps = append(ps, line...)
+	}
+	return ps
+}
+
+// edges returns the lines and the rings of the polygons.
+func (p *parts) edges() [][]Point {
+	lines := append([][]Point(nil), p.lines...)
+	for _, poly := range p.polys {
+		lines = append(lines, poly...)
+	}
+	return lines
+}
+
+func pointSegmentDistance(p, a, b Point) float64 {
+	dx, dy := b.X-a.X, b.Y-a.Y
+	if dx == 0 && dy == 0 {
+		return math.Hypot(p.X-a.X, p.Y-a.Y)
+	}
+	t := ((p.X-a.X)*dx + (p.Y-a.Y)*dy) / (dx*dx + dy*dy)
+	t = math.Max(0, math.Min(1, t))
+	return math.Hypot(p.X-(a.X+t*dx), p.Y-(a.Y+t*dy))
+}
+
+func minPointsDistance(a, b []Point, dist func(p, q Point) float64) float64 {
+	d := math.Inf(1)
+	for _, p := range a {
+		for _, q := range b {
+			d = math.Min(d, dist(p, q))
+		}
+	}
+	return d
+}
+
+// haversine returns the great-circle distance between the points of longitude and latitude in degrees.
+func haversine(p, q Point, radius float64) float64 {
+	lat1, lat2 := p.Y*math.Pi/180, q.Y*math.Pi/180
+	dLat, dLon := lat2-lat1, (q.X-p.X)*math.Pi/180
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * radius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
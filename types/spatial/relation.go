@@ -0,0 +1,391 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spatial
+
+import "math"
+
+// The relations between geometries are computed in the plane, the coordinates of the geographic geometries
+// are treated as Cartesian coordinates. It's accurate enough for the geometries that are small compared
+// with the earth and don't cross the antimeridian.
+
+// Rect is the minimum bounding rectangle of a geometry.
+type Rect struct {
+	MinX, MinY, MaxX, MaxY float64
+	// Empty means the rectangle contains no point.
+	Empty bool
+}
+
+// MBR returns the minimum bounding rectangle of the geometry.
+func (g *Geometry) MBR() Rect {
+	r := Rect{MinX: math.Inf(1), MinY: math.Inf(1), MaxX: math.Inf(-1), MaxY: math.Inf(-1), Empty: true}
+	g.forEachPoint(func(p Point) {
+		r.MinX, r.MaxX = math.Min(r.MinX, p.X), math.Max(r.MaxX, p.X)
+		r.MinY, r.MaxY = math.Min(r.MinY, p.Y), math.Max(r.MaxY, p.Y)
+		r.Empty = false
+	})
+	return r
+}
+
+// Envelope returns the MBR of the geometry as a geometry. It's a POINT or LINESTRING if the MBR is degenerate,
+// and an empty GEOMETRYCOLLECTION if the geometry is empty.
+func (g *Geometry) Envelope() *Geometry {
+	r := g.MBR()
+	switch {
+	case r.Empty:
+		return &Geometry{Type: TypeGeometryCollection, SRID: g.SRID}
+	case r.MinX == r.MaxX && r.MinY == r.MaxY:
+		return NewPoint(r.MinX, r.MinY, g.SRID)
+	case r.MinX == r.MaxX || r.MinY == r.MaxY:
+		return &Geometry{Type: TypeLineString, SRID: g.SRID, Coords: [][]Point{{{X: r.MinX, Y: r.MinY}, {X: r.MaxX, Y: r.MaxY}}}}
+	}
+	ring := []Point{{X: r.MinX, Y: r.MinY}, {X: r.MaxX, Y: r.MinY}, {X: r.MaxX, Y: r.MaxY}, {X: r.MinX, Y: r.MaxY}, {X: r.MinX, Y: r.MinY}}
+	return &Geometry{Type: TypePolygon, SRID: g.SRID, Coords: [][]Point{ring}}
+}
+
+// Contains returns whether the other rectangle is inside the rectangle.
+func (r Rect) Contains(o Rect) bool {
+	return !r.Empty && !o.Empty && r.MinX <= o.MinX && r.MinY <= o.MinY && r.MaxX >= o.MaxX && r.MaxY >= o.MaxY
+}
+
+// Intersects returns whether the rectangles have any common point.
+func (r Rect) Intersects(o Rect) bool {
+	return !r.Empty && !o.Empty && r.MinX <= o.MaxX && o.MinX <= r.MaxX && r.MinY <= o.MaxY && o.MinY <= r.MaxY
+}
+
+// CheckSameSRID checks whether the geometries passed to the binary spatial function have the same SRID.
+func CheckSameSRID(funcName string, a, b *Geometry) error {
+	if a.SRID != b.SRID {
+		return ErrDifferentSRIDs.GenWithStackByArgs(funcName, a.SRID, b.SRID)
+	}
+	return nil
+}
+
+// parts are the primitive geometries of a geometry.
+type parts struct {
+	points []Point
+	lines  [][]Point
+	polys  [][][]Point
+}
+
+func (g *Geometry) parts() *parts {
+	p := &parts{}
+	g.collectParts(p)
+	return p
+}
+
+func (g *Geometry) collectParts(p *parts) {
+	switch g.Type {
+	case TypePoint:
+		p.points = append(p.points, g.Coords[0][0])
+	case TypeLineString:
+		p.lines = append(p.lines, g.Coords[0])
+	case TypePolygon:
+		p.polys = append(p.polys, g.Coords)
+	default:
+		for _, e := range g.Geoms {
+			e.collectParts(p)
+		}
+	}
+}
+
+func (p *parts) isEmpty() bool {
+	return len(p.points) == 0 && len(p.lines) == 0 && len(p.polys) == 0
+}
+
+// orient returns a positive value if c is on the left of the directed line ab, a negative value if it's on
+// the right, and 0 if the three points are collinear.
+func orient(a, b, c Point) float64 {
+	return (b.X-a.X)*(c.Y-a.Y) - (b.Y-a.Y)*(c.X-a.X)
+}
+
+func onSegment(p, a, b Point) bool {
+	return orient(a, b, p) == 0 &&
+		math.Min(a.X, b.X) <= p.X && p.X <= math.Max(a.X, b.X) &&
+		math.Min(a.Y, b.Y) <= p.Y && p.Y <= math.Max(a.Y, b.Y)
+}
+
+func segmentsIntersect(a, b, c, d Point) bool {
+	o1, o2, o3, o4 := orient(a, b, c), orient(a, b, d), orient(c, d, a), orient(c, d, b)
+	if (o1 > 0 && o2 < 0 || o1 < 0 && o2 > 0) && (o3 > 0 && o4 < 0 || o3 < 0 && o4 > 0) {
+		return true
+	}
+	return onSegment(c, a, b) || onSegment(d, a, b) || onSegment(a, c, d) || onSegment(b, c, d)
+}
+
+// segmentsCross returns whether the segments intersect at a single point which is in the interior of both.
+func segmentsCross(a, b, c, d Point) bool {
+	o1, o2, o3, o4 := orient(a, b, c), orient(a, b, d), orient(c, d, a), orient(c, d, b)
+	return (o1 > 0 && o2 < 0 || o1 < 0 && o2 > 0) && (o3 > 0 && o4 < 0 || o3 < 0 && o4 > 0)
+}
+
+func onLine(p Point, line []Point) bool {
+	for i := 1; i < len(line); i++ {
+		if onSegment(p, line[i-1], line[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// The locations of a point relative to an area.
+const (
+	outside  = -1
+	boundary = 0
+	inside   = 1
+)
+
+func locateInRing(p Point, ring []Point) int {
+	in := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		a, b := ring[j], ring[i]
+		if onSegment(p, a, b) {
+			return boundary
+		}
+		if (a.Y > p.Y) != (b.Y > p.Y) && p.X < (b.X-a.X)*(p.Y-a.Y)/(b.Y-a.Y)+a.X {
+			in = !in
+		}
+	}
+	if in {
+		return inside
+	}
+	return outside
+}
+
+func locateInPolygon(p Point, poly [][]Point) int {
+	loc := locateInRing(p, poly[0])
+	if loc != inside {
+		return loc
+	}
+	for _, hole := range poly[1:] {
+		switch locateInRing(p, hole) {
+		case inside:
+			return outside
+		case boundary:
+			return boundary
+		}
+	}
+	return inside
+}
+
+func locateInPolygons(p Point, polys [][][]Point) int {
+	loc := outside
+	for _, poly := range polys {
+		if l := locateInPolygon(p, poly); l > loc {
+			loc = l
+		}
+	}
+	return loc
+}
+
+// forEachEdge calls the function for all the segments of the lines until it returns true.
+func forEachEdge(lines [][]Point, fn func(a, b Point) bool) bool {
+	for _, line := range lines {
+		for i := 1; i < len(line); i++ {
+			if fn(line[i-1], line[i]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func lineIntersectsLines(line []Point, lines [][]Point) bool {
+	return forEachEdge([][]Point{line}, func(a, b Point) bool {
+		return forEachEdge(lines, func(c, d Point) bool {
+			return segmentsIntersect(a, b, c, d)
+		})
+	})
+}
+
+func (p *parts) intersects(o *parts) bool {
+	for _, pt := range p.points {
+		if o.coversPoint(pt) {
+			return true
+		}
+	}
+	for _, line := range p.lines {
+		for _, pt := range o.points {
+			if onLine(pt, line) {
+				return true
+			}
+		}
+		if lineIntersectsLines(line, o.lines) {
+			return true
+		}
+		for _, poly := range o.polys {
+			if lineIntersectsLines(line, poly) || locateInPolygon(line[0], poly) != outside {
+				return true
+			}
+		}
+	}
+	for _, poly := range p.polys {
+		for _, pt := range o.points {
+			if locateInPolygon(pt, poly) != outside {
+				return true
+			}
+		}
+		for _, line := range o.lines {
+			if lineIntersectsLines(line, poly) || locateInPolygon(line[0], poly) != outside {
+				return true
+			}
+		}
+		for _, other := range o.polys {
+			if forEachEdge(poly, func(a, b Point) bool { return lineIntersectsLines([]Point{a, b}, other) }) ||
+				locateInPolygon(poly[0][0], other) != outside || locateInPolygon(other[0][0], poly) != outside {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (p *parts) coversPoint(pt Point) bool {
+	for _, q := range p.points {
+		if q == pt {
+			return true
+		}
+	}
+	for _, line := range p.lines {
+		if onLine(pt, line) {
+			return true
+		}
+	}
+	return locateInPolygons(pt, p.polys) != outside
+}
+
+func midpoint(a, b Point) Point {
+	return Point{X: (a.X + b.X) / 2, Y: (a.Y + b.Y) / 2}
+}
+
+// coversSegment returns whether all the points of the segment are in the geometry. It checks the end points
+// and the midpoint of the segment, and whether the segment crosses the boundary of the areas, so a segment
+// covered by the union of multiple lines or areas may be reported as not covered.
+func (p *parts) coversSegment(a, b Point) bool {
+	for _, line := range p.lines {
+		for i := 1; i < len(line); i++ {
+			if onSegment(a, line[i-1], line[i]) && onSegment(b, line[i-1], line[i]) {
+				return true
+			}
+		}
+	}
+	for _, poly := range p.polys {
+		if locateInPolygon(a, poly) == outside || locateInPolygon(b, poly) == outside ||
+			locateInPolygon(midpoint(a, b), poly) == outside {
+			continue
+		}
+		if !forEachEdge(poly, func(c, d Point) bool { return segmentsCross(a, b, c, d) }) {
+			return true
+		}
+	}
+	return false
+}
+
+// covers returns whether all the points of the other geometry are in the geometry.
+func (p *parts) covers(o *parts) bool {
+	for _, pt := range o.points {
+		if !p.coversPoint(pt) {
+			return false
+		}
+	}
+	for _, line := range o.lines {
+		if forEachEdge([][]Point{line}, func(a, b Point) bool { return !p.coversSegment(a, b) }) {
+			return false
+		}
+	}
+	for _, poly := range o.polys {
+		if len(p.polys) == 0 || forEachEdge(poly, func(a, b Point) bool { return !p.coversSegment(a, b) }) {
+			return false
+		}
+		// The holes of the areas shouldn't be inside the polygon.
+		for _, area := range p.polys {
+			for _, hole := range area[1:] {
+				if locateInPolygon(midpoint(hole[0], hole[1]), poly) == inside {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// inInterior returns whether the point is in the interior of the geometry.
+func (p *parts) inInterior(pt Point) bool {
+	for _, q := range p.points {
+		if q == pt {
+			return true
+		}
+	}
+	for _, line := range p.lines {
+		closed := line[0] == line[len(line)-1]
+		if onLine(pt, line) && (closed || pt != line[0] && pt != line[len(line)-1]) {
+			return true
+		}
+	}
+	return locateInPolygons(pt, p.polys) == inside
+}
+
+// interiorsIntersect returns whether the interiors of the geometries intersect, given that the other geometry
+// is covered by the geometry.
+func (p *parts) interiorsIntersect(o *parts) bool {
+	if len(o.polys) > 0 {
+		return true
+	}
+	for _, pt := range o.points {
+		if p.inInterior(pt) {
+			return true
+		}
+	}
+	return forEachEdge(o.lines, func(a, b Point) bool {
+		return p.inInterior(a) || p.inInterior(midpoint(a, b))
+	})
+}
+
+// Intersects returns whether the geometries have any common point.
+func Intersects(a, b *Geometry) bool {
+	return a.MBR().Intersects(b.MBR()) && a.parts().intersects(b.parts())
+}
+
+// Disjoint returns whether the geometries have no common point.
+func Disjoint(a, b *Geometry) bool {
+	return !Intersects(a, b)
+}
+
+// Contains returns whether no point of b lies in the exterior of a, and at least one point of the interior
+// of b lies in the interior of a.
+func Contains(a, b *Geometry) bool {
+	if !a.MBR().Contains(b.MBR()) {
+		return false
+	}
+	pa, pb := a.parts(), b.parts()
+	return pa.covers(pb) && pa.interiorsIntersect(pb)
+}
+
+// Within returns whether a is within b.
+func Within(a, b *Geometry) bool {
+	return Contains(b, a)
+}
+
+// Equals returns whether the geometries consist of the same points.
+func Equals(a, b *Geometry) bool {
+	ra, rb := a.MBR(), b.MBR()
+	if ra.Empty || rb.Empty {
+		return ra.Empty && rb.Empty
+	}
+	if ra != rb {
+		return false
+	}
+	pa, pb := a.parts(), b.parts()
+	return pa.covers(pb) && pb.covers(pa)
+}
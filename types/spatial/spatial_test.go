@@ -0,0 +1,319 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spatial
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func mustParseWKT(t *testing.T, text string, srid uint32) *Geometry {
+	g, err := ParseWKT(text, srid, false, "st_geomfromtext")
+	require.NoError(t, err, text)
+	return g
+}
+
+func TestWKT(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"POINT(1 2)", "POINT(1 2)"},
+		{" point ( -1.5  2e3 ) ", "POINT(-1.5 2000)"},
+		{"LINESTRING(0 0, 1 1, 2 0)", "LINESTRING(0 0,1 1,2 0)"},
+		{"POLYGON((0 0,10 0,10 10,0 10,0 0),(1 1,1 2,2 2,1 1))", "POLYGON((0 0,10 0,10 10,0 10,0 0),(1 1,1 2,2 2,1 1))"},
+		{"MULTIPOINT(0 0, 1 1)", "MULTIPOINT((0 0),(1 1))"},
+		{"MULTIPOINT((0 0),(1 1))", "MULTIPOINT((0 0),(1 1))"},
+		{"MULTILINESTRING((0 0,1 1),(2 2,3 3))", "MULTILINESTRING((0 0,1 1),(2 2,3 3))"},
+		{"MULTIPOLYGON(((0 0,1 0,1 1,0 0)),((2 2,3 2,3 3,2 2)))", "MULTIPOLYGON(((0 0,1 0,1 1,0 0)),((2 2,3 2,3 3,2 2)))"},
+		{"GEOMETRYCOLLECTION(POINT(1 2),LINESTRING(0 0,1 1))", "GEOMETRYCOLLECTION(POINT(1 2),LINESTRING(0 0,1 1))"},
+		{"GEOMCOLLECTION(GEOMETRYCOLLECTION EMPTY)", "GEOMETRYCOLLECTION(GEOMETRYCOLLECTION EMPTY)"},
+		{"GEOMETRYCOLLECTION()", "GEOMETRYCOLLECTION EMPTY"},
+	}
+	for _, tt := range tests {
+		g := mustParseWKT(t, tt.input, 0)
+		require.Equal(t, tt.expected, g.WKT())
+
+		// The storage format and WKB can be decoded to the same geometry.
+		decoded, err := Unmarshal(g.Marshal())
+		require.NoError(t, err)
+		require.Equal(t, g, decoded)
+		decoded, err = ParseWKB(g.WKB(), 0, false)
+		require.NoError(t, err)
+		require.Equal(t, g, decoded)
+	}
+
+	for _, input := range []string{
+		"", "POINT(1)", "POINT(1 2", "POINT(1 2) x", "LINESTRING(0 0)", "POLYGON((0 0,1 0,1 1,0 1))",
+		"POLYGON((0 0,1 0,0 0))", "MULTIPOINT()", "CIRCLE(0 0)", "POINT(a b)",
+	} {
+		_, err := ParseWKT(input, 0, false, "st_geomfromtext")
+		require.True(t, ErrInvalidGISData.Equal(err), input)
+	}
+
+	_, err := ParseWKT("POINT(1 2)", 3857, false, "st_geomfromtext")
+	require.True(t, ErrSRSNotFound.Equal(err))
+	_, err = ParseWKT("POINT(181 0)", 4326, false, "st_geomfromtext")
+	require.True(t, ErrLongitudeOutOfRange.Equal(err))
+	_, err = ParseWKT("POINT(0 -91)", 4326, false, "st_geomfromtext")
+	require.True(t, ErrLatitudeOutOfRange.Equal(err))
+	// The geographic coordinates may be in the latitude-longitude order.
+	g, err := ParseWKT("POINT(10 170)", 4326, true, "st_geomfromtext")
+	require.NoError(t, err)
+	require.Equal(t, "POINT(170 10)", g.WKT())
+	require.Equal(t, "POINT(10 170)", g.SwapXY().WKT())
+	_, err = ParseWKT("POINT(0 -91)", 4326, true, "st_geomfromtext")
+	require.NoError(t, err)
+}
+
+func TestWKB(t *testing.T) {
+	// POINT(1 2) in big-endian.
+	data := []byte{0, 0, 0, 0, 1, 0x3f, 0xf0, 0, 0, 0, 0, 0, 0, 0x40, 0, 0, 0, 0, 0, 0, 0}
+	g, err := ParseWKB(data, 0, false)
+	require.NoError(t, err)
+	require.Equal(t, "POINT(1 2)", g.WKT())
+	require.Equal(t, []byte{1, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0xf0, 0x3f, 0, 0, 0, 0, 0, 0, 0, 0x40}, g.WKB())
+
+	for _, data := range [][]byte{
+		nil,
+		{1, 1, 0, 0, 0},
+		{2, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0xf0, 0x3f, 0, 0, 0, 0, 0, 0, 0, 0x40},
+		{1, 9, 0, 0, 0},
+		// A linestring with too many points.
+		{1, 2, 0, 0, 0, 0xff, 0xff, 0xff, 0xff},
+		// A point with trailing bytes.
+		{1, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0xf0, 0x3f, 0, 0, 0, 0, 0, 0, 0, 0x40, 0},
+	} {
+		_, err := ParseWKB(data, 0, false)
+		require.True(t, ErrInvalidGISData.Equal(err))
+	}
+	_, err = Unmarshal([]byte{1, 2})
+	require.True(t, ErrCantCreateGeometryObject.Equal(err))
+	require.NoError(t, Check(NewPoint(1, 2, 4326).Marshal()))
+}
+
+func TestGeoJSON(t *testing.T) {
+	tests := []string{
+		`{"type": "Point", "coordinates": [1.5, 2]}`,
+		`{"type": "LineString", "coordinates": [[0, 0], [1, 1]]}`,
+		`{"type": "Polygon", "coordinates": [[[0, 0], [1, 0], [1, 1], [0, 0]]]}`,
+		`{"type": "MultiPoint", "coordinates": [[0, 0], [1, 1]]}`,
+		`{"type": "MultiLineString", "coordinates": [[[0, 0], [1, 1]]]}`,
+		`{"type": "MultiPolygon", "coordinates": [[[[0, 0], [1, 0], [1, 1], [0, 0]]]]}`,
+		`{"type": "GeometryCollection", "geometries": [{"type": "Point", "coordinates": [1, 2]}]}`,
+		`{"type": "GeometryCollection", "geometries": []}`,
+	}
+	for _, input := range tests {
+		g, err := ParseGeoJSON([]byte(input), 4326)
+		require.NoError(t, err, input)
+		output, err := json.Marshal(g.GeoJSON(-1))
+		require.NoError(t, err)
+		require.JSONEq(t, input, string(output))
+	}
+
+	g, err := ParseGeoJSON([]byte(`{"type": "Point", "coordinates": [1.23456, 2, 100]}`), 4326)
+	require.NoError(t, err)
+	require.Equal(t, "POINT(1.23456 2)", g.WKT())
+	require.Equal(t, []interface{}{1.23, 2.0}, g.GeoJSON(2)["coordinates"])
+
+	for _, input := range []string{
+		`{"type": "Point", "coordinates": [1]}`,
+		`{"type": "Point"}`,
+		`{"type": "Circle", "coordinates": [1, 2]}`,
+		`{"type": "GeometryCollection"}`,
+		`[1, 2]`,
+	} {
+		_, err := ParseGeoJSON([]byte(input), 4326)
+		require.True(t, ErrInvalidGISData.Equal(err), input)
+	}
+	_, err = ParseGeoJSON([]byte(`{"type": "Point", "coordinates": [200, 2]}`), 4326)
+	require.True(t, ErrLongitudeOutOfRange.Equal(err))
+}
+
+func TestRelations(t *testing.T) {
+	zone := "POLYGON((0 0,10 0,10 10,0 10,0 0),(4 4,6 4,6 6,4 6,4 4))"
+	tests := []struct {
+		a, b                         string
+		intersects, contains, equals bool
+	}{
+		{zone, "POINT(1 1)", true, true, false},
+		{zone, "POINT(5 5)", false, false, false},
+		{zone, "POINT(0 5)", true, false, false},
+		{zone, "POINT(4 5)", true, false, false},
+		{zone, "POINT(11 5)", false, false, false},
+		{zone, "LINESTRING(1 1,3 3)", true, true, false},
+		{zone, "LINESTRING(1 1,5 5)", true, false, false},
+		{zone, "LINESTRING(0 0,10 0)", true, false, false},
+		{zone, "LINESTRING(-1 5,11 5)", true, false, false},
+		{zone, "POLYGON((1 1,3 1,3 3,1 1))", true, true, false},
+		{zone, "POLYGON((1 1,8 1,8 8,1 8,1 1))", true, false, false},
+		{zone, "POLYGON((4.5 4.5,5 4.5,5 5,4.5 4.5))", false, false, false},
+		{zone, zone, true, true, true},
+		{zone, "MULTIPOINT(1 1,2 2)", true, true, false},
+		{zone, "MULTIPOINT(1 1,20 20)", true, false, false},
+		{"POLYGON((0 0,2 0,2 2,0 2,0 0))", "POLYGON((2 0,4 0,4 2,2 2,2 0))", true, false, false},
+		{"POLYGON((0 0,2 0,2 2,0 2,0 0))", "POLYGON((1 1,3 1,3 3,1 3,1 1))", true, false, false},
+		{"LINESTRING(0 0,2 2)", "LINESTRING(0 2,2 0)", true, false, false},
+		{"LINESTRING(0 0,2 2)", "POINT(1 1)", true, true, false},
+		{"LINESTRING(0 0,2 2)", "POINT(0 0)", true, false, false},
+		{"LINESTRING(0 0,2 2,4 4)", "LINESTRING(4 4,2 2,0 0)", true, true, true},
+		{"POINT(1 1)", "POINT(1 1)", true, true, true},
+		{"POINT(1 1)", "MULTIPOINT(1 1,1 1)", true, true, true},
+		{"GEOMETRYCOLLECTION(POINT(1 1),POLYGON((5 5,6 5,6 6,5 5)))", "POINT(5.9 5.5)", true, true, false},
+		{"GEOMETRYCOLLECTION EMPTY", "POINT(1 1)", false, false, false},
+		{"GEOMETRYCOLLECTION EMPTY", "GEOMETRYCOLLECTION EMPTY", false, false, true},
+	}
+	for _, tt := range tests {
+		a, b := mustParseWKT(t, tt.a, 0), mustParseWKT(t, tt.b, 0)
+		require.Equal(t, tt.intersects, Intersects(a, b), "%s intersects %s", tt.a, tt.b)
+		require.Equal(t, tt.intersects, Intersects(b, a), "%s intersects %s", tt.b, tt.a)
+		require.Equal(t, !tt.intersects, Disjoint(a, b), "%s disjoint %s", tt.a, tt.b)
+		require.Equal(t, tt.contains, Contains(a, b), "%s contains %s", tt.a, tt.b)
+		require.Equal(t, tt.contains, Within(b, a), "%s within %s", tt.b, tt.a)
+		require.Equal(t, tt.equals, Equals(a, b), "%s equals %s", tt.a, tt.b)
+	}
+
+	r := mustParseWKT(t, zone, 0).MBR()
+	require.True(t, r.Contains(mustParseWKT(t, "POINT(5 5)", 0).MBR()))
+	require.True(t, r.Intersects(mustParseWKT(t, "LINESTRING(10 10,20 20)", 0).MBR()))
+	require.False(t, r.Intersects(mustParseWKT(t, "GEOMETRYCOLLECTION EMPTY", 0).MBR()))
+	require.Equal(t, "POLYGON((0 0,10 0,10 10,0 10,0 0))", mustParseWKT(t, zone, 0).Envelope().WKT())
+	require.Equal(t, "LINESTRING(1 2,1 5)", mustParseWKT(t, "LINESTRING(1 2,1 5)", 0).Envelope().WKT())
+	require.Equal(t, "POINT(1 2)", mustParseWKT(t, "MULTIPOINT(1 2,1 2)", 0).Envelope().WKT())
+	require.Equal(t, "GEOMETRYCOLLECTION EMPTY", mustParseWKT(t, "GEOMETRYCOLLECTION EMPTY", 0).Envelope().WKT())
+
+	require.NoError(t, CheckSameSRID("st_contains", NewPoint(0, 0, 0), NewPoint(0, 0, 0)))
+	require.True(t, ErrDifferentSRIDs.Equal(CheckSameSRID("st_contains", NewPoint(0, 0, 0), NewPoint(0, 0, 4326))))
+}
+
+func TestMeasures(t *testing.T) {
+	d, err := Distance(mustParseWKT(t, "POINT(0 0)", 0), mustParseWKT(t, "POINT(3 4)", 0))
+	require.NoError(t, err)
+	require.Equal(t, 5.0, d)
+	d, err = Distance(mustParseWKT(t, "POINT(0 5)", 0), mustParseWKT(t, "LINESTRING(-1 0,1 0)", 0))
+	require.NoError(t, err)
+	require.Equal(t, 5.0, d)
+	d, err = Distance(mustParseWKT(t, "POLYGON((0 0,1 0,1 1,0 1,0 0))", 0), mustParseWKT(t, "POLYGON((3 0,4 0,4 1,3 1,3 0))", 0))
+	require.NoError(t, err)
+	require.Equal(t, 2.0, d)
+	d, err = Distance(mustParseWKT(t, "POLYGON((0 0,10 0,10 10,0 10,0 0))", 0), mustParseWKT(t, "POINT(5 5)", 0))
+	require.NoError(t, err)
+	require.Equal(t, 0.0, d)
+	d, err = Distance(mustParseWKT(t, "GEOMETRYCOLLECTION EMPTY", 0), mustParseWKT(t, "POINT(5 5)", 0))
+	require.NoError(t, err)
+	require.True(t, math.IsNaN(d))
+
+	// One degree of latitude.
+	d, err = Distance(NewPoint(0, 0, 4326), NewPoint(0, 1, 4326))
+	require.NoError(t, err)
+	require.InDelta(t, EarthRadius*math.Pi/180, d, 1e-6)
+	_, err = Distance(NewPoint(0, 0, 4326), mustParseWKT(t, "LINESTRING(0 0,1 1)", 4326))
+	require.True(t, ErrNotImplementedForGeographicSRS.Equal(err))
+
+	d, err = DistanceSphere(NewPoint(0, 0, 0), NewPoint(180, 0, 0), 1)
+	require.NoError(t, err)
+	require.InDelta(t, math.Pi, d, 1e-9)
+	_, err = DistanceSphere(NewPoint(0, 0, 0), NewPoint(180, 0, 0), 0)
+	require.True(t, ErrNonPositiveRadius.Equal(err))
+	_, err = DistanceSphere(NewPoint(0, 0, 0), NewPoint(0, 100, 0), 1)
+	require.True(t, ErrLatitudeOutOfRange.Equal(err))
+	_, err = DistanceSphere(NewPoint(0, 0, 0), mustParseWKT(t, "LINESTRING(0 0,1 1)", 0), 1)
+	require.True(t, ErrUnsupportedArgument.Equal(err))
+
+	area, err := Area(mustParseWKT(t, "POLYGON((0 0,10 0,10 10,0 10,0 0),(4 4,6 4,6 6,4 6,4 4))", 0))
+	require.NoError(t, err)
+	require.Equal(t, 96.0, area)
+	area, err = Area(mustParseWKT(t, "MULTIPOLYGON(((0 0,0 1,1 1,0 0)),((2 2,3 2,3 3,2 2)))", 0))
+	require.NoError(t, err)
+	require.Equal(t, 1.0, area)
+	_, err = Area(mustParseWKT(t, "POINT(0 0)", 0))
+	require.True(t, ErrUnsupportedArgument.Equal(err))
+	_, err = Area(mustParseWKT(t, "POLYGON((0 0,1 0,1 1,0 0))", 4326))
+	require.True(t, ErrNotImplementedForGeographicSRS.Equal(err))
+
+	length, err := Length(mustParseWKT(t, "MULTILINESTRING((0 0,3 4),(0 0,0 1))", 0))
+	require.NoError(t, err)
+	require.Equal(t, 6.0, length)
+	length, err = Length(mustParseWKT(t, "LINESTRING(0 0,0 1)", 4326))
+	require.NoError(t, err)
+	require.InDelta(t, EarthRadius*math.Pi/180, length, 1e-6)
+}
+
+func rangesContain(ranges []CellRange, id uint64) bool {
+	for _, r := range ranges {
+		if r.Low <= id && id <= r.High {
+			return true
+		}
+	}
+	return false
+}
+
+func TestCells(t *testing.T) {
+	// The small geometry around the origin is covered by 4 fine cells.
+	require.Len(t, IndexCells(mustParseWKT(t, "LINESTRING(-1e-9 -1e-9,1e-9 1e-9)", 4326)), 4)
+	require.Len(t, IndexCells(NewPoint(1, 2, 4326)), 1)
+	require.Empty(t, IndexCells(mustParseWKT(t, "GEOMETRYCOLLECTION EMPTY", 0)))
+	require.Empty(t, QueryRanges(mustParseWKT(t, "GEOMETRYCOLLECTION EMPTY", 0).MBR(), 0))
+
+	// Some cells of the geometries intersecting the query are always in the ranges of the query.
+	zone := mustParseWKT(t, "POLYGON((116.3 39.9,116.5 39.9,116.5 40.1,116.3 40.1,116.3 39.9))", 4326)
+	ranges := QueryRanges(zone.MBR(), 4326)
+	for i := 1; i < len(ranges); i++ {
+		require.Less(t, ranges[i-1].High+1, ranges[i].Low)
+	}
+	for _, wkt := range []string{
+		"POINT(116.4 40)", "POINT(116.3 39.9)", "LINESTRING(116.4 40,120 45)", "POLYGON((0 0,170 0,170 80,0 80,0 0))",
+		"LINESTRING(116 39,117 41)",
+	} {
+		g := mustParseWKT(t, wkt, 4326)
+		require.True(t, Intersects(zone, g))
+		found := false
+		for _, id := range IndexCells(g) {
+			found = found || rangesContain(ranges, id)
+		}
+		require.True(t, found, wkt)
+	}
+	for _, wkt := range []string{"POINT(0 0)", "POINT(116.4 41)", "LINESTRING(-10 -10,-20 -20)"} {
+		for _, id := range IndexCells(mustParseWKT(t, wkt, 4326)) {
+			require.False(t, rangesContain(ranges, id), wkt)
+		}
+	}
+
+	// The geometries out of the space are indexed by the cells on the edge.
+	far := NewPoint(1e9, 1e9, 0)
+	require.True(t, rangesContain(QueryRanges(NewPoint(2e9, 2e9, 0).MBR(), 0), IndexCells(far)[0]))
+
+	// All the points within the distance are in the expanded rectangle.
+	center := NewPoint(116.4, 60, 4326)
+	r := center.MBR().ExpandOnSphere(10000, EarthRadius)
+	for _, p := range []*Geometry{NewPoint(116.4, 60.089, 4326), NewPoint(116.579, 60, 4326), NewPoint(116.221, 60, 4326)} {
+		d, err := DistanceSphere(center, p, EarthRadius)
+		require.NoError(t, err)
+		require.Less(t, d, 10000.0)
+		require.True(t, r.Contains(p.MBR()))
+	}
+	// The whole range of longitude is used if the rectangle is close to the pole.
+	dLat := 2000000 / EarthRadius * 180 / math.Pi
+	require.Equal(t, Rect{MinX: -180, MinY: 60 - dLat, MaxX: 180, MaxY: 60 + dLat},
+		center.MBR().ExpandOnSphere(2000000, EarthRadius))
+	require.Equal(t, Rect{MinX: -1, MinY: -1, MaxX: 2, MaxY: 2}, NewPoint(0, 0, 0).MBR().Expand(1).Expand(0).union(NewPoint(2, 2, 0).MBR()))
+}
+
+func (r Rect) union(o Rect) Rect {
+	return Rect{MinX: math.Min(r.MinX, o.MinX), MinY: math.Min(r.MinY, o.MinY), MaxX: math.Max(r.MaxX, o.MaxX), MaxY: math.Max(r.MaxY, o.MaxY)}
+}
@@ -0,0 +1,233 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spatial
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/pingcap/errors"
+)
+
+const (
+	wkbBigEndian    byte = 0
+	wkbLittleEndian byte = 1
+	// maxNestingDepth is the max depth of the nested GEOMETRYCOLLECTIONs.
+	maxNestingDepth = 64
+)
+
+var errInvalidWKB = errors.New("invalid WKB")
+
+// WKB returns the well-known binary representation of the geometry in little-endian.
+func (g *Geometry) WKB() []byte {
+	return g.appendWKB(make([]byte, 0, g.wkbSize()))
+}
+
+func (g *Geometry) wkbSize() int {
+	size := 1 + 4
+	switch g.Type {
+	case TypePoint:
+		return size + 16
+	case TypeLineString:
+		return size + 4 + 16*len(g.Coords[0])
+	case TypePolygon:
+		size += 4
+		for _, ring := range g.Coords {
+			size += 4 + 16*len(ring)
+		}
+		return size
+	}
+	size += 4
+	for _, e := range g.Geoms {
+		size += e.wkbSize()
+	}
+	return size
+}
+
+func (g *Geometry) appendWKB(buf []byte) []byte {
+	buf = append(buf, wkbLittleEndian)
+	buf = appendUint32(buf, uint32(g.Type))
+	switch g.Type {
+	case TypePoint:
+		return appendPoint(buf, g.Coords[0][0])
+	case TypeLineString:
+		return appendPoints(buf, g.Coords[0])
+	case TypePolygon:
+		buf = appendUint32(buf, uint32(len(g.Coords)))
+		for _, ring := range g.Coords {
+			buf = appendPoints(buf, ring)
+		}
+		return buf
+	}
+	buf = appendUint32(buf, uint32(len(g.Geoms)))
+	for _, e := range g.Geoms {
+		buf = e.appendWKB(buf)
+	}
+	return buf
+}
+
+func appendUint32(buf []byte, v uint32) []byte {
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], v)
+	return append(buf, b[:]...)
+}
+
+func appendPoint(buf []byte, p Point) []byte {
+	var b [16]byte
+	binary.LittleEndian.PutUint64(b[:8], math.Float64bits(p.X))
+	binary.LittleEndian.PutUint64(b[8:], math.Float64bits(p.Y))
+	return append(buf, b[:]...)
+}
+
+func appendPoints(buf []byte, ps []Point) []byte {
+	buf = appendUint32(buf, uint32(len(ps)))
+	for _, p := range ps {
+		buf = appendPoint(buf, p)
+	}
+	return buf
+}
+
+// ParseWKB parses the well-known binary representation of a geometry. The coordinates are swapped if swapXY is
+// true, which is used to read the geographic coordinates in the latitude-longitude order.
+func ParseWKB(data []byte, srid uint32, swapXY bool) (*Geometry, error) {
+	r := &wkbReader{data: data}
+	g, err := r.readGeometry(0)
+	if err != nil || r.pos != len(data) {
+		return nil, ErrInvalidGISData.GenWithStackByArgs("st_geomfromwkb")
+	}
+	if swapXY {
+		g = g.SwapXY()
+	}
+	g.SRID = srid
+	if err = g.Validate("st_geomfromwkb"); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+type wkbReader struct {
+	data  []byte
+	pos   int
+	order binary.ByteOrder
+}
+
+func (r *wkbReader) readUint32() (uint32, error) {
+	if r.pos+4 > len(r.data) {
+		return 0, errInvalidWKB
+	}
+	v := r.order.Uint32(r.data[r.pos:])
+	r.pos += 4
+	return v, nil
+}
+
+func (r *wkbReader) readPoint() (Point, error) {
+	if r.pos+16 > len(r.data) {
+		return Point{}, errInvalidWKB
+	}
+	p := Point{
+		X: math.Float64frombits(r.order.Uint64(r.data[r.pos:])),
+		Y: math.Float64frombits(r.order.Uint64(r.data[r.pos+8:])),
+	}
+	r.pos += 16
+	return p, nil
+}
+
+func (r *wkbReader) readPoints() ([]Point, error) {
+	n, err := r.readUint32()
+	if err != nil {
+		return nil, err
+	}
+	// Check the length before allocating the points, the number may be corrupted.
+	if uint64(n)*16 > uint64(len(r.data)-r.pos) {
+		return nil, errInvalidWKB
+	}
+	ps := make([]Point, 0, n)
+	for i := uint32(0); i < n; i++ {
+		p, err := r.readPoint()
+		if err != nil {
+			return nil, err
+		}
+		ps = append(ps, p)
+	}
+	return ps, nil
+}
+
+func (r *wkbReader) readGeometry(depth int) (*Geometry, error) {
+	if depth > maxNestingDepth || r.pos >= len(r.data) {
+		return nil, errInvalidWKB
+	}
+	switch r.data[r.pos] {
+	case wkbLittleEndian:
+		r.order = binary.LittleEndian
+	case wkbBigEndian:
+		r.order = binary.BigEndian
+	default:
+		return nil, errInvalidWKB
+	}
+	r.pos++
+	tp, err := r.readUint32()
+	if err != nil {
+		return nil, err
+	}
+	g := &Geometry{Type: byte(tp)}
+	switch tp {
+	case uint32(TypePoint):
+		p, err := r.readPoint()
+		if err != nil {
+			return nil, err
+		}
+		g.Coords = [][]Point{{p}}
+	case uint32(TypeLineString):
+		ps, err := r.readPoints()
+		if err != nil {
+			return nil, err
+		}
+		g.Coords = [][]Point{ps}
+	case uint32(TypePolygon):
+		n, err := r.readUint32()
+		if err != nil {
+			return nil, err
+		}
+		if uint64(n)*4 > uint64(len(r.data)-r.pos) {
+			return nil, errInvalidWKB
+		}
+		g.Coords = make([][]Point, 0, n)
+		for i := uint32(0); i < n; i++ {
+			ring, err := r.readPoints()
+			if err != nil {
+				return nil, err
+			}
+			g.Coords = append(g.Coords, ring)
+		}
+	case uint32(TypeMultiPoint), uint32(TypeMultiLineString), uint32(TypeMultiPolygon), uint32(TypeGeometryCollection):
+		n, err := r.readUint32()
+		if err != nil {
+			return nil, err
+		}
+		if uint64(n)*5 > uint64(len(r.data)-r.pos) {
+			return nil, errInvalidWKB
+		}
+		for i := uint32(0); i < n; i++ {
+			e, err := r.readGeometry(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			g.Geoms = append(g.Geoms, e)
+		}
+	default:
+		return nil, errInvalidWKB
+	}
+	return g, nil
+}
@@ -0,0 +1,307 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spatial
+
+import (
+	"strconv"
+	"strings"
+)
+
+// WKT returns the well-known text representation of the geometry.
+func (g *Geometry) WKT() string {
+	var sb strings.Builder
+	g.writeWKT(&sb)
+	return sb.String()
+}
+
+func (g *Geometry) writeWKT(sb *strings.Builder) {
+	sb.WriteString(typeNames[g.Type])
+	if g.Type == TypeGeometryCollection && len(g.Geoms) == 0 {
+		sb.WriteString(" EMPTY")
+		return
+	}
+	sb.WriteByte('(')
+	switch g.Type {
+	case TypePoint:
+		writePoint(sb, g.Coords[0][0])
+	case TypeLineString:
+		writePoints(sb, g.Coords[0])
+	case TypePolygon:
+		writeRings(sb, g.Coords)
+	default:
+		for i, e := range g.Geoms {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			switch g.Type {
+			case TypeMultiPoint:
+				sb.WriteByte('(')
+				writePoint(sb, e.Coords[0][0])
+				sb.WriteByte(')')
+			case TypeMultiLineString:
+				sb.WriteByte('(')
+				writePoints(sb, e.Coords[0])
+				sb.WriteByte(')')
+			case TypeMultiPolygon:
+				sb.WriteByte('(')
+				writeRings(sb, e.Coords)
+				sb.WriteByte(')')
+			default:
+				e.writeWKT(sb)
+			}
+		}
+	}
+	sb.WriteByte(')')
+}
+
+func formatCoord(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+func writePoint(sb *strings.Builder, p Point) {
+	sb.WriteString(formatCoord(p.X))
+	sb.WriteByte(' ')
+	sb.WriteString(formatCoord(p.Y))
+}
+
+func writePoints(sb *strings.Builder, ps []Point) {
+	for i, p := range ps {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		writePoint(sb, p)
+	}
+}
+
+func writeRings(sb *strings.Builder, rings [][]Point) {
+	for i, ring := range rings {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteByte('(')
+		writePoints(sb, ring)
+		sb.WriteByte(')')
+	}
+}
+
+// ParseWKT parses the well-known text representation of a geometry. The coordinates are swapped if swapXY is
+// true, which is used to read the geographic coordinates in the latitude-longitude order. The name of the
+// function is used in the error message.
+func ParseWKT(text string, srid uint32, swapXY bool, funcName string) (*Geometry, error) {
+	p := &wktParser{s: text}
+	g, ok := p.parseGeometry(0)
+	p.skipSpaces()
+	if !ok || p.pos != len(p.s) {
+		return nil, ErrInvalidGISData.GenWithStackByArgs(funcName)
+	}
+	if swapXY {
+		g = g.SwapXY()
+	}
+	g.SRID = srid
+	if err := g.Validate(funcName); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+type wktParser struct {
+	s   string
+	pos int
+}
+
+func (p *wktParser) skipSpaces() {
+	for p.pos < len(p.s) && strings.IndexByte(" \t\r\n", p.s[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+// consume consumes the byte if it's the next non-space byte.
+func (p *wktParser) consume(b byte) bool {
+	p.skipSpaces()
+	if p.pos < len(p.s) && p.s[p.pos] == b {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *wktParser) word() string {
+	p.skipSpaces()
+	start := p.pos
+	for p.pos < len(p.s) {
+		c := p.s[p.pos]
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z') {
+			break
+		}
+		p.pos++
+	}
+	return strings.ToUpper(p.s[start:p.pos])
+}
+
+func (p *wktParser) number() (float64, bool) {
+	p.skipSpaces()
+	start := p.pos
+	for p.pos < len(p.s) && strings.IndexByte("0123456789+-.eE", p.s[p.pos]) >= 0 {
+		p.pos++
+	}
+	f, err := strconv.ParseFloat(p.s[start:p.pos], 64)
+	return f, err == nil
+}
+
+func (p *wktParser) point() (Point, bool) {
+	x, ok := p.number()
+	if !ok {
+		return Point{}, false
+	}
+	y, ok := p.number()
+	return Point{X: x, Y: y}, ok
+}
+
+// points parses the comma-separated points, a point may be parenthesized if the parentheses are allowed.
+func (p *wktParser) points(allowParens bool) ([]Point, bool) {
+	var ps []Point
+	for {
+		paren := allowParens && p.consume('(')
+		pt, ok := p.point()
+		if !ok || paren && !p.consume(')') {
+			return nil, false
+		}
+		ps = append(ps, pt)
+		if !p.consume(',') {
+			return ps, true
+		}
+	}
+}
+
+// list parses the comma-separated items enclosed in parentheses.
+func (p *wktParser) list(item func() bool) bool {
+	if !p.consume('(') {
+		return false
+	}
+	for {
+		if !item() {
+			return false
+		}
+		if !p.consume(',') {
+			return p.consume(')')
+		}
+	}
+}
+
+func (p *wktParser) rings() ([][]Point, bool) {
+	var rings [][]Point
+	ok := p.list(func() bool {
+		if !p.consume('(') {
+			return false
+		}
+		ring, ok := p.points(false)
+		rings = append(rings, ring)
+		return ok && p.consume(')')
+	})
+	return rings, ok
+}
+
+func (p *wktParser) parseGeometry(depth int) (*Geometry, bool) {
+	if depth > maxNestingDepth {
+		return nil, false
+	}
+	g := &Geometry{}
+	switch p.word() {
+	case "POINT":
+		g.Type = TypePoint
+		if !p.consume('(') {
+			return nil, false
+		}
+		pt, ok := p.point()
+		if !ok || !p.consume(')') {
+			return nil, false
+		}
+		g.Coords = [][]Point{{pt}}
+	case "LINESTRING":
+		g.Type = TypeLineString
+		if !p.consume('(') {
+			return nil, false
+		}
+		ps, ok := p.points(false)
+		if !ok || !p.consume(')') {
+			return nil, false
+		}
+		g.Coords = [][]Point{ps}
+	case "POLYGON":
+		g.Type = TypePolygon
+		rings, ok := p.rings()
+		if !ok {
+			return nil, false
+		}
+		g.Coords = rings
+	case "MULTIPOINT":
+		g.Type = TypeMultiPoint
+		if !p.consume('(') {
+			return nil, false
+		}
+		ps, ok := p.points(true)
+		if !ok || !p.consume(')') {
+			return nil, false
+		}
+		for _, pt := range ps {
+			g.Geoms = append(g.Geoms, &Geometry{Type: TypePoint, Coords: [][]Point{{pt}}})
+		}
+	case "MULTILINESTRING":
+		g.Type = TypeMultiLineString
+		ok := p.list(func() bool {
+			if !p.consume('(') {
+				return false
+			}
+			ps, ok := p.points(false)
+			g.Geoms = append(g.Geoms, &Geometry{Type: TypeLineString, Coords: [][]Point{ps}})
+			return ok && p.consume(')')
+		})
+		if !ok {
+			return nil, false
+		}
+	case "MULTIPOLYGON":
+		g.Type = TypeMultiPolygon
+		ok := p.list(func() bool {
+			rings, ok := p.rings()
+			g.Geoms = append(g.Geoms, &Geometry{Type: TypePolygon, Coords: rings})
+			return ok
+		})
+		if !ok {
+			return nil, false
+		}
+	case "GEOMETRYCOLLECTION", "GEOMCOLLECTION":
+		g.Type = TypeGeometryCollection
+		save := p.pos
+		if p.word() == "EMPTY" {
+			return g, true
+		}
+		p.pos = save
+		if p.consume('(') && p.consume(')') {
+			return g, true
+		}
+		p.pos = save
+		ok := p.list(func() bool {
+			e, ok := p.parseGeometry(depth + 1)
+			g.Geoms = append(g.Geoms, e)
+			return ok
+		})
+		if !ok {
+			return nil, false
+		}
+	default:
+		return nil, false
+	}
+	return g, true
+}
@@ -111,14 +111,22 @@ func IsJobRollbackable(job *model.Job) bool {
 		}
 	case model.ActionAddTablePartition:
 		return job.SchemaState == model.StateNone || job.SchemaState == model.StateReplicaOnly
+	case model.ActionReorganizePartition, model.ActionAlterTablePartitioning, model.ActionRemovePartitioning:
+		return job.SchemaState != model.StateDeleteReorganization
 	case model.ActionDropColumn, model.ActionDropColumns, model.ActionDropTablePartition,
 		model.ActionRebaseAutoID, model.ActionShardRowID,
 		model.ActionTruncateTable, model.ActionAddForeignKey,
 		model.ActionDropForeignKey, model.ActionRenameTable,
 		model.ActionModifyTableCharsetAndCollate, model.ActionTruncateTablePartition,
 		model.ActionModifySchemaCharsetAndCollate, model.ActionRepairTable,
-		model.ActionModifyTableAutoIdCache, model.ActionModifySchemaDefaultPlacement:
+		model.ActionModifyTableAutoIdCache, model.ActionModifySchemaDefaultPlacement,
+		model.ActionCreateProcedure, model.ActionDropProcedure,
+		model.ActionCreateTrigger, model.ActionDropTrigger,
+		model.ActionDropCheckConstraint, model.ActionAlterCheckConstraint:
 		return job.SchemaState == model.StateNone
+	case model.ActionMultiSchemaChange:
+		// The sub-jobs can't be rolled back after they reach their non-revertible schema states.
+		return job.MultiSchemaInfo.Revertible
 	}
 	return true
 }
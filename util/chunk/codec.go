@@ -173,6 +173,10 @@ func bytesToI64Slice(b []byte) (i64s []int64) {
 const varElemLen = -1
 
 func getFixedLen(colType *types.FieldType) int {
+	if colType.Array {
+		// The values of the array type are JSON arrays.
+		return varElemLen
+	}
 	switch colType.Tp {
 	case mysql.TypeFloat:
 		return 4
@@ -34,6 +34,10 @@ var (
 	ErrCancelledDDLJob = ClassDDL.NewStd(mysql.ErrCancelledDDLJob)
 	// ErrRunMultiSchemaChanges means we run multi schema changes.
 	ErrRunMultiSchemaChanges = ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message(fmt.Sprintf(mysql.MySQLErrName[mysql.ErrUnsupportedDDLOperation].Raw, "multi schema change"), nil))
+	// ErrOperateSameColumn means we change the same column multiple times in a multi-schema change.
+	ErrOperateSameColumn = ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message(fmt.Sprintf(mysql.MySQLErrName[mysql.ErrUnsupportedDDLOperation].Raw, "operate same column '%s'"), nil))
+	// ErrOperateSameIndex means we change the same index multiple times in a multi-schema change.
+	ErrOperateSameIndex = ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message(fmt.Sprintf(mysql.MySQLErrName[mysql.ErrUnsupportedDDLOperation].Raw, "operate same index '%s'"), nil))
 	// ErrWaitReorgTimeout means we wait for reorganization timeout.
 	ErrWaitReorgTimeout = ClassDDL.NewStdErr(mysql.ErrLockWaitTimeout, mysql.MySQLErrName[mysql.ErrWaitReorgTimeout])
 	// ErrInvalidStoreVer means invalid store version.
@@ -129,6 +133,8 @@ var (
 	ErrUnsupportedCoalescePartition = ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message(fmt.Sprintf(mysql.MySQLErrName[mysql.ErrUnsupportedDDLOperation].Raw, "coalesce partitions"), nil))
 	// ErrUnsupportedReorganizePartition returns for does not support reorganize partitions.
 	ErrUnsupportedReorganizePartition = ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message(fmt.Sprintf(mysql.MySQLErrName[mysql.ErrUnsupportedDDLOperation].Raw, "reorganize partition"), nil))
+	// ErrUnsupportedSubPartitionManagement returns for does not support the partition management of a subpartitioned table.
+	ErrUnsupportedSubPartitionManagement = ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message(fmt.Sprintf(mysql.MySQLErrName[mysql.ErrUnsupportedDDLOperation].Raw, "%s on subpartitioned table"), nil))
 	// ErrUnsupportedCheckPartition returns for does not support check partitions.
 	ErrUnsupportedCheckPartition = ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message(fmt.Sprintf(mysql.MySQLErrName[mysql.ErrUnsupportedDDLOperation].Raw, "check partition"), nil))
 	// ErrUnsupportedOptimizePartition returns for does not support optimize partitions.
@@ -137,6 +143,8 @@ var (
 	ErrUnsupportedRebuildPartition = ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message(fmt.Sprintf(mysql.MySQLErrName[mysql.ErrUnsupportedDDLOperation].Raw, "rebuild partition"), nil))
 	// ErrUnsupportedRemovePartition returns for does not support remove partitions.
 	ErrUnsupportedRemovePartition = ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message(fmt.Sprintf(mysql.MySQLErrName[mysql.ErrUnsupportedDDLOperation].Raw, "remove partitioning"), nil))
+	// ErrUnsupportedAlterTablePartitioning returns for does not support changing the partitioning of the table.
+	ErrUnsupportedAlterTablePartitioning = ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message(fmt.Sprintf(mysql.MySQLErrName[mysql.ErrUnsupportedDDLOperation].Raw, "alter table partition by"), nil))
 	// ErrUnsupportedRepairPartition returns for does not support repair partitions.
 	ErrUnsupportedRepairPartition = ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message(fmt.Sprintf(mysql.MySQLErrName[mysql.ErrUnsupportedDDLOperation].Raw, "repair partition"), nil))
 	// ErrGeneratedColumnFunctionIsNotAllowed returns for unsupported functions for generated columns.
@@ -215,10 +223,20 @@ var (
 
 	// ErrNotAllowedTypeInPartition returns not allowed type error when creating table partition with unsupported expression type.
 	ErrNotAllowedTypeInPartition = ClassDDL.NewStd(mysql.ErrFieldTypeNotAllowedAsPartitionField)
+	// ErrBlobFieldInPartFunc returns BLOB field is not allowed in partition function.
+	ErrBlobFieldInPartFunc = ClassDDL.NewStd(mysql.ErrBlobFieldInPartFunc)
 	// ErrPartitionMgmtOnNonpartitioned returns it's not a partition table.
 	ErrPartitionMgmtOnNonpartitioned = ClassDDL.NewStd(mysql.ErrPartitionMgmtOnNonpartitioned)
 	// ErrDropPartitionNonExistent returns error in list of partition.
 	ErrDropPartitionNonExistent = ClassDDL.NewStd(mysql.ErrDropPartitionNonExistent)
+	// ErrReorgNoParam returns reorganize partition without parameters on a not hash partitioned table.
+	ErrReorgNoParam = ClassDDL.NewStd(mysql.ErrReorgNoParam)
+	// ErrReorgPartitionNotExist returns more partitions to reorganize than there are partitions.
+	ErrReorgPartitionNotExist = ClassDDL.NewStd(mysql.ErrReorgPartitionNotExist)
+	// ErrConsecutiveReorgPartitions returns the reorganized partitions are not in consecutive order.
+	ErrConsecutiveReorgPartitions = ClassDDL.NewStd(mysql.ErrConsecutiveReorgPartitions)
+	// ErrReorgOutsideRange returns reorganize of range partitions changes the total ranges.
+	ErrReorgOutsideRange = ClassDDL.NewStd(mysql.ErrReorgOutsideRange)
 	// ErrSameNamePartition returns duplicate partition name.
 	ErrSameNamePartition = ClassDDL.NewStd(mysql.ErrSameNamePartition)
 	// ErrSameNamePartitionField returns duplicate partition field.
@@ -362,4 +380,79 @@ var (
 
 	// ErrAutoConvert when auto convert happens
 	ErrAutoConvert = ClassDDL.NewStd(mysql.ErrAutoConvert)
+
+	// ErrColumnCheckConstraintReferencesOtherColumn returns when the column check constraint refers to other columns.
+	ErrColumnCheckConstraintReferencesOtherColumn = ClassDDL.NewStd(mysql.ErrColumnCheckConstraintReferencesOtherColumn)
+	// ErrCheckConstraintNamedFunctionIsNotAllowed returns when the check constraint contains a disallowed function.
+	ErrCheckConstraintNamedFunctionIsNotAllowed = ClassDDL.NewStd(mysql.ErrCheckConstraintNamedFunctionIsNotAllowed)
+	// ErrCheckConstraintFunctionIsNotAllowed returns when the check constraint contains a subquery or a disallowed expression.
+	ErrCheckConstraintFunctionIsNotAllowed = ClassDDL.NewStd(mysql.ErrCheckConstraintFunctionIsNotAllowed)
+	// ErrCheckConstraintVariables returns when the check constraint refers to a user or system variable.
+	ErrCheckConstraintVariables = ClassDDL.NewStd(mysql.ErrCheckConstraintVariables)
+	// ErrCheckConstraintRowValue returns when the check constraint refers to a row value.
+	ErrCheckConstraintRowValue = ClassDDL.NewStd(mysql.ErrCheckConstraintRowValue)
+	// ErrCheckConstraintRefersAutoIncrementColumn returns when the check constraint refers to an auto-increment column.
+	ErrCheckConstraintRefersAutoIncrementColumn = ClassDDL.NewStd(mysql.ErrCheckConstraintRefersAutoIncrementColumn)
+	// ErrCheckConstraintRefersUnknownColumn returns when the check constraint refers to a non-existing column.
+	ErrCheckConstraintRefersUnknownColumn = ClassDDL.NewStd(mysql.ErrCheckConstraintRefersUnknownColumn)
+	// ErrCheckConstraintNotFound returns when the check constraint to alter or drop doesn't exist.
+	ErrCheckConstraintNotFound = ClassDDL.NewStd(mysql.ErrCheckConstraintNotFound)
+	// ErrCheckConstraintDupName returns when the check constraint name already exists.
+	ErrCheckConstraintDupName = ClassDDL.NewStd(mysql.ErrCheckConstraintDupName)
+	// ErrDependentByCheckConstraint returns when the dropped or renamed column is used by a check constraint.
+	ErrDependentByCheckConstraint = ClassDDL.NewStd(mysql.ErrDependentByCheckConstraint)
+	// ErrSpDupParam returns when a stored procedure has duplicate parameter names.
+	ErrSpDupParam = ClassDDL.NewStd(mysql.ErrSpDupParam)
+	// ErrSpDupVar returns when a local variable is declared twice in a stored procedure block.
+	ErrSpDupVar = ClassDDL.NewStd(mysql.ErrSpDupVar)
+	// ErrSpDupCurs returns when a cursor is declared twice in a stored procedure block.
+	ErrSpDupCurs = ClassDDL.NewStd(mysql.ErrSpDupCurs)
+	// ErrSpCursorMismatch returns when a stored procedure refers to an undeclared cursor.
+	ErrSpCursorMismatch = ClassDDL.NewStd(mysql.ErrSpCursorMismatch)
+	// ErrSpLilabelMismatch returns when LEAVE or ITERATE refers to an unknown label.
+	ErrSpLilabelMismatch = ClassDDL.NewStd(mysql.ErrSpLilabelMismatch)
+	// ErrSpLabelRedefine returns when a label is redefined in a stored procedure.
+	ErrSpLabelRedefine = ClassDDL.NewStd(mysql.ErrSpLabelRedefine)
+	// ErrSpVarcondAfterCurshndlr returns when a variable is declared after a cursor or a handler.
+	ErrSpVarcondAfterCurshndlr = ClassDDL.NewStd(mysql.ErrSpVarcondAfterCurshndlr)
+	// ErrSpCursorAfterHandler returns when a cursor is declared after a handler.
+	ErrSpCursorAfterHandler = ClassDDL.NewStd(mysql.ErrSpCursorAfterHandler)
+	// ErrSpBadSQLstate returns when the SQLSTATE value of a handler condition is invalid.
+	ErrSpBadSQLstate = ClassDDL.NewStd(mysql.ErrSpBadSQLstate)
+	// ErrTrgOnViewOrTempTable returns when creating a trigger on a view or a temporary table.
+	ErrTrgOnViewOrTempTable = ClassDDL.NewStd(mysql.ErrTrgOnViewOrTempTable)
+	// ErrTrgInWrongSchema returns when the trigger and its table are in different schemas.
+	ErrTrgInWrongSchema = ClassDDL.NewStd(mysql.ErrTrgInWrongSchema)
+	// ErrNoTriggersOnSystemSchema returns when creating a trigger on a table of a system schema.
+	ErrNoTriggersOnSystemSchema = ClassDDL.NewStd(mysql.ErrNoTriggersOnSystemSchema)
+	// ErrTrgCantChangeRow returns when a trigger assigns to a row which can't be changed.
+	ErrTrgCantChangeRow = ClassDDL.NewStd(mysql.ErrTrgCantChangeRow)
+	// ErrTrgNoSuchRowInTrg returns when a trigger refers to a row which doesn't exist for its event.
+	ErrTrgNoSuchRowInTrg = ClassDDL.NewStd(mysql.ErrTrgNoSuchRowInTrg)
+	// ErrSpNoRetset returns when a trigger body contains a statement which returns a result set.
+	ErrSpNoRetset = ClassDDL.NewStd(mysql.ErrSpNoRetset)
+	// ErrCommitNotAllowedInSfOrTrg returns when a trigger body commits explicitly or implicitly.
+	ErrCommitNotAllowedInSfOrTrg = ClassDDL.NewStd(mysql.ErrCommitNotAllowedInSfOrTrg)
+	// ErrReferencedTrgDoesNotExist returns when the trigger in FOLLOWS or PRECEDES doesn't exist.
+	ErrReferencedTrgDoesNotExist = ClassDDL.NewStd(mysql.ErrReferencedTrgDoesNotExist)
+
+	// ErrBadFtColumn returns when the column can't be a part of FULLTEXT index.
+	ErrBadFtColumn = ClassDDL.NewStd(mysql.ErrBadFtColumn)
+	// ErrFulltextFunctionalIndex returns when a key part of FULLTEXT index is an expression.
+	ErrFulltextFunctionalIndex = ClassDDL.NewStd(mysql.ErrFulltextFunctionalIndex)
+
+	// ErrSpatialMustHaveGeomCol returns when the column of SPATIAL index isn't a geometry column.
+	ErrSpatialMustHaveGeomCol = ClassDDL.NewStd(mysql.ErrSpatialMustHaveGeomCol)
+	// ErrSpatialCantHaveNull returns when the column of SPATIAL index is nullable.
+	ErrSpatialCantHaveNull = ClassDDL.NewStd(mysql.ErrSpatialCantHaveNull)
+	// ErrSpatialFunctionalIndex returns when the key part of SPATIAL index is an expression.
+	ErrSpatialFunctionalIndex = ClassDDL.NewStd(mysql.ErrSpatialFunctionalIndex)
+	// ErrTooManyKeyParts returns when the index has too many key parts.
+	ErrTooManyKeyParts = ClassDDL.NewStd(mysql.ErrTooManyKeyParts)
+	// ErrWarnUselessSpatialIndex is the warning when the SPATIAL index can't be used by the optimizer since
+	// the column has no SRID attribute.
+	ErrWarnUselessSpatialIndex = ClassDDL.NewStd(mysql.ErrWarnUselessSpatialIndex)
+
+	// ErrNotSupportedYet returns when the feature is not supported yet, e.g. the unique multi-valued index.
+	ErrNotSupportedYet = ClassDDL.NewStd(mysql.ErrNotSupportedYet)
 )
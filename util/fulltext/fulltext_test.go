@@ -0,0 +1,134 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fulltext
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenizer(t *testing.T) {
+	tk, err := NewTokenizer("")
+	require.NoError(t, err)
+	require.Equal(t, ParserWhitespace, tk.Name())
+	require.Equal(t, []string{"quick", "brown", "fox", "jumps", "over", "lazy", "dog", "fox"},
+		tk.Tokenize("The quick brown-fox jumps over a lazy DOG, fox!"))
+	require.Equal(t, []string{"quick", "brown", "fox"}, UniqueTokens(tk, "quick brown", "Fox brown"))
+
+	tk, err = NewTokenizer("NGRAM")
+	require.NoError(t, err)
+	require.Equal(t, ParserNgram, tk.Name())
+	require.Equal(t, []string{"全文", "文检", "检索", "ab", "bc"}, tk.Tokenize("全文检索 abc d"))
+
+	_, err = NewTokenizer("mecab")
+	require.Error(t, err)
+}
+
+func TestNaturalLanguageQuery(t *testing.T) {
+	tk, err := NewTokenizer("")
+	require.NoError(t, err)
+	q := ParseQuery(tk, "red apple", false)
+	require.Zero(t, q.Score("green banana"))
+	one := q.Score("apple pie")
+	two := q.Score("red apple pie")
+	require.Greater(t, one, 0.0)
+	require.Greater(t, two, one)
+	// The shorter row ranks higher with the same matched terms.
+	require.Greater(t, q.Score("red apple"), two)
+	require.Greater(t, q.Score("apple apple pie"), one)
+
+	tokens, ok := q.IndexTokens()
+	require.True(t, ok)
+	require.Equal(t, []Token{{Text: "red"}, {Text: "apple"}}, tokens)
+
+	// The stopwords and the short words are never matched.
+	q = ParseQuery(tk, "the of", false)
+	require.Zero(t, q.Score("the of"))
+	_, ok = q.IndexTokens()
+	require.False(t, ok)
+}
+
+func TestBooleanQuery(t *testing.T) {
+	tk, err := NewTokenizer("")
+	require.NoError(t, err)
+	cases := []struct {
+		query   string
+		text    string
+		matched bool
+	}{
+		{"+apple -pie", "apple juice", true},
+		{"+apple -pie", "apple pie", false},
+		{"+apple -pie", "orange juice", false},
+		{"+apple +juice", "apple juice", true},
+		{"+apple +juice", "apple pie", false},
+		{"apple banana", "banana split", true},
+		{"-apple", "banana split", false},
+		{"app*", "applesauce", true},
+		{"app*", "snapple", false},
+		{`"apple pie"`, "the apple pie", true},
+		{`"apple pie"`, "pie apple", false},
+		{"+apple +(pie juice)", "apple juice", true},
+		{"+apple +(pie juice)", "apple tree", false},
+		{"+apple -(pie juice)", "apple tree", true},
+		{"~apple", "apple tree", true},
+	}
+	for _, c := range cases {
+		q := ParseQuery(tk, c.query, true)
+		require.Equal(t, c.matched, q.Score(c.text) != 0, "%s against %s", c.query, c.text)
+	}
+
+	q := ParseQuery(tk, "apple >juice <pie", true)
+	require.Greater(t, q.Score("apple juice"), q.Score("apple pie"))
+	q = ParseQuery(tk, "apple ~pie", true)
+	require.Greater(t, q.Score("apple juice"), q.Score("apple pie"))
+
+	indexCases := []struct {
+		query  string
+		tokens []Token
+		ok     bool
+	}{
+		{"+apple -pie", []Token{{Text: "apple"}}, true},
+		{"apple app* -pie", []Token{{Text: "apple"}, {Text: "app", Prefix: true}}, true},
+		{`"apple pie" (juice tree)`, []Token{{Text: "apple"}, {Text: "juice"}, {Text: "tree"}}, true},
+		{"+(apple juice) banana", []Token{{Text: "apple"}, {Text: "juice"}}, true},
+		{"-apple", nil, false},
+		{"+the", nil, false},
+	}
+	for _, c := range indexCases {
+		tokens, ok := ParseQuery(tk, c.query, true).IndexTokens()
+		require.Equal(t, c.ok, ok, c.query)
+		require.Equal(t, c.tokens, tokens, c.query)
+	}
+}
+
+func TestNgramQuery(t *testing.T) {
+	tk, err := NewTokenizer(ParserNgram)
+	require.NoError(t, err)
+	q := ParseQuery(tk, "全文检索", false)
+	require.Greater(t, q.Score("数据库的全文索引"), 0.0)
+	require.Zero(t, q.Score("数据库"))
+
+	// A word is searched as the phrase of its n-grams in the boolean mode.
+	q = ParseQuery(tk, "+全文检索", true)
+	require.Greater(t, q.Score("支持全文检索"), 0.0)
+	require.Zero(t, q.Score("数据库的全文索引"))
+	tokens, ok := q.IndexTokens()
+	require.True(t, ok)
+	require.Equal(t, []Token{{Text: "全文"}}, tokens)
+
+	q = ParseQuery(tk, "全*", true)
+	require.Greater(t, q.Score("全文"), 0.0)
+}
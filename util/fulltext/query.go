@@ -0,0 +1,348 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fulltext
+
+import (
+	"math"
+	"strings"
+	"unicode/utf8"
+)
+
+// The search modes of MATCH ... AGAINST.
+const (
+	ModeNaturalLanguage = "natural language mode"
+	ModeBoolean         = "boolean mode"
+)
+
+type termKind byte
+
+const (
+	termWord termKind = iota
+	termPrefix
+	termPhrase
+	termGroup
+)
+
+// The operators of the boolean mode.
+const (
+	opNone     byte = 0
+	opRequired byte = '+'
+	opExcluded byte = '-'
+	opNegate   byte = '~'
+	opIncrease byte = '>'
+	opDecrease byte = '<'
+)
+
+// clause is a term of the query with its operator.
+type clause struct {
+	op     byte
+	kind   termKind
+	tokens []string
+	group  []*clause
+}
+
+// Query is a parsed full-text search query.
+type Query struct {
+	tk      Tokenizer
+	clauses []*clause
+}
+
+// ParseQuery parses the search string of MATCH ... AGAINST. All the words of a natural language query are
+// optional, a row matches if it contains any of them. A boolean mode query supports the operators
+// + - ~ < > ( ), the double-quoted phrases and the trailing * for the prefix search.
+func ParseQuery(tk Tokenizer, query string, booleanMode bool) *Query {
+	q := &Query{tk: tk}
+	if !booleanMode {
+		for _, token := range UniqueTokens(tk, query) {
+			q.clauses = append(q.clauses, &clause{kind: termWord, tokens: []string{token}})
+		}
+		return q
+	}
+	p := &queryParser{tk: tk, s: query}
+	q.clauses = p.parseClauses()
+	return q
+}
+
+type queryParser struct {
+	tk  Tokenizer
+	s   string
+	pos int
+}
+
+func (p *queryParser) parseClauses() []*clause {
+	var clauses []*clause
+	op := opNone
+	for p.pos < len(p.s) {
+		ch := p.s[p.pos]
+		switch ch {
+		case opRequired, opExcluded, opNegate, opIncrease, opDecrease:
+			op = ch
+			p.pos++
+			continue
+		case ')':
+			p.pos++
+			return clauses
+		case '(':
+			p.pos++
+			if group := p.parseClauses(); len(group) > 0 {
+				clauses = append(clauses, &clause{op: op, kind: termGroup, group: group})
+			}
+		case '"':
+			p.pos++
+			end := strings.IndexByte(p.s[p.pos:], '"')
+			if end < 0 {
+				end = len(p.s) - p.pos
+			}
+			if c := p.newClause(op, p.s[p.pos:p.pos+end], false); c != nil {
+				clauses = append(clauses, c)
+			}
+			p.pos += end + 1
+		default:
+			r, size := utf8.DecodeRuneInString(p.s[p.pos:])
+			if !isWordChar(r) {
+				// The other characters are delimiters.
+				p.pos += size
+				break
+			}
+			start := p.pos
+			for p.pos < len(p.s) {
+				r, size = utf8.DecodeRuneInString(p.s[p.pos:])
+				if !isWordChar(r) {
+					break
+				}
+				p.pos += size
+			}
+			prefix := p.pos < len(p.s) && p.s[p.pos] == '*'
+			if prefix {
+				p.pos++
+			}
+			if c := p.newClause(op, p.s[start:p.pos], prefix); c != nil {
+				clauses = append(clauses, c)
+			}
+		}
+		// An operator only applies to the term following it.
+		op = opNone
+	}
+	return clauses
+}
+
+// newClause builds the clause of a word or a phrase. A word split into several tokens by the tokenizer, such as
+// a word tokenized by the ngram parser, is searched as a phrase. Nil is returned if the text has no token.
+func (p *queryParser) newClause(op byte, text string, prefix bool) *clause {
+	if prefix {
+		word := strings.ToLower(strings.TrimSuffix(text, "*"))
+		// The prefix is matched against the tokens directly, unless the ngram parser splits it into several n-grams.
+		if p.tk.Name() != ParserNgram || utf8.RuneCountInString(word) <= NgramTokenSize {
+			if word == "" {
+				return nil
+			}
+			return &clause{op: op, kind: termPrefix, tokens: []string{word}}
+		}
+	}
+	tokens := p.tk.Tokenize(text)
+	switch len(tokens) {
+	case 0:
+		return nil
+	case 1:
+		return &clause{op: op, kind: termWord, tokens: tokens}
+	}
+	return &clause{op: op, kind: termPhrase, tokens: tokens}
+}
+
+// document is the tokenized text of a row.
+type document struct {
+	// segments are the tokens of each column, a phrase can't span two columns.
+	segments [][]string
+	tf       map[string]int
+	length   int
+}
+
+func newDocument(tk Tokenizer, texts []string) *document {
+	d := &document{segments: make([][]string, 0, len(texts)), tf: make(map[string]int)}
+	for _, text := range texts {
+		tokens := tk.Tokenize(text)
+		for _, token := range tokens {
+			d.tf[token]++
+		}
+		d.length += len(tokens)
+		d.segments = append(d.segments, tokens)
+	}
+	return d
+}
+
+// frequency returns the number of occurrences of the term in the document.
+func (d *document) frequency(c *clause) int {
+	switch c.kind {
+	case termWord:
+		return d.tf[c.tokens[0]]
+	case termPrefix:
+		freq := 0
+		for token, tf := range d.tf {
+			if strings.HasPrefix(token, c.tokens[0]) {
+				freq += tf
+			}
+		}
+		return freq
+	case termPhrase:
+		freq := 0
+		for _, tokens := range d.segments {
+			for i := 0; i+len(c.tokens) <= len(tokens); i++ {
+				matched := true
+				for j, token := range c.tokens {
+					if tokens[i+j] != token {
+						matched = false
+						break
+					}
+				}
+				if matched {
+					freq++
+				}
+			}
+		}
+		return freq
+	}
+	return 0
+}
+
+// eval returns the relevance of the document to the clauses and whether the document matches them. A document
+// matches if it contains all the required terms and none of the excluded terms, and it contains any of the other
+// terms when there is no required term.
+func (d *document) eval(clauses []*clause) (score float64, matched bool) {
+	hasRequired, anyMatched := false, false
+	for _, c := range clauses {
+		var s float64
+		var m bool
+		if c.kind == termGroup {
+			s, m = d.eval(c.group)
+		} else if freq := d.frequency(c); freq > 0 {
+			// The relevance of a term grows with the logarithm of its frequency.
+			s, m = 1+math.Log(float64(freq)), true
+		}
+		switch c.op {
+		case opRequired:
+			if !m {
+				return 0, false
+			}
+			hasRequired = true
+		case opExcluded:
+			if m {
+				return 0, false
+			}
+			continue
+		case opNegate:
+			// The term lowers the relevance instead of excluding the document.
+			s = -s / 2
+		case opIncrease:
+			s *= 1.5
+		case opDecrease:
+			s /= 1.5
+		}
+		if m {
+			anyMatched = true
+			score += s
+		}
+	}
+	if !hasRequired && !anyMatched {
+		return 0, false
+	}
+	return score, true
+}
+
+// Score returns the relevance of the row whose indexed columns are the texts, 0 is returned if the row doesn't
+// match the query. The relevance is normalized by the number of tokens of the row, so the rows with the same
+// matched terms are ranked by their lengths.
+func (q *Query) Score(texts ...string) float64 {
+	if len(q.clauses) == 0 {
+		return 0
+	}
+	d := newDocument(q.tk, texts)
+	score, matched := d.eval(q.clauses)
+	if !matched {
+		return 0
+	}
+	if d.length > 1 {
+		score /= math.Sqrt(float64(d.length))
+	}
+	if score == 0 {
+		// A matched row always has a non-zero relevance, so it can be used as a filter.
+		score = math.SmallestNonzeroFloat64
+	}
+	return score
+}
+
+// Token is a token to look up in the FULLTEXT index.
+type Token struct {
+	Text string
+	// Prefix means all the tokens starting with the text are looked up.
+	Prefix bool
+}
+
+// IndexTokens returns the tokens whose posting lists contain all the rows matched by the query. False is returned
+// if the rows can't be found from the index, for example, the query has no term or only has excluded terms.
+func (q *Query) IndexTokens() ([]Token, bool) {
+	tokens, ok := coverTokens(q.clauses)
+	if !ok {
+		return nil, false
+	}
+	unique := tokens[:0]
+	seen := make(map[Token]struct{}, len(tokens))
+	for _, t := range tokens {
+		if _, ok := seen[t]; !ok {
+			seen[t] = struct{}{}
+			unique = append(unique, t)
+		}
+	}
+	return unique, true
+}
+
+func coverTokens(clauses []*clause) ([]Token, bool) {
+	hasRequired := false
+	for _, c := range clauses {
+		if c.op != opRequired {
+			continue
+		}
+		hasRequired = true
+		// Every matched row contains the required term, so its tokens are enough.
+		if tokens, ok := clauseTokens(c); ok {
+			return tokens, true
+		}
+	}
+	if hasRequired {
+		return nil, false
+	}
+	var tokens []Token
+	for _, c := range clauses {
+		if c.op == opExcluded {
+			continue
+		}
+		t, ok := clauseTokens(c)
+		if !ok {
+			return nil, false
+		}
+		tokens = append(tokens, t...)
+	}
+	return tokens, len(tokens) > 0
+}
+
+func clauseTokens(c *clause) ([]Token, bool) {
+	switch c.kind {
+	case termWord, termPhrase:
+		// A row containing the phrase contains its first token.
+		return []Token{{Text: c.tokens[0]}}, true
+	case termPrefix:
+		return []Token{{Text: c.tokens[0], Prefix: true}}, true
+	}
+	return coverTokens(c.group)
+}
@@ -0,0 +1,146 @@
+// Copyright 2022 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fulltext
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/pingcap/errors"
+)
+
+const (
+	// ParserWhitespace is the name of the default parser, which splits the text into words.
+	ParserWhitespace = "whitespace"
+	// ParserNgram is the name of the parser which splits the text into n-grams, it's used for the
+	// languages without word delimiters such as Chinese and Japanese.
+	ParserNgram = "ngram"
+)
+
+const (
+	// MinTokenSize is the min length of the words stored in the index by the whitespace parser,
+	// the same as the default value of innodb_ft_min_token_size.
+	MinTokenSize = 3
+	// MaxTokenSize is the max length of the words stored in the index by the whitespace parser,
+	// the same as the default value of innodb_ft_max_token_size.
+	MaxTokenSize = 84
+	// NgramTokenSize is the length of the n-grams, the same as the default value of ngram_token_size.
+	NgramTokenSize = 2
+)
+
+// stopWords is the default stopword list of InnoDB, such words are too common to be useful in the index.
+var stopWords = map[string]struct{}{
+	"a": {}, "about": {}, "an": {}, "are": {}, "as": {}, "at": {}, "be": {}, "by": {}, "com": {},
+	"de": {}, "en": {}, "for": {}, "from": {}, "how": {}, "i": {}, "in": {}, "is": {}, "it": {},
+	"la": {}, "of": {}, "on": {}, "or": {}, "that": {}, "the": {}, "this": {}, "to": {}, "was": {},
+	"what": {}, "when": {}, "where": {}, "who": {}, "will": {}, "with": {}, "und": {}, "www": {},
+}
+
+// Tokenizer splits the text into the tokens stored in the FULLTEXT index.
+// The tokens are lowercased, so the search is case-insensitive.
+type Tokenizer interface {
+	// Tokenize returns the tokens of the text in the order they appear, a token may appear more than once.
+	Tokenize(text string) []string
+	// Name returns the name of the parser.
+	Name() string
+}
+
+// NewTokenizer returns the tokenizer of the parser, the empty name means the default parser.
+func NewTokenizer(parser string) (Tokenizer, error) {
+	switch strings.ToLower(parser) {
+	case "", ParserWhitespace:
+		return whitespaceTokenizer{}, nil
+	case ParserNgram:
+		return ngramTokenizer{n: NgramTokenSize}, nil
+	}
+	return nil, errors.Errorf("unknown FULLTEXT parser %s", parser)
+}
+
+// isWordChar reports whether the character is a part of a word. The other characters are delimiters.
+func isWordChar(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.Is(unicode.Mn, r)
+}
+
+// splitWords splits the text into the lowercased words.
+func splitWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !isWordChar(r)
+	})
+}
+
+// whitespaceTokenizer splits the text into words, the words which are too short, too long or in the
+// stopword list are skipped.
+type whitespaceTokenizer struct{}
+
+// Tokenize implements the Tokenizer interface.
+func (whitespaceTokenizer) Tokenize(text string) []string {
+	words := splitWords(text)
+	tokens := words[:0]
+	for _, w := range words {
+		if n := utf8.RuneCountInString(w); n < MinTokenSize || n > MaxTokenSize {
+			continue
+		}
+		if _, ok := stopWords[w]; ok {
+			continue
+		}
+		tokens = append(tokens, w)
+	}
+	return tokens
+}
+
+// Name implements the Tokenizer interface.
+func (whitespaceTokenizer) Name() string {
+	return ParserWhitespace
+}
+
+// ngramTokenizer splits each word of the text into the overlapping sequences of n characters,
+// the words shorter than n characters are skipped.
+type ngramTokenizer struct {
+	n int
+}
+
+// Tokenize implements the Tokenizer interface.
+func (t ngramTokenizer) Tokenize(text string) []string {
+	var tokens []string
+	for _, w := range splitWords(text) {
+		runes := []rune(w)
+		for i := 0; i+t.n <= len(runes); i++ {
+			tokens = append(tokens, string(runes[i:i+t.n]))
+		}
+	}
+	return tokens
+}
+
+// Name implements the Tokenizer interface.
+func (ngramTokenizer) Name() string {
+	return ParserNgram
+}
+
+// UniqueTokens returns the distinct tokens of the texts, which are the tokens stored in the index for a row.
+func UniqueTokens(tk Tokenizer, texts ...string) []string {
+	var tokens []string
+	seen := make(map[string]struct{})
+	for _, text := range texts {
+		for _, token := range tk.Tokenize(text) {
+			if _, ok := seen[token]; ok {
+				continue
+			}
+			seen[token] = struct{}{}
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
+}
@@ -127,6 +127,12 @@ const (
 	TypeCTE = "CTEFullScan"
 	// TypeCTEDefinition is the type of CTE definition
 	TypeCTEDefinition = "CTE"
+	// TypeForeignKeyCheck is the type of FKCheck
+	TypeForeignKeyCheck = "Foreign_Key_Check"
+	// TypeForeignKeyCascade is the type of FKCascade
+	TypeForeignKeyCascade = "Foreign_Key_Cascade"
+	// TypeJSONTable is the type of JSON_TABLE.
+	TypeJSONTable = "JSONTable"
 )
 
 // plan id.
@@ -184,6 +190,9 @@ const (
 	typeCTE                   int = 50
 	typeCTEDefinition         int = 51
 	typeCTETable              int = 52
+	typeForeignKeyCheck       int = 53
+	typeForeignKeyCascade     int = 54
+	typeJSONTable             int = 55
 )
 
 // TypeStringToPhysicalID converts the plan type string to plan id.
@@ -293,6 +302,12 @@ func TypeStringToPhysicalID(tp string) int {
 		return typeCTEDefinition
 	case TypeCTETable:
 		return typeCTETable
+	case TypeForeignKeyCheck:
+		return typeForeignKeyCheck
+	case TypeForeignKeyCascade:
+		return typeForeignKeyCascade
+	case TypeJSONTable:
+		return typeJSONTable
 	}
 	// Should never reach here.
 	return 0
@@ -403,6 +418,12 @@ func PhysicalIDToTypeString(id int) string {
 		return TypeCTEDefinition
 	case typeCTETable:
 		return TypeCTETable
+	case typeForeignKeyCheck:
+		return TypeForeignKeyCheck
+	case typeForeignKeyCascade:
+		return TypeForeignKeyCascade
+	case typeJSONTable:
+		return TypeJSONTable
 	}
 
 	// Should never reach here.
@@ -184,6 +184,20 @@ type RecordSet interface {
 	Close() error
 }
 
+// ResultSetWriter writes a result set to the client while the statement is still being executed,
+// it's used to stream the result sets produced by the statements of a stored procedure.
+// The RecordSet is closed by the caller.
+type ResultSetWriter func(ctx context.Context, rs RecordSet) error
+
+type resultSetWriterKeyType struct{}
+
+func (k resultSetWriterKeyType) String() string {
+	return "result_set_writer"
+}
+
+// ResultSetWriterKey is the context key of the ResultSetWriter.
+var ResultSetWriterKey = resultSetWriterKeyType{}
+
 // MultiQueryNoDelayResult is an interface for one no-delay result for one statement in multi-queries.
 type MultiQueryNoDelayResult interface {
 	// AffectedRows return affected row for one statement in multi-queries.